@@ -10,6 +10,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	lfxAuth "github.com/LF-Engineering/lfx-kit/auth"
 	swagerrors "github.com/go-openapi/errors"
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
@@ -23,6 +24,9 @@ const (
 	adminScope   Scope = "admin"
 )
 
+// APITokenPrefix is the prefix of the API tokens issued to machine clients, used to tell them apart from JWTs
+const APITokenPrefix = "ecla_"
+
 // Scope string
 type Scope string
 
@@ -34,18 +38,59 @@ type UserPermissioner interface {
 	GetUserCompanyIDs(userID string) ([]string, error)
 }
 
+// APITokenValidator interface methods - ValidateAPIToken returns the v1 API user of the token, ValidateAPITokenACL the
+// v2 API user with the ACL scopes of the token
+type APITokenValidator interface {
+	ValidateAPIToken(token string) (*user.CLAUser, error)
+	ValidateAPITokenACL(token string) (*lfxAuth.User, error)
+}
+
 // Authorizer data model
 type Authorizer struct {
-	authValidator    Validator
-	userPermissioner UserPermissioner
+	authValidator     Validator
+	userPermissioner  UserPermissioner
+	apiTokenValidator APITokenValidator
+	// aclAuth authorizes the X-ACL headers of the v2 API
+	aclAuth func(token string) (*lfxAuth.User, error)
 }
 
 // NewAuthorizer creates a new authorizer based on the specified parameters
-func NewAuthorizer(authValidator Validator, userPermissioner UserPermissioner) Authorizer {
+func NewAuthorizer(authValidator Validator, userPermissioner UserPermissioner, apiTokenValidator APITokenValidator) Authorizer {
 	return Authorizer{
-		authValidator:    authValidator,
-		userPermissioner: userPermissioner,
+		authValidator:     authValidator,
+		userPermissioner:  userPermissioner,
+		apiTokenValidator: apiTokenValidator,
+		aclAuth:           lfxAuth.SwaggerAuth,
+	}
+}
+
+// IsAPIToken returns true if the token is an API token rather than a JWT
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+// LfAuthAuth is the authorizer of the v2 API - the API tokens are validated the same way as by SecurityAuth and
+// return a user limited to the scope of the token, the other values are the X-ACL headers of the API gateway
+func (a Authorizer) LfAuthAuth(token string) (*lfxAuth.User, error) {
+	f := logrus.Fields{
+		"functionName": "auth.authorizer.LfAuthAuth",
+	}
+
+	if !IsAPIToken(token) {
+		return a.aclAuth(token)
+	}
+
+	if a.apiTokenValidator == nil {
+		log.WithFields(f).Warn("API token provided but API tokens are not enabled")
+		return nil, swagerrors.New(401, "API tokens are not enabled")
+	}
+	log.WithFields(f).Debug("verifying API token...")
+	apiUser, err := a.apiTokenValidator.ValidateAPITokenACL(token)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("LfAuthAuth - verify API token error")
+		return nil, swagerrors.New(401, err.Error())
 	}
+	return apiUser, nil
 }
 
 // SecurityAuth creates a new CLA user based on the token and scopes
@@ -59,6 +104,21 @@ func (a Authorizer) SecurityAuth(token string, scopes []string) (*user.CLAUser,
 	// It is passed a token extracted from the Authentication Bearer header, and
 	// the list of scopes mentioned by the spec for this route.
 
+	// API tokens are scoped when issued - the user is built from the token record rather than the scopes
+	if IsAPIToken(token) {
+		if a.apiTokenValidator == nil {
+			log.WithFields(f).Warn("API token provided but API tokens are not enabled")
+			return nil, swagerrors.New(401, "API tokens are not enabled")
+		}
+		log.WithFields(f).Debug("verifying API token...")
+		apiUser, err := a.apiTokenValidator.ValidateAPIToken(token)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("SecurityAuth - verify API token error")
+			return nil, swagerrors.New(401, err.Error())
+		}
+		return apiUser, nil
+	}
+
 	// Verify the token is valid
 	log.WithFields(f).Debug("verifying token...")
 	claims, err := a.authValidator.VerifyToken(token)
@@ -0,0 +1,96 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	lfxAuth "github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAPIToken = APITokenPrefix + "b5c2e7a1-3f4d-4c8e-8a9b-1d2e3f4a5b6c_secret"
+
+type apiTokenValidator struct {
+	users map[string]*lfxAuth.User
+}
+
+func (v *apiTokenValidator) ValidateAPIToken(token string) (*user.CLAUser, error) {
+	if u, ok := v.users[token]; ok {
+		return &user.CLAUser{LFUsername: u.UserName}, nil
+	}
+	return nil, errors.New("invalid API token")
+}
+
+func (v *apiTokenValidator) ValidateAPITokenACL(token string) (*lfxAuth.User, error) {
+	if u, ok := v.users[token]; ok {
+		return u, nil
+	}
+	return nil, errors.New("invalid API token")
+}
+
+func newTestAuthorizer(apiTokens APITokenValidator) (Authorizer, *[]string) {
+	var aclTokens []string
+	authorizer := NewAuthorizer(Validator{}, nil, apiTokens)
+	authorizer.aclAuth = func(token string) (*lfxAuth.User, error) {
+		aclTokens = append(aclTokens, token)
+		return &lfxAuth.User{UserName: "jdoe"}, nil
+	}
+	return authorizer, &aclTokens
+}
+
+func TestLfAuthAuthAPIToken(t *testing.T) {
+	apiUser := &lfxAuth.User{UserName: utils.APITokenUsernamePrefix + "b5c2e7a1-3f4d-4c8e-8a9b-1d2e3f4a5b6c"}
+	authorizer, aclTokens := newTestAuthorizer(&apiTokenValidator{users: map[string]*lfxAuth.User{testAPIToken: apiUser}})
+
+	authUser, err := authorizer.LfAuthAuth(testAPIToken)
+	assert.NoError(t, err)
+	assert.Equal(t, apiUser, authUser)
+
+	// the unknown, revoked and expired API tokens are rejected, never passed to the X-ACL authorizer
+	_, err = authorizer.LfAuthAuth(testAPIToken + "x")
+	assert.Error(t, err)
+	assert.Empty(t, *aclTokens)
+}
+
+func TestLfAuthAuthAPITokenIdentityPinned(t *testing.T) {
+	apiUser := &lfxAuth.User{UserName: utils.APITokenUsernamePrefix + "b5c2e7a1-3f4d-4c8e-8a9b-1d2e3f4a5b6c"}
+	authorizer, _ := newTestAuthorizer(&apiTokenValidator{users: map[string]*lfxAuth.User{testAPIToken: apiUser}})
+
+	authUser, err := authorizer.LfAuthAuth(testAPIToken)
+	assert.NoError(t, err)
+
+	// the handlers apply the X-USERNAME and X-EMAIL headers sent by the client
+	userName, email := "auditor", "auditor@example.org"
+	utils.SetAuthUserProperties(authUser, &userName, &email)
+	assert.Equal(t, utils.APITokenUsernamePrefix+"b5c2e7a1-3f4d-4c8e-8a9b-1d2e3f4a5b6c", authUser.UserName)
+	assert.Empty(t, authUser.Email)
+
+	// the identity of the other users is still set from the headers
+	aclUser, err := authorizer.LfAuthAuth("eyJhbGxvd2VkIjp0cnVlfQ==")
+	assert.NoError(t, err)
+	utils.SetAuthUserProperties(aclUser, &userName, &email)
+	assert.Equal(t, "auditor", aclUser.UserName)
+	assert.Equal(t, "auditor@example.org", aclUser.Email)
+}
+
+func TestLfAuthAuthAPITokensDisabled(t *testing.T) {
+	authorizer, aclTokens := newTestAuthorizer(nil)
+
+	_, err := authorizer.LfAuthAuth(testAPIToken)
+	assert.Error(t, err)
+	assert.Empty(t, *aclTokens)
+}
+
+func TestLfAuthAuthACL(t *testing.T) {
+	authorizer, aclTokens := newTestAuthorizer(&apiTokenValidator{})
+
+	authUser, err := authorizer.LfAuthAuth("eyJhbGxvd2VkIjp0cnVlfQ==")
+	assert.NoError(t, err)
+	assert.Equal(t, "jdoe", authUser.UserName)
+	assert.Equal(t, []string{"eyJhbGxvd2VkIjp0cnVlfQ=="}, *aclTokens)
+}
@@ -0,0 +1,127 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// Bitbucket Cloud OAuth endpoints
+const (
+	CloudOauthAuthorizeURL = "https://bitbucket.org/site/oauth2/authorize"
+	CloudOauthTokenURL     = "https://bitbucket.org/site/oauth2/access_token"
+)
+
+// User is the authenticated Bitbucket user
+type User struct {
+	AccountID   string
+	Username    string
+	DisplayName string
+	Emails      []string
+}
+
+// OauthConfig returns the oauth2 config of the flavor, Bitbucket Cloud ignores the baseURL
+func OauthConfig(flavor, baseURL, clientID, clientSecret, redirectURL string) *oauth2.Config {
+	config := &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+	}
+
+	if flavor == FlavorDataCenter {
+		baseURL = strings.TrimSuffix(baseURL, "/")
+		config.Endpoint = oauth2.Endpoint{
+			AuthURL:  baseURL + "/rest/oauth2/latest/authorize",
+			TokenURL: baseURL + "/rest/oauth2/latest/token",
+		}
+		config.Scopes = []string{"PUBLIC_REPOS"}
+		return config
+	}
+
+	// the scopes of Bitbucket Cloud are the ones of the consumer
+	config.Endpoint = oauth2.Endpoint{
+		AuthURL:  CloudOauthAuthorizeURL,
+		TokenURL: CloudOauthTokenURL,
+	}
+	return config
+}
+
+// CurrentUser returns the user the access token of the client belongs to, along with the confirmed email addresses
+func (c *Client) CurrentUser() (*User, error) {
+	if c.IsCloud() {
+		var cloudUser struct {
+			AccountID   string `json:"account_id"`
+			Nickname    string `json:"nickname"`
+			DisplayName string `json:"display_name"`
+		}
+		if err := c.do(http.MethodGet, "/2.0/user", nil, &cloudUser); err != nil {
+			return nil, err
+		}
+		user := &User{
+			AccountID:   cloudUser.AccountID,
+			Username:    cloudUser.Nickname,
+			DisplayName: cloudUser.DisplayName,
+		}
+
+		err := c.getAll("/2.0/user/emails", func(values json.RawMessage) error {
+			var page []struct {
+				Email       string `json:"email"`
+				IsPrimary   bool   `json:"is_primary"`
+				IsConfirmed bool   `json:"is_confirmed"`
+			}
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, email := range page {
+				if !email.IsConfirmed {
+					continue
+				}
+				// the primary email comes first
+				if email.IsPrimary {
+					user.Emails = append([]string{email.Email}, user.Emails...)
+				} else {
+					user.Emails = append(user.Emails, email.Email)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	var username string
+	if err := c.do(http.MethodGet, "/plugins/servlet/applinks/whoami", nil, &username); err != nil {
+		return nil, err
+	}
+	if username == "" {
+		return nil, fmt.Errorf("unable to load the bitbucket user - whoami is empty")
+	}
+
+	var dataCenterUser struct {
+		ID           int    `json:"id"`
+		Slug         string `json:"slug"`
+		DisplayName  string `json:"displayName"`
+		EmailAddress string `json:"emailAddress"`
+	}
+	if err := c.do(http.MethodGet, fmt.Sprintf("/rest/api/1.0/users/%s", url.PathEscape(username)), nil, &dataCenterUser); err != nil {
+		return nil, err
+	}
+	user := &User{
+		AccountID:   fmt.Sprintf("%d", dataCenterUser.ID),
+		Username:    dataCenterUser.Slug,
+		DisplayName: dataCenterUser.DisplayName,
+	}
+	if dataCenterUser.EmailAddress != "" {
+		user.Emails = []string{dataCenterUser.EmailAddress}
+	}
+	return user, nil
+}
@@ -0,0 +1,48 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// build status constants
+const (
+	// BuildStatusKey is the key of the build status reported by EasyCLA, a status with the same key replaces the
+	// previous one
+	BuildStatusKey  = "easycla"
+	BuildStatusName = "EasyCLA"
+
+	BuildStatusSuccessful = "SUCCESSFUL"
+	BuildStatusFailed     = "FAILED"
+	BuildStatusInProgress = "INPROGRESS"
+)
+
+// BuildStatus is the EasyCLA status of a commit
+type BuildStatus struct {
+	State       string `json:"state"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+// SetBuildStatus reports the build status of the commit of the repository
+func (c *Client) SetBuildStatus(owner, slug, commitSHA, state, targetURL, description string) error {
+	status := &BuildStatus{
+		State:       state,
+		Key:         BuildStatusKey,
+		Name:        BuildStatusName,
+		URL:         targetURL,
+		Description: description,
+	}
+
+	if c.IsCloud() {
+		return c.do(http.MethodPost, fmt.Sprintf("%s/commit/%s/statuses/build", c.repositoryPath(owner, slug), url.PathEscape(commitSHA)), status, nil)
+	}
+	// the build status api of Bitbucket Data Center isn't scoped to the repository
+	return c.do(http.MethodPost, fmt.Sprintf("/rest/build-status/1.0/commits/%s", url.PathEscape(commitSHA)), status, nil)
+}
@@ -0,0 +1,216 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/telemetry"
+)
+
+// Bitbucket flavors
+const (
+	// FlavorCloud is bitbucket.org
+	FlavorCloud = "cloud"
+	// FlavorDataCenter is a self-managed Bitbucket Data Center (or Server) instance
+	FlavorDataCenter = "datacenter"
+
+	// CloudAPIBaseURL is the base url of the Bitbucket Cloud REST API
+	CloudAPIBaseURL = "https://api.bitbucket.org"
+	// CloudWebBaseURL is the base url of the Bitbucket Cloud web site
+	CloudWebBaseURL = "https://bitbucket.org"
+)
+
+// ErrNotFound is returned when the Bitbucket API responds with 404
+var ErrNotFound = errors.New("bitbucket resource not found")
+
+// APIError is returned when the Bitbucket API responds with an unexpected status
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Body       string
+}
+
+// Error returns the error message
+func (e *APIError) Error() string {
+	return fmt.Sprintf("bitbucket api %s %s failed with status %d : %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// Client is a minimal Bitbucket REST client covering both Bitbucket Cloud and Bitbucket Data Center
+type Client struct {
+	flavor      string
+	baseURL     string
+	accessToken string
+	httpClient  *http.Client
+}
+
+// IsValidFlavor returns true if the flavor is a supported Bitbucket flavor
+func IsValidFlavor(flavor string) bool {
+	return flavor == FlavorCloud || flavor == FlavorDataCenter
+}
+
+// NewClient creates a client of the given flavor, the baseURL is only required by Bitbucket Data Center
+func NewClient(flavor, baseURL, accessToken string) (*Client, error) {
+	if !IsValidFlavor(flavor) {
+		return nil, fmt.Errorf("unsupported bitbucket flavor : %s", flavor)
+	}
+	if accessToken == "" {
+		return nil, errors.New("bitbucket access token is empty")
+	}
+	if flavor == FlavorCloud && baseURL == "" {
+		baseURL = CloudAPIBaseURL
+	}
+	if baseURL == "" {
+		return nil, errors.New("bitbucket data center base url is empty")
+	}
+
+	return &Client{
+		flavor:      flavor,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		accessToken: accessToken,
+		httpClient: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: telemetry.NewInstrumentedTransport(telemetry.ProviderBitbucket, http.DefaultTransport),
+		},
+	}, nil
+}
+
+// NewClientFromEncryptedToken creates a client from the access token encrypted by EncryptSecret
+func NewClientFromEncryptedToken(flavor, baseURL, encryptedToken string, bitbucketApp *App) (*Client, error) {
+	if encryptedToken == "" {
+		return nil, errors.New("unable to create bitbucket client - access token is empty")
+	}
+	accessToken, err := DecryptSecret(encryptedToken, bitbucketApp)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(flavor, baseURL, accessToken)
+}
+
+// Flavor returns the flavor of the client
+func (c *Client) Flavor() string {
+	return c.flavor
+}
+
+// IsCloud returns true if the client targets Bitbucket Cloud
+func (c *Client) IsCloud() bool {
+	return c.flavor == FlavorCloud
+}
+
+// do sends the request and decodes the response into out when not nil
+func (c *Client) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &APIError{StatusCode: resp.StatusCode, Method: method, Path: path, Body: string(respBody)}
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	// a few endpoints respond with plain text
+	if text, ok := out.(*string); ok {
+		respBody, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		*text = strings.TrimSpace(string(respBody))
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// cloudPage is a page of the Bitbucket Cloud paginated responses
+type cloudPage struct {
+	Values json.RawMessage `json:"values"`
+	Next   string          `json:"next"`
+}
+
+// dataCenterPage is a page of the Bitbucket Data Center paginated responses
+type dataCenterPage struct {
+	Values        json.RawMessage `json:"values"`
+	IsLastPage    bool            `json:"isLastPage"`
+	NextPageStart int             `json:"nextPageStart"`
+}
+
+// maxPages guards the pagination against the misbehaving servers
+const maxPages = 100
+
+// getAll walks the pages of the path and calls add with the values of each page
+func (c *Client) getAll(path string, add func(values json.RawMessage) error) error {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	if c.IsCloud() {
+		next := path + separator + "pagelen=100"
+		for page := 0; next != "" && page < maxPages; page++ {
+			var resp cloudPage
+			if err := c.do(http.MethodGet, next, nil, &resp); err != nil {
+				return err
+			}
+			if err := add(resp.Values); err != nil {
+				return err
+			}
+			// the next link of Bitbucket Cloud is absolute
+			next = strings.TrimPrefix(resp.Next, c.baseURL)
+		}
+		return nil
+	}
+
+	start := 0
+	for page := 0; page < maxPages; page++ {
+		var resp dataCenterPage
+		if err := c.do(http.MethodGet, fmt.Sprintf("%s%slimit=100&start=%d", path, separator, start), nil, &resp); err != nil {
+			return err
+		}
+		if err := add(resp.Values); err != nil {
+			return err
+		}
+		if resp.IsLastPage {
+			return nil
+		}
+		start = resp.NextPageStart
+	}
+	return nil
+}
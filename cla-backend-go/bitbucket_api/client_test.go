@@ -0,0 +1,229 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, flavor string, handler func(serverURL string, w http.ResponseWriter, r *http.Request)) *Client {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		handler(server.URL, w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(flavor, server.URL, "token")
+	assert.NoError(t, err)
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient("github", "", "token")
+	assert.Error(t, err)
+	_, err = NewClient(FlavorDataCenter, "", "token")
+	assert.Error(t, err)
+	_, err = NewClient(FlavorCloud, "", "")
+	assert.Error(t, err)
+
+	client, err := NewClient(FlavorCloud, "", "token")
+	assert.NoError(t, err)
+	assert.True(t, client.IsCloud())
+}
+
+func TestListRepositoriesCloudPagination(t *testing.T) {
+	client := newTestClient(t, FlavorCloud, func(serverURL string, w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/2.0/repositories/acme", r.URL.Path)
+		if r.URL.Query().Get("page") == "" {
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{1}","slug":"one","name":"One","full_name":"acme/one","workspace":{"slug":"acme"}}],"next":"` + serverURL + `/2.0/repositories/acme?pagelen=100&page=2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values":[{"uuid":"{2}","slug":"two","name":"Two","full_name":"acme/two","is_private":true,"workspace":{"slug":"acme"}}]}`))
+	})
+
+	repos, err := client.ListRepositories("acme")
+	assert.NoError(t, err)
+	assert.Len(t, repos, 2)
+	assert.Equal(t, "acme/two", repos[1].FullName)
+	assert.True(t, repos[1].Private)
+}
+
+func TestListRepositoriesDataCenterPagination(t *testing.T) {
+	client := newTestClient(t, FlavorDataCenter, func(serverURL string, w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/1.0/projects/PRJ/repos", r.URL.Path)
+		if r.URL.Query().Get("start") == "0" {
+			_, _ = w.Write([]byte(`{"values":[{"id":1,"slug":"one","name":"One","project":{"key":"PRJ"}}],"isLastPage":false,"nextPageStart":1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values":[{"id":2,"slug":"two","name":"Two","public":true,"project":{"key":"PRJ"}}],"isLastPage":true}`))
+	})
+
+	repos, err := client.ListRepositories("PRJ")
+	assert.NoError(t, err)
+	assert.Len(t, repos, 2)
+	assert.Equal(t, "2", repos[1].ExternalID)
+	assert.Equal(t, "PRJ/two", repos[1].FullName)
+	assert.False(t, repos[1].Private)
+}
+
+func TestGetRepositoryNotFound(t *testing.T) {
+	client := newTestClient(t, FlavorCloud, func(serverURL string, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.GetRepository("acme", "missing")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestGetPullRequestCommitAuthorsCloud(t *testing.T) {
+	client := newTestClient(t, FlavorCloud, func(serverURL string, w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/2.0/repositories/acme/one/pullrequests/4/commits", r.URL.Path)
+		_, _ = w.Write([]byte(`{"values":[
+			{"hash":"a1","author":{"raw":"Jane Doe <jane@example.org>","user":{"nickname":"jdoe","account_id":"557058:1"}}},
+			{"hash":"b2","author":{"raw":"John Roe <john@example.org>"}}
+		]}`))
+	})
+
+	authors, err := client.GetPullRequestCommitAuthors("acme", "one", 4)
+	assert.NoError(t, err)
+	assert.Len(t, authors, 2)
+	assert.Equal(t, "jane@example.org", authors[0].Email)
+	assert.Equal(t, "jdoe", authors[0].Username)
+	assert.Equal(t, "John Roe", authors[1].Name)
+	assert.Empty(t, authors[1].Username)
+}
+
+func TestUpsertPullRequestCommentCloud(t *testing.T) {
+	var method string
+	var content map[string]map[string]string
+	client := newTestClient(t, FlavorCloud, func(serverURL string, w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"values":[{"id":1,"content":{"raw":"LGTM"}},{"id":9,"content":{"raw":"` + CommentMarker + `\nold"}}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/2.0/repositories/acme/one/pullrequests/4/comments/9":
+			method = r.Method
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&content))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	assert.NoError(t, client.UpsertPullRequestComment("acme", "one", 4, "new"))
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, CommentMarker+"\nnew", content["content"]["raw"])
+}
+
+func TestUpsertPullRequestCommentDataCenterCreate(t *testing.T) {
+	var created map[string]string
+	client := newTestClient(t, FlavorDataCenter, func(serverURL string, w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/1.0/projects/PRJ/repos/one/pull-requests/4/activities":
+			_, _ = w.Write([]byte(`{"values":[{"action":"OPENED"}],"isLastPage":true}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/1.0/projects/PRJ/repos/one/pull-requests/4/comments":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	assert.NoError(t, client.UpsertPullRequestComment("PRJ", "one", 4, "new"))
+	assert.Equal(t, CommentMarker+"\nnew", created["text"])
+}
+
+func TestSetBuildStatus(t *testing.T) {
+	var status BuildStatus
+	client := newTestClient(t, FlavorDataCenter, func(serverURL string, w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/rest/build-status/1.0/commits/a1", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	assert.NoError(t, client.SetBuildStatus("PRJ", "one", "a1", BuildStatusFailed, "https://sign", "missing CLA"))
+	assert.Equal(t, BuildStatusKey, status.Key)
+	assert.Equal(t, BuildStatusFailed, status.State)
+}
+
+func TestSetWebhookUpdatesExisting(t *testing.T) {
+	var updated cloudWebhook
+	client := newTestClient(t, FlavorCloud, func(serverURL string, w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{h1}","url":"https://cla/v4/bitbucket/activity","active":false}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/2.0/repositories/acme/one/hooks/{h1}":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	assert.NoError(t, client.SetWebhook("acme", "one", "https://cla/v4/bitbucket/activity", "secret"))
+	assert.True(t, updated.Active)
+	assert.Equal(t, "secret", updated.Secret)
+	assert.Equal(t, cloudPullRequestEvents, updated.Events)
+}
+
+func TestValidateSignature(t *testing.T) {
+	payload := []byte(`{"pullrequest":{}}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	_, _ = mac.Write(payload)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.NoError(t, ValidateSignature(payload, signature, "secret"))
+	assert.Equal(t, ErrInvalidSignature, ValidateSignature(payload, signature, "other"))
+	assert.Equal(t, ErrInvalidSignature, ValidateSignature(payload, "sha1=abc", "secret"))
+	assert.Equal(t, ErrInvalidSignature, ValidateSignature(payload, signature, ""))
+}
+
+func TestParsePullRequestEvent(t *testing.T) {
+	event, err := ParsePullRequestEvent("pullrequest:updated", []byte(`{
+		"pullrequest":{"id":4,"source":{"commit":{"hash":"a1"}},"destination":{"branch":{"name":"main"}}},
+		"repository":{"uuid":"{r1}","full_name":"acme/one"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &PullRequestEvent{Flavor: FlavorCloud, EventKey: "pullrequest:updated", Owner: "acme", Slug: "one",
+		RepositoryExternalID: "{r1}", PullRequestID: 4, HeadCommit: "a1", TargetBranch: "main"}, event)
+
+	event, err = ParsePullRequestEvent("pr:opened", []byte(`{"pullRequest":{"id":5,"fromRef":{"latestCommit":"b2"},
+		"toRef":{"displayId":"master","repository":{"id":12,"slug":"one","project":{"key":"PRJ"}}}}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &PullRequestEvent{Flavor: FlavorDataCenter, EventKey: "pr:opened", Owner: "PRJ", Slug: "one",
+		RepositoryExternalID: "12", PullRequestID: 5, HeadCommit: "b2", TargetBranch: "master"}, event)
+
+	event, err = ParsePullRequestEvent("pr:comment:added", []byte(`{"pullRequest":{"id":5,"fromRef":{"latestCommit":"b2"},
+		"toRef":{"displayId":"master","repository":{"id":12,"slug":"one","project":{"key":"PRJ"}}}},"comment":{"text":"/easycla"}}`))
+	assert.NoError(t, err)
+	assert.True(t, IsCommentEvent(event.EventKey))
+	assert.Equal(t, "/easycla", event.Comment)
+
+	_, err = ParsePullRequestEvent("repo:push", []byte(`{}`))
+	assert.Equal(t, ErrUnsupportedEvent, err)
+}
+
+func TestEncryptDecryptSecret(t *testing.T) {
+	app := &App{bitbucketAppPrivateKey: "MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI="}
+
+	encrypted, err := EncryptSecret("access-token", app)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "access-token", encrypted)
+
+	decrypted, err := DecryptSecret(encrypted, app)
+	assert.NoError(t, err)
+	assert.Equal(t, "access-token", decrypted)
+
+	_, err = DecryptSecret("abcd", app)
+	assert.Error(t, err)
+	_, err = EncryptSecret("access-token", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/keyring"
+)
+
+// EncryptSecret encrypts the access token or secret of a Bitbucket organization with the keyring of the application,
+// or its key when no keyring is configured
+func EncryptSecret(secret string, bitbucketApp *App) (string, error) {
+	if bitbucketApp == nil || (bitbucketApp.keys == nil && bitbucketApp.bitbucketAppPrivateKey == "") {
+		return "", errors.New("unable to encrypt secret - Bitbucket app structure is nil or empty")
+	}
+	return keyring.EncryptSecret(bitbucketApp.keys, bitbucketApp.bitbucketAppPrivateKey, []byte(secret))
+}
+
+// DecryptSecret decrypts the secret encrypted by EncryptSecret
+func DecryptSecret(encryptedSecret string, bitbucketApp *App) (string, error) {
+	if bitbucketApp == nil || (bitbucketApp.keys == nil && bitbucketApp.bitbucketAppPrivateKey == "") {
+		return "", errors.New("unable to decrypt secret - Bitbucket app structure is nil or empty")
+	}
+	decrypted, err := keyring.DecryptSecret(bitbucketApp.keys, bitbucketApp.bitbucketAppPrivateKey, encryptedSecret)
+	if err != nil {
+		return "", fmt.Errorf("decode secret : %v", err)
+	}
+
+	return string(decrypted), nil
+}
+
+// NeedsReEncryption returns true if the encrypted value isn't an envelope of the primary key of the keyring of the
+// Bitbucket application - always false when no keyring is configured
+func NeedsReEncryption(encrypted string, bitbucketApp *App) bool {
+	if bitbucketApp.keys == nil {
+		return false
+	}
+	return bitbucketApp.keys.NeedsReEncryption(encrypted)
+}
+
+// ReEncrypt decrypts the value encrypted with the key of the application or a previous key of the keyring and
+// encrypts it with the primary key of the keyring
+func ReEncrypt(encrypted string, bitbucketApp *App) (string, error) {
+	return keyring.ReEncrypt(bitbucketApp.keys, bitbucketApp.bitbucketAppPrivateKey, encrypted)
+}
+
+// NewSecret returns a random secret, used for the webhook secrets of the Bitbucket organizations
+func NewSecret() (string, error) {
+	return keyring.NewSecret()
+}
@@ -0,0 +1,68 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"sync"
+
+	"github.com/communitybridge/easycla/cla-backend-go/keyring"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// App is a wrapper for the Bitbucket configuration items - the EasyCLA OAuth consumer of Bitbucket Cloud and the key
+// used to encrypt the access tokens and secrets of the Bitbucket organizations
+type App struct {
+	bitbucketAppID         string
+	bitbucketAppSecret     string
+	bitbucketAppPrivateKey string
+	// keys encrypts the access tokens and secrets when set, the values encrypted with the private key are still
+	// decrypted until they are re-encrypted
+	keys *keyring.Keyring
+}
+
+var bitbucketAppSingleton *App
+
+var once sync.Once
+
+// Init initializes the required bitbucket variables
+func Init(bbAppID, bbAppSecret, bbAppPrivateKey string) *App {
+	if bitbucketAppSingleton == nil {
+		once.Do(
+			func() {
+				log.Debug("Creating object single instance...")
+				bitbucketAppSingleton = &App{
+					bitbucketAppID:         bbAppID,
+					bitbucketAppSecret:     bbAppSecret,
+					bitbucketAppPrivateKey: bbAppPrivateKey,
+				}
+			})
+	}
+	return bitbucketAppSingleton
+}
+
+// GetAppID returns the client ID of the Bitbucket Cloud OAuth consumer
+func (app *App) GetAppID() string {
+	return app.bitbucketAppID
+}
+
+// GetAppSecret returns the secret of the Bitbucket Cloud OAuth consumer
+func (app *App) GetAppSecret() string {
+	return app.bitbucketAppSecret
+}
+
+// GetAppPrivateKey returns the key used to encrypt the Bitbucket secrets
+func (app *App) GetAppPrivateKey() string {
+	return app.bitbucketAppPrivateKey
+}
+
+// SetKeyring sets the keyring encrypting the access tokens and secrets - the same keyring as the GitLab application,
+// so that a key rotation covers the Bitbucket organizations
+func (app *App) SetKeyring(keys *keyring.Keyring) {
+	app.keys = keys
+}
+
+// GetKeyring returns the keyring encrypting the access tokens and secrets, nil when not configured
+func (app *App) GetKeyring() *keyring.Keyring {
+	return app.keys
+}
@@ -0,0 +1,274 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+)
+
+// CommentMarker identifies the pull request comment managed by EasyCLA, the comment is updated instead of adding a
+// new one on each pull request update
+const CommentMarker = "<!-- easycla-bitbucket -->"
+
+// PullRequest is a Bitbucket pull request
+type PullRequest struct {
+	ID           int
+	Title        string
+	State        string
+	SourceBranch string
+	TargetBranch string
+	HeadCommit   string
+	WebURL       string
+}
+
+// CommitAuthor is the author of a pull request commit, Username is empty when the commit author isn't mapped to a
+// Bitbucket user
+type CommitAuthor struct {
+	CommitSHA string
+	Name      string
+	Email     string
+	Username  string
+	AccountID string
+}
+
+type cloudPullRequest struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Source struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"source"`
+	Destination struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"destination"`
+	Links struct {
+		HTML link `json:"html"`
+	} `json:"links"`
+}
+
+type dataCenterRef struct {
+	DisplayID    string `json:"displayId"`
+	LatestCommit string `json:"latestCommit"`
+}
+
+type dataCenterPullRequest struct {
+	ID      int           `json:"id"`
+	Title   string        `json:"title"`
+	State   string        `json:"state"`
+	FromRef dataCenterRef `json:"fromRef"`
+	ToRef   dataCenterRef `json:"toRef"`
+	Links   struct {
+		Self []link `json:"self"`
+	} `json:"links"`
+}
+
+type cloudCommit struct {
+	Hash   string `json:"hash"`
+	Author struct {
+		Raw  string `json:"raw"`
+		User *struct {
+			Nickname    string `json:"nickname"`
+			AccountID   string `json:"account_id"`
+			DisplayName string `json:"display_name"`
+		} `json:"user"`
+	} `json:"author"`
+}
+
+type dataCenterCommit struct {
+	ID     string `json:"id"`
+	Author struct {
+		Name         string `json:"name"`
+		EmailAddress string `json:"emailAddress"`
+		Slug         string `json:"slug"`
+	} `json:"author"`
+}
+
+type cloudComment struct {
+	ID      int `json:"id"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+}
+
+type dataCenterComment struct {
+	ID      int    `json:"id"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+}
+
+// pullRequestPath returns the API path of the pull request
+func (c *Client) pullRequestPath(owner, slug string, pullRequestID int) string {
+	if c.IsCloud() {
+		return fmt.Sprintf("%s/pullrequests/%d", c.repositoryPath(owner, slug), pullRequestID)
+	}
+	return fmt.Sprintf("%s/pull-requests/%d", c.repositoryPath(owner, slug), pullRequestID)
+}
+
+// GetPullRequest returns the pull request of the repository
+func (c *Client) GetPullRequest(owner, slug string, pullRequestID int) (*PullRequest, error) {
+	if c.IsCloud() {
+		var pr cloudPullRequest
+		if err := c.do(http.MethodGet, c.pullRequestPath(owner, slug, pullRequestID), nil, &pr); err != nil {
+			return nil, err
+		}
+		return &PullRequest{
+			ID:           pr.ID,
+			Title:        pr.Title,
+			State:        pr.State,
+			SourceBranch: pr.Source.Branch.Name,
+			TargetBranch: pr.Destination.Branch.Name,
+			HeadCommit:   pr.Source.Commit.Hash,
+			WebURL:       pr.Links.HTML.Href,
+		}, nil
+	}
+
+	var pr dataCenterPullRequest
+	if err := c.do(http.MethodGet, c.pullRequestPath(owner, slug, pullRequestID), nil, &pr); err != nil {
+		return nil, err
+	}
+	result := &PullRequest{
+		ID:           pr.ID,
+		Title:        pr.Title,
+		State:        pr.State,
+		SourceBranch: pr.FromRef.DisplayID,
+		TargetBranch: pr.ToRef.DisplayID,
+		HeadCommit:   pr.FromRef.LatestCommit,
+	}
+	if len(pr.Links.Self) > 0 {
+		result.WebURL = pr.Links.Self[0].Href
+	}
+	return result, nil
+}
+
+// GetPullRequestCommitAuthors returns the authors of the pull request commits
+func (c *Client) GetPullRequestCommitAuthors(owner, slug string, pullRequestID int) ([]*CommitAuthor, error) {
+	var authors []*CommitAuthor
+	path := c.pullRequestPath(owner, slug, pullRequestID) + "/commits"
+
+	if c.IsCloud() {
+		err := c.getAll(path, func(values json.RawMessage) error {
+			var page []cloudCommit
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, commit := range page {
+				author := &CommitAuthor{CommitSHA: commit.Hash}
+				// the raw author is the "Name <email>" of the git commit
+				if address, err := mail.ParseAddress(commit.Author.Raw); err == nil {
+					author.Name = address.Name
+					author.Email = address.Address
+				} else {
+					author.Name = commit.Author.Raw
+				}
+				if commit.Author.User != nil {
+					author.Username = commit.Author.User.Nickname
+					author.AccountID = commit.Author.User.AccountID
+					if author.Name == "" {
+						author.Name = commit.Author.User.DisplayName
+					}
+				}
+				authors = append(authors, author)
+			}
+			return nil
+		})
+		return authors, err
+	}
+
+	err := c.getAll(path, func(values json.RawMessage) error {
+		var page []dataCenterCommit
+		if err := json.Unmarshal(values, &page); err != nil {
+			return err
+		}
+		for _, commit := range page {
+			authors = append(authors, &CommitAuthor{
+				CommitSHA: commit.ID,
+				Name:      commit.Author.Name,
+				Email:     commit.Author.EmailAddress,
+				Username:  commit.Author.Slug,
+			})
+		}
+		return nil
+	})
+	return authors, err
+}
+
+// UpsertPullRequestComment adds the EasyCLA comment to the pull request or updates the existing one, the comment
+// is identified by the CommentMarker
+func (c *Client) UpsertPullRequestComment(owner, slug string, pullRequestID int, body string) error {
+	if !strings.Contains(body, CommentMarker) {
+		body = CommentMarker + "\n" + body
+	}
+	path := c.pullRequestPath(owner, slug, pullRequestID)
+
+	if c.IsCloud() {
+		var existing *cloudComment
+		err := c.getAll(path+"/comments", func(values json.RawMessage) error {
+			var page []cloudComment
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for i := range page {
+				if strings.Contains(page[i].Content.Raw, CommentMarker) {
+					existing = &page[i]
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		payload := map[string]interface{}{"content": map[string]string{"raw": body}}
+		if existing == nil {
+			return c.do(http.MethodPost, path+"/comments", payload, nil)
+		}
+		if existing.Content.Raw == body {
+			return nil
+		}
+		return c.do(http.MethodPut, fmt.Sprintf("%s/comments/%d", path, existing.ID), payload, nil)
+	}
+
+	// Bitbucket Data Center lists the pull request comments in the activities
+	var existing *dataCenterComment
+	err := c.getAll(path+"/activities", func(values json.RawMessage) error {
+		var page []struct {
+			Action  string             `json:"action"`
+			Comment *dataCenterComment `json:"comment"`
+		}
+		if err := json.Unmarshal(values, &page); err != nil {
+			return err
+		}
+		for _, activity := range page {
+			if activity.Action == "COMMENTED" && activity.Comment != nil && strings.Contains(activity.Comment.Text, CommentMarker) {
+				existing = activity.Comment
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		return c.do(http.MethodPost, path+"/comments", map[string]string{"text": body}, nil)
+	}
+	if existing.Text == body {
+		return nil
+	}
+	return c.do(http.MethodPut, fmt.Sprintf("%s/comments/%d", path, existing.ID), map[string]interface{}{
+		"text":    body,
+		"version": existing.Version,
+	}, nil)
+}
@@ -0,0 +1,137 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Repository is a Bitbucket repository, the owner is the workspace of Bitbucket Cloud or the project key of Bitbucket
+// Data Center
+type Repository struct {
+	ExternalID string
+	Owner      string
+	Slug       string
+	Name       string
+	FullName   string
+	WebURL     string
+	Private    bool
+}
+
+type link struct {
+	Href string `json:"href"`
+}
+
+type cloudRepository struct {
+	UUID      string `json:"uuid"`
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	FullName  string `json:"full_name"`
+	IsPrivate bool   `json:"is_private"`
+	Workspace struct {
+		Slug string `json:"slug"`
+	} `json:"workspace"`
+	Links struct {
+		HTML link `json:"html"`
+	} `json:"links"`
+}
+
+func (r *cloudRepository) toRepository() *Repository {
+	return &Repository{
+		ExternalID: r.UUID,
+		Owner:      r.Workspace.Slug,
+		Slug:       r.Slug,
+		Name:       r.Name,
+		FullName:   r.FullName,
+		WebURL:     r.Links.HTML.Href,
+		Private:    r.IsPrivate,
+	}
+}
+
+type dataCenterRepository struct {
+	ID      int    `json:"id"`
+	Slug    string `json:"slug"`
+	Name    string `json:"name"`
+	Public  bool   `json:"public"`
+	Project struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Links struct {
+		Self []link `json:"self"`
+	} `json:"links"`
+}
+
+func (r *dataCenterRepository) toRepository() *Repository {
+	repo := &Repository{
+		ExternalID: strconv.Itoa(r.ID),
+		Owner:      r.Project.Key,
+		Slug:       r.Slug,
+		Name:       r.Name,
+		FullName:   fmt.Sprintf("%s/%s", r.Project.Key, r.Slug),
+		Private:    !r.Public,
+	}
+	if len(r.Links.Self) > 0 {
+		repo.WebURL = r.Links.Self[0].Href
+	}
+	return repo
+}
+
+// repositoryPath returns the API path of the repository
+func (c *Client) repositoryPath(owner, slug string) string {
+	if c.IsCloud() {
+		return fmt.Sprintf("/2.0/repositories/%s/%s", url.PathEscape(owner), url.PathEscape(slug))
+	}
+	return fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s", url.PathEscape(owner), url.PathEscape(slug))
+}
+
+// GetRepository returns the repository of the owner
+func (c *Client) GetRepository(owner, slug string) (*Repository, error) {
+	if c.IsCloud() {
+		var repo cloudRepository
+		if err := c.do(http.MethodGet, c.repositoryPath(owner, slug), nil, &repo); err != nil {
+			return nil, err
+		}
+		return repo.toRepository(), nil
+	}
+
+	var repo dataCenterRepository
+	if err := c.do(http.MethodGet, c.repositoryPath(owner, slug), nil, &repo); err != nil {
+		return nil, err
+	}
+	return repo.toRepository(), nil
+}
+
+// ListRepositories returns the repositories of the workspace (Cloud) or project (Data Center)
+func (c *Client) ListRepositories(owner string) ([]*Repository, error) {
+	var repos []*Repository
+	if c.IsCloud() {
+		err := c.getAll(fmt.Sprintf("/2.0/repositories/%s", url.PathEscape(owner)), func(values json.RawMessage) error {
+			var page []cloudRepository
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for i := range page {
+				repos = append(repos, page[i].toRepository())
+			}
+			return nil
+		})
+		return repos, err
+	}
+
+	err := c.getAll(fmt.Sprintf("/rest/api/1.0/projects/%s/repos", url.PathEscape(owner)), func(values json.RawMessage) error {
+		var page []dataCenterRepository
+		if err := json.Unmarshal(values, &page); err != nil {
+			return err
+		}
+		for i := range page {
+			repos = append(repos, page[i].toRepository())
+		}
+		return nil
+	})
+	return repos, err
+}
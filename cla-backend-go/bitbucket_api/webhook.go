@@ -0,0 +1,301 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// webhook constants
+const (
+	// WebhookName is the name of the webhooks registered by EasyCLA
+	WebhookName = "EasyCLA"
+
+	// EventKeyHeader is the header carrying the event key of the webhook deliveries
+	EventKeyHeader = "X-Event-Key"
+	// SignatureHeader is the header carrying the HMAC signature of the webhook deliveries
+	SignatureHeader = "X-Hub-Signature"
+	// DataCenterPingEvent is sent by Bitbucket Data Center when testing the webhook
+	DataCenterPingEvent = "diagnostics:ping"
+)
+
+// the pull request events EasyCLA subscribes to
+var (
+	cloudPullRequestEvents      = []string{"pullrequest:created", "pullrequest:updated", "pullrequest:comment_created"}
+	dataCenterPullRequestEvents = []string{"pr:opened", "pr:from_ref_updated", "pr:modified", "pr:comment:added"}
+)
+
+// ErrUnsupportedEvent is returned when the event isn't a pull request event EasyCLA handles
+var ErrUnsupportedEvent = errors.New("unsupported bitbucket event")
+
+// ErrInvalidSignature is returned when the webhook signature doesn't match the secret
+var ErrInvalidSignature = errors.New("invalid bitbucket webhook signature")
+
+// Webhook is a repository webhook
+type Webhook struct {
+	ID     string
+	URL    string
+	Active bool
+}
+
+type cloudWebhook struct {
+	UUID        string   `json:"uuid,omitempty"`
+	Description string   `json:"description"`
+	URL         string   `json:"url"`
+	Active      bool     `json:"active"`
+	Secret      string   `json:"secret,omitempty"`
+	Events      []string `json:"events"`
+}
+
+type dataCenterWebhook struct {
+	ID            int               `json:"id,omitempty"`
+	Name          string            `json:"name"`
+	URL           string            `json:"url"`
+	Active        bool              `json:"active"`
+	Events        []string          `json:"events"`
+	Configuration map[string]string `json:"configuration,omitempty"`
+}
+
+// IsCommentEvent returns true if the event key is a pull request comment event
+func IsCommentEvent(eventKey string) bool {
+	return eventKey == "pullrequest:comment_created" || eventKey == "pr:comment:added"
+}
+
+// IsPullRequestEvent returns true if the event key is one of the pull request events EasyCLA subscribes to
+func IsPullRequestEvent(eventKey string) bool {
+	for _, event := range append(cloudPullRequestEvents, dataCenterPullRequestEvents...) {
+		if event == eventKey {
+			return true
+		}
+	}
+	return false
+}
+
+// findWebhook returns the EasyCLA webhook of the repository, nil if not registered
+func (c *Client) findWebhook(owner, slug, webhookURL string) (*Webhook, error) {
+	var found *Webhook
+	if c.IsCloud() {
+		err := c.getAll(c.repositoryPath(owner, slug)+"/hooks", func(values json.RawMessage) error {
+			var page []cloudWebhook
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, hook := range page {
+				if hook.URL == webhookURL {
+					found = &Webhook{ID: hook.UUID, URL: hook.URL, Active: hook.Active}
+				}
+			}
+			return nil
+		})
+		return found, err
+	}
+
+	err := c.getAll(c.repositoryPath(owner, slug)+"/webhooks", func(values json.RawMessage) error {
+		var page []dataCenterWebhook
+		if err := json.Unmarshal(values, &page); err != nil {
+			return err
+		}
+		for _, hook := range page {
+			if hook.URL == webhookURL {
+				found = &Webhook{ID: strconv.Itoa(hook.ID), URL: hook.URL, Active: hook.Active}
+			}
+		}
+		return nil
+	})
+	return found, err
+}
+
+// SetWebhook registers the EasyCLA pull request webhook of the repository, an existing webhook with the same url
+// is updated so the secret and the events stay current
+func (c *Client) SetWebhook(owner, slug, webhookURL, secret string) error {
+	existing, err := c.findWebhook(owner, slug, webhookURL)
+	if err != nil {
+		return err
+	}
+
+	if c.IsCloud() {
+		hook := &cloudWebhook{
+			Description: WebhookName,
+			URL:         webhookURL,
+			Active:      true,
+			Secret:      secret,
+			Events:      cloudPullRequestEvents,
+		}
+		if existing == nil {
+			return c.do(http.MethodPost, c.repositoryPath(owner, slug)+"/hooks", hook, nil)
+		}
+		return c.do(http.MethodPut, fmt.Sprintf("%s/hooks/%s", c.repositoryPath(owner, slug), existing.ID), hook, nil)
+	}
+
+	hook := &dataCenterWebhook{
+		Name:          WebhookName,
+		URL:           webhookURL,
+		Active:        true,
+		Events:        dataCenterPullRequestEvents,
+		Configuration: map[string]string{"secret": secret},
+	}
+	if existing == nil {
+		return c.do(http.MethodPost, c.repositoryPath(owner, slug)+"/webhooks", hook, nil)
+	}
+	return c.do(http.MethodPut, fmt.Sprintf("%s/webhooks/%s", c.repositoryPath(owner, slug), existing.ID), hook, nil)
+}
+
+// RemoveWebhook removes the EasyCLA webhook of the repository, nothing is done if the webhook isn't registered
+func (c *Client) RemoveWebhook(owner, slug, webhookURL string) error {
+	existing, err := c.findWebhook(owner, slug, webhookURL)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+
+	if c.IsCloud() {
+		return c.do(http.MethodDelete, fmt.Sprintf("%s/hooks/%s", c.repositoryPath(owner, slug), existing.ID), nil, nil)
+	}
+	return c.do(http.MethodDelete, fmt.Sprintf("%s/webhooks/%s", c.repositoryPath(owner, slug), existing.ID), nil, nil)
+}
+
+// ValidateSignature checks the X-Hub-Signature header (sha256=<hex hmac>) of a webhook delivery against the secret
+func ValidateSignature(payload []byte, signature, secret string) error {
+	if secret == "" || !strings.HasPrefix(signature, "sha256=") {
+		return ErrInvalidSignature
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// PullRequestEvent is the part of the pull request webhook payloads EasyCLA needs, for both Bitbucket flavors
+type PullRequestEvent struct {
+	Flavor               string
+	EventKey             string
+	Owner                string
+	Slug                 string
+	RepositoryExternalID string
+	PullRequestID        int
+	HeadCommit           string
+	TargetBranch         string
+	// Comment is the text of the comment of the comment events
+	Comment string
+}
+
+type cloudPullRequestPayload struct {
+	PullRequest struct {
+		ID     int `json:"id"`
+		Source struct {
+			Commit struct {
+				Hash string `json:"hash"`
+			} `json:"commit"`
+		} `json:"source"`
+		Destination struct {
+			Branch struct {
+				Name string `json:"name"`
+			} `json:"branch"`
+		} `json:"destination"`
+	} `json:"pullrequest"`
+	Repository struct {
+		UUID     string `json:"uuid"`
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Comment struct {
+		Content struct {
+			Raw string `json:"raw"`
+		} `json:"content"`
+	} `json:"comment"`
+}
+
+type dataCenterPullRequestPayload struct {
+	PullRequest struct {
+		ID      int `json:"id"`
+		FromRef struct {
+			LatestCommit string `json:"latestCommit"`
+		} `json:"fromRef"`
+		ToRef struct {
+			DisplayID  string `json:"displayId"`
+			Repository struct {
+				ID      int    `json:"id"`
+				Slug    string `json:"slug"`
+				Project struct {
+					Key string `json:"key"`
+				} `json:"project"`
+			} `json:"repository"`
+		} `json:"toRef"`
+	} `json:"pullRequest"`
+	Comment struct {
+		Text string `json:"text"`
+	} `json:"comment"`
+}
+
+// ParsePullRequestEvent parses the pull request webhook payload of the event key, ErrUnsupportedEvent is returned
+// for the other events
+func ParsePullRequestEvent(eventKey string, payload []byte) (*PullRequestEvent, error) {
+	for _, event := range cloudPullRequestEvents {
+		if event != eventKey {
+			continue
+		}
+		var p cloudPullRequestPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, err
+		}
+		parts := strings.SplitN(p.Repository.FullName, "/", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid bitbucket repository name : %s", p.Repository.FullName)
+		}
+		return &PullRequestEvent{
+			Flavor:               FlavorCloud,
+			EventKey:             eventKey,
+			Owner:                parts[0],
+			Slug:                 parts[1],
+			RepositoryExternalID: p.Repository.UUID,
+			PullRequestID:        p.PullRequest.ID,
+			HeadCommit:           p.PullRequest.Source.Commit.Hash,
+			TargetBranch:         p.PullRequest.Destination.Branch.Name,
+			Comment:              p.Comment.Content.Raw,
+		}, nil
+	}
+
+	for _, event := range dataCenterPullRequestEvents {
+		if event != eventKey {
+			continue
+		}
+		var p dataCenterPullRequestPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, err
+		}
+		repo := p.PullRequest.ToRef.Repository
+		return &PullRequestEvent{
+			Flavor:               FlavorDataCenter,
+			EventKey:             eventKey,
+			Owner:                repo.Project.Key,
+			Slug:                 repo.Slug,
+			RepositoryExternalID: strconv.Itoa(repo.ID),
+			PullRequestID:        p.PullRequest.ID,
+			HeadCommit:           p.PullRequest.FromRef.LatestCommit,
+			TargetBranch:         p.PullRequest.ToRef.DisplayID,
+			Comment:              p.Comment.Text,
+		}, nil
+	}
+
+	return nil, ErrUnsupportedEvent
+}
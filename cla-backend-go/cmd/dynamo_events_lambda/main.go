@@ -7,16 +7,25 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"strconv"
 
 	"github.com/communitybridge/easycla/cla-backend-go/project/repository"
 	"github.com/communitybridge/easycla/cla-backend-go/project/service"
 
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bot_allowlist"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_group_settings"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_deliveries"
 	v2Repositories "github.com/communitybridge/easycla/cla-backend-go/v2/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/repository_requirements"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/store"
 
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bitbucket_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_instances"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
 
+	bitbucketApi "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
 	gitlab "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	"github.com/communitybridge/easycla/cla-backend-go/keyring"
 
 	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 
@@ -25,6 +34,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
 	"github.com/communitybridge/easycla/cla-backend-go/cla_manager"
 
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
 	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
 
@@ -36,6 +46,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
 
 	"github.com/communitybridge/easycla/cla-backend-go/token"
 
@@ -99,12 +110,24 @@ func init() {
 	approvalListRequestsRepo := approval_list.NewRepository(awsSession, stage)
 	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
 	gitlabOrganizationRepo := gitlab_organizations.NewRepository(awsSession, stage)
+	bitbucketOrganizationRepo := bitbucket_organizations.NewRepository(awsSession, stage)
 	storeRepo := store.NewRepository(awsSession, stage)
+	claGroupSettingsRepo := cla_group_settings.NewRepository(awsSession, stage)
+	botAllowlistRepo := bot_allowlist.NewRepository(awsSession, stage)
+	repositoryRequirementsRepo := repository_requirements.NewRepository(awsSession, stage)
+	githubDeliveriesRepo := github_deliveries.NewRepository(awsSession, stage)
 
 	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
 	github.Init(configFile.GitHub.AppID, configFile.GitHub.AppPrivateKey, configFile.GitHub.AccessToken)
 	// initialize gitlab
 	gitlabApp := gitlab.Init(configFile.Gitlab.AppClientID, configFile.Gitlab.AppClientSecret, configFile.Gitlab.AppPrivateKey)
+	gitlabKeys, err := keyring.FromEnvironment(awsSession)
+	if err != nil {
+		log.Panicf("Unable to load the keyring - Error: %v", err)
+	}
+	gitlabApp.SetKeyring(gitlabKeys)
+	// the bitbucket secrets are encrypted with the same keyring
+	bitbucketApi.Init(configFile.Bitbucket.AppClientID, configFile.Bitbucket.AppClientSecret, configFile.Bitbucket.AppPrivateKey).SetKeyring(gitlabKeys)
 
 	user_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
 	project_service.InitClient(configFile.APIGatewayURL)
@@ -136,11 +159,29 @@ func init() {
 
 	usersService := users.NewService(usersRepo, eventsService)
 	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo, eventsService, repositoriesRepo, githubOrganizationsRepo, gerritService)
-	v2RepositoryService := v2Repositories.NewService(repositoriesRepo, v2Repository, projectClaGroupRepo, githubOrganizationsRepo, gitlabOrganizationRepo, eventsService)
-	gitlabOrgService := gitlab_organizations.NewService(gitlabOrganizationRepo, v2RepositoryService, projectClaGroupRepo, storeRepo, usersService, signaturesRepo, companyRepo)
+	gitlabInstancesService := gitlab_instances.NewService(gitlab_instances.NewRepository(awsSession, stage), eventsService)
+	emailTemplateService := emails.NewEmailTemplateService(projectRepo, projectClaGroupRepo, projectService, configFile.CorporateConsoleV1URL, configFile.CorporateConsoleV2URL)
+	emailService := emails.NewService(emailTemplateService, projectService)
+	v2RepositoryService := v2Repositories.NewService(repositoriesRepo, v2Repository, projectClaGroupRepo, githubOrganizationsRepo, gitlabOrganizationRepo, gitlabInstancesService, bitbucketOrganizationRepo, eventsService, emailService)
+	gitlabOrgService := gitlab_organizations.NewService(gitlabOrganizationRepo, v2RepositoryService, projectClaGroupRepo, storeRepo, usersService, signaturesRepo, companyRepo, gitlabInstancesService)
+	bitbucketOrgService := bitbucket_organizations.NewService(bitbucketOrganizationRepo, v2RepositoryService, eventsService)
 
 	companyService := company.NewService(companyRepo, configFile.CorporateConsoleV1URL, userRepo, usersService)
 	v2CompanyService := v2Company.NewService(companyService, signaturesRepo, projectRepo, usersRepo, companyRepo, projectClaGroupRepo, eventsService)
+
+	// the signatures service runs the pull request checks of the queued GitHub webhook deliveries
+	var githubOrgValidation = true // default is true/enabled
+	if githubOrgValidationString := os.Getenv("GH_ORG_VALIDATION"); githubOrgValidationString != "" {
+		githubOrgValidation, err = strconv.ParseBool(githubOrgValidationString)
+		if err != nil {
+			log.Panicf("GH_ORG_VALIDATION value must be a boolean string - Error: %v", err)
+		}
+	}
+	claGroupSettingsService := cla_group_settings.NewService(claGroupSettingsRepo, eventsService)
+	botAllowlistService := bot_allowlist.NewService(botAllowlistRepo, projectClaGroupRepo, repositoriesService, eventsService)
+	repositoryRequirementsService := repository_requirements.NewService(repositoryRequirementsRepo, repositoriesService, eventsService)
+	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, githubOrgValidation, repositoriesService, githubOrganizationsService, projectService, claGroupSettingsService, botAllowlistService, repositoryRequirementsService, gitlabApp, configFile.ClaV1ApiURL, configFile.CLALandingPage, configFile.CLALogoURL)
+
 	organization_service.InitClient(configFile.APIGatewayURL, eventsService)
 	acs_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
 	dynamoEventsService = dynamo_events.NewService(
@@ -161,6 +202,11 @@ func init() {
 		approvalListRequestsRepo,
 		gitlabApp,
 		gitlabOrgService,
+		bitbucketOrganizationRepo,
+		bitbucketOrgService,
+		metrics.NewHistoryRepository(awsSession, stage, projectClaGroupRepo),
+		githubDeliveriesRepo,
+		signaturesService,
 	)
 }
 
@@ -0,0 +1,79 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"strconv"
+
+	bitbucket "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	gitlab "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	ini "github.com/communitybridge/easycla/cla-backend-go/init"
+	"github.com/communitybridge/easycla/cla-backend-go/keyring"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bitbucket_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_instances"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
+)
+
+// re-encrypts the oauth credentials and webhook secrets of the GitLab organizations, the secrets of the GitLab instances
+// and the secrets of the Bitbucket organizations with the primary key of the keyring - run after adding a new primary
+// key, a previous key can be retired once the run reports no failure
+func main() {
+	ini.Init()
+	awsSession, err := ini.GetAWSSession()
+	if err != nil {
+		log.Fatalf("unable to load AWS session, error: %v", err)
+	}
+
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+
+	// defaults to a dry run, listing the records with credentials to re-encrypt
+	dryRun := true
+	if value := os.Getenv("DRY_RUN"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			log.Fatalf("invalid DRY_RUN value: %s", value)
+		}
+	}
+
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Fatalf("unable to load config, error: %v", err)
+	}
+
+	keys, err := keyring.FromEnvironment(awsSession)
+	if err != nil {
+		log.Fatalf("unable to load the keyring, error: %v", err)
+	}
+	if keys == nil {
+		log.Fatalf("no keyring configured - please set the key IDs in the environment variable: %s", keyring.KeyIDsEnv)
+	}
+	log.Infof("re-encrypting with primary key: %s, active keys: %v, dry run: %t", keys.PrimaryKeyID(), keys.KeyIDs(), dryRun)
+
+	gitLabApp := gitlab.Init(configFile.Gitlab.AppClientID, configFile.Gitlab.AppClientSecret, configFile.Gitlab.AppPrivateKey)
+	gitLabApp.SetKeyring(keys)
+	bitbucketApp := bitbucket.Init(configFile.Bitbucket.AppClientID, configFile.Bitbucket.AppClientSecret, configFile.Bitbucket.AppPrivateKey)
+	bitbucketApp.SetKeyring(keys)
+
+	ctx := utils.NewContext()
+	result, err := gitlab_organizations.ReEncryptCredentials(ctx,
+		gitlab_organizations.NewRepository(awsSession, stage),
+		gitlab_instances.NewRepository(awsSession, stage),
+		bitbucket_organizations.NewRepository(awsSession, stage),
+		gitLabApp, bitbucketApp, dryRun)
+	if err != nil {
+		log.Fatalf("unable to re-encrypt the credentials, error: %v", err)
+	}
+
+	log.Infof("scanned %d records, re-encrypted: %v", result.Scanned, result.ReEncrypted)
+	if len(result.Failed) > 0 {
+		log.Fatalf("unable to re-encrypt the credentials of: %v", result.Failed)
+	}
+}
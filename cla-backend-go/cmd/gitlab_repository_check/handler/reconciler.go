@@ -0,0 +1,572 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	gitLabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	v1Repositories "github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
+	v2Repositories "github.com/communitybridge/easycla/cla-backend-go/v2/repositories"
+	"github.com/sirupsen/logrus"
+	goGitLab "github.com/xanzy/go-gitlab"
+)
+
+// Reconciler applies the GitLab project deltas of the GitLab groups/organizations to our database. It is used by the
+// periodic check, which compares the whole group, and by the project events of the group hooks and system hooks,
+// which apply a single project change.
+type Reconciler struct {
+	gitlabOrganizationRepo    gitlab_organizations.RepositoryInterface
+	gitlabOrganizationService gitlab_organizations.ServiceInterface
+	gitV2Repository           v2Repositories.RepositoryInterface
+	v2RepositoriesService     v2Repositories.ServiceInterface
+	projectClaGroupRepo       projects_cla_groups.Repository
+	eventsService             events.Service
+}
+
+// NewReconciler creates a new GitLab project reconciler
+func NewReconciler(gitlabOrganizationRepo gitlab_organizations.RepositoryInterface, gitlabOrganizationService gitlab_organizations.ServiceInterface, gitV2Repository v2Repositories.RepositoryInterface, v2RepositoriesService v2Repositories.ServiceInterface, projectClaGroupRepo projects_cla_groups.Repository, eventsService events.Service) *Reconciler {
+	return &Reconciler{
+		gitlabOrganizationRepo:    gitlabOrganizationRepo,
+		gitlabOrganizationService: gitlabOrganizationService,
+		gitV2Repository:           gitV2Repository,
+		v2RepositoriesService:     v2RepositoriesService,
+		projectClaGroupRepo:       projectClaGroupRepo,
+		eventsService:             eventsService,
+	}
+}
+
+// ReconcileGroup compares the GitLab projects of the group/organization with our database - the new projects are
+// added and the deleted projects are removed. The group hook sending the project events is registered on the way, the
+// periodic check then only catches the events which were missed.
+func (r *Reconciler) ReconcileGroup(ctx context.Context, gitLabOrganizationID string) error {
+	f := logrus.Fields{
+		"functionName":         "cmd.gitlab_repository_check.handler.ReconcileGroup",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"gitLabOrganizationID": gitLabOrganizationID,
+	}
+
+	gitLabOrg, err := r.gitlabOrganizationRepo.GetGitLabOrganization(ctx, gitLabOrganizationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading GitLab group/organization")
+		return err
+	}
+	log.WithFields(f).Debugf("start - processing GitLab group/organization: %s with group ID: %d associated with project SFID: %s", gitLabOrg.OrganizationURL, gitLabOrg.ExternalGroupID, gitLabOrg.ProjectSFID)
+
+	claGroupID, err := r.getClaGroupID(ctx, gitLabOrg)
+	if err != nil {
+		return err
+	}
+
+	if gitLabOrg.AuthInfo == "" {
+		log.WithFields(f).Debugf("GitLab group/organization: %s not fully onboarded - missing authentication info - skipping", gitLabOrg.OrganizationURL)
+		return nil
+	}
+
+	gitLabClient, err := r.gitlabOrganizationService.GetGitLabOrganizationClient(ctx, gitLabOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem loading GitLab client for group/organization: %s", gitLabOrg.OrganizationURL)
+		return err
+	}
+
+	// Register the group hook - the tiers without group hooks rely on this periodic check only
+	if hookErr := r.gitlabOrganizationService.SetGroupHook(ctx, gitLabOrg, gitLabClient); hookErr != nil {
+		if errors.Is(hookErr, gitLabApi.ErrGroupHooksUnsupported) {
+			log.WithFields(f).Debugf("group hooks not available for group/organization: %s - relying on the periodic check", gitLabOrg.OrganizationFullPath)
+		} else {
+			log.WithFields(f).WithError(hookErr).Warnf("problem registering the group hook of group/organization: %s", gitLabOrg.OrganizationFullPath)
+		}
+	}
+
+	// Refresh the subgroups before adding the new projects, the subgroups moved or renamed keep their settings
+	r.syncSubgroups(ctx, gitLabOrg, gitLabClient)
+
+	gitLabProjects, err := gitLabApi.GetGroupProjectListByGroupID(ctx, gitLabClient, gitLabOrg.ExternalGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem loading GitLab projects for group/organization: %s using the groupID: %d", gitLabOrg.OrganizationFullPath, gitLabOrg.ExternalGroupID)
+		return err
+	}
+	log.WithFields(f).Debugf("found %d GitLab projects for group/organization: %s", len(gitLabProjects), gitLabOrg.OrganizationFullPath)
+
+	gitLabDBProjects, err := r.getDBProjects(ctx, gitLabOrg)
+	if err != nil {
+		return err
+	}
+
+	// Follow the renamed, transferred and archived projects first, the projects moved within the registered groups keep
+	// their record instead of being added again
+	if r.followProjects(ctx, gitLabOrg, claGroupID, gitLabProjects, gitLabDBProjects) {
+		gitLabDBProjects, err = r.getDBProjects(ctx, gitLabOrg)
+		if err != nil {
+			return err
+		}
+	}
+
+	newGitLabProjects := getNewProjects(gitLabProjects, gitLabDBProjects)
+	log.WithFields(f).Debugf("Found %d GitLab projects/repositories that are to be added for GitLab Group: %s", len(newGitLabProjects), gitLabOrg.OrganizationFullPath)
+	r.addProjects(ctx, gitLabOrg, claGroupID, newGitLabProjects)
+
+	gitLabProjects, err = gitLabApi.GetGroupProjectListByGroupID(ctx, gitLabClient, gitLabOrg.ExternalGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem loading GitLab projects for group/organization: %s using the groupID: %d", gitLabOrg.OrganizationFullPath, gitLabOrg.ExternalGroupID)
+		return err
+	}
+	log.WithFields(f).Debugf("found %d GitLab projects for group/organization: %s", len(gitLabProjects), gitLabOrg.OrganizationFullPath)
+
+	dBProjects, err := r.getDBProjects(ctx, gitLabOrg)
+	if err != nil {
+		return err
+	}
+	log.WithFields(f).Debugf("Found %d GitLab projects/repositories for GitLab Group: %s", len(dBProjects), gitLabOrg.OrganizationFullPath)
+
+	deletedGitLabProjects := getDeletedProjects(gitLabProjects, dBProjects)
+	log.WithFields(f).Debugf("Found %d GitLab projects/repositories that are to be removed from the GitLab Group: %s", len(deletedGitLabProjects), gitLabOrg.OrganizationFullPath)
+	r.removeProjects(ctx, gitLabOrg, deletedGitLabProjects)
+
+	log.WithFields(f).Debugf("done - processed GitLab group/organization: %s with group ID: %d associated with project SFID: %s", gitLabOrg.OrganizationURL, gitLabOrg.ExternalGroupID, gitLabOrg.ProjectSFID)
+	return nil
+}
+
+// ProjectEvent applies a project event of the group hooks and system hooks. The project is added when created, or
+// renamed or transferred into the auto-enabled group/organization. The known projects follow their renames, transfers
+// and archived flag and are removed when deleted or transferred out of the registered groups. gitLabOrg is the
+// group/organization of the project path after the event, nil when the project isn't under a registered group,
+// previousGitLabOrg the group/organization of the project path before the event. Only the project records of these
+// groups/organizations are changed, and the deletes and transfers are confirmed with the GitLab API first - the event
+// payload isn't trusted.
+func (r *Reconciler) ProjectEvent(ctx context.Context, gitLabOrg, previousGitLabOrg *common.GitLabOrganization, event *goGitLab.ProjectSystemEvent) error {
+	f := logrus.Fields{
+		"functionName":   "cmd.gitlab_repository_check.handler.ProjectEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventName":      event.EventName,
+		"projectID":      event.ProjectID,
+		"projectPath":    event.PathWithNamespace,
+		"oldProjectPath": event.OldPathWithNamespace,
+	}
+
+	dbProject, err := r.gitV2Repository.GitLabGetRepositoryByExternalID(ctx, int64(event.ProjectID))
+	if err != nil {
+		if _, ok := err.(*utils.GitLabRepositoryNotFound); !ok {
+			log.WithFields(f).WithError(err).Warn("problem loading the GitLab project from the database")
+			return err
+		}
+		dbProject = nil
+	}
+
+	if dbProject != nil {
+		if getOwnerGitLabOrganization(dbProject, gitLabOrg, previousGitLabOrg) == nil {
+			log.WithFields(f).Warnf("GitLab project record belongs to group/organization: %s, not to the group/organization of the event - ignoring", dbProject.RepositoryOrganizationName)
+			return nil
+		}
+	}
+
+	if event.EventName == gitLabApi.ProjectDestroyEvent || gitLabOrg == nil {
+		if dbProject == nil {
+			log.WithFields(f).Debug("GitLab project not in the database - nothing to remove")
+			return nil
+		}
+		return r.removeProject(ctx, event)
+	}
+
+	if dbProject == nil && !gitLabOrg.AutoEnabled {
+		log.WithFields(f).Debugf("GitLab group/organization: %s is not auto-enabled - leaving the project to the periodic check", gitLabOrg.OrganizationFullPath)
+		return nil
+	}
+
+	claGroupID, err := r.getClaGroupID(ctx, gitLabOrg)
+	if err != nil {
+		return err
+	}
+
+	gitLabClient, err := r.gitlabOrganizationService.GetGitLabOrganizationClient(ctx, gitLabOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem loading GitLab client for group/organization: %s", gitLabOrg.OrganizationURL)
+		return err
+	}
+	gitLabProject, err := gitLabApi.GetProjectByID(ctx, gitLabClient, event.ProjectID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the GitLab project")
+		return err
+	}
+	if !gitLabApi.IsProjectUnderGroup(gitLabProject.PathWithNamespace, gitLabOrg.OrganizationFullPath) {
+		log.WithFields(f).Warnf("GitLab project is at: %s, not under group/organization: %s - ignoring", gitLabProject.PathWithNamespace, gitLabOrg.OrganizationFullPath)
+		return nil
+	}
+
+	if dbProject == nil {
+		r.addProjects(ctx, gitLabOrg, claGroupID, []*goGitLab.Project{gitLabProject})
+		return nil
+	}
+	return r.followProject(ctx, gitLabOrg, claGroupID, dbProject, gitLabProject)
+}
+
+// removeProject applies the delete, or the transfer out of the registered groups, of the project. The repositories
+// service confirms both with the GitLab API before removing the record.
+func (r *Reconciler) removeProject(ctx context.Context, event *goGitLab.ProjectSystemEvent) error {
+	if event.EventName == gitLabApi.ProjectDestroyEvent {
+		return r.v2RepositoriesService.GitLabRemoteDeleteRepository(ctx, int64(event.ProjectID))
+	}
+	// transferred out of the registered groups - removed with the notification of the CLA Managers
+	_, err := r.v2RepositoriesService.GitLabTransferRepository(ctx, &v2Repositories.GitLabTransferRepoModel{
+		RepositoryExternalID: int64(event.ProjectID),
+		NewFullPath:          event.PathWithNamespace,
+	})
+	return err
+}
+
+// SubgroupEvent applies a group or subgroup event of the group hooks and system hooks, the subgroups of the
+// group/organization are refreshed
+func (r *Reconciler) SubgroupEvent(ctx context.Context, gitLabOrg *common.GitLabOrganization, event *goGitLab.GroupSystemEvent) error {
+	f := logrus.Fields{
+		"functionName":   "cmd.gitlab_repository_check.handler.SubgroupEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventName":      event.EventName,
+		"groupID":        event.GroupID,
+		"groupPath":      event.PathWithNamespace,
+	}
+
+	gitLabClient, err := r.gitlabOrganizationService.GetGitLabOrganizationClient(ctx, gitLabOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem loading GitLab client for group/organization: %s", gitLabOrg.OrganizationURL)
+		return err
+	}
+
+	r.syncSubgroups(ctx, gitLabOrg, gitLabClient)
+	return nil
+}
+
+// getClaGroupID returns the auto-enabled CLA Group of the group/organization, the CLA Group of its project when not set
+func (r *Reconciler) getClaGroupID(ctx context.Context, gitLabOrg *common.GitLabOrganization) (string, error) {
+	f := logrus.Fields{
+		"functionName":   "cmd.gitlab_repository_check.handler.getClaGroupID",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    gitLabOrg.ProjectSFID,
+	}
+
+	if gitLabOrg.AutoEnabledClaGroupID != "" {
+		return gitLabOrg.AutoEnabledClaGroupID, nil
+	}
+
+	log.WithFields(f).Debugf("GitLab group/organization: %s not fully onboarded - missing CLA Group ID", gitLabOrg.OrganizationURL)
+	pcg, err := r.projectClaGroupRepo.GetClaGroupIDForProject(ctx, gitLabOrg.ProjectSFID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem querying for CLA Group ID for project SFID: %s", gitLabOrg.ProjectSFID)
+		return "", err
+	}
+	log.WithFields(f).Debug("found CLA Group ID: ", pcg.ClaGroupID)
+	return pcg.ClaGroupID, nil
+}
+
+// getDBProjects returns the GitLab projects of the group/organization in our database
+func (r *Reconciler) getDBProjects(ctx context.Context, gitLabOrg *common.GitLabOrganization) ([]*v1Repositories.RepositoryDBModel, error) {
+	f := logrus.Fields{
+		"functionName":   "cmd.gitlab_repository_check.handler.getDBProjects",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	dbProjects, err := r.gitV2Repository.GitLabGetRepositoriesByOrganizationName(ctx, gitLabOrg.OrganizationFullPath)
+	if err != nil {
+		if _, ok := err.(*utils.GitLabRepositoryNotFound); ok {
+			log.WithFields(f).Debugf("GitLab group/organization: %s does not have any repositories in the database", gitLabOrg.OrganizationFullPath)
+			return nil, nil
+		}
+		log.WithFields(f).WithError(err).Warnf("problem loading GitLab projects for group/organization: %s from the database", gitLabOrg.OrganizationFullPath)
+		return nil, err
+	}
+	return dbProjects, nil
+}
+
+// syncSubgroups refreshes the subgroups of the group/organization and logs the subgroups renamed, moved or removed
+func (r *Reconciler) syncSubgroups(ctx context.Context, gitLabOrg *common.GitLabOrganization, gitLabClient *goGitLab.Client) {
+	f := logrus.Fields{
+		"functionName":   "cmd.gitlab_repository_check.handler.syncSubgroups",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	subgroupChanges, err := r.gitlabOrganizationService.SyncSubgroups(ctx, gitLabOrg, gitLabClient)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem refreshing the subgroups of group/organization: %s - using the known subgroups", gitLabOrg.OrganizationFullPath)
+	}
+	for _, change := range subgroupChanges {
+		if change.Kind == common.GitLabSubgroupAdded {
+			log.WithFields(f).Debugf("found new subgroup: %s under group/organization: %s", change.Subgroup.FullPath, gitLabOrg.OrganizationFullPath)
+			continue
+		}
+		r.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.GitLabSubgroupChanged,
+			ProjectSFID: gitLabOrg.ProjectSFID,
+			CLAGroupID:  change.Subgroup.ClaGroupID,
+			EventData: &events.GitLabSubgroupChangedEventData{
+				GitLabOrganizationName: gitLabOrg.OrganizationFullPath,
+				GitLabGroupID:          int64(gitLabOrg.ExternalGroupID),
+				SubgroupID:             change.Subgroup.GroupID,
+				Change:                 change.Kind,
+				PreviousFullPath:       change.PreviousFullPath,
+				FullPath:               change.Subgroup.FullPath,
+			},
+		})
+	}
+}
+
+// followProjects applies the renames, transfers and archived flag changes of the GitLab projects of the
+// group/organization to their records, the projects transferred from another registered group/organization are looked
+// up by their GitLab project ID. Returns true when a record was updated.
+func (r *Reconciler) followProjects(ctx context.Context, gitLabOrg *common.GitLabOrganization, claGroupID string, gitLabProjects []*goGitLab.Project, gitLabDBProjects []*v1Repositories.RepositoryDBModel) bool {
+	f := logrus.Fields{
+		"functionName":   "cmd.gitlab_repository_check.handler.followProjects",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"groupFullPath":  gitLabOrg.OrganizationFullPath,
+	}
+
+	dbProjectsByExternalID := make(map[string]*v1Repositories.RepositoryDBModel, len(gitLabDBProjects))
+	for _, gitLabDBProject := range gitLabDBProjects {
+		dbProjectsByExternalID[gitLabDBProject.RepositoryExternalID] = gitLabDBProject
+	}
+
+	updated := false
+	for _, gitLabProject := range gitLabProjects {
+		dbProject, ok := dbProjectsByExternalID[strconv.Itoa(gitLabProject.ID)]
+		if !ok {
+			var err error
+			dbProject, err = r.gitV2Repository.GitLabGetRepositoryByExternalID(ctx, int64(gitLabProject.ID))
+			if err != nil {
+				if _, notFound := err.(*utils.GitLabRepositoryNotFound); !notFound {
+					log.WithFields(f).WithError(err).Warnf("problem loading GitLab project: %s from the database", gitLabProject.PathWithNamespace)
+				}
+				continue
+			}
+		}
+
+		if dbProject.RepositoryFullPath == gitLabProject.PathWithNamespace && dbProject.IsArchived == gitLabProject.Archived {
+			continue
+		}
+		if err := r.followProject(ctx, gitLabOrg, claGroupID, dbProject, gitLabProject); err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem updating GitLab project: %s in the database", gitLabProject.PathWithNamespace)
+			continue
+		}
+		updated = true
+	}
+
+	return updated
+}
+
+// followProject applies the rename, transfer and archived flag change of the GitLab project to its record - a path
+// change in the same namespace is a rename, in another namespace a transfer
+func (r *Reconciler) followProject(ctx context.Context, gitLabOrg *common.GitLabOrganization, claGroupID string, dbProject *v1Repositories.RepositoryDBModel, gitLabProject *goGitLab.Project) error {
+	repositoryExternalID := int64(gitLabProject.ID)
+	if dbProject.RepositoryFullPath != gitLabProject.PathWithNamespace {
+		var err error
+		if strings.EqualFold(path.Dir(dbProject.RepositoryFullPath), path.Dir(gitLabProject.PathWithNamespace)) {
+			_, err = r.v2RepositoriesService.GitLabRenameRepository(ctx, repositoryExternalID, gitLabProject.PathWithNamespace, gitLabProject.WebURL)
+		} else {
+			projectClaGroupID, _ := getProjectClaGroupID(gitLabOrg, claGroupID, gitLabProject)
+			_, err = r.v2RepositoriesService.GitLabTransferRepository(ctx, &v2Repositories.GitLabTransferRepoModel{
+				RepositoryExternalID: repositoryExternalID,
+				NewFullPath:          gitLabProject.PathWithNamespace,
+				NewURL:               gitLabProject.WebURL,
+				GitLabOrganization:   gitLabOrg,
+				ClaGroupID:           projectClaGroupID,
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if dbProject.IsArchived != gitLabProject.Archived {
+		return r.v2RepositoriesService.GitLabSetRepositoryArchived(ctx, repositoryExternalID, gitLabProject.Archived)
+	}
+	return nil
+}
+
+// addProjects adds the new GitLab projects of the group/organization to our database, enabled
+func (r *Reconciler) addProjects(ctx context.Context, gitLabOrg *common.GitLabOrganization, claGroupID string, gitLabProjects []*goGitLab.Project) {
+	f := logrus.Fields{
+		"functionName":   "cmd.gitlab_repository_check.handler.addProjects",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	// Build the lists of the GitLab Project/repo ID values by CLA Group - the add repositories takes a list
+	for projectClaGroupID, gitLabProjectIDList := range getProjectIDsByCLAGroup(gitLabOrg, claGroupID, gitLabProjects) {
+		// Add the repositories - will generate a log event
+		_, addErr := r.v2RepositoriesService.GitLabAddRepositoriesWithEnabledFlag(ctx, gitLabOrg.ProjectSFID, &v2Repositories.GitLabAddRepoModel{
+			ClaGroupID:    projectClaGroupID,
+			GroupName:     gitLabOrg.OrganizationName,
+			ExternalID:    int64(gitLabOrg.ExternalGroupID),
+			GroupFullPath: gitLabOrg.OrganizationFullPath,
+			ProjectIDList: gitLabProjectIDList,
+		}, true) // set to enabled when adding since this was added as a result of the auto-enable feature
+		if addErr != nil {
+			log.WithFields(f).WithError(addErr).Warnf("problem adding GitLab projects for group/organization: %s with CLA Group: %s to the database", gitLabOrg.OrganizationFullPath, projectClaGroupID)
+		} else {
+			log.WithFields(f).Debugf("added %d GitLab projects for group/organization: %s with CLA Group: %s to the database", len(gitLabProjectIDList), gitLabOrg.OrganizationFullPath, projectClaGroupID)
+		}
+	}
+}
+
+// removeProjects removes the deleted GitLab projects from our database, gitLabOrg is only used for logging
+func (r *Reconciler) removeProjects(ctx context.Context, gitLabOrg *common.GitLabOrganization, dbProjects []*v1Repositories.RepositoryDBModel) {
+	f := logrus.Fields{
+		"functionName":   "cmd.gitlab_repository_check.handler.removeProjects",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+	if gitLabOrg != nil {
+		f["groupFullPath"] = gitLabOrg.OrganizationFullPath
+	}
+
+	for _, gitLabProjectDBRecord := range dbProjects {
+		repositoryExternalID, parseIntErr := strconv.ParseInt(gitLabProjectDBRecord.RepositoryExternalID, 10, 64)
+		if parseIntErr != nil {
+			log.WithFields(f).WithError(parseIntErr).Warnf("problem converting repository %s external ID string value: %s to integer", gitLabProjectDBRecord.RepositoryFullPath, gitLabProjectDBRecord.RepositoryExternalID)
+			continue
+		}
+		deleteErr := r.v2RepositoriesService.GitLabDeleteRepositoryByExternalID(ctx, repositoryExternalID)
+		if deleteErr != nil {
+			log.WithFields(f).WithError(deleteErr).Warnf("problem deleting repository %s with external ID: %s", gitLabProjectDBRecord.RepositoryFullPath, gitLabProjectDBRecord.RepositoryExternalID)
+		} else {
+			log.WithFields(f).Debugf("deleted GitLab project %s from the database", gitLabProjectDBRecord.RepositoryName)
+		}
+	}
+}
+
+// getNewProjects is a helper function to determine if we have any new GitLab projects that are not in our database
+func getNewProjects(gitLabProjects []*goGitLab.Project, gitLabDBProjects []*v1Repositories.RepositoryDBModel) []*goGitLab.Project {
+	var response []*goGitLab.Project
+	f := logrus.Fields{
+		"functionName": "getNewProjects",
+	}
+	if len(gitLabDBProjects) == 0 {
+		// No projects in the database - return all the projects from GitLab
+		log.WithFields(f).Debugf("no projects in the database - returning all projects from GitLab: %+v", gitLabProjects)
+		return gitLabProjects
+	}
+
+	// For each GitLab Project/Repo
+	for _, gitLabProject := range gitLabProjects {
+		found := false
+
+		// For each GitLab Project/Repo in the database
+		for _, gitLabDBProject := range gitLabDBProjects {
+			// Compare the full name/path
+			if strings.ToLower(gitLabProject.PathWithNamespace) == strings.ToLower(gitLabDBProject.RepositoryFullPath) {
+				found = true
+				break
+			}
+		}
+
+		// Didn't find the GitLab Project Repo from GitLab defined in our database - must have been added!
+		if !found {
+			// Add to our list
+			response = append(response, gitLabProject)
+		}
+	}
+
+	return response
+}
+
+// getProjectIDsByCLAGroup is a helper function to group the new GitLab projects by the CLA Group of their subgroup. The
+// projects use the CLA Group of the closest subgroup overriding it, or the CLA Group of the group/organization. The
+// projects of the subgroups with auto-enable turned off are skipped.
+func getProjectIDsByCLAGroup(gitLabOrg *common.GitLabOrganization, claGroupID string, gitLabProjects []*goGitLab.Project) map[string][]int64 {
+	response := make(map[string][]int64)
+	f := logrus.Fields{
+		"functionName": "getProjectIDsByCLAGroup",
+	}
+
+	for _, gitLabProject := range gitLabProjects {
+		projectClaGroupID, autoEnabled := getProjectClaGroupID(gitLabOrg, claGroupID, gitLabProject)
+		if !autoEnabled {
+			log.WithFields(f).Debugf("skipping GitLab project: %s - auto-enable turned off by its subgroup", gitLabProject.PathWithNamespace)
+			continue
+		}
+		response[projectClaGroupID] = append(response[projectClaGroupID], int64(gitLabProject.ID))
+	}
+
+	return response
+}
+
+// getProjectClaGroupID is a helper function returning the CLA Group of the GitLab project - the CLA Group of the
+// closest subgroup overriding it, or the CLA Group of the group/organization - and false when auto-enable is turned off
+// by its subgroup. Only the subgroup overrides are evaluated, the group/organization auto-enabled flag is not checked.
+func getProjectClaGroupID(gitLabOrg *common.GitLabOrganization, claGroupID string, gitLabProject *goGitLab.Project) (string, bool) {
+	if gitLabProject.Namespace == nil {
+		return claGroupID, true
+	}
+
+	settings := gitLabOrg.SubgroupSettings(int64(gitLabProject.Namespace.ID))
+	if settings.AutoEnabledSource != 0 && !settings.AutoEnabled {
+		return claGroupID, false
+	}
+	if settings.ClaGroupSource != 0 {
+		return settings.ClaGroupID, true
+	}
+	return claGroupID, true
+}
+
+// getDeletedProjects is a helper function to determine if we have any new GitLab projects that were removed from GitLab but are still in our database
+func getDeletedProjects(gitLabProjects []*goGitLab.Project, gitLabDBProjects []*v1Repositories.RepositoryDBModel) []*v1Repositories.RepositoryDBModel {
+	response := make([]*v1Repositories.RepositoryDBModel, 0)
+	f := logrus.Fields{
+		"functionName": "getDeletedProjects",
+	}
+
+	if len(gitLabProjects) == 0 {
+		// No projects in GitLab - return all the projects from the database
+		log.WithFields(f).Debugf("no projects in GitLab - returning all projects from the database: %+v", gitLabDBProjects)
+		return gitLabDBProjects
+	}
+
+	log.WithFields(f).Debugf("len(gitLabProjects): %d and len(gitLabDbProjects): %d", len(gitLabProjects), len(gitLabDBProjects))
+
+	// For each GitLab Project/Repo in the database
+	for _, gitLabDBProject := range gitLabDBProjects {
+		found := false
+
+		// For each GitLab Project/Repo
+		for _, gitLabProject := range gitLabProjects {
+			// Compare the full name/path
+			log.WithFields(f).Debugf("comparing GitLab project: %s with GitLab DB project: %s", gitLabProject.PathWithNamespace, gitLabDBProject.RepositoryFullPath)
+			if strings.ToLower(gitLabProject.PathWithNamespace) == strings.ToLower(gitLabDBProject.RepositoryFullPath) {
+				found = true
+				break
+			}
+
+		}
+
+		// Didn't find the GitLab Project Repo from the database defined in GitLab - must have been removed!
+		if !found {
+			// Add to our list
+			log.WithFields(f).Debugf("adding GitLab project: %s to the list of projects to be deleted", gitLabDBProject.RepositoryFullPath)
+			response = append(response, gitLabDBProject)
+		} else {
+			log.WithFields(f).Debugf("GitLab project: %s was not found in the list of projects to be deleted", gitLabDBProject.RepositoryFullPath)
+		}
+	}
+
+	return response
+}
+
+// getOwnerGitLabOrganization is a helper function returning the group/organization owning the project record, nil
+// when the record belongs to none of the groups/organizations
+func getOwnerGitLabOrganization(dbProject *v1Repositories.RepositoryDBModel, gitLabOrgs ...*common.GitLabOrganization) *common.GitLabOrganization {
+	for _, gitLabOrg := range gitLabOrgs {
+		if gitLabOrg == nil || dbProject.ProjectSFID != gitLabOrg.ProjectSFID {
+			continue
+		}
+		if strings.EqualFold(dbProject.RepositoryOrganizationName, gitLabOrg.OrganizationName) || strings.EqualFold(dbProject.RepositoryOrganizationName, gitLabOrg.OrganizationFullPath) {
+			return gitLabOrg
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	gitlabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	"github.com/communitybridge/easycla/cla-backend-go/health"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_instances"
+)
+
+// healthProbes returns the dependency probes run by the readiness health check. The DynamoDB tables and the
+// signature files bucket are critical, all the other dependencies only degrade the service when they fail.
+func healthProbes(awsSession *session.Session, stage string, configFile config.Config, gitlabInstancesRepo gitlab_instances.RepositoryInterface) []health.Probe {
+	probes := health.DynamoTableProbes(awsSession, stage)
+
+	if configFile.SignatureFilesBucket != "" {
+		probes = append(probes, health.NewS3BucketProbe(awsSession, configFile.SignatureFilesBucket, true))
+	}
+	if configFile.SNSEventTopicARN != "" {
+		probes = append(probes, health.NewSNSTopicProbe(awsSession, configFile.SNSEventTopicARN, false))
+	}
+	if configFile.Docraptor.APIKey != "" {
+		probes = append(probes, health.NewDocraptorProbe(configFile.Docraptor.APIKey, false))
+	}
+	if authServer := utils.GetProperty("DOCUSIGN_AUTH_SERVER"); authServer != "" {
+		probes = append(probes, health.NewDocuSignProbe(authServer, false))
+	}
+	if configFile.GitHub.AppID != 0 && configFile.GitHub.AppPrivateKey != "" {
+		probes = append(probes, health.NewGitHubAppProbe(int64(configFile.GitHub.AppID), configFile.GitHub.AppPrivateKey, false))
+	}
+	probes = append(probes, health.NewGitLabOAuthProbe(func(ctx context.Context) (map[string]string, error) {
+		return gitLabInstanceBaseURLs(ctx, configFile, gitlabInstancesRepo)
+	}, false))
+	if configFile.PlatformAPIGatewayURL != "" {
+		for _, basePath := range []string{"user-service/v1", "project-service", "organization-service", "acs/v1/api"} {
+			probes = append(probes, health.NewPlatformServiceProbe(configFile.PlatformAPIGatewayURL, basePath, false))
+		}
+	}
+
+	return probes
+}
+
+// gitLabInstanceBaseURLs returns the base URLs of the gitlab.com instance, when the GitLab application is configured,
+// and of the self-managed GitLab instances of the registry, keyed by the instance ID
+func gitLabInstanceBaseURLs(ctx context.Context, configFile config.Config, gitlabInstancesRepo gitlab_instances.RepositoryInterface) (map[string]string, error) {
+	baseURLs := make(map[string]string)
+	if configFile.Gitlab.AppClientID != "" {
+		baseURLs[gitlabApi.DefaultInstanceID] = gitlabApi.DefaultBaseURL
+	}
+
+	instances, err := gitlabInstancesRepo.GetInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, instance := range instances {
+		baseURLs[instance.InstanceID] = instance.BaseURL
+	}
+
+	return baseURLs, nil
+}
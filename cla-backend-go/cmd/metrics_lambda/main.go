@@ -40,6 +40,7 @@ var (
 
 var awsSession = session.Must(session.NewSession(&aws.Config{}))
 var metricsRepo metrics.Repository
+var metricsHistoryRepo metrics.HistoryRepository
 var stage string
 
 func init() {
@@ -54,6 +55,7 @@ func init() {
 	}
 	pcgRepo := projects_cla_groups.NewRepository(awsSession, stage)
 	metricsRepo = metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, pcgRepo)
+	metricsHistoryRepo = metrics.NewHistoryRepository(awsSession, stage, pcgRepo)
 	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
 	project_service.InitClient(configFile.APIGatewayURL)
 }
@@ -63,6 +65,14 @@ func handler(ctx context.Context, event events.CloudWatchEvent) {
 	if err != nil {
 		log.Fatalf("Unable to save metrics in dynamodb. error = %s", err)
 	}
+
+	// The daily history is maintained by the DynamoDB stream - only seed it when asked to
+	if os.Getenv("METRICS_HISTORY_BACKFILL") == "true" {
+		err = metricsHistoryRepo.BackfillHistory(ctx)
+		if err != nil {
+			log.Fatalf("Unable to backfill the metrics history. error = %s", err)
+		}
+	}
 }
 
 func printBuildInfo() {
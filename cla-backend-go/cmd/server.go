@@ -17,13 +17,20 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/project/repository"
 	"github.com/communitybridge/easycla/cla-backend-go/project/service"
 
+	gitlabRepositoryCheck "github.com/communitybridge/easycla/cla-backend-go/cmd/gitlab_repository_check/handler"
 	gitlab_activity "github.com/communitybridge/easycla/cla-backend-go/v2/gitlab-activity"
 
 	"github.com/go-openapi/strfmt"
 
+	bitbucket_activity "github.com/communitybridge/easycla/cla-backend-go/v2/bitbucket-activity"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bitbucket_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bitbucket_sign"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_instances"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
 
+	bitbucketApi "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
 	gitlab "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	"github.com/communitybridge/easycla/cla-backend-go/keyring"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_sign"
 
 	"github.com/communitybridge/easycla/cla-backend-go/emails"
@@ -57,7 +64,6 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 
-	lfxAuth "github.com/LF-Engineering/lfx-kit/auth"
 	"github.com/communitybridge/easycla/cla-backend-go/docs"
 	v1Repositories "github.com/communitybridge/easycla/cla-backend-go/repositories"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
@@ -91,11 +97,19 @@ import (
 	v2Ops "github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/github"
 	"github.com/communitybridge/easycla/cla-backend-go/health"
+	"github.com/communitybridge/easycla/cla-backend-go/telemetry"
 	"github.com/communitybridge/easycla/cla-backend-go/template"
 	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/api_tokens"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/auditors"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bot_allowlist"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_group_settings"
 	v2ClaManager "github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_verdicts"
 	v2Company "github.com/communitybridge/easycla/cla-backend-go/v2/company"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_deliveries"
 	v2Health "github.com/communitybridge/easycla/cla-backend-go/v2/health"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/repository_requirements"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/store"
 	v2Template "github.com/communitybridge/easycla/cla-backend-go/v2/template"
 
@@ -243,6 +257,13 @@ func server(localMode bool) http.Handler {
 	github.Init(configFile.GitHub.AppID, configFile.GitHub.AppPrivateKey, configFile.GitHub.AccessToken)
 	// initialize gitlab
 	gitlabApp := gitlab.Init(configFile.Gitlab.AppClientID, configFile.Gitlab.AppClientSecret, configFile.Gitlab.AppPrivateKey)
+	gitlabKeys, err := keyring.FromEnvironment(awsSession)
+	if err != nil {
+		logrus.Panic(err)
+	}
+	gitlabApp.SetKeyring(gitlabKeys)
+	// the bitbucket secrets are encrypted with the same keyring
+	bitbucketApi.Init(configFile.Bitbucket.AppClientID, configFile.Bitbucket.AppClientSecret, configFile.Bitbucket.AppPrivateKey).SetKeyring(gitlabKeys)
 
 	// Our backend repository handlers
 	userRepo := user.NewDynamoRepository(awsSession, stage)
@@ -257,10 +278,19 @@ func server(localMode bool) http.Handler {
 	v1ProjectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
 	v1CLAGroupRepo := repository.NewRepository(awsSession, stage, gitV1Repository, gerritRepo, v1ProjectClaGroupRepo)
 	metricsRepo := metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, v1ProjectClaGroupRepo)
+	metricsHistoryRepo := metrics.NewHistoryRepository(awsSession, stage, v1ProjectClaGroupRepo)
 	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
 	gitlabOrganizationRepo := gitlab_organizations.NewRepository(awsSession, stage)
+	bitbucketOrganizationRepo := bitbucket_organizations.NewRepository(awsSession, stage)
+	gitlabInstancesRepo := gitlab_instances.NewRepository(awsSession, stage)
 	claManagerReqRepo := cla_manager.NewRepository(awsSession, stage)
 	storeRepository := store.NewRepository(awsSession, stage)
+	auditorsRepo := auditors.NewRepository(awsSession, stage)
+	claGroupSettingsRepo := cla_group_settings.NewRepository(awsSession, stage)
+	botAllowlistRepo := bot_allowlist.NewRepository(awsSession, stage)
+	repositoryRequirementsRepo := repository_requirements.NewRepository(awsSession, stage)
+	apiTokensRepo := api_tokens.NewRepository(awsSession, stage)
+	githubDeliveriesRepo := github_deliveries.NewRepository(awsSession, stage)
 
 	// Our service layer handlers
 	eventsService := events.NewService(eventsRepo, combinedRepo{
@@ -291,7 +321,7 @@ func server(localMode bool) http.Handler {
 
 	v1ProjectClaGroupService := projects_cla_groups.NewService(v1ProjectClaGroupRepo)
 	usersService := users.NewService(usersRepo, eventsService)
-	healthService := health.New(Version, Commit, Branch, BuildDate)
+	healthService := health.New(Version, Commit, Branch, BuildDate, healthProbes(awsSession, stage, configFile, gitlabInstancesRepo)...)
 	templateService := template.NewService(stage, templateRepo, docraptorClient, awsSession)
 	v1ProjectService := service.NewService(v1CLAGroupRepo, gitV1Repository, gerritRepo, v1ProjectClaGroupRepo, usersRepo)
 	emailTemplateService := emails.NewEmailTemplateService(v1CLAGroupRepo, v1ProjectClaGroupRepo, v1ProjectService, configFile.CorporateConsoleV1URL, configFile.CorporateConsoleV2URL)
@@ -301,21 +331,35 @@ func server(localMode bool) http.Handler {
 	v2CompanyService := v2Company.NewService(v1CompanyService, signaturesRepo, v1CLAGroupRepo, usersRepo, v1CompanyRepo, v1ProjectClaGroupRepo, eventsService)
 
 	v1RepositoriesService := v1Repositories.NewService(gitV1Repository, githubOrganizationsRepo, v1ProjectClaGroupRepo)
-	v2RepositoriesService := v2Repositories.NewService(gitV1Repository, gitV2Repository, v1ProjectClaGroupRepo, githubOrganizationsRepo, gitlabOrganizationRepo, eventsService)
+	gitlabInstancesService := gitlab_instances.NewService(gitlabInstancesRepo, eventsService)
+	v2RepositoriesService := v2Repositories.NewService(gitV1Repository, gitV2Repository, v1ProjectClaGroupRepo, githubOrganizationsRepo, gitlabOrganizationRepo, gitlabInstancesService, bitbucketOrganizationRepo, eventsService, emailService)
 	githubOrganizationsService := github_organizations.NewService(githubOrganizationsRepo, gitV1Repository, v1ProjectClaGroupRepo)
-	gitlabOrganizationsService := gitlab_organizations.NewService(gitlabOrganizationRepo, v2RepositoriesService, v1ProjectClaGroupRepo, storeRepository, usersService, signaturesRepo, v1CompanyRepo)
-	v1SignaturesService := signatures.NewService(signaturesRepo, v1CompanyService, usersService, eventsService, githubOrgValidation, v1RepositoriesService, githubOrganizationsService, v1ProjectService, gitlabApp, configFile.ClaV1ApiURL, configFile.CLALandingPage, configFile.CLALogoURL)
+	gitlabOrganizationsService := gitlab_organizations.NewService(gitlabOrganizationRepo, v2RepositoriesService, v1ProjectClaGroupRepo, storeRepository, usersService, signaturesRepo, v1CompanyRepo, gitlabInstancesService)
+	claGroupSettingsService := cla_group_settings.NewService(claGroupSettingsRepo, eventsService)
+	botAllowlistService := bot_allowlist.NewService(botAllowlistRepo, v1ProjectClaGroupRepo, v1RepositoriesService, eventsService)
+	repositoryRequirementsService := repository_requirements.NewService(repositoryRequirementsRepo, v1RepositoriesService, eventsService)
+	v1SignaturesService := signatures.NewService(signaturesRepo, v1CompanyService, usersService, eventsService, githubOrgValidation, v1RepositoriesService, githubOrganizationsService, v1ProjectService, claGroupSettingsService, botAllowlistService, repositoryRequirementsService, gitlabApp, configFile.ClaV1ApiURL, configFile.CLALandingPage, configFile.CLALogoURL)
 	v2SignatureService := v2Signatures.NewService(awsSession, configFile.SignatureFilesBucket, v1ProjectService, v1CompanyService, v1SignaturesService, v1ProjectClaGroupRepo, signaturesRepo, usersService)
 	v1ClaManagerService := cla_manager.NewService(claManagerReqRepo, v1ProjectClaGroupRepo, v1CompanyService, v1ProjectService, usersService, v1SignaturesService, eventsService, emailTemplateService, configFile.CorporateConsoleV1URL)
 	v2ClaManagerService := v2ClaManager.NewService(emailTemplateService, v1CompanyService, v1ProjectService, v1ClaManagerService, usersService, v1RepositoriesService, v2CompanyService, eventsService, v1ProjectClaGroupRepo)
 	v1ApprovalListService := approval_list.NewService(approvalListRepo, v1ProjectClaGroupRepo, v1ProjectService, usersRepo, v1CompanyRepo, v1CLAGroupRepo, signaturesRepo, emailTemplateService, configFile.CorporateConsoleV2URL, http.DefaultClient)
-	authorizer := auth.NewAuthorizer(authValidator, userRepo)
-	v2MetricsService := metrics.NewService(metricsRepo, v1ProjectClaGroupRepo)
-	gitlabActivityService := gitlab_activity.NewService(gitV1Repository, gitV2Repository, usersRepo, signaturesRepo, v1ProjectClaGroupRepo, v1CompanyRepo, signaturesRepo, gitlabOrganizationsService)
+	apiTokensService := api_tokens.NewService(apiTokensRepo, v1ProjectClaGroupRepo, v1CompanyRepo, eventsService)
+	authorizer := auth.NewAuthorizer(authValidator, userRepo, apiTokensService)
+	v2MetricsService := metrics.NewService(metricsRepo, metricsHistoryRepo, v1ProjectClaGroupRepo)
+	if err = telemetry.Register(metrics.NewTotalCountCollector(metricsRepo)); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to register the business metrics collector")
+	}
+	auditorsService := auditors.NewService(auditorsRepo, v1ProjectClaGroupRepo, eventsService)
+	gitlabProjectReconciler := gitlabRepositoryCheck.NewReconciler(gitlabOrganizationRepo, gitlabOrganizationsService, gitV2Repository, v2RepositoriesService, v1ProjectClaGroupRepo, eventsService)
+	gitlabActivityService := gitlab_activity.NewService(gitV1Repository, gitV2Repository, usersRepo, signaturesRepo, v1ProjectClaGroupRepo, v1CompanyRepo, signaturesRepo, gitlabOrganizationsService, botAllowlistService, repositoryRequirementsService, claGroupSettingsService, gitlabProjectReconciler)
 	gitlabSignService := gitlab_sign.NewService(v2RepositoriesService, usersService, storeRepository, gitlabApp, gitlabOrganizationsService)
+	bitbucketOrganizationsService := bitbucket_organizations.NewService(bitbucketOrganizationRepo, v2RepositoriesService, eventsService)
+	claVerdictsService := cla_verdicts.NewService(usersService, signaturesRepo, v1CompanyRepo, botAllowlistService, gitlabOrganizationsService)
+	bitbucketActivityService := bitbucket_activity.NewService(bitbucketOrganizationsService, v2RepositoriesService, usersRepo, signaturesRepo, v1ProjectClaGroupRepo, v1CompanyRepo, botAllowlistService, claGroupSettingsService)
+	bitbucketSignService := bitbucket_sign.NewService(v2RepositoriesService, bitbucketOrganizationsService, usersService, storeRepository, eventsService)
 	v2GithubOrganizationsService := v2GithubOrganizations.NewService(githubOrganizationsRepo, gitV1Repository, v1ProjectClaGroupRepo, githubOrganizationsService)
 	autoEnableService := dynamo_events.NewAutoEnableService(v1RepositoriesService, gitV1Repository, githubOrganizationsRepo, v1ProjectClaGroupRepo, v1ProjectService)
-	v2GithubActivityService := v2GithubActivity.NewService(gitV1Repository, githubOrganizationsRepo, eventsService, autoEnableService, emailService)
+	v2GithubActivityService := v2GithubActivity.NewService(gitV1Repository, githubOrganizationsRepo, eventsService, autoEnableService, emailService, v1SignaturesService, githubDeliveriesRepo)
 
 	v2ClaGroupService := cla_groups.NewService(v1ProjectService, templateService, v1ProjectClaGroupRepo, v1ClaManagerService, v1SignaturesService, metricsRepo, gerritService, v1RepositoriesService, eventsService)
 	v2SignService := sign.NewService(configFile.ClaV1ApiURL, v1CompanyRepo, v1CLAGroupRepo, v1ProjectClaGroupRepo, v1CompanyService, v2ClaGroupService, configFile.DocuSignPrivateKey)
@@ -329,7 +373,7 @@ func server(localMode bool) http.Handler {
 
 	// Setup security handlers
 	api.OauthSecurityAuth = authorizer.SecurityAuth
-	v2API.LfAuthAuth = lfxAuth.SwaggerAuth
+	v2API.LfAuthAuth = authorizer.LfAuthAuth
 
 	// Setup our API handlers
 	users.Configure(api, usersService, eventsService)
@@ -341,7 +385,7 @@ func server(localMode bool) http.Handler {
 	v2Template.Configure(v2API, templateService, v1ProjectClaGroupService, eventsService)
 	github.Configure(api, configFile.GitHub.ClientID, configFile.GitHub.ClientSecret, configFile.GitHub.AccessToken, sessionStore)
 	signatures.Configure(api, v1SignaturesService, sessionStore, eventsService)
-	v2Signatures.Configure(v2API, v1ProjectService, v1CLAGroupRepo, v1CompanyService, v1SignaturesService, sessionStore, eventsService, v2SignatureService, v1ProjectClaGroupRepo)
+	v2Signatures.Configure(v2API, v1ProjectService, v1CLAGroupRepo, v1CompanyService, v1SignaturesService, sessionStore, eventsService, v2SignatureService, v1ProjectClaGroupRepo, auditorsService)
 	approval_list.Configure(api, v1ApprovalListService, sessionStore, v1SignaturesService, eventsService)
 	v1Company.Configure(api, v1CompanyService, usersService, companyUserValidation, eventsService)
 	docs.Configure(api)
@@ -349,13 +393,23 @@ func server(localMode bool) http.Handler {
 	version.Configure(api, Version, Commit, Branch, BuildDate)
 	v2Version.Configure(v2API, Version, Commit, Branch, BuildDate)
 	events.Configure(api, eventsService)
-	v2Events.Configure(v2API, eventsService, v1CompanyRepo, v1ProjectClaGroupRepo, v1ProjectService)
-	v2Metrics.Configure(v2API, v2MetricsService, v1CompanyRepo)
+	v2Events.Configure(v2API, eventsService, v1CompanyRepo, v1ProjectClaGroupRepo, v1ProjectService, auditorsService)
+	auditors.Configure(v2API, auditorsService, v1ProjectClaGroupRepo)
+	cla_group_settings.Configure(v2API, claGroupSettingsService, v1ProjectClaGroupRepo)
+	bot_allowlist.Configure(v2API, botAllowlistService, v1ProjectClaGroupRepo)
+	repository_requirements.Configure(v2API, repositoryRequirementsService, v1ProjectClaGroupRepo)
+	cla_verdicts.Configure(v2API, claVerdictsService, usersService, v1ProjectClaGroupRepo)
+	api_tokens.Configure(v2API, apiTokensService)
+	v2Metrics.Configure(v2API, v2MetricsService, v1CompanyRepo, v1ProjectClaGroupRepo)
 	github_organizations.Configure(api, githubOrganizationsService, eventsService)
 	v2GithubOrganizations.Configure(v2API, v2GithubOrganizationsService, eventsService)
 	gitlab_organizations.Configure(v2API, gitlabOrganizationsService, eventsService, sessionStore, configFile.CLAContributorv2Base)
+	gitlab_instances.Configure(v2API, gitlabInstancesService)
 	gitlab_sign.Configure(v2API, gitlabSignService, eventsService, configFile.CLAContributorv2Base, sessionStore)
 	gitlab_activity.Configure(v2API, gitlabActivityService, gitlabOrganizationsService, eventsService, gitlabApp, gitlabSignService, configFile.CLAContributorv2Base, sessionStore)
+	bitbucket_organizations.Configure(v2API, bitbucketOrganizationsService)
+	bitbucket_activity.Configure(v2API, bitbucketActivityService, bitbucketOrganizationsService)
+	bitbucket_sign.Configure(v2API, bitbucketSignService, configFile.CLAContributorv2Base, sessionStore)
 	v1Repositories.Configure(api, v1RepositoriesService, eventsService)
 	v2Repositories.Configure(v2API, v2RepositoriesService, eventsService)
 	gerrits.Configure(api, gerritService, v1ProjectService, eventsService)
@@ -376,8 +430,11 @@ func server(localMode bool) http.Handler {
 
 	// The middleware configuration is for the handler executors. These do not apply to the swagger.json document.
 	// The middleware executes after routing but before authentication, binding and validation
-	middlewareSetupfunc := func(handler http.Handler) http.Handler {
-		return setRequestIDHandler(responseLoggingMiddleware(userCreaterMiddleware(handler)))
+	// The builder runs after the swagger router - the instrumentation can label the requests by operation
+	middlewareSetupfunc := func(api string) func(handler http.Handler) http.Handler {
+		return func(handler http.Handler) http.Handler {
+			return setRequestIDHandler(responseLoggingMiddleware(userCreaterMiddleware(telemetry.InstrumentAPI(api, handler))))
+		}
 	}
 
 	v2API.CsvProducer = openapi_runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
@@ -431,19 +488,32 @@ func server(localMode bool) http.Handler {
 		apiHandler = setupCORSHandlerLocal(
 			wrapHandlers(
 				// v1 API => /v3, python side is /v1 and /v2
-				api.Serve(middlewareSetupfunc), swaggerSpec.BasePath(),
+				api.Serve(middlewareSetupfunc("v3")), swaggerSpec.BasePath(),
 				// v2 API => /v4
-				v2API.Serve(middlewareSetupfunc), v2SwaggerSpec.BasePath()))
+				v2API.Serve(middlewareSetupfunc("v4")), v2SwaggerSpec.BasePath()))
 	} else {
 		apiHandler = setupCORSHandler(
 			wrapHandlers(
 				// v1 API => /v3, python side is /v1 and /v2
-				api.Serve(middlewareSetupfunc), swaggerSpec.BasePath(),
+				api.Serve(middlewareSetupfunc("v3")), swaggerSpec.BasePath(),
 				// v2 API => /v4
-				v2API.Serve(middlewareSetupfunc), v2SwaggerSpec.BasePath()),
+				v2API.Serve(middlewareSetupfunc("v4")), v2SwaggerSpec.BasePath()),
 			configFile.AllowedOrigins)
 	}
-	return apiHandler
+
+	// Prometheus scrape endpoint, served outside the swagger APIs - disabled unless a scrape token is configured
+	metricsToken := viper.GetString("PROMETHEUS_METRICS_TOKEN")
+	if metricsToken == "" {
+		log.WithFields(f).Info("PROMETHEUS_METRICS_TOKEN is not set - the metrics endpoint is disabled")
+	}
+	metricsHandler := telemetry.Handler(metricsToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == telemetry.MetricsPath {
+			metricsHandler.ServeHTTP(w, r)
+			return
+		}
+		apiHandler.ServeHTTP(w, r)
+	})
 }
 
 // setupCORSHandler sets up the CORS logic and creates the middleware HTTP handler
@@ -623,6 +693,11 @@ func createUserFromRequest(authorizer auth.Authorizer, usersService users.Servic
 		return
 	}
 
+	// API tokens belong to machine clients - there is no user record to create
+	if auth.IsAPIToken(t[1]) {
+		return
+	}
+
 	// parse user from the auth token
 	claUser, err := authorizer.SecurityAuth(t[1], []string{})
 	if err != nil {
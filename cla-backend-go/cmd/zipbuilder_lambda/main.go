@@ -7,6 +7,7 @@ import (
 	"context"
 	"os"
 
+	"github.com/communitybridge/easycla/cla-backend-go/telemetry"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
 	"github.com/communitybridge/easycla/cla-backend-go/v2/signatures"
@@ -88,6 +89,9 @@ func handler(ctx context.Context, event BuildZipEvent) error {
 	if err != nil {
 		log.WithField("args", event).Error("failed to build zip", err)
 	}
+	if pushErr := telemetry.PushIfConfigured("easycla-zipbuilder"); pushErr != nil {
+		log.WithError(pushErr).Warn("unable to push zip builder metrics")
+	}
 	return err
 }
 
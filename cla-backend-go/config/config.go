@@ -54,6 +54,9 @@ type Config struct {
 	// Gitlab Application
 	Gitlab Gitlab `json:"gitlab"`
 
+	// Bitbucket Application
+	Bitbucket Bitbucket `json:"bitbucket"`
+
 	// Dynamo Session Store
 	SessionStoreTableName string `json:"sessionStoreTableName"`
 
@@ -143,6 +146,7 @@ type GitHub struct {
 	AccessToken                    string `json:"accessToken"`
 	AppID                          int    `json:"app_id"`
 	AppPrivateKey                  string `json:"app_private_key"`
+	AppWebhookSecret               string `json:"app_webhook_secret"`
 	TestOrganization               string `json:"test_organization"`
 	TestOrganizationInstallationID string `json:"test_organization_installation_id"`
 	TestRepository                 string `json:"test_repository"`
@@ -158,6 +162,16 @@ type Gitlab struct {
 	WebHookURI      string `json:"app_web_hook_uri"`
 }
 
+// Bitbucket config data model, the OAuth consumer is the one of Bitbucket Cloud - the Bitbucket Data Center
+// organizations have their own application links
+type Bitbucket struct {
+	AppClientID     string `json:"app_client_id"`
+	AppClientSecret string `json:"app_client_secret"`
+	AppPrivateKey   string `json:"app_client_private_key"`
+	RedirectURI     string `json:"app_redirect_uri"`
+	WebHookURI      string `json:"app_web_hook_uri"`
+}
+
 // MetricsReport keeps the config needed to send the metrics data report
 type MetricsReport struct {
 	AwsSQSRegion   string `json:"aws_sqs_region"`
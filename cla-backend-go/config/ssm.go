@@ -65,6 +65,7 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 		fmt.Sprintf("cla-gh-access-token-%s", stage),
 		fmt.Sprintf("cla-gh-app-id-%s", stage),
 		fmt.Sprintf("cla-gh-app-private-key-%s", stage),
+		fmt.Sprintf("cla-gh-app-webhook-secret-%s", stage),
 		fmt.Sprintf("cla-gh-test-organization-%s", stage),
 		fmt.Sprintf("cla-gh-test-organization-installation-id-%s", stage),
 		fmt.Sprintf("cla-gh-test-repository-%s", stage),
@@ -75,6 +76,11 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 		fmt.Sprintf("cla-gitlab-app-private-key-%s", stage),
 		fmt.Sprintf("cla-gitlab-app-redirect-uri-%s", stage),
 		fmt.Sprintf("cla-gitlab-app-web-hook-uri-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-id-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-secret-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-private-key-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-redirect-uri-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-web-hook-uri-%s", stage),
 		fmt.Sprintf("cla-corporate-base-%s", stage),
 		fmt.Sprintf("cla-corporate-v1-base-%s", stage),
 		fmt.Sprintf("cla-corporate-v2-base-%s", stage),
@@ -151,6 +157,8 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 			config.GitHub.AppID = githubAppID
 		case fmt.Sprintf("cla-gh-app-private-key-%s", stage):
 			config.GitHub.AppPrivateKey = resp.value
+		case fmt.Sprintf("cla-gh-app-webhook-secret-%s", stage):
+			config.GitHub.AppWebhookSecret = resp.value
 		case fmt.Sprintf("cla-gh-test-organization-%s", stage):
 			config.GitHub.TestOrganization = resp.value
 		case fmt.Sprintf("cla-gh-test-organization-installation-id-%s", stage):
@@ -177,6 +185,18 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 			config.Gitlab.RedirectURI = resp.value
 		case fmt.Sprintf("cla-gitlab-app-web-hook-uri-%s", stage):
 			config.Gitlab.WebHookURI = resp.value
+
+		//	bitbucket ssm
+		case fmt.Sprintf("cla-bitbucket-app-id-%s", stage):
+			config.Bitbucket.AppClientID = resp.value
+		case fmt.Sprintf("cla-bitbucket-app-secret-%s", stage):
+			config.Bitbucket.AppClientSecret = resp.value
+		case fmt.Sprintf("cla-bitbucket-app-private-key-%s", stage):
+			config.Bitbucket.AppPrivateKey = resp.value
+		case fmt.Sprintf("cla-bitbucket-app-redirect-uri-%s", stage):
+			config.Bitbucket.RedirectURI = resp.value
+		case fmt.Sprintf("cla-bitbucket-app-web-hook-uri-%s", stage):
+			config.Bitbucket.WebHookURI = resp.value
 		case fmt.Sprintf("cla-contributor-v2-base-%s", stage):
 			config.CLAContributorv2Base = resp.value
 		case fmt.Sprintf("cla-api-v4-base-%s", stage):
@@ -0,0 +1,142 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+// GitLabRepositoryActionTemplateParams is email params for GitLabRepositoryActionTemplate
+type GitLabRepositoryActionTemplateParams struct {
+	CommonEmailParams
+	CLAGroupTemplateParams
+	RepositoryName string
+}
+
+// GitLabRepositoryDeletedTemplateParams is email params for GitLabRepositoryDeletedTemplate
+type GitLabRepositoryDeletedTemplateParams struct {
+	GitLabRepositoryActionTemplateParams
+}
+
+const (
+	// GitLabRepositoryDeletedTemplateName is email template name for GitLabRepositoryDeletedTemplate
+	GitLabRepositoryDeletedTemplateName = "GitLabRepositoryDeletedTemplate"
+	// GitLabRepositoryDeletedTemplate is email template for
+	GitLabRepositoryDeletedTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the GitLab Repository {{.RepositoryName}} associated with the CLA Group {{.CLAGroupName}}.</p>
+<p>EasyCLA was notified that the GitLab Repository {{.RepositoryName}} was deleted from GitLab. It's now removed from EasyCLA platform.</p>
+`
+)
+
+// RenderGitLabRepositoryDeletedTemplate renders GitLabRepositoryDeletedTemplate
+func RenderGitLabRepositoryDeletedTemplate(svc EmailTemplateService, claGroupID string, params GitLabRepositoryDeletedTemplateParams) (string, error) {
+	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromCLAGroup(claGroupID)
+	if err != nil {
+		return "", err
+	}
+
+	// assign the prefilled struct
+	params.CLAGroupTemplateParams = claGroupParams
+	return RenderTemplate(params.CLAGroupTemplateParams.Version, GitLabRepositoryDeletedTemplateName, GitLabRepositoryDeletedTemplate, params)
+}
+
+// GitLabRepositoryArchivedTemplateParams is email params for GitLabRepositoryArchivedTemplate
+type GitLabRepositoryArchivedTemplateParams struct {
+	GitLabRepositoryActionTemplateParams
+}
+
+const (
+	// GitLabRepositoryArchivedTemplateName is email template name for GitLabRepositoryArchivedTemplate
+	GitLabRepositoryArchivedTemplateName = "GitLabRepositoryArchivedTemplate"
+	// GitLabRepositoryArchivedTemplate is email template for
+	GitLabRepositoryArchivedTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the GitLab Repository {{.RepositoryName}} associated with the CLA Group {{.CLAGroupName}}.</p>
+<p>EasyCLA was notified that the GitLab Repository {{.RepositoryName}} was archived from GitLab. The repository stays enrolled on EasyCLA platform, the merge requests are checked again once it is unarchived.</p>
+`
+)
+
+// RenderGitLabRepositoryArchivedTemplate renders GitLabRepositoryArchivedTemplate
+func RenderGitLabRepositoryArchivedTemplate(svc EmailTemplateService, claGroupID string, params GitLabRepositoryArchivedTemplateParams) (string, error) {
+	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromCLAGroup(claGroupID)
+	if err != nil {
+		return "", err
+	}
+
+	// assign the prefilled struct
+	params.CLAGroupTemplateParams = claGroupParams
+	return RenderTemplate(params.CLAGroupTemplateParams.Version, GitLabRepositoryArchivedTemplateName, GitLabRepositoryArchivedTemplate, params)
+}
+
+// GitLabRepositoryRenamedTemplateParams is email params for GitLabRepositoryRenamedTemplate
+type GitLabRepositoryRenamedTemplateParams struct {
+	GitLabRepositoryActionTemplateParams
+	OldRepositoryName string
+	NewRepositoryName string
+}
+
+const (
+	// GitLabRepositoryRenamedTemplateName is email template name for GitLabRepositoryRenamedTemplate
+	GitLabRepositoryRenamedTemplateName = "GitLabRepositoryRenamedTemplate"
+	// GitLabRepositoryRenamedTemplate is email template for
+	GitLabRepositoryRenamedTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the GitLab Repository {{.RepositoryName}} associated with the CLA Group {{.CLAGroupName}}.</p>
+<p>EasyCLA was notified that the GitLab Repository {{.OldRepositoryName}} was renamed to {{.NewRepositoryName}} from GitLab. The change was reflected to EasyCLA platform.</p>
+`
+)
+
+// RenderGitLabRepositoryRenamedTemplate renders GitLabRepositoryRenamedTemplate
+func RenderGitLabRepositoryRenamedTemplate(svc EmailTemplateService, claGroupID string, params GitLabRepositoryRenamedTemplateParams) (string, error) {
+	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromCLAGroup(claGroupID)
+	if err != nil {
+		return "", err
+	}
+
+	// assign the prefilled struct
+	params.CLAGroupTemplateParams = claGroupParams
+	return RenderTemplate(params.CLAGroupTemplateParams.Version, GitLabRepositoryRenamedTemplateName, GitLabRepositoryRenamedTemplate, params)
+}
+
+// GitLabRepositoryTransferredTemplateParams is email params for GitLabRepositoryTransferredTemplate
+type GitLabRepositoryTransferredTemplateParams struct {
+	GitLabRepositoryActionTemplateParams
+	OldNamespace string
+	NewNamespace string
+}
+
+const (
+	// GitLabRepositoryTransferredTemplateName is email template name for GitLabRepositoryTransferredTemplate
+	GitLabRepositoryTransferredTemplateName = "GitLabRepositoryTransferredTemplate"
+	// GitLabRepositoryTransferredTemplate is email template for
+	GitLabRepositoryTransferredTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the GitLab Repository {{.RepositoryName}} associated with the CLA Group {{.CLAGroupName}}.</p>
+<p>EasyCLA was notified that the GitLab Repository {{.RepositoryName}} was transferred from the {{.OldNamespace}} namespace to the {{.NewNamespace}} namespace from GitLab. The change was reflected to EasyCLA platform.</p>
+`
+)
+
+const (
+	// GitLabRepositoryTransferredFailedTemplateName is email template name for GitLabRepositoryTransferredFailedTemplate
+	GitLabRepositoryTransferredFailedTemplateName = "GitLabRepositoryTransferredFailedTemplate"
+	// GitLabRepositoryTransferredFailedTemplate is email template for
+	GitLabRepositoryTransferredFailedTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the GitLab Repository {{.RepositoryName}} associated with the CLA Group {{.CLAGroupName}}.</p>
+<p>EasyCLA was notified that the GitLab Repository {{.RepositoryName}} was transferred from the {{.OldNamespace}} namespace to the {{.NewNamespace}} namespace from GitLab.</p>
+<p>However, we detected that EasyCLA is not enabled for the new namespace {{.NewNamespace}}. The GitLab Repository {{.RepositoryName}} is now disabled from EasyCLA platform.</p>
+`
+)
+
+// RenderGitLabRepositoryTransferredTemplate renders GitLabRepositoryTransferredFailedTemplate or GitLabRepositoryTransferredTemplate
+func RenderGitLabRepositoryTransferredTemplate(svc EmailTemplateService, claGroupID string, params GitLabRepositoryTransferredTemplateParams, success bool) (string, error) {
+	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromCLAGroup(claGroupID)
+	if err != nil {
+		return "", err
+	}
+
+	// assign the prefilled struct
+	params.CLAGroupTemplateParams = claGroupParams
+	if success {
+		return RenderTemplate(params.CLAGroupTemplateParams.Version, GitLabRepositoryTransferredTemplateName, GitLabRepositoryTransferredTemplate, params)
+	}
+	return RenderTemplate(params.CLAGroupTemplateParams.Version, GitLabRepositoryTransferredFailedTemplateName, GitLabRepositoryTransferredFailedTemplate, params)
+}
@@ -0,0 +1,82 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func testGitLabRepositoryActionTemplateParams(repositoryName string) GitLabRepositoryActionTemplateParams {
+	return GitLabRepositoryActionTemplateParams{
+		CommonEmailParams: CommonEmailParams{
+			RecipientName: "CLA Manager",
+		},
+		CLAGroupTemplateParams: CLAGroupTemplateParams{
+			CLAGroupName: "JohnsProject",
+		},
+		RepositoryName: repositoryName,
+	}
+}
+
+func TestGitLabRepositoryDeletedTemplate(t *testing.T) {
+	params := GitLabRepositoryDeletedTemplateParams{
+		GitLabRepositoryActionTemplateParams: testGitLabRepositoryActionTemplateParams("johnsGroup/johnsRepository"),
+	}
+
+	result, err := RenderTemplate(utils.V2, GitLabRepositoryDeletedTemplateName, GitLabRepositoryDeletedTemplate,
+		params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Hello CLA Manager")
+	assert.Contains(t, result, "regarding the GitLab Repository johnsGroup/johnsRepository")
+	assert.Contains(t, result, "associated with the CLA Group JohnsProject")
+	assert.Contains(t, result, "GitLab Repository johnsGroup/johnsRepository was deleted")
+}
+
+func TestGitLabRepositoryArchivedTemplate(t *testing.T) {
+	params := GitLabRepositoryArchivedTemplateParams{
+		GitLabRepositoryActionTemplateParams: testGitLabRepositoryActionTemplateParams("johnsGroup/johnsRepository"),
+	}
+
+	result, err := RenderTemplate(utils.V2, GitLabRepositoryArchivedTemplateName, GitLabRepositoryArchivedTemplate,
+		params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "associated with the CLA Group JohnsProject")
+	assert.Contains(t, result, "GitLab Repository johnsGroup/johnsRepository was archived")
+}
+
+func TestGitLabRepositoryRenamedTemplate(t *testing.T) {
+	params := GitLabRepositoryRenamedTemplateParams{
+		GitLabRepositoryActionTemplateParams: testGitLabRepositoryActionTemplateParams("johnsGroup/johnsNewRepository"),
+		OldRepositoryName:                    "johnsGroup/johnsOldRepository",
+		NewRepositoryName:                    "johnsGroup/johnsNewRepository",
+	}
+
+	result, err := RenderTemplate(utils.V2, GitLabRepositoryRenamedTemplateName, GitLabRepositoryRenamedTemplate,
+		params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "regarding the GitLab Repository johnsGroup/johnsNewRepository")
+	assert.Contains(t, result, "GitLab Repository johnsGroup/johnsOldRepository was renamed to johnsGroup/johnsNewRepository")
+}
+
+func TestGitLabRepositoryTransferredTemplate(t *testing.T) {
+	params := GitLabRepositoryTransferredTemplateParams{
+		GitLabRepositoryActionTemplateParams: testGitLabRepositoryActionTemplateParams("johnsNewGroup/johnsRepository"),
+		OldNamespace:                         "johnsOldGroup",
+		NewNamespace:                         "johnsNewGroup",
+	}
+
+	result, err := RenderTemplate(utils.V2, GitLabRepositoryTransferredTemplateName, GitLabRepositoryTransferredTemplate,
+		params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "GitLab Repository johnsNewGroup/johnsRepository was transferred from the johnsOldGroup namespace to the johnsNewGroup namespace")
+
+	result, err = RenderTemplate(utils.V2, GitLabRepositoryTransferredFailedTemplateName, GitLabRepositoryTransferredFailedTemplate,
+		params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "EasyCLA is not enabled for the new namespace johnsNewGroup")
+	assert.Contains(t, result, "The GitLab Repository johnsNewGroup/johnsRepository is now disabled")
+}
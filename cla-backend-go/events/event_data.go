@@ -5,6 +5,7 @@ package events
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
@@ -54,12 +55,14 @@ type RepositoryDisabledEventData struct {
 type RepositoryDeletedEventData struct {
 	RepositoryName       string
 	RepositoryExternalID int64
+	RepositoryType       string
 }
 
 // RepositoryRenamedEventData event data model
 type RepositoryRenamedEventData struct {
 	NewRepositoryName string
 	OldRepositoryName string
+	RepositoryType    string
 }
 
 // RepositoryTransferredEventData event data model
@@ -67,6 +70,14 @@ type RepositoryTransferredEventData struct {
 	RepositoryName   string
 	OldGithubOrgName string
 	NewGithubOrgName string
+	RepositoryType   string
+}
+
+// RepositoryArchivedEventData event data model
+type RepositoryArchivedEventData struct {
+	RepositoryName string
+	RepositoryType string
+	Archived       bool
 }
 
 // RepositoryUpdatedEventData event data model
@@ -200,6 +211,21 @@ type GitHubOrganizationUpdatedEventData struct {
 	BranchProtectionEnabled bool
 }
 
+// GitHubOrganizationBranchProtectionPolicyUpdatedEventData data model
+type GitHubOrganizationBranchProtectionPolicyUpdatedEventData struct {
+	GitHubOrganizationName string
+	DefaultBranch          bool
+	BranchPatterns         []string
+	EnforceAdmin           bool
+}
+
+// GitHubOrganizationRulesetUpdatedEventData data model
+type GitHubOrganizationRulesetUpdatedEventData struct {
+	GitHubOrganizationName string
+	RulesetID              int64
+	BranchPatterns         []string
+}
+
 // GitLabOrganizationAddedEventData data model
 type GitLabOrganizationAddedEventData struct {
 	GitLabOrganizationName  string
@@ -221,6 +247,76 @@ type GitLabOrganizationUpdatedEventData struct {
 	AutoEnabledClaGroupID  string
 }
 
+// GitLabOrganizationWebhookSecretRotatedEventData data model
+type GitLabOrganizationWebhookSecretRotatedEventData struct {
+	GitLabOrganizationName string
+	GitLabGroupID          int64
+	ProjectsUpdated        int
+	ProjectsFailed         int
+	PreviousSecretExpiry   string
+}
+
+// GitLabSubgroupUpdatedEventData data model
+type GitLabSubgroupUpdatedEventData struct {
+	GitLabOrganizationName string
+	GitLabGroupID          int64
+	SubgroupFullPath       string
+	SubgroupID             int64
+	ClaGroupID             string
+	// AutoEnabled is empty when the subgroup inherits the auto-enabled flag
+	AutoEnabled string
+}
+
+// GitLabSubgroupChangedEventData data model
+type GitLabSubgroupChangedEventData struct {
+	GitLabOrganizationName string
+	GitLabGroupID          int64
+	SubgroupID             int64
+	// Change is one of renamed, moved or removed
+	Change           string
+	PreviousFullPath string
+	FullPath         string
+}
+
+// GitLabInstanceAddedEventData data model
+type GitLabInstanceAddedEventData struct {
+	InstanceID string
+	BaseURL    string
+}
+
+// GitLabInstanceUpdatedEventData data model
+type GitLabInstanceUpdatedEventData struct {
+	InstanceID string
+	BaseURL    string
+}
+
+// GitLabInstanceDeletedEventData data model
+type GitLabInstanceDeletedEventData struct {
+	InstanceID string
+	BaseURL    string
+}
+
+// BitbucketOrganizationAddedEventData data model
+type BitbucketOrganizationAddedEventData struct {
+	OrganizationKey       string
+	Flavor                string
+	AutoEnabled           bool
+	AutoEnabledClaGroupID string
+}
+
+// BitbucketOrganizationUpdatedEventData data model
+type BitbucketOrganizationUpdatedEventData struct {
+	OrganizationKey       string
+	AutoEnabled           bool
+	AutoEnabledClaGroupID string
+	AccessTokenUpdated    bool
+}
+
+// BitbucketOrganizationDeletedEventData data model
+type BitbucketOrganizationDeletedEventData struct {
+	OrganizationKey string
+}
+
 // CCLAApprovalListRequestCreatedEventData data model
 type CCLAApprovalListRequestCreatedEventData struct {
 	RequestID string
@@ -445,6 +541,119 @@ type SignatureAutoCreateECLAUpdatedEventData struct {
 	AutoCreateECLA bool
 }
 
+// AuditorAddedEventData data model
+type AuditorAddedEventData struct {
+	AuditorLFUsername string
+	ScopeType         string
+	ScopeID           string
+}
+
+// AuditorDeletedEventData data model
+type AuditorDeletedEventData struct {
+	AuditorLFUsername string
+	ScopeType         string
+	ScopeID           string
+}
+
+// AuditorAccessedEventData data model
+type AuditorAccessedEventData struct {
+	Resource  string
+	ScopeType string
+	ScopeID   string
+}
+
+// APITokenCreatedEventData data model
+type APITokenCreatedEventData struct {
+	TokenID   string
+	TokenName string
+	ScopeType string
+	ScopeID   string
+}
+
+// APITokenRevokedEventData data model
+type APITokenRevokedEventData struct {
+	TokenID   string
+	TokenName string
+	ScopeType string
+	ScopeID   string
+}
+
+// APITokenUsedEventData data model
+type APITokenUsedEventData struct {
+	TokenID   string
+	TokenName string
+	ScopeType string
+	ScopeID   string
+}
+
+// ClaGroupSettingsUpdatedEventData data model
+type ClaGroupSettingsUpdatedEventData struct {
+	RequireAuthors    bool
+	RequireCommitters bool
+	RequireCoAuthors  bool
+}
+
+// ClaGroupCommentTemplateUpdatedEventData data model
+type ClaGroupCommentTemplateUpdatedEventData struct {
+	CustomComment   bool
+	CustomBadge     bool
+	CollapseSigned  bool
+	CollapseMissing bool
+}
+
+// ClaGroupCommentTemplateDeletedEventData data model
+type ClaGroupCommentTemplateDeletedEventData struct{}
+
+// BotAllowlistEntryAddedEventData data model
+type BotAllowlistEntryAddedEventData struct {
+	MatchType string
+	Value     string
+	ScopeType string
+	ScopeID   string
+}
+
+// BotAllowlistEntryDeletedEventData data model
+type BotAllowlistEntryDeletedEventData struct {
+	MatchType string
+	Value     string
+	ScopeType string
+	ScopeID   string
+}
+
+// BotAllowlistExemptionEventData data model
+type BotAllowlistExemptionEventData struct {
+	Provider        string
+	RepositoryName  string
+	ChangeRequestID int
+	Username        string
+	Email           string
+	MatchType       string
+	ScopeType       string
+	ScopeID         string
+}
+
+// RepositoryRequirementsUpdatedEventData data model
+type RepositoryRequirementsUpdatedEventData struct {
+	RepositoryName string
+	PathExemptions []string
+	TargetBranches []string
+	MinChangeSize  int64
+}
+
+// RepositoryRequirementsDeletedEventData data model
+type RepositoryRequirementsDeletedEventData struct {
+	RepositoryName string
+}
+
+// RepositoryRequirementsCheckSkippedEventData data model
+type RepositoryRequirementsCheckSkippedEventData struct {
+	Provider        string
+	RepositoryName  string
+	ChangeRequestID int
+	Rule            string
+	Reason          string
+}
+
 // GetEventDetailsString returns the details string for this event
 func (ed *SignatureAutoCreateECLAUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 
@@ -556,7 +765,7 @@ func (ed *RepositoryDisabledEventData) GetEventDetailsString(args *LogEventArgs)
 
 // GetEventDetailsString returns the details string for this event
 func (ed *RepositoryDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
-	data := "The GitHub repository " // nolint
+	data := fmt.Sprintf("The %s repository", repositoryTypeName(ed.RepositoryType)) // nolint
 	if args.CLAGroupName != "" {
 		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
 	}
@@ -579,7 +788,7 @@ func (ed *RepositoryDeletedEventData) GetEventDetailsString(args *LogEventArgs)
 
 // GetEventDetailsString returns the details string for this event
 func (ed *RepositoryRenamedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
-	data := fmt.Sprintf("The GitHub repository renamed from %s to %s for the project %s", ed.OldRepositoryName, ed.NewRepositoryName, args.ProjectName)
+	data := fmt.Sprintf("The %s repository renamed from %s to %s for the project %s", repositoryTypeName(ed.RepositoryType), ed.OldRepositoryName, ed.NewRepositoryName, args.ProjectName)
 	if args.UserName != "" {
 		data = data + fmt.Sprintf(" by the user %s", args.UserName)
 	}
@@ -590,6 +799,23 @@ func (ed *RepositoryRenamedEventData) GetEventDetailsString(args *LogEventArgs)
 // GetEventDetailsString returns the details string for this event
 func (ed *RepositoryTransferredEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The GitHub repository : %s transferred from %s to %s Github Organization for the project %s", ed.RepositoryName, ed.OldGithubOrgName, ed.NewGithubOrgName, args.ProjectName)
+	if ed.RepositoryType == utils.GitLabRepositoryType {
+		data = fmt.Sprintf("The GitLab repository : %s transferred from the %s namespace to the %s namespace for the project %s", ed.RepositoryName, ed.OldGithubOrgName, ed.NewGithubOrgName, args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *RepositoryArchivedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	action := "archived"
+	if !ed.Archived {
+		action = "unarchived"
+	}
+	data := fmt.Sprintf("The %s repository %s was %s for the project %s", repositoryTypeName(ed.RepositoryType), ed.RepositoryName, action, args.ProjectName)
 	if args.UserName != "" {
 		data = data + fmt.Sprintf(" by the user %s", args.UserName)
 	}
@@ -761,6 +987,40 @@ func (ed *GitHubOrganizationDeletedEventData) GetEventDetailsString(args *LogEve
 	return data, true
 }
 
+// GetEventDetailsString returns the details string for this event
+func (ed *GitHubOrganizationBranchProtectionPolicyUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The branch protection policy of the GitHub Organization '%s' was updated", ed.GitHubOrganizationName)
+	if !ed.DefaultBranch && len(ed.BranchPatterns) == 0 {
+		data = data + " to no protected branches"
+	} else {
+		var branches []string
+		if ed.DefaultBranch {
+			branches = append(branches, "the default branch")
+		}
+		branches = append(branches, ed.BranchPatterns...)
+		data = data + fmt.Sprintf(" to require EasyCLA on %s", strings.Join(branches, ", "))
+		data = data + fmt.Sprintf(" with enforce admin set to %t", ed.EnforceAdmin)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *GitHubOrganizationRulesetUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The EasyCLA ruleset %d of the GitHub Organization '%s' was updated to require EasyCLA on %s", ed.RulesetID, ed.GitHubOrganizationName, strings.Join(ed.BranchPatterns, ", "))
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventDetailsString returns the details string for this event
 func (ed *GitHubOrganizationUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The GitHub Organization '%s' was updated", ed.GitHubOrganizationName)
@@ -829,6 +1089,137 @@ func (ed *GitLabOrganizationUpdatedEventData) GetEventDetailsString(args *LogEve
 	return data, true
 }
 
+// GetEventDetailsString returns the details string for this event
+func (ed *GitLabOrganizationWebhookSecretRotatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The webhook secret of the GitLab Group %s with group ID: %d was rotated - %d project webhooks were updated, %d failed - the previous secret is accepted until %s",
+		ed.GitLabOrganizationName, ed.GitLabGroupID, ed.ProjectsUpdated, ed.ProjectsFailed, ed.PreviousSecretExpiry)
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *GitLabSubgroupUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The settings of the subgroup %s with group ID: %d of the GitLab Group %s with group ID: %d were updated", ed.SubgroupFullPath, ed.SubgroupID, ed.GitLabOrganizationName, ed.GitLabGroupID)
+	if ed.ClaGroupID != "" {
+		data = data + fmt.Sprintf(" with cla-group: %s", ed.ClaGroupID)
+	} else {
+		data = data + " with the cla-group of the parent"
+	}
+	if ed.AutoEnabled != "" {
+		data = data + fmt.Sprintf(" with auto-enabled: %s", ed.AutoEnabled)
+	} else {
+		data = data + " with the auto-enabled flag of the parent"
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *GitLabSubgroupChangedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	var data string
+	switch ed.Change {
+	case "removed":
+		data = fmt.Sprintf("The subgroup %s with group ID: %d was removed from the GitLab Group %s with group ID: %d, its settings were dropped", ed.PreviousFullPath, ed.SubgroupID, ed.GitLabOrganizationName, ed.GitLabGroupID)
+	default:
+		data = fmt.Sprintf("The subgroup %s with group ID: %d of the GitLab Group %s with group ID: %d was %s to %s", ed.PreviousFullPath, ed.SubgroupID, ed.GitLabOrganizationName, ed.GitLabGroupID, ed.Change, ed.FullPath)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *GitLabInstanceAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The GitLab instance %s with base URL %s was added", ed.InstanceID, ed.BaseURL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *GitLabInstanceUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The GitLab instance %s with base URL %s was updated", ed.InstanceID, ed.BaseURL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *GitLabInstanceDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The GitLab instance %s with base URL %s was deleted", ed.InstanceID, ed.BaseURL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *BitbucketOrganizationAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Bitbucket %s organization: %s was added with auto-enabled: %t", ed.Flavor, ed.OrganizationKey, ed.AutoEnabled)
+	if ed.AutoEnabledClaGroupID != "" {
+		data = data + fmt.Sprintf(" with auto-enabled-cla-group: %s", ed.AutoEnabledClaGroupID)
+	}
+	if args.ProjectSFID != "" {
+		data = data + fmt.Sprintf(" for the project SFID %s", args.ProjectSFID)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *BitbucketOrganizationUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Bitbucket organization: %s was updated with auto-enabled: %t", ed.OrganizationKey, ed.AutoEnabled)
+	if ed.AutoEnabledClaGroupID != "" {
+		data = data + fmt.Sprintf(" with auto-enabled-cla-group: %s", ed.AutoEnabledClaGroupID)
+	}
+	if ed.AccessTokenUpdated {
+		data = data + " with a new access token"
+	}
+	if args.ProjectSFID != "" {
+		data = data + fmt.Sprintf(" for the project SFID %s", args.ProjectSFID)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *BitbucketOrganizationDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Bitbucket organization: %s was deleted", ed.OrganizationKey)
+	if args.ProjectSFID != "" {
+		data = data + fmt.Sprintf(" for the project SFID %s", args.ProjectSFID)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventDetailsString returns the details string for this event
 func (ed *CCLAApprovalListRequestApprovedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s approved a CCLA Approval Request for Project: %s and Company: %s with Request ID: %s.",
@@ -1474,11 +1865,9 @@ func (ed *ClaManagerRoleDeletedData) GetEventDetailsString(args *LogEventArgs) (
 	return data, false
 }
 
-// Event Summary started
-
-// GetEventSummaryString returns the summary string for this event
-func (ed *CLAGroupEnrolledProjectData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
-	data := fmt.Sprintf("The project %s was enrolled into the CLA Group %s", args.ProjectName, args.CLAGroupName)
+// GetEventDetailsString returns the details string for this event
+func (ed *AuditorAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The user %s was added as an auditor for the %s %s", ed.AuditorLFUsername, auditorScopeName(ed.ScopeType), ed.ScopeID)
 	if args.UserName != "" {
 		data = data + fmt.Sprintf(" by the user %s", args.UserName)
 	}
@@ -1486,9 +1875,9 @@ func (ed *CLAGroupEnrolledProjectData) GetEventSummaryString(args *LogEventArgs)
 	return data, true
 }
 
-// GetEventSummaryString returns the summary string for this event
-func (ed *CLAGroupUnenrolledProjectData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
-	data := fmt.Sprintf("The project %s was unenrolled from the CLA Group %s", args.ProjectName, args.CLAGroupName)
+// GetEventDetailsString returns the details string for this event
+func (ed *AuditorDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The user %s was removed as an auditor for the %s %s", ed.AuditorLFUsername, auditorScopeName(ed.ScopeType), ed.ScopeID)
 	if args.UserName != "" {
 		data = data + fmt.Sprintf(" by the user %s", args.UserName)
 	}
@@ -1496,18 +1885,16 @@ func (ed *CLAGroupUnenrolledProjectData) GetEventSummaryString(args *LogEventArg
 	return data, true
 }
 
-// GetEventSummaryString returns the summary string for this event
-func (ed *ProjectServiceCLAEnabledData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
-	data := "CLA Service was enabled"
-	if args.CLAGroupName != "" {
-		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
-	}
-	if args.ProjectName != "" {
-		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
-	}
-	if args.CompanyName != "" {
-		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
-	}
+// GetEventDetailsString returns the details string for this event
+func (ed *AuditorAccessedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The auditor %s accessed %s for the %s %s", args.LfUsername, ed.Resource, auditorScopeName(ed.ScopeType), ed.ScopeID)
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *APITokenCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The API token %s (%s) was created for the %s %s", ed.TokenName, ed.TokenID, ed.ScopeType, ed.ScopeID)
 	if args.UserName != "" {
 		data = data + fmt.Sprintf(" by the user %s", args.UserName)
 	}
@@ -1515,18 +1902,9 @@ func (ed *ProjectServiceCLAEnabledData) GetEventSummaryString(args *LogEventArgs
 	return data, true
 }
 
-// GetEventSummaryString returns the summary string for this event
-func (ed *ProjectServiceCLADisabledData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
-	data := "CLA Service was disabled"
-	if args.CLAGroupName != "" {
-		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
-	}
-	if args.ProjectName != "" {
-		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
-	}
-	if args.CompanyName != "" {
-		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
-	}
+// GetEventDetailsString returns the details string for this event
+func (ed *APITokenRevokedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The API token %s (%s) for the %s %s was revoked", ed.TokenName, ed.TokenID, ed.ScopeType, ed.ScopeID)
 	if args.UserName != "" {
 		data = data + fmt.Sprintf(" by the user %s", args.UserName)
 	}
@@ -1534,22 +1912,175 @@ func (ed *ProjectServiceCLADisabledData) GetEventSummaryString(args *LogEventArg
 	return data, true
 }
 
-// GetEventSummaryString returns the summary string for this event
-func (ed *RepositoryAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
-	repositoryType := utils.GitHubRepositoryType
-	if ed.RepositoryType != "" {
-		repositoryType = ed.RepositoryType
-	}
-	data := fmt.Sprintf("The %s repository %s was added", repositoryType, ed.RepositoryName)
-	if args.CLAGroupName != "" {
-		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
-	}
-	if args.ProjectName != "" {
-		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
-	}
-	if args.CompanyName != "" {
-		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
-	}
+// GetEventDetailsString returns the details string for this event
+func (ed *APITokenUsedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The API token %s (%s) for the %s %s was used to authenticate a request.", ed.TokenName, ed.TokenID, ed.ScopeType, ed.ScopeID)
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *ClaGroupSettingsUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The settings of the CLA Group %s were updated - require authors: %t, require committers: %t, require co-authors: %t",
+		args.CLAGroupID, ed.RequireAuthors, ed.RequireCommitters, ed.RequireCoAuthors)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *ClaGroupCommentTemplateUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The comment template of the CLA Group %s was updated - custom comment: %t, custom badge: %t, collapse signed: %t, collapse missing: %t",
+		args.CLAGroupID, ed.CustomComment, ed.CustomBadge, ed.CollapseSigned, ed.CollapseMissing)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *ClaGroupCommentTemplateDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The comment template of the CLA Group %s was removed", args.CLAGroupID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *BotAllowlistEntryAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s entry %s was added to the bot allowlist of the %s %s", ed.MatchType, ed.Value, ed.ScopeType, ed.ScopeID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *BotAllowlistEntryDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s entry %s was removed from the bot allowlist of the %s %s", ed.MatchType, ed.Value, ed.ScopeType, ed.ScopeID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *BotAllowlistExemptionEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s user %s (%s) was exempted from the CLA check of the change request %d in the repository %s by the %s entry of the %s %s bot allowlist.",
+		ed.Provider, ed.Username, ed.Email, ed.ChangeRequestID, ed.RepositoryName, ed.MatchType, ed.ScopeType, ed.ScopeID)
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *RepositoryRequirementsUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The requirements of the repository %s were updated with the path exemptions [%s], the target branches [%s] and the minimum change size %d",
+		ed.RepositoryName, strings.Join(ed.PathExemptions, ", "), strings.Join(ed.TargetBranches, ", "), ed.MinChangeSize)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *RepositoryRequirementsDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The requirements of the repository %s were removed", ed.RepositoryName)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *RepositoryRequirementsCheckSkippedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The CLA check of the %s change request %d in the repository %s was not required by the %s rule of the repository requirements: %s.",
+		ed.Provider, ed.ChangeRequestID, ed.RepositoryName, ed.Rule, ed.Reason)
+	return data, true
+}
+
+// Event Summary started
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLAGroupEnrolledProjectData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The project %s was enrolled into the CLA Group %s", args.ProjectName, args.CLAGroupName)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLAGroupUnenrolledProjectData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The project %s was unenrolled from the CLA Group %s", args.ProjectName, args.CLAGroupName)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *ProjectServiceCLAEnabledData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := "CLA Service was enabled"
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *ProjectServiceCLADisabledData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := "CLA Service was disabled"
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *RepositoryAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	repositoryType := utils.GitHubRepositoryType
+	if ed.RepositoryType != "" {
+		repositoryType = ed.RepositoryType
+	}
+	data := fmt.Sprintf("The %s repository %s was added", repositoryType, ed.RepositoryName)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
 	if args.UserName != "" {
 		data = data + fmt.Sprintf(" by the user %s", args.UserName)
 	}
@@ -1589,7 +2120,7 @@ func (ed *RepositoryDisabledEventData) GetEventSummaryString(args *LogEventArgs)
 
 // GetEventSummaryString returns the summary string for this event
 func (ed *RepositoryDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
-	data := "The GitHub repository " // nolint
+	data := fmt.Sprintf("The %s repository", repositoryTypeName(ed.RepositoryType)) // nolint
 	if args.CLAGroupName != "" {
 		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
 	}
@@ -1615,7 +2146,7 @@ func (ed *RepositoryDeletedEventData) GetEventSummaryString(args *LogEventArgs)
 
 // GetEventSummaryString returns the summary string for this event
 func (ed *RepositoryRenamedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
-	data := fmt.Sprintf("The GitHub repository was renamed from %s to %s", ed.OldRepositoryName, ed.NewRepositoryName)
+	data := fmt.Sprintf("The %s repository was renamed from %s to %s", repositoryTypeName(ed.RepositoryType), ed.OldRepositoryName, ed.NewRepositoryName)
 	if args.CLAGroupName != "" {
 		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
 	}
@@ -1635,6 +2166,9 @@ func (ed *RepositoryRenamedEventData) GetEventSummaryString(args *LogEventArgs)
 // GetEventSummaryString returns the summary string for this event
 func (ed *RepositoryTransferredEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The GitHub repository : %s was transferred from %s to %s Github Organization", ed.RepositoryName, ed.OldGithubOrgName, ed.NewGithubOrgName)
+	if ed.RepositoryType == utils.GitLabRepositoryType {
+		data = fmt.Sprintf("The GitLab repository : %s was transferred from the %s namespace to the %s namespace", ed.RepositoryName, ed.OldGithubOrgName, ed.NewGithubOrgName)
+	}
 	if args.CLAGroupName != "" {
 		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
 	}
@@ -1651,6 +2185,26 @@ func (ed *RepositoryTransferredEventData) GetEventSummaryString(args *LogEventAr
 	return data, true
 }
 
+// GetEventSummaryString returns the summary string for this event
+func (ed *RepositoryArchivedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	action := "archived"
+	if !ed.Archived {
+		action = "unarchived"
+	}
+	data := fmt.Sprintf("The %s repository %s was %s", repositoryTypeName(ed.RepositoryType), ed.RepositoryName, action)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventSummaryString returns the summary string for this event
 func (ed *RepositoryUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The GitHub repository %s was updated", ed.RepositoryName)
@@ -1850,6 +2404,40 @@ func (ed *GitHubOrganizationDeletedEventData) GetEventSummaryString(args *LogEve
 	return data, true
 }
 
+// GetEventSummaryString returns the summary string for this event
+func (ed *GitHubOrganizationBranchProtectionPolicyUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The branch protection policy of the GitHub Organization '%s' was updated", ed.GitHubOrganizationName)
+	if !ed.DefaultBranch && len(ed.BranchPatterns) == 0 {
+		data = data + " to no protected branches"
+	} else {
+		var branches []string
+		if ed.DefaultBranch {
+			branches = append(branches, "the default branch")
+		}
+		branches = append(branches, ed.BranchPatterns...)
+		data = data + fmt.Sprintf(" to require EasyCLA on %s", strings.Join(branches, ", "))
+		data = data + fmt.Sprintf(" with enforce admin set to %t", ed.EnforceAdmin)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *GitHubOrganizationRulesetUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The EasyCLA ruleset of the GitHub Organization '%s' was updated to require EasyCLA on %s", ed.GitHubOrganizationName, strings.Join(ed.BranchPatterns, ", "))
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventSummaryString returns the summary string for this event
 func (ed *GitHubOrganizationUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The GitHub Organization '%s' was updated", ed.GitHubOrganizationName)
@@ -1927,6 +2515,117 @@ func (ed *GitLabOrganizationUpdatedEventData) GetEventSummaryString(args *LogEve
 	return data, true
 }
 
+// GetEventSummaryString returns the summary string for this event
+func (ed *GitLabOrganizationWebhookSecretRotatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The webhook secret of the GitLab group %s was rotated", ed.GitLabOrganizationName)
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *GitLabSubgroupUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The settings of the subgroup %s of the GitLab group %s were updated", ed.SubgroupFullPath, ed.GitLabOrganizationName)
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *GitLabSubgroupChangedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	var data string
+	switch ed.Change {
+	case "removed":
+		data = fmt.Sprintf("The subgroup %s was removed from the GitLab group %s", ed.PreviousFullPath, ed.GitLabOrganizationName)
+	default:
+		data = fmt.Sprintf("The subgroup %s of the GitLab group %s was %s to %s", ed.PreviousFullPath, ed.GitLabOrganizationName, ed.Change, ed.FullPath)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *GitLabInstanceAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The GitLab instance %s was added", ed.BaseURL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *GitLabInstanceUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The GitLab instance %s was updated", ed.BaseURL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *GitLabInstanceDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The GitLab instance %s was deleted", ed.BaseURL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *BitbucketOrganizationAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The Bitbucket organization %s was added with auto-enabled set to %t", ed.OrganizationKey, ed.AutoEnabled)
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *BitbucketOrganizationUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The Bitbucket organization %s was updated with auto-enabled set to %t", ed.OrganizationKey, ed.AutoEnabled)
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *BitbucketOrganizationDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The Bitbucket organization %s was deleted", ed.OrganizationKey)
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventSummaryString returns the summary string for this event
 func (ed *CCLAApprovalListRequestApprovedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The user %s approved a CCLA approval request", args.UserName)
@@ -2725,3 +3424,222 @@ func (ed *SignatureAutoCreateECLAUpdatedEventData) GetEventSummaryString(args *L
 	data = data + "."
 	return data, false
 }
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *AuditorAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The user %s was added as an auditor", ed.AuditorLFUsername)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *AuditorDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The user %s was removed as an auditor", ed.AuditorLFUsername)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *AuditorAccessedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The auditor %s accessed %s", args.LfUsername, ed.Resource)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// auditorScopeName returns a readable name for the auditor scope type
+func auditorScopeName(scopeType string) string {
+	if scopeType == "cla_group" {
+		return "CLA Group"
+	}
+	return scopeType
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *APITokenCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The API token %s was created", ed.TokenName)
+	data = data + apiTokenScopeSummary(args)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *APITokenRevokedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The API token %s was revoked", ed.TokenName)
+	data = data + apiTokenScopeSummary(args)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *APITokenUsedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The API token %s was used to authenticate a request", ed.TokenName)
+	data = data + apiTokenScopeSummary(args)
+	data = data + "."
+	return data, true
+}
+
+// apiTokenScopeSummary returns the scope portion of the API token event summary
+func apiTokenScopeSummary(args *LogEventArgs) string {
+	if args.CLAGroupName != "" {
+		return fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.CompanyName != "" {
+		return fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	return ""
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *ClaGroupSettingsUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := "The CLA Group settings were updated"
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *BotAllowlistEntryAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s entry %s was added to the bot allowlist", ed.MatchType, ed.Value)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *BotAllowlistEntryDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s entry %s was removed from the bot allowlist", ed.MatchType, ed.Value)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *BotAllowlistExemptionEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The user %s was exempted from the CLA check in the repository %s", ed.Username, ed.RepositoryName)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *RepositoryRequirementsUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The requirements of the repository %s were updated", ed.RepositoryName)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *RepositoryRequirementsDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The requirements of the repository %s were removed", ed.RepositoryName)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *RepositoryRequirementsCheckSkippedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The CLA check of the change request %d in the repository %s was not required: %s", ed.ChangeRequestID, ed.RepositoryName, ed.Reason)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *ClaGroupCommentTemplateUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := "The pull request comment template was updated"
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *ClaGroupCommentTemplateDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := "The pull request comment template was removed"
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// repositoryTypeName returns the repository type of the repository events, GitHub when not set
+func repositoryTypeName(repositoryType string) string {
+	if repositoryType == "" {
+		return utils.GitHubRepositoryType
+	}
+	return repositoryType
+}
@@ -37,6 +37,8 @@ const (
 	RepositoryDisabled                 = "repository.disabled"
 	RepositoryDeleted                  = "repository.deleted"
 	RepositoryUpdated                  = "repository.updated"
+	RepositoryArchived                 = "repository.archived"
+	RepositoryUnarchived               = "repository.unarchived"
 	RepositoryBranchProtectionAdded    = "repository.branchprotection.updated"
 	RepositoryBranchProtectionDisabled = "repository.branchprotection.updated"
 	RepositoryBranchProtectionUpdated  = "repository.branchprotection.updated"
@@ -50,10 +52,25 @@ const (
 	GitHubOrganizationDeleted = "github_organization.deleted"
 	GitHubOrganizationUpdated = "github_organization.updated"
 
+	GitHubOrganizationBranchProtectionPolicyUpdated = "github_organization.branch_protection_policy.updated"
+	GitHubOrganizationRulesetUpdated                = "github_organization.ruleset.updated"
+
 	GitlabOrganizationAdded   = "gitlab_organization.added"
 	GitlabOrganizationDeleted = "gitlab_organization.deleted"
 	GitlabOrganizationUpdated = "gitlab_organization.updated"
 
+	GitLabOrganizationWebhookSecretRotated = "gitlab_organization.webhook_secret.rotated"
+	GitLabSubgroupUpdated                  = "gitlab_organization.subgroup.updated"
+	GitLabSubgroupChanged                  = "gitlab_organization.subgroup.changed"
+
+	GitLabInstanceAdded   = "gitlab_instance.added"
+	GitLabInstanceUpdated = "gitlab_instance.updated"
+	GitLabInstanceDeleted = "gitlab_instance.deleted"
+
+	BitbucketOrganizationAdded   = "bitbucket_organization.added"
+	BitbucketOrganizationUpdated = "bitbucket_organization.updated"
+	BitbucketOrganizationDeleted = "bitbucket_organization.deleted"
+
 	CompanyACLUserAdded       = "company_acl.user_added"
 	CompanyACLRequestAdded    = "company_acl.request_added"
 	CompanyACLRequestApproved = "company_acl.request_approved"
@@ -96,4 +113,24 @@ const (
 	ProjectServiceCLAEnabled       = "project.service.cla.enabled"
 	ProjectServiceCLADisabled      = "project.service.cla.disabled"
 	SignatureAutoCreateECLAUpdated = "signature.auto_create_ecla.updated"
+
+	AuditorAdded    = "auditor.added"
+	AuditorDeleted  = "auditor.deleted"
+	AuditorAccessed = "auditor.accessed"
+
+	APITokenCreated = "api_token.created"
+	APITokenRevoked = "api_token.revoked"
+	APITokenUsed    = "api_token.used"
+
+	ClaGroupSettingsUpdated        = "cla_group.settings.updated"
+	ClaGroupCommentTemplateUpdated = "cla_group.comment_template.updated"
+	ClaGroupCommentTemplateDeleted = "cla_group.comment_template.deleted"
+
+	BotAllowlistEntryAdded   = "bot_allowlist.entry.added"
+	BotAllowlistEntryDeleted = "bot_allowlist.entry.deleted"
+	BotAllowlistExemption    = "bot_allowlist.exemption"
+
+	RepositoryRequirementsUpdated      = "repository_requirements.updated"
+	RepositoryRequirementsDeleted      = "repository_requirements.deleted"
+	RepositoryRequirementsCheckSkipped = "repository_requirements.check_skipped"
 )
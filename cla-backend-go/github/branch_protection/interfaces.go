@@ -30,3 +30,14 @@ type CombinedRepository interface {
 	V3Repositories
 	V4BranchProtectionRepository
 }
+
+// RulesetRepository has the v3 (REST) repository and organization rulesets functionality - not available in the
+// go-github version we use
+type RulesetRepository interface {
+	GetRulesForBranch(ctx context.Context, owner, repo, branch string) ([]*BranchRule, error)
+	ListRepositoryRulesets(ctx context.Context, owner, repo string) ([]*Ruleset, error)
+	ListOrganizationRulesets(ctx context.Context, org string) ([]*Ruleset, error)
+	GetOrganizationRuleset(ctx context.Context, org string, rulesetID int64) (*Ruleset, error)
+	CreateOrganizationRuleset(ctx context.Context, org string, ruleset *Ruleset) (*Ruleset, error)
+	UpdateOrganizationRuleset(ctx context.Context, org string, rulesetID int64, ruleset *Ruleset) (*Ruleset, error)
+}
@@ -0,0 +1,52 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+)
+
+// MergeQueueStatusChecks are the status checks the merge queue of a branch requires so the merge groups are not merged
+// without the EasyCLA status
+var MergeQueueStatusChecks = []string{github.CheckRunName}
+
+// EnableMergeQueueStatusCheck adds the EasyCLA status to the required checks of the given branch, which the merge queue
+// of the branch enforces on its merge groups. The existing checks and the enforce admin option are preserved.
+func (bp *BranchProtectionRepository) EnableMergeQueueStatusCheck(ctx context.Context, owner, repoName, branchName string) error {
+	enforceAdmin := false
+	protection, err := bp.GetProtectedBranch(ctx, owner, repoName, branchName)
+	if err != nil && !errors.Is(err, ErrBranchNotProtected) {
+		return err
+	}
+	if protection != nil {
+		enforceAdmin = IsEnforceAdminEnabled(protection)
+	}
+
+	return bp.EnableBranchProtection(ctx, owner, repoName, branchName, enforceAdmin, MergeQueueStatusChecks, nil)
+}
+
+// IsMergeQueueStatusCheckEnabled checks if the EasyCLA status is required by the branch protection, and so by the merge
+// queue of the branch
+func IsMergeQueueStatusCheckEnabled(protection *BranchProtectionRule) bool {
+	if protection == nil || !protection.RequiresStatusChecks {
+		return false
+	}
+
+	for _, statusCheck := range MergeQueueStatusChecks {
+		found := false
+		for _, c := range protection.RequiredStatusCheckContexts {
+			if c == statusCheck {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/golang/mock/gomock"
+	"github.com/shurcooL/githubv4"
+)
+
+func TestEnableMergeQueueStatusCheck(t *testing.T) {
+	owner := "johnqueue"
+	repo := "johnsrepoqueue"
+	branchName := DefaultBranchName
+
+	currentProtections := &RepoBranchProtectionQueryResult{
+		RepositoryOwner: struct {
+			Repository BranchProtectionRuleRepositoryParam `graphql:"repository(name: $name)"`
+		}{Repository: BranchProtectionRuleRepositoryParam{
+			Name: "repoNameValue",
+			ID:   "repoIDValue",
+			BranchProtectionRules: BranchProtectionRuleQueryParam{
+				TotalCount: 1,
+				Nodes: []BranchProtectionRule{
+					{
+						ID:                          "branchProtectionID",
+						Pattern:                     branchName,
+						RequiresStatusChecks:        true,
+						IsAdminEnforced:             true,
+						RequiredStatusCheckContexts: []string{"circle/ci"},
+					},
+				},
+			},
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockCombinedRepository(ctrl)
+	m.
+		EXPECT().
+		GetRepositoryBranchProtections(gomock.Any(), owner, repo).
+		Return(currentProtections, nil).
+		Times(2)
+
+	m.
+		EXPECT().
+		UpdateBranchProtection(gomock.Any(), &githubv4.UpdateBranchProtectionRuleInput{
+			BranchProtectionRuleID:      githubv4.ID("branchProtectionID"),
+			Pattern:                     githubv4.NewString(githubv4.String(branchName)),
+			AllowsForcePushes:           githubv4.NewBoolean(false),
+			AllowsDeletions:             githubv4.NewBoolean(false),
+			IsAdminEnforced:             githubv4.NewBoolean(true),
+			RequiresStatusChecks:        githubv4.NewBoolean(true),
+			RequiredStatusCheckContexts: V4StringSlice("circle/ci", "EasyCLA"),
+		}).
+		Return(nil, nil)
+
+	branchProtectionRepo := newBranchProtectionRepository(m)
+	err := branchProtectionRepo.EnableMergeQueueStatusCheck(context.Background(), owner, repo, branchName)
+	assert.Equal(t, nil, err)
+}
+
+func TestIsMergeQueueStatusCheckEnabled(t *testing.T) {
+	assert.Equal(t, false, IsMergeQueueStatusCheckEnabled(nil))
+	assert.Equal(t, false, IsMergeQueueStatusCheckEnabled(&BranchProtectionRule{
+		RequiresStatusChecks:        true,
+		RequiredStatusCheckContexts: []string{"circle/ci"},
+	}))
+	assert.Equal(t, false, IsMergeQueueStatusCheckEnabled(&BranchProtectionRule{
+		RequiredStatusCheckContexts: []string{"EasyCLA"},
+	}))
+	assert.Equal(t, true, IsMergeQueueStatusCheckEnabled(&BranchProtectionRule{
+		RequiresStatusChecks:        true,
+		RequiredStatusCheckContexts: []string{"circle/ci", "EasyCLA"},
+	}))
+}
@@ -0,0 +1,173 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"fmt"
+)
+
+// drift reasons
+const (
+	DriftReasonNotProtected       = "branch is not protected"
+	DriftReasonStatusChecksOff    = "status checks are not required"
+	DriftReasonStatusCheckRemoved = "required status check was removed"
+)
+
+// Policy is the branch protection policy of a GitHub organization, applied to each of its enrolled repositories
+type Policy struct {
+	// DefaultBranch protects the default branch of each repository
+	DefaultBranch bool
+	// BranchPatterns are the additional branch name patterns to protect, such as release/*
+	BranchPatterns []string
+	// StatusChecks are the status checks the protected branches require
+	StatusChecks []string
+	EnforceAdmin bool
+}
+
+// PolicyDrift is a protected branch pattern of a repository which no longer complies with the policy
+type PolicyDrift struct {
+	RepositoryName string
+	BranchPattern  string
+	Reason         string
+	// MissingStatusChecks are the policy status checks the branch protection rule does not require
+	MissingStatusChecks []string
+}
+
+// IsEmpty checks if the policy has no branch to protect
+func (p *Policy) IsEmpty() bool {
+	return p == nil || (!p.DefaultBranch && len(p.BranchPatterns) == 0)
+}
+
+// branchPatterns returns the unique branch patterns of the policy, the default branch first
+func (p *Policy) branchPatterns(defaultBranch string) []string {
+	var patterns []string
+	seen := make(map[string]bool)
+	add := func(pattern string) {
+		if pattern != "" && !seen[pattern] {
+			seen[pattern] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	if p.DefaultBranch {
+		add(defaultBranch)
+	}
+	for _, pattern := range p.BranchPatterns {
+		add(pattern)
+	}
+	return patterns
+}
+
+// policyPatterns resolves the branch patterns of the policy for the given repository
+func (bp *BranchProtectionRepository) policyPatterns(ctx context.Context, owner, repoName string, policy *Policy) ([]string, error) {
+	var defaultBranch string
+	if policy.DefaultBranch {
+		var err error
+		defaultBranch, err = bp.GetDefaultBranchForRepo(ctx, owner, repoName)
+		if err != nil {
+			return nil, fmt.Errorf("fetching the default branch for owner : %s and repo : %s failed : %w", owner, repoName, err)
+		}
+	}
+	return policy.branchPatterns(defaultBranch), nil
+}
+
+// ApplyPolicy protects the branches of the policy of the given repository. The existing checks of the branch protection
+// rules are preserved.
+func (bp *BranchProtectionRepository) ApplyPolicy(ctx context.Context, owner, repoName string, policy *Policy) error {
+	if policy.IsEmpty() {
+		return nil
+	}
+	repoName = CleanGithubRepoName(repoName)
+
+	patterns, err := bp.policyPatterns(ctx, owner, repoName, policy)
+	if err != nil {
+		return err
+	}
+
+	for _, pattern := range patterns {
+		if err := bp.EnableBranchProtection(ctx, owner, repoName, pattern, policy.EnforceAdmin, policy.StatusChecks, nil); err != nil {
+			return fmt.Errorf("applying the branch protection policy on pattern : %s failed : %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// CheckPolicy returns the branch patterns of the given repository which drifted from the policy - the rule was removed,
+// or the policy status checks are no longer required
+func (bp *BranchProtectionRepository) CheckPolicy(ctx context.Context, owner, repoName string, policy *Policy) ([]*PolicyDrift, error) {
+	if policy.IsEmpty() {
+		return nil, nil
+	}
+	repoName = CleanGithubRepoName(repoName)
+
+	patterns, err := bp.policyPatterns(ctx, owner, repoName, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult, err := bp.combinedRepo.GetRepositoryBranchProtections(ctx, owner, repoName)
+	if err != nil {
+		return nil, fmt.Errorf("fetching repo protections for owner : %s and repoName : %s failed : %w", owner, repoName, err)
+	}
+
+	return policyDrift(repoName, patterns, queryResult.RepositoryOwner.Repository.BranchProtectionRules.Nodes, policy.StatusChecks), nil
+}
+
+// policyDrift compares the branch protection rules of a repository with the policy patterns and status checks
+func policyDrift(repoName string, patterns []string, rules []BranchProtectionRule, statusChecks []string) []*PolicyDrift {
+	var drift []*PolicyDrift
+	for _, pattern := range patterns {
+		var rule *BranchProtectionRule
+		for i := range rules {
+			if rules[i].Pattern == pattern {
+				rule = &rules[i]
+				break
+			}
+		}
+
+		if rule == nil {
+			drift = append(drift, &PolicyDrift{
+				RepositoryName:      repoName,
+				BranchPattern:       pattern,
+				Reason:              DriftReasonNotProtected,
+				MissingStatusChecks: statusChecks,
+			})
+			continue
+		}
+
+		var missing []string
+		for _, statusCheck := range statusChecks {
+			found := false
+			for _, c := range rule.RequiredStatusCheckContexts {
+				if c == statusCheck {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missing = append(missing, statusCheck)
+			}
+		}
+
+		if !rule.RequiresStatusChecks {
+			drift = append(drift, &PolicyDrift{
+				RepositoryName:      repoName,
+				BranchPattern:       pattern,
+				Reason:              DriftReasonStatusChecksOff,
+				MissingStatusChecks: statusChecks,
+			})
+		} else if len(missing) > 0 {
+			drift = append(drift, &PolicyDrift{
+				RepositoryName:      repoName,
+				BranchPattern:       pattern,
+				Reason:              DriftReasonStatusCheckRemoved,
+				MissingStatusChecks: missing,
+			})
+		}
+	}
+
+	return drift
+}
@@ -0,0 +1,84 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/golang/mock/gomock"
+	githubpkg "github.com/google/go-github/v37/github"
+)
+
+func TestPolicyBranchPatterns(t *testing.T) {
+	policy := &Policy{DefaultBranch: true, BranchPatterns: []string{"release/*", "main", ""}}
+	assert.Equal(t, []string{"main", "release/*"}, policy.branchPatterns("main"))
+
+	policy = &Policy{BranchPatterns: []string{"release/*"}}
+	assert.Equal(t, []string{"release/*"}, policy.branchPatterns("main"))
+
+	assert.Equal(t, true, (*Policy)(nil).IsEmpty())
+	assert.Equal(t, true, (&Policy{EnforceAdmin: true}).IsEmpty())
+	assert.Equal(t, false, policy.IsEmpty())
+}
+
+func TestPolicyDrift(t *testing.T) {
+	statusChecks := []string{"EasyCLA"}
+	rules := []BranchProtectionRule{
+		{Pattern: "main", RequiresStatusChecks: true, RequiredStatusCheckContexts: []string{"circle/ci", "EasyCLA"}},
+		{Pattern: "release/*", RequiresStatusChecks: true, RequiredStatusCheckContexts: []string{"circle/ci"}},
+		{Pattern: "develop", RequiredStatusCheckContexts: []string{"EasyCLA"}},
+	}
+
+	drift := policyDrift("repo", []string{"main", "release/*", "develop", "hotfix/*"}, rules, statusChecks)
+	assert.Equal(t, 3, len(drift))
+	assert.Equal(t, &PolicyDrift{RepositoryName: "repo", BranchPattern: "release/*", Reason: DriftReasonStatusCheckRemoved, MissingStatusChecks: statusChecks}, drift[0])
+	assert.Equal(t, &PolicyDrift{RepositoryName: "repo", BranchPattern: "develop", Reason: DriftReasonStatusChecksOff, MissingStatusChecks: statusChecks}, drift[1])
+	assert.Equal(t, &PolicyDrift{RepositoryName: "repo", BranchPattern: "hotfix/*", Reason: DriftReasonNotProtected, MissingStatusChecks: statusChecks}, drift[2])
+
+	assert.Equal(t, 0, len(policyDrift("repo", []string{"main"}, rules, statusChecks)))
+}
+
+func TestCheckPolicy(t *testing.T) {
+	owner := "johnpolicy"
+	repo := "johnsrepopolicy"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockCombinedRepository(ctrl)
+	m.
+		EXPECT().
+		Get(gomock.Any(), owner, repo).
+		Return(&githubpkg.Repository{DefaultBranch: githubpkg.String("trunk")}, nil, nil)
+	m.
+		EXPECT().
+		GetRepositoryBranchProtections(gomock.Any(), owner, repo).
+		Return(&RepoBranchProtectionQueryResult{
+			RepositoryOwner: struct {
+				Repository BranchProtectionRuleRepositoryParam `graphql:"repository(name: $name)"`
+			}{Repository: BranchProtectionRuleRepositoryParam{
+				Name: "repoNameValue",
+				ID:   "repoIDValue",
+				BranchProtectionRules: BranchProtectionRuleQueryParam{
+					TotalCount: 1,
+					Nodes: []BranchProtectionRule{
+						{ID: "branchProtectionID", Pattern: "trunk", RequiresStatusChecks: true, RequiredStatusCheckContexts: []string{"EasyCLA"}},
+					},
+				},
+			}},
+		}, nil)
+
+	branchProtectionRepo := newBranchProtectionRepository(m)
+	drift, err := branchProtectionRepo.CheckPolicy(context.Background(), owner, repo, &Policy{
+		DefaultBranch:  true,
+		BranchPatterns: []string{"release/*"},
+		StatusChecks:   []string{"EasyCLA"},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(drift))
+	assert.Equal(t, "release/*", drift[0].BranchPattern)
+	assert.Equal(t, DriftReasonNotProtected, drift[0].Reason)
+}
@@ -35,6 +35,7 @@ type combinedRepositoryProvider struct {
 type branchProtectionRepositoryConfig struct {
 	enableBlockingLimiter    bool
 	enableNonBlockingLimiter bool
+	rulesetRepo              RulesetRepository
 }
 
 // BranchProtectionRepositoryOption enables optional parameters to BranchProtectionRepository
@@ -54,9 +55,17 @@ func EnableNonBlockingLimiter() BranchProtectionRepositoryOption {
 	}
 }
 
+// withRulesetRepository sets the repository of the rulesets functionality
+func withRulesetRepository(rulesetRepo RulesetRepository) BranchProtectionRepositoryOption {
+	return func(config *branchProtectionRepositoryConfig) {
+		config.rulesetRepo = rulesetRepo
+	}
+}
+
 // BranchProtectionRepository contains helper methods interacting with github api related to branch protection
 type BranchProtectionRepository struct {
 	combinedRepo CombinedRepository
+	rulesetRepo  RulesetRepository
 }
 
 // NewBranchProtectionRepository creates a new BranchProtectionRepository
@@ -76,6 +85,7 @@ func NewBranchProtectionRepository(installationID int64, opts ...BranchProtectio
 		V4BranchProtectionRepository: v4BranchProtectionRepo,
 	}
 
+	opts = append(opts, withRulesetRepository(NewRulesetRepositoryV3(v3Client)))
 	return newBranchProtectionRepository(combinedRepo, opts...), nil
 }
 
@@ -93,6 +103,7 @@ func newBranchProtectionRepository(combinedRepo CombinedRepository, opts ...Bran
 
 	return &BranchProtectionRepository{
 		combinedRepo: combinedRepo,
+		rulesetRepo:  config.rulesetRepo,
 	}
 }
 
@@ -0,0 +1,242 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ruleset constants
+const (
+	// OrganizationRulesetName is the name of the organization ruleset managed by EasyCLA
+	OrganizationRulesetName = "EasyCLA"
+
+	RulesetTargetBranch             = "branch"
+	RulesetEnforcementActive        = "active"
+	RulesetRuleRequiredStatusChecks = "required_status_checks"
+	// RulesetDefaultBranchRef matches the default branch of each repository
+	RulesetDefaultBranchRef = "~DEFAULT_BRANCH"
+	// RulesetAllRepositories matches each repository of the organization
+	RulesetAllRepositories = "~ALL"
+)
+
+// ErrRulesetsNotConfigured is returned when the repository has no rulesets client
+var ErrRulesetsNotConfigured = errors.New("rulesets are not configured")
+
+// BranchRulesetStatus is the status checks the rulesets require on a branch
+type BranchRulesetStatus struct {
+	// RulesetsDetected is true when the repository has rulesets, including the ones of its organization
+	RulesetsDetected bool
+	// RequiredStatusChecks maps the status check contexts required on the branch to the IDs of the rulesets requiring them
+	RequiredStatusChecks map[string][]int64
+}
+
+// IsStatusCheckRequired checks if the status check is required by any of the rulesets of the branch
+func (s *BranchRulesetStatus) IsStatusCheckRequired(statusCheck string) bool {
+	return s != nil && len(s.RequiredStatusChecks[statusCheck]) > 0
+}
+
+// GetBranchRulesetStatus detects the rulesets of the repository and returns the status checks they require on the branch
+func (bp *BranchProtectionRepository) GetBranchRulesetStatus(ctx context.Context, owner, repoName, branchName string) (*BranchRulesetStatus, error) {
+	if bp.rulesetRepo == nil {
+		return nil, ErrRulesetsNotConfigured
+	}
+	repoName = CleanGithubRepoName(repoName)
+
+	status := &BranchRulesetStatus{
+		RequiredStatusChecks: map[string][]int64{},
+	}
+	rulesets, err := bp.rulesetRepo.ListRepositoryRulesets(ctx, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	if len(rulesets) == 0 {
+		return status, nil
+	}
+	status.RulesetsDetected = true
+
+	rules, err := bp.rulesetRepo.GetRulesForBranch(ctx, owner, repoName, branchName)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.Type != RulesetRuleRequiredStatusChecks {
+			continue
+		}
+		parameters, err := requiredStatusChecksParameters(rule.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the required status checks of the ruleset : %d failed : %w", rule.RulesetID, err)
+		}
+		for _, check := range parameters.RequiredStatusChecks {
+			status.RequiredStatusChecks[check.Context] = append(status.RequiredStatusChecks[check.Context], rule.RulesetID)
+		}
+	}
+
+	return status, nil
+}
+
+// EnableOrganizationRulesetStatusChecks creates or updates the EasyCLA organization ruleset, so the status checks are
+// required on the given ref patterns of each repository of the organization. The existing rules, conditions and
+// bypass actors of the ruleset are preserved.
+func (bp *BranchProtectionRepository) EnableOrganizationRulesetStatusChecks(ctx context.Context, org string, refPatterns, statusChecks []string) (*Ruleset, error) {
+	if bp.rulesetRepo == nil {
+		return nil, ErrRulesetsNotConfigured
+	}
+	var rulesetRefPatterns []string
+	for _, pattern := range refPatterns {
+		rulesetRefPatterns = append(rulesetRefPatterns, RulesetRefPattern(pattern))
+	}
+	if len(rulesetRefPatterns) == 0 {
+		rulesetRefPatterns = []string{RulesetDefaultBranchRef}
+	}
+
+	rulesets, err := bp.rulesetRepo.ListOrganizationRulesets(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ruleset := range rulesets {
+		if ruleset.Name != OrganizationRulesetName || (ruleset.Target != "" && ruleset.Target != RulesetTargetBranch) {
+			continue
+		}
+
+		// the list results have no rules
+		rulesetID := ruleset.ID
+		current, err := bp.rulesetRepo.GetOrganizationRuleset(ctx, org, rulesetID)
+		if err != nil {
+			return nil, err
+		}
+		if err := mergeRulesetStatusChecks(current, rulesetRefPatterns, statusChecks); err != nil {
+			return nil, err
+		}
+		return bp.rulesetRepo.UpdateOrganizationRuleset(ctx, org, rulesetID, current)
+	}
+
+	ruleset, err := newOrganizationRuleset(rulesetRefPatterns, statusChecks)
+	if err != nil {
+		return nil, err
+	}
+	return bp.rulesetRepo.CreateOrganizationRuleset(ctx, org, ruleset)
+}
+
+// RulesetRefPattern converts a branch name pattern to the ref name pattern of the rulesets, such as release/* to
+// refs/heads/release/*
+func RulesetRefPattern(pattern string) string {
+	if strings.HasPrefix(pattern, "~") || strings.HasPrefix(pattern, "refs/") {
+		return pattern
+	}
+	return "refs/heads/" + pattern
+}
+
+// IsRulesetStatusCheckRequired checks if the ruleset has a required_status_checks rule requiring the status check
+func IsRulesetStatusCheckRequired(ruleset *Ruleset, statusCheck string) bool {
+	if ruleset == nil {
+		return false
+	}
+	for _, rule := range ruleset.Rules {
+		if rule.Type != RulesetRuleRequiredStatusChecks {
+			continue
+		}
+		parameters, err := requiredStatusChecksParameters(rule.Parameters)
+		if err != nil {
+			continue
+		}
+		for _, check := range parameters.RequiredStatusChecks {
+			if check.Context == statusCheck {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newOrganizationRuleset creates the EasyCLA organization ruleset which applies to each repository of the organization
+func newOrganizationRuleset(refPatterns, statusChecks []string) (*Ruleset, error) {
+	ruleset := &Ruleset{
+		Name:        OrganizationRulesetName,
+		Target:      RulesetTargetBranch,
+		Enforcement: RulesetEnforcementActive,
+		Conditions: &RulesetConditions{
+			RefName: &RulesetRefCondition{
+				Include: []string{},
+				Exclude: []string{},
+			},
+			RepositoryName: json.RawMessage(fmt.Sprintf(`{"include":["%s"],"exclude":[]}`, RulesetAllRepositories)),
+		},
+	}
+	if err := mergeRulesetStatusChecks(ruleset, refPatterns, statusChecks); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+// mergeRulesetStatusChecks adds the ref patterns to the ruleset conditions and the status checks to its
+// required_status_checks rule, and activates the ruleset
+func mergeRulesetStatusChecks(ruleset *Ruleset, refPatterns, statusChecks []string) error {
+	// these are set by github
+	ruleset.ID = 0
+	ruleset.SourceType = ""
+	ruleset.Source = ""
+	ruleset.Enforcement = RulesetEnforcementActive
+
+	if ruleset.Conditions == nil {
+		ruleset.Conditions = &RulesetConditions{}
+	}
+	if ruleset.Conditions.RefName == nil {
+		ruleset.Conditions.RefName = &RulesetRefCondition{Include: []string{}, Exclude: []string{}}
+	}
+	if ruleset.Conditions.RefName.Exclude == nil {
+		ruleset.Conditions.RefName.Exclude = []string{}
+	}
+	ruleset.Conditions.RefName.Include = mergeStatusChecks(ruleset.Conditions.RefName.Include, refPatterns, nil)
+
+	var statusChecksRule *RulesetRule
+	for _, rule := range ruleset.Rules {
+		if rule.Type == RulesetRuleRequiredStatusChecks {
+			statusChecksRule = rule
+			break
+		}
+	}
+	if statusChecksRule == nil {
+		statusChecksRule = &RulesetRule{Type: RulesetRuleRequiredStatusChecks}
+		ruleset.Rules = append(ruleset.Rules, statusChecksRule)
+	}
+
+	parameters, err := requiredStatusChecksParameters(statusChecksRule.Parameters)
+	if err != nil {
+		return err
+	}
+	for _, statusCheck := range statusChecks {
+		found := false
+		for _, check := range parameters.RequiredStatusChecks {
+			if check.Context == statusCheck {
+				found = true
+				break
+			}
+		}
+		if !found {
+			parameters.RequiredStatusChecks = append(parameters.RequiredStatusChecks, &RequiredStatusCheck{Context: statusCheck})
+		}
+	}
+
+	statusChecksRule.Parameters, err = json.Marshal(parameters)
+	return err
+}
+
+func requiredStatusChecksParameters(raw json.RawMessage) (*RequiredStatusChecksParameters, error) {
+	parameters := &RequiredStatusChecksParameters{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, parameters); err != nil {
+			return nil, err
+		}
+	}
+	if parameters.RequiredStatusChecks == nil {
+		parameters.RequiredStatusChecks = []*RequiredStatusCheck{}
+	}
+	return parameters, nil
+}
@@ -0,0 +1,138 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+type fakeRulesetRepository struct {
+	repositoryRulesets   []*Ruleset
+	branchRules          []*BranchRule
+	organizationRulesets []*Ruleset
+	created              *Ruleset
+	updated              *Ruleset
+	updatedID            int64
+}
+
+func (f *fakeRulesetRepository) GetRulesForBranch(ctx context.Context, owner, repo, branch string) ([]*BranchRule, error) {
+	return f.branchRules, nil
+}
+
+func (f *fakeRulesetRepository) ListRepositoryRulesets(ctx context.Context, owner, repo string) ([]*Ruleset, error) {
+	return f.repositoryRulesets, nil
+}
+
+func (f *fakeRulesetRepository) ListOrganizationRulesets(ctx context.Context, org string) ([]*Ruleset, error) {
+	return f.organizationRulesets, nil
+}
+
+func (f *fakeRulesetRepository) GetOrganizationRuleset(ctx context.Context, org string, rulesetID int64) (*Ruleset, error) {
+	for _, ruleset := range f.organizationRulesets {
+		if ruleset.ID == rulesetID {
+			return ruleset, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeRulesetRepository) CreateOrganizationRuleset(ctx context.Context, org string, ruleset *Ruleset) (*Ruleset, error) {
+	f.created = ruleset
+	return ruleset, nil
+}
+
+func (f *fakeRulesetRepository) UpdateOrganizationRuleset(ctx context.Context, org string, rulesetID int64, ruleset *Ruleset) (*Ruleset, error) {
+	f.updatedID = rulesetID
+	f.updated = ruleset
+	return ruleset, nil
+}
+
+func TestRulesetRefPattern(t *testing.T) {
+	assert.Equal(t, "refs/heads/release/*", RulesetRefPattern("release/*"))
+	assert.Equal(t, "refs/heads/main", RulesetRefPattern("refs/heads/main"))
+	assert.Equal(t, "~DEFAULT_BRANCH", RulesetRefPattern(RulesetDefaultBranchRef))
+}
+
+func TestGetBranchRulesetStatus(t *testing.T) {
+	fake := &fakeRulesetRepository{}
+	bp := newBranchProtectionRepository(nil, withRulesetRepository(fake))
+
+	status, err := bp.GetBranchRulesetStatus(context.Background(), "owner", "org/repo", "main")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, status.RulesetsDetected)
+	assert.Equal(t, false, status.IsStatusCheckRequired("EasyCLA"))
+
+	fake.repositoryRulesets = []*Ruleset{{ID: 7, Name: "org rules", SourceType: "Organization"}, {ID: 9, Name: "repo rules"}}
+	fake.branchRules = []*BranchRule{
+		{Type: "deletion", RulesetID: 9},
+		{Type: RulesetRuleRequiredStatusChecks, RulesetID: 9, Parameters: json.RawMessage(`{"required_status_checks":[{"context":"circle/ci"}],"strict_required_status_checks_policy":false}`)},
+		{Type: RulesetRuleRequiredStatusChecks, RulesetID: 7, Parameters: json.RawMessage(`{"required_status_checks":[{"context":"EasyCLA","integration_id":1234}],"strict_required_status_checks_policy":true}`)},
+	}
+	status, err = bp.GetBranchRulesetStatus(context.Background(), "owner", "repo", "main")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, status.RulesetsDetected)
+	assert.Equal(t, true, status.IsStatusCheckRequired("EasyCLA"))
+	assert.Equal(t, []int64{7}, status.RequiredStatusChecks["EasyCLA"])
+	assert.Equal(t, []int64{9}, status.RequiredStatusChecks["circle/ci"])
+
+	_, err = newBranchProtectionRepository(nil).GetBranchRulesetStatus(context.Background(), "owner", "repo", "main")
+	assert.Equal(t, ErrRulesetsNotConfigured, err)
+}
+
+func TestEnableOrganizationRulesetStatusChecksCreate(t *testing.T) {
+	fake := &fakeRulesetRepository{
+		organizationRulesets: []*Ruleset{{ID: 3, Name: "security", Target: RulesetTargetBranch}},
+	}
+	bp := newBranchProtectionRepository(nil, withRulesetRepository(fake))
+
+	_, err := bp.EnableOrganizationRulesetStatusChecks(context.Background(), "org", nil, []string{"EasyCLA"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, (*Ruleset)(nil), fake.updated)
+	assert.Equal(t, OrganizationRulesetName, fake.created.Name)
+	assert.Equal(t, RulesetEnforcementActive, fake.created.Enforcement)
+	assert.Equal(t, []string{RulesetDefaultBranchRef}, fake.created.Conditions.RefName.Include)
+	assert.Equal(t, `{"include":["~ALL"],"exclude":[]}`, string(fake.created.Conditions.RepositoryName))
+	assert.Equal(t, true, IsRulesetStatusCheckRequired(fake.created, "EasyCLA"))
+}
+
+func TestEnableOrganizationRulesetStatusChecksUpdate(t *testing.T) {
+	fake := &fakeRulesetRepository{
+		organizationRulesets: []*Ruleset{{
+			ID:           42,
+			Name:         OrganizationRulesetName,
+			Target:       RulesetTargetBranch,
+			SourceType:   "Organization",
+			Source:       "org",
+			Enforcement:  "evaluate",
+			BypassActors: json.RawMessage(`[{"actor_id":1,"actor_type":"OrganizationAdmin","bypass_mode":"always"}]`),
+			Conditions: &RulesetConditions{
+				RefName:        &RulesetRefCondition{Include: []string{RulesetDefaultBranchRef}},
+				RepositoryName: json.RawMessage(`{"include":["easycla-*"],"exclude":[]}`),
+			},
+			Rules: []*RulesetRule{
+				{Type: "deletion"},
+				{Type: RulesetRuleRequiredStatusChecks, Parameters: json.RawMessage(`{"required_status_checks":[{"context":"circle/ci"}],"strict_required_status_checks_policy":true}`)},
+			},
+		}},
+	}
+	bp := newBranchProtectionRepository(nil, withRulesetRepository(fake))
+
+	_, err := bp.EnableOrganizationRulesetStatusChecks(context.Background(), "org", []string{"release/*"}, []string{"EasyCLA"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, (*Ruleset)(nil), fake.created)
+	assert.Equal(t, int64(42), fake.updatedID)
+	assert.Equal(t, int64(0), fake.updated.ID)
+	assert.Equal(t, "", fake.updated.SourceType)
+	assert.Equal(t, RulesetEnforcementActive, fake.updated.Enforcement)
+	assert.Equal(t, []string{RulesetDefaultBranchRef, "refs/heads/release/*"}, fake.updated.Conditions.RefName.Include)
+	assert.Equal(t, []string{}, fake.updated.Conditions.RefName.Exclude)
+	assert.Equal(t, `{"include":["easycla-*"],"exclude":[]}`, string(fake.updated.Conditions.RepositoryName))
+	assert.Equal(t, `[{"actor_id":1,"actor_type":"OrganizationAdmin","bypass_mode":"always"}]`, string(fake.updated.BypassActors))
+	assert.Equal(t, 2, len(fake.updated.Rules))
+	assert.Equal(t, `{"required_status_checks":[{"context":"circle/ci"},{"context":"EasyCLA"}],"strict_required_status_checks_policy":true}`, string(fake.updated.Rules[1].Parameters))
+}
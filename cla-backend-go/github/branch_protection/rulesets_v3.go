@@ -0,0 +1,220 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	githubpkg "github.com/google/go-github/v37/github"
+)
+
+// Ruleset is a repository or organization ruleset
+type Ruleset struct {
+	ID          int64  `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Target      string `json:"target,omitempty"`
+	SourceType  string `json:"source_type,omitempty"`
+	Source      string `json:"source,omitempty"`
+	Enforcement string `json:"enforcement"`
+	// BypassActors are kept as is when the ruleset is updated
+	BypassActors json.RawMessage    `json:"bypass_actors,omitempty"`
+	Conditions   *RulesetConditions `json:"conditions,omitempty"`
+	Rules        []*RulesetRule     `json:"rules,omitempty"`
+}
+
+// RulesetConditions are the conditions of the refs and repositories a ruleset applies to
+type RulesetConditions struct {
+	RefName            *RulesetRefCondition `json:"ref_name,omitempty"`
+	RepositoryName     json.RawMessage      `json:"repository_name,omitempty"`
+	RepositoryID       json.RawMessage      `json:"repository_id,omitempty"`
+	RepositoryProperty json.RawMessage      `json:"repository_property,omitempty"`
+}
+
+// RulesetRefCondition are the ref name patterns a ruleset applies to
+type RulesetRefCondition struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// RulesetRule is a rule of a ruleset - the parameters depend on the rule type
+type RulesetRule struct {
+	Type       string          `json:"type"`
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
+// RequiredStatusChecksParameters are the parameters of the required_status_checks rule
+type RequiredStatusChecksParameters struct {
+	RequiredStatusChecks             []*RequiredStatusCheck `json:"required_status_checks"`
+	StrictRequiredStatusChecksPolicy bool                   `json:"strict_required_status_checks_policy"`
+}
+
+// RequiredStatusCheck is a status check required by the required_status_checks rule
+type RequiredStatusCheck struct {
+	Context       string `json:"context"`
+	IntegrationID *int64 `json:"integration_id,omitempty"`
+}
+
+// BranchRule is a rule which applies to a branch, with the ruleset it comes from
+type BranchRule struct {
+	Type              string          `json:"type"`
+	Parameters        json.RawMessage `json:"parameters,omitempty"`
+	RulesetSourceType string          `json:"ruleset_source_type"`
+	RulesetSource     string          `json:"ruleset_source"`
+	RulesetID         int64           `json:"ruleset_id"`
+}
+
+// RulesetRepositoryV3 implements RulesetRepository with the github REST api
+type RulesetRepositoryV3 struct {
+	client *githubpkg.Client
+}
+
+// NewRulesetRepositoryV3 creates a new RulesetRepositoryV3
+func NewRulesetRepositoryV3(client *githubpkg.Client) *RulesetRepositoryV3 {
+	return &RulesetRepositoryV3{
+		client: client,
+	}
+}
+
+// GetRulesForBranch returns the active rules which apply to the branch, from the repository and organization rulesets
+func (r *RulesetRepositoryV3) GetRulesForBranch(ctx context.Context, owner, repo, branch string) ([]*BranchRule, error) {
+	u := fmt.Sprintf("repos/%s/%s/rules/branches/%s", owner, repo, branch)
+	var rules []*BranchRule
+	err := r.list(ctx, u, func(page json.RawMessage) error {
+		var pageRules []*BranchRule
+		if err := json.Unmarshal(page, &pageRules); err != nil {
+			return err
+		}
+		rules = append(rules, pageRules...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching the rules for owner : %s, repo : %s and branch : %s failed : %w", owner, repo, branch, err)
+	}
+	return rules, nil
+}
+
+// ListRepositoryRulesets returns the rulesets of the repository, including the ones of the organization
+func (r *RulesetRepositoryV3) ListRepositoryRulesets(ctx context.Context, owner, repo string) ([]*Ruleset, error) {
+	rulesets, err := r.listRulesets(ctx, fmt.Sprintf("repos/%s/%s/rulesets?includes_parents=true", owner, repo))
+	if err != nil {
+		return nil, fmt.Errorf("fetching the rulesets for owner : %s and repo : %s failed : %w", owner, repo, err)
+	}
+	return rulesets, nil
+}
+
+// ListOrganizationRulesets returns the rulesets of the organization - the rules are not part of the list results
+func (r *RulesetRepositoryV3) ListOrganizationRulesets(ctx context.Context, org string) ([]*Ruleset, error) {
+	rulesets, err := r.listRulesets(ctx, fmt.Sprintf("orgs/%s/rulesets", org))
+	if err != nil {
+		return nil, fmt.Errorf("fetching the rulesets for org : %s failed : %w", org, err)
+	}
+	return rulesets, nil
+}
+
+// GetOrganizationRuleset returns the organization ruleset with its rules
+func (r *RulesetRepositoryV3) GetOrganizationRuleset(ctx context.Context, org string, rulesetID int64) (*Ruleset, error) {
+	var ruleset Ruleset
+	if err := r.do(ctx, http.MethodGet, fmt.Sprintf("orgs/%s/rulesets/%d", org, rulesetID), nil, &ruleset); err != nil {
+		return nil, fmt.Errorf("fetching the ruleset : %d for org : %s failed : %w", rulesetID, org, err)
+	}
+	return &ruleset, nil
+}
+
+// CreateOrganizationRuleset creates a new organization ruleset
+func (r *RulesetRepositoryV3) CreateOrganizationRuleset(ctx context.Context, org string, ruleset *Ruleset) (*Ruleset, error) {
+	var created Ruleset
+	if err := r.do(ctx, http.MethodPost, fmt.Sprintf("orgs/%s/rulesets", org), ruleset, &created); err != nil {
+		return nil, fmt.Errorf("creating the ruleset : %s for org : %s failed : %w", ruleset.Name, org, err)
+	}
+	return &created, nil
+}
+
+// UpdateOrganizationRuleset updates the organization ruleset
+func (r *RulesetRepositoryV3) UpdateOrganizationRuleset(ctx context.Context, org string, rulesetID int64, ruleset *Ruleset) (*Ruleset, error) {
+	var updated Ruleset
+	if err := r.do(ctx, http.MethodPut, fmt.Sprintf("orgs/%s/rulesets/%d", org, rulesetID), ruleset, &updated); err != nil {
+		return nil, fmt.Errorf("updating the ruleset : %d for org : %s failed : %w", rulesetID, org, err)
+	}
+	return &updated, nil
+}
+
+func (r *RulesetRepositoryV3) listRulesets(ctx context.Context, u string) ([]*Ruleset, error) {
+	var rulesets []*Ruleset
+	err := r.list(ctx, u, func(page json.RawMessage) error {
+		var pageRulesets []*Ruleset
+		if err := json.Unmarshal(page, &pageRulesets); err != nil {
+			return err
+		}
+		rulesets = append(rulesets, pageRulesets...)
+		return nil
+	})
+	return rulesets, err
+}
+
+// list loads each page of the list endpoint
+func (r *RulesetRepositoryV3) list(ctx context.Context, u string, addPage func(page json.RawMessage) error) error {
+	opts := &githubpkg.ListOptions{PerPage: 100}
+	for {
+		pageURL, err := addListOptions(u, opts)
+		if err != nil {
+			return err
+		}
+
+		var page json.RawMessage
+		req, err := r.client.NewRequest(http.MethodGet, pageURL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := r.client.Do(ctx, req, &page)
+		if err != nil {
+			if ok, wErr := github.CheckAndWrapForKnownErrors(resp, err); ok {
+				return wErr
+			}
+			return err
+		}
+
+		if err := addPage(page); err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (r *RulesetRepositoryV3) do(ctx context.Context, method, u string, body, v interface{}) error {
+	req, err := r.client.NewRequest(method, u, body)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(ctx, req, v)
+	if err != nil {
+		if ok, wErr := github.CheckAndWrapForKnownErrors(resp, err); ok {
+			return wErr
+		}
+		return err
+	}
+	return nil
+}
+
+// addListOptions adds the paging parameters to the url which may already have query parameters
+func addListOptions(u string, opts *githubpkg.ListOptions) (string, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	query.Set("per_page", fmt.Sprintf("%d", opts.PerPage))
+	if opts.Page > 0 {
+		query.Set("page", fmt.Sprintf("%d", opts.Page))
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
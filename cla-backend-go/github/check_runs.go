@@ -0,0 +1,328 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/google/go-github/v37/github"
+	"github.com/sirupsen/logrus"
+)
+
+// Check run constants
+const (
+	// CheckRunName is the name of the EasyCLA check run - same as the legacy commit status context
+	CheckRunName = "EasyCLA"
+
+	// CheckRunActionRerun is the identifier of the "Re-run" requested action
+	CheckRunActionRerun = "rerun"
+	// CheckRunActionSign is the identifier of the "Sign CLA" requested action
+	CheckRunActionSign = "sign"
+
+	checkRunStatusCompleted  = "completed"
+	checkRunConclusionPassed = "success"
+	checkRunConclusionFailed = "action_required"
+	checkRunNotRequiredTitle = "EasyCLA check not required"
+	checkRunIncompleteTitle  = "EasyCLA check incomplete - not all the commits could be checked"
+
+	// signCLACommentMarker identifies the comment answering the "Sign CLA" action, updated on each request
+	signCLACommentMarker = "<!-- easycla-sign-cla -->"
+)
+
+// CheckRunInput contains the details needed to publish the EasyCLA check run for a pull request
+type CheckRunInput struct {
+	Owner          string
+	Repo           string
+	HeadSHA        string
+	PullRequestID  int
+	InstallationID int64
+	RepositoryID   int64
+	Signed         []*UserCommitSummary
+	Missing        []*UserCommitSummary
+	SignURL        string
+	LandingPage    string
+	// CommitsNote describes how the commits were loaded for large pull requests, if needed
+	CommitsNote string
+	// CommitsTruncated is set when some of the pull request commits could not be loaded - the check then fails, the
+	// authors of the commits we could not load may not be covered by a CLA
+	CommitsTruncated bool
+	// NotRequiredReason is set when the repository requirements skip the CLA check of the pull request
+	NotRequiredReason string
+}
+
+// PullRequestIDFromCheckRun returns the pull request number the check run belongs to - the external ID is set when
+// we publish the check run, the pull requests list is empty for pull requests opened from forks
+func PullRequestIDFromCheckRun(checkRun *github.CheckRun) (int, error) {
+	if checkRun == nil {
+		return 0, fmt.Errorf("missing check run")
+	}
+	if checkRun.ExternalID != nil && *checkRun.ExternalID != "" {
+		return strconv.Atoi(*checkRun.ExternalID)
+	}
+	for _, pr := range checkRun.PullRequests {
+		if pr.Number != nil {
+			return *pr.Number, nil
+		}
+	}
+	return 0, fmt.Errorf("unable to determine the pull request for check run")
+}
+
+// CreateOrUpdateCheckRun publishes the EasyCLA check run on the head commit of the pull request, updating the
+// existing check run for the commit if we have already created one
+func CreateOrUpdateCheckRun(ctx context.Context, client *github.Client, input *CheckRunInput) (*github.CheckRun, *github.Response, error) {
+	f := logrus.Fields{
+		"functionName":   "github.check_runs.CreateOrUpdateCheckRun",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"owner":          input.Owner,
+		"repo":           input.Repo,
+		"SHA":            input.HeadSHA,
+		"pullRequestID":  input.PullRequestID,
+	}
+
+	conclusion, title := checkRunResult(input)
+	output := &github.CheckRunOutput{
+		Title:   github.String(title),
+		Summary: github.String(checkRunSummary(input)),
+	}
+	actions := checkRunActions(len(input.Missing) > 0)
+	detailsURL := input.SignURL
+	if len(input.Missing) == 0 {
+		detailsURL = fmt.Sprintf("%s/#/?version=2", input.LandingPage)
+	}
+	completedAt := github.Timestamp{Time: time.Now()}
+	externalID := strconv.Itoa(input.PullRequestID)
+
+	existing, _, err := client.Checks.ListCheckRunsForRef(ctx, input.Owner, input.Repo, input.HeadSHA, &github.ListCheckRunsOptions{
+		CheckName: github.String(CheckRunName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list check runs - creating a new check run")
+	}
+
+	if checkRun := findOwnCheckRun(existing); checkRun != nil {
+		log.WithFields(f).Debugf("updating check run: %d with conclusion: %s", checkRun.GetID(), conclusion)
+		return client.Checks.UpdateCheckRun(ctx, input.Owner, input.Repo, checkRun.GetID(), github.UpdateCheckRunOptions{
+			Name:        CheckRunName,
+			DetailsURL:  github.String(detailsURL),
+			ExternalID:  github.String(externalID),
+			Status:      github.String(checkRunStatusCompleted),
+			Conclusion:  github.String(conclusion),
+			CompletedAt: &completedAt,
+			Output:      output,
+			Actions:     actions,
+		})
+	}
+
+	log.WithFields(f).Debugf("creating check run with conclusion: %s", conclusion)
+	return client.Checks.CreateCheckRun(ctx, input.Owner, input.Repo, github.CreateCheckRunOptions{
+		Name:        CheckRunName,
+		HeadSHA:     input.HeadSHA,
+		DetailsURL:  github.String(detailsURL),
+		ExternalID:  github.String(externalID),
+		Status:      github.String(checkRunStatusCompleted),
+		Conclusion:  github.String(conclusion),
+		CompletedAt: &completedAt,
+		Output:      output,
+		Actions:     actions,
+	})
+}
+
+// isCheckRunPermissionError returns true when the GitHub App installation has not (yet) accepted the checks permission
+func isCheckRunPermissionError(resp *github.Response) bool {
+	return resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound)
+}
+
+// findOwnCheckRun returns the EasyCLA check run created by our GitHub App, if any
+func findOwnCheckRun(results *github.ListCheckRunsResults) *github.CheckRun {
+	if results == nil {
+		return nil
+	}
+	for _, checkRun := range results.CheckRuns {
+		if checkRun.GetName() != CheckRunName {
+			continue
+		}
+		if checkRun.App != nil && getGithubAppID() != 0 && checkRun.App.GetID() != int64(getGithubAppID()) {
+			continue
+		}
+		return checkRun
+	}
+	return nil
+}
+
+// checkRunResult returns the conclusion and title of the check run
+func checkRunResult(input *CheckRunInput) (string, string) {
+	switch {
+	case input.NotRequiredReason != "":
+		return checkRunConclusionPassed, checkRunNotRequiredTitle
+	case input.CommitsTruncated:
+		return checkRunConclusionFailed, checkRunIncompleteTitle
+	default:
+		return checkRunConclusion(input.Signed, input.Missing)
+	}
+}
+
+func checkRunConclusion(signed, missing []*UserCommitSummary) (string, string) {
+	if len(missing) == 0 && len(signed) > 0 {
+		_, title := assembleCLAStatus(CheckRunName, true)
+		return checkRunConclusionPassed, title
+	}
+	_, title := assembleCLAStatus(CheckRunName, false)
+	return checkRunConclusionFailed, title
+}
+
+func checkRunActions(missing bool) []*github.CheckRunAction {
+	actions := []*github.CheckRunAction{
+		{
+			Label:       "Re-run",
+			Description: "Re-run the EasyCLA check",
+			Identifier:  CheckRunActionRerun,
+		},
+	}
+	if missing {
+		actions = append(actions, &github.CheckRunAction{
+			Label:       "Sign CLA",
+			Description: "Open the CLA signing page (Details)",
+			Identifier:  CheckRunActionSign,
+		})
+	}
+	return actions
+}
+
+// checkRunAuthorStatus returns the status column for the author in the summary table
+func checkRunAuthorStatus(summary *UserCommitSummary, signed bool) string {
+	switch {
+	case summary.Exempt:
+		return fmt.Sprintf(":white_check_mark: Exempt (%s)", summary.ExemptReason)
+	case signed:
+		return ":white_check_mark: Authorized"
+	case !summary.IsValid():
+		return ":x: Missing GitHub user ID"
+	case summary.Affiliated:
+		return ":x: Company affiliation not confirmed"
+	default:
+		return ":x: Not authorized under a signed CLA"
+	}
+}
+
+// checkRunSummary builds the markdown summary - a table of the commit authors and their CLA status
+func checkRunSummary(input *CheckRunInput) string {
+	if input.NotRequiredReason != "" {
+		return fmt.Sprintf("The CLA check is not required for this pull request - %s.\n\nUse **Re-run** to check the pull request again once it changes.\n", input.NotRequiredReason)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("| Author | Commits | Status |\n")
+	sb.WriteString("| --- | --- | --- |\n")
+
+	writeRows := func(summaries []*UserCommitSummary, signed bool) {
+		committers := getAuthorInfoCommits(summaries, false)
+		authors := make([]string, 0, len(committers))
+		for author := range committers {
+			authors = append(authors, author)
+		}
+		sort.Strings(authors)
+		for _, author := range authors {
+			var shas []string
+			for _, summary := range committers[author] {
+				shas = append(shas, summary.SHA)
+			}
+			name := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(author), "/"))
+			if name == "" {
+				name = unknown
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", name, strings.Join(shas, ", "), checkRunAuthorStatus(committers[author][0], signed)))
+		}
+	}
+	writeRows(input.Signed, true)
+	writeRows(input.Missing, false)
+
+	if input.CommitsNote != "" {
+		sb.WriteString(fmt.Sprintf("\n:warning: %s\n", input.CommitsNote))
+	}
+
+	if len(input.Missing) > 0 {
+		sb.WriteString(fmt.Sprintf("\n[Sign the CLA](%s) to be authorized. Use **Re-run** once the CLA has been signed.\n", input.SignURL))
+	} else if len(input.Signed) > 0 {
+		sb.WriteString("\nThe committers listed above are authorized under a signed CLA.\n")
+	}
+	return sb.String()
+}
+
+// CreateSignCLAComment answers the "Sign CLA" check run action - check run actions can only notify the app, so we
+// mention the user on the pull request with the link to the signing page. The comment of an earlier request is
+// updated rather than adding a new comment each time.
+func CreateSignCLAComment(ctx context.Context, installationID int64, owner, repo string, pullRequestID int, login, signURL string) error {
+	f := logrus.Fields{
+		"functionName":   "github.check_runs.CreateSignCLAComment",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"owner":          owner,
+		"repo":           repo,
+		"pullRequestID":  pullRequestID,
+		"login":          login,
+	}
+
+	client, err := NewGithubAppClient(installationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create Github client")
+		return err
+	}
+
+	body := signCLACommentBody(login, signURL)
+	comment, err := findSignCLAComment(ctx, client, owner, repo, pullRequestID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list the pull request comments")
+		return err
+	}
+
+	if comment != nil {
+		log.WithFields(f).Debugf("updating sign CLA comment: %d", comment.GetID())
+		_, _, err = client.Issues.EditComment(ctx, owner, repo, comment.GetID(), &github.IssueComment{Body: github.String(body)})
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to edit sign CLA comment")
+			return err
+		}
+		return nil
+	}
+
+	_, _, err = client.Issues.CreateComment(ctx, owner, repo, pullRequestID, &github.IssueComment{Body: github.String(body)})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create sign CLA comment")
+		return err
+	}
+	return nil
+}
+
+// signCLACommentBody returns the comment mentioning the user who requested the signing link
+func signCLACommentBody(login, signURL string) string {
+	return fmt.Sprintf("@%s please <a href='%s' target='_blank'>sign the CLA</a> - select **Re-run** on the EasyCLA check once you are done.\n%s",
+		login, signURL, signCLACommentMarker)
+}
+
+// findSignCLAComment returns the comment answering an earlier "Sign CLA" action, if any
+func findSignCLAComment(ctx context.Context, client *github.Client, owner, repo string, pullRequestID int) (*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := client.Issues.ListComments(ctx, owner, repo, pullRequestID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), signCLACommentMarker) {
+				return comment, nil
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func commitSummary(sha, login string, id int64) *UserCommitSummary {
+	return &UserCommitSummary{
+		SHA: sha,
+		CommitAuthor: &github.User{
+			ID:    github.Int64(id),
+			Login: github.String(login),
+		},
+	}
+}
+
+func TestCheckRunSummary(t *testing.T) {
+	input := &CheckRunInput{
+		Signed:  []*UserCommitSummary{commitSummary("abc", "alice", 1)},
+		Missing: []*UserCommitSummary{commitSummary("def", "bob", 2)},
+		SignURL: "https://example.org/sign",
+	}
+
+	summary := checkRunSummary(input)
+	assert.True(t, strings.Contains(summary, "| login: alice | abc | :white_check_mark: Authorized |"))
+	assert.True(t, strings.Contains(summary, "| login: bob | def | :x: Not authorized under a signed CLA |"))
+	assert.True(t, strings.Contains(summary, "[Sign the CLA](https://example.org/sign)"))
+
+	conclusion, _ := checkRunConclusion(input.Signed, input.Missing)
+	assert.Equal(t, checkRunConclusionFailed, conclusion)
+	conclusion, _ = checkRunConclusion(input.Signed, nil)
+	assert.Equal(t, checkRunConclusionPassed, conclusion)
+	conclusion, _ = checkRunConclusion(nil, nil)
+	assert.Equal(t, checkRunConclusionFailed, conclusion)
+}
+
+func TestCheckRunResult(t *testing.T) {
+	signed := []*UserCommitSummary{commitSummary("abc", "alice", 1)}
+
+	conclusion, _ := checkRunResult(&CheckRunInput{Signed: signed})
+	assert.Equal(t, checkRunConclusionPassed, conclusion)
+	conclusion, title := checkRunResult(&CheckRunInput{Signed: signed, CommitsTruncated: true})
+	assert.Equal(t, checkRunConclusionFailed, conclusion)
+	assert.Equal(t, checkRunIncompleteTitle, title)
+	conclusion, title = checkRunResult(&CheckRunInput{NotRequiredReason: "the target branch feature/x is not enforced"})
+	assert.Equal(t, checkRunConclusionPassed, conclusion)
+	assert.Equal(t, checkRunNotRequiredTitle, title)
+}
+
+func TestUniqueAuthors(t *testing.T) {
+	authors := UniqueAuthors([]*UserCommitSummary{
+		commitSummary("a", "alice", 1),
+		commitSummary("b", "bob", 2),
+		commitSummary("c", "alice", 1),
+	})
+	assert.Len(t, authors, 2)
+	assert.Equal(t, "a", authors[0].SHA)
+	assert.Equal(t, "b", authors[1].SHA)
+}
+
+func TestCheckRunSummaryNotRequired(t *testing.T) {
+	input := &CheckRunInput{
+		NotRequiredReason: "the target branch feature/x is not enforced",
+	}
+
+	summary := checkRunSummary(input)
+	assert.True(t, strings.Contains(summary, "The CLA check is not required for this pull request - the target branch feature/x is not enforced."))
+	assert.False(t, strings.Contains(summary, "| Author |"))
+}
+
+func TestSignCLACommentBody(t *testing.T) {
+	body := signCLACommentBody("octocat", "https://example.org/sign")
+	assert.True(t, strings.HasPrefix(body, "@octocat please <a href='https://example.org/sign' target='_blank'>sign the CLA</a>"))
+	assert.True(t, strings.Contains(body, signCLACommentMarker))
+}
+
+func TestCheckRunActions(t *testing.T) {
+	assert.Len(t, checkRunActions(false), 1)
+	actions := checkRunActions(true)
+	assert.Len(t, actions, 2)
+	for _, action := range actions {
+		assert.LessOrEqual(t, len(action.Label), 20)
+		assert.LessOrEqual(t, len(action.Description), 40)
+		assert.LessOrEqual(t, len(action.Identifier), 20)
+	}
+}
+
+func TestPullRequestIDFromCheckRun(t *testing.T) {
+	id, err := PullRequestIDFromCheckRun(&github.CheckRun{ExternalID: github.String("42")})
+	assert.Nil(t, err)
+	assert.Equal(t, 42, id)
+
+	id, err = PullRequestIDFromCheckRun(&github.CheckRun{PullRequests: []*github.PullRequest{{Number: github.Int(7)}}})
+	assert.Nil(t, err)
+	assert.Equal(t, 7, id)
+
+	_, err = PullRequestIDFromCheckRun(&github.CheckRun{})
+	assert.NotNil(t, err)
+}
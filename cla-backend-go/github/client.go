@@ -13,6 +13,7 @@ import (
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/shurcooL/githubv4"
 
+	"github.com/communitybridge/easycla/cla-backend-go/telemetry"
 	"github.com/google/go-github/v37/github"
 	"golang.org/x/oauth2"
 )
@@ -74,7 +75,7 @@ func CheckAndWrapForKnownErrors(resp *github.Response, err error) (bool, error)
 
 // NewGithubAppClient creates a new github client from the supplied installationID
 func NewGithubAppClient(installationID int64) (*github.Client, error) {
-	itr, err := ghinstallation.New(http.DefaultTransport, int64(getGithubAppID()), installationID, []byte(getGithubAppPrivateKey()))
+	itr, err := ghinstallation.New(telemetry.NewInstrumentedTransport(telemetry.ProviderGitHub, http.DefaultTransport), int64(getGithubAppID()), installationID, []byte(getGithubAppPrivateKey()))
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +84,7 @@ func NewGithubAppClient(installationID int64) (*github.Client, error) {
 
 // NewGithubV4AppClient creates a new github v4 client from the supplied installationID
 func NewGithubV4AppClient(installationID int64) (*githubv4.Client, error) {
-	authTransport, err := ghinstallation.New(http.DefaultTransport, int64(getGithubAppID()), installationID, []byte(getGithubAppPrivateKey()))
+	authTransport, err := ghinstallation.New(telemetry.NewInstrumentedTransport(telemetry.ProviderGitHub, http.DefaultTransport), int64(getGithubAppID()), installationID, []byte(getGithubAppPrivateKey()))
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +98,10 @@ func NewGithubOauthClient() *github.Client {
 
 // NewGithubOauthClientWithAccessToken creates github client from specified accessToken
 func NewGithubOauthClientWithAccessToken(accessToken string) *github.Client {
-	ctx := context.TODO()
+	// The oauth2 client uses the instrumented base client from the context
+	ctx := context.WithValue(context.TODO(), oauth2.HTTPClient, &http.Client{
+		Transport: telemetry.NewInstrumentedTransport(telemetry.ProviderGitHub, http.DefaultTransport),
+	})
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: accessToken},
 	)
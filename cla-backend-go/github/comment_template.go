@@ -0,0 +1,95 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/v2/comment_templates"
+)
+
+// CommentTemplate is the comment template of the CLA Group of a pull request
+type CommentTemplate struct {
+	Template     *comment_templates.Template
+	ClaGroupName string
+}
+
+// renderCLAComment renders the CLA comment with the comment template of the CLA Group
+func renderCLAComment(commentTemplate *CommentTemplate, signURL, CLALandingPage, CLALogoURL string, signed, missing []*UserCommitSummary) (string, error) {
+	allSigned := len(missing) == 0
+	missingID := false
+	for _, userSummary := range missing {
+		if !userSummary.IsValid() {
+			missingID = true
+		}
+	}
+
+	landingPage := fmt.Sprintf("%s/#/?version=2", CLALandingPage)
+	badgeLink := signURL
+	if allSigned {
+		badgeLink = landingPage
+	}
+	badgeURL, badgeAlt := commentBadgeImage(allSigned, missingID, false, CLALogoURL)
+
+	data := &comment_templates.Data{
+		Provider:    "github",
+		ProjectName: commentTemplate.ClaGroupName,
+		SignURL:     signURL,
+		HelpURL:     help,
+		SupportURL:  supportURL,
+		LandingPage: landingPage,
+		Badge:       comment_templates.BadgeHTML(badgeLink, badgeURL, badgeAlt),
+		BadgeURL:    badgeURL,
+		BadgeAlt:    badgeAlt,
+	}
+
+	var exempt, authorized []*UserCommitSummary
+	for _, summary := range signed {
+		if summary.Exempt {
+			exempt = append(exempt, summary)
+		} else {
+			authorized = append(authorized, summary)
+		}
+	}
+	data.Signed = templateAuthors(authorized, func(*UserCommitSummary) string { return "" })
+	data.Exempt = templateAuthors(exempt, func(summary *UserCommitSummary) string { return summary.ExemptReason })
+	data.Missing = templateAuthors(missing, func(summary *UserCommitSummary) string {
+		switch {
+		case !summary.IsValid():
+			return comment_templates.ReasonMissingID
+		case !summary.Affiliated && !summary.Authorized:
+			return comment_templates.ReasonConfirmAffiliation
+		default:
+			return comment_templates.ReasonNotSigned
+		}
+	})
+
+	return comment_templates.Render(commentTemplate.Template, data)
+}
+
+// templateAuthors groups the commits of each identity into the authors listed by the comment templates
+func templateAuthors(summaries []*UserCommitSummary, reason func(summary *UserCommitSummary) string) []*comment_templates.Author {
+	var authors []*comment_templates.Author
+	byIdentity := make(map[string]*comment_templates.Author)
+	for _, summary := range summaries {
+		key := summary.IdentityKey()
+		if author, ok := byIdentity[key]; ok && key != "" {
+			author.Commits = append(author.Commits, summary.SHA)
+			continue
+		}
+
+		author := &comment_templates.Author{
+			Email:   summary.GetCommitAuthorEmail(),
+			Commits: []string{summary.SHA},
+			Reason:  reason(summary),
+		}
+		if summary.CommitAuthor != nil {
+			author.Name = summary.CommitAuthor.GetName()
+			author.Login = summary.CommitAuthor.GetLogin()
+		}
+		byIdentity[key] = author
+		authors = append(authors, author)
+	}
+	return authors
+}
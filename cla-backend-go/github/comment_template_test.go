@@ -0,0 +1,50 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/v2/comment_templates"
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderCLAComment(t *testing.T) {
+	octocat := &github.User{ID: github.Int64(1), Login: github.String("octocat"), Name: github.String("Octo Cat")}
+	dependabot := &github.User{ID: github.Int64(2), Login: github.String("dependabot[bot]"), Type: github.String("Bot")}
+	jane := &github.User{ID: github.Int64(3), Login: github.String("jane"), Email: github.String("jane@example.org")}
+
+	signed := []*UserCommitSummary{
+		{SHA: "aaa111", CommitAuthor: octocat, Authorized: true, Affiliated: true},
+		{SHA: "bbb222", CommitAuthor: octocat, Authorized: true, Affiliated: true},
+		{SHA: "ccc333", CommitAuthor: dependabot, Exempt: true, ExemptReason: "GitHub App bot"},
+	}
+	missing := []*UserCommitSummary{
+		{SHA: "ddd444", CommitAuthor: jane, Affiliated: true},
+		{SHA: "eee555", CommitAuthor: &github.User{Name: github.String("no id")}},
+	}
+
+	commentTemplate := &CommentTemplate{
+		Template: &comment_templates.Template{
+			Comment: "{{projectName}} {{{badge}}}{{#each signed}} [{{login}}: {{commits}}]{{/each}}{{#each missing}} [{{login}}: {{reason}}]{{/each}}{{#each exempt}} [{{login}}: {{reason}}]{{/each}}",
+		},
+		ClaGroupName: "Example",
+	}
+
+	comment, err := renderCLAComment(commentTemplate, "https://sign.example.org", "https://landing.example.org", "https://logo.example.org", signed, missing)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(comment, `Example <a href="https://sign.example.org"><img src="https://logo.example.org/cla-missing-id.svg" alt="CLA Missing ID"`))
+	assert.Contains(t, comment, "[octocat: aaa111, bbb222]")
+	assert.Contains(t, comment, "[jane: not-signed]")
+	assert.Contains(t, comment, "[: missing-id]")
+	assert.Contains(t, comment, "[dependabot[bot]: GitHub App bot]")
+	assert.Contains(t, comment, comment_templates.StatusMarker(false))
+
+	comment, err = renderCLAComment(commentTemplate, "https://sign.example.org", "https://landing.example.org", "https://logo.example.org", signed, nil)
+	assert.Nil(t, err)
+	assert.Contains(t, comment, `<a href="https://landing.example.org/#/?version=2"><img src="https://logo.example.org/cla-signed.svg" alt="CLA Signed"`)
+	assert.Contains(t, comment, comment_templates.StatusMarker(true))
+}
@@ -0,0 +1,178 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v37/github"
+)
+
+// Commit identity roles - the reason an identity has to be covered by a CLA
+const (
+	CommitRoleAuthor    = "author"
+	CommitRoleCommitter = "committer"
+	CommitRoleCoAuthor  = "co-author"
+
+	// gitHubWebFlowLogin is the committer of commits created through the GitHub web UI - never a contributor
+	gitHubWebFlowLogin = "web-flow"
+	gitHubNoReplyHost  = "users.noreply.github.com"
+)
+
+var (
+	coAuthorTrailerRegex = regexp.MustCompile(`(?im)^\s*co-authored-by:\s*(.*?)\s*<([^<>\s]+@[^<>\s]+)>\s*$`)
+	noReplyEmailRegex    = regexp.MustCompile(`^(?:(\d+)\+)?([^@+]+)@` + regexp.QuoteMeta(gitHubNoReplyHost) + `$`)
+)
+
+// CommitIdentityPolicy defines which commit identities require CLA coverage
+type CommitIdentityPolicy struct {
+	Authors    bool
+	Committers bool
+	CoAuthors  bool
+}
+
+// DefaultCommitIdentityPolicy is the policy used when the CLA Group has not configured one - commit authors only
+func DefaultCommitIdentityPolicy() CommitIdentityPolicy {
+	return CommitIdentityPolicy{Authors: true}
+}
+
+// parseCoAuthors returns the identities listed in the Co-authored-by trailers of the commit message
+func parseCoAuthors(message string) []*github.User {
+	var coAuthors []*github.User
+	for _, match := range coAuthorTrailerRegex.FindAllStringSubmatch(message, -1) {
+		coAuthors = append(coAuthors, userFromEmail(match[1], match[2]))
+	}
+	return coAuthors
+}
+
+// userFromEmail builds a user from a name and email - GitHub noreply addresses also give us the login and user ID
+func userFromEmail(name, email string) *github.User {
+	email = strings.ToLower(strings.TrimSpace(email))
+	user := &github.User{
+		Email: github.String(email),
+	}
+	if name != "" {
+		user.Name = github.String(name)
+	}
+	if match := noReplyEmailRegex.FindStringSubmatch(email); match != nil {
+		user.Login = github.String(match[2])
+		if id, err := strconv.ParseInt(match[1], 10, 64); err == nil {
+			user.ID = github.Int64(id)
+		}
+	}
+	return user
+}
+
+// commitUser returns the GitHub user for the commit author or committer, falling back to the git name/email
+func commitUser(user *github.User, gitUser *github.CommitAuthor) *github.User {
+	if user != nil && user.ID != nil {
+		if user.Email == nil && gitUser != nil && gitUser.Email != nil {
+			withEmail := *user
+			withEmail.Email = gitUser.Email
+			return &withEmail
+		}
+		return user
+	}
+	if gitUser != nil && gitUser.Email != nil {
+		return userFromEmail(gitUser.GetName(), gitUser.GetEmail())
+	}
+	return user
+}
+
+// identityKeys returns the keys used to de-duplicate identities - the GitHub user ID, login and email
+func identityKeys(user *github.User) []string {
+	var keys []string
+	if user == nil {
+		return keys
+	}
+	if user.ID != nil {
+		keys = append(keys, "id:"+strconv.FormatInt(*user.ID, 10))
+	}
+	if user.Login != nil && *user.Login != "" {
+		keys = append(keys, "login:"+strings.ToLower(*user.Login))
+	}
+	if user.Email != nil && *user.Email != "" {
+		keys = append(keys, "email:"+strings.ToLower(*user.Email))
+	}
+	return keys
+}
+
+// IdentityKey returns a key identifying the commit identity - the GitHub user ID, login or email
+func (u UserCommitSummary) IdentityKey() string {
+	keys := identityKeys(u.CommitAuthor)
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[0]
+}
+
+// IsBot returns true when GitHub reports the identity as a bot account, such as the GitHub App accounts
+func (u UserCommitSummary) IsBot() bool {
+	return u.CommitAuthor != nil && u.CommitAuthor.GetType() == "Bot"
+}
+
+// commitIdentities returns the identities of the commit that require CLA coverage according to the policy - each
+// identity is only listed once per commit, with the first matching role. Commit authors are identified by their
+// GitHub account as before, committers and co-authors may also be identified by email.
+func commitIdentities(commit *github.RepositoryCommit, policy CommitIdentityPolicy) []*UserCommitSummary {
+	var summaries []*UserCommitSummary
+	seen := make(map[string]bool)
+	markSeen := func(user *github.User) bool {
+		keys := identityKeys(user)
+		duplicate := false
+		for _, key := range keys {
+			if seen[key] {
+				duplicate = true
+			}
+			seen[key] = true
+		}
+		return duplicate
+	}
+	add := func(user *github.User, role string) {
+		if markSeen(user) {
+			return
+		}
+		summaries = append(summaries, &UserCommitSummary{
+			SHA:          commit.GetSHA(),
+			CommitAuthor: user,
+			Role:         role,
+		})
+	}
+
+	var gitCommit *github.Commit
+	if commit.Commit != nil {
+		gitCommit = commit.Commit
+	}
+
+	if policy.Authors {
+		add(commit.Author, CommitRoleAuthor)
+	} else {
+		// the author does not need coverage, but must not be listed again as committer or co-author
+		markSeen(commit.Author)
+	}
+	if gitCommit != nil && gitCommit.Author != nil {
+		markSeen(&github.User{Email: gitCommit.Author.Email})
+	}
+
+	if policy.Committers {
+		var gitCommitter *github.CommitAuthor
+		if gitCommit != nil {
+			gitCommitter = gitCommit.Committer
+		}
+		committer := commitUser(commit.Committer, gitCommitter)
+		if committer != nil && committer.GetLogin() != gitHubWebFlowLogin {
+			add(committer, CommitRoleCommitter)
+		}
+	}
+
+	if policy.CoAuthors && gitCommit != nil {
+		for _, coAuthor := range parseCoAuthors(gitCommit.GetMessage()) {
+			add(coAuthor, CommitRoleCoAuthor)
+		}
+	}
+
+	return summaries
+}
@@ -0,0 +1,123 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoAuthors(t *testing.T) {
+	message := "Fix the parser\n\nSigned-off-by: Alice <alice@example.org>\nCo-authored-by: Bob Smith <Bob@Example.org>\nco-authored-by: carol <123+carol@users.noreply.github.com>\nCo-authored-by: missing email\n"
+
+	coAuthors := parseCoAuthors(message)
+	assert.Len(t, coAuthors, 2)
+
+	assert.Equal(t, "bob@example.org", coAuthors[0].GetEmail())
+	assert.Equal(t, "Bob Smith", coAuthors[0].GetName())
+	assert.Nil(t, coAuthors[0].Login)
+
+	assert.Equal(t, "carol", coAuthors[1].GetLogin())
+	assert.Equal(t, int64(123), coAuthors[1].GetID())
+}
+
+func TestUserFromNoReplyEmail(t *testing.T) {
+	user := userFromEmail("", "dave@users.noreply.github.com")
+	assert.Equal(t, "dave", user.GetLogin())
+	assert.Nil(t, user.ID)
+	assert.Nil(t, user.Name)
+}
+
+func TestCommitIdentities(t *testing.T) {
+	alice := &github.User{ID: github.Int64(1), Login: github.String("alice")}
+	bob := &github.User{ID: github.Int64(2), Login: github.String("bob")}
+	webFlow := &github.User{ID: github.Int64(19864447), Login: github.String(gitHubWebFlowLogin)}
+
+	commit := func(author, committer *github.User, message string) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:       github.String("abc"),
+			Author:    author,
+			Committer: committer,
+			Commit: &github.Commit{
+				Author:    &github.CommitAuthor{Email: github.String("alice@example.org")},
+				Committer: &github.CommitAuthor{Email: github.String("bob@example.org")},
+				Message:   github.String(message),
+			},
+		}
+	}
+	all := CommitIdentityPolicy{Authors: true, Committers: true, CoAuthors: true}
+
+	tests := []struct {
+		name   string
+		commit *github.RepositoryCommit
+		policy CommitIdentityPolicy
+		want   []string
+	}{
+		{
+			name:   "default policy - author only",
+			commit: commit(alice, bob, "Co-authored-by: Carol <carol@example.org>"),
+			policy: DefaultCommitIdentityPolicy(),
+			want:   []string{CommitRoleAuthor},
+		},
+		{
+			name:   "all roles",
+			commit: commit(alice, bob, "Co-authored-by: Carol <carol@example.org>"),
+			policy: all,
+			want:   []string{CommitRoleAuthor, CommitRoleCommitter, CommitRoleCoAuthor},
+		},
+		{
+			name:   "author is also the committer",
+			commit: commit(alice, alice, ""),
+			policy: all,
+			want:   []string{CommitRoleAuthor},
+		},
+		{
+			name:   "web-flow committer is skipped",
+			commit: commit(alice, webFlow, ""),
+			policy: all,
+			want:   []string{CommitRoleAuthor},
+		},
+		{
+			name:   "co-author repeating the author email is skipped",
+			commit: commit(alice, alice, "Co-authored-by: Alice <ALICE@example.org>"),
+			policy: all,
+			want:   []string{CommitRoleAuthor},
+		},
+		{
+			name:   "co-authors only",
+			commit: commit(alice, bob, "Co-authored-by: Carol <carol@example.org>\nCo-authored-by: Bob <bob@example.org>"),
+			policy: CommitIdentityPolicy{CoAuthors: true},
+			want:   []string{CommitRoleCoAuthor, CommitRoleCoAuthor},
+		},
+		{
+			name:   "committer without GitHub account",
+			commit: commit(alice, nil, ""),
+			policy: CommitIdentityPolicy{Committers: true},
+			want:   []string{CommitRoleCommitter},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var roles []string
+			for _, identity := range commitIdentities(tt.commit, tt.policy) {
+				assert.Equal(t, "abc", identity.SHA)
+				roles = append(roles, identity.Role)
+			}
+			assert.Equal(t, tt.want, roles)
+		})
+	}
+}
+
+func TestCommitIdentityValidity(t *testing.T) {
+	coAuthor := &UserCommitSummary{CommitAuthor: userFromEmail("Carol", "carol@example.org"), Role: CommitRoleCoAuthor}
+	assert.True(t, coAuthor.IsValid())
+	assert.Contains(t, coAuthor.getUserInfo(false), "email: carol@example.org")
+	assert.Contains(t, coAuthor.getUserInfo(false), "(co-author)")
+
+	author := &UserCommitSummary{CommitAuthor: userFromEmail("Carol", "carol@example.org"), Role: CommitRoleAuthor}
+	assert.False(t, author.IsValid())
+}
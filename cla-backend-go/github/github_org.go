@@ -41,6 +41,22 @@ func GetMembership(ctx context.Context, user, organizationName string) (*github.
 	return membership, nil
 }
 
+// OrganizationMembership checks the GitHub organization approval lists of the corporate signatures
+type OrganizationMembership struct{}
+
+// IsMember returns true if the GitHub user is a member of the GitHub organization
+func (OrganizationMembership) IsMember(ctx context.Context, user, organizationName string) (bool, error) {
+	membership, err := GetMembership(ctx, user, organizationName)
+	if err != nil {
+		// GitHub reports the users outside the organization as not found
+		if err == ErrGithubOrganizationNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return membership != nil, nil
+}
+
 // GetOrganization gets github organization
 func GetOrganization(ctx context.Context, organizationName string) (*github.Organization, error) {
 	f := logrus.Fields{
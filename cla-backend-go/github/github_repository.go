@@ -13,6 +13,7 @@ import (
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/comment_templates"
 	"github.com/sirupsen/logrus"
 
 	"github.com/communitybridge/easycla/cla-backend-go/logging"
@@ -26,6 +27,7 @@ var (
 
 const (
 	help         = "https://help.github.com/en/github/committing-changes-to-your-project/why-are-my-commits-linked-to-the-wrong-user"
+	supportURL   = "https://jira.linuxfoundation.org/servicedesk/customer/portal/4"
 	unknown      = "Unknown"
 	failureState = "failure"
 	successState = "success"
@@ -81,6 +83,11 @@ type UserCommitSummary struct {
 	CommitAuthor *github.User
 	Affiliated   bool
 	Authorized   bool
+	// Role is the reason the identity needs CLA coverage - author, committer or co-author
+	Role string
+	// Exempt is set for bots and service accounts matching the bot allowlist - they are listed with the signed authors
+	Exempt       bool
+	ExemptReason string
 }
 
 // GetCommitAuthorID commit author username ID (numeric value as a string) if available, otherwise returns empty string
@@ -115,11 +122,15 @@ func (u UserCommitSummary) GetCommitAuthorEmail() string {
 	return ""
 }
 
-// IsValid returns true if the commit author information is available
+// IsValid returns true if the commit author information is available - committers and co-authors may be identified
+// by their email address alone
 func (u UserCommitSummary) IsValid() bool {
 	valid := false
 	if u.CommitAuthor != nil {
 		valid = u.CommitAuthor.ID != nil && (u.CommitAuthor.Login != nil || u.CommitAuthor.Name != nil)
+		if !valid && u.Role != "" && u.Role != CommitRoleAuthor {
+			valid = u.GetCommitAuthorEmail() != ""
+		}
 	}
 	return valid
 }
@@ -157,68 +168,99 @@ func (u UserCommitSummary) getUserInfo(tagUser bool) string {
 		tagValue = "@"
 	}
 	if u.CommitAuthor != nil {
-		if *u.CommitAuthor.Login != "" {
+		if u.CommitAuthor.Login != nil && *u.CommitAuthor.Login != "" {
 			sb.WriteString(fmt.Sprintf("login: %s%s / ", tagValue, *u.CommitAuthor.Login))
 		}
 
 		if u.CommitAuthor.Name != nil {
 			sb.WriteString(fmt.Sprintf("%sname: %s / ", userInfo, utils.StringValue(u.CommitAuthor.Name)))
 		}
+
+		if u.CommitAuthor.Login == nil && u.CommitAuthor.Email != nil {
+			sb.WriteString(fmt.Sprintf("email: %s / ", utils.StringValue(u.CommitAuthor.Email)))
+		}
+	}
+
+	if u.Role != "" && u.Role != CommitRoleAuthor {
+		sb.WriteString(fmt.Sprintf("(%s) ", u.Role))
 	}
 
 	return strings.Replace(sb.String(), "/ $", "", -1)
 }
 
-func GetPullRequestCommitAuthors(ctx context.Context, installationID int64, pullRequestID int, owner, repo string) ([]*UserCommitSummary, *string, error) {
+// GetPullRequestCommitAuthors returns the commit identities of the pull request that require CLA coverage according
+// to the policy, along with the latest commit SHA. Pull requests above the 250 commits limit of the pull request
+// commits API are loaded from the branch history instead.
+func GetPullRequestCommitAuthors(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, policy CommitIdentityPolicy) (*PullRequestCommits, error) {
 	f := logrus.Fields{
 		"functionName":  "github.github_repository.GetPullRequestCommitAuthors",
 		"pullRequestID": pullRequestID,
+		"policy":        fmt.Sprintf("%+v", policy),
 	}
-	var userCommitSummary []*UserCommitSummary
 
 	client, err := NewGithubAppClient(installationID)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warn("unable to create Github client")
-		return nil, nil, err
+		return nil, err
 	}
 
-	commits, resp, comErr := client.PullRequests.ListCommits(ctx, owner, repo, pullRequestID, &github.ListOptions{})
-	if comErr != nil {
-		log.WithFields(f).WithError(comErr).Warnf("problem listing commits for repo: %s/%s pull request: %d", owner, repo, pullRequestID)
-		return nil, nil, comErr
+	pullRequest, resp, prErr := client.PullRequests.Get(ctx, owner, repo, pullRequestID)
+	if prErr != nil {
+		log.WithFields(f).WithError(prErr).Warnf("problem loading repo: %s/%s pull request: %d", owner, repo, pullRequestID)
+		return nil, prErr
 	}
 	if resp.StatusCode != http.StatusOK {
 		msg := fmt.Sprintf("unexpected status code: %d - expected: %d", resp.StatusCode, http.StatusOK)
 		log.WithFields(f).Warn(msg)
-		return nil, nil, errors.New(msg)
+		return nil, errors.New(msg)
+	}
+
+	result := &PullRequestCommits{
+		LatestSHA:    pullRequest.GetHead().GetSHA(),
+		TotalCommits: pullRequest.GetCommits(),
+		Strategy:     CommitStrategyPullRequest,
 	}
 
-	log.WithFields(f).Debugf("found %d commits for pull request: %d", len(commits), pullRequestID)
+	var commits []*github.RepositoryCommit
+	if result.TotalCommits > pullRequestCommitsLimit {
+		log.WithFields(f).Debugf("pull request has %d commits - above the API limit of %d, loading the branch history", result.TotalCommits, pullRequestCommitsLimit)
+		result.Strategy = CommitStrategyBranchHistory
+		commits, err = listBranchHistoryCommits(ctx, client, owner, repo, pullRequest.GetBase().GetSHA(), result.LatestSHA, maxBranchHistoryCommits)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the branch history - falling back to the pull request commits")
+			result.Strategy = CommitStrategyPullRequest
+		}
+	}
+	if result.Strategy == CommitStrategyPullRequest {
+		commits, err = listPullRequestCommits(ctx, client, owner, repo, pullRequestID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem listing commits for repo: %s/%s pull request: %d", owner, repo, pullRequestID)
+			return nil, err
+		}
+	}
+	commits = uniqueCommits(commits)
+	result.LoadedCommits = len(commits)
+
+	log.WithFields(f).Debugf("loaded %d of %d commits for pull request: %d using strategy: %s", len(commits), result.TotalCommits, pullRequestID, result.Strategy)
 	for _, commit := range commits {
-		log.WithFields(f).Debugf("loaded commit: %+v", commit)
-		commitAuthor := ""
-		if commit.Commit != nil && commit.Commit.Author != nil && commit.Commit.Author.Login != nil {
-			log.WithFields(f).Debugf("commit.Commit.Author: %s", utils.StringValue(commit.Commit.Author.Login))
-			commitAuthor = utils.StringValue(commit.Commit.Author.Login)
-		} else if commit.Author != nil && commit.Author.Login != nil {
-			log.WithFields(f).Debugf("commit.Author.Login: %s", utils.StringValue(commit.Author.Login))
-			commitAuthor = utils.StringValue(commit.Author.Login)
+		identities := commitIdentities(commit, policy)
+		for _, identity := range identities {
+			log.WithFields(f).Debugf("commit: %s %s: %s", identity.SHA, identity.Role, identity.getUserInfo(false))
 		}
-		log.WithFields(f).Debugf("commitAuthor: %s", commitAuthor)
-		userCommitSummary = append(userCommitSummary, &UserCommitSummary{
-			SHA:          *commit.SHA,
-			CommitAuthor: commit.Author,
-			Affiliated:   false,
-			Authorized:   false,
-		})
+		result.Authors = append(result.Authors, identities...)
 	}
 
-	// get latest commit SHA
-	latestCommitSHA := commits[len(commits)-1].SHA
-	return userCommitSummary, latestCommitSHA, nil
+	// the pull request head is the latest commit - only fall back to the listed commits if it is missing
+	if result.LatestSHA == "" && len(commits) > 0 {
+		result.LatestSHA = commits[len(commits)-1].GetSHA()
+	}
+	return result, nil
 }
 
-func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, repoID *int64, latestSHA string, signed []*UserCommitSummary, missing []*UserCommitSummary, CLABaseAPIURL, CLALandingPage, CLALogoURL string) error {
+// UpdatePullRequest updates the CLA comment and publishes the CLA check result for the pull request - the commits note
+// describes how the commits were loaded and is added to the check output. The check fails when the commits were
+// truncated, whatever the status of the loaded commit authors.
+func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, repoID *int64, latestSHA, commitsNote string, commitsTruncated bool, signed []*UserCommitSummary, missing []*UserCommitSummary, commentTemplate *CommentTemplate, CLABaseAPIURL, CLALandingPage, CLALogoURL string) error {
 	f := logrus.Fields{
 		"functionName":   "github.github_repository.UpdatePullRequest",
 		"installationID": installationID,
@@ -249,7 +291,7 @@ func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID
 		return failedErr
 	}
 
-	body := assembleCLAComment(ctx, int(installationID), pullRequestID, repoID, signed, missing, CLABaseAPIURL, CLALogoURL, CLALandingPage)
+	body := assembleCLAComment(ctx, int(installationID), pullRequestID, repoID, signed, missing, commentTemplate, CLABaseAPIURL, CLALogoURL, CLALandingPage)
 
 	if len(missing) == 0 {
 		// All contributors are passing
@@ -280,7 +322,7 @@ func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID
 			// If we have previously succeeded, then we also need to update the comment (pass => fail)
 			log.WithFields(f).Debugf("Found previously succeeeded checks - updating the CLA comment in the PR : %d", pullRequestID)
 			// Generate a new comment with all the failed CLA info
-			failedComment := assembleCLAComment(ctx, int(installationID), pullRequestID, repoID, signed, missing, CLABaseAPIURL, CLALogoURL, CLALandingPage)
+			failedComment := assembleCLAComment(ctx, int(installationID), pullRequestID, repoID, signed, missing, commentTemplate, CLABaseAPIURL, CLALogoURL, CLALandingPage)
 			previousSucceededComment.Body = &failedComment
 			_, _, err = client.Issues.EditComment(ctx, owner, repo, *previousSucceededComment.ID, previousSucceededComment)
 			if err != nil {
@@ -299,17 +341,42 @@ func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID
 		}
 	}
 
+	// Publish the check run - fall back to the commit status below if the installation has not granted the checks permission
+	checkRunSignURL := getFullSignURL("github", strconv.Itoa(int(installationID)), strconv.Itoa(int(*repoID)), strconv.Itoa(pullRequestID), CLABaseAPIURL)
+	_, checkResp, checkErr := CreateOrUpdateCheckRun(ctx, client, &CheckRunInput{
+		Owner:            owner,
+		Repo:             repo,
+		HeadSHA:          latestSHA,
+		PullRequestID:    pullRequestID,
+		InstallationID:   installationID,
+		RepositoryID:     *repoID,
+		Signed:           signed,
+		Missing:          missing,
+		SignURL:          checkRunSignURL,
+		LandingPage:      CLALandingPage,
+		CommitsNote:      commitsNote,
+		CommitsTruncated: commitsTruncated,
+	})
+	if checkErr == nil {
+		return nil
+	}
+	if !isCheckRunPermissionError(checkResp) {
+		log.WithFields(f).WithError(checkErr).Warn("unable to publish check run")
+		return checkErr
+	}
+	log.WithFields(f).WithError(checkErr).Warn("check runs are not permitted for this installation - falling back to the commit status")
+
 	// Update/Create the status
-	context := "EasyCLA"
+	context := CheckRunName
 	var statusBody string
 	var state string
 	var signURL string
 
-	if len(missing) > 0 {
+	if len(missing) > 0 || commitsTruncated {
 		state = failureState
 		context, statusBody = assembleCLAStatus(context, false)
 		signURL = getFullSignURL("github", strconv.Itoa(int(installationID)), strconv.Itoa(int(*repoID)), strconv.Itoa(pullRequestID), CLABaseAPIURL)
-		log.WithFields(f).Debugf("Creating new CLA %s status - %d passed, %d missing, signing url %s", state, len(signed), len(missing), signURL)
+		log.WithFields(f).Debugf("Creating new CLA %s status - %d passed, %d missing, commits truncated: %t, signing url %s", state, len(signed), len(missing), commitsTruncated, signURL)
 	} else if len(signed) > 0 {
 		state = successState
 		context, statusBody = assembleCLAStatus(context, true)
@@ -342,6 +409,68 @@ func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID
 	return nil
 }
 
+// PublishCLANotRequired publishes a passing EasyCLA check on the head commit of the pull request when the repository
+// requirements skip the CLA check, falling back to the commit status if the installation has not granted the checks
+// permission - the commit authors are not checked and the CLA comment is left as is
+func PublishCLANotRequired(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, repoID int64, latestSHA, reason, CLALandingPage string) error {
+	f := logrus.Fields{
+		"functionName":   "github.github_repository.PublishCLANotRequired",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"installationID": installationID,
+		"owner":          owner,
+		"repo":           repo,
+		"SHA":            latestSHA,
+		"pullRequestID":  pullRequestID,
+		"reason":         reason,
+	}
+
+	client, err := NewGithubAppClient(installationID)
+	if err != nil || client == nil {
+		log.WithFields(f).WithError(err).Warn("unable to create Github client")
+		return err
+	}
+
+	_, checkResp, checkErr := CreateOrUpdateCheckRun(ctx, client, &CheckRunInput{
+		Owner:             owner,
+		Repo:              repo,
+		HeadSHA:           latestSHA,
+		PullRequestID:     pullRequestID,
+		InstallationID:    installationID,
+		RepositoryID:      repoID,
+		LandingPage:       CLALandingPage,
+		NotRequiredReason: reason,
+	})
+	if checkErr == nil {
+		return nil
+	}
+	if !isCheckRunPermissionError(checkResp) {
+		log.WithFields(f).WithError(checkErr).Warn("unable to publish check run")
+		return checkErr
+	}
+	log.WithFields(f).WithError(checkErr).Warn("check runs are not permitted for this installation - falling back to the commit status")
+
+	state := successState
+	statusContext := CheckRunName
+	targetURL := fmt.Sprintf("%s/#/?version=2", CLALandingPage)
+	description := fmt.Sprintf("%s: %s", checkRunNotRequiredTitle, reason)
+	// GitHub rejects commit status descriptions longer than 140 characters
+	if len(description) > 140 {
+		description = description[:137] + "..."
+	}
+	_, _, err = CreateStatus(ctx, client, owner, repo, latestSHA, &Status{
+		State:       &state,
+		TargetURL:   &targetURL,
+		Context:     &statusContext,
+		Description: &description,
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create status")
+		return err
+	}
+
+	return nil
+}
+
 func hasCheckPreviouslyFailed(ctx context.Context, client *github.Client, owner, repo string, pullRequestID int) (bool, *github.IssueComment, error) {
 	f := logrus.Fields{
 		"functionName": "github.github_repository.hasCheckPreviouslyFailed",
@@ -363,6 +492,9 @@ func hasCheckPreviouslyFailed(ctx context.Context, client *github.Client, owner,
 		if strings.Contains(*comment.Body, "is missing the User") {
 			return true, comment, nil
 		}
+		if strings.Contains(*comment.Body, comment_templates.StatusMarker(false)) {
+			return true, comment, nil
+		}
 	}
 	return false, nil, nil
 }
@@ -382,6 +514,9 @@ func hasCheckPreviouslySucceeded(ctx context.Context, client *github.Client, own
 		if strings.Contains(*comment.Body, "The committers listed above are authorized under a signed CLA.") {
 			return true, comment, nil
 		}
+		if strings.Contains(*comment.Body, comment_templates.StatusMarker(true)) {
+			return true, comment, nil
+		}
 	}
 
 	return false, nil, nil
@@ -397,7 +532,7 @@ func assembleCLAStatus(authorName string, signed bool) (string, string) {
 	return authorName, "Missing CLA Authorization."
 }
 
-func assembleCLAComment(ctx context.Context, installationID, pullRequestID int, repositoryID *int64, signed, missing []*UserCommitSummary, apiBaseURL, CLALogoURL, CLALandingPage string) string {
+func assembleCLAComment(ctx context.Context, installationID, pullRequestID int, repositoryID *int64, signed, missing []*UserCommitSummary, commentTemplate *CommentTemplate, apiBaseURL, CLALogoURL, CLALandingPage string) string {
 	f := logrus.Fields{
 		"functionName":   "github.github_repository.assembleCLAComment",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -410,13 +545,21 @@ func assembleCLAComment(ctx context.Context, installationID, pullRequestID int,
 	repositoryType := "github"
 	missingID := false
 	for _, userSummary := range missing {
-		if userSummary.GetCommitAuthorID() == "" {
+		if !userSummary.IsValid() {
 			missingID = true
 		}
 	}
 
 	log.WithFields(f).Debug("Building CLAComment body ")
 	signURL := getFullSignURL(repositoryType, strconv.Itoa(installationID), strconv.Itoa(int(*repositoryID)), strconv.Itoa(pullRequestID), apiBaseURL)
+	if commentTemplate != nil && commentTemplate.Template != nil {
+		log.WithFields(f).Debug("rendering the CLA comment with the comment template of the CLA Group")
+		comment, err := renderCLAComment(commentTemplate, signURL, CLALandingPage, CLALogoURL, signed, missing)
+		if err == nil {
+			return comment
+		}
+		log.WithFields(f).WithError(err).Warn("unable to render the comment template - using the default comment")
+	}
 	commentBody := getCommentBody(repositoryType, signURL, signed, missing)
 	allSigned := len(missing) == 0
 	badge := getCommentBadge(allSigned, signURL, missingID, false, CLALandingPage, CLALogoURL)
@@ -447,6 +590,10 @@ func getCommentBody(repositoryType, signURL string, signed, missing []*UserCommi
 			for _, summary := range v {
 				shas = append(shas, summary.SHA)
 				log.WithFields(f).Debugf("SHAS for signed users: %s", shas)
+				if summary.Exempt {
+					committersComment.WriteString(fmt.Sprintf("<li>%s%s(%s) - exempt: %s</li>", success, k, strings.Join(shas, ", "), summary.ExemptReason))
+					continue
+				}
 				committersComment.WriteString(fmt.Sprintf("<li>%s%s(%s)</li>", success, k, strings.Join(shas, ", ")))
 			}
 		}
@@ -454,7 +601,6 @@ func getCommentBody(repositoryType, signURL string, signed, missing []*UserCommi
 
 	if len(missing) > 0 {
 		log.WithFields(f).Debugf("processing %d missing contributors", len(missing))
-		supportURL := "https://jira.linuxfoundation.org/servicedesk/customer/portal/4"
 		committers := getAuthorInfoCommits(missing, true)
 		helpURL := help
 
@@ -499,33 +645,34 @@ func getCommentBody(repositoryType, signURL string, signed, missing []*UserCommi
 }
 
 func getCommentBadge(allSigned bool, signURL string, missingUserId, managerApproved bool, CLALandingPage, CLALogoURL string) string {
-	var alt string
 	var text string
 	var badgeHyperLink string
-	var badgeURL string
 
+	badgeURL, alt := commentBadgeImage(allSigned, missingUserId, managerApproved, CLALogoURL)
 	if allSigned {
-		badgeURL = fmt.Sprintf("%s/cla-signed.svg", CLALogoURL)
 		badgeHyperLink = fmt.Sprintf("%s/#/?version=2", CLALandingPage)
-		alt = "CLA Signed"
 		return fmt.Sprintf(`<a href="%s"><img src="%s" alt="%s" align="left" height="28" width="328" >`, badgeHyperLink, badgeURL, alt)
 	}
 	badgeHyperLink = signURL
-	if missingUserId {
-		badgeURL = fmt.Sprintf("%s/cla-missing-id.svg", CLALogoURL)
-		alt = "CLA Missing ID"
-	} else if managerApproved {
-		badgeURL = fmt.Sprintf("%s/cla-confirmation-needed.svg", CLALogoURL)
-		alt = "CLA Confirmation Needed"
-	} else {
-		badgeURL = fmt.Sprintf("%s/cla-not-signed.svg", CLALogoURL)
-		alt = "CLA Not Signed"
-	}
 
 	text = fmt.Sprintf(`<a href="%s"><img src="%s" alt="%s" align="left" height="28" width="328" >`, badgeHyperLink, badgeURL, alt)
 	return fmt.Sprintf("%s<br/>", text)
 }
 
+// commentBadgeImage returns the image and alt text of the comment badge
+func commentBadgeImage(allSigned, missingUserId, managerApproved bool, CLALogoURL string) (string, string) {
+	switch {
+	case allSigned:
+		return fmt.Sprintf("%s/cla-signed.svg", CLALogoURL), "CLA Signed"
+	case missingUserId:
+		return fmt.Sprintf("%s/cla-missing-id.svg", CLALogoURL), "CLA Missing ID"
+	case managerApproved:
+		return fmt.Sprintf("%s/cla-confirmation-needed.svg", CLALogoURL), "CLA Confirmation Needed"
+	default:
+		return fmt.Sprintf("%s/cla-not-signed.svg", CLALogoURL), "CLA Not Signed"
+	}
+}
+
 func getFullSignURL(repositoryType, installationID, githubRepositoryID, pullRequestID, apiBaseURL string) string {
 	return fmt.Sprintf("%s/v2/repository-provider/%s/sign/%s/%s/%s/#/?version=2", apiBaseURL, repositoryType, installationID, githubRepositoryID, pullRequestID)
 }
@@ -549,6 +696,22 @@ func getAuthorInfoCommits(userSummary []*UserCommitSummary, tagUser bool) map[st
 	return result
 }
 
+// UniqueAuthors keeps the first commit summary of each author, in order - the pull request comment and check run
+// list each author once, whatever the number of commits
+func UniqueAuthors(userSummary []*UserCommitSummary) []*UserCommitSummary {
+	seen := make(map[string]bool, len(userSummary))
+	unique := make([]*UserCommitSummary, 0, len(userSummary))
+	for _, author := range userSummary {
+		key := author.getUserInfo(false)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, author)
+	}
+	return unique
+}
+
 // GetRepositoryByExternalID finds github repository by github repository id
 func GetRepositoryByExternalID(ctx context.Context, installationID, id int64) (*github.Repository, error) {
 	client, err := NewGithubAppClient(installationID)
@@ -0,0 +1,223 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/google/go-github/v37/github"
+	"github.com/sirupsen/logrus"
+)
+
+// the commit status description is limited to 140 characters
+const maxStatusDescriptionLength = 140
+
+var (
+	// mergeGroupHeadRefPattern matches the merge queue branches - gh-readonly-queue/<base branch>/pr-<number>-<sha>
+	mergeGroupHeadRefPattern = regexp.MustCompile(`gh-readonly-queue/.+/pr-(\d+)-[0-9a-fA-F]+$`)
+	// mergeGroupCommitPattern matches the title of the merge queue head commit - "Merge pull request #123 from ..." for
+	// merge commits, "Title (#123)" for squashed commits
+	mergeGroupCommitPattern = regexp.MustCompile(`^(?:Merge pull request #(\d+) from \S+|.+ \(#(\d+)\))$`)
+)
+
+// MergeGroupInput contains the details needed to publish the EasyCLA status of a merge group
+type MergeGroupInput struct {
+	InstallationID int64
+	Owner          string
+	Repo           string
+	HeadSHA        string
+	HeadRef        string
+	LandingPage    string
+}
+
+// NewMergeGroupInput returns the details of a merge group of the repository with the owner/repo full name
+func NewMergeGroupInput(installationID int64, repositoryName, headSHA, headRef, landingPage string) (*MergeGroupInput, error) {
+	owner, repo := repositoryName, ""
+	if parts := strings.SplitN(repositoryName, "/", 2); len(parts) == 2 {
+		owner, repo = parts[0], parts[1]
+	}
+	if owner == "" || repo == "" {
+		return nil, fmt.Errorf("invalid repository name : %s", repositoryName)
+	}
+
+	return &MergeGroupInput{
+		InstallationID: installationID,
+		Owner:          owner,
+		Repo:           repo,
+		HeadSHA:        headSHA,
+		HeadRef:        headRef,
+		LandingPage:    landingPage,
+	}, nil
+}
+
+// MergeGroupPullRequest is the EasyCLA result of a pull request of a merge group
+type MergeGroupPullRequest struct {
+	Number  int
+	HeadSHA string
+	HTMLURL string
+	Passed  bool
+	// Evaluated is false when EasyCLA has not (yet) reported a result for the head commit of the pull request
+	Evaluated bool
+}
+
+// PullRequestIDFromMergeGroupRef returns the pull request number of a merge queue branch, such as
+// refs/heads/gh-readonly-queue/main/pr-123-0123456789abcdef
+func PullRequestIDFromMergeGroupRef(headRef string) (int, error) {
+	matches := mergeGroupHeadRefPattern.FindStringSubmatch(headRef)
+	if matches == nil {
+		return 0, fmt.Errorf("unable to determine the pull request of the merge group ref: %s", headRef)
+	}
+	return strconv.Atoi(matches[1])
+}
+
+// mergeGroupPullRequestID returns the pull request of a merge group - the pull request of the head ref. The pull
+// requests ahead in the queue have their own merge groups, so only the head commit is considered: when its title
+// references a pull request, it must be the one of the head ref.
+func mergeGroupPullRequestID(headRef, headCommitMessage string) (int, error) {
+	pullRequestID, err := PullRequestIDFromMergeGroupRef(headRef)
+	if err != nil {
+		return 0, err
+	}
+
+	// only the first line references the pull request
+	title := strings.TrimSpace(strings.SplitN(headCommitMessage, "\n", 2)[0])
+	matches := mergeGroupCommitPattern.FindStringSubmatch(title)
+	if matches == nil {
+		return pullRequestID, nil
+	}
+	number := matches[1]
+	if number == "" {
+		number = matches[2]
+	}
+	if number != strconv.Itoa(pullRequestID) {
+		return 0, fmt.Errorf("the merge group head commit references pull request #%s, not the pull request #%d of the ref: %s", number, pullRequestID, headRef)
+	}
+	return pullRequestID, nil
+}
+
+// mergeGroupStatus returns the state, description and target URL of the merge group status - the merge group passes
+// when each of its pull requests passed the EasyCLA check
+func mergeGroupStatus(pullRequests []*MergeGroupPullRequest, landingPage string) (string, string, string) {
+	var failed []string
+	targetURL := fmt.Sprintf("%s/#/?version=2", landingPage)
+	for _, pullRequest := range pullRequests {
+		if pullRequest.Passed {
+			continue
+		}
+		if len(failed) == 0 && pullRequest.HTMLURL != "" {
+			targetURL = pullRequest.HTMLURL
+		}
+		if pullRequest.Evaluated {
+			failed = append(failed, fmt.Sprintf("#%d", pullRequest.Number))
+		} else {
+			failed = append(failed, fmt.Sprintf("#%d (not checked)", pullRequest.Number))
+		}
+	}
+
+	if len(pullRequests) == 0 {
+		return failureState, "Unable to determine the pull requests of the merge group.", targetURL
+	}
+	if len(failed) == 0 {
+		_, description := assembleCLAStatus(CheckRunName, true)
+		return successState, description, targetURL
+	}
+
+	description := fmt.Sprintf("Missing CLA Authorization for pull requests: %s", strings.Join(failed, ", "))
+	if len(description) > maxStatusDescriptionLength {
+		description = description[:maxStatusDescriptionLength-3] + "..."
+	}
+	return failureState, description, targetURL
+}
+
+// UpdateMergeGroupStatus publishes the EasyCLA status on the head commit of a merge group, based on the EasyCLA
+// result of the pull request of the group
+func UpdateMergeGroupStatus(ctx context.Context, input *MergeGroupInput) error {
+	f := logrus.Fields{
+		"functionName":   "github.merge_queue.UpdateMergeGroupStatus",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"owner":          input.Owner,
+		"repo":           input.Repo,
+		"headSHA":        input.HeadSHA,
+		"headRef":        input.HeadRef,
+	}
+
+	client, err := NewGithubAppClient(input.InstallationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create Github client")
+		return err
+	}
+
+	headCommit, _, err := client.Git.GetCommit(ctx, input.Owner, input.Repo, input.HeadSHA)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the merge group head commit")
+		return err
+	}
+
+	var pullRequests []*MergeGroupPullRequest
+	pullRequestID, err := mergeGroupPullRequestID(input.HeadRef, headCommit.GetMessage())
+	if err != nil {
+		// reported as a failure - the merge group does not match its pull request
+		log.WithFields(f).WithError(err).Warn("unable to determine the pull request of the merge group")
+	} else {
+		pullRequest, _, prErr := client.PullRequests.Get(ctx, input.Owner, input.Repo, pullRequestID)
+		if prErr != nil {
+			log.WithFields(f).WithError(prErr).Warnf("unable to load pull request: %d", pullRequestID)
+			return prErr
+		}
+		result := &MergeGroupPullRequest{
+			Number:  pullRequestID,
+			HeadSHA: pullRequest.GetHead().GetSHA(),
+			HTMLURL: pullRequest.GetHTMLURL(),
+		}
+		result.Passed, result.Evaluated = pullRequestCLAResult(ctx, client, input.Owner, input.Repo, result.HeadSHA)
+		log.WithFields(f).Debugf("pull request: %d passed: %t evaluated: %t", pullRequestID, result.Passed, result.Evaluated)
+		pullRequests = append(pullRequests, result)
+	}
+
+	state, description, targetURL := mergeGroupStatus(pullRequests, input.LandingPage)
+	statusContext := CheckRunName
+	log.WithFields(f).Debugf("creating merge group status: %s - %s", state, description)
+	_, _, err = CreateStatus(ctx, client, input.Owner, input.Repo, input.HeadSHA, &Status{
+		State:       &state,
+		TargetURL:   &targetURL,
+		Description: &description,
+		Context:     &statusContext,
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the merge group status")
+		return err
+	}
+
+	return nil
+}
+
+// pullRequestCLAResult returns the EasyCLA result of the head commit of a pull request - our check run, or the commit
+// status for the installations which have not granted the checks permission
+func pullRequestCLAResult(ctx context.Context, client *github.Client, owner, repo, sha string) (bool, bool) {
+	checkRuns, _, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, &github.ListCheckRunsOptions{
+		CheckName: github.String(CheckRunName),
+	})
+	if err == nil {
+		if checkRun := findOwnCheckRun(checkRuns); checkRun != nil && checkRun.GetStatus() == checkRunStatusCompleted {
+			return checkRun.GetConclusion() == checkRunConclusionPassed, true
+		}
+	}
+
+	combinedStatus, _, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, sha, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return false, false
+	}
+	for _, status := range combinedStatus.Statuses {
+		if status.GetContext() == CheckRunName {
+			return status.GetState() == successState, true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullRequestIDFromMergeGroupRef(t *testing.T) {
+	pullRequestID, err := PullRequestIDFromMergeGroupRef("refs/heads/gh-readonly-queue/main/pr-123-8f1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c")
+	assert.Nil(t, err)
+	assert.Equal(t, 123, pullRequestID)
+
+	pullRequestID, err = PullRequestIDFromMergeGroupRef("gh-readonly-queue/release/v1.2/pr-7-abc123")
+	assert.Nil(t, err)
+	assert.Equal(t, 7, pullRequestID)
+
+	_, err = PullRequestIDFromMergeGroupRef("refs/heads/main")
+	assert.NotNil(t, err)
+}
+
+func TestMergeGroupPullRequestID(t *testing.T) {
+	headRef := "refs/heads/gh-readonly-queue/main/pr-15-abc123"
+
+	for _, message := range []string{
+		"Merge pull request #15 from octocat/feature\n\nAdd the feature",
+		"Add the feature (#15)\n\n* squashed commit (#9)",
+		"Rebased commit without a reference",
+		"Update the docs for #4",
+	} {
+		pullRequestID, err := mergeGroupPullRequestID(headRef, message)
+		assert.Nil(t, err, message)
+		assert.Equal(t, 15, pullRequestID, message)
+	}
+
+	// the head commit of another pull request
+	_, err := mergeGroupPullRequestID(headRef, "Merge pull request #12 from octocat/other")
+	assert.NotNil(t, err)
+	_, err = mergeGroupPullRequestID(headRef, "Fix the build (#9)")
+	assert.NotNil(t, err)
+
+	// the commit title does not identify the merge group without its merge queue ref
+	_, err = mergeGroupPullRequestID("refs/heads/main", "Fix the build (#9)")
+	assert.NotNil(t, err)
+}
+
+func TestNewMergeGroupInput(t *testing.T) {
+	input, err := NewMergeGroupInput(1, "octocat/hello-world", "abc123", "refs/heads/gh-readonly-queue/main/pr-1-abc123", "https://landing.example.org")
+	assert.Nil(t, err)
+	assert.Equal(t, "octocat", input.Owner)
+	assert.Equal(t, "hello-world", input.Repo)
+
+	_, err = NewMergeGroupInput(1, "hello-world", "abc123", "", "")
+	assert.NotNil(t, err)
+}
+
+func TestMergeGroupStatus(t *testing.T) {
+	landingPage := "https://landing.example.org"
+
+	state, description, targetURL := mergeGroupStatus([]*MergeGroupPullRequest{
+		{Number: 1, Passed: true, Evaluated: true},
+		{Number: 2, Passed: true, Evaluated: true},
+	}, landingPage)
+	assert.Equal(t, successState, state)
+	assert.Equal(t, "EasyCLA check passed. You are authorized to contribute.", description)
+	assert.Equal(t, "https://landing.example.org/#/?version=2", targetURL)
+
+	state, description, targetURL = mergeGroupStatus([]*MergeGroupPullRequest{
+		{Number: 1, Passed: true, Evaluated: true},
+		{Number: 2, Evaluated: true, HTMLURL: "https://github.com/org/repo/pull/2"},
+		{Number: 3, HTMLURL: "https://github.com/org/repo/pull/3"},
+	}, landingPage)
+	assert.Equal(t, failureState, state)
+	assert.Equal(t, "Missing CLA Authorization for pull requests: #2, #3 (not checked)", description)
+	assert.Equal(t, "https://github.com/org/repo/pull/2", targetURL)
+
+	state, _, _ = mergeGroupStatus(nil, landingPage)
+	assert.Equal(t, failureState, state)
+
+	var many []*MergeGroupPullRequest
+	for i := 1000; i < 1050; i++ {
+		many = append(many, &MergeGroupPullRequest{Number: i, Evaluated: true})
+	}
+	_, description, _ = mergeGroupStatus(many, landingPage)
+	assert.Len(t, description, maxStatusDescriptionLength)
+	assert.True(t, strings.HasSuffix(description, "..."))
+}
@@ -0,0 +1,93 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/google/go-github/v37/github"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// filesPerPage is the maximum page size of the pull request files API
+	filesPerPage = 100
+	// pullRequestFilesLimit is the maximum number of files returned by the pull request files API
+	pullRequestFilesLimit = 3000
+)
+
+// PullRequestChanges contains the target branch, the size and the changed files of a pull request - used to evaluate
+// the repository requirements before the commit authors are checked
+type PullRequestChanges struct {
+	BaseRef      string
+	HeadSHA      string
+	ChangedLines int64
+	ChangedFiles int
+	// Files are the paths of the changed files, the renamed files include both the previous and the new path
+	Files []string
+	// FilesComplete is false when the files were not requested or GitHub did not return all the changed files
+	FilesComplete bool
+}
+
+// GetPullRequestChanges loads the target branch and the size of the pull request, and the changed files when withFiles
+// is set - the files API is paginated and bounded to 3000 files, so the files are only loaded when needed
+func GetPullRequestChanges(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, withFiles bool) (*PullRequestChanges, error) {
+	f := logrus.Fields{
+		"functionName":   "github.pull_request_changes.GetPullRequestChanges",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"owner":          owner,
+		"repo":           repo,
+		"pullRequestID":  pullRequestID,
+		"withFiles":      withFiles,
+	}
+
+	client, err := NewGithubAppClient(installationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create Github client")
+		return nil, err
+	}
+
+	pullRequest, _, err := client.PullRequests.Get(ctx, owner, repo, pullRequestID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem loading repo: %s/%s pull request: %d", owner, repo, pullRequestID)
+		return nil, err
+	}
+
+	result := &PullRequestChanges{
+		BaseRef:      pullRequest.GetBase().GetRef(),
+		HeadSHA:      pullRequest.GetHead().GetSHA(),
+		ChangedLines: int64(pullRequest.GetAdditions() + pullRequest.GetDeletions()),
+		ChangedFiles: pullRequest.GetChangedFiles(),
+	}
+	if !withFiles {
+		return result, nil
+	}
+
+	loaded := 0
+	opts := &github.ListOptions{PerPage: filesPerPage}
+	for {
+		page, resp, listErr := client.PullRequests.ListFiles(ctx, owner, repo, pullRequestID, opts)
+		if listErr != nil {
+			log.WithFields(f).WithError(listErr).Warnf("problem listing files page: %d", opts.Page)
+			return nil, listErr
+		}
+		for _, file := range page {
+			result.Files = append(result.Files, file.GetFilename())
+			if file.GetPreviousFilename() != "" {
+				result.Files = append(result.Files, file.GetPreviousFilename())
+			}
+		}
+		loaded += len(page)
+		if resp.NextPage == 0 || loaded >= pullRequestFilesLimit {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	result.FilesComplete = loaded >= result.ChangedFiles
+	log.WithFields(f).Debugf("loaded %d of %d changed files", loaded, result.ChangedFiles)
+	return result, nil
+}
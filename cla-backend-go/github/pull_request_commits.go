@@ -0,0 +1,144 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/google/go-github/v37/github"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// pullRequestCommitsLimit is the maximum number of commits returned by the pull request commits API
+	pullRequestCommitsLimit = 250
+	// commitsPerPage is the maximum page size of the commits APIs
+	commitsPerPage = 100
+	// maxBranchHistoryCommits bounds the branch history walk used for pull requests above the API limit
+	maxBranchHistoryCommits = 5000
+)
+
+// Commit loading strategies
+const (
+	CommitStrategyPullRequest   = "pull-request"
+	CommitStrategyBranchHistory = "branch-history"
+)
+
+// PullRequestCommits contains the commit identities of a pull request and the details of how the commits were loaded
+type PullRequestCommits struct {
+	Authors       []*UserCommitSummary
+	LatestSHA     string
+	TotalCommits  int
+	LoadedCommits int
+	Strategy      string
+}
+
+// Truncated returns true when some of the pull request commits could not be loaded
+func (c *PullRequestCommits) Truncated() bool {
+	return c.LoadedCommits < c.TotalCommits
+}
+
+// Note returns a description of the commit loading for the check output, empty when every commit was loaded
+// through the pull request commits API
+func (c *PullRequestCommits) Note() string {
+	switch {
+	case c.Truncated():
+		return fmt.Sprintf("This pull request has %d commits - only the latest %d commits could be checked. Please split the pull request or contact the project maintainers.",
+			c.TotalCommits, c.LoadedCommits)
+	case c.Strategy == CommitStrategyBranchHistory:
+		return fmt.Sprintf("This pull request has %d commits, more than the %d commits returned by the GitHub pull request API - the commits were loaded from the branch history.",
+			c.TotalCommits, pullRequestCommitsLimit)
+	default:
+		return ""
+	}
+}
+
+// listPullRequestCommits loads the pull request commits page by page - GitHub returns at most 250 commits
+func listPullRequestCommits(ctx context.Context, client *github.Client, owner, repo string, pullRequestID int) ([]*github.RepositoryCommit, error) {
+	f := logrus.Fields{
+		"functionName":  "github.pull_request_commits.listPullRequestCommits",
+		"owner":         owner,
+		"repo":          repo,
+		"pullRequestID": pullRequestID,
+	}
+
+	var commits []*github.RepositoryCommit
+	opts := &github.ListOptions{PerPage: commitsPerPage}
+	for {
+		page, resp, err := client.PullRequests.ListCommits(ctx, owner, repo, pullRequestID, opts)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem listing commits page: %d", opts.Page)
+			return nil, err
+		}
+		commits = append(commits, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return commits, nil
+}
+
+// listBranchHistoryCommits walks the history of the head commit back to the merge base - used for pull requests
+// above the pull request commits API limit. The walk stops after maxCommits commits.
+func listBranchHistoryCommits(ctx context.Context, client *github.Client, owner, repo, baseSHA, headSHA string, maxCommits int) ([]*github.RepositoryCommit, error) {
+	f := logrus.Fields{
+		"functionName": "github.pull_request_commits.listBranchHistoryCommits",
+		"owner":        owner,
+		"repo":         repo,
+		"baseSHA":      baseSHA,
+		"headSHA":      headSHA,
+	}
+
+	comparison, _, err := client.Repositories.CompareCommits(ctx, owner, repo, baseSHA, headSHA)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to compare the base and head commits")
+		return nil, err
+	}
+	mergeBaseSHA := ""
+	if comparison.MergeBaseCommit != nil {
+		mergeBaseSHA = comparison.MergeBaseCommit.GetSHA()
+	}
+	log.WithFields(f).Debugf("walking the history from %s to the merge base %s", headSHA, mergeBaseSHA)
+
+	var commits []*github.RepositoryCommit
+	opts := &github.CommitsListOptions{
+		SHA:         headSHA,
+		ListOptions: github.ListOptions{PerPage: commitsPerPage},
+	}
+	for {
+		page, resp, listErr := client.Repositories.ListCommits(ctx, owner, repo, opts)
+		if listErr != nil {
+			log.WithFields(f).WithError(listErr).Warnf("problem listing commits page: %d", opts.Page)
+			return nil, listErr
+		}
+		for _, commit := range page {
+			if commit.GetSHA() == mergeBaseSHA || len(commits) == maxCommits {
+				return commits, nil
+			}
+			commits = append(commits, commit)
+		}
+		if resp.NextPage == 0 {
+			return commits, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// uniqueCommits removes the commits listed more than once, keeping the order
+func uniqueCommits(commits []*github.RepositoryCommit) []*github.RepositoryCommit {
+	seen := make(map[string]bool, len(commits))
+	unique := make([]*github.RepositoryCommit, 0, len(commits))
+	for _, commit := range commits {
+		if seen[commit.GetSHA()] {
+			continue
+		}
+		seen[commit.GetSHA()] = true
+		unique = append(unique, commit)
+	}
+	return unique
+}
@@ -0,0 +1,43 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullRequestCommitsNote(t *testing.T) {
+	commits := &PullRequestCommits{TotalCommits: 12, LoadedCommits: 12, Strategy: CommitStrategyPullRequest}
+	assert.False(t, commits.Truncated())
+	assert.Equal(t, "", commits.Note())
+
+	commits = &PullRequestCommits{TotalCommits: 400, LoadedCommits: 400, Strategy: CommitStrategyBranchHistory}
+	assert.False(t, commits.Truncated())
+	assert.Contains(t, commits.Note(), "loaded from the branch history")
+
+	commits = &PullRequestCommits{TotalCommits: 6000, LoadedCommits: maxBranchHistoryCommits, Strategy: CommitStrategyBranchHistory}
+	assert.True(t, commits.Truncated())
+	assert.Contains(t, commits.Note(), "only the latest 5000 commits")
+}
+
+func TestUniqueCommits(t *testing.T) {
+	commits := uniqueCommits([]*github.RepositoryCommit{
+		{SHA: github.String("a")},
+		{SHA: github.String("b")},
+		{SHA: github.String("a")},
+	})
+	assert.Len(t, commits, 2)
+	assert.Equal(t, "b", commits[1].GetSHA())
+}
+
+func TestCheckRunSummaryCommitsNote(t *testing.T) {
+	summary := checkRunSummary(&CheckRunInput{
+		Signed:      []*UserCommitSummary{commitSummary("abc", "alice", 1)},
+		CommitsNote: "loaded from the branch history",
+	})
+	assert.Contains(t, summary, ":warning: loaded from the branch history")
+}
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	models "github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	github_organizations "github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -142,6 +143,21 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetGitHubOrganizationsByParent(ct
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubOrganizationsByParent", reflect.TypeOf((*MockRepositoryInterface)(nil).GetGitHubOrganizationsByParent), ctx, parentProjectSFID)
 }
 
+// GetGitHubOrganizationBranchProtectionPolicy mocks base method.
+func (m *MockRepositoryInterface) GetGitHubOrganizationBranchProtectionPolicy(ctx context.Context, githubOrganizationName string) (*github_organizations.BranchProtectionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGitHubOrganizationBranchProtectionPolicy", ctx, githubOrganizationName)
+	ret0, _ := ret[0].(*github_organizations.BranchProtectionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGitHubOrganizationBranchProtectionPolicy indicates an expected call of GetGitHubOrganizationBranchProtectionPolicy.
+func (mr *MockRepositoryInterfaceMockRecorder) GetGitHubOrganizationBranchProtectionPolicy(ctx, githubOrganizationName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubOrganizationBranchProtectionPolicy", reflect.TypeOf((*MockRepositoryInterface)(nil).GetGitHubOrganizationBranchProtectionPolicy), ctx, githubOrganizationName)
+}
+
 // UpdateGitHubOrganization mocks base method.
 func (m *MockRepositoryInterface) UpdateGitHubOrganization(ctx context.Context, projectSFID, organizationName string, autoEnabled bool, autoEnabledClaGroupID string, branchProtectionEnabled bool, enabled *bool) error {
 	m.ctrl.T.Helper()
@@ -155,3 +171,17 @@ func (mr *MockRepositoryInterfaceMockRecorder) UpdateGitHubOrganization(ctx, pro
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitHubOrganization", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateGitHubOrganization), ctx, projectSFID, organizationName, autoEnabled, autoEnabledClaGroupID, branchProtectionEnabled, enabled)
 }
+
+// UpdateGitHubOrganizationBranchProtectionPolicy mocks base method.
+func (m *MockRepositoryInterface) UpdateGitHubOrganizationBranchProtectionPolicy(ctx context.Context, githubOrganizationName string, policy *github_organizations.BranchProtectionPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGitHubOrganizationBranchProtectionPolicy", ctx, githubOrganizationName, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGitHubOrganizationBranchProtectionPolicy indicates an expected call of UpdateGitHubOrganizationBranchProtectionPolicy.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateGitHubOrganizationBranchProtectionPolicy(ctx, githubOrganizationName, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitHubOrganizationBranchProtectionPolicy", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateGitHubOrganizationBranchProtectionPolicy), ctx, githubOrganizationName, policy)
+}
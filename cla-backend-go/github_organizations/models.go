@@ -3,22 +3,48 @@
 
 package github_organizations
 
-import "github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github/branch_protection"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
 
 // GithubOrganization is data model for github organizations
 type GithubOrganization struct {
-	DateCreated                string `json:"date_created,omitempty"`
-	DateModified               string `json:"date_modified,omitempty"`
-	OrganizationInstallationID int64  `json:"organization_installation_id,omitempty"`
-	OrganizationName           string `json:"organization_name,omitempty"`
-	OrganizationNameLower      string `json:"organization_name_lower,omitempty"`
-	OrganizationSFID           string `json:"organization_sfid,omitempty"`
-	ProjectSFID                string `json:"project_sfid"`
-	Enabled                    bool   `json:"enabled"`
-	AutoEnabled                bool   `json:"auto_enabled"`
-	BranchProtectionEnabled    bool   `json:"branch_protection_enabled"`
-	AutoEnabledClaGroupID      string `json:"auto_enabled_cla_group_id,omitempty"`
-	Version                    string `json:"version,omitempty"`
+	DateCreated                string                  `json:"date_created,omitempty"`
+	DateModified               string                  `json:"date_modified,omitempty"`
+	OrganizationInstallationID int64                   `json:"organization_installation_id,omitempty"`
+	OrganizationName           string                  `json:"organization_name,omitempty"`
+	OrganizationNameLower      string                  `json:"organization_name_lower,omitempty"`
+	OrganizationSFID           string                  `json:"organization_sfid,omitempty"`
+	ProjectSFID                string                  `json:"project_sfid"`
+	Enabled                    bool                    `json:"enabled"`
+	AutoEnabled                bool                    `json:"auto_enabled"`
+	BranchProtectionEnabled    bool                    `json:"branch_protection_enabled"`
+	AutoEnabledClaGroupID      string                  `json:"auto_enabled_cla_group_id,omitempty"`
+	BranchProtectionPolicy     *BranchProtectionPolicy `json:"branch_protection_policy,omitempty"`
+	Version                    string                  `json:"version,omitempty"`
+}
+
+// BranchProtectionPolicy is the branch protection policy applied to the CLA enabled repositories of the GitHub organization
+type BranchProtectionPolicy struct {
+	DefaultBranch  bool     `json:"default_branch"`
+	BranchPatterns []string `json:"branch_patterns,omitempty"`
+	EnforceAdmin   bool     `json:"enforce_admin"`
+	DateModified   string   `json:"date_modified,omitempty"`
+}
+
+// ToPolicy converts to the branch_protection.Policy which requires the EasyCLA status check
+func (p *BranchProtectionPolicy) ToPolicy() *branch_protection.Policy {
+	if p == nil {
+		return nil
+	}
+	return &branch_protection.Policy{
+		DefaultBranch:  p.DefaultBranch,
+		BranchPatterns: p.BranchPatterns,
+		StatusChecks:   []string{utils.GitHubBotName},
+		EnforceAdmin:   p.EnforceAdmin,
+	}
 }
 
 // ToModel converts to models.GithubOrganization
@@ -43,6 +43,8 @@ type RepositoryInterface interface {
 	GetGitHubOrganization(ctx context.Context, githubOrganizationName string) (*models.GithubOrganization, error)
 	GetGitHubOrganizationByName(ctx context.Context, githubOrganizationName string) (*models.GithubOrganizations, error)
 	UpdateGitHubOrganization(ctx context.Context, projectSFID string, organizationName string, autoEnabled bool, autoEnabledClaGroupID string, branchProtectionEnabled bool, enabled *bool) error
+	GetGitHubOrganizationBranchProtectionPolicy(ctx context.Context, githubOrganizationName string) (*BranchProtectionPolicy, error)
+	UpdateGitHubOrganizationBranchProtectionPolicy(ctx context.Context, githubOrganizationName string, policy *BranchProtectionPolicy) error
 	DeleteGitHubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error
 	DeleteGitHubOrganizationByParent(ctx context.Context, parentProjectSFID string, githubOrgName string) error
 }
@@ -457,6 +459,96 @@ func (repo Repository) UpdateGitHubOrganization(ctx context.Context, projectSFID
 	return nil
 }
 
+// GetGitHubOrganizationBranchProtectionPolicy returns the branch protection policy of the GitHub organization, nil if
+// the organization has no policy
+func (repo Repository) GetGitHubOrganizationBranchProtectionPolicy(ctx context.Context, githubOrganizationName string) (*BranchProtectionPolicy, error) {
+	f := logrus.Fields{
+		"functionName":           "v1.github_organizations.repository.GetGitHubOrganizationBranchProtectionPolicy",
+		utils.XREQUESTID:         ctx.Value(utils.XREQUESTID),
+		"githubOrganizationName": githubOrganizationName,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {
+				S: aws.String(githubOrganizationName),
+			},
+		},
+		TableName: aws.String(repo.githubOrgTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the github organization")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrOrganizationDoesNotExist
+	}
+
+	var org GithubOrganization
+	err = dynamodbattribute.UnmarshalMap(result.Item, &org)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error unmarshalling organization table data")
+		return nil, err
+	}
+	return org.BranchProtectionPolicy, nil
+}
+
+// UpdateGitHubOrganizationBranchProtectionPolicy saves the branch protection policy of the GitHub organization - a nil
+// policy removes it
+func (repo Repository) UpdateGitHubOrganizationBranchProtectionPolicy(ctx context.Context, githubOrganizationName string, policy *BranchProtectionPolicy) error {
+	f := logrus.Fields{
+		"functionName":           "v1.github_organizations.repository.UpdateGitHubOrganizationBranchProtectionPolicy",
+		utils.XREQUESTID:         ctx.Value(utils.XREQUESTID),
+		"githubOrganizationName": githubOrganizationName,
+		"tableName":              repo.githubOrgTableName,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{
+		"#P": aws.String("branch_protection_policy"),
+		"#M": aws.String("date_modified"),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":m": {
+			S: aws.String(currentTime),
+		},
+	}
+	updateExpression := "SET #M = :m REMOVE #P"
+
+	if policy != nil {
+		policy.DateModified = currentTime
+		policyValue, err := dynamodbattribute.Marshal(policy)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to marshal the branch protection policy")
+			return err
+		}
+		expressionAttributeValues[":p"] = policyValue
+		updateExpression = "SET #M = :m, #P = :p"
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {
+				S: aws.String(githubOrganizationName),
+			},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		UpdateExpression:          aws.String(updateExpression),
+		ConditionExpression:       aws.String("attribute_exists(organization_name)"),
+		TableName:                 aws.String(repo.githubOrgTableName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrOrganizationDoesNotExist
+		}
+		log.WithFields(f).WithError(err).Warn("unable to update the branch protection policy of the github organization")
+		return err
+	}
+
+	return nil
+}
+
 // DeleteGitHubOrganization deletes the github organization by project SFID
 func (repo Repository) DeleteGitHubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error {
 	f := logrus.Fields{
@@ -26,6 +26,7 @@ type ServiceInterface interface {
 	GetGitHubOrganizationsByParent(ctx context.Context, parentProjectSFID string) (*models.GithubOrganizations, error)
 	GetGitHubOrganizationByName(ctx context.Context, githubOrgName string) (*models.GithubOrganization, error)
 	UpdateGitHubOrganization(ctx context.Context, projectSFID string, organizationName string, autoEnabled bool, autoEnabledClaGroupID string, branchProtectionEnabled bool) error
+	GetGitHubOrganizationBranchProtectionPolicy(ctx context.Context, githubOrgName string) (*BranchProtectionPolicy, error)
 	DeleteGitHubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error
 	RemoveDuplicates(input []*models.GithubOrganization) []*models.GithubOrganization
 }
@@ -137,6 +138,12 @@ func (s Service) GetGitHubOrganizationByName(ctx context.Context, githubOrgName
 	return gitHubOrgs.List[0], err
 }
 
+// GetGitHubOrganizationBranchProtectionPolicy returns the branch protection policy of the github organization, nil if
+// the organization has no policy
+func (s Service) GetGitHubOrganizationBranchProtectionPolicy(ctx context.Context, githubOrgName string) (*BranchProtectionPolicy, error) {
+	return s.repo.GetGitHubOrganizationBranchProtectionPolicy(ctx, githubOrgName)
+}
+
 // UpdateGitHubOrganization updates the specified github organization based on the project SFID, organization name provided values
 func (s Service) UpdateGitHubOrganization(ctx context.Context, projectSFID string, organizationName string, autoEnabled bool, autoEnabledClaGroupID string, branchProtectionEnabled bool) error {
 	// check if valid cla group id is passed
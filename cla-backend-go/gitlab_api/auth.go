@@ -7,41 +7,34 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/communitybridge/easycla/cla-backend-go/config"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
 )
 
-const oauthURL = "https://gitlab.com/oauth/token"
-
-// RefreshOauthToken common routine to refresh the GitLab token
-func RefreshOauthToken(refreshToken string) (*OauthSuccessResponse, error) {
-	gitLabConfig := config.GetConfig().Gitlab
+// RefreshOauthToken common routine to refresh the GitLab token of the instance, a nil instance refreshes a gitlab.com
+// token
+func RefreshOauthToken(instance *Instance, refreshToken string) (*OauthSuccessResponse, error) {
+	instance = resolveInstance(instance)
+	oauthURL := instance.OauthTokenURL()
 	f := logrus.Fields{
 		"functionName": "gitlab.auth.RefreshOauthToken",
+		"instanceID":   instance.InstanceID,
 		"refreshToken": refreshToken,
 	}
 
-	if len(gitLabConfig.AppClientID) > 4 {
-		f["gitLabClientID"] = fmt.Sprintf("%s...%s", gitLabConfig.AppClientID[0:4], gitLabConfig.AppClientID[len(gitLabConfig.AppClientID)-4:])
-	} else {
-		return nil, errors.New("gitlab application client ID value is not set - value is empty or malformed")
-	}
-	if len(gitLabConfig.AppClientSecret) > 4 {
-		f["gitLabClientSecret"] = fmt.Sprintf("%s...%s", gitLabConfig.AppClientSecret[0:4], gitLabConfig.AppClientSecret[len(gitLabConfig.AppClientSecret)-4:])
-	} else {
-		return nil, errors.New("gitlab application client secret value is not set - value is empty or malformed")
+	if err := addClientCredentialFields(f, instance); err != nil {
+		return nil, err
 	}
 
 	// For info on this authorization flow, see: https://docs.gitlab.com/ee/api/oauth2.html#authorization-code-flow
 	client := resty.New()
 	params := map[string]string{
-		"client_id":     gitLabConfig.AppClientID,
-		"client_secret": gitLabConfig.AppClientSecret,
+		"client_id":     instance.AppClientID,
+		"client_secret": instance.AppClientSecret,
 		"refresh_token": refreshToken,
 		"grant_type":    "refresh_token",
-		"redirect_uri":  gitLabConfig.RedirectURI,
+		"redirect_uri":  instance.RedirectURI,
 		//"redirect_uri": "http://localhost:8080/v4/gitlab/oauth/callback",
 	}
 	resp, err := client.R().
@@ -61,34 +54,30 @@ func RefreshOauthToken(refreshToken string) (*OauthSuccessResponse, error) {
 	return resp.Result().(*OauthSuccessResponse), nil
 }
 
-// FetchOauthCredentials is responsible for fetching the credentials from gitlab for alredy started Oauth process (access_token, refresh_token)
-func FetchOauthCredentials(code string) (*OauthSuccessResponse, error) {
-	gitLabConfig := config.GetConfig().Gitlab
+// FetchOauthCredentials is responsible for fetching the credentials from gitlab for alredy started Oauth process (access_token, refresh_token),
+// a nil instance fetches the gitlab.com credentials
+func FetchOauthCredentials(instance *Instance, code string) (*OauthSuccessResponse, error) {
+	instance = resolveInstance(instance)
+	oauthURL := instance.OauthTokenURL()
 	f := logrus.Fields{
 		"functionName": "gitlab.auth.FetchOauthCredentials",
+		"instanceID":   instance.InstanceID,
 		"code":         code,
-		"redirectURI":  config.GetConfig().Gitlab.RedirectURI,
+		"redirectURI":  instance.RedirectURI,
 	}
 
-	if len(gitLabConfig.AppClientID) > 4 {
-		f["gitLabClientID"] = fmt.Sprintf("%s...%s", gitLabConfig.AppClientID[0:4], gitLabConfig.AppClientID[len(gitLabConfig.AppClientID)-4:])
-	} else {
-		return nil, errors.New("gitlab application client ID value is not set - value is empty or malformed")
-	}
-	if len(gitLabConfig.AppClientSecret) > 4 {
-		f["gitLabClientSecret"] = fmt.Sprintf("%s...%s", gitLabConfig.AppClientSecret[0:4], gitLabConfig.AppClientSecret[len(gitLabConfig.AppClientSecret)-4:])
-	} else {
-		return nil, errors.New("gitlab application client secret value is not set - value is empty or malformed")
+	if err := addClientCredentialFields(f, instance); err != nil {
+		return nil, err
 	}
 
 	// For info on this authorization flow, see: https://docs.gitlab.com/ee/api/oauth2.html#authorization-code-flow
 	client := resty.New()
 	params := map[string]string{
-		"client_id":     gitLabConfig.AppClientID,
-		"client_secret": gitLabConfig.AppClientSecret,
+		"client_id":     instance.AppClientID,
+		"client_secret": instance.AppClientSecret,
 		"code":          code,
 		"grant_type":    "authorization_code",
-		"redirect_uri":  gitLabConfig.RedirectURI,
+		"redirect_uri":  instance.RedirectURI,
 		//"redirect_uri": "http://localhost:8080/v4/gitlab/oauth/callback",
 	}
 
@@ -109,3 +98,19 @@ func FetchOauthCredentials(code string) (*OauthSuccessResponse, error) {
 
 	return resp.Result().(*OauthSuccessResponse), nil
 }
+
+// addClientCredentialFields validates the OAuth application credentials of the instance and adds their masked values
+// to the log fields
+func addClientCredentialFields(f logrus.Fields, instance *Instance) error {
+	if len(instance.AppClientID) > 4 {
+		f["gitLabClientID"] = fmt.Sprintf("%s...%s", instance.AppClientID[0:4], instance.AppClientID[len(instance.AppClientID)-4:])
+	} else {
+		return errors.New("gitlab application client ID value is not set - value is empty or malformed")
+	}
+	if len(instance.AppClientSecret) > 4 {
+		f["gitLabClientSecret"] = fmt.Sprintf("%s...%s", instance.AppClientSecret[0:4], instance.AppClientSecret[len(instance.AppClientSecret)-4:])
+	} else {
+		return errors.New("gitlab application client secret value is not set - value is empty or malformed")
+	}
+	return nil
+}
@@ -4,17 +4,14 @@
 package gitlab
 
 import (
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
-	"encoding/base64"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
+	"net/http"
 
+	"github.com/communitybridge/easycla/cla-backend-go/keyring"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/telemetry"
 
 	goGitLab "github.com/xanzy/go-gitlab"
 )
@@ -28,8 +25,9 @@ type OauthSuccessResponse struct {
 	CreatedAt    int    `json:"created_at"`
 }
 
-// NewGitlabOauthClient creates a new gitlab client from the given oauth info, authInfo is encrypted
-func NewGitlabOauthClient(authInfo string, gitLabApp *App) (*goGitLab.Client, error) {
+// NewGitlabOauthClient creates a new gitlab client of the instance from the given oauth info, authInfo is encrypted.
+// A nil instance creates a gitlab.com client.
+func NewGitlabOauthClient(authInfo string, gitLabApp *App, instance *Instance) (*goGitLab.Client, error) {
 	if authInfo == "" {
 		return nil, errors.New("unable to decrypt auth info - authentication info input is nil")
 	}
@@ -46,57 +44,61 @@ func NewGitlabOauthClient(authInfo string, gitLabApp *App) (*goGitLab.Client, er
 		return nil, errors.New("unable to decrypt auth info - value is nil")
 	}
 
-	log.Infof("creating oauth client with access token : %s", oauthResp.AccessToken)
-	return goGitLab.NewOAuthClient(oauthResp.AccessToken)
+	instance = resolveInstance(instance)
+	log.Infof("creating oauth client for instance : %s", instance.InstanceID)
+	return goGitLab.NewOAuthClient(oauthResp.AccessToken, instance.clientOptions()...)
 }
 
-// NewGitlabOauthClientFromAccessToken creates a new gitlab client from the given access token
-func NewGitlabOauthClientFromAccessToken(accessToken string) (*goGitLab.Client, error) {
-	return goGitLab.NewOAuthClient(accessToken)
+// NewGitlabOauthClientFromAccessToken creates a new gitlab client of the instance from the given access token, a nil
+// instance creates a gitlab.com client
+func NewGitlabOauthClientFromAccessToken(accessToken string, instance *Instance) (*goGitLab.Client, error) {
+	return goGitLab.NewOAuthClient(accessToken, resolveInstance(instance).clientOptions()...)
+}
+
+// instrumentedHTTPClient returns the client option which records the GitLab API call metrics
+func instrumentedHTTPClient() goGitLab.ClientOptionFunc {
+	return goGitLab.WithHTTPClient(&http.Client{
+		Transport: telemetry.NewInstrumentedTransport(telemetry.ProviderGitLab, http.DefaultTransport),
+	})
 }
 
 // EncryptAuthInfo encrypts the oauth response into a string
 func EncryptAuthInfo(oauthResp *OauthSuccessResponse, gitLabApp *App) (string, error) {
-	keyDecoded, err := base64.StdEncoding.DecodeString(gitLabApp.GetAppPrivateKey())
-	if err != nil {
-		return "", fmt.Errorf("problem decoding GitLab private glClientKey, error: %v", err)
-	}
-
 	b, err := json.Marshal(oauthResp)
 	if err != nil {
 		return "", fmt.Errorf("problem marshalling oauth resp json, error: %v", err)
 	}
-	authInfo := string(b)
-	//log.Infof("auth info before encrypting : %s", authInfo)
 
-	encrypted, err := encrypt(keyDecoded, []byte(authInfo))
-	if err != nil {
-		return "", fmt.Errorf("encrypt failed : %v", err)
-	}
+	return encryptValue(b, gitLabApp)
+}
 
-	return hex.EncodeToString(encrypted), nil
+// EncryptSecret encrypts the secret with the keyring of the GitLab application, or its private key when no keyring is
+// configured, such as the client secret of a GitLab instance
+func EncryptSecret(secret string, gitLabApp *App) (string, error) {
+	return encryptValue([]byte(secret), gitLabApp)
 }
 
-// DecryptAuthInfo decrypts the auth info into OauthSuccessResponse data structure
-func DecryptAuthInfo(authInfoEncoded string, gitLabApp *App) (*OauthSuccessResponse, error) {
-	ciphertext, err := hex.DecodeString(authInfoEncoded)
+// DecryptSecret decrypts the secret encrypted by EncryptSecret
+func DecryptSecret(encryptedSecret string, gitLabApp *App) (string, error) {
+	decrypted, err := decryptValue(encryptedSecret, gitLabApp)
 	if err != nil {
-		return nil, fmt.Errorf("decode auth info %s : %v", authInfoEncoded, err)
+		return "", fmt.Errorf("decode secret : %v", err)
 	}
 
-	//log.Infof("auth info decoded : %s", ciphertext)
+	return string(decrypted), nil
+}
 
-	keyDecoded, err := base64.StdEncoding.DecodeString(gitLabApp.GetAppPrivateKey())
-	if err != nil {
-		return nil, fmt.Errorf("decode glClientKey : %v", err)
-	}
+// NewSecret returns a random secret, used for the webhook secrets of the GitLab organizations
+func NewSecret() (string, error) {
+	return keyring.NewSecret()
+}
 
-	//log.Debugf("before decrypt : keyDecoded : %s, cipherText : %s", keyDecoded, ciphertext)
-	decrypted, err := decrypt(keyDecoded, ciphertext)
+// DecryptAuthInfo decrypts the auth info into OauthSuccessResponse data structure
+func DecryptAuthInfo(authInfoEncoded string, gitLabApp *App) (*OauthSuccessResponse, error) {
+	decrypted, err := decryptValue(authInfoEncoded, gitLabApp)
 	if err != nil {
-		return nil, fmt.Errorf("decrypt failed : %v", err)
+		return nil, fmt.Errorf("decode auth info : %v", err)
 	}
-	//log.Debugf("after decrypt : keyDecoded : %s, decrypted : %s", keyDecoded, decrypted)
 
 	var oauthResp OauthSuccessResponse
 	if err := json.Unmarshal(decrypted, &oauthResp); err != nil {
@@ -106,51 +108,29 @@ func DecryptAuthInfo(authInfoEncoded string, gitLabApp *App) (*OauthSuccessRespo
 	return &oauthResp, nil
 }
 
-func encrypt(key, message []byte) ([]byte, error) {
-	// Initialize block cipher
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create the byte slice that will hold encrypted message
-	cipherText := make([]byte, aes.BlockSize+len(message))
-
-	// Generate the Initialization Vector (IV) nonce
-	// which is stored at the beginning of the byte slice
-	// The IV is the same length as the AES blocksize
-	iv := cipherText[:aes.BlockSize]
-	_, err = io.ReadFull(rand.Reader, iv)
-	if err != nil {
-		return nil, err
+// NeedsReEncryption returns true if the encrypted value isn't an envelope of the primary key of the keyring of the
+// GitLab application - always false when no keyring is configured
+func NeedsReEncryption(encrypted string, gitLabApp *App) bool {
+	if gitLabApp.keys == nil {
+		return false
 	}
-
-	// Choose the block cipher mode of operation
-	// Using the cipher feedback (CFB) mode here.
-	// CBCEncrypter also available.
-	cfb := cipher.NewCFBEncrypter(block, iv)
-	// Generate the encrypted message and store it
-	// in the remaining bytes after the IV nonce
-	cfb.XORKeyStream(cipherText[aes.BlockSize:], message)
-
-	return cipherText, nil
+	return gitLabApp.keys.NeedsReEncryption(encrypted)
 }
 
-// AES decryption
-func decrypt(key, cipherText []byte) ([]byte, error) {
-	// Initialize block cipher
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, err
-	}
-
-	// Separate the IV nonce from the encrypted message bytes
-	iv := cipherText[:aes.BlockSize]
-	cipherText = cipherText[aes.BlockSize:]
+// ReEncrypt decrypts the value encrypted with the private key or a previous key of the keyring and encrypts it with the
+// primary key of the keyring
+func ReEncrypt(encrypted string, gitLabApp *App) (string, error) {
+	return keyring.ReEncrypt(gitLabApp.keys, gitLabApp.GetAppPrivateKey(), encrypted)
+}
 
-	// Decrypt the message using the CFB block mode
-	cfb := cipher.NewCFBDecrypter(block, iv)
-	cfb.XORKeyStream(cipherText, cipherText)
+// encryptValue seals the value in an envelope of the keyring, or encrypts it with the private key as hex when no
+// keyring is configured
+func encryptValue(value []byte, gitLabApp *App) (string, error) {
+	return keyring.EncryptSecret(gitLabApp.keys, gitLabApp.GetAppPrivateKey(), value)
+}
 
-	return cipherText, nil
+// decryptValue opens the envelopes with the keyring, the hex values encrypted before the keyring are decrypted with
+// the private key
+func decryptValue(encrypted string, gitLabApp *App) ([]byte, error) {
+	return keyring.DecryptSecret(gitLabApp.keys, gitLabApp.GetAppPrivateKey(), encrypted)
 }
@@ -61,6 +61,7 @@ func GetGroupsListAll(ctx context.Context, client *goGitLab.Client, minAccessLev
 		if resp.NextPage == 0 {
 			break
 		}
+		listGroupsOpts.Page = resp.NextPage
 	}
 
 	return groupList, nil
@@ -187,11 +188,57 @@ func GetGroupProjectListByGroupID(ctx context.Context, client *goGitLab.Client,
 		if resp.NextPage == 0 {
 			break
 		}
+		opts.Page = resp.NextPage
 	}
 
 	return projectList, nil
 }
 
+// GetDescendantGroupsByGroupID returns all the subgroups under the specified group, at any depth
+func GetDescendantGroupsByGroupID(ctx context.Context, client *goGitLab.Client, groupID int) ([]*goGitLab.Group, error) {
+	f := logrus.Fields{
+		"functionName":   "gitlab_api.client_groups.GetDescendantGroupsByGroupID",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"groupID":        groupID,
+	}
+	if groupID == 0 {
+		return nil, errors.New("invalid groupID value - 0")
+	}
+
+	opts := &goGitLab.ListDescendantGroupsOptions{
+		ListOptions: goGitLab.ListOptions{
+			Page:    1,   // starts with one: https://docs.gitlab.com/ee/api/#offset-based-pagination
+			PerPage: 100, // max is 100
+		},
+		AllAvailable: utils.Bool(true),
+	}
+
+	var groupList []*goGitLab.Group
+	for {
+		// https://docs.gitlab.com/ee/api/groups.html#list-a-groups-descendant-groups
+		groups, resp, listGroupsErr := client.Groups.ListDescendantGroups(groupID, opts)
+		if listGroupsErr != nil {
+			msg := fmt.Sprintf("unable to list descendant groups, error: %+v", listGroupsErr)
+			log.WithFields(f).WithError(listGroupsErr).Warn(msg)
+			return nil, errors.New(msg)
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			msg := fmt.Sprintf("unable to list descendant groups, status code: %d", resp.StatusCode)
+			log.WithFields(f).Warn(msg)
+			return nil, errors.New(msg)
+		}
+
+		groupList = append(groupList, groups...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return groupList, nil
+}
+
 // ListGroupMembers lists the members of a given groupID
 func ListGroupMembers(ctx context.Context, client *goGitLab.Client, groupID int) ([]*goGitLab.GroupMember, error) {
 	f := logrus.Fields{
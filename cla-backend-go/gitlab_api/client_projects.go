@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/sirupsen/logrus"
@@ -16,6 +18,9 @@ import (
 	goGitLab "github.com/xanzy/go-gitlab"
 )
 
+// ErrProjectNotFound is returned when the GitLab project doesn't exist, or isn't visible to the client anymore
+var ErrProjectNotFound = errors.New("gitlab project not found")
+
 // GetProjectListAll returns a complete list of GitLab projects for which the client as authorization/visibility
 func GetProjectListAll(ctx context.Context, client *goGitLab.Client) ([]*goGitLab.Project, error) {
 	// https://docs.gitlab.com/ce/api/projects.html#list-projects
@@ -95,6 +100,10 @@ func GetProjectByID(ctx context.Context, client *goGitLab.Client, gitLabProjectI
 	// Query GitLab for repos - fetch the list of repositories available to the GitLab App
 	project, resp, getProjectErr := client.Projects.GetProject(gitLabProjectID, &goGitLab.GetProjectOptions{})
 	if getProjectErr != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			log.WithFields(f).Debugf("project by ID: %d not found", gitLabProjectID)
+			return nil, fmt.Errorf("%w : %d", ErrProjectNotFound, gitLabProjectID)
+		}
 		msg := fmt.Sprintf("unable to get project by ID: %d, error: %+v", gitLabProjectID, getProjectErr)
 		log.WithFields(f).WithError(getProjectErr).Warn(msg)
 		return nil, errors.New(msg)
@@ -113,6 +122,11 @@ func GetProjectByID(ctx context.Context, client *goGitLab.Client, gitLabProjectI
 	return project, nil
 }
 
+// IsProjectUnderGroup returns true when the GitLab project path is under the group full path, in any of its subgroups
+func IsProjectUnderGroup(projectPath, groupFullPath string) bool {
+	return groupFullPath != "" && strings.HasPrefix(strings.ToLower(projectPath), strings.ToLower(groupFullPath)+"/")
+}
+
 // EnableMergePipelineProtection enables the pipeline protection on given project, by default it's
 // turned off and when a new MR is raised users can merge requests bypassing the pipelines. With this
 // setting gitlab disables the Merge button if any of the pipelines are failing
@@ -5,8 +5,10 @@ package gitlab
 
 import (
 	"encoding/json"
+	"path/filepath"
 	"testing"
 
+	"github.com/communitybridge/easycla/cla-backend-go/keyring"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,7 +33,7 @@ func TestNewGitlabOauthClient(t *testing.T) {
 		encrypted, err := EncryptAuthInfo(&oauthResp, gitLabApp)
 		assert.NoError(t, err)
 
-		client, err := NewGitlabOauthClient(encrypted, gitLabApp)
+		client, err := NewGitlabOauthClient(encrypted, gitLabApp, nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, client)
 	}
@@ -59,3 +61,50 @@ func TestEncryptDecryptAuthInfo(t *testing.T) {
 		assert.Equal(t, &oauthResp, oauthRespDecrypted)
 	}
 }
+
+func TestEncryptDecryptWithKeyring(t *testing.T) {
+	kms, err := keyring.NewFileKMS(filepath.Join(t.TempDir(), "keys.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, kms.AddKey("key-1"))
+	assert.NoError(t, kms.AddKey("key-2"))
+
+	// a separate app, the singleton is shared by the other tests
+	gitLabApp := &App{gitLabAppID: glClientID, gitLabAppSecret: glClientSecret, gitLabAppPrivateKey: glClientKey}
+	legacy, err := EncryptSecret("secret", gitLabApp)
+	assert.NoError(t, err)
+	assert.False(t, NeedsReEncryption(legacy, gitLabApp))
+
+	keys, err := keyring.New(kms, "key-1")
+	assert.NoError(t, err)
+	gitLabApp.SetKeyring(keys)
+
+	// the values encrypted before the keyring are still decrypted
+	decrypted, err := DecryptSecret(legacy, gitLabApp)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+	assert.True(t, NeedsReEncryption(legacy, gitLabApp))
+
+	reEncrypted, err := ReEncrypt(legacy, gitLabApp)
+	assert.NoError(t, err)
+	assert.True(t, keyring.IsEnvelope(reEncrypted))
+	assert.False(t, NeedsReEncryption(reEncrypted, gitLabApp))
+
+	var oauthResp OauthSuccessResponse
+	assert.NoError(t, json.Unmarshal([]byte(oauthRespStr), &oauthResp))
+	authInfo, err := EncryptAuthInfo(&oauthResp, gitLabApp)
+	assert.NoError(t, err)
+
+	// key-2 becomes the primary key, key-1 is still active
+	keys, err = keyring.New(kms, "key-2", "key-1")
+	assert.NoError(t, err)
+	gitLabApp.SetKeyring(keys)
+	assert.True(t, NeedsReEncryption(authInfo, gitLabApp))
+	oauthRespDecrypted, err := DecryptAuthInfo(authInfo, gitLabApp)
+	assert.NoError(t, err)
+	assert.Equal(t, &oauthResp, oauthRespDecrypted)
+
+	// the envelopes can't be decrypted without the keyring
+	gitLabApp.SetKeyring(nil)
+	_, err = DecryptSecret(reEncrypted, gitLabApp)
+	assert.Error(t, err)
+}
@@ -0,0 +1,221 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+	"github.com/xanzy/go-gitlab"
+)
+
+// external status check constants
+const (
+	// ExternalStatusCheckName is the name of the external status check registered by EasyCLA
+	ExternalStatusCheckName = "EasyCLA"
+
+	ExternalStatusCheckPassed = "passed"
+	ExternalStatusCheckFailed = "failed"
+
+	// ExternalStatusCheckSignatureHeader is the header of the HMAC signature of the status check callbacks, signed
+	// with the shared secret of the external status check
+	ExternalStatusCheckSignatureHeader = "X-Gitlab-Signature"
+)
+
+// ErrExternalStatusChecksUnsupported is returned when the external status checks are not available for the project,
+// such as projects of the GitLab free tier
+var ErrExternalStatusChecksUnsupported = errors.New("external status checks are not available for the project")
+
+// ExternalStatusCheck is an external status check of a project
+type ExternalStatusCheck struct {
+	ID                int                       `json:"id"`
+	Name              string                    `json:"name"`
+	ProjectID         int                       `json:"project_id"`
+	ExternalURL       string                    `json:"external_url"`
+	ProtectedBranches []*gitlab.ProtectedBranch `json:"protected_branches"`
+}
+
+// MergeRequestStatusCheck is the status of an external status check on a merge request
+type MergeRequestStatusCheck struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	ExternalURL string `json:"external_url"`
+	Status      string `json:"status"`
+}
+
+type externalStatusCheckOptions struct {
+	Name        string `json:"name"`
+	ExternalURL string `json:"external_url"`
+	// an empty list applies the check to each branch
+	ProtectedBranchIDs []int `json:"protected_branch_ids"`
+	// the callbacks are signed with the shared secret, it is never returned by the api
+	SharedSecret string `json:"shared_secret,omitempty"`
+}
+
+type externalStatusCheckResponseOptions struct {
+	SHA                   string `json:"sha"`
+	ExternalStatusCheckID int    `json:"external_status_check_id"`
+	Status                string `json:"status"`
+}
+
+// ExternalStatusCheckURL returns the url GitLab calls back when the merge requests of the project change
+func ExternalStatusCheckURL(claAPIV4Base string) string {
+	return fmt.Sprintf("%s/v4/gitlab/status-check", claAPIV4Base)
+}
+
+// SetExternalStatusCheck registers the EasyCLA external status check on all the branches of the project, should be
+// idempotent operation. GitLab only has external status checks on the project level, so a group is enrolled by
+// registering the check on each of its projects. The callbacks are signed with the shared secret, the existing check
+// is always updated when a shared secret is given as the api doesn't return it.
+func SetExternalStatusCheck(gitLabClient *gitlab.Client, projectID int, externalURL, sharedSecret string) (*ExternalStatusCheck, error) {
+	f := logrus.Fields{
+		"functionName": "gitlab_api.SetExternalStatusCheck",
+		"projectID":    projectID,
+		"externalURL":  externalURL,
+	}
+
+	existingCheck, err := findExistingExternalStatusCheck(gitLabClient, projectID, externalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &externalStatusCheckOptions{
+		Name:               ExternalStatusCheckName,
+		ExternalURL:        externalURL,
+		ProtectedBranchIDs: []int{},
+		SharedSecret:       sharedSecret,
+	}
+
+	var statusCheck ExternalStatusCheck
+	if existingCheck == nil {
+		log.WithFields(f).Debug("adding the external status check...")
+		if err := doExternalStatusCheckRequest(gitLabClient, http.MethodPost, fmt.Sprintf("projects/%d/external_status_checks", projectID), options, &statusCheck); err != nil {
+			return nil, fmt.Errorf("adding external status check for project : %d, failed : %w", projectID, err)
+		}
+		return &statusCheck, nil
+	}
+
+	if existingCheck.Name == ExternalStatusCheckName && len(existingCheck.ProtectedBranches) == 0 && sharedSecret == "" {
+		return existingCheck, nil
+	}
+
+	log.WithFields(f).Debugf("updating the external status check : %d...", existingCheck.ID)
+	if err := doExternalStatusCheckRequest(gitLabClient, http.MethodPut, fmt.Sprintf("projects/%d/external_status_checks/%d", projectID, existingCheck.ID), options, &statusCheck); err != nil {
+		return nil, fmt.Errorf("editing external status check : %d for project : %d, failed : %w", existingCheck.ID, projectID, err)
+	}
+	return &statusCheck, nil
+}
+
+// RemoveExternalStatusCheck removes the EasyCLA external status check from the given project
+func RemoveExternalStatusCheck(gitLabClient *gitlab.Client, projectID int, externalURL string) error {
+	existingCheck, err := findExistingExternalStatusCheck(gitLabClient, projectID, externalURL)
+	if err != nil {
+		return err
+	}
+
+	if existingCheck == nil {
+		return nil
+	}
+
+	if err := doExternalStatusCheckRequest(gitLabClient, http.MethodDelete, fmt.Sprintf("projects/%d/external_status_checks/%d", projectID, existingCheck.ID), nil, nil); err != nil {
+		return fmt.Errorf("removing external status check : %d for project : %d, failed : %w", existingCheck.ID, projectID, err)
+	}
+	return nil
+}
+
+// GetMergeRequestExternalStatusCheck returns the EasyCLA external status check of the merge request, nil when the
+// project has no EasyCLA external status check
+func GetMergeRequestExternalStatusCheck(gitLabClient *gitlab.Client, projectID int, mergeID int, externalURL string) (*MergeRequestStatusCheck, error) {
+	var statusChecks []*MergeRequestStatusCheck
+	if err := doExternalStatusCheckRequest(gitLabClient, http.MethodGet, fmt.Sprintf("projects/%d/merge_requests/%d/status_checks", projectID, mergeID), nil, &statusChecks); err != nil {
+		return nil, fmt.Errorf("fetching status checks for project : %d and merge id : %d, failed : %w", projectID, mergeID, err)
+	}
+
+	for _, statusCheck := range statusChecks {
+		if statusCheck.ExternalURL == externalURL {
+			return statusCheck, nil
+		}
+	}
+	return nil, nil
+}
+
+// SetExternalStatusCheckResponse responds to the external status check of the merge request for the commit sha
+func SetExternalStatusCheckResponse(gitLabClient *gitlab.Client, projectID int, mergeID int, commitSha string, statusCheckID int, status string) error {
+	f := logrus.Fields{
+		"functionName":  "gitlab_api.SetExternalStatusCheckResponse",
+		"projectID":     projectID,
+		"mergeID":       mergeID,
+		"commitSha":     commitSha,
+		"statusCheckID": statusCheckID,
+		"status":        status,
+	}
+
+	log.WithFields(f).Debug("setting external status check response...")
+	options := &externalStatusCheckResponseOptions{
+		SHA:                   commitSha,
+		ExternalStatusCheckID: statusCheckID,
+		Status:                status,
+	}
+	if err := doExternalStatusCheckRequest(gitLabClient, http.MethodPost, fmt.Sprintf("projects/%d/merge_requests/%d/status_check_responses", projectID, mergeID), options, nil); err != nil {
+		return fmt.Errorf("setting external status check response for the sha : %s and project id : %d failed : %w", commitSha, projectID, err)
+	}
+
+	log.WithFields(f).Debug("external status check response set successfully")
+	return nil
+}
+
+// ExternalStatusCheckSignature returns the HMAC signature of the status check callback payload with the shared secret,
+// as sent in the ExternalStatusCheckSignatureHeader header
+func ExternalStatusCheckSignature(sharedSecret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(sharedSecret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidExternalStatusCheckSignature returns true when the signature of the status check callback payload matches the
+// shared secret, an empty signature never matches
+func ValidExternalStatusCheckSignature(sharedSecret string, payload []byte, signature string) bool {
+	if sharedSecret == "" || signature == "" {
+		return false
+	}
+	return hmac.Equal([]byte(ExternalStatusCheckSignature(sharedSecret, payload)), []byte(signature))
+}
+
+func findExistingExternalStatusCheck(gitLabClient *gitlab.Client, projectID int, externalURL string) (*ExternalStatusCheck, error) {
+	var statusChecks []*ExternalStatusCheck
+	if err := doExternalStatusCheckRequest(gitLabClient, http.MethodGet, fmt.Sprintf("projects/%d/external_status_checks", projectID), nil, &statusChecks); err != nil {
+		return nil, fmt.Errorf("fetching external status checks for project : %d, failed : %w", projectID, err)
+	}
+
+	for _, statusCheck := range statusChecks {
+		if statusCheck.ExternalURL == externalURL {
+			return statusCheck, nil
+		}
+	}
+	return nil, nil
+}
+
+// doExternalStatusCheckRequest calls the external status checks api, which is not part of the go-gitlab version we use
+func doExternalStatusCheckRequest(gitLabClient *gitlab.Client, method, path string, opt, v interface{}) error {
+	req, err := gitLabClient.NewRequest(method, path, opt, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := gitLabClient.Do(req, v)
+	if err != nil {
+		// the api is only available on the ultimate tier
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			return fmt.Errorf("%w : %v", ErrExternalStatusChecksUnsupported, err)
+		}
+		return err
+	}
+	return nil
+}
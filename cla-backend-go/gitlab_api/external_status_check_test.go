@@ -0,0 +1,139 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xanzy/go-gitlab"
+)
+
+const testStatusCheckURL = "https://api.easycla.lfx.dev/v4/gitlab/status-check"
+
+func newTestGitLabClient(t *testing.T, handler http.HandlerFunc) *gitlab.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the client loads the rate limits from the base url when created
+		if r.URL.Path == "/api/v4/" {
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+	assert.NoError(t, err)
+	return client
+}
+
+func TestSetExternalStatusCheckCreate(t *testing.T) {
+	var created map[string]interface{}
+	client := newTestGitLabClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/7/external_status_checks":
+			_, _ = w.Write([]byte(`[{"id":1,"name":"compliance","project_id":7,"external_url":"https://compliance.example.com"}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/projects/7/external_status_checks":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":2,"name":"EasyCLA","project_id":7,"external_url":"` + testStatusCheckURL + `","protected_branches":[]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	statusCheck, err := SetExternalStatusCheck(client, 7, testStatusCheckURL, "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, statusCheck.ID)
+	assert.Equal(t, ExternalStatusCheckName, created["name"])
+	assert.Equal(t, "secret", created["shared_secret"])
+	assert.Equal(t, testStatusCheckURL, created["external_url"])
+	assert.Equal(t, []interface{}{}, created["protected_branch_ids"])
+}
+
+func TestSetExternalStatusCheckExisting(t *testing.T) {
+	client := newTestGitLabClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"id":2,"name":"EasyCLA","project_id":7,"external_url":"` + testStatusCheckURL + `","protected_branches":[]}]`))
+	})
+
+	statusCheck, err := SetExternalStatusCheck(client, 7, testStatusCheckURL, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, statusCheck.ID)
+}
+
+func TestSetExternalStatusCheckExistingSharedSecret(t *testing.T) {
+	var updated map[string]interface{}
+	client := newTestGitLabClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/7/external_status_checks":
+			_, _ = w.Write([]byte(`[{"id":2,"name":"EasyCLA","project_id":7,"external_url":"` + testStatusCheckURL + `","protected_branches":[]}]`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v4/projects/7/external_status_checks/2":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+			_, _ = w.Write([]byte(`{"id":2,"name":"EasyCLA","project_id":7,"external_url":"` + testStatusCheckURL + `","protected_branches":[]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	statusCheck, err := SetExternalStatusCheck(client, 7, testStatusCheckURL, "rotated")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, statusCheck.ID)
+	assert.Equal(t, "rotated", updated["shared_secret"])
+}
+
+func TestValidExternalStatusCheckSignature(t *testing.T) {
+	payload := []byte(`{"object_kind":"merge_request"}`)
+	signature := ExternalStatusCheckSignature("secret", payload)
+
+	assert.True(t, ValidExternalStatusCheckSignature("secret", payload, signature))
+	assert.False(t, ValidExternalStatusCheckSignature("other", payload, signature))
+	assert.False(t, ValidExternalStatusCheckSignature("secret", []byte(`{"object_kind":"note"}`), signature))
+	assert.False(t, ValidExternalStatusCheckSignature("secret", payload, ""))
+	assert.False(t, ValidExternalStatusCheckSignature("", payload, ExternalStatusCheckSignature("", payload)))
+}
+
+func TestExternalStatusChecksUnsupported(t *testing.T) {
+	client := newTestGitLabClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"403 Forbidden"}`))
+	})
+
+	_, err := SetExternalStatusCheck(client, 7, testStatusCheckURL, "secret")
+	assert.True(t, errors.Is(err, ErrExternalStatusChecksUnsupported))
+
+	_, err = GetMergeRequestExternalStatusCheck(client, 7, 3, testStatusCheckURL)
+	assert.True(t, errors.Is(err, ErrExternalStatusChecksUnsupported))
+}
+
+func TestSetExternalStatusCheckResponse(t *testing.T) {
+	var response map[string]interface{}
+	client := newTestGitLabClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/7/merge_requests/3/status_checks":
+			_, _ = w.Write([]byte(`[{"id":1,"name":"compliance","external_url":"https://compliance.example.com","status":"passed"},{"id":2,"name":"EasyCLA","external_url":"` + testStatusCheckURL + `","status":"pending"}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/projects/7/merge_requests/3/status_check_responses":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&response))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":5,"status":"passed"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	statusCheck, err := GetMergeRequestExternalStatusCheck(client, 7, 3, testStatusCheckURL)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, statusCheck.ID)
+	assert.Equal(t, "pending", statusCheck.Status)
+
+	assert.NoError(t, SetExternalStatusCheckResponse(client, 7, 3, "abc123", statusCheck.ID, ExternalStatusCheckPassed))
+	assert.Equal(t, "abc123", response["sha"])
+	assert.Equal(t, float64(2), response["external_status_check_id"])
+	assert.Equal(t, ExternalStatusCheckPassed, response["status"])
+}
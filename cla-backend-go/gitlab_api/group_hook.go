@@ -0,0 +1,185 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// project and subgroup hook event names, sent by the group hooks and the system hooks
+const (
+	ProjectCreateEvent   = "project_create"
+	ProjectRenameEvent   = "project_rename"
+	ProjectTransferEvent = "project_transfer"
+	ProjectDestroyEvent  = "project_destroy"
+	ProjectUpdateEvent   = "project_update"
+
+	GroupCreateEvent     = "group_create"
+	GroupRenameEvent     = "group_rename"
+	GroupDestroyEvent    = "group_destroy"
+	SubgroupCreateEvent  = "subgroup_create"
+	SubgroupDestroyEvent = "subgroup_destroy"
+)
+
+// ErrGroupHooksUnsupported is returned when the group hooks are not available for the group, such as groups of the
+// GitLab free tier
+var ErrGroupHooksUnsupported = errors.New("group hooks are not available for the group")
+
+// GroupHook is a group hook with the project and subgroup events, which are not part of the go-gitlab version we use
+type GroupHook struct {
+	ID                    int    `json:"id"`
+	URL                   string `json:"url"`
+	GroupID               int    `json:"group_id"`
+	ProjectEvents         bool   `json:"project_events"`
+	SubgroupEvents        bool   `json:"subgroup_events"`
+	EnableSSLVerification bool   `json:"enable_ssl_verification"`
+}
+
+type groupHookOptions struct {
+	URL                   string  `json:"url"`
+	ProjectEvents         bool    `json:"project_events"`
+	SubgroupEvents        bool    `json:"subgroup_events"`
+	EnableSSLVerification bool    `json:"enable_ssl_verification"`
+	Token                 *string `json:"token,omitempty"`
+}
+
+// ProjectHookURL returns the url GitLab calls back with the project and subgroup events of the group hooks and the
+// system hooks
+func ProjectHookURL(claAPIV4Base string) string {
+	return fmt.Sprintf("%s/v4/gitlab/project-hook", claAPIV4Base)
+}
+
+// SetGroupWebHook adds the group hook sending the project and subgroup events of the group and its subgroups, if the
+// hook is there already tries to set the events if anything is missing, should be idempotent operation
+func SetGroupWebHook(gitLabClient *gitlab.Client, hookURL string, groupID int, token string) error {
+	existingHook, err := findExistingGroupHook(gitLabClient, hookURL, groupID)
+	if err != nil {
+		return err
+	}
+
+	opts := &groupHookOptions{
+		URL:                   hookURL,
+		ProjectEvents:         true,
+		SubgroupEvents:        true,
+		EnableSSLVerification: true,
+		Token:                 gitlab.String(token),
+	}
+
+	if existingHook == nil {
+		if err = doGroupHookRequest(gitLabClient, http.MethodPost, fmt.Sprintf("groups/%d/hooks", groupID), opts, nil); err != nil {
+			return fmt.Errorf("adding group hook for group : %d, failed : %w", groupID, err)
+		}
+		return nil
+	}
+
+	if !existingHook.EnableSSLVerification || !existingHook.ProjectEvents || !existingHook.SubgroupEvents {
+		if err = doGroupHookRequest(gitLabClient, http.MethodPut, fmt.Sprintf("groups/%d/hooks/%d", groupID, existingHook.ID), opts, nil); err != nil {
+			return fmt.Errorf("editing group hook for group : %d, failed : %w", groupID, err)
+		}
+	}
+
+	return nil
+}
+
+// SetGroupWebHookToken replaces the secret token of the group hook of the given groupID, the secret tokens aren't
+// returned by GitLab so the hook is always edited - the hook is added when missing
+func SetGroupWebHookToken(gitLabClient *gitlab.Client, hookURL string, groupID int, token string) error {
+	existingHook, err := findExistingGroupHook(gitLabClient, hookURL, groupID)
+	if err != nil {
+		return err
+	}
+
+	if existingHook == nil {
+		return SetGroupWebHook(gitLabClient, hookURL, groupID, token)
+	}
+
+	err = doGroupHookRequest(gitLabClient, http.MethodPut, fmt.Sprintf("groups/%d/hooks/%d", groupID, existingHook.ID), &groupHookOptions{
+		URL:                   hookURL,
+		ProjectEvents:         true,
+		SubgroupEvents:        true,
+		EnableSSLVerification: true,
+		Token:                 gitlab.String(token),
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("editing group hook token for group : %d, failed : %w", groupID, err)
+	}
+
+	return nil
+}
+
+// RemoveGroupWebHook removes the existing group hook from the given group
+func RemoveGroupWebHook(gitLabClient *gitlab.Client, hookURL string, groupID int) error {
+	existingHook, err := findExistingGroupHook(gitLabClient, hookURL, groupID)
+	if err != nil {
+		return err
+	}
+
+	if existingHook == nil {
+		return nil
+	}
+
+	return doGroupHookRequest(gitLabClient, http.MethodDelete, fmt.Sprintf("groups/%d/hooks/%d", groupID, existingHook.ID), nil, nil)
+}
+
+// ParseProjectHookEvent parses the payload of a group hook or system hook event. The project events are returned as
+// *gitlab.ProjectSystemEvent, the group and subgroup events as *gitlab.GroupSystemEvent - the subgroup events of the
+// group hooks use the fields of the group events of the system hooks.
+func ParseProjectHookEvent(payload []byte) (interface{}, error) {
+	var base gitlab.BaseSystemEvent
+	if err := json.Unmarshal(payload, &base); err != nil {
+		return nil, err
+	}
+
+	var event interface{}
+	switch base.EventName {
+	case ProjectCreateEvent, ProjectRenameEvent, ProjectTransferEvent, ProjectDestroyEvent, ProjectUpdateEvent:
+		event = &gitlab.ProjectSystemEvent{}
+	case GroupCreateEvent, GroupRenameEvent, GroupDestroyEvent, SubgroupCreateEvent, SubgroupDestroyEvent:
+		event = &gitlab.GroupSystemEvent{}
+	default:
+		return nil, fmt.Errorf("unexpected project hook event : %s", base.EventName)
+	}
+
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func findExistingGroupHook(gitLabClient *gitlab.Client, hookURL string, groupID int) (*GroupHook, error) {
+	var hooks []*GroupHook
+	if err := doGroupHookRequest(gitLabClient, http.MethodGet, fmt.Sprintf("groups/%d/hooks", groupID), nil, &hooks); err != nil {
+		return nil, fmt.Errorf("fetching hooks for group : %d, failed : %w", groupID, err)
+	}
+
+	for _, hook := range hooks {
+		if hook.URL == hookURL {
+			return hook, nil
+		}
+	}
+	return nil, nil
+}
+
+// doGroupHookRequest calls the group hooks api with the project and subgroup events
+func doGroupHookRequest(gitLabClient *gitlab.Client, method, path string, opt, v interface{}) error {
+	req, err := gitLabClient.NewRequest(method, path, opt, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := gitLabClient.Do(req, v)
+	if err != nil {
+		// the api is only available on the premium tier
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			return fmt.Errorf("%w : %v", ErrGroupHooksUnsupported, err)
+		}
+		return err
+	}
+	return nil
+}
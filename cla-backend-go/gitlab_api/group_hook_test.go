@@ -0,0 +1,85 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xanzy/go-gitlab"
+)
+
+const testProjectHookURL = "https://api.easycla.lfx.dev/v4/gitlab/project-hook"
+
+func TestSetGroupWebHookCreate(t *testing.T) {
+	var created map[string]interface{}
+	client := newTestGitLabClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/groups/5/hooks":
+			_, _ = w.Write([]byte(`[{"id":1,"url":"https://ci.example.com","group_id":5}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/groups/5/hooks":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":2,"url":"` + testProjectHookURL + `","group_id":5,"project_events":true,"subgroup_events":true}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	assert.NoError(t, SetGroupWebHook(client, testProjectHookURL, 5, "secret"))
+	assert.Equal(t, testProjectHookURL, created["url"])
+	assert.Equal(t, true, created["project_events"])
+	assert.Equal(t, true, created["subgroup_events"])
+	assert.Equal(t, "secret", created["token"])
+}
+
+func TestSetGroupWebHookExisting(t *testing.T) {
+	client := newTestGitLabClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"id":2,"url":"` + testProjectHookURL + `","group_id":5,"project_events":true,"subgroup_events":true,"enable_ssl_verification":true}]`))
+	})
+
+	assert.NoError(t, SetGroupWebHook(client, testProjectHookURL, 5, "secret"))
+}
+
+func TestGroupHooksUnsupported(t *testing.T) {
+	client := newTestGitLabClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"404 Not Found"}`))
+	})
+
+	err := SetGroupWebHook(client, testProjectHookURL, 5, "secret")
+	assert.True(t, errors.Is(err, ErrGroupHooksUnsupported))
+}
+
+func TestParseProjectHookEvent(t *testing.T) {
+	event, err := ParseProjectHookEvent([]byte(`{"event_name":"project_rename","name":"tools","path":"tools","path_with_namespace":"org/tools","project_id":74,"old_path_with_namespace":"org/utils"}`))
+	assert.NoError(t, err)
+	projectEvent, ok := event.(*gitlab.ProjectSystemEvent)
+	assert.True(t, ok)
+	assert.Equal(t, ProjectRenameEvent, projectEvent.EventName)
+	assert.Equal(t, 74, projectEvent.ProjectID)
+	assert.Equal(t, "org/utils", projectEvent.OldPathWithNamespace)
+
+	event, err = ParseProjectHookEvent([]byte(`{"event_name":"project_update","name":"tools","path":"tools","path_with_namespace":"org/tools","project_id":74}`))
+	assert.NoError(t, err)
+	projectEvent, ok = event.(*gitlab.ProjectSystemEvent)
+	assert.True(t, ok)
+	assert.Equal(t, ProjectUpdateEvent, projectEvent.EventName)
+
+	event, err = ParseProjectHookEvent([]byte(`{"event_name":"subgroup_create","name":"sub","path":"sub","full_path":"org/sub","group_id":10,"parent_group_id":7}`))
+	assert.NoError(t, err)
+	groupEvent, ok := event.(*gitlab.GroupSystemEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "org/sub", groupEvent.PathWithNamespace)
+	assert.Equal(t, 10, groupEvent.GroupID)
+
+	_, err = ParseProjectHookEvent([]byte(`{"event_name":"user_create"}`))
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ package gitlab
 import (
 	"sync"
 
+	"github.com/communitybridge/easycla/cla-backend-go/keyring"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 )
 
@@ -14,6 +15,9 @@ type App struct {
 	gitLabAppID         string
 	gitLabAppSecret     string
 	gitLabAppPrivateKey string
+	// keys encrypts the oauth credentials and secrets when set, the values encrypted with the private key are still
+	// decrypted until they are re-encrypted
+	keys *keyring.Keyring
 }
 
 var gitLabAppSingleton *App
@@ -50,3 +54,13 @@ func (app *App) GetAppSecret() string {
 func (app *App) GetAppPrivateKey() string {
 	return app.gitLabAppPrivateKey
 }
+
+// SetKeyring sets the keyring encrypting the oauth credentials and secrets
+func (app *App) SetKeyring(keys *keyring.Keyring) {
+	app.keys = keys
+}
+
+// GetKeyring returns the keyring encrypting the oauth credentials and secrets, nil when not configured
+func (app *App) GetKeyring() *keyring.Keyring {
+	return app.keys
+}
@@ -0,0 +1,98 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	goGitLab "github.com/xanzy/go-gitlab"
+)
+
+// gitlab instance constants
+const (
+	// DefaultInstanceID is the ID of the gitlab.com instance, used by the GitLab organizations without an instance
+	DefaultInstanceID = "gitlab.com"
+	// DefaultBaseURL is the base URL of the gitlab.com instance
+	DefaultBaseURL = "https://gitlab.com"
+)
+
+// Instance is a GitLab instance with the EasyCLA OAuth application registered on it, either gitlab.com or a
+// self-managed GitLab
+type Instance struct {
+	InstanceID      string
+	BaseURL         string
+	AppClientID     string
+	AppClientSecret string
+	RedirectURI     string
+	// WebhookSecret is the secret token of the project webhooks, the auth state of the GitLab organization is used
+	// when empty
+	WebhookSecret string
+}
+
+// DefaultInstance returns the gitlab.com instance using the EasyCLA GitLab application of the configuration
+func DefaultInstance() *Instance {
+	gitLabConfig := config.GetConfig().Gitlab
+	return &Instance{
+		InstanceID:      DefaultInstanceID,
+		BaseURL:         DefaultBaseURL,
+		AppClientID:     gitLabConfig.AppClientID,
+		AppClientSecret: gitLabConfig.AppClientSecret,
+		RedirectURI:     gitLabConfig.RedirectURI,
+	}
+}
+
+// IsDefaultInstanceID returns true if the instance ID refers to gitlab.com
+func IsDefaultInstanceID(instanceID string) bool {
+	return instanceID == "" || instanceID == DefaultInstanceID
+}
+
+// resolveInstance returns the gitlab.com instance when the given instance is nil
+func resolveInstance(instance *Instance) *Instance {
+	if instance == nil {
+		return DefaultInstance()
+	}
+	return instance
+}
+
+// baseURL returns the base URL of the instance without the trailing slash
+func (i *Instance) baseURL() string {
+	if i == nil || i.BaseURL == "" {
+		return DefaultBaseURL
+	}
+	return strings.TrimSuffix(i.BaseURL, "/")
+}
+
+// OauthTokenURL returns the OAuth token endpoint of the instance
+func (i *Instance) OauthTokenURL() string {
+	return fmt.Sprintf("%s/oauth/token", i.baseURL())
+}
+
+// OauthAuthorizeURL returns the OAuth authorization endpoint of the instance
+func (i *Instance) OauthAuthorizeURL() string {
+	return fmt.Sprintf("%s/oauth/authorize", i.baseURL())
+}
+
+// ApplicationsURL returns the page of the instance listing the OAuth applications authorized by the user
+func (i *Instance) ApplicationsURL() string {
+	return fmt.Sprintf("%s/-/profile/applications", i.baseURL())
+}
+
+// WebhookToken returns the secret token for the project webhooks, the given fallback when the instance has no
+// webhook secret
+func (i *Instance) WebhookToken(fallback string) string {
+	if i == nil || i.WebhookSecret == "" {
+		return fallback
+	}
+	return i.WebhookSecret
+}
+
+// clientOptions returns the options of the GitLab API clients of the instance
+func (i *Instance) clientOptions() []goGitLab.ClientOptionFunc {
+	return []goGitLab.ClientOptionFunc{
+		goGitLab.WithBaseURL(i.baseURL()),
+		instrumentedHTTPClient(),
+	}
+}
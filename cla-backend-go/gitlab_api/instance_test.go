@@ -0,0 +1,59 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceURLs(t *testing.T) {
+	instance := &Instance{InstanceID: "gitlab.example.org", BaseURL: "https://gitlab.example.org/"}
+	assert.Equal(t, "https://gitlab.example.org/oauth/token", instance.OauthTokenURL())
+	assert.Equal(t, "https://gitlab.example.org/oauth/authorize", instance.OauthAuthorizeURL())
+	assert.Equal(t, "https://gitlab.example.org/-/profile/applications", instance.ApplicationsURL())
+
+	var defaultInstance *Instance
+	assert.Equal(t, "https://gitlab.com/oauth/token", defaultInstance.OauthTokenURL())
+}
+
+func TestInstanceWebhookToken(t *testing.T) {
+	assert.Equal(t, "auth-state", (&Instance{}).WebhookToken("auth-state"))
+	assert.Equal(t, "secret", (&Instance{WebhookSecret: "secret"}).WebhookToken("auth-state"))
+
+	var defaultInstance *Instance
+	assert.Equal(t, "auth-state", defaultInstance.WebhookToken("auth-state"))
+}
+
+func TestIsDefaultInstanceID(t *testing.T) {
+	assert.True(t, IsDefaultInstanceID(""))
+	assert.True(t, IsDefaultInstanceID(DefaultInstanceID))
+	assert.False(t, IsDefaultInstanceID("gitlab.example.org"))
+}
+
+func TestEncryptDecryptSecret(t *testing.T) {
+	gitLabApp := Init(glClientID, glClientSecret, glClientKey)
+
+	encrypted, err := EncryptSecret("instance-secret", gitLabApp)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "instance-secret", encrypted)
+
+	decrypted, err := DecryptSecret(encrypted, gitLabApp)
+	assert.NoError(t, err)
+	assert.Equal(t, "instance-secret", decrypted)
+
+	_, err = DecryptSecret("abcd", gitLabApp)
+	assert.Error(t, err)
+}
+
+func TestNewSecret(t *testing.T) {
+	secret, err := NewSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 64)
+
+	other, err := NewSecret()
+	assert.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+}
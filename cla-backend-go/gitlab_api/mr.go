@@ -5,9 +5,11 @@ package gitlab
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/comment_templates"
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/sirupsen/logrus"
@@ -24,6 +26,68 @@ func FetchMrInfo(client *gitlab.Client, projectID int, mergeID int) (*gitlab.Mer
 	return m, nil
 }
 
+// MrChanges contains the changed files and the size of a merge request - used to evaluate the repository requirements
+type MrChanges struct {
+	// Files are the paths of the changed files, the renamed files include both the old and the new path
+	Files []string
+	// FilesComplete is false when GitLab truncated the list of the changed files
+	FilesComplete bool
+	// ChangedLines is the number of added and deleted lines
+	ChangedLines int64
+	// ChangedLinesKnown is false when GitLab did not return the diff of one or more changed files
+	ChangedLinesKnown bool
+}
+
+// FetchMrChanges is responsible for fetching the changed files and the size of the merge request
+func FetchMrChanges(client *gitlab.Client, projectID int, mergeID int) (*MrChanges, error) {
+	f := logrus.Fields{
+		"functionName": "gitlab_api.FetchMrChanges",
+		"projectID":    projectID,
+		"mergeID":      mergeID,
+	}
+
+	log.WithFields(f).Debug("fetching merge request changes...")
+	m, _, err := client.MergeRequests.GetMergeRequestChanges(projectID, mergeID, &gitlab.GetMergeRequestChangesOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("fetching merge request changes : %d for project : %v failed : %v", mergeID, projectID, err)
+	}
+
+	changes := mrChanges(m)
+	log.WithFields(f).Debugf("found %d changed files, complete: %t, %d changed lines, known: %t",
+		len(changes.Files), changes.FilesComplete, changes.ChangedLines, changes.ChangedLinesKnown)
+	return changes, nil
+}
+
+// mrChanges collects the changed files and counts the added and deleted lines of the merge request diffs - GitLab
+// returns an empty diff for the collapsed or too large files, the size is then unknown
+func mrChanges(m *gitlab.MergeRequest) *MrChanges {
+	changes := &MrChanges{
+		FilesComplete:     !m.Overflow,
+		ChangedLinesKnown: !m.Overflow,
+	}
+
+	for _, change := range m.Changes {
+		changes.Files = append(changes.Files, change.NewPath)
+		if change.OldPath != "" && change.OldPath != change.NewPath {
+			changes.Files = append(changes.Files, change.OldPath)
+		}
+
+		if change.Diff == "" {
+			if !change.RenamedFile {
+				changes.ChangedLinesKnown = false
+			}
+			continue
+		}
+		for _, line := range strings.Split(change.Diff, "\n") {
+			if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+				changes.ChangedLines++
+			}
+		}
+	}
+
+	return changes
+}
+
 func GetLatestCommit(client *gitlab.Client, projectID int, mergeID int) (*gitlab.Commit, error) {
 	f := logrus.Fields{
 		"functionName": "gitlab_api.GetLatestCommit",
@@ -44,8 +108,22 @@ func GetLatestCommit(client *gitlab.Client, projectID int, mergeID int) (*gitlab
 	return commits[0], nil
 }
 
+// MrParticipant is a merge request commit author, with the bot flag of the GitLab user which is not part of the
+// go-gitlab version we use
+type MrParticipant struct {
+	*gitlab.User
+	// Bot is set when GitLab reports the user as a bot, such as the users of the project and group access tokens
+	Bot bool
+}
+
+// gitLabUser is a GitLab user of the users api with the bot flag
+type gitLabUser struct {
+	gitlab.User
+	Bot bool `json:"bot"`
+}
+
 // FetchMrParticipants is responsible to get unique mr participants
-func FetchMrParticipants(client *gitlab.Client, projectID int, mergeID int) ([]*gitlab.User, error) {
+func FetchMrParticipants(client *gitlab.Client, projectID int, mergeID int) ([]*MrParticipant, error) {
 	f := logrus.Fields{
 		"functionName": "gitlab_api.FetchMrParticipants",
 		"projectID":    projectID,
@@ -65,7 +143,7 @@ func FetchMrParticipants(client *gitlab.Client, projectID int, mergeID int) ([]*
 		return nil, nil
 	}
 
-	var results []*gitlab.User
+	var results []*MrParticipant
 
 	for _, commit := range commits {
 		log.WithFields(f).Debugf("commit information: %v", commit)
@@ -132,7 +210,7 @@ func SetMrComment(client *gitlab.Client, projectID int, mergeID int, message str
 
 	if len(notes) > 0 {
 		for _, n := range notes {
-			if strings.Contains(n.Body, "cla-signed.svg") || strings.Contains(n.Body, "cla-not-signed.svg") || strings.Contains(n.Body, "cla-missing-id.svg") || strings.Contains(n.Body, "cla-confirmation-needed.svg") {
+			if strings.Contains(n.Body, "cla-signed.svg") || strings.Contains(n.Body, "cla-not-signed.svg") || strings.Contains(n.Body, "cla-missing-id.svg") || strings.Contains(n.Body, "cla-confirmation-needed.svg") || strings.Contains(n.Body, comment_templates.MarkerPrefix) {
 				previousNote = n
 				break
 			}
@@ -161,23 +239,31 @@ func SetMrComment(client *gitlab.Client, projectID int, mergeID int, message str
 }
 
 // getUser is responsible for fetching the user info for given user email
-func getUser(client *gitlab.Client, email, name *string) (*gitlab.User, error) {
+func getUser(client *gitlab.Client, email, name *string) (*MrParticipant, error) {
 	f := logrus.Fields{
 		"functionName": "gitlab_api.getUser",
 		"email":        *email,
 		"name":         *name,
 	}
 
-	user := &gitlab.User{
-		Email: *email,
-		Name:  *name,
+	user := &MrParticipant{
+		User: &gitlab.User{
+			Email: *email,
+			Name:  *name,
+		},
 	}
 
-	users, _, err := client.Users.ListUsers(&gitlab.ListUsersOptions{
+	// the users api is called directly, the bot flag is not part of the go-gitlab version we use
+	req, err := client.NewRequest(http.MethodGet, "users", &gitlab.ListUsersOptions{
 		Active:  utils.Bool(true),
 		Blocked: utils.Bool(false),
 		Search:  email,
-	})
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var users []*gitLabUser
+	_, err = client.Do(req, &users)
 	if err != nil {
 		log.WithFields(f).Warnf("unable to find user for email : %s, error : %v", utils.StringValue(email), err)
 		return nil, err
@@ -195,6 +281,7 @@ func getUser(client *gitlab.Client, email, name *string) (*gitlab.User, error) {
 			log.WithFields(f).Debugf("found matching user : %+v - updating GitLab username and ID", found)
 			user.Username = found.Username
 			user.ID = found.ID
+			user.Bot = found.Bot
 			break
 		}
 	}
@@ -0,0 +1,46 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchMrChanges(t *testing.T) {
+	client := newTestGitLabClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v4/projects/7/merge_requests/3/changes" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			return
+		}
+		_, _ = w.Write([]byte(`{"iid":3,"target_branch":"main","overflow":false,"changes":[
+			{"old_path":"docs/index.md","new_path":"docs/index.md","diff":"@@ -1,2 +1,2 @@\n-old line\n+new line\n context\n"},
+			{"old_path":"README.md","new_path":"docs/README.md","renamed_file":true,"diff":""},
+			{"old_path":"docs/new.md","new_path":"docs/new.md","new_file":true,"diff":"@@ -0,0 +1 @@\n+added\n"}
+		]}`))
+	})
+
+	changes, err := FetchMrChanges(client, 7, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/index.md", "docs/README.md", "README.md", "docs/new.md"}, changes.Files)
+	assert.True(t, changes.FilesComplete)
+	assert.True(t, changes.ChangedLinesKnown)
+	assert.Equal(t, int64(3), changes.ChangedLines)
+}
+
+func TestFetchMrChangesOverflow(t *testing.T) {
+	client := newTestGitLabClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"iid":3,"overflow":true,"changes":[
+			{"old_path":"docs/index.md","new_path":"docs/index.md","diff":"@@ -1 +1 @@\n-old\n+new\n"},
+			{"old_path":"src/large.go","new_path":"src/large.go","diff":""}
+		]}`))
+	})
+
+	changes, err := FetchMrChanges(client, 7, 3)
+	assert.NoError(t, err)
+	assert.False(t, changes.FilesComplete)
+	assert.False(t, changes.ChangedLinesKnown)
+}
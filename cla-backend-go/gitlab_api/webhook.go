@@ -49,6 +49,33 @@ func SetWebHook(gitLabClient *gitlab.Client, hookURL string, projectID int, toke
 	return nil
 }
 
+// SetWebHookToken replaces the secret token of the webhook of the given projectID, the secret tokens aren't returned
+// by GitLab so the hook is always edited - the webhook is added when missing
+func SetWebHookToken(gitLabClient *gitlab.Client, hookURL string, projectID int, token string) error {
+	existingWebHook, err := findExistingWebHook(gitLabClient, hookURL, projectID)
+	if err != nil {
+		return err
+	}
+
+	if existingWebHook == nil {
+		return SetWebHook(gitLabClient, hookURL, projectID, token)
+	}
+
+	_, _, err = gitLabClient.Projects.EditProjectHook(projectID, existingWebHook.ID, &gitlab.EditProjectHookOptions{
+		URL:                   gitlab.String(hookURL),
+		MergeRequestsEvents:   gitlab.Bool(true),
+		PushEvents:            gitlab.Bool(true),
+		NoteEvents:            gitlab.Bool(true), // subscribe to comment events
+		EnableSSLVerification: gitlab.Bool(true),
+		Token:                 gitlab.String(token),
+	})
+	if err != nil {
+		return fmt.Errorf("editing web hook token for project : %d, failed : %v", projectID, err)
+	}
+
+	return nil
+}
+
 // RemoveWebHook removes existing webhook from the given project
 func RemoveWebHook(gitLabClient *gitlab.Client, hookURL string, projectID int) error {
 	existingWebHook, err := findExistingWebHook(gitLabClient, hookURL, projectID)
@@ -21,6 +21,30 @@ func Configure(api *operations.ClaAPI, service Service) {
 
 		return health.NewHealthCheckOK().WithPayload(result)
 	})
+
+	api.HealthLivenessCheckHandler = health.LivenessCheckHandlerFunc(func(params health.LivenessCheckParams) middleware.Responder {
+		result, err := service.LivenessCheck(params.HTTPRequest.Context())
+		if err != nil {
+			return health.NewLivenessCheckServiceUnavailable().WithPayload(&models.Health{Status: StatusNotHealthy})
+		}
+
+		if result.Status == StatusNotHealthy {
+			return health.NewLivenessCheckServiceUnavailable().WithPayload(result)
+		}
+		return health.NewLivenessCheckOK().WithPayload(result)
+	})
+
+	api.HealthReadinessCheckHandler = health.ReadinessCheckHandlerFunc(func(params health.ReadinessCheckParams) middleware.Responder {
+		result, err := service.ReadinessCheck(params.HTTPRequest.Context())
+		if err != nil {
+			return health.NewReadinessCheckServiceUnavailable().WithPayload(&models.Health{Status: StatusNotHealthy})
+		}
+
+		if result.Status == StatusNotHealthy {
+			return health.NewReadinessCheckServiceUnavailable().WithPayload(result)
+		}
+		return health.NewReadinessCheckOK().WithPayload(result)
+	})
 }
 
 type codedResponse interface {
@@ -0,0 +1,127 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// overall status values
+const (
+	// StatusHealthy indicates all the probes passed
+	StatusHealthy = "healthy"
+	// StatusDegraded indicates one or more optional probes failed - the service is still usable
+	StatusDegraded = "degraded"
+	// StatusNotHealthy indicates one or more critical probes failed
+	StatusNotHealthy = "not healthy"
+)
+
+// DefaultProbeTimeout is the timeout used when the probe does not specify one
+const DefaultProbeTimeout = 5 * time.Second
+
+// Probe is a single health check of an external dependency
+type Probe struct {
+	// Name is the name of the sub-system reported in the health response
+	Name string
+	// Critical probes make the service not healthy when they fail, non-critical probes only degrade the service
+	Critical bool
+	// Timeout bounds the execution of the probe, DefaultProbeTimeout is used when zero
+	Timeout time.Duration
+	// Check runs the probe - a nil error is success
+	Check func(ctx context.Context) error
+}
+
+// runProbes runs the probes concurrently, each with its own timeout, and returns the status of each probe
+func runProbes(ctx context.Context, probes []Probe) []*models.HealthStatus {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allStatus := make([]*models.HealthStatus, 0, len(probes))
+
+	wg.Add(len(probes))
+	for _, probe := range probes {
+		go func(probe Probe) {
+			defer wg.Done()
+			status := runProbe(ctx, probe)
+			mu.Lock()
+			allStatus = append(allStatus, status)
+			mu.Unlock()
+		}(probe)
+	}
+	wg.Wait()
+
+	// Stable ordering makes the report easier to read and compare
+	sort.Slice(allStatus, func(i, j int) bool {
+		return allStatus[i].Name < allStatus[j].Name
+	})
+
+	return allStatus
+}
+
+// runProbe runs a single probe and converts the result into a health status
+func runProbe(ctx context.Context, probe Probe) *models.HealthStatus {
+	f := logrus.Fields{
+		"functionName": "health.probe.runProbe",
+		"probe":        probe.Name,
+		"critical":     probe.Critical,
+	}
+
+	timeout := probe.Timeout
+	if timeout <= 0 {
+		timeout = DefaultProbeTimeout
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- probe.Check(probeCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-probeCtx.Done():
+		err = fmt.Errorf("probe timed out after %s", timeout)
+	}
+	latency := time.Since(start)
+
+	status := &models.HealthStatus{
+		TimeStamp:     time.Now().UTC().Format(time.RFC3339),
+		Name:          probe.Name,
+		Healthy:       err == nil,
+		Critical:      probe.Critical,
+		Duration:      latency.String(),
+		LatencyMillis: latency.Milliseconds(),
+	}
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("health probe failed")
+		status.Error = err.Error()
+	}
+
+	return status
+}
+
+// overallStatus returns not healthy if any critical probe failed, degraded if any optional probe failed, healthy otherwise
+func overallStatus(allStatus []*models.HealthStatus) string {
+	status := StatusHealthy
+	for _, item := range allStatus {
+		if item.Healthy {
+			continue
+		}
+		if item.Critical {
+			return StatusNotHealthy
+		}
+		status = StatusDegraded
+	}
+	return status
+}
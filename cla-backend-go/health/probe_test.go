@@ -0,0 +1,142 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func passingProbe(name string, critical bool) Probe {
+	return Probe{
+		Name:     name,
+		Critical: critical,
+		Check: func(ctx context.Context) error {
+			return nil
+		},
+	}
+}
+
+func failingProbe(name string, critical bool) Probe {
+	return Probe{
+		Name:     name,
+		Critical: critical,
+		Check: func(ctx context.Context) error {
+			return errors.New("probe failed")
+		},
+	}
+}
+
+func TestReadinessCheckStatus(t *testing.T) {
+	testCases := []struct {
+		name           string
+		probes         []Probe
+		expectedStatus string
+	}{
+		{
+			name:           "no probes",
+			probes:         nil,
+			expectedStatus: StatusHealthy,
+		},
+		{
+			name:           "all probes pass",
+			probes:         []Probe{passingProbe("dynamo", true), passingProbe("sns", false)},
+			expectedStatus: StatusHealthy,
+		},
+		{
+			name:           "optional probe fails",
+			probes:         []Probe{passingProbe("dynamo", true), failingProbe("sns", false)},
+			expectedStatus: StatusDegraded,
+		},
+		{
+			name:           "critical probe fails",
+			probes:         []Probe{failingProbe("dynamo", true), failingProbe("sns", false)},
+			expectedStatus: StatusNotHealthy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := New("v1", "abc", "main", "now", tc.probes...)
+			result, err := service.ReadinessCheck(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatus, result.Status)
+			assert.Equal(t, len(tc.probes)+1, len(result.Healths))
+		})
+	}
+}
+
+func TestLivenessCheckSkipsProbes(t *testing.T) {
+	service := New("v1", "abc", "main", "now", failingProbe("dynamo", true))
+	result, err := service.LivenessCheck(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, StatusHealthy, result.Status)
+	assert.Equal(t, 1, len(result.Healths))
+}
+
+func TestHealthCheckSkipsProbes(t *testing.T) {
+	called := false
+	probe := Probe{
+		Name:     "dynamo",
+		Critical: true,
+		Check: func(ctx context.Context) error {
+			called = true
+			return errors.New("probe failed")
+		},
+	}
+
+	service := New("v1", "abc", "main", "now", probe)
+	result, err := service.HealthCheck(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, StatusHealthy, result.Status)
+	assert.False(t, called)
+}
+
+func TestGitLabOAuthProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/down/") {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		// the token info endpoint requires a token - reachable
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	baseURLs := map[string]string{"gitlab.example.org": server.URL + "/"}
+	probe := NewGitLabOAuthProbe(func(ctx context.Context) (map[string]string, error) {
+		return baseURLs, nil
+	}, false)
+	assert.Nil(t, probe.Check(context.Background()))
+
+	baseURLs["gitlab.down.example.org"] = server.URL + "/down"
+	err := probe.Check(context.Background())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "gitlab.down.example.org")
+	assert.NotContains(t, err.Error(), "gitlab.example.org:")
+}
+
+func TestProbeTimeout(t *testing.T) {
+	slow := Probe{
+		Name:     "slow",
+		Critical: false,
+		Timeout:  10 * time.Millisecond,
+		Check: func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		},
+	}
+
+	status := runProbe(context.Background(), slow)
+	assert.False(t, status.Healthy)
+	assert.Contains(t, status.Error, "timed out")
+	assert.Less(t, status.LatencyMillis, int64(1000))
+}
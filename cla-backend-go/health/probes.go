@@ -0,0 +1,216 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/bradleyfalzon/ghinstallation/v2"
+)
+
+// githubAppURL is the GitHub API endpoint which returns the authenticated GitHub App
+const githubAppURL = "https://api.github.com/app"
+
+// docraptorDocLogsURL is a lightweight authenticated DocRaptor endpoint
+const docraptorDocLogsURL = "https://docraptor.com/doc_logs.json?per_page=1"
+
+// DynamoTableProbes returns the probes for the DynamoDB tables used by the service
+func DynamoTableProbes(awsSession *session.Session, stage string) []Probe {
+	tableNames := []string{
+		"ccla-whitelist-requests",
+		"cla-manager-requests",
+		"companies",
+		"company-invites",
+		"events",
+		"gerrit-instances",
+		"github-orgs",
+		"metrics",
+		"projects",
+		"projects-cla-groups",
+		"repositories",
+		"session-store",
+		"signatures",
+		"store",
+		"user-permissions",
+		"users",
+	}
+
+	probes := make([]Probe, 0, len(tableNames))
+	for _, tableName := range tableNames {
+		probes = append(probes, NewDynamoTableProbe(awsSession, "cla-"+stage+"-"+tableName))
+	}
+	return probes
+}
+
+// NewDynamoTableProbe returns a critical probe which describes the DynamoDB table
+func NewDynamoTableProbe(awsSession *session.Session, tableName string) Probe {
+	client := dynamodb.New(awsSession)
+	return Probe{
+		Name:     "EasyCLA - Dynamodb - " + tableName,
+		Critical: true,
+		Check: func(ctx context.Context) error {
+			_, err := client.DescribeTableWithContext(ctx, &dynamodb.DescribeTableInput{
+				TableName: aws.String(tableName),
+			})
+			return err
+		},
+	}
+}
+
+// NewS3BucketProbe returns a probe which verifies the S3 bucket exists and is accessible
+func NewS3BucketProbe(awsSession *session.Session, bucket string, critical bool) Probe {
+	client := s3.New(awsSession)
+	return Probe{
+		Name:     "EasyCLA - S3 - " + bucket,
+		Critical: critical,
+		Check: func(ctx context.Context) error {
+			_, err := client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+				Bucket: aws.String(bucket),
+			})
+			return err
+		},
+	}
+}
+
+// NewSNSTopicProbe returns a probe which verifies the SNS topic used to send emails is accessible
+func NewSNSTopicProbe(awsSession *session.Session, topicARN string, critical bool) Probe {
+	client := sns.New(awsSession)
+	return Probe{
+		Name:     "EasyCLA - SNS - email topic",
+		Critical: critical,
+		Check: func(ctx context.Context) error {
+			_, err := client.GetTopicAttributesWithContext(ctx, &sns.GetTopicAttributesInput{
+				TopicArn: aws.String(topicARN),
+			})
+			return err
+		},
+	}
+}
+
+// NewHTTPProbe returns a probe which issues a GET request to the URL. When expectedStatus is zero any response
+// below 500 is considered healthy, which verifies reachability without requiring credentials. The optional
+// decorate function can add authentication headers to the request.
+func NewHTTPProbe(name, url string, expectedStatus int, critical bool, decorate func(req *http.Request)) Probe {
+	return Probe{
+		Name:     name,
+		Critical: critical,
+		Check: func(ctx context.Context) error {
+			return checkURL(ctx, url, expectedStatus, decorate)
+		},
+	}
+}
+
+// NewDocraptorProbe returns a probe which verifies the DocRaptor API key is accepted
+func NewDocraptorProbe(apiKey string, critical bool) Probe {
+	return NewHTTPProbe("EasyCLA - DocRaptor", docraptorDocLogsURL, http.StatusOK, critical, func(req *http.Request) {
+		req.SetBasicAuth(apiKey, "")
+	})
+}
+
+// NewDocuSignProbe returns a probe which verifies the DocuSign authorization server is reachable
+func NewDocuSignProbe(authServerURL string, critical bool) Probe {
+	return NewHTTPProbe("EasyCLA - DocuSign", authServerURL+"/oauth/userinfo", 0, critical, nil)
+}
+
+// NewGitHubAppProbe returns a probe which authenticates as the GitHub App using the app private key
+func NewGitHubAppProbe(appID int64, privateKey string, critical bool) Probe {
+	return Probe{
+		Name:     "EasyCLA - GitHub App",
+		Critical: critical,
+		Check: func(ctx context.Context) error {
+			transport, err := ghinstallation.NewAppsTransport(http.DefaultTransport, appID, []byte(privateKey))
+			if err != nil {
+				return err
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubAppURL, nil)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Accept", "application/vnd.github+json")
+			return checkHTTPResponse(&http.Client{Transport: transport}, req, http.StatusOK)
+		},
+	}
+}
+
+// NewGitLabOAuthProbe returns a probe which verifies the GitLab OAuth endpoint of each GitLab instance is reachable.
+// The base URLs, keyed by the instance ID, are loaded on each run so that the registered instances are included.
+func NewGitLabOAuthProbe(baseURLs func(ctx context.Context) (map[string]string, error), critical bool) Probe {
+	return Probe{
+		Name:     "EasyCLA - GitLab OAuth",
+		Critical: critical,
+		Check: func(ctx context.Context) error {
+			instances, err := baseURLs(ctx)
+			if err != nil {
+				return err
+			}
+
+			instanceIDs := make([]string, 0, len(instances))
+			for instanceID := range instances {
+				instanceIDs = append(instanceIDs, instanceID)
+			}
+			sort.Strings(instanceIDs)
+
+			var failed []string
+			for _, instanceID := range instanceIDs {
+				baseURL := strings.TrimSuffix(instances[instanceID], "/")
+				if checkErr := checkURL(ctx, baseURL+"/oauth/token/info", 0, nil); checkErr != nil {
+					failed = append(failed, fmt.Sprintf("%s: %v", instanceID, checkErr))
+				}
+			}
+			if len(failed) > 0 {
+				return errors.New(strings.Join(failed, "; "))
+			}
+			return nil
+		},
+	}
+}
+
+// NewPlatformServiceProbe returns a probe which verifies the platform service is reachable through the API gateway
+func NewPlatformServiceProbe(apiGatewayURL, serviceBasePath string, critical bool) Probe {
+	return NewHTTPProbe("EasyCLA - Platform - "+serviceBasePath, fmt.Sprintf("%s/%s", apiGatewayURL, serviceBasePath), 0, critical, nil)
+}
+
+// checkURL issues a GET request to the URL and validates the response status code
+func checkURL(ctx context.Context, url string, expectedStatus int, decorate func(req *http.Request)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if decorate != nil {
+		decorate(req)
+	}
+	return checkHTTPResponse(http.DefaultClient, req, expectedStatus)
+}
+
+// checkHTTPResponse runs the request and validates the response status code
+func checkHTTPResponse(client *http.Client, req *http.Request, expectedStatus int) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint
+
+	if expectedStatus == 0 {
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+		return nil
+	}
+
+	if resp.StatusCode != expectedStatus {
+		return fmt.Errorf("unexpected status code: %d, expected: %d", resp.StatusCode, expectedStatus)
+	}
+
+	return nil
+}
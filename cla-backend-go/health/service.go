@@ -5,14 +5,9 @@ package health
 
 import (
 	"context"
-	"sync"
 	"time"
 
-	"github.com/aws/aws-sdk-go/service/dynamodb"
-	log "github.com/communitybridge/easycla/cla-backend-go/logging"
-
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
-	ini "github.com/communitybridge/easycla/cla-backend-go/init"
 )
 
 // Service provides an API to the health API
@@ -21,48 +16,61 @@ type Service struct {
 	commit    string
 	branch    string
 	buildDate string
+	probes    []Probe
 }
 
 // HealthService interface
 type HealthService interface { // nolint
 	HealthCheck(ctx context.Context) (*models.Health, error)
+	LivenessCheck(ctx context.Context) (*models.Health, error)
+	ReadinessCheck(ctx context.Context) (*models.Health, error)
 }
 
-// New is a simple helper function to create a health service instance
-func New(version, commit, branch, buildDate string) Service {
+// New is a simple helper function to create a health service instance, the probes are run by the readiness check
+func New(version, commit, branch, buildDate string, probes ...Probe) Service {
 	return Service{
 		version:   version,
 		commit:    commit,
 		branch:    branch,
 		buildDate: buildDate,
+		probes:    probes,
 	}
 }
 
-// HealthCheck API call returns the current health of the service
+// HealthCheck API call returns the current health of the service process - the dependency probes make external
+// calls, they are only run by the readiness check
 func (s Service) HealthCheck(ctx context.Context) (*models.Health, error) {
+	return s.LivenessCheck(ctx)
+}
+
+// LivenessCheck API call returns the health of the service process only - no dependencies are checked
+func (s Service) LivenessCheck(ctx context.Context) (*models.Health, error) {
+	return s.response(StatusHealthy, []*models.HealthStatus{s.serviceStatus()}), nil
+}
+
+// ReadinessCheck API call runs all the probes and returns healthy, degraded if an optional dependency failed, or
+// not healthy if a critical dependency failed
+func (s Service) ReadinessCheck(ctx context.Context) (*models.Health, error) {
+	allStatus := []*models.HealthStatus{s.serviceStatus()}
+	allStatus = append(allStatus, runProbes(ctx, s.probes)...)
+
+	return s.response(overallStatus(allStatus), allStatus), nil
+}
 
-	// General health
-	hs := models.HealthStatus{
+// serviceStatus returns the status of the service itself
+func (s Service) serviceStatus() *models.HealthStatus {
+	return &models.HealthStatus{
 		TimeStamp: time.Now().UTC().Format(time.RFC3339),
 		Healthy:   true,
+		Critical:  true,
 		Name:      "CLA",
 		Duration:  time.Since(time.Now()).String(),
 	}
+}
 
-	var allStatus []*models.HealthStatus
-	allStatus = append(allStatus, &hs)
-	allStatus = append(allStatus, getDynamoTableStatus()...)
-
-	var status = "healthy"
-	for _, item := range allStatus {
-		// If any of our dynamodb tables are not healthy, then overall we are not healthy
-		if !item.Healthy {
-			status = "not healthy"
-			break
-		}
-	}
-
-	response := models.Health{
+// response builds the health response
+func (s Service) response(status string, allStatus []*models.HealthStatus) *models.Health {
+	return &models.Health{
 		Status:         status,
 		TimeStamp:      time.Now().UTC().Format(time.RFC3339),
 		Version:        s.version,
@@ -71,73 +79,4 @@ func (s Service) HealthCheck(ctx context.Context) (*models.Health, error) {
 		BuildTimeStamp: s.buildDate,
 		Healths:        allStatus,
 	}
-
-	return &response, nil
-}
-
-// getDynamoTableStatus queries the dynamodb tables and reports if it is healthy
-func getDynamoTableStatus() []*models.HealthStatus {
-	var allStatus []*models.HealthStatus
-
-	tableNames := []string{
-		"cla-" + ini.GetStage() + "-ccla-whitelist-requests",
-		"cla-" + ini.GetStage() + "-cla-manager-requests",
-		"cla-" + ini.GetStage() + "-companies",
-		"cla-" + ini.GetStage() + "-company-invites",
-		"cla-" + ini.GetStage() + "-events",
-		"cla-" + ini.GetStage() + "-gerrit-instances",
-		"cla-" + ini.GetStage() + "-github-orgs",
-		"cla-" + ini.GetStage() + "-metrics",
-		"cla-" + ini.GetStage() + "-projects",
-		"cla-" + ini.GetStage() + "-projects-cla-groups",
-		"cla-" + ini.GetStage() + "-repositories",
-		"cla-" + ini.GetStage() + "-session-store",
-		"cla-" + ini.GetStage() + "-signatures",
-		"cla-" + ini.GetStage() + "-store",
-		"cla-" + ini.GetStage() + "-user-permissions",
-		"cla-" + ini.GetStage() + "-users",
-	}
-
-	var wg sync.WaitGroup
-	wg.Add(len(tableNames))
-
-	for _, tableName := range tableNames {
-		go func(tableName string) {
-			defer wg.Done()
-
-			// Do a quick check to see if we have a database connection
-			dynamoNow := time.Now()
-			dynamoAlive := isDynamoAlive(tableName)
-			dynamoDuration := time.Since(dynamoNow)
-			dy := models.HealthStatus{TimeStamp: time.Now().UTC().Format(time.RFC3339),
-				Healthy:  dynamoAlive,
-				Name:     "EasyCLA - Dynamodb - " + tableName,
-				Duration: dynamoDuration.String()}
-
-			allStatus = append(allStatus, &dy)
-		}(tableName)
-	}
-
-	wg.Wait()
-
-	return allStatus
-}
-
-// isDynamoAlive runs a check to see if we have connectivity to the database for the given table - returns true if successful, false otherwise
-func isDynamoAlive(tableName string) bool {
-	// Grab the AWS session
-	awsSession, err := ini.GetAWSSession()
-	if err != nil {
-		log.Warnf("Unable to acquire AWS session - returning failed health, error: %v", err)
-		return false
-	}
-
-	// Create a client and make a query - don't worry about the result - just check the error response
-	dynamoDBClient := dynamodb.New(awsSession)
-	_, err = dynamoDBClient.DescribeTable(&dynamodb.DescribeTableInput{
-		TableName: &tableName,
-	})
-
-	// No error is success
-	return err == nil
 }
@@ -0,0 +1,45 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package keyring
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+)
+
+// AWSKMS wraps the data keys with the AWS KMS keys, the key IDs are the key ARNs or aliases
+type AWSKMS struct {
+	client kmsiface.KMSAPI
+}
+
+// NewAWSKMS creates the AWS KMS client of the session
+func NewAWSKMS(awsSession *session.Session) *AWSKMS {
+	return &AWSKMS{client: kms.New(awsSession)}
+}
+
+// Encrypt wraps the plaintext with the KMS key
+func (k *AWSKMS) Encrypt(keyID string, plaintext []byte) ([]byte, error) {
+	output, err := k.client.Encrypt(&kms.EncryptInput{
+		KeyId:     aws.String(keyID),
+		Plaintext: plaintext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.CiphertextBlob, nil
+}
+
+// Decrypt unwraps the ciphertext with the KMS key
+func (k *AWSKMS) Decrypt(keyID string, ciphertext []byte) ([]byte, error) {
+	output, err := k.client.Decrypt(&kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: ciphertext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Plaintext, nil
+}
@@ -0,0 +1,49 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package keyring
+
+import (
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+const (
+	// KeyIDsEnv is the environment variable with the comma separated IDs of the active keys, the primary key first
+	KeyIDsEnv = "CLA_KEYRING_KEY_IDS"
+	// FileKMSEnv is the environment variable with the path of the key file of the local file KMS, the AWS KMS is used
+	// when not set
+	FileKMSEnv = "CLA_KEYRING_FILE_KMS"
+)
+
+// FromEnvironment returns the keyring configured by the environment, nil when no key is configured - the secrets are
+// then encrypted with the legacy static keys. The missing keys of the local file KMS are generated.
+func FromEnvironment(awsSession *session.Session) (*Keyring, error) {
+	keyIDs := strings.Split(os.Getenv(KeyIDsEnv), ",")
+	for i := range keyIDs {
+		keyIDs[i] = strings.TrimSpace(keyIDs[i])
+	}
+	if strings.Join(keyIDs, "") == "" {
+		return nil, nil
+	}
+
+	path := os.Getenv(FileKMSEnv)
+	if path == "" {
+		return New(NewAWSKMS(awsSession), keyIDs...)
+	}
+
+	fileKMS, err := NewFileKMS(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, keyID := range keyIDs {
+		if keyID != "" && !fileKMS.HasKey(keyID) {
+			if err := fileKMS.AddKey(keyID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return New(fileKMS, keyIDs...)
+}
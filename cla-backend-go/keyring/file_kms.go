@@ -0,0 +1,121 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package keyring
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileKMS is a local stand-in of the KMS for development - the key encryption keys are stored in plain text in a
+// JSON file, it must never be used by the deployed environments
+type FileKMS struct {
+	path string
+
+	mu   sync.RWMutex
+	keys map[string][]byte
+}
+
+// fileKMSContent is the content of the key file, the keys are base64 encoded
+type fileKMSContent struct {
+	Keys map[string]string `json:"keys"`
+}
+
+// NewFileKMS loads the keys of the key file, a missing file has no keys
+func NewFileKMS(path string) (*FileKMS, error) {
+	kms := &FileKMS{
+		path: filepath.Clean(path),
+		keys: make(map[string][]byte),
+	}
+
+	content, err := os.ReadFile(kms.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return kms, nil
+		}
+		return nil, fmt.Errorf("read key file %s : %v", kms.path, err)
+	}
+
+	var keyFile fileKMSContent
+	if err := json.Unmarshal(content, &keyFile); err != nil {
+		return nil, fmt.Errorf("decode key file %s : %v", kms.path, err)
+	}
+	for keyID, encoded := range keyFile.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != dataKeySize {
+			return nil, fmt.Errorf("key file %s : invalid key %s", kms.path, keyID)
+		}
+		kms.keys[keyID] = key
+	}
+
+	return kms, nil
+}
+
+// HasKey returns true if the key file has the key
+func (k *FileKMS) HasKey(keyID string) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	_, ok := k.keys[keyID]
+	return ok
+}
+
+// AddKey generates a new key and saves the key file
+func (k *FileKMS) AddKey(keyID string) error {
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, ok := k.keys[keyID]; ok {
+		return fmt.Errorf("key %s already exists", keyID)
+	}
+	k.keys[keyID] = key
+
+	keyFile := fileKMSContent{Keys: make(map[string]string, len(k.keys))}
+	for id, value := range k.keys {
+		keyFile.Keys[id] = base64.StdEncoding.EncodeToString(value)
+	}
+	content, err := json.MarshalIndent(keyFile, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(k.path, content, 0600)
+}
+
+// Encrypt wraps the plaintext with the key
+func (k *FileKMS) Encrypt(keyID string, plaintext []byte) ([]byte, error) {
+	key, err := k.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return seal(key, plaintext, []byte(keyID))
+}
+
+// Decrypt unwraps the ciphertext with the key
+func (k *FileKMS) Decrypt(keyID string, ciphertext []byte) ([]byte, error) {
+	key, err := k.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return open(key, ciphertext, []byte(keyID))
+}
+
+func (k *FileKMS) key(keyID string) ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in key file %s", keyID, k.path)
+	}
+	return key, nil
+}
@@ -0,0 +1,243 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// envelopePrefix starts the envelopes, followed by the key ID, the wrapped data key and the sealed secret - all
+// base64 encoded and separated by dots. The legacy hex encoded ciphertexts never contain a dot.
+const envelopePrefix = "ek1."
+
+// dataKeySize is the size of the AES-256 data keys generated for each envelope
+const dataKeySize = 32
+
+// maxCachedDataKeys bounds the cache of the unwrapped data keys, the cache is cleared when full
+const maxCachedDataKeys = 1024
+
+var (
+	// ErrNoKeys is returned when the keyring is created without a key
+	ErrNoKeys = errors.New("keyring has no keys")
+	// ErrUnknownKey is returned when the envelope was encrypted with a key which isn't active in the keyring
+	ErrUnknownKey = errors.New("envelope key is not active in the keyring")
+	// ErrInvalidEnvelope is returned when the value isn't a valid envelope
+	ErrInvalidEnvelope = errors.New("invalid envelope")
+)
+
+// KMS wraps and unwraps the data keys of the envelopes with its key encryption keys, identified by key IDs
+type KMS interface {
+	Encrypt(keyID string, plaintext []byte) ([]byte, error)
+	Decrypt(keyID string, ciphertext []byte) ([]byte, error)
+}
+
+// Keyring encrypts the secrets with envelope encryption - each secret is sealed with its own data key, wrapped by the
+// primary key of the KMS. The secrets are decrypted with any of the active keys, so a new primary key can be rolled
+// out before the secrets encrypted with the previous one are re-encrypted.
+type Keyring struct {
+	kms    KMS
+	keyIDs []string
+
+	mu       sync.Mutex
+	dataKeys map[string][]byte
+}
+
+// New creates a keyring with the given active keys of the KMS, the first key is the primary key
+func New(kms KMS, keyIDs ...string) (*Keyring, error) {
+	var active []string
+	for _, keyID := range keyIDs {
+		keyID = strings.TrimSpace(keyID)
+		if keyID != "" {
+			active = append(active, keyID)
+		}
+	}
+	if len(active) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	return &Keyring{
+		kms:      kms,
+		keyIDs:   active,
+		dataKeys: make(map[string][]byte),
+	}, nil
+}
+
+// PrimaryKeyID returns the ID of the key encrypting the new secrets
+func (k *Keyring) PrimaryKeyID() string {
+	return k.keyIDs[0]
+}
+
+// KeyIDs returns the IDs of the active keys, the primary key first
+func (k *Keyring) KeyIDs() []string {
+	return append([]string(nil), k.keyIDs...)
+}
+
+// IsActive returns true if the secrets encrypted with the key can be decrypted
+func (k *Keyring) IsActive(keyID string) bool {
+	for _, active := range k.keyIDs {
+		if active == keyID {
+			return true
+		}
+	}
+	return false
+}
+
+// Encrypt seals the plaintext with a new data key wrapped by the primary key
+func (k *Keyring) Encrypt(plaintext []byte) (string, error) {
+	keyID := k.PrimaryKeyID()
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", fmt.Errorf("generate data key : %v", err)
+	}
+
+	wrappedKey, err := k.kms.Encrypt(keyID, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("wrap data key with key %s : %v", keyID, err)
+	}
+
+	sealed, err := seal(dataKey, plaintext, []byte(keyID))
+	if err != nil {
+		return "", err
+	}
+
+	return envelopePrefix + strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString([]byte(keyID)),
+		base64.RawURLEncoding.EncodeToString(wrappedKey),
+		base64.RawURLEncoding.EncodeToString(sealed),
+	}, "."), nil
+}
+
+// Decrypt opens the envelope with the data key unwrapped by the key of the envelope, which must be active
+func (k *Keyring) Decrypt(value string) ([]byte, error) {
+	keyID, wrappedKey, sealed, err := parseEnvelope(value)
+	if err != nil {
+		return nil, err
+	}
+	if !k.IsActive(keyID) {
+		return nil, fmt.Errorf("%w : %s", ErrUnknownKey, keyID)
+	}
+
+	dataKey, err := k.unwrap(keyID, wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(dataKey, sealed, []byte(keyID))
+}
+
+// NeedsReEncryption returns true if the value isn't an envelope of the primary key - the legacy ciphertexts and the
+// envelopes of the previous keys
+func (k *Keyring) NeedsReEncryption(value string) bool {
+	keyID, err := EnvelopeKeyID(value)
+	return err != nil || keyID != k.PrimaryKeyID()
+}
+
+// unwrap returns the data key, the unwrapped keys are cached as each KMS call adds latency
+func (k *Keyring) unwrap(keyID string, wrappedKey []byte) ([]byte, error) {
+	cacheKey := keyID + "." + string(wrappedKey)
+
+	k.mu.Lock()
+	dataKey, ok := k.dataKeys[cacheKey]
+	k.mu.Unlock()
+	if ok {
+		return dataKey, nil
+	}
+
+	dataKey, err := k.kms.Decrypt(keyID, wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key with key %s : %v", keyID, err)
+	}
+
+	k.mu.Lock()
+	if len(k.dataKeys) >= maxCachedDataKeys {
+		k.dataKeys = make(map[string][]byte)
+	}
+	k.dataKeys[cacheKey] = dataKey
+	k.mu.Unlock()
+
+	return dataKey, nil
+}
+
+// IsEnvelope returns true if the value was encrypted by a keyring
+func IsEnvelope(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix)
+}
+
+// EnvelopeKeyID returns the ID of the key of the envelope
+func EnvelopeKeyID(value string) (string, error) {
+	keyID, _, _, err := parseEnvelope(value)
+	return keyID, err
+}
+
+func parseEnvelope(value string) (string, []byte, []byte, error) {
+	if !IsEnvelope(value) {
+		return "", nil, nil, ErrInvalidEnvelope
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, envelopePrefix), ".")
+	if len(parts) != 3 {
+		return "", nil, nil, ErrInvalidEnvelope
+	}
+
+	decoded := make([][]byte, 0, len(parts))
+	for _, part := range parts {
+		b, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil || len(b) == 0 {
+			return "", nil, nil, ErrInvalidEnvelope
+		}
+		decoded = append(decoded, b)
+	}
+
+	return string(decoded[0]), decoded[1], decoded[2], nil
+}
+
+// seal encrypts the plaintext with AES-GCM, the nonce is stored at the beginning of the ciphertext
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts the ciphertext sealed by seal
+func open(key, ciphertext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, ErrInvalidEnvelope
+	}
+
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt failed : %v", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
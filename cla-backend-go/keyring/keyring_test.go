@@ -0,0 +1,181 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package keyring
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFileKMS(t *testing.T, keyIDs ...string) *FileKMS {
+	kms, err := NewFileKMS(filepath.Join(t.TempDir(), "keys.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, keyID := range keyIDs {
+		if err := kms.AddKey(keyID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return kms
+}
+
+func TestKeyringRoundTrip(t *testing.T) {
+	keys, err := New(newFileKMS(t, "key-1"), "key-1")
+	assert.Nil(t, err)
+
+	envelope, err := keys.Encrypt([]byte("oauth token"))
+	assert.Nil(t, err)
+	assert.True(t, IsEnvelope(envelope))
+	assert.False(t, strings.Contains(envelope, "oauth token"))
+
+	keyID, err := EnvelopeKeyID(envelope)
+	assert.Nil(t, err)
+	assert.Equal(t, "key-1", keyID)
+
+	plaintext, err := keys.Decrypt(envelope)
+	assert.Nil(t, err)
+	assert.Equal(t, "oauth token", string(plaintext))
+	assert.False(t, keys.NeedsReEncryption(envelope))
+}
+
+func TestKeyringRotation(t *testing.T) {
+	kms := newFileKMS(t, "key-1", "key-2")
+	previous, err := New(kms, "key-1")
+	assert.Nil(t, err)
+	envelope, err := previous.Encrypt([]byte("secret"))
+	assert.Nil(t, err)
+
+	// key-2 becomes the primary key, the envelopes of key-1 are still decrypted until they are re-encrypted
+	current, err := New(kms, "key-2", "key-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "key-2", current.PrimaryKeyID())
+	assert.True(t, current.NeedsReEncryption(envelope))
+	plaintext, err := current.Decrypt(envelope)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+
+	reEncrypted, err := current.Encrypt(plaintext)
+	assert.Nil(t, err)
+	assert.False(t, current.NeedsReEncryption(reEncrypted))
+
+	// key-1 is retired
+	retired, err := New(kms, "key-2")
+	assert.Nil(t, err)
+	_, err = retired.Decrypt(envelope)
+	assert.True(t, errors.Is(err, ErrUnknownKey))
+	plaintext, err = retired.Decrypt(reEncrypted)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+}
+
+func TestKeyringNeedsReEncryptionLegacy(t *testing.T) {
+	keys, err := New(newFileKMS(t, "key-1"), "key-1")
+	assert.Nil(t, err)
+	assert.True(t, keys.NeedsReEncryption("a1b2c3d4"))
+	assert.False(t, IsEnvelope("a1b2c3d4"))
+}
+
+func TestKeyringNoKeys(t *testing.T) {
+	_, err := New(newFileKMS(t), " ", "")
+	assert.Equal(t, ErrNoKeys, err)
+}
+
+func TestKeyringTampered(t *testing.T) {
+	keys, err := New(newFileKMS(t, "key-1"), "key-1")
+	assert.Nil(t, err)
+	envelope, err := keys.Encrypt([]byte("secret"))
+	assert.Nil(t, err)
+
+	parts := strings.Split(envelope, ".")
+	sealed := []byte(parts[3])
+	if sealed[5] == 'A' {
+		sealed[5] = 'B'
+	} else {
+		sealed[5] = 'A'
+	}
+	parts[3] = string(sealed)
+	_, err = keys.Decrypt(strings.Join(parts, "."))
+	assert.NotNil(t, err)
+
+	_, err = keys.Decrypt("ek1.not-an-envelope")
+	assert.Equal(t, ErrInvalidEnvelope, err)
+}
+
+func TestFileKMSPersistsKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	kms, err := NewFileKMS(path)
+	assert.Nil(t, err)
+	assert.Nil(t, kms.AddKey("key-1"))
+	assert.NotNil(t, kms.AddKey("key-1"))
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	keys, err := New(kms, "key-1")
+	assert.Nil(t, err)
+	envelope, err := keys.Encrypt([]byte("secret"))
+	assert.Nil(t, err)
+
+	reloaded, err := NewFileKMS(path)
+	assert.Nil(t, err)
+	assert.True(t, reloaded.HasKey("key-1"))
+	keys, err = New(reloaded, "key-1")
+	assert.Nil(t, err)
+	plaintext, err := keys.Decrypt(envelope)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+}
+
+func TestFromEnvironment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	t.Setenv(FileKMSEnv, path)
+
+	t.Setenv(KeyIDsEnv, "")
+	keys, err := FromEnvironment(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, keys)
+
+	t.Setenv(KeyIDsEnv, "key-2, key-1")
+	keys, err = FromEnvironment(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key-2", "key-1"}, keys.KeyIDs())
+
+	kms, err := NewFileKMS(path)
+	assert.Nil(t, err)
+	assert.True(t, kms.HasKey("key-1"))
+	assert.True(t, kms.HasKey("key-2"))
+}
+
+func TestLegacySecretReEncryption(t *testing.T) {
+	const legacyKey = "MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI="
+
+	legacy, err := EncryptSecret(nil, legacyKey, []byte("access-token"))
+	assert.Nil(t, err)
+	assert.False(t, IsEnvelope(legacy))
+	decrypted, err := DecryptSecret(nil, legacyKey, legacy)
+	assert.Nil(t, err)
+	assert.Equal(t, "access-token", string(decrypted))
+
+	keys, err := New(newFileKMS(t, "key-1"), "key-1")
+	assert.Nil(t, err)
+	assert.True(t, keys.NeedsReEncryption(legacy))
+	reEncrypted, err := ReEncrypt(keys, legacyKey, legacy)
+	assert.Nil(t, err)
+	assert.False(t, keys.NeedsReEncryption(reEncrypted))
+	decrypted, err = DecryptSecret(keys, legacyKey, reEncrypted)
+	assert.Nil(t, err)
+	assert.Equal(t, "access-token", string(decrypted))
+
+	_, err = DecryptSecret(nil, legacyKey, reEncrypted)
+	assert.NotNil(t, err)
+	_, err = ReEncrypt(nil, legacyKey, legacy)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,126 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// EncryptSecret seals the value in an envelope of the keyring, or encrypts it with the legacy static key - a base64
+// encoded AES key - as hex when no keyring is configured
+func EncryptSecret(keys *Keyring, legacyKey string, value []byte) (string, error) {
+	if keys != nil {
+		return keys.Encrypt(value)
+	}
+
+	keyDecoded, err := base64.StdEncoding.DecodeString(legacyKey)
+	if err != nil {
+		return "", fmt.Errorf("problem decoding the static key, error: %v", err)
+	}
+
+	encrypted, err := encryptLegacy(keyDecoded, value)
+	if err != nil {
+		return "", fmt.Errorf("encrypt failed : %v", err)
+	}
+
+	return hex.EncodeToString(encrypted), nil
+}
+
+// DecryptSecret opens the envelopes with the keyring, the hex values encrypted before the keyring are decrypted with
+// the legacy static key
+func DecryptSecret(keys *Keyring, legacyKey string, encrypted string) ([]byte, error) {
+	if IsEnvelope(encrypted) {
+		if keys == nil {
+			return nil, errors.New("value is encrypted with a keyring, no keyring configured")
+		}
+		return keys.Decrypt(encrypted)
+	}
+
+	ciphertext, err := hex.DecodeString(encrypted)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aes.BlockSize {
+		return nil, errors.New("value is too short")
+	}
+
+	keyDecoded, err := base64.StdEncoding.DecodeString(legacyKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode static key : %v", err)
+	}
+
+	decrypted, err := decryptLegacy(keyDecoded, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt failed : %v", err)
+	}
+
+	return decrypted, nil
+}
+
+// ReEncrypt decrypts the value encrypted with the legacy static key or a previous key of the keyring and encrypts it
+// with the primary key of the keyring
+func ReEncrypt(keys *Keyring, legacyKey string, encrypted string) (string, error) {
+	if keys == nil {
+		return "", errors.New("re-encrypt : no keyring configured")
+	}
+
+	decrypted, err := DecryptSecret(keys, legacyKey, encrypted)
+	if err != nil {
+		return "", err
+	}
+
+	return keys.Encrypt(decrypted)
+}
+
+// NewSecret returns a random hex encoded secret, used for the webhook secrets
+func NewSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// encryptLegacy is the AES encryption with the cipher feedback mode of the values encrypted before the keyring, the IV
+// nonce is stored at the beginning of the cipher text
+func encryptLegacy(key, message []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText := make([]byte, aes.BlockSize+len(message))
+	iv := cipherText[:aes.BlockSize]
+	if _, err = io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+
+	cfb := cipher.NewCFBEncrypter(block, iv)
+	cfb.XORKeyStream(cipherText[aes.BlockSize:], message)
+
+	return cipherText, nil
+}
+
+// decryptLegacy decrypts the values encrypted by encryptLegacy
+func decryptLegacy(key, cipherText []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := cipherText[:aes.BlockSize]
+	plainText := make([]byte, len(cipherText)-aes.BlockSize)
+
+	cfb := cipher.NewCFBDecrypter(block, iv)
+	cfb.XORKeyStream(plainText, cipherText[aes.BlockSize:])
+
+	return plainText, nil
+}
@@ -33,6 +33,18 @@ const RepositoryNoteColumn = "note"
 // RepositoryDateModifiedColumn constant
 const RepositoryDateModifiedColumn = "date_modified"
 
+// RepositoryFullPathColumn constant
+const RepositoryFullPathColumn = "repository_full_path"
+
+// RepositoryURLColumn constant
+const RepositoryURLColumn = "repository_url"
+
+// RepositorySfdcIDColumn constant
+const RepositorySfdcIDColumn = "repository_sfdc_id"
+
+// RepositoryArchivedColumn constant
+const RepositoryArchivedColumn = "is_archived"
+
 // RepositoryEnabled constant
 const RepositoryEnabled = "enabled"
 
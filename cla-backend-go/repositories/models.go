@@ -29,6 +29,7 @@ type RepositoryDBModel struct {
 	Version                    string `dynamodbav:"version" json:"version,omitempty"`
 	IsRemoteDeleted            bool   `dynamodbav:"is_remote_deleted" json:"is_transfered,omitempty"`
 	WasCLAEnforced             bool   `dynamodbav:"was_cla_enforced" json:"was_cla_enforced,omitempty"`
+	IsArchived                 bool   `dynamodbav:"is_archived" json:"is_archived,omitempty"`
 }
 
 func convertModels(dbModels []*RepositoryDBModel) []*models.GithubRepository {
@@ -163,7 +163,7 @@ func (s *service) GetRepository(ctx context.Context, repositoryID string) (*mode
 	}
 	log.WithFields(f).Debug("Searching for repository...")
 	ghRepo, err := s.repo.GitHubGetRepository(ctx, repositoryID)
-	if err != nil || ghRepo != nil {
+	if err != nil {
 		log.WithFields(f).WithError(err).Debug("unable to get repository")
 		return nil, err
 	}
@@ -81,6 +81,7 @@ type SignatureRepository interface {
 	GetProjectCompanySignatures(ctx context.Context, companyID, projectID string, approved, signed *bool, nextKey *string, sortOrder *string, pageSize *int64) (*models.Signatures, error)
 	GetProjectCompanyEmployeeSignatures(ctx context.Context, params signatures.GetProjectCompanyEmployeeSignaturesParams, criteria *ApprovalCriteria) (*models.Signatures, error)
 	GetProjectCompanyEmployeeSignature(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, employeeUserModel *models.User, wg *sync.WaitGroup, resultChannel chan<- *EmployeeModel, errorChannel chan<- error)
+	GetEmployeeSignature(ctx context.Context, claGroupID, companyID, userID string) (*models.Signature, error)
 	CreateProjectCompanyEmployeeSignature(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, employeeUserModel *models.User) error
 	GetCompanySignatures(ctx context.Context, params signatures.GetCompanySignaturesParams, pageSize int64, loadACL bool) (*models.Signatures, error)
 	GetCompanyIDsWithSignedCorporateSignatures(ctx context.Context, claGroupID string) ([]SignatureCompanyID, error)
@@ -1897,6 +1898,74 @@ func (repo repository) GetProjectCompanyEmployeeSignature(ctx context.Context, c
 	}
 }
 
+// GetEmployeeSignature returns the approved and signed employee acknowledgement of the user for the company and CLA
+// Group, nil if the user hasn't acknowledged
+func (repo repository) GetEmployeeSignature(ctx context.Context, claGroupID, companyID, userID string) (*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.repository.GetEmployeeSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.signatureTableName,
+		"claGroupID":     claGroupID,
+		"companyID":      companyID,
+		"userID":         userID,
+	}
+
+	// the acknowledgements of the user are looked up by the user rather than by the company - the companies have many
+	// employee acknowledgements
+	condition := expression.Key("signature_project_id").Equal(expression.Value(claGroupID)).
+		And(expression.Key("signature_reference_id").Equal(expression.Value(userID)))
+	var filterAdded bool
+	var filter expression.ConditionBuilder
+	filter = addAndCondition(filter, expression.Name("signature_user_ccla_company_id").Equal(expression.Value(companyID)), &filterAdded)
+	filter = addAndCondition(filter, expression.Name("signature_reference_type").Equal(expression.Value(utils.SignatureReferenceTypeUser)), &filterAdded)
+	filter = addAndCondition(filter, expression.Name("signature_approved").Equal(expression.Value(true)), &filterAdded)
+	filter = addAndCondition(filter, expression.Name("signature_signed").Equal(expression.Value(true)), &filterAdded)
+
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).WithProjection(buildProjection()).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for employee signature query")
+		return nil, err
+	}
+
+	// Assemble the query input parameters
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.signatureTableName),
+		Limit:                     aws.Int64(100),                             // The maximum number of items to evaluate (not necessarily the number of matching items)
+		IndexName:                 aws.String(SignatureProjectReferenceIndex), // Name of a secondary index to scan
+	}
+
+	// Loop until we have all the records
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).WithError(errQuery).Warn("error retrieving the employee acknowledgement")
+			return nil, errQuery
+		}
+
+		signatureList, modelErr := repo.buildProjectSignatureModels(ctx, results, claGroupID, LoadACLDetails)
+		if modelErr != nil {
+			log.WithFields(f).WithError(modelErr).Warn("error converting DB model to response model for the employee acknowledgement")
+			return nil, modelErr
+		}
+		if len(signatureList) > 0 {
+			if len(signatureList) > 1 {
+				log.WithFields(f).Warnf("found multiple matching employee acknowledgements - found %d", len(signatureList))
+			}
+			return signatureList[0], nil
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			return nil, nil
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+}
+
 // CreateProjectCompanyEmployeeSignature creates a new project employee signature using the provided details
 func (repo repository) CreateProjectCompanyEmployeeSignature(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, employeeUserModel *models.User) error {
 	f := logrus.Fields{
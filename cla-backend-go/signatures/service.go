@@ -7,9 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
-	"strings"
 	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -19,6 +17,10 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/github"
 	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bot_allowlist"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_evaluation"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/comment_templates"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/repository_requirements"
 
 	"github.com/sirupsen/logrus"
 
@@ -68,25 +70,37 @@ type SignatureService interface {
 	createOrGetEmployeeModels(ctx context.Context, claGroupModel *models.ClaGroup, companyModel *models.Company, corporateSignatureModel *models.Signature) ([]*models.User, error)
 	CreateOrUpdateEmployeeSignature(ctx context.Context, claGroupModel *models.ClaGroup, companyModel *models.Company, corporateSignatureModel *models.Signature) ([]*models.User, error)
 	handleGitHubStatusUpdate(ctx context.Context, employeeUserModel *models.User) error
+	RecheckPullRequest(ctx context.Context, repositoryID, pullRequestID int64) error
+}
+
+// ClaGroupSettingsProvider returns the pull request settings of a CLA Group - the commit identities which require CLA
+// coverage and the comment template
+type ClaGroupSettingsProvider interface {
+	GetCommitIdentityPolicy(ctx context.Context, claGroupID string) (github.CommitIdentityPolicy, error)
+	GetCommentTemplate(ctx context.Context, claGroupID string) (*comment_templates.Template, error)
 }
 
 type service struct {
-	repo                SignatureRepository
-	companyService      company.IService
-	usersService        users.Service
-	eventsService       events.Service
-	githubOrgValidation bool
-	repositoryService   repositories.Service
-	githubOrgService    github_organizations.ServiceInterface
-	claGroupService     service2.Service
-	gitLabApp           *gitlab_api.App
-	claBaseAPIURL       string
-	claLandingPage      string
-	claLogoURL          string
+	repo                     SignatureRepository
+	companyService           company.IService
+	usersService             users.Service
+	eventsService            events.Service
+	githubOrgValidation      bool
+	repositoryService        repositories.Service
+	githubOrgService         github_organizations.ServiceInterface
+	claGroupService          service2.Service
+	claGroupSettingsProvider ClaGroupSettingsProvider
+	botAllowlistService      bot_allowlist.ServiceInterface
+	requirementsService      repository_requirements.ServiceInterface
+	gitLabApp                *gitlab_api.App
+	claBaseAPIURL            string
+	claLandingPage           string
+	claLogoURL               string
+	evaluator                *cla_evaluation.Evaluator
 }
 
 // NewService creates a new signature service
-func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool, repositoryService repositories.Service, githubOrgService github_organizations.ServiceInterface, claGroupService service2.Service, gitLabApp *gitlab_api.App, CLABaseAPIURL, CLALandingPage, CLALogoURL string) SignatureService {
+func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool, repositoryService repositories.Service, githubOrgService github_organizations.ServiceInterface, claGroupService service2.Service, claGroupSettingsProvider ClaGroupSettingsProvider, botAllowlistService bot_allowlist.ServiceInterface, requirementsService repository_requirements.ServiceInterface, gitLabApp *gitlab_api.App, CLABaseAPIURL, CLALandingPage, CLALogoURL string) SignatureService {
 	return service{
 		repo,
 		companyService,
@@ -96,10 +110,14 @@ func NewService(repo SignatureRepository, companyService company.IService, users
 		repositoryService,
 		githubOrgService,
 		claGroupService,
+		claGroupSettingsProvider,
+		botAllowlistService,
+		requirementsService,
 		gitLabApp,
 		CLABaseAPIURL,
 		CLALandingPage,
 		CLALogoURL,
+		cla_evaluation.NewEvaluator(usersService, repo, github.OrganizationMembership{}, nil),
 	}
 }
 
@@ -673,7 +691,7 @@ func (s service) createOrGetEmployeeModels(ctx context.Context, claGroupModel *m
 				// From one of records, we need to decode the access token and use that to create a GitLab client
 				// This will give us the accessInfo we need to create the GitLab client
 				accessInfo := "" // TODO: Need to get the access token from one of the exising GitLab repositories ?
-				gitLabClient, gitLabClientErr := gitlab_api.NewGitlabOauthClient(accessInfo, s.gitLabApp)
+				gitLabClient, gitLabClientErr := gitlab_api.NewGitlabOauthClient(accessInfo, s.gitLabApp, nil)
 				if gitLabClientErr != nil {
 					log.WithFields(f).WithError(gitLabClientErr).Warnf("problem creating GitLab client for user: %s, error: %+v", simpleUserInfoModelEntry.GitLabUserName, gitLabClientErr)
 					responseErr = gitLabClientErr
@@ -975,103 +993,109 @@ func (s service) updateChangeRequest(ctx context.Context, ghOrg *models.GithubOr
 	gitHubOrgName := utils.StringValue(githubRepository.Owner.Login)
 	gitHubRepoName := utils.StringValue(githubRepository.Name)
 
+	// Load the commit identities which require CLA coverage for the CLA Group
+	policy := github.DefaultCommitIdentityPolicy()
+	if s.claGroupSettingsProvider != nil {
+		var policyErr error
+		policy, policyErr = s.claGroupSettingsProvider.GetCommitIdentityPolicy(ctx, projectID)
+		if policyErr != nil {
+			log.WithFields(f).WithError(policyErr).Warnf("unable to load the commit identity policy for CLA Group: %s - using the default policy", projectID)
+		}
+	}
+
+	// The repository requirements are evaluated before the commit authors are loaded
+	claRepositoryID := s.getRepositoryID(ctx, repositoryID)
+	if changes, decision := s.evaluateRepositoryRequirements(ctx, ghOrg.OrganizationInstallationID, claRepositoryID, int(pullRequestID), gitHubOrgName, gitHubRepoName); decision != nil && !decision.Required {
+		log.WithFields(f).Debugf("CLA check not required for PR: %d by the %s rule - %s", pullRequestID, decision.Rule, decision.Reason)
+		s.requirementsService.LogCheckSkipped(ctx, &repository_requirements.SkippedCheck{
+			CLAGroupID:      projectID,
+			Provider:        utils.GitHubType,
+			RepositoryName:  fmt.Sprintf("%s/%s", gitHubOrgName, gitHubRepoName),
+			ChangeRequestID: int(pullRequestID),
+			Decision:        decision,
+		})
+		return github.PublishCLANotRequired(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.GetID(), changes.HeadSHA, decision.Reason, s.claLandingPage)
+	}
+
 	// Fetch committers
 	log.WithFields(f).Debugf("fetching commit authors for PR: %d using repository owner: %s, repo: %s", pullRequestID, gitHubOrgName, gitHubRepoName)
-	authors, latestSHA, authorsErr := github.GetPullRequestCommitAuthors(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName)
+	pullRequestCommits, authorsErr := github.GetPullRequestCommitAuthors(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, policy)
 	if authorsErr != nil {
 		log.WithFields(f).WithError(authorsErr).Warnf("unable to get commit authors for %s/%s for PR: %d", gitHubOrgName, gitHubRepoName, pullRequestID)
 		return authorsErr
 	}
-	log.WithFields(f).Debugf("found %d commit authors for %s/%s for PR: %d", len(authors), gitHubOrgName, gitHubRepoName, pullRequestID)
+	authors := pullRequestCommits.Authors
+	log.WithFields(f).Debugf("found %d commit authors in %d of %d commits for %s/%s for PR: %d",
+		len(authors), pullRequestCommits.LoadedCommits, pullRequestCommits.TotalCommits, gitHubOrgName, gitHubRepoName, pullRequestID)
 
 	signed := make([]*github.UserCommitSummary, 0)
 	unsigned := make([]*github.UserCommitSummary, 0)
 
-	// triage signed and unsigned users
-	log.WithFields(f).Debugf("triaging %d commit authors for PR: %d using repository %s/%s",
-		len(authors), pullRequestID, gitHubOrgName, gitHubRepoName)
-	for _, userSummary := range authors {
+	// bots and service accounts on the allowlist of the repository, CLA Group or foundation are exempt
+	allowlist := s.getBotAllowlistMatcher(ctx, projectID, claRepositoryID)
 
-		if !userSummary.IsValid() {
-			log.WithFields(f).Debugf("invalid user summary: %+v", *userSummary)
-			unsigned = append(unsigned, userSummary)
-			continue
-		}
-
-		commitAuthorID := userSummary.GetCommitAuthorID()
-		commitAuthorUsername := userSummary.GetCommitAuthorUsername()
-		commitAuthorEmail := userSummary.GetCommitAuthorEmail()
-
-		log.WithFields(f).Debugf("checking user - sha: %s, user ID: %s, username: %s, email: %s",
-			userSummary.SHA, commitAuthorID, commitAuthorUsername, commitAuthorEmail)
-
-		var user *models.User
-		var userErr error
-
-		if commitAuthorID != "" {
-			log.WithFields(f).Debugf("looking up user by ID: %s", commitAuthorID)
-			user, userErr = s.usersService.GetUserByGitHubID(commitAuthorID)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by github id: %s", commitAuthorID)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by ID: %s", commitAuthorID)
-			}
-		}
-		if user == nil && commitAuthorUsername != "" {
-			log.WithFields(f).Debugf("looking up user by username: %s", commitAuthorUsername)
-			user, userErr = s.usersService.GetUserByGitHubUsername(commitAuthorUsername)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by github username: %s", commitAuthorUsername)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by username: %s", commitAuthorUsername)
-			}
+	log.WithFields(f).Debugf("evaluating %d commit authors for PR: %d using repository %s/%s",
+		len(authors), pullRequestID, gitHubOrgName, gitHubRepoName)
+	identities := make([]cla_evaluation.Identity, len(authors))
+	for i, userSummary := range authors {
+		identities[i] = cla_evaluation.Identity{
+			Provider:   utils.GitHubType,
+			ExternalID: userSummary.GetCommitAuthorID(),
+			Username:   userSummary.GetCommitAuthorUsername(),
+			Email:      userSummary.GetCommitAuthorEmail(),
+			// GitHub only links the commit to the account when the commit email is a verified email of the account
+			EmailVerified: userSummary.GetCommitAuthorID() != "",
+			Bot:           userSummary.IsBot(),
 		}
-		if user == nil && commitAuthorEmail != "" {
-			log.WithFields(f).Debugf("looking up user by email: %s", commitAuthorEmail)
-			user, userErr = s.usersService.GetUserByEmail(commitAuthorEmail)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by user email: %s", commitAuthorEmail)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by email: %s", commitAuthorEmail)
+	}
+	verdicts := s.evaluator.Evaluate(ctx, projectID, identities, allowlist)
+
+	loggedExemptions := make(map[string]bool)
+	for i, verdict := range verdicts {
+		userSummary := authors[i]
+		userSummary.Affiliated = verdict.Affiliated()
+		log.WithFields(f).Debugf("commit author - sha: %s, user ID: %s, username: %s, email: %s - authorized: %t, reason: %s",
+			userSummary.SHA, verdict.Identity.ExternalID, verdict.Identity.Username, verdict.Identity.Email, verdict.Authorized, verdict.Reason)
+
+		if verdict.Exempt() {
+			userSummary.Exempt = true
+			userSummary.ExemptReason = verdict.ExemptReason()
+			signed = append(signed, userSummary)
+			if key := verdict.Identity.Key(); !loggedExemptions[key] {
+				loggedExemptions[key] = true
+				s.botAllowlistService.LogExemption(ctx, &bot_allowlist.Exemption{
+					CLAGroupID:      projectID,
+					Provider:        utils.GitHubType,
+					RepositoryName:  fmt.Sprintf("%s/%s", gitHubOrgName, gitHubRepoName),
+					ChangeRequestID: int(pullRequestID),
+					Username:        verdict.Identity.Username,
+					Email:           verdict.Identity.Email,
+					Entry:           verdict.ExemptEntry,
+				})
 			}
-		}
-
-		if user == nil {
-			log.WithFields(f).Debugf("unable to find user for commit author - sha: %s, user ID: %s, username: %s, email: %s",
-				userSummary.SHA, commitAuthorID, commitAuthorUsername, commitAuthorEmail)
-			unsigned = append(unsigned, userSummary)
 			continue
 		}
 
-		log.WithFields(f).Debugf("checking to see if user has signed an ICLA or ECLA for project: %s", projectID)
-		userSigned, companyAffiliation, signedErr := s.hasUserSigned(ctx, user, projectID)
-		if signedErr != nil {
-			log.WithFields(f).WithError(signedErr).Warnf("has user signed error - user: %+v, project: %s", user, projectID)
+		// the authors without the GitHub account details are listed as invalid, whatever their verdict
+		userSummary.Authorized = verdict.Authorized && userSummary.IsValid()
+		if userSummary.Authorized {
+			signed = append(signed, userSummary)
+		} else {
 			unsigned = append(unsigned, userSummary)
-			continue
-		}
-
-		if companyAffiliation != nil {
-			userSummary.Affiliated = *companyAffiliation
-		}
-
-		if userSigned != nil {
-			userSummary.Authorized = *userSigned
-			if userSummary.Authorized {
-				signed = append(signed, userSummary)
-			} else {
-				unsigned = append(unsigned, userSummary)
-			}
 		}
 	}
 
+	// large pull requests list the same authors on many commits
+	signed = github.UniqueAuthors(signed)
+	unsigned = github.UniqueAuthors(unsigned)
 	log.WithFields(f).Debugf("commit authors status => signed: %+v and missing: %+v", signed, unsigned)
+	if pullRequestCommits.Truncated() {
+		log.WithFields(f).Warnf("only %d of %d commits were loaded for PR: %d - failing the check", pullRequestCommits.LoadedCommits, pullRequestCommits.TotalCommits, pullRequestID)
+	}
 
 	// update pull request
-	updateErr := github.UpdatePullRequest(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.ID, *latestSHA, signed, unsigned, s.claBaseAPIURL, s.claLandingPage, s.claLogoURL)
+	commentTemplate := s.getCommentTemplate(ctx, projectID)
+	updateErr := github.UpdatePullRequest(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.ID, pullRequestCommits.LatestSHA, pullRequestCommits.Note(), pullRequestCommits.Truncated(), signed, unsigned, commentTemplate, s.claBaseAPIURL, s.claLandingPage, s.claLogoURL)
 	if updateErr != nil {
 		log.WithFields(f).Debugf("unable to update PR: %d", pullRequestID)
 		return updateErr
@@ -1080,252 +1104,147 @@ func (s service) updateChangeRequest(ctx context.Context, ghOrg *models.GithubOr
 	return nil
 }
 
-// hasUserSigned checks to see if the user has signed an ICLA or ECLA for the project, returns:
-// false, false, nil if user is not authorized for ICLA or ECLA
-// false, false, some error if user is not authorized for ICLA or ECLA - we has some problem looking up stuff
-// true, false, nil if user has an ICLA (authorized, but not company affiliation, no error)
-// true, true, nil if user has an ECLA (authorized, with company affiliation, no error)
-func (s service) hasUserSigned(ctx context.Context, user *models.User, projectID string) (*bool, *bool, error) {
+// getRepositoryID returns the EasyCLA repository ID of the GitHub repository, empty when the repository is not found -
+// the repository allowlist entries and requirements are then ignored
+func (s service) getRepositoryID(ctx context.Context, repositoryExternalID int64) string {
 	f := logrus.Fields{
-		"functionName": "v1.signatures.service.updateChangeRequest",
-		"projectID":    projectID,
-		"user":         user,
+		"functionName":         "v1.signatures.service.getRepositoryID",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"repositoryExternalID": repositoryExternalID,
 	}
-	var hasSigned bool
-	var companyAffiliation bool
-
-	approved := true
-	signed := true
 
-	// Check for ICLA
-	log.WithFields(f).Debugf("checking to see if user has signed an ICLA")
-	signature, sigErr := s.GetIndividualSignature(ctx, projectID, user.UserID, &approved, &signed)
-	if sigErr != nil {
-		log.WithFields(f).WithError(sigErr).Warnf("problem checking for ICLA signature for user: %s", user.UserID)
-		return &hasSigned, &companyAffiliation, sigErr
+	claRepository, repoErr := s.repositoryService.GetRepositoryByExternalID(ctx, strconv.FormatInt(repositoryExternalID, 10))
+	if repoErr != nil {
+		log.WithFields(f).WithError(repoErr).Warn("unable to load the repository - ignoring repository allowlist entries and requirements")
+		return ""
 	}
-	if signature != nil {
-		hasSigned = true
-		log.WithFields(f).Debugf("ICLA signature check passed for user: %+v on project : %s", user, projectID)
-		return &hasSigned, &companyAffiliation, nil // ICLA passes, no company affiliation
-	} else {
-		log.WithFields(f).Debugf("ICLA signature check failed for user: %+v on project: %s - ICLA not signed", user, projectID)
+	if claRepository == nil {
+		return ""
 	}
+	return claRepository.RepositoryID
+}
 
-	// Check for Employee Acknowledgment ECLA
-	companyID := user.CompanyID
-	log.WithFields(f).Debugf("checking to see if user has signed a ECLA for company: %s", companyID)
-
-	if companyID != "" {
-		companyAffiliation = true
-
-		// Get employee signature
-		log.WithFields(f).Debugf("ECLA signature check - user has a company: %s - looking for user's employee acknowledgement...", companyID)
-
-		// Load the company - make sure it is valid
-		companyModel, compModelErr := s.companyService.GetCompany(ctx, companyID)
-		if compModelErr != nil {
-			log.WithFields(f).WithError(compModelErr).Warnf("problem looking up company: %s", companyID)
-			return &hasSigned, &companyAffiliation, compModelErr
-		}
-
-		// Load the CLA Group - make sure it is valid
-		claGroupModel, claGroupModelErr := s.claGroupService.GetCLAGroupByID(ctx, projectID)
-		if claGroupModelErr != nil {
-			log.WithFields(f).WithError(claGroupModelErr).Warnf("problem looking up project: %s", projectID)
-			return &hasSigned, &companyAffiliation, claGroupModelErr
-		}
+// evaluateRepositoryRequirements evaluates the repository requirements against the pull request - a nil decision
+// requires the CLA check, the pull request changes are only loaded when the repository has requirements
+func (s service) evaluateRepositoryRequirements(ctx context.Context, installationID int64, repositoryID string, pullRequestID int, owner, repo string) (*github.PullRequestChanges, *repository_requirements.Decision) {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.service.evaluateRepositoryRequirements",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   repositoryID,
+		"pullRequestID":  pullRequestID,
+	}
 
-		employeeSigned, err := s.processEmployeeSignature(ctx, companyModel, claGroupModel, user)
+	if s.requirementsService == nil || repositoryID == "" {
+		return nil, nil
+	}
 
-		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("problem looking up employee signature for company: %s", companyID)
-			return &hasSigned, &companyAffiliation, err
-		}
-		if employeeSigned != nil {
-			hasSigned = *employeeSigned
-		}
+	rules, err := s.requirementsService.GetRules(ctx, repositoryID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the repository requirements - enforcing the CLA check")
+		return nil, nil
+	}
+	if rules == nil {
+		return nil, nil
+	}
 
-	} else {
-		log.WithFields(f).Debugf("ECLA signature check - user does not have a company ID assigned - skipping...")
+	changes, err := github.GetPullRequestChanges(ctx, installationID, pullRequestID, owner, repo, rules.RequiresFiles())
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the pull request changes - enforcing the CLA check")
+		return nil, nil
 	}
 
-	return &hasSigned, &companyAffiliation, nil
+	return changes, rules.Evaluate(&repository_requirements.ChangeRequest{
+		TargetBranch:      changes.BaseRef,
+		Files:             changes.Files,
+		FilesComplete:     changes.FilesComplete,
+		ChangedLines:      changes.ChangedLines,
+		ChangedLinesKnown: true,
+	})
 }
 
-func (s service) processEmployeeSignature(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, user *models.User) (*bool, error) {
+// getBotAllowlistMatcher loads the bot allowlist for the GitHub repository - a nil matcher exempts nobody
+func (s service) getBotAllowlistMatcher(ctx context.Context, claGroupID string, repositoryID string) *bot_allowlist.Matcher {
 	f := logrus.Fields{
-		"functionName":   "v2.signatures.service.processEmployeeSignature",
+		"functionName":   "v1.signatures.service.getBotAllowlistMatcher",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
-		"companyID":      companyModel.CompanyID,
-		"projectID":      claGroupModel.ProjectID,
-		"userID":         user.UserID,
+		"claGroupID":     claGroupID,
+		"repositoryID":   repositoryID,
 	}
-	var wg sync.WaitGroup
-	resultChannel := make(chan *EmployeeModel)
-	errorChannel := make(chan error)
-	hasSigned := false
-	projectID := claGroupModel.ProjectID
-	companyID := companyModel.CompanyID
-	approved := true
-	signed := true
 
-	wg.Add(1)
-	go s.repo.GetProjectCompanyEmployeeSignature(ctx, companyModel, claGroupModel, user, &wg, resultChannel, errorChannel)
-
-	go func() {
-		wg.Wait()
-		close(resultChannel)
-		close(errorChannel)
-	}()
-
-	for result := range resultChannel {
-		if result != nil {
-			employeeSignature := result.Signature
-			if employeeSignature != nil {
-				log.WithFields(f).Debugf("ECLA Signature check - located employee acknowledgement - signature id: %s", employeeSignature.SignatureID)
-
-				// Get corporate ccla signature of company to access the approval list
-				cclaSignature, cclaErr := s.GetCorporateSignature(ctx, projectID, companyID, &approved, &signed)
-				if cclaErr != nil {
-					log.WithFields(f).WithError(cclaErr).Warnf("problem looking up ECLA signature for company: %s, project: %s", companyID, projectID)
-					return &hasSigned, cclaErr
-				}
-
-				if cclaSignature != nil {
-					userApproved, approvedErr := s.userIsApproved(ctx, user, cclaSignature)
-					if approvedErr != nil {
-						log.WithFields(f).WithError(approvedErr).Warnf("problem determining if user: %s is approved for project: %s", user.UserID, projectID)
-						return &hasSigned, approvedErr
-					}
-					log.WithFields(f).Debugf("ECLA Signature check - user approved: %t for projectID: %s for company: %s", userApproved, projectID, user.CompanyID)
-
-					if userApproved {
-						log.WithFields(f).Debugf("user: %s is in the approval list for signature: %s", user.UserID, employeeSignature.SignatureID)
-						hasSigned = true
-					}
-				}
-			} else {
-				log.WithFields(f).Debugf("ECLA Signature check - unable to locate employee acknowledgement for user: %s, company: %s, project: %s", user.UserID, companyID, projectID)
-			}
-		}
+	if s.botAllowlistService == nil {
+		return nil
 	}
 
-	for empSigErr := range errorChannel {
-		log.WithFields(f).WithError(empSigErr).Warnf("problem looking up employee signature for user: %s, company: %s, project: %s", user.UserID, companyID, projectID)
-		return &hasSigned, empSigErr
+	matcher, err := s.botAllowlistService.GetMatcher(ctx, claGroupID, repositoryID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the bot allowlist - no authors are exempt")
+		return nil
 	}
-
-	return &hasSigned, nil
-
+	return matcher
 }
 
-func (s service) userIsApproved(ctx context.Context, user *models.User, cclaSignature *models.Signature) (bool, error) {
-	emails := append(user.Emails, string(user.LfEmail))
-
+// getCommentTemplate returns the pull request comment template of the CLA Group, nil when the default comment applies
+func (s service) getCommentTemplate(ctx context.Context, claGroupID string) *github.CommentTemplate {
 	f := logrus.Fields{
-		"functionName": "v1.signatures.service.userIsApproved",
+		"functionName":   "v1.signatures.service.getCommentTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
 	}
 
-	// check GitHub username approval list
-	gitHubUsernameApprovalList := cclaSignature.GithubUsernameApprovalList
-	if len(gitHubUsernameApprovalList) > 0 {
-		for _, gitHubUsername := range gitHubUsernameApprovalList {
-			if strings.EqualFold(gitHubUsername, strings.TrimSpace(user.GithubUsername)) {
-				return true, nil
-			}
-		}
-	} else {
-		log.WithFields(f).Debugf("no matching github username found in ccla: %s", cclaSignature.SignatureID)
+	if s.claGroupSettingsProvider == nil {
+		return nil
 	}
 
-	// check GitLab username approval list
-	gitLabUsernameApprovalList := cclaSignature.GitlabUsernameApprovalList
-	if len(gitLabUsernameApprovalList) > 0 {
-		for _, gitLabUsername := range gitLabUsernameApprovalList {
-			if strings.EqualFold(gitLabUsername, strings.TrimSpace(user.GitlabUsername)) {
-				return true, nil
-			}
-		}
-	} else {
-		log.WithFields(f).Debugf("no matching gitlab username found in ccla: %s", cclaSignature.SignatureID)
+	tmpl, err := s.claGroupSettingsProvider.GetCommentTemplate(ctx, claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the comment template - using the default comment")
+		return nil
 	}
-
-	// check email email approval list
-	emailApprovalList := cclaSignature.EmailApprovalList
-	if len(emailApprovalList) > 0 {
-		for _, email := range emails {
-			if strings.EqualFold(email, strings.TrimSpace(user.LfUsername)) {
-				return true, nil
-			}
-		}
-	} else {
-		log.WithFields(f).Debugf("no matching email found in ccla: %s", cclaSignature.SignatureID)
+	if tmpl == nil {
+		return nil
 	}
 
-	// check domain email approval list
-	domainApprovalList := cclaSignature.DomainApprovalList
-	if len(domainApprovalList) > 0 {
-		matched, err := s.processPattern(emails, domainApprovalList)
-		if err != nil {
-			return false, err
-		}
-		if matched != nil && *matched {
-			return true, nil
-		}
+	claGroupName := ""
+	claGroupModel, claGroupErr := s.claGroupService.GetCLAGroupByID(ctx, claGroupID)
+	if claGroupErr != nil {
+		log.WithFields(f).WithError(claGroupErr).Warn("unable to load the CLA Group name for the comment template")
+	} else if claGroupModel != nil {
+		claGroupName = claGroupModel.ProjectName
 	}
 
-	// check github org email ApprovalList
-	if user.GithubUsername != "" {
-		githubOrgApprovalList := cclaSignature.GithubOrgApprovalList
-		if len(githubOrgApprovalList) > 0 {
-			log.WithFields(f).Debugf("determining if github user :%s is associated with ant of the github orgs : %+v", user.GithubUsername, githubOrgApprovalList)
-		}
-
-		for _, org := range githubOrgApprovalList {
-			membership, err := github.GetMembership(ctx, user.GithubUsername, org)
-			if err != nil {
-				break
-			}
-			if membership != nil {
-				log.WithFields(f).Debugf("found matching github organization: %s for user: %s", org, user.GithubUsername)
-				return true, nil
-			} else {
-				log.WithFields(f).Debugf("user: %s is not in the organization: %s", user.GithubUsername, org)
-			}
-		}
+	return &github.CommentTemplate{
+		Template:     tmpl,
+		ClaGroupName: claGroupName,
 	}
-
-	return false, nil
 }
 
-func (s service) processPattern(emails []string, patterns []string) (*bool, error) {
-	matched := false
-
-	for _, pattern := range patterns {
-		if strings.HasPrefix(pattern, "*.") {
-			pattern = strings.Replace(pattern, "*.", ".*", -1)
-		} else if strings.HasPrefix(pattern, "*") {
-			pattern = strings.Replace(pattern, "*", ".*", -1)
-		} else if strings.HasPrefix(pattern, ".") {
-			pattern = strings.Replace(pattern, ".", ".*", -1)
-		}
+// RecheckPullRequest re-evaluates the CLA status of the commit authors of the specified pull request and publishes the result
+func (s service) RecheckPullRequest(ctx context.Context, repositoryID, pullRequestID int64) error {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.service.RecheckPullRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   repositoryID,
+		"pullRequestID":  pullRequestID,
+	}
 
-		preProcessedPattern := fmt.Sprintf("^.*@%s$", pattern)
-		compiled, err := regexp.Compile(preProcessedPattern)
-		if err != nil {
-			return nil, err
-		}
+	claRepository, repoErr := s.repositoryService.GetRepositoryByExternalID(ctx, strconv.FormatInt(repositoryID, 10))
+	if repoErr != nil {
+		log.WithFields(f).WithError(repoErr).Warnf("unable to fetch repository by ID: %d", repositoryID)
+		return repoErr
+	}
+	if !claRepository.Enabled {
+		log.WithFields(f).Debugf("repository: %s is NOT enabled - skipping the CLA check", claRepository.RepositoryURL)
+		return nil
+	}
 
-		for _, email := range emails {
-			if compiled.MatchString(email) {
-				matched = true
-				break
-			}
-		}
+	githubOrg, githubOrgErr := s.githubOrgService.GetGitHubOrganizationByName(ctx, claRepository.RepositoryOrganizationName)
+	if githubOrgErr != nil {
+		log.WithFields(f).WithError(githubOrgErr).Warnf("unable to lookup GitHub organization by name: %s", claRepository.RepositoryOrganizationName)
+		return githubOrgErr
 	}
 
-	return &matched, nil
+	log.WithFields(f).Debugf("re-checking pull request for CLA group: %s", claRepository.RepositoryClaGroupID)
+	return s.updateChangeRequest(ctx, githubOrg, repositoryID, pullRequestID, claRepository.RepositoryClaGroupID)
 }
 
 func (s service) handleGitHubStatusUpdate(ctx context.Context, employeeUserModel *models.User) error {
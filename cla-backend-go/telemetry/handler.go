@@ -0,0 +1,63 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsPath is the path of the Prometheus scrape endpoint
+const MetricsPath = "/metrics"
+
+// Handler returns the Prometheus scrape handler, the scraper must provide the bearerToken in the Authorization header.
+// The endpoint is disabled, answering not found, when no bearerToken is configured.
+func Handler(bearerToken string) http.Handler {
+	if bearerToken == "" {
+		return http.NotFoundHandler()
+	}
+	metricsHandler := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+
+	expected := []byte("Bearer " + bearerToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		metricsHandler.ServeHTTP(w, r)
+	})
+}
+
+// statusRecorder captures the response status code
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code before writing it
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	r.status = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// InstrumentAPI records the latency and status of the requests by swagger operation. The handler must run after
+// the swagger router, which is the case for the middleware passed to the API Serve function.
+func InstrumentAPI(api string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		operation := "unknown"
+		if route := middleware.MatchedRouteFrom(r); route != nil && route.Operation != nil {
+			operation = route.Operation.ID
+		}
+		apiRequestDuration.WithLabelValues(api, operation, r.Method, strconv.Itoa(recorder.status)).Observe(time.Since(start).Seconds())
+	})
+}
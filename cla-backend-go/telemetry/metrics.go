@@ -0,0 +1,89 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// namespace is the prefix of all the EasyCLA metric names
+const namespace = "easycla"
+
+// Registry holds all the EasyCLA metrics exposed by the /metrics endpoint
+var Registry = prometheus.NewRegistry()
+
+var (
+	// apiRequestDuration tracks the latency and status of the API requests by swagger operation
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "api",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the API requests by swagger operation and response status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"api", "operation", "method", "status"})
+
+	// externalRequestsTotal counts the calls to the external providers such as GitHub and GitLab
+	externalRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "external",
+		Name:      "requests_total",
+		Help:      "Number of requests to external providers by provider and response status code.",
+	}, []string{"provider", "status"})
+
+	// externalRateLimitRemaining tracks the rate limit remaining reported by the external providers
+	externalRateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "external",
+		Name:      "rate_limit_remaining",
+		Help:      "Rate limit remaining as reported by the most recent response from the external provider.",
+	}, []string{"provider"})
+
+	// emailsSentTotal counts the email send attempts by outcome
+	emailsSentTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "email",
+		Name:      "sent_total",
+		Help:      "Number of emails sent by outcome.",
+	}, []string{"outcome"})
+
+	// zipBuilderDuration tracks how long the signature zip builds take
+	zipBuilderDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "zip_builder",
+		Name:      "duration_seconds",
+		Help:      "Duration of the signature zip builds by CLA type, file type and outcome.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 900},
+	}, []string{"cla_type", "file_type", "outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		apiRequestDuration,
+		externalRequestsTotal,
+		externalRateLimitRemaining,
+		emailsSentTotal,
+		zipBuilderDuration,
+	)
+}
+
+// Register adds the collectors to the EasyCLA registry
+func Register(cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := Registry.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outcome returns the outcome label for the error
+func outcome(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
@@ -0,0 +1,18 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"time"
+)
+
+// ObserveEmailSend records the outcome of an email send attempt
+func ObserveEmailSend(err error) {
+	emailsSentTotal.WithLabelValues(outcome(err)).Inc()
+}
+
+// ObserveZipBuild records the duration and outcome of a signature zip build
+func ObserveZipBuild(claType, fileType string, start time.Time, err error) {
+	zipBuilderDuration.WithLabelValues(claType, fileType, outcome(err)).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,23 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// PushGatewayURLEnv is the environment variable with the Prometheus Pushgateway URL
+const PushGatewayURLEnv = "PROMETHEUS_PUSHGATEWAY_URL"
+
+// PushIfConfigured pushes the metrics to the Pushgateway when configured. Lambda functions which are not
+// reachable by the scraper, such as the zip builder, use this at the end of each invocation.
+func PushIfConfigured(job string) error {
+	gatewayURL := os.Getenv(PushGatewayURLEnv)
+	if gatewayURL == "" {
+		return nil
+	}
+	return push.New(gatewayURL, job).Gatherer(Registry).Add()
+}
@@ -0,0 +1,72 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrumentedTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	before := testutil.ToFloat64(externalRequestsTotal.WithLabelValues("test-provider", "202"))
+	client := &http.Client{Transport: NewInstrumentedTransport("test-provider", nil)}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Body.Close())
+
+	assert.Equal(t, before+1, testutil.ToFloat64(externalRequestsTotal.WithLabelValues("test-provider", "202")))
+	assert.Equal(t, float64(4321), testutil.ToFloat64(externalRateLimitRemaining.WithLabelValues("test-provider")))
+}
+
+func TestObserveEmailSend(t *testing.T) {
+	success := testutil.ToFloat64(emailsSentTotal.WithLabelValues("success"))
+	failure := testutil.ToFloat64(emailsSentTotal.WithLabelValues("failure"))
+
+	ObserveEmailSend(nil)
+	ObserveEmailSend(errors.New("boom"))
+
+	assert.Equal(t, success+1, testutil.ToFloat64(emailsSentTotal.WithLabelValues("success")))
+	assert.Equal(t, failure+1, testutil.ToFloat64(emailsSentTotal.WithLabelValues("failure")))
+}
+
+func TestHandlerRequiresToken(t *testing.T) {
+	handler := Handler("secret")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, MetricsPath, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), "easycla_email_sent_total"))
+}
+
+func TestHandlerDisabledWithoutToken(t *testing.T) {
+	handler := Handler("")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, MetricsPath, nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
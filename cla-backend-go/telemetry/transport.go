@@ -0,0 +1,58 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// provider names used as the metric label values
+const (
+	ProviderGitHub    = "github"
+	ProviderGitLab    = "gitlab"
+	ProviderBitbucket = "bitbucket"
+)
+
+// rateLimitHeaders are the response headers the providers use to report the rate limit remaining
+var rateLimitHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+
+// instrumentedTransport counts the requests to the provider and records the rate limit remaining
+type instrumentedTransport struct {
+	provider string
+	next     http.RoundTripper
+}
+
+// NewInstrumentedTransport wraps the transport to count the requests to the provider and record the rate limit
+// remaining reported in the responses, http.DefaultTransport is used when next is nil
+func NewInstrumentedTransport(provider string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &instrumentedTransport{
+		provider: provider,
+		next:     next,
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		externalRequestsTotal.WithLabelValues(t.provider, "error").Inc()
+		return resp, err
+	}
+
+	externalRequestsTotal.WithLabelValues(t.provider, strconv.Itoa(resp.StatusCode)).Inc()
+	for _, header := range rateLimitHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			if remaining, parseErr := strconv.ParseFloat(value, 64); parseErr == nil {
+				externalRateLimitRemaining.WithLabelValues(t.provider).Set(remaining)
+			}
+			break
+		}
+	}
+
+	return resp, nil
+}
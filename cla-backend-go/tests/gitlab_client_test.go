@@ -51,7 +51,7 @@ func TestGetGroupByName(t *testing.T) { // no lint
 		gitLabApp := gitlab_api.Init(config.Gitlab.AppClientID, config.Gitlab.AppClientSecret, config.Gitlab.AppPrivateKey)
 
 		// Create a new client
-		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp)
+		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp, nil)
 		assert.Nil(t, err, "GitLab OAuth Client Error is Nil")
 		assert.NotNil(t, gitLabClient, "GitLab OAuth Client is Not Nil")
 
@@ -92,7 +92,7 @@ func TestGetGroupByID(t *testing.T) { // no lint
 		gitLabApp := gitlab_api.Init(config.Gitlab.AppClientID, config.Gitlab.AppClientSecret, config.Gitlab.AppPrivateKey)
 
 		// Create a new client
-		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp)
+		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp, nil)
 		assert.Nil(t, err, "GitLab OAuth Client Error is Nil")
 		assert.NotNil(t, gitLabClient, "GitLab OAuth Client is Not Nil")
 
@@ -130,7 +130,7 @@ func TestGetGroupByFullPath(t *testing.T) { // no lint
 		gitLabApp := gitlab_api.Init(config.Gitlab.AppClientID, config.Gitlab.AppClientSecret, config.Gitlab.AppPrivateKey)
 
 		// Create a new client
-		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp)
+		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp, nil)
 		assert.Nil(t, err, "GitLab OAuth Client Error is Nil")
 		assert.NotNil(t, gitLabClient, "GitLab OAuth Client is Not Nil")
 
@@ -168,7 +168,7 @@ func TestGetGroupProjectListByGroupID(t *testing.T) { // no lint
 		gitLabApp := gitlab_api.Init(config.Gitlab.AppClientID, config.Gitlab.AppClientSecret, config.Gitlab.AppPrivateKey)
 
 		// Create a new client
-		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp)
+		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp, nil)
 		assert.Nil(t, err, "GitLab OAuth Client Error is Nil")
 		assert.NotNil(t, gitLabClient, "GitLab OAuth Client is Not Nil")
 
@@ -210,7 +210,7 @@ func TestGitLabListGroups(t *testing.T) { // no lint
 		gitLabApp := gitlab_api.Init(config.Gitlab.AppClientID, config.Gitlab.AppClientSecret, config.Gitlab.AppPrivateKey)
 
 		// Create a new client
-		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp)
+		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp, nil)
 		assert.Nil(t, err, "GitLab OAuth Client Error is Nil")
 		assert.NotNil(t, gitLabClient, "GitLab OAuth Client is Not Nil")
 
@@ -265,7 +265,7 @@ func TestGitLabListProjects(t *testing.T) { // no lint
 		gitLabApp := gitlab_api.Init(config.Gitlab.AppClientID, config.Gitlab.AppClientSecret, config.Gitlab.AppPrivateKey)
 
 		// Create a new client
-		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp)
+		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp, nil)
 		assert.Nil(t, err, "GitLab OAuth Client Error is Nil")
 		assert.NotNil(t, gitLabClient, "GitLab OAuth Client is Not Nil")
 
@@ -355,7 +355,7 @@ func TestGitLabGetUserByUsername(t *testing.T) {
 		assert.NotNil(t, gitLabApp, "GitLab App reference is Not Nil")
 
 		// Create a new client
-		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp)
+		gitLabClient, err := gitlab_api.NewGitlabOauthClient(accessInfo, gitLabApp, nil)
 		assert.Nil(t, err, "GitLab OAuth Client Error is Nil")
 		assert.NotNil(t, gitLabClient, "GitLab OAuth Client is Not Nil")
 
@@ -20,6 +20,7 @@ type Service interface {
 	GetUserByLFUserName(lfUserName string) (*models.User, error)
 	GetUserByUserName(userName string, fullMatch bool) (*models.User, error)
 	GetUserByEmail(userEmail string) (*models.User, error)
+	GetUsersByEmail(userEmail string) ([]*models.User, error)
 	GetUserByGitHubID(gitHubID string) (*models.User, error)
 	GetUserByGitHubUsername(gitlabUsername string) (*models.User, error)
 	GetUserByGitlabID(gitHubID int) (*models.User, error)
@@ -137,6 +138,14 @@ func (s service) GetUserByEmail(userEmail string) (*models.User, error) {
 	return s.repo.GetUserByEmail(userEmail)
 }
 
+// GetUsersByEmail fetches the users with the email in their list of emails
+func (s service) GetUsersByEmail(userEmail string) ([]*models.User, error) {
+	if userEmail == "" {
+		return nil, errors.New("userEmail is empty")
+	}
+	return s.repo.GetUsersByEmail(userEmail)
+}
+
 // GetUserByGitHubID fetches the user by GitHub ID
 func (s service) GetUserByGitHubID(gitHubID string) (*models.User, error) {
 	if gitHubID == "" {
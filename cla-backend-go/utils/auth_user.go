@@ -6,13 +6,23 @@ package utils
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/LF-Engineering/lfx-kit/auth"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/sirupsen/logrus"
 )
 
-// SetAuthUserProperties adds username and email to auth user
+// APITokenUsernamePrefix is the prefix of the username of the requests authenticated with an API token
+const APITokenUsernamePrefix = "api-token:"
+
+// IsAPITokenUser returns true if the user is the principal of an API token
+func IsAPITokenUser(authUser *auth.User) bool {
+	return authUser != nil && strings.HasPrefix(authUser.UserName, APITokenUsernamePrefix)
+}
+
+// SetAuthUserProperties adds username and email to auth user - the identity of the API tokens is pinned, the
+// username and email headers are set by the client
 func SetAuthUserProperties(authUser *auth.User, xUserName *string, xEmail *string) {
 	f := logrus.Fields{
 		"functionName": "utils.SetAuthUserProperties",
@@ -20,6 +30,11 @@ func SetAuthUserProperties(authUser *auth.User, xUserName *string, xEmail *strin
 		"userEmail":    authUser.Email,
 	}
 
+	if IsAPITokenUser(authUser) {
+		log.WithFields(f).Debug("API token user - ignoring the x-username and x-email headers")
+		return
+	}
+
 	if xUserName != nil {
 		authUser.UserName = *xUserName
 	}
@@ -168,6 +168,12 @@ const GitLabDuplicateRepoFound = "Duplicate GitLab repositories were found"
 // GitLabRepoExists  is a string that indicates the GitLab repository already exists
 const GitLabRepoExists = "GitLab repository exists"
 
+// Bitbucket is the Bitbucket spelled out with the proper case
+const Bitbucket = "Bitbucket"
+
+// BitbucketLower is the Bitbucket spelled out in lower case, the repository type of the Bitbucket repositories
+const BitbucketLower = "bitbucket"
+
 // GitLabEmailLabel represents the GitLab Email label used for email
 const GitLabEmailLabel = "GitLab Email Address"
 
@@ -220,6 +226,9 @@ const GitLabRepositoryType = "GitLab"
 // GitHubRepositoryType representing the GitLab repository type
 const GitHubRepositoryType = "GitHub"
 
+// BitbucketRepositoryType representing the Bitbucket repository type
+const BitbucketRepositoryType = "Bitbucket"
+
 // ContextKey is the key for the context
 type contextKey string
 
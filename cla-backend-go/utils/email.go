@@ -17,6 +17,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sns"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/telemetry"
 )
 
 // EmailSender contains method to send email
@@ -94,9 +95,12 @@ func (s *snsEmail) SendEmail(subject string, body string, recipients []string) e
 // SendEmail function send email. It uses emailSender interface.
 func SendEmail(subject string, body string, recipients []string) error {
 	if emailSender == nil {
+		telemetry.ObserveEmailSend(errors.New("email sender not set"))
 		return errors.New("email sender not set")
 	}
-	return emailSender.SendEmail(subject, body, recipients)
+	err := emailSender.SendEmail(subject, body, recipients)
+	telemetry.ObserveEmailSend(err)
+	return err
 }
 
 // GetCorporateURL returns the corporate URL based on the specified flag
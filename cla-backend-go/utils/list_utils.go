@@ -16,3 +16,17 @@ func FindInt64Duplicates(a, b []int64) []int64 {
 
 	return duplicates
 }
+
+// FindStringDuplicates returns the values found in both lists
+func FindStringDuplicates(a, b []string) []string {
+	var duplicates []string
+	for i := 0; i < len(a); i++ {
+		for j := 0; j < len(b); j++ {
+			if a[i] == b[j] {
+				duplicates = append(duplicates, a[i])
+			}
+		}
+	}
+
+	return duplicates
+}
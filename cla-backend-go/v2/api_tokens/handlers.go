@@ -0,0 +1,110 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_tokens
+
+import (
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/api_tokens"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service ServiceInterface) {
+
+	api.APITokensListAPITokensHandler = api_tokens.ListAPITokensHandlerFunc(
+		func(params api_tokens.ListAPITokensParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.api_tokens.handlers.APITokensListAPITokensHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"scopeType":      params.ScopeType,
+				"scopeID":        params.ScopeID,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to list API tokens - only admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return api_tokens.NewListAPITokensForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetAPITokens(ctx, params.ScopeType, params.ScopeID)
+			if err != nil {
+				msg := fmt.Sprintf("problem loading API tokens for %s %s", params.ScopeType, params.ScopeID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return api_tokens.NewListAPITokensBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return api_tokens.NewListAPITokensOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.APITokensCreateAPITokenHandler = api_tokens.CreateAPITokenHandlerFunc(
+		func(params api_tokens.CreateAPITokenParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.api_tokens.handlers.APITokensCreateAPITokenHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"name":           utils.StringValue(params.Body.Name),
+				"scopeType":      utils.StringValue(params.Body.ScopeType),
+				"scopeID":        utils.StringValue(params.Body.ScopeID),
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to create API tokens - only admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return api_tokens.NewCreateAPITokenForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.CreateAPIToken(ctx, params.Body)
+			if err != nil {
+				msg := "problem creating API token"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return api_tokens.NewCreateAPITokenBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return api_tokens.NewCreateAPITokenOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.APITokensRevokeAPITokenHandler = api_tokens.RevokeAPITokenHandlerFunc(
+		func(params api_tokens.RevokeAPITokenParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.api_tokens.handlers.APITokensRevokeAPITokenHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"tokenID":        params.TokenID,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to revoke API tokens - only admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return api_tokens.NewRevokeAPITokenForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err := service.RevokeAPIToken(ctx, params.TokenID)
+			if err != nil {
+				if err == ErrAPITokenNotFound {
+					return api_tokens.NewRevokeAPITokenNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, "API token not found", err))
+				}
+				msg := fmt.Sprintf("problem revoking API token %s", params.TokenID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return api_tokens.NewRevokeAPITokenBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return api_tokens.NewRevokeAPITokenNoContent().WithXRequestID(reqID)
+		})
+}
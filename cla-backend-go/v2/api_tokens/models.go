@@ -0,0 +1,53 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_tokens
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+const (
+	// ScopeTypeProject indicates the API token is issued to a CLA Group (project in the v1 API)
+	ScopeTypeProject = "project"
+	// ScopeTypeCompany indicates the API token is issued to a company
+	ScopeTypeCompany = "company"
+)
+
+// DBAPITokenModel data model for the API tokens table
+type DBAPITokenModel struct {
+	TokenID      string `dynamodbav:"token_id" json:"token_id"`
+	Name         string `dynamodbav:"name" json:"name"`
+	ScopeType    string `dynamodbav:"scope_type" json:"scope_type"`
+	ScopeID      string `dynamodbav:"scope_id" json:"scope_id"`
+	TokenHash    string `dynamodbav:"token_hash" json:"token_hash"`
+	TokenPrefix  string `dynamodbav:"token_prefix" json:"token_prefix"`
+	CreatedBy    string `dynamodbav:"created_by" json:"created_by"`
+	DateCreated  string `dynamodbav:"date_created" json:"date_created"`
+	DateModified string `dynamodbav:"date_modified" json:"date_modified"`
+	ExpiresAt    string `dynamodbav:"expires_at" json:"expires_at"`
+	LastUsedAt   string `dynamodbav:"last_used_at" json:"last_used_at"`
+	Revoked      bool   `dynamodbav:"revoked" json:"revoked"`
+	DateRevoked  string `dynamodbav:"date_revoked" json:"date_revoked"`
+	RevokedBy    string `dynamodbav:"revoked_by" json:"revoked_by"`
+	Version      string `dynamodbav:"version" json:"version"`
+}
+
+// toModel converts the database model to the API model - the token hash is never returned
+func (m *DBAPITokenModel) toModel() *models.APIToken {
+	return &models.APIToken{
+		TokenID:     m.TokenID,
+		Name:        m.Name,
+		ScopeType:   m.ScopeType,
+		ScopeID:     m.ScopeID,
+		TokenPrefix: m.TokenPrefix,
+		CreatedBy:   m.CreatedBy,
+		DateCreated: m.DateCreated,
+		ExpiresAt:   m.ExpiresAt,
+		LastUsedAt:  m.LastUsedAt,
+		Revoked:     m.Revoked,
+		DateRevoked: m.DateRevoked,
+		RevokedBy:   m.RevokedBy,
+		Version:     m.Version,
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_tokens
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// table columns and indexes
+const (
+	// TokenIDColumn is the primary key of the API tokens table
+	TokenIDColumn = "token_id"
+	// TokenScopeIDColumn is the CLA Group ID or company ID of the token
+	TokenScopeIDColumn = "scope_id"
+
+	// TokenScopeIDIndex the index for the scope ID
+	TokenScopeIDIndex = "api-token-scope-id-index"
+)
+
+// RepositoryInterface defines the API token data access functions
+type RepositoryInterface interface {
+	AddAPIToken(ctx context.Context, token *DBAPITokenModel) error
+	GetAPIToken(ctx context.Context, tokenID string) (*DBAPITokenModel, error)
+	GetAPITokensByScopeID(ctx context.Context, scopeID string) ([]*DBAPITokenModel, error)
+	UpdateLastUsed(ctx context.Context, tokenID, lastUsedAt string) error
+	RevokeAPIToken(ctx context.Context, tokenID, revokedBy, dateRevoked string) error
+}
+
+// Repository object/struct
+type Repository struct {
+	stage             string
+	dynamoDBClient    *dynamodb.DynamoDB
+	apiTokenTableName string
+}
+
+// NewRepository creates a new instance of the API tokens repository
+func NewRepository(awsSession *session.Session, stage string) RepositoryInterface {
+	return &Repository{
+		stage:             stage,
+		dynamoDBClient:    dynamodb.New(awsSession),
+		apiTokenTableName: fmt.Sprintf("cla-%s-api-tokens", stage),
+	}
+}
+
+// AddAPIToken adds the API token record to the database
+func (repo *Repository) AddAPIToken(ctx context.Context, token *DBAPITokenModel) error {
+	f := logrus.Fields{
+		"functionName":   "v2.api_tokens.repository.AddAPIToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tokenID":        token.TokenID,
+		"scopeType":      token.ScopeType,
+		"scopeID":        token.ScopeID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(token)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall API token record")
+		return err
+	}
+
+	log.WithFields(f).Debug("adding API token record to the database...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.apiTokenTableName),
+		ConditionExpression: aws.String("attribute_not_exists(token_id)"),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to add API token record")
+		return err
+	}
+
+	return nil
+}
+
+// GetAPIToken returns the API token record by ID, nil if not found
+func (repo *Repository) GetAPIToken(ctx context.Context, tokenID string) (*DBAPITokenModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.api_tokens.repository.GetAPIToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tokenID":        tokenID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			TokenIDColumn: {S: aws.String(tokenID)},
+		},
+		TableName: aws.String(repo.apiTokenTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load API token record")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		log.WithFields(f).Debug("unable to find API token record - no results")
+		return nil, nil
+	}
+
+	var token DBAPITokenModel
+	err = dynamodbattribute.UnmarshalMap(result.Item, &token)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem decoding API token record")
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// GetAPITokensByScopeID returns the API tokens issued to the specified CLA Group ID or company ID
+func (repo *Repository) GetAPITokensByScopeID(ctx context.Context, scopeID string) ([]*DBAPITokenModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.api_tokens.repository.GetAPITokensByScopeID",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scopeID":        scopeID,
+	}
+
+	condition := expression.Key(TokenScopeIDColumn).Equal(expression.Value(scopeID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem building query expression")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.apiTokenTableName),
+		IndexName:                 aws.String(TokenScopeIDIndex),
+	}
+
+	var tokens []*DBAPITokenModel
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("error querying API tokens table")
+			return nil, queryErr
+		}
+
+		var page []*DBAPITokenModel
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding API token records")
+			return nil, err
+		}
+		tokens = append(tokens, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return tokens, nil
+}
+
+// UpdateLastUsed updates the last used timestamp of the API token
+func (repo *Repository) UpdateLastUsed(ctx context.Context, tokenID, lastUsedAt string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.api_tokens.repository.UpdateLastUsed",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tokenID":        tokenID,
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			TokenIDColumn: {S: aws.String(tokenID)},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#L": aws.String("last_used_at"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":l": {S: aws.String(lastUsedAt)},
+		},
+		UpdateExpression: aws.String("SET #L = :l"),
+		TableName:        aws.String(repo.apiTokenTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update API token last used timestamp")
+		return err
+	}
+
+	return nil
+}
+
+// RevokeAPIToken marks the API token as revoked - the record is kept for auditing
+func (repo *Repository) RevokeAPIToken(ctx context.Context, tokenID, revokedBy, dateRevoked string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.api_tokens.repository.RevokeAPIToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tokenID":        tokenID,
+		"revokedBy":      revokedBy,
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			TokenIDColumn: {S: aws.String(tokenID)},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#R":  aws.String("revoked"),
+			"#RB": aws.String("revoked_by"),
+			"#DR": aws.String("date_revoked"),
+			"#M":  aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":r":  {BOOL: aws.Bool(true)},
+			":rb": {S: aws.String(revokedBy)},
+			":dr": {S: aws.String(dateRevoked)},
+		},
+		UpdateExpression: aws.String("SET #R = :r, #RB = :rb, #DR = :dr, #M = :dr"),
+		TableName:        aws.String(repo.apiTokenTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to revoke API token")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,338 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_tokens
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultExpiresInDays is the token lifetime when the caller does not specify one
+const defaultExpiresInDays = 90
+
+// ACL scope types of the v2 API users of the API tokens
+const (
+	aclScopeProject      = "project"
+	aclScopeOrganization = "organization"
+)
+
+// errors
+var (
+	// ErrAPITokenNotFound is returned when the API token does not exist
+	ErrAPITokenNotFound = errors.New("API token not found")
+	// ErrAPITokenInvalid is returned when the API token is unknown, revoked, expired or does not match the stored hash
+	ErrAPITokenInvalid = errors.New("invalid API token")
+	// ErrInvalidScope is returned when the scope type or scope ID of the token is not valid
+	ErrInvalidScope = errors.New("invalid API token scope")
+)
+
+// ServiceInterface defines the API token service functions
+type ServiceInterface interface {
+	CreateAPIToken(ctx context.Context, input *models.APITokenInput) (*models.APITokenCreated, error)
+	GetAPITokens(ctx context.Context, scopeType, scopeID string) (*models.APITokenList, error)
+	RevokeAPIToken(ctx context.Context, tokenID string) error
+
+	ValidateAPIToken(token string) (*user.CLAUser, error)
+	ValidateAPITokenACL(token string) (*auth.User, error)
+}
+
+// Service data model
+type Service struct {
+	repo                RepositoryInterface
+	projectClaGroupRepo projects_cla_groups.Repository
+	companyRepo         company.IRepository
+	eventsService       events.Service
+}
+
+// NewService creates a new API token service
+func NewService(repo RepositoryInterface, projectClaGroupRepo projects_cla_groups.Repository, companyRepo company.IRepository, eventsService events.Service) ServiceInterface {
+	return &Service{
+		repo:                repo,
+		projectClaGroupRepo: projectClaGroupRepo,
+		companyRepo:         companyRepo,
+		eventsService:       eventsService,
+	}
+}
+
+// CreateAPIToken issues a new API token for the CLA Group or company, the token secret is only returned by this call
+func (s *Service) CreateAPIToken(ctx context.Context, input *models.APITokenInput) (*models.APITokenCreated, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.api_tokens.service.CreateAPIToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"name":           utils.StringValue(input.Name),
+		"scopeType":      utils.StringValue(input.ScopeType),
+		"scopeID":        utils.StringValue(input.ScopeID),
+	}
+
+	scopeType := utils.StringValue(input.ScopeType)
+	scopeID := utils.StringValue(input.ScopeID)
+	if (scopeType != ScopeTypeProject && scopeType != ScopeTypeCompany) || !utils.IsUUIDv4(scopeID) {
+		log.WithFields(f).Warn("invalid API token scope")
+		return nil, ErrInvalidScope
+	}
+
+	tokenID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for the API token")
+		return nil, err
+	}
+
+	token, err := generateToken(tokenID.String())
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate the API token secret")
+		return nil, err
+	}
+
+	expiresInDays := input.ExpiresInDays
+	if expiresInDays <= 0 {
+		expiresInDays = defaultExpiresInDays
+	}
+
+	now, currentTime := utils.CurrentTime()
+	record := &DBAPITokenModel{
+		TokenID:      tokenID.String(),
+		Name:         strings.TrimSpace(utils.StringValue(input.Name)),
+		ScopeType:    scopeType,
+		ScopeID:      scopeID,
+		TokenHash:    hashToken(token),
+		TokenPrefix:  tokenPrefix(tokenID.String()),
+		CreatedBy:    utils.GetUserNameFromContext(ctx),
+		DateCreated:  currentTime,
+		DateModified: currentTime,
+		ExpiresAt:    utils.TimeToString(now.AddDate(0, 0, int(expiresInDays))),
+		Version:      "v1",
+	}
+
+	err = s.repo.AddAPIToken(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	args := eventArgs(events.APITokenCreated, record, &events.APITokenCreatedEventData{
+		TokenID:   record.TokenID,
+		TokenName: record.Name,
+		ScopeType: record.ScopeType,
+		ScopeID:   record.ScopeID,
+	})
+	args.LfUsername = record.CreatedBy
+	s.eventsService.LogEventWithContext(ctx, args)
+
+	return &models.APITokenCreated{
+		Token:    token,
+		APIToken: record.toModel(),
+	}, nil
+}
+
+// GetAPITokens returns the list of API tokens issued to the CLA Group or company
+func (s *Service) GetAPITokens(ctx context.Context, scopeType, scopeID string) (*models.APITokenList, error) {
+	tokens, err := s.repo.GetAPITokensByScopeID(ctx, scopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.APITokenList{
+		List: []*models.APIToken{},
+	}
+	for _, token := range tokens {
+		if token.ScopeType == scopeType {
+			response.List = append(response.List, token.toModel())
+		}
+	}
+
+	return response, nil
+}
+
+// RevokeAPIToken revokes the API token
+func (s *Service) RevokeAPIToken(ctx context.Context, tokenID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.api_tokens.service.RevokeAPIToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tokenID":        tokenID,
+	}
+
+	record, err := s.repo.GetAPIToken(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		log.WithFields(f).Warn("API token not found")
+		return ErrAPITokenNotFound
+	}
+
+	_, currentTime := utils.CurrentTime()
+	err = s.repo.RevokeAPIToken(ctx, tokenID, utils.GetUserNameFromContext(ctx), currentTime)
+	if err != nil {
+		return err
+	}
+
+	args := eventArgs(events.APITokenRevoked, record, &events.APITokenRevokedEventData{
+		TokenID:   record.TokenID,
+		TokenName: record.Name,
+		ScopeType: record.ScopeType,
+		ScopeID:   record.ScopeID,
+	})
+	args.LfUsername = utils.GetUserNameFromContext(ctx)
+	s.eventsService.LogEventWithContext(ctx, args)
+
+	return nil
+}
+
+// ValidateAPIToken verifies the API token and returns a CLA user limited to the scope of the token. Each successful
+// use updates the last used timestamp and is recorded as an event.
+func (s *Service) ValidateAPIToken(token string) (*user.CLAUser, error) {
+	ctx := context.Background()
+	f := logrus.Fields{
+		"functionName": "v2.api_tokens.service.ValidateAPIToken",
+	}
+
+	tokenID, err := parseTokenID(token)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to parse API token")
+		return nil, ErrAPITokenInvalid
+	}
+	f["tokenID"] = tokenID
+
+	record, err := s.repo.GetAPIToken(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || !tokenMatchesHash(token, record.TokenHash) {
+		log.WithFields(f).Warn("API token not found or hash mismatch")
+		return nil, ErrAPITokenInvalid
+	}
+	if record.Revoked {
+		log.WithFields(f).Warnf("API token was revoked on %s by %s", record.DateRevoked, record.RevokedBy)
+		return nil, ErrAPITokenInvalid
+	}
+
+	expiresAt, err := utils.ParseDateTime(record.ExpiresAt)
+	if err != nil || time.Now().UTC().After(expiresAt) {
+		log.WithFields(f).Warnf("API token expired on %s", record.ExpiresAt)
+		return nil, ErrAPITokenInvalid
+	}
+
+	_, currentTime := utils.CurrentTime()
+	if updateErr := s.repo.UpdateLastUsed(ctx, tokenID, currentTime); updateErr != nil {
+		// Not fatal - the token is still valid
+		log.WithFields(f).WithError(updateErr).Warn("problem updating API token last used timestamp")
+	}
+
+	claUser := &user.CLAUser{
+		UserID:     apiTokenUsername(record),
+		Name:       record.Name,
+		LFUsername: apiTokenUsername(record),
+	}
+	switch record.ScopeType {
+	case ScopeTypeProject:
+		claUser.ProjectIDs = []string{record.ScopeID}
+	case ScopeTypeCompany:
+		claUser.CompanyIDs = []string{record.ScopeID}
+	}
+
+	args := eventArgs(events.APITokenUsed, record, &events.APITokenUsedEventData{
+		TokenID:   record.TokenID,
+		TokenName: record.Name,
+		ScopeType: record.ScopeType,
+		ScopeID:   record.ScopeID,
+	})
+	args.LfUsername = claUser.LFUsername
+	args.UserName = record.Name
+	s.eventsService.LogEventWithContext(ctx, args)
+
+	return claUser, nil
+}
+
+// ValidateAPITokenACL verifies the API token like ValidateAPIToken and returns the v2 API user - the ACL scopes are the
+// SFIDs of the projects of the CLA Group or the SFID of the company the token is issued to
+func (s *Service) ValidateAPITokenACL(token string) (*auth.User, error) {
+	ctx := context.Background()
+	f := logrus.Fields{
+		"functionName": "v2.api_tokens.service.ValidateAPITokenACL",
+	}
+
+	claUser, err := s.ValidateAPIToken(token)
+	if err != nil {
+		return nil, err
+	}
+	f["username"] = claUser.LFUsername
+
+	var scopes []auth.Scope
+	for _, claGroupID := range claUser.ProjectIDs {
+		projectClaGroups, projectErr := s.projectClaGroupRepo.GetProjectsIdsForClaGroup(ctx, claGroupID)
+		if projectErr != nil {
+			log.WithFields(f).WithError(projectErr).Warnf("unable to load the projects of the CLA Group: %s", claGroupID)
+			return nil, projectErr
+		}
+		for _, projectClaGroup := range projectClaGroups {
+			scopes = append(scopes, apiTokenScope(aclScopeProject, projectClaGroup.ProjectSFID))
+		}
+	}
+	for _, companyID := range claUser.CompanyIDs {
+		companyModel, companyErr := s.companyRepo.GetCompany(ctx, companyID)
+		if companyErr != nil {
+			log.WithFields(f).WithError(companyErr).Warnf("unable to load the company: %s", companyID)
+			return nil, companyErr
+		}
+		if companyModel.CompanyExternalID != "" {
+			scopes = append(scopes, apiTokenScope(aclScopeOrganization, companyModel.CompanyExternalID))
+		}
+	}
+	if len(scopes) == 0 {
+		log.WithFields(f).Warn("no project or company SFID found for the scope of the API token")
+		return nil, ErrInvalidScope
+	}
+
+	return &auth.User{
+		UserName: claUser.LFUsername,
+		ACL: auth.ACL{
+			Allowed: true,
+			Scopes:  scopes,
+		},
+	}, nil
+}
+
+// apiTokenScope returns the ACL scope of the project or organization the API token is issued to
+func apiTokenScope(scopeType, id string) auth.Scope {
+	return auth.Scope{
+		Type:  scopeType,
+		ID:    id,
+		Role:  utils.CLAManagerRole,
+		Level: "staff",
+	}
+}
+
+// apiTokenUsername returns the synthetic username used for requests authenticated with the API token
+func apiTokenUsername(record *DBAPITokenModel) string {
+	return utils.APITokenUsernamePrefix + record.TokenID
+}
+
+// eventArgs builds the event arguments for the scope of the API token
+func eventArgs(eventType string, record *DBAPITokenModel, eventData events.EventData) *events.LogEventArgs {
+	args := &events.LogEventArgs{
+		EventType: eventType,
+		EventData: eventData,
+	}
+
+	switch record.ScopeType {
+	case ScopeTypeProject:
+		args.CLAGroupID = record.ScopeID
+	case ScopeTypeCompany:
+		args.CompanyID = record.ScopeID
+	}
+
+	return args
+}
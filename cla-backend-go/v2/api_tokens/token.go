@@ -0,0 +1,61 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	claAuth "github.com/communitybridge/easycla/cla-backend-go/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// secretLength is the number of random bytes in the token secret
+const secretLength = 32
+
+// ErrMalformedToken is returned when the token does not match the API token format
+var ErrMalformedToken = errors.New("malformed API token")
+
+// generateToken creates a new token for the specified token ID, the format is ecla_<token id>_<secret>
+func generateToken(tokenID string) (string, error) {
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return claAuth.APITokenPrefix + tokenID + "_" + hex.EncodeToString(secret), nil
+}
+
+// parseTokenID returns the token ID embedded in the token
+func parseTokenID(token string) (string, error) {
+	if !strings.HasPrefix(token, claAuth.APITokenPrefix) {
+		return "", ErrMalformedToken
+	}
+
+	parts := strings.Split(strings.TrimPrefix(token, claAuth.APITokenPrefix), "_")
+	if len(parts) != 2 || !utils.IsUUIDv4(parts[0]) || len(parts[1]) != hex.EncodedLen(secretLength) {
+		return "", ErrMalformedToken
+	}
+
+	return parts[0], nil
+}
+
+// hashToken returns the hex encoded SHA-256 hash of the token - only the hash is stored at rest
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenMatchesHash compares the token against the stored hash in constant time
+func tokenMatchesHash(token, tokenHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(tokenHash)) == 1
+}
+
+// tokenPrefix returns the displayable prefix of the token which does not reveal the secret
+func tokenPrefix(tokenID string) string {
+	return claAuth.APITokenPrefix + strings.Split(tokenID, "-")[0]
+}
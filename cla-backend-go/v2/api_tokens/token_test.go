@@ -0,0 +1,61 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_tokens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTokenID = "b5c2e7a1-3f4d-4c8e-8a9b-1d2e3f4a5b6c"
+
+func TestGenerateAndParseToken(t *testing.T) {
+	token, err := generateToken(testTokenID)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(token, "ecla_"+testTokenID+"_"))
+
+	tokenID, err := parseTokenID(token)
+	assert.Nil(t, err)
+	assert.Equal(t, testTokenID, tokenID)
+
+	other, err := generateToken(testTokenID)
+	assert.Nil(t, err)
+	assert.NotEqual(t, token, other, "each token should have a unique secret")
+}
+
+func TestParseTokenIDMalformed(t *testing.T) {
+	testCases := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "jwt", token: "eyJhbGciOiJSUzI1NiJ9.eyJzdWIiOiIxIn0.c2ln"},
+		{name: "missing secret", token: "ecla_" + testTokenID},
+		{name: "short secret", token: "ecla_" + testTokenID + "_abc"},
+		{name: "invalid token id", token: "ecla_not-a-uuid_" + strings.Repeat("a", 64)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseTokenID(tc.token)
+			assert.Equal(t, ErrMalformedToken, err)
+		})
+	}
+}
+
+func TestTokenMatchesHash(t *testing.T) {
+	token, err := generateToken(testTokenID)
+	assert.Nil(t, err)
+
+	tokenHash := hashToken(token)
+	assert.NotContains(t, tokenHash, token)
+	assert.True(t, tokenMatchesHash(token, tokenHash))
+	assert.False(t, tokenMatchesHash(token+"x", tokenHash))
+}
+
+func TestTokenPrefix(t *testing.T) {
+	assert.Equal(t, "ecla_b5c2e7a1", tokenPrefix(testTokenID))
+}
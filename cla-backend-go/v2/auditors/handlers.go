@@ -0,0 +1,204 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package auditors
+
+import (
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/auditors"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service ServiceInterface, projectsClaGroupsRepo projects_cla_groups.Repository) { // nolint
+
+	api.AuditorsListFoundationAuditorsHandler = auditors.ListFoundationAuditorsHandlerFunc(
+		func(params auditors.ListFoundationAuditorsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.auditors.handlers.AuditorsListFoundationAuditorsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"foundationSFID": params.FoundationSFID,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to list the auditors for foundation %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return auditors.NewListFoundationAuditorsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetAuditors(ctx, ScopeTypeFoundation, params.FoundationSFID)
+			if err != nil {
+				msg := fmt.Sprintf("problem loading auditors for foundation %s", params.FoundationSFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return auditors.NewListFoundationAuditorsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return auditors.NewListFoundationAuditorsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.AuditorsAddFoundationAuditorHandler = auditors.AddFoundationAuditorHandlerFunc(
+		func(params auditors.AddFoundationAuditorParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.auditors.handlers.AuditorsAddFoundationAuditorHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"foundationSFID": params.FoundationSFID,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to add auditors for foundation %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return auditors.NewAddFoundationAuditorForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.AddAuditor(ctx, ScopeTypeFoundation, params.FoundationSFID, params.Body)
+			if err != nil {
+				if err == ErrAuditorAlreadyExists {
+					return auditors.NewAddFoundationAuditorConflict().WithXRequestID(reqID).WithPayload(utils.ErrorResponseConflictWithError(reqID, "auditor already exists", err))
+				}
+				msg := fmt.Sprintf("problem adding auditor for foundation %s", params.FoundationSFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return auditors.NewAddFoundationAuditorBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return auditors.NewAddFoundationAuditorOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.AuditorsDeleteFoundationAuditorHandler = auditors.DeleteFoundationAuditorHandlerFunc(
+		func(params auditors.DeleteFoundationAuditorParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.auditors.handlers.AuditorsDeleteFoundationAuditorHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"foundationSFID": params.FoundationSFID,
+				"auditorID":      params.AuditorID,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to remove auditors for foundation %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return auditors.NewDeleteFoundationAuditorForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err := service.DeleteAuditor(ctx, ScopeTypeFoundation, params.FoundationSFID, params.AuditorID)
+			if err != nil {
+				if err == ErrAuditorNotFound {
+					return auditors.NewDeleteFoundationAuditorNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, "auditor not found", err))
+				}
+				msg := fmt.Sprintf("problem removing auditor %s for foundation %s", params.AuditorID, params.FoundationSFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return auditors.NewDeleteFoundationAuditorBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return auditors.NewDeleteFoundationAuditorNoContent().WithXRequestID(reqID)
+		})
+
+	api.AuditorsListClaGroupAuditorsHandler = auditors.ListClaGroupAuditorsHandlerFunc(
+		func(params auditors.ListClaGroupAuditorsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.auditors.handlers.AuditorsListClaGroupAuditorsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"claGroupID":     params.ClaGroupID,
+			}
+
+			if !common.IsUserAuthorizedForCLAGroup(ctx, authUser, params.ClaGroupID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to list the auditors for CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return auditors.NewListClaGroupAuditorsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetAuditors(ctx, ScopeTypeCLAGroup, params.ClaGroupID)
+			if err != nil {
+				msg := fmt.Sprintf("problem loading auditors for CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return auditors.NewListClaGroupAuditorsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return auditors.NewListClaGroupAuditorsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.AuditorsAddClaGroupAuditorHandler = auditors.AddClaGroupAuditorHandlerFunc(
+		func(params auditors.AddClaGroupAuditorParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.auditors.handlers.AuditorsAddClaGroupAuditorHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"claGroupID":     params.ClaGroupID,
+			}
+
+			if !common.IsUserAuthorizedForCLAGroup(ctx, authUser, params.ClaGroupID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to add auditors for CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return auditors.NewAddClaGroupAuditorForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.AddAuditor(ctx, ScopeTypeCLAGroup, params.ClaGroupID, params.Body)
+			if err != nil {
+				if err == ErrAuditorAlreadyExists {
+					return auditors.NewAddClaGroupAuditorConflict().WithXRequestID(reqID).WithPayload(utils.ErrorResponseConflictWithError(reqID, "auditor already exists", err))
+				}
+				msg := fmt.Sprintf("problem adding auditor for CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return auditors.NewAddClaGroupAuditorBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return auditors.NewAddClaGroupAuditorOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.AuditorsDeleteClaGroupAuditorHandler = auditors.DeleteClaGroupAuditorHandlerFunc(
+		func(params auditors.DeleteClaGroupAuditorParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.auditors.handlers.AuditorsDeleteClaGroupAuditorHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"claGroupID":     params.ClaGroupID,
+				"auditorID":      params.AuditorID,
+			}
+
+			if !common.IsUserAuthorizedForCLAGroup(ctx, authUser, params.ClaGroupID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to remove auditors for CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return auditors.NewDeleteClaGroupAuditorForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err := service.DeleteAuditor(ctx, ScopeTypeCLAGroup, params.ClaGroupID, params.AuditorID)
+			if err != nil {
+				if err == ErrAuditorNotFound {
+					return auditors.NewDeleteClaGroupAuditorNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, "auditor not found", err))
+				}
+				msg := fmt.Sprintf("problem removing auditor %s for CLA Group %s", params.AuditorID, params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return auditors.NewDeleteClaGroupAuditorBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return auditors.NewDeleteClaGroupAuditorNoContent().WithXRequestID(reqID)
+		})
+}
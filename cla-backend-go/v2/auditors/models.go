@@ -0,0 +1,50 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package auditors
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+const (
+	// ScopeTypeFoundation indicates the auditor assignment covers every CLA Group under a foundation
+	ScopeTypeFoundation = "foundation"
+	// ScopeTypeCLAGroup indicates the auditor assignment covers a single CLA Group
+	ScopeTypeCLAGroup = "cla_group"
+)
+
+// DBAuditorModel data model for the auditors table
+type DBAuditorModel struct {
+	AuditorID    string `dynamodbav:"auditor_id" json:"auditor_id"`
+	ScopeType    string `dynamodbav:"scope_type" json:"scope_type"`
+	ScopeID      string `dynamodbav:"scope_id" json:"scope_id"`
+	LfUsername   string `dynamodbav:"lf_username" json:"lf_username"`
+	LfEmail      string `dynamodbav:"lf_email" json:"lf_email"`
+	AddedBy      string `dynamodbav:"added_by" json:"added_by"`
+	Note         string `dynamodbav:"note" json:"note"`
+	DateCreated  string `dynamodbav:"date_created" json:"date_created"`
+	DateModified string `dynamodbav:"date_modified" json:"date_modified"`
+	Version      string `dynamodbav:"version" json:"version"`
+}
+
+// toModel converts the database model to the API model
+func (m *DBAuditorModel) toModel() *models.Auditor {
+	return &models.Auditor{
+		AuditorID:    m.AuditorID,
+		ScopeType:    m.ScopeType,
+		ScopeID:      m.ScopeID,
+		LfUsername:   m.LfUsername,
+		LfEmail:      m.LfEmail,
+		AddedBy:      m.AddedBy,
+		Note:         m.Note,
+		DateCreated:  m.DateCreated,
+		DateModified: m.DateModified,
+		Version:      m.Version,
+	}
+}
+
+// matchesScope returns true if the auditor assignment covers the specified scope
+func (m *DBAuditorModel) matchesScope(scopeType, scopeID string) bool {
+	return m.ScopeType == scopeType && m.ScopeID == scopeID
+}
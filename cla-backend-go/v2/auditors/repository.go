@@ -0,0 +1,209 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package auditors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// table columns and indexes
+const (
+	// AuditorIDColumn is the primary key of the auditors table
+	AuditorIDColumn = "auditor_id"
+	// AuditorScopeIDColumn is the foundation SFID or CLA Group ID of the assignment
+	AuditorScopeIDColumn = "scope_id"
+	// AuditorLFUsernameColumn is the LF username of the auditor
+	AuditorLFUsernameColumn = "lf_username"
+
+	// AuditorScopeIDIndex the index for the scope ID
+	AuditorScopeIDIndex = "auditor-scope-id-index"
+	// AuditorLFUsernameIndex the index for the auditor LF username
+	AuditorLFUsernameIndex = "auditor-lf-username-index"
+)
+
+// RepositoryInterface defines the auditor data access functions
+type RepositoryInterface interface {
+	AddAuditor(ctx context.Context, auditor *DBAuditorModel) error
+	GetAuditor(ctx context.Context, auditorID string) (*DBAuditorModel, error)
+	GetAuditorsByScopeID(ctx context.Context, scopeID string) ([]*DBAuditorModel, error)
+	GetAuditorsByLFUsername(ctx context.Context, lfUsername string) ([]*DBAuditorModel, error)
+	DeleteAuditor(ctx context.Context, auditorID string) error
+}
+
+// Repository object/struct
+type Repository struct {
+	stage            string
+	dynamoDBClient   *dynamodb.DynamoDB
+	auditorTableName string
+}
+
+// NewRepository creates a new instance of the auditors repository
+func NewRepository(awsSession *session.Session, stage string) RepositoryInterface {
+	return &Repository{
+		stage:            stage,
+		dynamoDBClient:   dynamodb.New(awsSession),
+		auditorTableName: fmt.Sprintf("cla-%s-auditors", stage),
+	}
+}
+
+// AddAuditor adds the auditor assignment record to the database
+func (repo *Repository) AddAuditor(ctx context.Context, auditor *DBAuditorModel) error {
+	f := logrus.Fields{
+		"functionName":   "v2.auditors.repository.AddAuditor",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"auditorID":      auditor.AuditorID,
+		"scopeType":      auditor.ScopeType,
+		"scopeID":        auditor.ScopeID,
+		"lfUsername":     auditor.LfUsername,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(auditor)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall auditor record")
+		return err
+	}
+
+	log.WithFields(f).Debug("adding auditor record to the database...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.auditorTableName),
+		ConditionExpression: aws.String("attribute_not_exists(auditor_id)"),
+	})
+	if err != nil {
+		if aErr, ok := err.(awserr.Error); ok && aErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).WithError(err).Warn("auditor record already exists")
+			return fmt.Errorf("auditor record already exists")
+		}
+		log.WithFields(f).WithError(err).Warn("unable to add auditor record")
+		return err
+	}
+
+	return nil
+}
+
+// GetAuditor returns the auditor assignment by ID, nil if not found
+func (repo *Repository) GetAuditor(ctx context.Context, auditorID string) (*DBAuditorModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.auditors.repository.GetAuditor",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"auditorID":      auditorID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			AuditorIDColumn: {S: aws.String(auditorID)},
+		},
+		TableName: aws.String(repo.auditorTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load auditor record")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		log.WithFields(f).Debug("unable to find auditor record - no results")
+		return nil, nil
+	}
+
+	var auditor DBAuditorModel
+	err = dynamodbattribute.UnmarshalMap(result.Item, &auditor)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem decoding auditor record")
+		return nil, err
+	}
+
+	return &auditor, nil
+}
+
+// GetAuditorsByScopeID returns the auditors assigned to the specified foundation SFID or CLA Group ID
+func (repo *Repository) GetAuditorsByScopeID(ctx context.Context, scopeID string) ([]*DBAuditorModel, error) {
+	condition := expression.Key(AuditorScopeIDColumn).Equal(expression.Value(scopeID))
+	return repo.queryAuditors(ctx, condition, AuditorScopeIDIndex)
+}
+
+// GetAuditorsByLFUsername returns all the auditor assignments for the specified LF username
+func (repo *Repository) GetAuditorsByLFUsername(ctx context.Context, lfUsername string) ([]*DBAuditorModel, error) {
+	condition := expression.Key(AuditorLFUsernameColumn).Equal(expression.Value(lfUsername))
+	return repo.queryAuditors(ctx, condition, AuditorLFUsernameIndex)
+}
+
+// DeleteAuditor removes the auditor assignment record
+func (repo *Repository) DeleteAuditor(ctx context.Context, auditorID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.auditors.repository.DeleteAuditor",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"auditorID":      auditorID,
+	}
+
+	log.WithFields(f).Debug("deleting auditor record...")
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			AuditorIDColumn: {S: aws.String(auditorID)},
+		},
+		TableName: aws.String(repo.auditorTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to delete auditor record")
+		return err
+	}
+
+	return nil
+}
+
+// queryAuditors is a helper function to query the auditors table using the specified index
+func (repo *Repository) queryAuditors(ctx context.Context, condition expression.KeyConditionBuilder, indexName string) ([]*DBAuditorModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.auditors.repository.queryAuditors",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"indexName":      indexName,
+	}
+
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem building query expression")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.auditorTableName),
+		IndexName:                 aws.String(indexName),
+	}
+
+	var auditors []*DBAuditorModel
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("error querying auditors table")
+			return nil, queryErr
+		}
+
+		var page []*DBAuditorModel
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding auditor records")
+			return nil, err
+		}
+		auditors = append(auditors, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return auditors, nil
+}
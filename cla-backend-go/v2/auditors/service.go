@@ -0,0 +1,267 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package auditors
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// errors
+var (
+	// ErrAuditorNotFound is returned when the auditor assignment does not exist for the given scope
+	ErrAuditorNotFound = errors.New("auditor not found")
+	// ErrAuditorAlreadyExists is returned when the user is already an auditor for the given scope
+	ErrAuditorAlreadyExists = errors.New("auditor already exists")
+)
+
+// ServiceInterface defines the auditor service functions
+type ServiceInterface interface {
+	AddAuditor(ctx context.Context, scopeType, scopeID string, input *models.AuditorInput) (*models.Auditor, error)
+	GetAuditors(ctx context.Context, scopeType, scopeID string) (*models.AuditorList, error)
+	DeleteAuditor(ctx context.Context, scopeType, scopeID, auditorID string) error
+
+	IsCLAGroupAuditor(ctx context.Context, lfUsername, claGroupID string) bool
+	IsFoundationAuditor(ctx context.Context, lfUsername, foundationSFID string) bool
+	LogAuditorAccess(ctx context.Context, lfUsername, scopeType, scopeID, resource string)
+}
+
+// Service data model
+type Service struct {
+	repo                  RepositoryInterface
+	projectsClaGroupsRepo projects_cla_groups.Repository
+	eventsService         events.Service
+}
+
+// NewService creates a new auditor service
+func NewService(repo RepositoryInterface, projectsClaGroupsRepo projects_cla_groups.Repository, eventsService events.Service) ServiceInterface {
+	return &Service{
+		repo:                  repo,
+		projectsClaGroupsRepo: projectsClaGroupsRepo,
+		eventsService:         eventsService,
+	}
+}
+
+// AddAuditor assigns the auditor role to the user for the specified foundation or CLA Group
+func (s *Service) AddAuditor(ctx context.Context, scopeType, scopeID string, input *models.AuditorInput) (*models.Auditor, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.auditors.service.AddAuditor",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scopeType":      scopeType,
+		"scopeID":        scopeID,
+		"lfUsername":     utils.StringValue(input.LfUsername),
+	}
+
+	existing, err := s.repo.GetAuditorsByLFUsername(ctx, utils.StringValue(input.LfUsername))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading existing auditor assignments")
+		return nil, err
+	}
+	for _, auditor := range existing {
+		if auditor.matchesScope(scopeType, scopeID) {
+			log.WithFields(f).Warn("user is already an auditor for this scope")
+			return nil, ErrAuditorAlreadyExists
+		}
+	}
+
+	auditorID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for the auditor record")
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	auditor := &DBAuditorModel{
+		AuditorID:    auditorID.String(),
+		ScopeType:    scopeType,
+		ScopeID:      scopeID,
+		LfUsername:   utils.StringValue(input.LfUsername),
+		LfEmail:      strings.TrimSpace(input.LfEmail.String()),
+		AddedBy:      utils.GetUserNameFromContext(ctx),
+		Note:         input.Note,
+		DateCreated:  currentTime,
+		DateModified: currentTime,
+		Version:      "v1",
+	}
+
+	err = s.repo.AddAuditor(ctx, auditor)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, s.eventArgs(ctx, events.AuditorAdded, scopeType, scopeID, &events.AuditorAddedEventData{
+		AuditorLFUsername: auditor.LfUsername,
+		ScopeType:         scopeType,
+		ScopeID:           scopeID,
+	}))
+
+	return auditor.toModel(), nil
+}
+
+// GetAuditors returns the list of auditors assigned to the specified foundation or CLA Group
+func (s *Service) GetAuditors(ctx context.Context, scopeType, scopeID string) (*models.AuditorList, error) {
+	auditors, err := s.repo.GetAuditorsByScopeID(ctx, scopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.AuditorList{
+		List: []*models.Auditor{},
+	}
+	for _, auditor := range auditors {
+		if auditor.ScopeType == scopeType {
+			response.List = append(response.List, auditor.toModel())
+		}
+	}
+
+	return response, nil
+}
+
+// DeleteAuditor removes the auditor assignment from the specified foundation or CLA Group
+func (s *Service) DeleteAuditor(ctx context.Context, scopeType, scopeID, auditorID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.auditors.service.DeleteAuditor",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scopeType":      scopeType,
+		"scopeID":        scopeID,
+		"auditorID":      auditorID,
+	}
+
+	auditor, err := s.repo.GetAuditor(ctx, auditorID)
+	if err != nil {
+		return err
+	}
+	// Don't allow removal of an assignment through a different scope
+	if auditor == nil || !auditor.matchesScope(scopeType, scopeID) {
+		log.WithFields(f).Warn("auditor assignment not found for scope")
+		return ErrAuditorNotFound
+	}
+
+	err = s.repo.DeleteAuditor(ctx, auditorID)
+	if err != nil {
+		return err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, s.eventArgs(ctx, events.AuditorDeleted, scopeType, scopeID, &events.AuditorDeletedEventData{
+		AuditorLFUsername: auditor.LfUsername,
+		ScopeType:         scopeType,
+		ScopeID:           scopeID,
+	}))
+
+	return nil
+}
+
+// IsCLAGroupAuditor returns true if the user is an auditor for the CLA Group or for the foundation of the CLA Group
+func (s *Service) IsCLAGroupAuditor(ctx context.Context, lfUsername, claGroupID string) bool {
+	f := logrus.Fields{
+		"functionName":   "v2.auditors.service.IsCLAGroupAuditor",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"lfUsername":     lfUsername,
+		"claGroupID":     claGroupID,
+	}
+
+	assignments, err := s.loadAssignments(ctx, lfUsername)
+	if err != nil || len(assignments) == 0 {
+		return false
+	}
+
+	for _, auditor := range assignments {
+		if auditor.matchesScope(ScopeTypeCLAGroup, claGroupID) {
+			log.WithFields(f).Debug("user is a CLA Group auditor")
+			return true
+		}
+	}
+
+	projectCLAGroup, err := s.projectsClaGroupsRepo.GetCLAGroup(ctx, claGroupID)
+	if err != nil || projectCLAGroup == nil {
+		log.WithFields(f).WithError(err).Debug("unable to load the foundation for the CLA Group")
+		return false
+	}
+
+	for _, auditor := range assignments {
+		if auditor.matchesScope(ScopeTypeFoundation, projectCLAGroup.FoundationSFID) {
+			log.WithFields(f).Debugf("user is a foundation auditor for foundation: %s", projectCLAGroup.FoundationSFID)
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsFoundationAuditor returns true if the user is an auditor for the foundation
+func (s *Service) IsFoundationAuditor(ctx context.Context, lfUsername, foundationSFID string) bool {
+	assignments, err := s.loadAssignments(ctx, lfUsername)
+	if err != nil {
+		return false
+	}
+
+	for _, auditor := range assignments {
+		if auditor.matchesScope(ScopeTypeFoundation, foundationSFID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LogAuditorAccess records an event for each read access granted through the auditor role
+func (s *Service) LogAuditorAccess(ctx context.Context, lfUsername, scopeType, scopeID, resource string) {
+	args := s.eventArgs(ctx, events.AuditorAccessed, scopeType, scopeID, &events.AuditorAccessedEventData{
+		Resource:  resource,
+		ScopeType: scopeType,
+		ScopeID:   scopeID,
+	})
+	args.LfUsername = lfUsername
+	s.eventsService.LogEventWithContext(ctx, args)
+}
+
+// loadAssignments loads the auditor assignments for the user, if any
+func (s *Service) loadAssignments(ctx context.Context, lfUsername string) ([]*DBAuditorModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.auditors.service.loadAssignments",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"lfUsername":     lfUsername,
+	}
+
+	if lfUsername == "" {
+		return nil, nil
+	}
+
+	assignments, err := s.repo.GetAuditorsByLFUsername(ctx, lfUsername)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading auditor assignments")
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// eventArgs builds the event arguments for the specified scope
+func (s *Service) eventArgs(ctx context.Context, eventType, scopeType, scopeID string, eventData events.EventData) *events.LogEventArgs {
+	args := &events.LogEventArgs{
+		EventType:  eventType,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData:  eventData,
+	}
+
+	switch scopeType {
+	case ScopeTypeCLAGroup:
+		args.CLAGroupID = scopeID
+	case ScopeTypeFoundation:
+		args.ProjectSFID = scopeID
+	default:
+		log.Warnf("unsupported auditor scope type: %s", scopeType)
+	}
+
+	return args
+}
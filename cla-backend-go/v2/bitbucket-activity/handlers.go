@@ -0,0 +1,92 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_activity
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	bitbucket "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/bitbucket_activity"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bitbucket_organizations"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type payloadContextKey struct{}
+
+// payloadMiddleware keeps the raw payload of the webhook deliveries in the request context, the signature is computed
+// over the bytes Bitbucket sent
+func payloadMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "unable to read the request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewBuffer(payload))
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), payloadContextKey{}, payload)))
+	})
+}
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, bitbucketOrgService bitbucket_organizations.ServiceInterface) {
+
+	api.BitbucketActivityBitbucketActivityHandler = bitbucket_activity.BitbucketActivityHandlerFunc(func(params bitbucket_activity.BitbucketActivityParams) middleware.Responder {
+		requestID, _ := uuid.NewV4()
+		reqID := requestID.String()
+		eventKey := utils.StringValue(params.XEventKey)
+		f := logrus.Fields{
+			"functionName":   "v2.bitbucket-activity.handlers.BitbucketActivityBitbucketActivityHandler",
+			"requestID":      reqID,
+			"eventKey":       eventKey,
+			"organizationID": params.OrganizationID,
+		}
+		log.WithFields(f).Debug("handling bitbucket activity callback")
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID)
+
+		org, err := bitbucketOrgService.GetBitbucketOrganization(ctx, params.OrganizationID)
+		if err != nil || org == nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the bitbucket organization of the webhook")
+			return bitbucket_activity.NewBitbucketActivityForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, "unknown bitbucket organization"))
+		}
+
+		// the deliveries are only trusted when signed with the secret of the organization
+		payload, _ := params.HTTPRequest.Context().Value(payloadContextKey{}).([]byte)
+		secret, err := bitbucketOrgService.GetWebhookSecret(org)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to decrypt the webhook secret of the bitbucket organization")
+			return bitbucket_activity.NewBitbucketActivityInternalServerError().WithPayload(utils.ErrorResponseInternalServerError(reqID, "unable to validate the webhook signature"))
+		}
+		if err := bitbucket.ValidateSignature(payload, utils.StringValue(params.XHubSignature), secret); err != nil {
+			log.WithFields(f).WithError(err).Warn("invalid bitbucket webhook signature")
+			return bitbucket_activity.NewBitbucketActivityUnauthorized().WithPayload(utils.ErrorResponseUnauthorized(reqID, err.Error()))
+		}
+
+		if eventKey == bitbucket.DataCenterPingEvent {
+			return bitbucket_activity.NewBitbucketActivityOK()
+		}
+
+		// As for the GitLab activity, we respond with 200 once the delivery is authenticated - Bitbucket disables the
+		// webhooks failing repeatedly and the check runs again on the next update or /easycla comment
+		event, err := bitbucket.ParsePullRequestEvent(eventKey, payload)
+		if err != nil {
+			log.WithFields(f).WithError(err).Debug("ignoring bitbucket event")
+			return bitbucket_activity.NewBitbucketActivityOK()
+		}
+
+		if err := service.ProcessPullRequestActivity(ctx, org, event); err != nil {
+			log.WithFields(f).WithError(err).Warn("processing bitbucket pull request event failed")
+		}
+		return bitbucket_activity.NewBitbucketActivityOK()
+	})
+
+	api.AddMiddlewareFor("POST", "/bitbucket/activity", payloadMiddleware)
+}
@@ -0,0 +1,380 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	bitbucket "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bitbucket_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bot_allowlist"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_evaluation"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_group_settings"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/comment_templates"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	missingID                 = errors.New("user missing in easyCLA records")
+	missingCompanyAffiliation = errors.New("must confirm affiliation with their company")
+	missingCompanyApproval    = errors.New("missing in company approval lists")
+)
+
+// status descriptions of the EasyCLA build status
+const (
+	missingCLAMsg = "Missing CLA Authorization"
+	signedCLAMsg  = "EasyCLA check passed. You are authorized to contribute."
+)
+
+// gatedAuthor is a commit author failing the CLA check
+type gatedAuthor struct {
+	*bitbucket.CommitAuthor
+	err error
+}
+
+// exemptAuthor is a commit author exempted from the CLA check by the bot allowlist
+type exemptAuthor struct {
+	*bitbucket.CommitAuthor
+	reason string
+}
+
+// Service handles the Bitbucket pull request activity
+type Service interface {
+	ProcessPullRequestActivity(ctx context.Context, org *common.BitbucketOrganization, event *bitbucket.PullRequestEvent) error
+}
+
+type service struct {
+	bitbucketOrgService         bitbucket_organizations.ServiceInterface
+	repositoriesService         repositories.ServiceInterface
+	usersRepository             users.UserRepository
+	signaturesRepository        signatures.SignatureRepository
+	projectsCLAGroupsRepository projects_cla_groups.Repository
+	companyRepository           company.IRepository
+	botAllowlistService         bot_allowlist.ServiceInterface
+	claGroupSettingsService     cla_group_settings.ServiceInterface
+	evaluator                   *cla_evaluation.Evaluator
+}
+
+// NewService creates a new Bitbucket activity service
+func NewService(bitbucketOrgService bitbucket_organizations.ServiceInterface, repositoriesService repositories.ServiceInterface, usersRepository users.UserRepository, signaturesRepository signatures.SignatureRepository,
+	projectsCLAGroupsRepository projects_cla_groups.Repository, companyRepository company.IRepository, botAllowlistService bot_allowlist.ServiceInterface, claGroupSettingsService cla_group_settings.ServiceInterface) Service {
+	return &service{
+		bitbucketOrgService:         bitbucketOrgService,
+		repositoriesService:         repositoriesService,
+		usersRepository:             usersRepository,
+		signaturesRepository:        signaturesRepository,
+		projectsCLAGroupsRepository: projectsCLAGroupsRepository,
+		companyRepository:           companyRepository,
+		botAllowlistService:         botAllowlistService,
+		claGroupSettingsService:     claGroupSettingsService,
+		// the GitHub organization and GitLab group approval lists are not checked for the Bitbucket authors
+		evaluator: cla_evaluation.NewEvaluator(usersRepository, signaturesRepository, nil, nil),
+	}
+}
+
+// ProcessPullRequestActivity checks the commit authors of the pull request, then reports the EasyCLA build status on
+// the head commit and comments on the pull request with the sign link
+func (s *service) ProcessPullRequestActivity(ctx context.Context, org *common.BitbucketOrganization, event *bitbucket.PullRequestEvent) error {
+	f := logrus.Fields{
+		"functionName":         "v2.bitbucket-activity.service.ProcessPullRequestActivity",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"organizationKey":      org.OrganizationKey,
+		"eventKey":             event.EventKey,
+		"repository":           fmt.Sprintf("%s/%s", event.Owner, event.Slug),
+		"repositoryExternalID": event.RepositoryExternalID,
+		"pullRequestID":        event.PullRequestID,
+	}
+
+	if bitbucket.IsCommentEvent(event.EventKey) && !strings.Contains(event.Comment, "/easycla") {
+		log.WithFields(f).Debug("pull request comment doesn't request an EasyCLA check - ignoring")
+		return nil
+	}
+
+	repo, err := s.repositoriesService.BitbucketGetRepositoryByExternalID(ctx, org.OrganizationKey, event.RepositoryExternalID)
+	if err != nil {
+		return fmt.Errorf("loading the bitbucket repository : %s failed : %v", event.RepositoryExternalID, err)
+	}
+	if repo == nil || !repo.Enabled || repo.RepositoryClaGroupID == "" {
+		log.WithFields(f).Debug("bitbucket repository is not enrolled to a CLA group - ignoring")
+		return nil
+	}
+	claGroupID := repo.RepositoryClaGroupID
+	f["claGroupID"] = claGroupID
+
+	client, err := s.bitbucketOrgService.GetBitbucketOrganizationClient(org)
+	if err != nil {
+		return fmt.Errorf("initializing bitbucket client : %v", err)
+	}
+
+	headCommit := event.HeadCommit
+	if headCommit == "" {
+		pullRequest, prErr := client.GetPullRequest(event.Owner, event.Slug, event.PullRequestID)
+		if prErr != nil {
+			return fmt.Errorf("loading the bitbucket pull request : %d failed : %v", event.PullRequestID, prErr)
+		}
+		headCommit = pullRequest.HeadCommit
+	}
+	f["headCommit"] = headCommit
+
+	authors, err := client.GetPullRequestCommitAuthors(event.Owner, event.Slug, event.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("loading the commit authors of the bitbucket pull request : %d failed : %v", event.PullRequestID, err)
+	}
+	authors = uniqueAuthors(authors)
+	log.WithFields(f).Debugf("found %d commit authors for the pull request", len(authors))
+
+	claGroupName := claGroupID
+	if claGroup, claGroupErr := s.projectsCLAGroupsRepository.GetClaGroupIDForProject(ctx, repo.RepositoryProjectSfid); claGroupErr == nil && claGroup != nil {
+		claGroupName = claGroup.ClaGroupName
+	}
+
+	// bots and service accounts on the allowlist of the repository, CLA Group or foundation are exempt
+	var allowlist *bot_allowlist.Matcher
+	if s.botAllowlistService != nil {
+		var allowlistErr error
+		allowlist, allowlistErr = s.botAllowlistService.GetMatcher(ctx, claGroupID, repo.RepositoryID)
+		if allowlistErr != nil {
+			log.WithFields(f).WithError(allowlistErr).Warn("unable to load the bot allowlist - no authors are exempt")
+		}
+	}
+
+	identities := make([]cla_evaluation.Identity, len(authors))
+	for i, author := range authors {
+		identities[i] = cla_evaluation.Identity{
+			Provider:   utils.BitbucketLower,
+			ExternalID: author.AccountID,
+			Username:   author.Username,
+			Email:      author.Email,
+			Name:       author.Name,
+			// Bitbucket only links the commit to the account when the commit email is a verified email of the account
+			EmailVerified: author.AccountID != "",
+		}
+	}
+	verdicts := s.evaluator.Evaluate(ctx, claGroupID, identities, allowlist)
+
+	var missingAuthors []*gatedAuthor
+	var signedAuthors []*bitbucket.CommitAuthor
+	var exemptAuthors []*exemptAuthor
+	for i, verdict := range verdicts {
+		author := authors[i]
+		switch {
+		case verdict.Exempt():
+			log.WithFields(f).Debugf("Bitbucket author: %s <%s> is exempt by the %s allowlist entry: %s", author.Name, author.Email, verdict.ExemptEntry.ScopeType, verdict.ExemptEntry.EntryID)
+			exemptAuthors = append(exemptAuthors, &exemptAuthor{CommitAuthor: author, reason: verdict.ExemptReason()})
+			s.botAllowlistService.LogExemption(ctx, &bot_allowlist.Exemption{
+				CLAGroupID:      claGroupID,
+				Provider:        utils.BitbucketLower,
+				RepositoryName:  repo.RepositoryName,
+				ChangeRequestID: event.PullRequestID,
+				Username:        author.Username,
+				Email:           author.Email,
+				Entry:           verdict.ExemptEntry,
+			})
+		case verdict.Authorized:
+			log.WithFields(f).Infof("Bitbucket author: %s <%s> has signed - %s", author.Name, author.Email, verdict.Reason)
+			signedAuthors = append(signedAuthors, author)
+		default:
+			log.WithFields(f).Infof("Bitbucket author: %s <%s> has NOT signed - %s", author.Name, author.Email, verdict.Reason)
+			missingAuthors = append(missingAuthors, &gatedAuthor{CommitAuthor: author, err: verdictError(verdict)})
+		}
+	}
+
+	signURL := GetFullSignURL(org.OrganizationID, repo.RepositoryID, event.PullRequestID)
+	comment := s.getCommentContent(ctx, claGroupID, claGroupName, missingAuthors, signedAuthors, exemptAuthors, signURL)
+
+	state, description, targetURL := bitbucket.BuildStatusSuccessful, signedCLAMsg, config.GetConfig().CLALandingPage
+	if len(missingAuthors) > 0 {
+		log.WithFields(f).Warnf("pull request failed with %d authors not passing authorization", len(missingAuthors))
+		state, description, targetURL = bitbucket.BuildStatusFailed, missingCLAMsg, signURL
+	}
+
+	if err := client.SetBuildStatus(event.Owner, event.Slug, headCommit, state, targetURL, description); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem setting the EasyCLA build status")
+		return fmt.Errorf("setting build status failed : %v", err)
+	}
+	if err := client.UpsertPullRequestComment(event.Owner, event.Slug, event.PullRequestID, comment); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem setting the pull request comment")
+		return fmt.Errorf("setting comment failed : %v", err)
+	}
+
+	return nil
+}
+
+// GetFullSignURL returns the sign link of the pull request
+func GetFullSignURL(organizationID, repositoryID string, pullRequestID int) string {
+	return fmt.Sprintf("%s/v4/repository-provider/%s/sign/%s/%s/%d/#/",
+		config.GetConfig().ClaAPIV4Base,
+		utils.BitbucketLower,
+		organizationID,
+		repositoryID,
+		pullRequestID,
+	)
+}
+
+// uniqueAuthors removes the duplicate commit authors - the same email or Bitbucket account
+func uniqueAuthors(authors []*bitbucket.CommitAuthor) []*bitbucket.CommitAuthor {
+	seen := make(map[string]bool)
+	var result []*bitbucket.CommitAuthor
+	for _, author := range authors {
+		key := strings.ToLower(author.Email)
+		if author.AccountID != "" {
+			key = author.AccountID
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, author)
+	}
+	return result
+}
+
+// getCommentContent renders the pull request comment with the comment template of the CLA Group, falling back to the
+// default comment
+func (s *service) getCommentContent(ctx context.Context, claGroupID, claGroupName string, missingAuthors []*gatedAuthor, signedAuthors []*bitbucket.CommitAuthor, exemptAuthors []*exemptAuthor, signURL string) string {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket-activity.service.getCommentContent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	if s.claGroupSettingsService != nil {
+		tmpl, err := s.claGroupSettingsService.GetCommentTemplate(ctx, claGroupID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the comment template - using the default comment")
+		} else if tmpl != nil {
+			comment, renderErr := RenderCommentTemplate(tmpl, claGroupName, missingAuthors, signedAuthors, exemptAuthors, signURL)
+			if renderErr == nil {
+				return comment
+			}
+			log.WithFields(f).WithError(renderErr).Warn("unable to render the comment template - using the default comment")
+		}
+	}
+
+	return PrepareCommentContent(missingAuthors, signedAuthors, exemptAuthors, signURL)
+}
+
+// RenderCommentTemplate renders the pull request comment with the comment template of the CLA Group
+func RenderCommentTemplate(tmpl *comment_templates.Template, claGroupName string, missingAuthors []*gatedAuthor, signedAuthors []*bitbucket.CommitAuthor, exemptAuthors []*exemptAuthor, signURL string) (string, error) {
+	landingPage := config.GetConfig().CLALandingPage + "/#/?version=2"
+	data := &comment_templates.Data{
+		Provider:    utils.BitbucketLower,
+		ProjectName: claGroupName,
+		SignURL:     signURL,
+		HelpURL:     "https://support.atlassian.com/bitbucket-cloud/docs/configure-your-dvcs-username-for-commits/",
+		SupportURL:  "https://jira.linuxfoundation.org/servicedesk/customer/portal/4",
+		LandingPage: landingPage,
+	}
+
+	badgeLink := landingPage
+	data.BadgeURL = fmt.Sprintf("%s/cla-signed.svg", config.GetConfig().CLALogoURL)
+	data.BadgeAlt = "CLA Signed"
+	if len(missingAuthors) > 0 {
+		badgeLink = signURL
+		data.BadgeURL = fmt.Sprintf("%s/cla-not-signed.svg", config.GetConfig().CLALogoURL)
+		data.BadgeAlt = "CLA Not Signed"
+	}
+
+	for _, signed := range signedAuthors {
+		data.Signed = append(data.Signed, templateAuthor(signed, ""))
+	}
+	for _, exempt := range exemptAuthors {
+		data.Exempt = append(data.Exempt, templateAuthor(exempt.CommitAuthor, exempt.reason))
+	}
+	for _, missing := range missingAuthors {
+		reason := comment_templates.ReasonNotSigned
+		if errors.Is(missing.err, missingID) {
+			reason = comment_templates.ReasonMissingID
+		} else if errors.Is(missing.err, missingCompanyAffiliation) {
+			reason = comment_templates.ReasonConfirmAffiliation
+			data.BadgeURL = fmt.Sprintf("%s/cla-confirmation-needed.svg", config.GetConfig().CLALogoURL)
+			data.BadgeAlt = "CLA Confirmation Needed"
+		}
+		data.Missing = append(data.Missing, templateAuthor(missing.CommitAuthor, reason))
+	}
+	// Bitbucket renders markdown, not html
+	data.Badge = fmt.Sprintf("[![%s](%s)](%s)", data.BadgeAlt, data.BadgeURL, badgeLink)
+
+	return comment_templates.Render(tmpl, data)
+}
+
+func templateAuthor(author *bitbucket.CommitAuthor, reason string) *comment_templates.Author {
+	return &comment_templates.Author{
+		Name:   author.Name,
+		Login:  author.Username,
+		Email:  author.Email,
+		Reason: reason,
+	}
+}
+
+// PrepareCommentContent builds the markdown pull request comment listing the signed, exempt and missing commit authors
+func PrepareCommentContent(missingAuthors []*gatedAuthor, signedAuthors []*bitbucket.CommitAuthor, exemptAuthors []*exemptAuthor, signURL string) string {
+	landingPage := config.GetConfig().CLALandingPage + "/#/?version=2"
+	easyCLASupportURL := "https://jira.linuxfoundation.org/servicedesk/customer/portal/4"
+
+	badgeLink, badge, badgeAlt := landingPage, "cla-signed.svg", "CLA Signed"
+	if len(missingAuthors) > 0 {
+		badgeLink, badge, badgeAlt = signURL, "cla-not-signed.svg", "CLA Not Signed"
+	}
+
+	var lines []string
+	for _, signed := range signedAuthors {
+		lines = append(lines, fmt.Sprintf("* :white_check_mark: %s", getAuthorInfo(signed)))
+	}
+	for _, exempt := range exemptAuthors {
+		lines = append(lines, fmt.Sprintf("* :white_check_mark: %s - exempt: %s", getAuthorInfo(exempt.CommitAuthor), exempt.reason))
+	}
+	for _, missing := range missingAuthors {
+		if errors.Is(missing.err, missingCompanyAffiliation) {
+			badge, badgeAlt = "cla-confirmation-needed.svg", "CLA Confirmation Needed"
+			lines = append(lines, fmt.Sprintf("* :x: %s. This user is authorized, but they must confirm their affiliation with their company. "+
+				"Start the authorization process [by clicking here](%s), click \"Corporate\", select the appropriate company from the list, "+
+				"then confirm your affiliation on the page that appears.", getAuthorInfo(missing.CommitAuthor), signURL))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("* :x: %s. The commit is not authorized under a signed CLA. [Please click here to be authorized](%s).",
+			getAuthorInfo(missing.CommitAuthor), signURL))
+	}
+
+	body := fmt.Sprintf("[![%s](https://s3.amazonaws.com/cla-project-logo-dev/%s)](%s)", badgeAlt, badge, badgeLink)
+	if len(lines) > 0 {
+		body += "\n\n" + strings.Join(lines, "\n")
+	}
+	if len(missingAuthors) > 0 {
+		body += fmt.Sprintf("\n\nFor further assistance with EasyCLA, [please submit a support request ticket](%s).", easyCLASupportURL)
+	}
+	return body
+}
+
+func getAuthorInfo(author *bitbucket.CommitAuthor) string {
+	if author.Username != "" {
+		return fmt.Sprintf("login:@%s/name:%s", author.Username, author.Name)
+	}
+	return fmt.Sprintf("email:%s/name:%s", author.Email, author.Name)
+}
+
+// verdictError returns the error reported in the pull request comment for the commit author
+func verdictError(verdict *cla_evaluation.Verdict) error {
+	switch verdict.Reason {
+	case cla_evaluation.ReasonMissingID:
+		return missingID
+	case cla_evaluation.ReasonMissingAffiliation:
+		return missingCompanyAffiliation
+	case cla_evaluation.ReasonNotApproved:
+		return missingCompanyApproval
+	}
+	return verdict.Err
+}
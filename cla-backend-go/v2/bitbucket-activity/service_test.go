@@ -0,0 +1,32 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_activity
+
+import (
+	"strings"
+	"testing"
+
+	bitbucket "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareCommentContent(t *testing.T) {
+	signed := []*bitbucket.CommitAuthor{{Name: "Jane Doe", Username: "jdoe", Email: "jane@example.org"}}
+	missing := []*gatedAuthor{
+		{CommitAuthor: &bitbucket.CommitAuthor{Name: "John Roe", Email: "john@example.org"}, err: missingID},
+		{CommitAuthor: &bitbucket.CommitAuthor{Name: "Ann Poe", Email: "ann@example.org"}, err: missingCompanyAffiliation},
+	}
+	exempt := []*exemptAuthor{{CommitAuthor: &bitbucket.CommitAuthor{Name: "ci", Email: "ci@example.org"}, reason: "build bot"}}
+
+	comment := PrepareCommentContent(missing, signed, exempt, "https://sign")
+	assert.True(t, strings.HasPrefix(comment, "[![CLA Confirmation Needed]"))
+	assert.Contains(t, comment, "* :white_check_mark: login:@jdoe/name:Jane Doe")
+	assert.Contains(t, comment, "exempt: build bot")
+	assert.Contains(t, comment, "* :x: email:john@example.org/name:John Roe. The commit is not authorized under a signed CLA. [Please click here to be authorized](https://sign).")
+	assert.Contains(t, comment, "must confirm their affiliation")
+
+	comment = PrepareCommentContent(nil, signed, nil, "https://sign")
+	assert.True(t, strings.HasPrefix(comment, "[![CLA Signed]"))
+	assert.NotContains(t, comment, ":x:")
+}
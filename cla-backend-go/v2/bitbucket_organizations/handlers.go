@@ -0,0 +1,175 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/bitbucket_organizations"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	project_service "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service ServiceInterface) { // nolint
+
+	api.BitbucketOrganizationsGetProjectBitbucketOrganizationsHandler = bitbucket_organizations.GetProjectBitbucketOrganizationsHandlerFunc(
+		func(params bitbucket_organizations.GetProjectBitbucketOrganizationsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.bitbucket_organizations.handlers.BitbucketOrganizationsGetProjectBitbucketOrganizationsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"projectSFID":    params.ProjectSFID,
+			}
+
+			if msg, found := checkProjectAccess(ctx, authUser, params.ProjectSFID, "get the Bitbucket organizations"); msg != "" {
+				log.WithFields(f).Debug(msg)
+				if !found {
+					return bitbucket_organizations.NewGetProjectBitbucketOrganizationsNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				return bitbucket_organizations.NewGetProjectBitbucketOrganizationsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetBitbucketOrganizationsByProjectSFID(ctx, params.ProjectSFID)
+			if err != nil {
+				msg := fmt.Sprintf("problem loading the Bitbucket organizations of the project: %s", params.ProjectSFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bitbucket_organizations.NewGetProjectBitbucketOrganizationsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return bitbucket_organizations.NewGetProjectBitbucketOrganizationsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.BitbucketOrganizationsAddProjectBitbucketOrganizationHandler = bitbucket_organizations.AddProjectBitbucketOrganizationHandlerFunc(
+		func(params bitbucket_organizations.AddProjectBitbucketOrganizationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":     "v2.bitbucket_organizations.handlers.BitbucketOrganizationsAddProjectBitbucketOrganizationHandler",
+				utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+				"authUser":         authUser.UserName,
+				"projectSFID":      params.ProjectSFID,
+				"flavor":           utils.StringValue(params.Body.Flavor),
+				"organizationName": utils.StringValue(params.Body.OrganizationName),
+			}
+
+			if msg, found := checkProjectAccess(ctx, authUser, params.ProjectSFID, "add a Bitbucket organization"); msg != "" {
+				log.WithFields(f).Debug(msg)
+				if !found {
+					return bitbucket_organizations.NewAddProjectBitbucketOrganizationNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				return bitbucket_organizations.NewAddProjectBitbucketOrganizationForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.AddBitbucketOrganization(ctx, params.ProjectSFID, params.Body)
+			if err != nil {
+				if errors.Is(err, ErrOrganizationExists) {
+					msg := fmt.Sprintf("the Bitbucket organization %s is already registered", utils.StringValue(params.Body.OrganizationName))
+					return bitbucket_organizations.NewAddProjectBitbucketOrganizationConflict().WithXRequestID(reqID).WithPayload(utils.ErrorResponseConflict(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem adding the Bitbucket organization %s", utils.StringValue(params.Body.OrganizationName))
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bitbucket_organizations.NewAddProjectBitbucketOrganizationBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return bitbucket_organizations.NewAddProjectBitbucketOrganizationOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.BitbucketOrganizationsUpdateProjectBitbucketOrganizationHandler = bitbucket_organizations.UpdateProjectBitbucketOrganizationHandlerFunc(
+		func(params bitbucket_organizations.UpdateProjectBitbucketOrganizationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":            "v2.bitbucket_organizations.handlers.BitbucketOrganizationsUpdateProjectBitbucketOrganizationHandler",
+				utils.XREQUESTID:          ctx.Value(utils.XREQUESTID),
+				"authUser":                authUser.UserName,
+				"projectSFID":             params.ProjectSFID,
+				"bitbucketOrganizationID": params.BitbucketOrganizationID,
+			}
+
+			if msg, found := checkProjectAccess(ctx, authUser, params.ProjectSFID, "update a Bitbucket organization"); msg != "" {
+				log.WithFields(f).Debug(msg)
+				if !found {
+					return bitbucket_organizations.NewUpdateProjectBitbucketOrganizationNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				return bitbucket_organizations.NewUpdateProjectBitbucketOrganizationForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.UpdateBitbucketOrganization(ctx, params.ProjectSFID, params.BitbucketOrganizationID, params.Body)
+			if err != nil {
+				if errors.Is(err, ErrOrganizationNotFound) {
+					msg := fmt.Sprintf("the Bitbucket organization %s was not found", params.BitbucketOrganizationID)
+					return bitbucket_organizations.NewUpdateProjectBitbucketOrganizationNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem updating the Bitbucket organization %s", params.BitbucketOrganizationID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bitbucket_organizations.NewUpdateProjectBitbucketOrganizationBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return bitbucket_organizations.NewUpdateProjectBitbucketOrganizationOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.BitbucketOrganizationsDeleteProjectBitbucketOrganizationHandler = bitbucket_organizations.DeleteProjectBitbucketOrganizationHandlerFunc(
+		func(params bitbucket_organizations.DeleteProjectBitbucketOrganizationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":            "v2.bitbucket_organizations.handlers.BitbucketOrganizationsDeleteProjectBitbucketOrganizationHandler",
+				utils.XREQUESTID:          ctx.Value(utils.XREQUESTID),
+				"authUser":                authUser.UserName,
+				"projectSFID":             params.ProjectSFID,
+				"bitbucketOrganizationID": params.BitbucketOrganizationID,
+			}
+
+			if msg, found := checkProjectAccess(ctx, authUser, params.ProjectSFID, "delete a Bitbucket organization"); msg != "" {
+				log.WithFields(f).Debug(msg)
+				if !found {
+					return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err := service.DeleteBitbucketOrganization(ctx, params.ProjectSFID, params.BitbucketOrganizationID)
+			if err != nil {
+				if errors.Is(err, ErrOrganizationNotFound) {
+					msg := fmt.Sprintf("the Bitbucket organization %s was not found", params.BitbucketOrganizationID)
+					return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem deleting the Bitbucket organization %s", params.BitbucketOrganizationID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationNoContent().WithXRequestID(reqID)
+		})
+}
+
+// checkProjectAccess returns an error message when the project doesn't exist (found is false) or the user isn't
+// allowed to manage it, an empty message otherwise
+func checkProjectAccess(ctx context.Context, authUser *auth.User, projectSFID, action string) (msg string, found bool) {
+	psc := project_service.GetClient()
+	projectModel, err := psc.GetProject(projectSFID)
+	if err != nil || projectModel == nil {
+		return fmt.Sprintf("unable to locate project with ID: %s", projectSFID), false
+	}
+
+	if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, projectSFID, utils.ALLOW_ADMIN_SCOPE) {
+		return fmt.Sprintf("user %s does not have access to %s for Project %s with scope of %s",
+			authUser.UserName, action, projectModel.Name, projectSFID), true
+	}
+	return "", true
+}
@@ -0,0 +1,362 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_organizations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/sirupsen/logrus"
+)
+
+// table columns and indexes
+const (
+	// OrganizationIDColumn is the primary key of the Bitbucket organizations table
+	OrganizationIDColumn = "organization_id"
+	// OrganizationKeyColumn is the unique key of the Bitbucket organization - the host followed by the workspace or project key
+	OrganizationKeyColumn = "organization_key"
+	// ProjectSFIDColumn is the project SFID column
+	ProjectSFIDColumn = "project_sfid"
+	// AccessTokenColumn is the encrypted access token column
+	AccessTokenColumn = "access_token"
+	// WebhookSecretColumn is the encrypted webhook secret column
+	WebhookSecretColumn = "webhook_secret"
+	// AppClientSecretColumn is the encrypted client secret of the Bitbucket Data Center application link
+	AppClientSecretColumn = "app_client_secret"
+	// DateModifiedColumn is the date modified column
+	DateModifiedColumn = "date_modified"
+
+	// BitbucketOrgProjectSFIDIndex the index for the project SFID
+	BitbucketOrgProjectSFIDIndex = "bitbucket-project-sfid-index"
+	// BitbucketOrgKeyIndex the index for the organization key
+	BitbucketOrgKeyIndex = "bitbucket-organization-key-index"
+)
+
+// RepositoryInterface defines the Bitbucket organizations data access functions
+type RepositoryInterface interface {
+	AddBitbucketOrganization(ctx context.Context, org *common.BitbucketOrganization) error
+	GetBitbucketOrganization(ctx context.Context, organizationID string) (*common.BitbucketOrganization, error)
+	GetBitbucketOrganizationByKey(ctx context.Context, organizationKey string) (*common.BitbucketOrganization, error)
+	GetBitbucketOrganizationsByProjectSFID(ctx context.Context, projectSFID string) ([]*common.BitbucketOrganization, error)
+	GetBitbucketOrganizations(ctx context.Context) ([]*common.BitbucketOrganization, error)
+	UpdateBitbucketOrganization(ctx context.Context, org *common.BitbucketOrganization) error
+	UpdateBitbucketOrganizationSecrets(ctx context.Context, organizationID string, current, updated OrganizationSecrets) error
+	DeleteBitbucketOrganization(ctx context.Context, organizationID string) error
+}
+
+// Repository object/struct
+type Repository struct {
+	stage                 string
+	dynamoDBClient        *dynamodb.DynamoDB
+	bitbucketOrgTableName string
+}
+
+// NewRepository creates a new instance of the Bitbucket organizations repository
+func NewRepository(awsSession *session.Session, stage string) RepositoryInterface {
+	return &Repository{
+		stage:                 stage,
+		dynamoDBClient:        dynamodb.New(awsSession),
+		bitbucketOrgTableName: fmt.Sprintf("cla-%s-bitbucket-orgs", stage),
+	}
+}
+
+// AddBitbucketOrganization adds the Bitbucket organization to the database
+func (repo *Repository) AddBitbucketOrganization(ctx context.Context, org *common.BitbucketOrganization) error {
+	f := logrus.Fields{
+		"functionName":    "v2.bitbucket_organizations.repository.AddBitbucketOrganization",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"organizationID":  org.OrganizationID,
+		"organizationKey": org.OrganizationKey,
+		"projectSFID":     org.ProjectSFID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(org)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall Bitbucket organization record")
+		return err
+	}
+
+	log.WithFields(f).Debug("adding Bitbucket organization record to the database...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.bitbucketOrgTableName),
+		ConditionExpression: aws.String("attribute_not_exists(organization_id)"),
+	})
+	if err != nil {
+		if aErr, ok := err.(awserr.Error); ok && aErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrOrganizationExists
+		}
+		log.WithFields(f).WithError(err).Warn("unable to add Bitbucket organization record")
+		return err
+	}
+
+	return nil
+}
+
+// GetBitbucketOrganization returns the Bitbucket organization by ID, nil if not found
+func (repo *Repository) GetBitbucketOrganization(ctx context.Context, organizationID string) (*common.BitbucketOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.repository.GetBitbucketOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			OrganizationIDColumn: {S: aws.String(organizationID)},
+		},
+		TableName: aws.String(repo.bitbucketOrgTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load Bitbucket organization record")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		log.WithFields(f).Debug("unable to find Bitbucket organization record - no results")
+		return nil, nil
+	}
+
+	var org common.BitbucketOrganization
+	err = dynamodbattribute.UnmarshalMap(result.Item, &org)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem decoding Bitbucket organization record")
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+// GetBitbucketOrganizationByKey returns the Bitbucket organization by key, nil if not found
+func (repo *Repository) GetBitbucketOrganizationByKey(ctx context.Context, organizationKey string) (*common.BitbucketOrganization, error) {
+	orgs, err := repo.queryOrganizations(ctx, expression.Key(OrganizationKeyColumn).Equal(expression.Value(organizationKey)), BitbucketOrgKeyIndex)
+	if err != nil {
+		return nil, err
+	}
+	if len(orgs) == 0 {
+		return nil, nil
+	}
+	return orgs[0], nil
+}
+
+// GetBitbucketOrganizationsByProjectSFID returns the Bitbucket organizations of the project
+func (repo *Repository) GetBitbucketOrganizationsByProjectSFID(ctx context.Context, projectSFID string) ([]*common.BitbucketOrganization, error) {
+	return repo.queryOrganizations(ctx, expression.Key(ProjectSFIDColumn).Equal(expression.Value(projectSFID)), BitbucketOrgProjectSFIDIndex)
+}
+
+// GetBitbucketOrganizations returns all the Bitbucket organizations
+func (repo *Repository) GetBitbucketOrganizations(ctx context.Context) ([]*common.BitbucketOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.repository.GetBitbucketOrganizations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		TableName: aws.String(repo.bitbucketOrgTableName),
+	}
+
+	var orgs []*common.BitbucketOrganization
+	for {
+		results, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.WithFields(f).WithError(scanErr).Warn("error scanning Bitbucket organizations table")
+			return nil, scanErr
+		}
+
+		var page []*common.BitbucketOrganization
+		err := dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding Bitbucket organization records")
+			return nil, err
+		}
+		orgs = append(orgs, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return orgs, nil
+}
+
+// UpdateBitbucketOrganization replaces the Bitbucket organization record, fails with ErrOrganizationNotFound if the
+// organization doesn't exist
+func (repo *Repository) UpdateBitbucketOrganization(ctx context.Context, org *common.BitbucketOrganization) error {
+	f := logrus.Fields{
+		"functionName":    "v2.bitbucket_organizations.repository.UpdateBitbucketOrganization",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"organizationID":  org.OrganizationID,
+		"organizationKey": org.OrganizationKey,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(org)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall Bitbucket organization record")
+		return err
+	}
+
+	log.WithFields(f).Debug("updating Bitbucket organization record...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.bitbucketOrgTableName),
+		ConditionExpression: aws.String("attribute_exists(organization_id)"),
+	})
+	if err != nil {
+		if aErr, ok := err.(awserr.Error); ok && aErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrOrganizationNotFound
+		}
+		log.WithFields(f).WithError(err).Warn("unable to update Bitbucket organization record")
+		return err
+	}
+
+	return nil
+}
+
+// OrganizationSecrets are the encrypted secrets of a Bitbucket organization
+type OrganizationSecrets struct {
+	AccessToken     string
+	WebhookSecret   string
+	AppClientSecret string
+}
+
+// UpdateBitbucketOrganizationSecrets replaces the encrypted secrets of the Bitbucket organization which changed. The
+// update fails with a conditional check error when a secret was updated since it was read, such as by a new access
+// token.
+func (repo *Repository) UpdateBitbucketOrganizationSecrets(ctx context.Context, organizationID string, current, updated OrganizationSecrets) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.repository.UpdateBitbucketOrganizationSecrets",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{
+		"#M": aws.String(DateModifiedColumn),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":m": {S: aws.String(currentTime)},
+	}
+	updateExpression := "SET #M = :m"
+	conditionExpression := "attribute_exists(organization_id)"
+
+	columns := []struct {
+		name, column, current, updated string
+	}{
+		{"T", AccessTokenColumn, current.AccessToken, updated.AccessToken},
+		{"S", WebhookSecretColumn, current.WebhookSecret, updated.WebhookSecret},
+		{"C", AppClientSecretColumn, current.AppClientSecret, updated.AppClientSecret},
+	}
+	for _, c := range columns {
+		if c.updated == "" || c.updated == c.current {
+			continue
+		}
+		expressionAttributeNames["#"+c.name] = aws.String(c.column)
+		expressionAttributeValues[":"+strings.ToLower(c.name)] = &dynamodb.AttributeValue{S: aws.String(c.updated)}
+		expressionAttributeValues[":o"+strings.ToLower(c.name)] = &dynamodb.AttributeValue{S: aws.String(c.current)}
+		updateExpression = fmt.Sprintf("%s, #%s = :%s", updateExpression, c.name, strings.ToLower(c.name))
+		conditionExpression = fmt.Sprintf("%s AND #%s = :o%s", conditionExpression, c.name, strings.ToLower(c.name))
+	}
+	if len(expressionAttributeNames) == 1 {
+		log.WithFields(f).Debug("no secret changed - nothing to update")
+		return nil
+	}
+
+	log.WithFields(f).Debug("updating Bitbucket organization secrets...")
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			OrganizationIDColumn: {S: aws.String(organizationID)},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		ConditionExpression:       aws.String(conditionExpression),
+		UpdateExpression:          aws.String(updateExpression),
+		TableName:                 aws.String(repo.bitbucketOrgTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update Bitbucket organization secrets")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteBitbucketOrganization removes the Bitbucket organization
+func (repo *Repository) DeleteBitbucketOrganization(ctx context.Context, organizationID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.repository.DeleteBitbucketOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	log.WithFields(f).Debug("deleting Bitbucket organization record...")
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			OrganizationIDColumn: {S: aws.String(organizationID)},
+		},
+		TableName: aws.String(repo.bitbucketOrgTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to delete Bitbucket organization record")
+		return err
+	}
+
+	return nil
+}
+
+// queryOrganizations returns the Bitbucket organizations matching the key condition of the index
+func (repo *Repository) queryOrganizations(ctx context.Context, condition expression.KeyConditionBuilder, indexName string) ([]*common.BitbucketOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.repository.queryOrganizations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"indexName":      indexName,
+	}
+
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem creating builder")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.bitbucketOrgTableName),
+		IndexName:                 aws.String(indexName),
+	}
+
+	var orgs []*common.BitbucketOrganization
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("unable to query Bitbucket organizations")
+			return nil, queryErr
+		}
+
+		var page []*common.BitbucketOrganization
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding Bitbucket organization records")
+			return nil, err
+		}
+		orgs = append(orgs, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return orgs, nil
+}
@@ -0,0 +1,421 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	bitbucketApi "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/repositories"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+// errors
+var (
+	// ErrOrganizationNotFound is returned when the Bitbucket organization does not exist
+	ErrOrganizationNotFound = errors.New("bitbucket organization not found")
+	// ErrOrganizationExists is returned when adding a Bitbucket organization which is already registered
+	ErrOrganizationExists = errors.New("bitbucket organization already exists")
+	// ErrInvalidFlavor is returned when the flavor is not cloud or datacenter
+	ErrInvalidFlavor = errors.New("the bitbucket flavor must be cloud or datacenter")
+	// ErrInvalidBaseURL is returned when the base URL of a Bitbucket Data Center organization is missing or not a https URL
+	ErrInvalidBaseURL = errors.New("the base URL of the bitbucket data center instance must be a https URL")
+	// ErrAccessTokenRequired is returned when adding a Bitbucket organization without the access token
+	ErrAccessTokenRequired = errors.New("the access token of the bitbucket organization is required")
+	// ErrInvalidAccessToken is returned when the access token can't list the repositories of the Bitbucket organization
+	ErrInvalidAccessToken = errors.New("the access token can't list the repositories of the bitbucket organization")
+)
+
+// ServiceInterface defines the Bitbucket organizations service functions
+type ServiceInterface interface {
+	AddBitbucketOrganization(ctx context.Context, projectSFID string, input *models.BitbucketOrganizationInput) (*models.BitbucketOrganization, error)
+	GetBitbucketOrganizationsByProjectSFID(ctx context.Context, projectSFID string) (*models.BitbucketOrganizationList, error)
+	UpdateBitbucketOrganization(ctx context.Context, projectSFID, organizationID string, input *models.BitbucketOrganizationInput) (*models.BitbucketOrganization, error)
+	DeleteBitbucketOrganization(ctx context.Context, projectSFID, organizationID string) error
+
+	GetBitbucketOrganization(ctx context.Context, organizationID string) (*common.BitbucketOrganization, error)
+	GetBitbucketOrganizationClient(org *common.BitbucketOrganization) (*bitbucketApi.Client, error)
+	GetWebhookSecret(org *common.BitbucketOrganization) (string, error)
+	GetOauthConfig(org *common.BitbucketOrganization) (*oauth2.Config, error)
+	WebhookURL(org *common.BitbucketOrganization) string
+}
+
+// Service data model
+type Service struct {
+	repo                RepositoryInterface
+	repositoriesService repositories.ServiceInterface
+	bitbucketApp        *bitbucketApi.App
+	eventsService       events.Service
+}
+
+// NewService creates a new Bitbucket organizations service
+func NewService(repo RepositoryInterface, repositoriesService repositories.ServiceInterface, eventsService events.Service) ServiceInterface {
+	return &Service{
+		repo:                repo,
+		repositoriesService: repositoriesService,
+		bitbucketApp:        bitbucketApi.Init(config.GetConfig().Bitbucket.AppClientID, config.GetConfig().Bitbucket.AppClientSecret, config.GetConfig().Bitbucket.AppPrivateKey),
+		eventsService:       eventsService,
+	}
+}
+
+// AddBitbucketOrganization registers the Bitbucket Cloud workspace or Bitbucket Data Center project for the project,
+// the access token is verified by listing the repositories. When auto enabled, the existing repositories are enrolled
+// to the CLA group.
+func (s *Service) AddBitbucketOrganization(ctx context.Context, projectSFID string, input *models.BitbucketOrganizationInput) (*models.BitbucketOrganization, error) {
+	f := logrus.Fields{
+		"functionName":     "v2.bitbucket_organizations.service.AddBitbucketOrganization",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"projectSFID":      projectSFID,
+		"flavor":           utils.StringValue(input.Flavor),
+		"organizationName": utils.StringValue(input.OrganizationName),
+		"baseURL":          input.BaseURL,
+	}
+
+	org, err := newOrganization(projectSFID, input)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid bitbucket organization")
+		return nil, err
+	}
+	if input.AccessToken == "" {
+		return nil, ErrAccessTokenRequired
+	}
+
+	existing, err := s.repo.GetBitbucketOrganizationByKey(ctx, org.OrganizationKey)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrOrganizationExists
+	}
+
+	repos, err := s.verifyAccessToken(org, input.AccessToken)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to verify the bitbucket access token")
+		return nil, err
+	}
+
+	organizationID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	org.OrganizationID = organizationID.String()
+
+	if err := s.applyInput(org, input); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem encrypting the bitbucket organization secrets")
+		return nil, err
+	}
+	webhookSecret, err := bitbucketApi.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	org.WebhookSecret, err = bitbucketApi.EncryptSecret(webhookSecret, s.bitbucketApp)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem encrypting the bitbucket webhook secret")
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	org.DateCreated = currentTime
+	org.DateModified = currentTime
+	org.Version = "v1"
+
+	err = s.repo.AddBitbucketOrganization(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:   events.BitbucketOrganizationAdded,
+		ProjectSFID: projectSFID,
+		LfUsername:  utils.GetUserNameFromContext(ctx),
+		EventData: &events.BitbucketOrganizationAddedEventData{
+			OrganizationKey:       org.OrganizationKey,
+			Flavor:                org.Flavor,
+			AutoEnabled:           org.AutoEnabled,
+			AutoEnabledClaGroupID: org.AutoEnabledClaGroupID,
+		},
+	})
+
+	if org.AutoEnabled && org.AutoEnabledClaGroupID != "" && len(repos) > 0 {
+		slugs := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			slugs = append(slugs, repo.Slug)
+		}
+		log.WithFields(f).Debugf("enrolling %d repositories to the auto enabled CLA group: %s", len(slugs), org.AutoEnabledClaGroupID)
+		if err := s.repositoriesService.BitbucketEnrollRepositories(ctx, projectSFID, org.OrganizationID, org.AutoEnabledClaGroupID, slugs, true); err != nil {
+			// the organization is registered, the repositories can be enrolled later on
+			log.WithFields(f).WithError(err).Warn("problem enrolling the repositories of the bitbucket organization")
+		}
+	}
+
+	return common.ToBitbucketModel(org), nil
+}
+
+// GetBitbucketOrganizationsByProjectSFID returns the Bitbucket organizations of the project
+func (s *Service) GetBitbucketOrganizationsByProjectSFID(ctx context.Context, projectSFID string) (*models.BitbucketOrganizationList, error) {
+	orgs, err := s.repo.GetBitbucketOrganizationsByProjectSFID(ctx, projectSFID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.BitbucketOrganizationList{
+		List: []*models.BitbucketOrganization{},
+	}
+	for _, org := range orgs {
+		result.List = append(result.List, common.ToBitbucketModel(org))
+	}
+	return result, nil
+}
+
+// UpdateBitbucketOrganization updates the Bitbucket organization of the project, the flavor, the name and the base
+// URL can't be changed and the secrets are kept when not provided
+func (s *Service) UpdateBitbucketOrganization(ctx context.Context, projectSFID, organizationID string, input *models.BitbucketOrganizationInput) (*models.BitbucketOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.service.UpdateBitbucketOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"organizationID": organizationID,
+	}
+
+	org, err := s.getProjectOrganization(ctx, projectSFID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	inputOrg, err := newOrganization(projectSFID, input)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid bitbucket organization")
+		return nil, err
+	}
+	// the repositories refer to the organization by the key
+	if inputOrg.OrganizationKey != org.OrganizationKey || inputOrg.Flavor != org.Flavor {
+		return nil, fmt.Errorf("the flavor and the name of the bitbucket organization %s can't be changed", org.OrganizationKey)
+	}
+
+	if input.AccessToken != "" {
+		if _, err := s.verifyAccessToken(org, input.AccessToken); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to verify the bitbucket access token")
+			return nil, err
+		}
+	}
+	if err := s.applyInput(org, input); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem encrypting the bitbucket organization secrets")
+		return nil, err
+	}
+	_, org.DateModified = utils.CurrentTime()
+
+	err = s.repo.UpdateBitbucketOrganization(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:   events.BitbucketOrganizationUpdated,
+		ProjectSFID: projectSFID,
+		LfUsername:  utils.GetUserNameFromContext(ctx),
+		EventData: &events.BitbucketOrganizationUpdatedEventData{
+			OrganizationKey:       org.OrganizationKey,
+			AutoEnabled:           org.AutoEnabled,
+			AutoEnabledClaGroupID: org.AutoEnabledClaGroupID,
+			AccessTokenUpdated:    input.AccessToken != "",
+		},
+	})
+
+	return common.ToBitbucketModel(org), nil
+}
+
+// DeleteBitbucketOrganization removes the Bitbucket organization of the project along with its repositories
+func (s *Service) DeleteBitbucketOrganization(ctx context.Context, projectSFID, organizationID string) error {
+	org, err := s.getProjectOrganization(ctx, projectSFID, organizationID)
+	if err != nil {
+		return err
+	}
+
+	// the webhooks are removed first, the repository stream events can't load the organization once deleted
+	s.removeWebhooks(ctx, org)
+
+	err = s.repositoriesService.BitbucketDeleteRepositories(ctx, org.OrganizationKey)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DeleteBitbucketOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:   events.BitbucketOrganizationDeleted,
+		ProjectSFID: projectSFID,
+		LfUsername:  utils.GetUserNameFromContext(ctx),
+		EventData: &events.BitbucketOrganizationDeletedEventData{
+			OrganizationKey: org.OrganizationKey,
+		},
+	})
+
+	return nil
+}
+
+// removeWebhooks removes the EasyCLA webhooks of the enabled repositories of the organization, failures are logged
+func (s *Service) removeWebhooks(ctx context.Context, org *common.BitbucketOrganization) {
+	f := logrus.Fields{
+		"functionName":    "v2.bitbucket_organizations.service.removeWebhooks",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"organizationKey": org.OrganizationKey,
+	}
+
+	repos, err := s.repositoriesService.BitbucketGetRepositoriesByProjectSFID(ctx, org.ProjectSFID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the repositories of the bitbucket organization")
+		return
+	}
+
+	client, err := s.GetBitbucketOrganizationClient(org)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the bitbucket client of the organization")
+		return
+	}
+
+	webhookURL := s.WebhookURL(org)
+	for _, repo := range repos.List {
+		if repo.RepositoryOrganizationName != org.OrganizationKey || !repo.Enabled {
+			continue
+		}
+		slug := strings.TrimPrefix(repo.RepositoryName, org.OrganizationKey+"/")
+		if err := client.RemoveWebhook(org.OrganizationName, slug, webhookURL); err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem removing the webhook of the repository: %s", repo.RepositoryName)
+		}
+	}
+}
+
+// GetBitbucketOrganization returns the Bitbucket organization, nil if not found
+func (s *Service) GetBitbucketOrganization(ctx context.Context, organizationID string) (*common.BitbucketOrganization, error) {
+	return s.repo.GetBitbucketOrganization(ctx, organizationID)
+}
+
+// GetBitbucketOrganizationClient returns the Bitbucket client of the organization
+func (s *Service) GetBitbucketOrganizationClient(org *common.BitbucketOrganization) (*bitbucketApi.Client, error) {
+	return bitbucketApi.NewClientFromEncryptedToken(org.Flavor, org.BaseURL, org.AccessToken, s.bitbucketApp)
+}
+
+// GetWebhookSecret returns the decrypted secret of the webhooks of the organization
+func (s *Service) GetWebhookSecret(org *common.BitbucketOrganization) (string, error) {
+	return bitbucketApi.DecryptSecret(org.WebhookSecret, s.bitbucketApp)
+}
+
+// GetOauthConfig returns the OAuth config of the contributors sign flow - the EasyCLA consumer of Bitbucket Cloud or
+// the OAuth application of the Bitbucket Data Center instance
+func (s *Service) GetOauthConfig(org *common.BitbucketOrganization) (*oauth2.Config, error) {
+	redirectURI := config.GetConfig().Bitbucket.RedirectURI
+	if org.Flavor == bitbucketApi.FlavorCloud {
+		return bitbucketApi.OauthConfig(org.Flavor, "", s.bitbucketApp.GetAppID(), s.bitbucketApp.GetAppSecret(), redirectURI), nil
+	}
+
+	if org.AppClientID == "" || org.AppClientSecret == "" {
+		return nil, fmt.Errorf("the OAuth application of the bitbucket organization %s is not configured", org.OrganizationKey)
+	}
+	appClientSecret, err := bitbucketApi.DecryptSecret(org.AppClientSecret, s.bitbucketApp)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting the application secret of the bitbucket organization : %s failed : %v", org.OrganizationKey, err)
+	}
+	return bitbucketApi.OauthConfig(org.Flavor, org.BaseURL, org.AppClientID, appClientSecret, redirectURI), nil
+}
+
+// WebhookURL returns the url of the webhooks of the organization repositories, the organization ID selects the
+// secret the deliveries are signed with
+func (s *Service) WebhookURL(org *common.BitbucketOrganization) string {
+	return fmt.Sprintf("%s?organization_id=%s", config.GetConfig().Bitbucket.WebHookURI, url.QueryEscape(org.OrganizationID))
+}
+
+// getProjectOrganization returns the Bitbucket organization, ErrOrganizationNotFound if it doesn't belong to the project
+func (s *Service) getProjectOrganization(ctx context.Context, projectSFID, organizationID string) (*common.BitbucketOrganization, error) {
+	org, err := s.repo.GetBitbucketOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil || org.ProjectSFID != projectSFID {
+		return nil, ErrOrganizationNotFound
+	}
+	return org, nil
+}
+
+// verifyAccessToken lists the repositories of the organization with the access token
+func (s *Service) verifyAccessToken(org *common.BitbucketOrganization, accessToken string) ([]*bitbucketApi.Repository, error) {
+	client, err := bitbucketApi.NewClient(org.Flavor, org.BaseURL, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := client.ListRepositories(org.OrganizationName)
+	if err != nil {
+		return nil, fmt.Errorf("%w : %v", ErrInvalidAccessToken, err)
+	}
+	return repos, nil
+}
+
+// applyInput sets the values of the input on the organization, encrypting the provided secrets
+func (s *Service) applyInput(org *common.BitbucketOrganization, input *models.BitbucketOrganizationInput) error {
+	org.AutoEnabled = input.AutoEnabled
+	org.AutoEnabledClaGroupID = input.AutoEnabledClaGroupID
+	if input.AppClientID != "" {
+		org.AppClientID = input.AppClientID
+	}
+
+	if input.AccessToken != "" {
+		accessToken, err := bitbucketApi.EncryptSecret(input.AccessToken, s.bitbucketApp)
+		if err != nil {
+			return err
+		}
+		org.AccessToken = accessToken
+	}
+	if input.AppClientSecret != "" {
+		appClientSecret, err := bitbucketApi.EncryptSecret(input.AppClientSecret, s.bitbucketApp)
+		if err != nil {
+			return err
+		}
+		org.AppClientSecret = appClientSecret
+	}
+	return nil
+}
+
+// newOrganization returns the organization of the input with the key and the URL - the host followed by the
+// workspace (Bitbucket Cloud) or the project key (Bitbucket Data Center)
+func newOrganization(projectSFID string, input *models.BitbucketOrganizationInput) (*common.BitbucketOrganization, error) {
+	flavor := utils.StringValue(input.Flavor)
+	if !bitbucketApi.IsValidFlavor(flavor) {
+		return nil, ErrInvalidFlavor
+	}
+	name := strings.TrimSpace(utils.StringValue(input.OrganizationName))
+
+	org := &common.BitbucketOrganization{
+		OrganizationName: name,
+		Flavor:           flavor,
+		ProjectSFID:      projectSFID,
+	}
+	if flavor == bitbucketApi.FlavorCloud {
+		org.OrganizationKey = strings.ToLower(fmt.Sprintf("bitbucket.org/%s", name))
+		org.OrganizationURL = fmt.Sprintf("%s/%s", bitbucketApi.CloudWebBaseURL, name)
+		return org, nil
+	}
+
+	baseURL, err := url.Parse(strings.TrimSuffix(input.BaseURL, "/"))
+	if err != nil || baseURL.Scheme != "https" || baseURL.Host == "" {
+		return nil, ErrInvalidBaseURL
+	}
+	org.BaseURL = baseURL.String()
+	org.OrganizationKey = strings.ToLower(fmt.Sprintf("%s%s/%s", baseURL.Host, baseURL.Path, name))
+	org.OrganizationURL = fmt.Sprintf("%s/projects/%s", org.BaseURL, name)
+	return org, nil
+}
@@ -0,0 +1,140 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_sign
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/bitbucket_sign"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/gofrs/uuid"
+	"github.com/savaki/dynastore"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SessionStoreKey for cla-bitbucket session
+	SessionStoreKey = "cla-bitbucket"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, contributorConsoleV2Base string, sessionStore *dynastore.Store) {
+	api.BitbucketSignBitbucketSignRequestHandler = bitbucket_sign.BitbucketSignRequestHandlerFunc(
+		func(params bitbucket_sign.BitbucketSignRequestParams) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID)
+			f := logrus.Fields{
+				"functionName":   "v2.bitbucket_sign.handlers.BitbucketSignBitbucketSignRequestHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"organizationID": params.BitbucketOrganizationID,
+				"repositoryID":   params.BitbucketRepositoryID,
+				"pullRequestID":  params.PullRequestID,
+			}
+
+			return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+				session, err := sessionStore.Get(params.HTTPRequest, SessionStoreKey)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error with session store lookup")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				oauthConfig, err := service.GetOauthConfig(ctx, params.BitbucketOrganizationID)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error loading the oauth config of the bitbucket organization")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				originURL, err := service.GetOriginURL(ctx, params.BitbucketOrganizationID, params.BitbucketRepositoryID, params.PullRequestID)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error getting origin URL")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				// the access tokens are not kept in the session, Bitbucket doesn't prompt again for an authorized consumer
+				stateID, err := uuid.NewV4()
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to generate the oauth2 state")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				state := fmt.Sprintf("user:%s", stateID.String())
+
+				log.WithFields(f).Debug("initiating bitbucket sign request ...")
+				session.Values["bitbucket_organization_id"] = params.BitbucketOrganizationID
+				session.Values["bitbucket_repository_id"] = params.BitbucketRepositoryID
+				session.Values["bitbucket_pull_request_id"] = params.PullRequestID
+				session.Values["bitbucket_origin_url"] = originURL
+				session.Values["bitbucket_oauth2_state"] = state
+				if err := session.Save(params.HTTPRequest, rw); err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to save the session")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				http.Redirect(rw, params.HTTPRequest, oauthConfig.AuthCodeURL(state), http.StatusFound)
+			})
+		})
+
+	api.BitbucketSignBitbucketUserOauthCallbackHandler = bitbucket_sign.BitbucketUserOauthCallbackHandlerFunc(
+		func(params bitbucket_sign.BitbucketUserOauthCallbackParams) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID)
+			f := logrus.Fields{
+				"functionName":   "v2.bitbucket_sign.handlers.BitbucketSignBitbucketUserOauthCallbackHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"state":          params.State,
+			}
+
+			return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+				session, err := sessionStore.Get(params.HTTPRequest, SessionStoreKey)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error with session store lookup")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				state, _ := session.Values["bitbucket_oauth2_state"].(string)
+				if state == "" || params.State != state {
+					msg := fmt.Sprintf("mismatch state, received: %s from callback, but loaded our state as: %s", params.State, state)
+					log.WithFields(f).Warn(msg)
+					http.Error(rw, msg, http.StatusForbidden)
+					return
+				}
+
+				organizationID, _ := session.Values["bitbucket_organization_id"].(string)
+				repositoryID, _ := session.Values["bitbucket_repository_id"].(string)
+				pullRequestID, _ := session.Values["bitbucket_pull_request_id"].(string)
+				originURL, _ := session.Values["bitbucket_origin_url"].(string)
+				if organizationID == "" || repositoryID == "" || pullRequestID == "" || originURL == "" {
+					log.WithFields(f).Warn("the sign request is missing from the session")
+					http.Error(rw, "no sign request", http.StatusBadRequest)
+					return
+				}
+
+				// the state is single use
+				delete(session.Values, "bitbucket_oauth2_state")
+				session.Save(params.HTTPRequest, rw) // nolint
+
+				consoleURL, err := service.InitiateSignRequest(ctx, organizationID, repositoryID, pullRequestID, params.Code, originURL, contributorConsoleV2Base)
+				if err != nil {
+					msg := fmt.Sprintf("problem initiating sign request for the pull request: %s", originURL)
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusInternalServerError)
+					return
+				}
+
+				log.WithFields(f).Debugf("redirecting to :%s ", consoleURL)
+				http.Redirect(rw, params.HTTPRequest, consoleURL, http.StatusSeeOther)
+			})
+		})
+}
@@ -0,0 +1,252 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_sign
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	bitbucketApi "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bitbucket_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/store"
+	"github.com/go-openapi/strfmt"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+// ErrNoConfirmedEmail is returned when the Bitbucket user has no confirmed email address
+var ErrNoConfirmedEmail = errors.New("the bitbucket user has no confirmed email address")
+
+// Service defines the Bitbucket sign flow functions
+type Service interface {
+	GetOauthConfig(ctx context.Context, organizationID string) (*oauth2.Config, error)
+	GetOriginURL(ctx context.Context, organizationID, repositoryID, pullRequestID string) (string, error)
+	InitiateSignRequest(ctx context.Context, organizationID, repositoryID, pullRequestID, code, originURL, contributorBaseURL string) (string, error)
+}
+
+type service struct {
+	repoService         repositories.ServiceInterface
+	bitbucketOrgService bitbucket_organizations.ServiceInterface
+	userService         users.Service
+	storeRepo           store.Repository
+	eventsService       events.Service
+}
+
+// NewService creates a new Bitbucket sign service
+func NewService(repoService repositories.ServiceInterface, bitbucketOrgService bitbucket_organizations.ServiceInterface, userService users.Service, storeRepo store.Repository, eventsService events.Service) Service {
+	return &service{
+		repoService:         repoService,
+		bitbucketOrgService: bitbucketOrgService,
+		userService:         userService,
+		storeRepo:           storeRepo,
+		eventsService:       eventsService,
+	}
+}
+
+// GetOauthConfig returns the OAuth config the contributors of the organization sign in with
+func (s service) GetOauthConfig(ctx context.Context, organizationID string) (*oauth2.Config, error) {
+	org, err := s.getOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.bitbucketOrgService.GetOauthConfig(org)
+}
+
+// GetOriginURL returns the web URL of the pull request the contributor returns to once signed
+func (s service) GetOriginURL(ctx context.Context, organizationID, repositoryID, pullRequestID string) (string, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_sign.service.GetOriginURL",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+		"repositoryID":   repositoryID,
+		"pullRequestID":  pullRequestID,
+	}
+
+	org, err := s.getOrganization(ctx, organizationID)
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := s.repoService.BitbucketGetRepository(ctx, repositoryID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Debug("unable to load the bitbucket repository")
+		return "", err
+	}
+
+	pullRequestIDInt, err := strconv.Atoi(pullRequestID)
+	if err != nil {
+		log.WithFields(f).Debugf("unable to convert the pull request ID : %s to int", pullRequestID)
+		return "", err
+	}
+
+	client, err := s.bitbucketOrgService.GetBitbucketOrganizationClient(org)
+	if err != nil {
+		log.WithFields(f).WithError(err).Debug("initializing the bitbucket client failed")
+		return "", err
+	}
+
+	pullRequest, err := client.GetPullRequest(org.OrganizationName, repositorySlug(org, repo), pullRequestIDInt)
+	if err != nil {
+		log.WithFields(f).WithError(err).Debug("unable to fetch the pull request")
+		return "", err
+	}
+
+	log.WithFields(f).Debugf("return URL of the sign request is : %s ", pullRequest.WebURL)
+	return pullRequest.WebURL, nil
+}
+
+// InitiateSignRequest exchanges the OAuth code of the contributor, records the active signature and returns the
+// contributor console URL
+func (s service) InitiateSignRequest(ctx context.Context, organizationID, repositoryID, pullRequestID, code, originURL, contributorBaseURL string) (string, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_sign.service.InitiateSignRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+		"repositoryID":   repositoryID,
+		"pullRequestID":  pullRequestID,
+		"originURL":      originURL,
+	}
+
+	org, err := s.getOrganization(ctx, organizationID)
+	if err != nil {
+		return "", err
+	}
+
+	oauthConfig, err := s.bitbucketOrgService.GetOauthConfig(org)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the oauth config of the bitbucket organization")
+		return "", err
+	}
+
+	token, err := oauthConfig.Exchange(ctx, code)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to fetch the access token of the user")
+		return "", err
+	}
+
+	client, err := bitbucketApi.NewClient(org.Flavor, org.BaseURL, token.AccessToken)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the bitbucket client of the user")
+		return "", err
+	}
+
+	claUser, err := s.getOrCreateUser(ctx, client)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to get or create user")
+		return "", err
+	}
+
+	repo, err := s.repoService.BitbucketGetRepository(ctx, repositoryID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the bitbucket repository")
+		return "", err
+	}
+
+	type StoreValue struct {
+		UserID        string `json:"user_id"`
+		ProjectID     string `json:"project_id"`
+		RepositoryID  string `json:"repository_id"`
+		PullRequestID string `json:"pull_request_id"`
+		ReturnURL     string `json:"return_url"`
+	}
+
+	// set active signature metadata to track the user signing process
+	key := fmt.Sprintf("active_signature:%s", claUser.UserID)
+	jsonData, err := json.Marshal(StoreValue{
+		UserID:        claUser.UserID,
+		ProjectID:     repo.RepositoryClaGroupID,
+		RepositoryID:  repositoryID,
+		PullRequestID: pullRequestID,
+		ReturnURL:     originURL,
+	})
+	if err != nil {
+		return "", err
+	}
+	expire := time.Now().AddDate(0, 0, 1).Unix()
+	if err := s.storeRepo.SetActiveSignatureMetaData(ctx, key, expire, string(jsonData)); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save signature metadata")
+		return "", err
+	}
+
+	params := "redirect=" + url.QueryEscape(originURL)
+	return fmt.Sprintf("https://%s/#/cla/project/%s/user/%s?%s", contributorBaseURL, repo.RepositoryClaGroupID, claUser.UserID, params), nil
+}
+
+// getOrCreateUser looks up the EasyCLA user by the confirmed emails of the Bitbucket user, the user is created with
+// the primary email when none matches
+func (s service) getOrCreateUser(ctx context.Context, client *bitbucketApi.Client) (*models.User, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_sign.service.getOrCreateUser",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	bitbucketUser, err := client.CurrentUser()
+	if err != nil {
+		log.WithFields(f).WithError(err).Debug("getting the bitbucket current user failed")
+		return nil, err
+	}
+	if len(bitbucketUser.Emails) == 0 {
+		return nil, ErrNoConfirmedEmail
+	}
+
+	for _, email := range bitbucketUser.Emails {
+		claUser, err := s.userService.GetUserByEmail(email)
+		if err == nil && claUser != nil {
+			log.WithFields(f).Debugf("found user by Bitbucket email: %s", email)
+			return claUser, nil
+		}
+	}
+
+	log.WithFields(f).Infof("unable to locate Bitbucket user - creating a new user record for Bitbucket user : %s", bitbucketUser.Username)
+	user := &models.User{
+		LfEmail:  strfmt.Email(bitbucketUser.Emails[0]),
+		Emails:   bitbucketUser.Emails,
+		Username: bitbucketUser.DisplayName,
+	}
+	claUser, err := s.userService.CreateUser(user, nil)
+	if err != nil {
+		log.WithFields(f).WithError(err).Debugf("unable to create claUser with details : %+v", user)
+		return nil, err
+	}
+
+	// Log the event
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.UserCreated,
+		UserID:    claUser.UserID,
+		UserModel: claUser,
+		EventData: &events.UserCreatedEventData{},
+	})
+
+	return claUser, nil
+}
+
+func (s service) getOrganization(ctx context.Context, organizationID string) (*common.BitbucketOrganization, error) {
+	org, err := s.bitbucketOrgService.GetBitbucketOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, fmt.Errorf("unable to locate bitbucket organization by ID: %s", organizationID)
+	}
+	return org, nil
+}
+
+// repositorySlug returns the slug of the repository, the name is prefixed with the organization key
+func repositorySlug(org *common.BitbucketOrganization, repo *v2Models.BitbucketRepository) string {
+	return strings.TrimPrefix(repo.RepositoryName, org.OrganizationKey+"/")
+}
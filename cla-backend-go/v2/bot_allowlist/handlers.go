@@ -0,0 +1,154 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/bot_allowlist"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service ServiceInterface, projectsClaGroupsRepo projects_cla_groups.Repository) { // nolint
+
+	api.BotAllowlistListBotAllowlistEntriesHandler = bot_allowlist.ListBotAllowlistEntriesHandlerFunc(
+		func(params bot_allowlist.ListBotAllowlistEntriesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.bot_allowlist.handlers.BotAllowlistListBotAllowlistEntriesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"scopeType":      params.ScopeType,
+				"scopeID":        params.ScopeID,
+			}
+
+			if !isUserAuthorizedForScope(ctx, authUser, params.ScopeType, params.ScopeID, service, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to list the bot allowlist for %s %s", authUser.UserName, params.ScopeType, params.ScopeID)
+				log.WithFields(f).Warn(msg)
+				return bot_allowlist.NewListBotAllowlistEntriesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetEntries(ctx, params.ScopeType, params.ScopeID)
+			if err != nil {
+				msg := fmt.Sprintf("problem loading the bot allowlist for %s %s", params.ScopeType, params.ScopeID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bot_allowlist.NewListBotAllowlistEntriesBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return bot_allowlist.NewListBotAllowlistEntriesOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.BotAllowlistAddBotAllowlistEntryHandler = bot_allowlist.AddBotAllowlistEntryHandlerFunc(
+		func(params bot_allowlist.AddBotAllowlistEntryParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			scopeType := utils.StringValue(params.Body.ScopeType)
+			scopeID := utils.StringValue(params.Body.ScopeID)
+			f := logrus.Fields{
+				"functionName":   "v2.bot_allowlist.handlers.BotAllowlistAddBotAllowlistEntryHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"scopeType":      scopeType,
+				"scopeID":        scopeID,
+				"matchType":      utils.StringValue(params.Body.MatchType),
+			}
+
+			if !isUserAuthorizedForScope(ctx, authUser, scopeType, scopeID, service, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to update the bot allowlist for %s %s", authUser.UserName, scopeType, scopeID)
+				log.WithFields(f).Warn(msg)
+				return bot_allowlist.NewAddBotAllowlistEntryForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.AddEntry(ctx, params.Body)
+			if err != nil {
+				msg := fmt.Sprintf("problem adding the bot allowlist entry for %s %s", scopeType, scopeID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bot_allowlist.NewAddBotAllowlistEntryBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return bot_allowlist.NewAddBotAllowlistEntryOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.BotAllowlistDeleteBotAllowlistEntryHandler = bot_allowlist.DeleteBotAllowlistEntryHandlerFunc(
+		func(params bot_allowlist.DeleteBotAllowlistEntryParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.bot_allowlist.handlers.BotAllowlistDeleteBotAllowlistEntryHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"entryID":        params.EntryID,
+			}
+
+			entry, err := service.GetEntry(ctx, params.EntryID)
+			if err != nil {
+				if err == ErrEntryNotFound {
+					return bot_allowlist.NewDeleteBotAllowlistEntryNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, "bot allowlist entry not found", err))
+				}
+				msg := fmt.Sprintf("problem loading the bot allowlist entry %s", params.EntryID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bot_allowlist.NewDeleteBotAllowlistEntryBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			if !isUserAuthorizedForScope(ctx, authUser, entry.ScopeType, entry.ScopeID, service, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to update the bot allowlist for %s %s", authUser.UserName, entry.ScopeType, entry.ScopeID)
+				log.WithFields(f).Warn(msg)
+				return bot_allowlist.NewDeleteBotAllowlistEntryForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err = service.DeleteEntry(ctx, params.EntryID)
+			if err != nil {
+				msg := fmt.Sprintf("problem removing the bot allowlist entry %s", params.EntryID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bot_allowlist.NewDeleteBotAllowlistEntryBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return bot_allowlist.NewDeleteBotAllowlistEntryNoContent().WithXRequestID(reqID)
+		})
+}
+
+// isUserAuthorizedForScope returns true if the user may manage the bot allowlist of the foundation, CLA Group or
+// repository - repository entries are authorized through the CLA Group of the repository
+func isUserAuthorizedForScope(ctx context.Context, authUser *auth.User, scopeType, scopeID string, service ServiceInterface, projectsClaGroupsRepo projects_cla_groups.Repository) bool {
+	f := logrus.Fields{
+		"functionName":   "v2.bot_allowlist.handlers.isUserAuthorizedForScope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scopeType":      scopeType,
+		"scopeID":        scopeID,
+	}
+
+	if utils.IsUserAdmin(authUser) {
+		return true
+	}
+
+	switch scopeType {
+	case ScopeTypeFoundation:
+		return utils.IsUserAuthorizedForProjectTree(ctx, authUser, scopeID, utils.ALLOW_ADMIN_SCOPE)
+	case ScopeTypeCLAGroup:
+		return common.IsUserAuthorizedForCLAGroup(ctx, authUser, scopeID, projectsClaGroupsRepo)
+	case ScopeTypeRepository:
+		claGroupID, err := service.GetRepositoryCLAGroupID(ctx, scopeID)
+		if err != nil || claGroupID == "" {
+			log.WithFields(f).WithError(err).Warn("unable to load the CLA Group of the repository")
+			return false
+		}
+		return common.IsUserAuthorizedForCLAGroup(ctx, authUser, claGroupID, projectsClaGroupsRepo)
+	default:
+		log.WithFields(f).Warn("unsupported bot allowlist scope type")
+		return false
+	}
+}
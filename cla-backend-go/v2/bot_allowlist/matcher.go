@@ -0,0 +1,85 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+var (
+	// GitLab names the users of project and group access tokens project_<id>_bot[_<suffix>] / group_<id>_bot[_<suffix>]
+	gitLabBotUsernameRegex = regexp.MustCompile(`^(project|group)_\d+_bot(_[0-9a-z]+)?$`)
+	gitLabBotEmailRegex    = regexp.MustCompile(`^(project|group)\d+_bot[0-9a-z_]*@noreply\.`)
+)
+
+// Identity is the commit author, committer or merge request participant checked against the allowlist
+type Identity struct {
+	// Provider is the repository provider - utils.GitHubType, utils.GitLabLower or utils.BitbucketLower
+	Provider string
+	Username string
+	Email    string
+	// EmailVerified is set when the provider verified the email belongs to the account - the email of a git commit is
+	// set by its author, it is only verified when the provider linked the commit to the account
+	EmailVerified bool
+	// Bot is set when the provider reports the account as a bot, such as the GitHub App accounts
+	Bot bool
+}
+
+// Matcher checks identities against the allowlist entries of a foundation, CLA Group and repository
+type Matcher struct {
+	entries []*DBBotAllowlistEntryModel
+}
+
+// NewMatcher creates a matcher for the entries - the entries are checked in order, most specific scope first
+func NewMatcher(entries []*DBBotAllowlistEntryModel) *Matcher {
+	return &Matcher{entries: entries}
+}
+
+// Match returns the first allowlist entry matching the identity, nil if the identity is not exempt
+func (m *Matcher) Match(identity Identity) *DBBotAllowlistEntryModel {
+	if m == nil {
+		return nil
+	}
+	for _, entry := range m.entries {
+		if entryMatches(entry, identity) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// entryMatches returns true if the entry matches the identity. The emails are only matched when verified by the
+// provider, anyone can commit with any email.
+func entryMatches(entry *DBBotAllowlistEntryModel, identity Identity) bool {
+	username := strings.ToLower(strings.TrimSpace(identity.Username))
+	email := ""
+	if identity.EmailVerified {
+		email = strings.ToLower(strings.TrimSpace(identity.Email))
+	}
+	value := strings.ToLower(strings.TrimSpace(entry.Value))
+
+	switch entry.MatchType {
+	case MatchTypeGitHubAppBot:
+		return identity.Provider == utils.GitHubType && identity.Bot
+	case MatchTypeBotSuffix:
+		return strings.HasSuffix(username, "[bot]")
+	case MatchTypeGitLabProjectBot:
+		return identity.Provider == utils.GitLabLower && (identity.Bot || gitLabBotUsernameRegex.MatchString(username) || gitLabBotEmailRegex.MatchString(email))
+	case MatchTypeUsername:
+		return value != "" && username == value
+	case MatchTypeEmail:
+		if value == "" || email == "" {
+			return false
+		}
+		if strings.HasPrefix(value, "*@") {
+			return strings.HasSuffix(email, value[1:])
+		}
+		return email == value
+	default:
+		return false
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcherMatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		entry    *DBBotAllowlistEntryModel
+		identity Identity
+		match    bool
+	}{
+		{
+			name:     "github app bot",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeGitHubAppBot},
+			identity: Identity{Provider: utils.GitHubType, Username: "dependabot[bot]", Bot: true},
+			match:    true,
+		},
+		{
+			name:     "github app bot ignores users",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeGitHubAppBot},
+			identity: Identity{Provider: utils.GitHubType, Username: "octocat"},
+			match:    false,
+		},
+		{
+			name:     "github app bot ignores gitlab",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeGitHubAppBot},
+			identity: Identity{Provider: utils.GitLabLower, Username: "renovate", Bot: true},
+			match:    false,
+		},
+		{
+			name:     "bot suffix",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeBotSuffix},
+			identity: Identity{Provider: utils.GitHubType, Username: "Renovate[bot]"},
+			match:    true,
+		},
+		{
+			name:     "bot suffix ignores robots",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeBotSuffix},
+			identity: Identity{Provider: utils.GitHubType, Username: "robot"},
+			match:    false,
+		},
+		{
+			name:     "gitlab project bot username",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeGitLabProjectBot},
+			identity: Identity{Provider: utils.GitLabLower, Username: "project_1234_bot_5f2a"},
+			match:    true,
+		},
+		{
+			name:     "gitlab group bot email",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeGitLabProjectBot},
+			identity: Identity{Provider: utils.GitLabLower, Username: "ci", Email: "group42_bot_abc@noreply.gitlab.example.org", EmailVerified: true},
+			match:    true,
+		},
+		{
+			name:     "gitlab group bot email must be verified",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeGitLabProjectBot},
+			identity: Identity{Provider: utils.GitLabLower, Email: "group42_bot_abc@noreply.gitlab.example.org"},
+			match:    false,
+		},
+		{
+			name:     "gitlab bot user",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeGitLabProjectBot},
+			identity: Identity{Provider: utils.GitLabLower, Username: "deploy-token-user", Bot: true},
+			match:    true,
+		},
+		{
+			name:     "gitlab project bot ignores github",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeGitLabProjectBot},
+			identity: Identity{Provider: utils.GitHubType, Username: "project_1234_bot"},
+			match:    false,
+		},
+		{
+			name:     "username is case insensitive",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeUsername, Value: "Release-Bot"},
+			identity: Identity{Provider: utils.GitLabLower, Username: "release-bot"},
+			match:    true,
+		},
+		{
+			name:     "username requires a value",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeUsername},
+			identity: Identity{Provider: utils.GitHubType},
+			match:    false,
+		},
+		{
+			name:     "email",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeEmail, Value: "ci@example.org"},
+			identity: Identity{Provider: utils.GitHubType, Email: "CI@example.org", EmailVerified: true},
+			match:    true,
+		},
+		{
+			name:     "email must be verified",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeEmail, Value: "ci@example.org"},
+			identity: Identity{Provider: utils.GitHubType, Email: "ci@example.org"},
+			match:    false,
+		},
+		{
+			name:     "email domain",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeEmail, Value: "*@bots.example.org"},
+			identity: Identity{Provider: utils.GitHubType, Email: "deploy@bots.example.org", EmailVerified: true},
+			match:    true,
+		},
+		{
+			name:     "email domain must be verified",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeEmail, Value: "*@bots.example.org"},
+			identity: Identity{Provider: utils.GitHubType, Email: "x@bots.example.org"},
+			match:    false,
+		},
+		{
+			name:     "email domain is not a suffix match",
+			entry:    &DBBotAllowlistEntryModel{MatchType: MatchTypeEmail, Value: "*@example.org"},
+			identity: Identity{Provider: utils.GitHubType, Email: "user@notexample.org", EmailVerified: true},
+			match:    false,
+		},
+		{
+			name:     "unknown match type",
+			entry:    &DBBotAllowlistEntryModel{MatchType: "regex", Value: ".*"},
+			identity: Identity{Provider: utils.GitHubType, Username: "octocat"},
+			match:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := NewMatcher([]*DBBotAllowlistEntryModel{tc.entry}).Match(tc.identity)
+			if tc.match {
+				assert.Equal(t, tc.entry, result)
+			} else {
+				assert.Nil(t, result)
+			}
+		})
+	}
+}
+
+func TestMatcherMostSpecificScopeFirst(t *testing.T) {
+	repositoryEntry := &DBBotAllowlistEntryModel{ScopeType: ScopeTypeRepository, MatchType: MatchTypeUsername, Value: "ci-user"}
+	foundationEntry := &DBBotAllowlistEntryModel{ScopeType: ScopeTypeFoundation, MatchType: MatchTypeBotSuffix}
+	matcher := NewMatcher([]*DBBotAllowlistEntryModel{repositoryEntry, foundationEntry})
+
+	assert.Equal(t, repositoryEntry, matcher.Match(Identity{Provider: utils.GitHubType, Username: "ci-user"}))
+	assert.Equal(t, foundationEntry, matcher.Match(Identity{Provider: utils.GitHubType, Username: "helper[bot]"}))
+	assert.Nil(t, matcher.Match(Identity{Provider: utils.GitHubType, Username: "octocat"}))
+
+	var nilMatcher *Matcher
+	assert.Nil(t, nilMatcher.Match(Identity{Provider: utils.GitHubType, Username: "helper[bot]"}))
+}
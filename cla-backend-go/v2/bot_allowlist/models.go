@@ -0,0 +1,77 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+const (
+	// ScopeTypeFoundation indicates the entry applies to every CLA Group under a foundation
+	ScopeTypeFoundation = "foundation"
+	// ScopeTypeCLAGroup indicates the entry applies to the repositories of a single CLA Group
+	ScopeTypeCLAGroup = "cla_group"
+	// ScopeTypeRepository indicates the entry applies to a single repository
+	ScopeTypeRepository = "repository"
+)
+
+const (
+	// MatchTypeGitHubAppBot matches the GitHub App bot accounts, such as dependabot[bot]
+	MatchTypeGitHubAppBot = "github_app_bot"
+	// MatchTypeBotSuffix matches any username ending with [bot]
+	MatchTypeBotSuffix = "bot_suffix"
+	// MatchTypeGitLabProjectBot matches the GitLab project and group access token bot users
+	MatchTypeGitLabProjectBot = "gitlab_project_bot"
+	// MatchTypeUsername matches an explicit GitHub or GitLab username
+	MatchTypeUsername = "username"
+	// MatchTypeEmail matches an explicit email address, or any address of a domain when the value is *@domain - only the
+	// emails verified by the provider are matched
+	MatchTypeEmail = "email"
+)
+
+// DBBotAllowlistEntryModel data model for the bot allowlist table
+type DBBotAllowlistEntryModel struct {
+	EntryID      string `dynamodbav:"entry_id" json:"entry_id"`
+	ScopeType    string `dynamodbav:"scope_type" json:"scope_type"`
+	ScopeID      string `dynamodbav:"scope_id" json:"scope_id"`
+	MatchType    string `dynamodbav:"match_type" json:"match_type"`
+	Value        string `dynamodbav:"value" json:"value"`
+	Note         string `dynamodbav:"note" json:"note"`
+	AddedBy      string `dynamodbav:"added_by" json:"added_by"`
+	DateCreated  string `dynamodbav:"date_created" json:"date_created"`
+	DateModified string `dynamodbav:"date_modified" json:"date_modified"`
+	Version      string `dynamodbav:"version" json:"version"`
+}
+
+// toModel converts the database model to the API model
+func (m *DBBotAllowlistEntryModel) toModel() *models.BotAllowlistEntry {
+	return &models.BotAllowlistEntry{
+		EntryID:      m.EntryID,
+		ScopeType:    m.ScopeType,
+		ScopeID:      m.ScopeID,
+		MatchType:    m.MatchType,
+		Value:        m.Value,
+		Note:         m.Note,
+		AddedBy:      m.AddedBy,
+		DateCreated:  m.DateCreated,
+		DateModified: m.DateModified,
+		Version:      m.Version,
+	}
+}
+
+// Reason returns a short description of the entry, shown next to the exempt authors
+func (m *DBBotAllowlistEntryModel) Reason() string {
+	switch m.MatchType {
+	case MatchTypeGitHubAppBot:
+		return "GitHub App bot"
+	case MatchTypeBotSuffix:
+		return "bot account"
+	case MatchTypeGitLabProjectBot:
+		return "GitLab project bot"
+	default:
+		return fmt.Sprintf("allowlisted %s", m.MatchType)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// table columns and indexes
+const (
+	// EntryIDColumn is the primary key of the bot allowlist table
+	EntryIDColumn = "entry_id"
+	// ScopeIDColumn is the foundation SFID, CLA Group ID or repository ID of the entry
+	ScopeIDColumn = "scope_id"
+
+	// ScopeIDIndex the index for the scope ID
+	ScopeIDIndex = "bot-allowlist-scope-id-index"
+)
+
+// RepositoryInterface defines the bot allowlist data access functions
+type RepositoryInterface interface {
+	AddEntry(ctx context.Context, entry *DBBotAllowlistEntryModel) error
+	GetEntry(ctx context.Context, entryID string) (*DBBotAllowlistEntryModel, error)
+	GetEntriesByScopeID(ctx context.Context, scopeID string) ([]*DBBotAllowlistEntryModel, error)
+	DeleteEntry(ctx context.Context, entryID string) error
+}
+
+// Repository object/struct
+type Repository struct {
+	stage              string
+	dynamoDBClient     *dynamodb.DynamoDB
+	allowlistTableName string
+}
+
+// NewRepository creates a new instance of the bot allowlist repository
+func NewRepository(awsSession *session.Session, stage string) RepositoryInterface {
+	return &Repository{
+		stage:              stage,
+		dynamoDBClient:     dynamodb.New(awsSession),
+		allowlistTableName: fmt.Sprintf("cla-%s-bot-allowlist", stage),
+	}
+}
+
+// AddEntry adds the allowlist entry to the database
+func (repo *Repository) AddEntry(ctx context.Context, entry *DBBotAllowlistEntryModel) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bot_allowlist.repository.AddEntry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"entryID":        entry.EntryID,
+		"scopeType":      entry.ScopeType,
+		"scopeID":        entry.ScopeID,
+		"matchType":      entry.MatchType,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(entry)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall bot allowlist record")
+		return err
+	}
+
+	log.WithFields(f).Debug("adding bot allowlist record to the database...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.allowlistTableName),
+		ConditionExpression: aws.String("attribute_not_exists(entry_id)"),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to add bot allowlist record")
+		return err
+	}
+
+	return nil
+}
+
+// GetEntry returns the allowlist entry by ID, nil if not found
+func (repo *Repository) GetEntry(ctx context.Context, entryID string) (*DBBotAllowlistEntryModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bot_allowlist.repository.GetEntry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"entryID":        entryID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			EntryIDColumn: {S: aws.String(entryID)},
+		},
+		TableName: aws.String(repo.allowlistTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load bot allowlist record")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		log.WithFields(f).Debug("unable to find bot allowlist record - no results")
+		return nil, nil
+	}
+
+	var entry DBBotAllowlistEntryModel
+	err = dynamodbattribute.UnmarshalMap(result.Item, &entry)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem decoding bot allowlist record")
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// GetEntriesByScopeID returns the allowlist entries of the specified foundation SFID, CLA Group ID or repository ID
+func (repo *Repository) GetEntriesByScopeID(ctx context.Context, scopeID string) ([]*DBBotAllowlistEntryModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bot_allowlist.repository.GetEntriesByScopeID",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scopeID":        scopeID,
+	}
+
+	condition := expression.Key(ScopeIDColumn).Equal(expression.Value(scopeID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem building query expression")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.allowlistTableName),
+		IndexName:                 aws.String(ScopeIDIndex),
+	}
+
+	var entries []*DBBotAllowlistEntryModel
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("error querying bot allowlist table")
+			return nil, queryErr
+		}
+
+		var page []*DBBotAllowlistEntryModel
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding bot allowlist records")
+			return nil, err
+		}
+		entries = append(entries, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return entries, nil
+}
+
+// DeleteEntry removes the allowlist entry
+func (repo *Repository) DeleteEntry(ctx context.Context, entryID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bot_allowlist.repository.DeleteEntry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"entryID":        entryID,
+	}
+
+	log.WithFields(f).Debug("deleting bot allowlist record...")
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			EntryIDColumn: {S: aws.String(entryID)},
+		},
+		TableName: aws.String(repo.allowlistTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to delete bot allowlist record")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,278 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// errors
+var (
+	// ErrEntryNotFound is returned when the allowlist entry does not exist
+	ErrEntryNotFound = errors.New("bot allowlist entry not found")
+	// ErrEntryValueRequired is returned when a username or email entry has no value
+	ErrEntryValueRequired = errors.New("a value is required for username and email entries")
+)
+
+// Exemption describes an author exempted from the CLA check by an allowlist entry
+type Exemption struct {
+	CLAGroupID      string
+	Provider        string
+	RepositoryName  string
+	ChangeRequestID int
+	Username        string
+	Email           string
+	Entry           *DBBotAllowlistEntryModel
+}
+
+// ServiceInterface defines the bot allowlist service functions
+type ServiceInterface interface {
+	AddEntry(ctx context.Context, input *models.BotAllowlistEntryInput) (*models.BotAllowlistEntry, error)
+	GetEntries(ctx context.Context, scopeType, scopeID string) (*models.BotAllowlistEntryList, error)
+	GetEntry(ctx context.Context, entryID string) (*models.BotAllowlistEntry, error)
+	DeleteEntry(ctx context.Context, entryID string) error
+	GetRepositoryCLAGroupID(ctx context.Context, repositoryID string) (string, error)
+
+	GetMatcher(ctx context.Context, claGroupID, repositoryID string) (*Matcher, error)
+	LogExemption(ctx context.Context, exemption *Exemption)
+}
+
+// Service data model
+type Service struct {
+	repo                  RepositoryInterface
+	projectsClaGroupsRepo projects_cla_groups.Repository
+	repositoriesService   repositories.Service
+	eventsService         events.Service
+}
+
+// NewService creates a new bot allowlist service
+func NewService(repo RepositoryInterface, projectsClaGroupsRepo projects_cla_groups.Repository, repositoriesService repositories.Service, eventsService events.Service) ServiceInterface {
+	return &Service{
+		repo:                  repo,
+		projectsClaGroupsRepo: projectsClaGroupsRepo,
+		repositoriesService:   repositoriesService,
+		eventsService:         eventsService,
+	}
+}
+
+// AddEntry adds an entry to the allowlist of the foundation, CLA Group or repository
+func (s *Service) AddEntry(ctx context.Context, input *models.BotAllowlistEntryInput) (*models.BotAllowlistEntry, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bot_allowlist.service.AddEntry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scopeType":      utils.StringValue(input.ScopeType),
+		"scopeID":        utils.StringValue(input.ScopeID),
+		"matchType":      utils.StringValue(input.MatchType),
+		"value":          input.Value,
+	}
+
+	matchType := utils.StringValue(input.MatchType)
+	value := strings.TrimSpace(input.Value)
+	if (matchType == MatchTypeUsername || matchType == MatchTypeEmail) && value == "" {
+		log.WithFields(f).Warn("missing value for the allowlist entry")
+		return nil, ErrEntryValueRequired
+	}
+
+	entryID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for the allowlist entry")
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	entry := &DBBotAllowlistEntryModel{
+		EntryID:      entryID.String(),
+		ScopeType:    utils.StringValue(input.ScopeType),
+		ScopeID:      utils.StringValue(input.ScopeID),
+		MatchType:    matchType,
+		Value:        value,
+		Note:         input.Note,
+		AddedBy:      utils.GetUserNameFromContext(ctx),
+		DateCreated:  currentTime,
+		DateModified: currentTime,
+		Version:      "v1",
+	}
+
+	err = s.repo.AddEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, s.eventArgs(ctx, events.BotAllowlistEntryAdded, entry, &events.BotAllowlistEntryAddedEventData{
+		MatchType: entry.MatchType,
+		Value:     entry.Value,
+		ScopeType: entry.ScopeType,
+		ScopeID:   entry.ScopeID,
+	}))
+
+	return entry.toModel(), nil
+}
+
+// GetEntries returns the allowlist entries of the foundation, CLA Group or repository
+func (s *Service) GetEntries(ctx context.Context, scopeType, scopeID string) (*models.BotAllowlistEntryList, error) {
+	entries, err := s.repo.GetEntriesByScopeID(ctx, scopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.BotAllowlistEntryList{
+		List: []*models.BotAllowlistEntry{},
+	}
+	for _, entry := range entries {
+		if entry.ScopeType != scopeType {
+			continue
+		}
+		result.List = append(result.List, entry.toModel())
+	}
+	return result, nil
+}
+
+// GetEntry returns the allowlist entry
+func (s *Service) GetEntry(ctx context.Context, entryID string) (*models.BotAllowlistEntry, error) {
+	entry, err := s.repo.GetEntry(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, ErrEntryNotFound
+	}
+	return entry.toModel(), nil
+}
+
+// DeleteEntry removes the entry from the allowlist
+func (s *Service) DeleteEntry(ctx context.Context, entryID string) error {
+	entry, err := s.repo.GetEntry(ctx, entryID)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return ErrEntryNotFound
+	}
+
+	err = s.repo.DeleteEntry(ctx, entryID)
+	if err != nil {
+		return err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, s.eventArgs(ctx, events.BotAllowlistEntryDeleted, entry, &events.BotAllowlistEntryDeletedEventData{
+		MatchType: entry.MatchType,
+		Value:     entry.Value,
+		ScopeType: entry.ScopeType,
+		ScopeID:   entry.ScopeID,
+	}))
+
+	return nil
+}
+
+// GetRepositoryCLAGroupID returns the CLA Group of the repository - used to authorize repository level entries
+func (s *Service) GetRepositoryCLAGroupID(ctx context.Context, repositoryID string) (string, error) {
+	repository, err := s.repositoriesService.GetRepository(ctx, repositoryID)
+	if err != nil {
+		return "", err
+	}
+	return repository.RepositoryClaGroupID, nil
+}
+
+// GetMatcher returns the matcher for the entries of the repository, its CLA Group and the foundation of the CLA Group
+func (s *Service) GetMatcher(ctx context.Context, claGroupID, repositoryID string) (*Matcher, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bot_allowlist.service.GetMatcher",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"repositoryID":   repositoryID,
+	}
+
+	foundationSFID := ""
+	projectCLAGroups, err := s.projectsClaGroupsRepo.GetProjectsIdsForClaGroup(ctx, claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the foundation of the CLA Group - ignoring foundation entries")
+	} else if len(projectCLAGroups) > 0 {
+		foundationSFID = projectCLAGroups[0].FoundationSFID
+	}
+
+	scopes := []struct {
+		scopeType string
+		scopeID   string
+	}{
+		{ScopeTypeRepository, repositoryID},
+		{ScopeTypeCLAGroup, claGroupID},
+		{ScopeTypeFoundation, foundationSFID},
+	}
+
+	var entries []*DBBotAllowlistEntryModel
+	for _, scope := range scopes {
+		if scope.scopeID == "" {
+			continue
+		}
+		scopeEntries, scopeErr := s.repo.GetEntriesByScopeID(ctx, scope.scopeID)
+		if scopeErr != nil {
+			log.WithFields(f).WithError(scopeErr).Warnf("unable to load the allowlist entries for %s %s", scope.scopeType, scope.scopeID)
+			return nil, scopeErr
+		}
+		for _, entry := range scopeEntries {
+			if entry.ScopeType == scope.scopeType {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	log.WithFields(f).Debugf("loaded %d allowlist entries", len(entries))
+	return NewMatcher(entries), nil
+}
+
+// LogExemption logs the event for an author exempted from the CLA check
+func (s *Service) LogExemption(ctx context.Context, exemption *Exemption) {
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.BotAllowlistExemption,
+		CLAGroupID: exemption.CLAGroupID,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData: &events.BotAllowlistExemptionEventData{
+			Provider:        exemption.Provider,
+			RepositoryName:  exemption.RepositoryName,
+			ChangeRequestID: exemption.ChangeRequestID,
+			Username:        exemption.Username,
+			Email:           exemption.Email,
+			MatchType:       exemption.Entry.MatchType,
+			ScopeType:       exemption.Entry.ScopeType,
+			ScopeID:         exemption.Entry.ScopeID,
+		},
+	})
+}
+
+// eventArgs is a helper function to build the event arguments for the scope of the entry
+func (s *Service) eventArgs(ctx context.Context, eventType string, entry *DBBotAllowlistEntryModel, eventData events.EventData) *events.LogEventArgs {
+	args := &events.LogEventArgs{
+		EventType:  eventType,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData:  eventData,
+	}
+
+	switch entry.ScopeType {
+	case ScopeTypeFoundation:
+		args.ProjectSFID = entry.ScopeID
+	case ScopeTypeCLAGroup:
+		args.CLAGroupID = entry.ScopeID
+	case ScopeTypeRepository:
+		claGroupID, err := s.GetRepositoryCLAGroupID(ctx, entry.ScopeID)
+		if err != nil {
+			log.WithError(err).Warnf("unable to load the CLA Group of repository: %s", entry.ScopeID)
+		}
+		args.CLAGroupID = claGroupID
+	default:
+		log.Warnf("unsupported bot allowlist scope type: %s", entry.ScopeType)
+	}
+
+	return args
+}
@@ -0,0 +1,172 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_evaluation
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// MatchDomainPattern returns true if the email matches the domain approval list pattern - example.com, *example.com,
+// *.example.com or .example.com
+func MatchDomainPattern(pattern, email string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if strings.HasPrefix(pattern, "*.") {
+		pattern = strings.Replace(pattern, "*.", ".*", 1)
+	} else if strings.HasPrefix(pattern, "*") {
+		pattern = strings.Replace(pattern, "*", ".*", 1)
+	} else if strings.HasPrefix(pattern, ".") {
+		pattern = strings.Replace(pattern, ".", ".*", 1)
+	}
+
+	compiled, err := regexp.Compile("(?i)^.*@" + pattern + "$")
+	if err != nil {
+		return false
+	}
+	return compiled.MatchString(strings.TrimSpace(email))
+}
+
+// identityEmails returns the emails of the EasyCLA user, the email of the contributor is only included when the
+// provider verified it - the commit emails are set by the contributor
+func identityEmails(identity Identity, user *models.User) []string {
+	var emails []string
+	add := func(email string) {
+		email = strings.TrimSpace(email)
+		if email != "" && !utils.StringInSlice(email, emails) {
+			emails = append(emails, email)
+		}
+	}
+	for _, email := range user.Emails {
+		add(email)
+	}
+	add(string(user.LfEmail))
+	if identity.EmailVerified {
+		add(identity.Email)
+	}
+	return emails
+}
+
+// providerUsername returns the username of the EasyCLA user for the provider, the username of the contributor when
+// the contributor is on the provider
+func providerUsername(provider string, identity Identity, user *models.User) string {
+	if identity.Provider == provider && identity.Username != "" {
+		return identity.Username
+	}
+	switch provider {
+	case utils.GitHubType:
+		return user.GithubUsername
+	case utils.GitLabLower:
+		return user.GitlabUsername
+	}
+	return ""
+}
+
+func containsFold(list []string, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", false
+	}
+	for _, entry := range list {
+		if strings.EqualFold(strings.TrimSpace(entry), value) {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// MatchApprovalLists returns the approval list entry of the corporate signature matching the contributor, nil if the
+// contributor isn't approved. The lists are checked from the cheapest to the ones calling the providers.
+func (e *Evaluator) MatchApprovalLists(ctx context.Context, corporateSignature *models.Signature, identity Identity, user *models.User) *ApprovalRule {
+	return e.matchApprovalLists(ctx, corporateSignature, identity, user, nil)
+}
+
+// matchApprovalLists returns the approval list entry matching the contributor, recording the lists checked when the
+// trace is set
+func (e *Evaluator) matchApprovalLists(ctx context.Context, corporateSignature *models.Signature, identity Identity, user *models.User, trace *UserTrace) *ApprovalRule {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_evaluation.approval.matchApprovalLists",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    corporateSignature.SignatureID,
+		"userID":         user.UserID,
+	}
+
+	emails := identityEmails(identity, user)
+	for _, email := range emails {
+		if entry, ok := containsFold(corporateSignature.EmailApprovalList, email); ok {
+			trace.check(ApprovalListEmail, emails, len(corporateSignature.EmailApprovalList), entry)
+			return &ApprovalRule{List: ApprovalListEmail, Value: entry}
+		}
+	}
+	trace.check(ApprovalListEmail, emails, len(corporateSignature.EmailApprovalList), "")
+
+	for _, pattern := range corporateSignature.DomainApprovalList {
+		for _, email := range emails {
+			if MatchDomainPattern(pattern, email) {
+				trace.check(ApprovalListDomain, emails, len(corporateSignature.DomainApprovalList), pattern)
+				return &ApprovalRule{List: ApprovalListDomain, Value: pattern}
+			}
+		}
+	}
+	trace.check(ApprovalListDomain, emails, len(corporateSignature.DomainApprovalList), "")
+
+	gitHubUsername := providerUsername(utils.GitHubType, identity, user)
+	entry, ok := containsFold(corporateSignature.GithubUsernameApprovalList, gitHubUsername)
+	trace.check(ApprovalListGitHubUsername, nonEmpty(gitHubUsername), len(corporateSignature.GithubUsernameApprovalList), entry)
+	if ok {
+		return &ApprovalRule{List: ApprovalListGitHubUsername, Value: entry}
+	}
+
+	gitLabUsername := providerUsername(utils.GitLabLower, identity, user)
+	entry, ok = containsFold(corporateSignature.GitlabUsernameApprovalList, gitLabUsername)
+	trace.check(ApprovalListGitLabUsername, nonEmpty(gitLabUsername), len(corporateSignature.GitlabUsernameApprovalList), entry)
+	if ok {
+		return &ApprovalRule{List: ApprovalListGitLabUsername, Value: entry}
+	}
+
+	if e.gitHubOrganizations != nil && gitHubUsername != "" {
+		for _, org := range corporateSignature.GithubOrgApprovalList {
+			member, err := e.gitHubOrganizations.IsMember(ctx, gitHubUsername, org)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to check the membership of the github organization: %s", org)
+				continue
+			}
+			if member {
+				trace.check(ApprovalListGitHubOrg, nonEmpty(gitHubUsername), len(corporateSignature.GithubOrgApprovalList), org)
+				return &ApprovalRule{List: ApprovalListGitHubOrg, Value: org}
+			}
+		}
+		trace.check(ApprovalListGitHubOrg, nonEmpty(gitHubUsername), len(corporateSignature.GithubOrgApprovalList), "")
+	}
+
+	if e.gitLabGroups != nil && gitLabUsername != "" {
+		for _, group := range corporateSignature.GitlabOrgApprovalList {
+			member, err := e.gitLabGroups.IsMember(ctx, gitLabUsername, group)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to check the membership of the gitlab group: %s", group)
+				continue
+			}
+			if member {
+				trace.check(ApprovalListGitLabGroup, nonEmpty(gitLabUsername), len(corporateSignature.GitlabOrgApprovalList), group)
+				return &ApprovalRule{List: ApprovalListGitLabGroup, Value: group}
+			}
+		}
+		trace.check(ApprovalListGitLabGroup, nonEmpty(gitLabUsername), len(corporateSignature.GitlabOrgApprovalList), "")
+	}
+
+	return nil
+}
+
+// nonEmpty returns the value as a list, an empty list for an empty value
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
@@ -0,0 +1,266 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_evaluation
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bot_allowlist"
+	"github.com/sirupsen/logrus"
+)
+
+// UserLookup locates the EasyCLA users of the contributors, implemented by users.UserRepository and users.Service
+type UserLookup interface {
+	GetUserByGitHubID(gitHubID string) (*models.User, error)
+	GetUserByGitHubUsername(gitHubUsername string) (*models.User, error)
+	GetUserByGitlabID(gitlabID int) (*models.User, error)
+	GetUserByGitLabUsername(gitlabUsername string) (*models.User, error)
+	GetUserByEmail(userEmail string) (*models.User, error)
+	GetUsersByEmail(userEmail string) ([]*models.User, error)
+}
+
+// SignatureLookup loads the signatures covering the users, implemented by signatures.SignatureRepository
+type SignatureLookup interface {
+	GetIndividualSignature(ctx context.Context, claGroupID, userID string, approved, signed *bool) (*models.Signature, error)
+	GetCorporateSignature(ctx context.Context, claGroupID, companyID string, approved, signed *bool) (*models.Signature, error)
+	GetEmployeeSignature(ctx context.Context, claGroupID, companyID, userID string) (*models.Signature, error)
+}
+
+// OrganizationMembership checks the membership of the GitHub organizations or GitLab groups of the approval lists
+type OrganizationMembership interface {
+	IsMember(ctx context.Context, username, organization string) (bool, error)
+}
+
+// Evaluator decides which contributors of a change request are covered by a signature of the CLA Group
+type Evaluator struct {
+	users               UserLookup
+	signatures          SignatureLookup
+	gitHubOrganizations OrganizationMembership
+	gitLabGroups        OrganizationMembership
+}
+
+// NewEvaluator creates a new evaluator - the organization approval lists are skipped when the membership is nil
+func NewEvaluator(users UserLookup, signatures SignatureLookup, gitHubOrganizations, gitLabGroups OrganizationMembership) *Evaluator {
+	return &Evaluator{
+		users:               users,
+		signatures:          signatures,
+		gitHubOrganizations: gitHubOrganizations,
+		gitLabGroups:        gitLabGroups,
+	}
+}
+
+// Evaluate returns the verdict of each identity, in order. The identities exempt by the allowlist are not looked up,
+// a nil allowlist exempts nobody.
+func (e *Evaluator) Evaluate(ctx context.Context, claGroupID string, identities []Identity, allowlist *bot_allowlist.Matcher) []*Verdict {
+	verdicts := make([]*Verdict, 0, len(identities))
+	// contributors usually have many commits in large change requests - each identity is only looked up once
+	evaluated := make(map[string]*Verdict)
+	for _, identity := range identities {
+		key := identity.Key()
+		if previous, ok := evaluated[key]; ok {
+			verdict := *previous
+			verdict.Identity = identity
+			verdicts = append(verdicts, &verdict)
+			continue
+		}
+
+		verdict := e.EvaluateIdentity(ctx, claGroupID, identity, allowlist)
+		evaluated[key] = verdict
+		verdicts = append(verdicts, verdict)
+	}
+	return verdicts
+}
+
+// EvaluateIdentity returns the verdict of the identity
+func (e *Evaluator) EvaluateIdentity(ctx context.Context, claGroupID string, identity Identity, allowlist *bot_allowlist.Matcher) *Verdict {
+	return e.evaluate(ctx, claGroupID, identity, allowlist, nil)
+}
+
+// Explain returns the decision trace of the verdict of the identity - the user lookups, the signatures and approval
+// lists checked for each EasyCLA user of the contributor and the final verdict
+func (e *Evaluator) Explain(ctx context.Context, claGroupID string, identity Identity, allowlist *bot_allowlist.Matcher) *Trace {
+	trace := &Trace{Identity: identity}
+	trace.Verdict = e.evaluate(ctx, claGroupID, identity, allowlist, trace)
+	return trace
+}
+
+// evaluate returns the verdict of the identity, recording the decision trace when the trace is set
+func (e *Evaluator) evaluate(ctx context.Context, claGroupID string, identity Identity, allowlist *bot_allowlist.Matcher, trace *Trace) *Verdict {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_evaluation.evaluator.evaluate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"provider":       identity.Provider,
+		"externalID":     identity.ExternalID,
+		"username":       identity.Username,
+		"email":          identity.Email,
+	}
+
+	if entry := allowlist.Match(bot_allowlist.Identity{
+		Provider:      identity.Provider,
+		Username:      identity.Username,
+		Email:         identity.Email,
+		EmailVerified: identity.EmailVerified,
+		Bot:           identity.Bot,
+	}); entry != nil {
+		log.WithFields(f).Debugf("contributor is exempt by the %s allowlist entry: %s", entry.ScopeType, entry.EntryID)
+		return &Verdict{Identity: identity, Authorized: true, Reason: ReasonExempt, ExemptEntry: entry}
+	}
+
+	if identity.IsEmpty() {
+		log.WithFields(f).Debug("contributor has no account ID, username or email")
+		return &Verdict{Identity: identity, Reason: ReasonMissingID}
+	}
+
+	users := e.findUsers(f, identity, trace)
+	if len(users) == 0 {
+		log.WithFields(f).Debug("unable to find the EasyCLA user of the contributor")
+		return &Verdict{Identity: identity, Reason: ReasonMissingID}
+	}
+
+	// the same email can be recorded on several users, the contributor is authorized by any of them
+	var best *Verdict
+	for _, user := range users {
+		userTrace := trace.user(user)
+		verdict := e.evaluateUser(ctx, f, claGroupID, identity, user, userTrace)
+		if userTrace != nil {
+			userTrace.Verdict = verdict
+		}
+		if verdict.Authorized {
+			log.WithFields(f).Debugf("contributor is authorized by user: %s - %s", user.UserID, verdict.Reason)
+			return verdict
+		}
+		if best == nil || verdict.rank() > best.rank() {
+			best = verdict
+		}
+	}
+
+	log.WithFields(f).Debugf("contributor is not authorized - %s", best.Reason)
+	return best
+}
+
+// evaluateUser checks the ICLA of the user, then the approval lists of the corporate signature of the company of the
+// user and the employee acknowledgement
+func (e *Evaluator) evaluateUser(ctx context.Context, f logrus.Fields, claGroupID string, identity Identity, user *models.User, trace *UserTrace) *Verdict {
+	verdict := &Verdict{Identity: identity, UserID: user.UserID, CompanyID: user.CompanyID}
+
+	icla, err := e.signatures.GetIndividualSignature(ctx, claGroupID, user.UserID, aws.Bool(true), aws.Bool(true))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem checking for ICLA signature for user: %s", user.UserID)
+		verdict.Reason, verdict.Err = ReasonError, err
+		return verdict
+	}
+	if icla != nil {
+		if trace != nil {
+			trace.IndividualSignatureID = icla.SignatureID
+		}
+		verdict.Authorized, verdict.Reason, verdict.SignatureID = true, ReasonICLA, icla.SignatureID
+		return verdict
+	}
+
+	if user.CompanyID == "" {
+		verdict.Reason = ReasonNotSigned
+		return verdict
+	}
+
+	corporateSignature, err := e.signatures.GetCorporateSignature(ctx, claGroupID, user.CompanyID, aws.Bool(true), aws.Bool(true))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem checking for CCLA signature for company: %s", user.CompanyID)
+		verdict.Reason, verdict.Err = ReasonError, err
+		return verdict
+	}
+	if corporateSignature == nil {
+		verdict.Reason = ReasonNotSigned
+		return verdict
+	}
+	verdict.SignatureID = corporateSignature.SignatureID
+	if trace != nil {
+		trace.CorporateSignatureID = corporateSignature.SignatureID
+	}
+
+	verdict.ApprovalRule = e.matchApprovalLists(ctx, corporateSignature, identity, user, trace)
+	if verdict.ApprovalRule == nil {
+		verdict.Reason = ReasonNotApproved
+		return verdict
+	}
+
+	employeeSignature, err := e.signatures.GetEmployeeSignature(ctx, claGroupID, user.CompanyID, user.UserID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem checking for the employee acknowledgement of user: %s", user.UserID)
+		verdict.Reason, verdict.Err = ReasonError, err
+		return verdict
+	}
+	if employeeSignature == nil {
+		verdict.Reason = ReasonMissingAffiliation
+		return verdict
+	}
+
+	if trace != nil {
+		trace.EmployeeSignatureID = employeeSignature.SignatureID
+	}
+	verdict.Authorized, verdict.Reason, verdict.SignatureID = true, ReasonECLA, employeeSignature.SignatureID
+	return verdict
+}
+
+// findUsers locates the EasyCLA users of the identity - by the account ID and username of the provider, then by email
+func (e *Evaluator) findUsers(f logrus.Fields, identity Identity, trace *Trace) []*models.User {
+	var user *models.User
+	var err error
+
+	switch identity.Provider {
+	case utils.GitHubType:
+		if identity.ExternalID != "" {
+			if user, err = e.users.GetUserByGitHubID(identity.ExternalID); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to get user by github id: %s", identity.ExternalID)
+			}
+			trace.lookup(LookupGitHubID, identity.ExternalID, []*models.User{user}, err)
+		}
+		if user == nil && identity.Username != "" {
+			if user, err = e.users.GetUserByGitHubUsername(identity.Username); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to get user by github username: %s", identity.Username)
+			}
+			trace.lookup(LookupGitHubUsername, identity.Username, []*models.User{user}, err)
+		}
+	case utils.GitLabLower:
+		if gitlabID, convErr := strconv.Atoi(identity.ExternalID); convErr == nil && gitlabID != 0 {
+			if user, err = e.users.GetUserByGitlabID(gitlabID); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to get user by gitlab id: %d", gitlabID)
+			}
+			trace.lookup(LookupGitLabID, identity.ExternalID, []*models.User{user}, err)
+		}
+		if user == nil && identity.Username != "" {
+			if user, err = e.users.GetUserByGitLabUsername(identity.Username); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to get user by gitlab username: %s", identity.Username)
+			}
+			trace.lookup(LookupGitLabUsername, identity.Username, []*models.User{user}, err)
+		}
+	}
+	if user != nil {
+		return []*models.User{user}
+	}
+
+	// Bitbucket doesn't link the accounts to the EasyCLA users, the commit email identifies them - only when Bitbucket
+	// linked the commit to an account, the email of an unlinked commit is whatever the author set
+	if identity.Email == "" || (identity.Provider == utils.BitbucketLower && identity.ExternalID == "") {
+		return nil
+	}
+	user, err = e.users.GetUserByEmail(identity.Email)
+	trace.lookup(LookupLFEmail, identity.Email, []*models.User{user}, err)
+	if err == nil && user != nil {
+		return []*models.User{user}
+	}
+	// the email isn't the LF email of a user - it can be any of the emails of the users
+	users, err := e.users.GetUsersByEmail(identity.Email)
+	trace.lookup(LookupEmail, identity.Email, users, err)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to get users by email: %s", identity.Email)
+		return nil
+	}
+	return users
+}
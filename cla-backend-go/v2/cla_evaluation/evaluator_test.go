@@ -0,0 +1,436 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_evaluation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bot_allowlist"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testCLAGroupID = "cla-group-1"
+	testCompanyID  = "company-1"
+)
+
+type fakeUsers struct {
+	users []*models.User
+	calls int
+}
+
+func (u *fakeUsers) find(match func(user *models.User) bool) (*models.User, error) {
+	u.calls++
+	for _, user := range u.users {
+		if match(user) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (u *fakeUsers) GetUserByGitHubID(gitHubID string) (*models.User, error) {
+	return u.find(func(user *models.User) bool { return user.GithubID == gitHubID })
+}
+
+func (u *fakeUsers) GetUserByGitHubUsername(gitHubUsername string) (*models.User, error) {
+	return u.find(func(user *models.User) bool { return user.GithubUsername == gitHubUsername })
+}
+
+func (u *fakeUsers) GetUserByGitlabID(gitlabID int) (*models.User, error) {
+	return u.find(func(user *models.User) bool { return user.GitlabID == gitlabID })
+}
+
+func (u *fakeUsers) GetUserByGitLabUsername(gitlabUsername string) (*models.User, error) {
+	return u.find(func(user *models.User) bool { return user.GitlabUsername == gitlabUsername })
+}
+
+func (u *fakeUsers) GetUserByEmail(userEmail string) (*models.User, error) {
+	return u.find(func(user *models.User) bool { return string(user.LfEmail) == userEmail })
+}
+
+func (u *fakeUsers) GetUsersByEmail(userEmail string) ([]*models.User, error) {
+	u.calls++
+	var users []*models.User
+	for _, user := range u.users {
+		if utils.StringInSlice(userEmail, user.Emails) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+type fakeSignatures struct {
+	iclas     map[string]*models.Signature
+	cclas     map[string]*models.Signature
+	employees map[string]*models.Signature
+	err       error
+}
+
+func (s *fakeSignatures) GetIndividualSignature(ctx context.Context, claGroupID, userID string, approved, signed *bool) (*models.Signature, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.iclas[userID], nil
+}
+
+func (s *fakeSignatures) GetCorporateSignature(ctx context.Context, claGroupID, companyID string, approved, signed *bool) (*models.Signature, error) {
+	return s.cclas[companyID], nil
+}
+
+func (s *fakeSignatures) GetEmployeeSignature(ctx context.Context, claGroupID, companyID, userID string) (*models.Signature, error) {
+	return s.employees[userID], nil
+}
+
+type fakeMembership map[string][]string
+
+func (m fakeMembership) IsMember(ctx context.Context, username, organization string) (bool, error) {
+	return utils.StringInSlice(username, m[organization]), nil
+}
+
+func TestEvaluateIdentity(t *testing.T) {
+	iclaUser := &models.User{UserID: "icla-user", GithubID: "1", GithubUsername: "icla"}
+	employee := &models.User{UserID: "employee", GithubID: "2", GithubUsername: "octocat", GitlabID: 2, GitlabUsername: "tanuki",
+		CompanyID: testCompanyID, Emails: []string{"dev@example.com"}, LfEmail: "dev@linuxfoundation.org"}
+	noCompany := &models.User{UserID: "no-company", GithubID: "3", GithubUsername: "nocompany"}
+	unsignedCompany := &models.User{UserID: "unsigned-company", GithubID: "4", GithubUsername: "unsigned", CompanyID: "company-2"}
+	notAcknowledged := &models.User{UserID: "not-acknowledged", GithubID: "5", GithubUsername: "newhire", CompanyID: testCompanyID,
+		Emails: []string{"newhire@example.com"}}
+	sharedEmailUnsigned := &models.User{UserID: "shared-1", Emails: []string{"shared@example.org"}}
+	sharedEmailSigned := &models.User{UserID: "shared-2", Emails: []string{"shared@example.org"}}
+	removedEmployee := &models.User{UserID: "removed-employee", GithubID: "6", GithubUsername: "removed", CompanyID: testCompanyID,
+		Emails: []string{"removed@personal.example.net"}}
+
+	users := &fakeUsers{users: []*models.User{iclaUser, employee, noCompany, unsignedCompany, notAcknowledged, sharedEmailUnsigned, sharedEmailSigned, removedEmployee}}
+	signatures := &fakeSignatures{
+		iclas: map[string]*models.Signature{
+			iclaUser.UserID:          {SignatureID: "icla-1"},
+			sharedEmailSigned.UserID: {SignatureID: "icla-2"},
+		},
+		cclas:     map[string]*models.Signature{},
+		employees: map[string]*models.Signature{employee.UserID: {SignatureID: "ecla-1"}, removedEmployee.UserID: {SignatureID: "ecla-2"}},
+	}
+	allowlist := bot_allowlist.NewMatcher([]*bot_allowlist.DBBotAllowlistEntryModel{
+		{EntryID: "entry-1", MatchType: bot_allowlist.MatchTypeBotSuffix, Note: "build bots"},
+	})
+	evaluator := NewEvaluator(users, signatures,
+		fakeMembership{"example-org": {"octocat"}},
+		fakeMembership{"https://gitlab.com/groups/example": {"tanuki"}})
+
+	gitHubEmployee := Identity{Provider: utils.GitHubType, ExternalID: "2", Username: "octocat"}
+	gitLabEmployee := Identity{Provider: utils.GitLabLower, ExternalID: "2", Username: "tanuki"}
+
+	testCases := []struct {
+		name       string
+		identity   Identity
+		ccla       *models.Signature
+		authorized bool
+		reason     Reason
+		userID     string
+		rule       *ApprovalRule
+	}{
+		{
+			name:       "allowlisted bot is exempt",
+			identity:   Identity{Provider: utils.GitHubType, Username: "renovate[bot]"},
+			authorized: true,
+			reason:     ReasonExempt,
+		},
+		{
+			name:     "empty identity",
+			identity: Identity{Provider: utils.GitHubType},
+			reason:   ReasonMissingID,
+		},
+		{
+			name:     "unknown contributor",
+			identity: Identity{Provider: utils.GitHubType, ExternalID: "99", Username: "stranger", Email: "stranger@example.net"},
+			reason:   ReasonMissingID,
+		},
+		{
+			name:       "icla by github id",
+			identity:   Identity{Provider: utils.GitHubType, ExternalID: "1"},
+			authorized: true,
+			reason:     ReasonICLA,
+			userID:     iclaUser.UserID,
+		},
+		{
+			name:       "icla by github username",
+			identity:   Identity{Provider: utils.GitHubType, Username: "icla"},
+			authorized: true,
+			reason:     ReasonICLA,
+			userID:     iclaUser.UserID,
+		},
+		{
+			name:     "no icla and no company",
+			identity: Identity{Provider: utils.GitHubType, ExternalID: "3"},
+			reason:   ReasonNotSigned,
+			userID:   noCompany.UserID,
+		},
+		{
+			name:     "company without ccla",
+			identity: Identity{Provider: utils.GitHubType, ExternalID: "4"},
+			reason:   ReasonNotSigned,
+			userID:   unsignedCompany.UserID,
+		},
+		{
+			name:     "ccla without matching approval list",
+			identity: gitHubEmployee,
+			ccla:     &models.Signature{SignatureID: "ccla-1", EmailApprovalList: []string{"other@example.com"}, DomainApprovalList: []string{"*.foo.com"}},
+			reason:   ReasonNotApproved,
+			userID:   employee.UserID,
+		},
+		{
+			name:       "email approval list is case insensitive",
+			identity:   gitHubEmployee,
+			ccla:       &models.Signature{SignatureID: "ccla-1", EmailApprovalList: []string{"Dev@Example.com"}},
+			authorized: true,
+			reason:     ReasonECLA,
+			userID:     employee.UserID,
+			rule:       &ApprovalRule{List: ApprovalListEmail, Value: "Dev@Example.com"},
+		},
+		{
+			name:       "email approval list matches the lf email",
+			identity:   gitHubEmployee,
+			ccla:       &models.Signature{SignatureID: "ccla-1", EmailApprovalList: []string{"dev@linuxfoundation.org"}},
+			authorized: true,
+			reason:     ReasonECLA,
+			userID:     employee.UserID,
+			rule:       &ApprovalRule{List: ApprovalListEmail, Value: "dev@linuxfoundation.org"},
+		},
+		{
+			name:       "domain approval list",
+			identity:   gitHubEmployee,
+			ccla:       &models.Signature{SignatureID: "ccla-1", DomainApprovalList: []string{"example.com"}},
+			authorized: true,
+			reason:     ReasonECLA,
+			userID:     employee.UserID,
+			rule:       &ApprovalRule{List: ApprovalListDomain, Value: "example.com"},
+		},
+		{
+			name:       "github username approval list",
+			identity:   gitHubEmployee,
+			ccla:       &models.Signature{SignatureID: "ccla-1", GithubUsernameApprovalList: []string{"OctoCat"}},
+			authorized: true,
+			reason:     ReasonECLA,
+			userID:     employee.UserID,
+			rule:       &ApprovalRule{List: ApprovalListGitHubUsername, Value: "OctoCat"},
+		},
+		{
+			name:       "github org approval list",
+			identity:   gitHubEmployee,
+			ccla:       &models.Signature{SignatureID: "ccla-1", GithubOrgApprovalList: []string{"other-org", "example-org"}},
+			authorized: true,
+			reason:     ReasonECLA,
+			userID:     employee.UserID,
+			rule:       &ApprovalRule{List: ApprovalListGitHubOrg, Value: "example-org"},
+		},
+		{
+			name:     "github org approval list without membership",
+			identity: gitHubEmployee,
+			ccla:     &models.Signature{SignatureID: "ccla-1", GithubOrgApprovalList: []string{"other-org"}},
+			reason:   ReasonNotApproved,
+			userID:   employee.UserID,
+		},
+		{
+			name:       "gitlab username approval list",
+			identity:   gitLabEmployee,
+			ccla:       &models.Signature{SignatureID: "ccla-1", GitlabUsernameApprovalList: []string{"tanuki"}},
+			authorized: true,
+			reason:     ReasonECLA,
+			userID:     employee.UserID,
+			rule:       &ApprovalRule{List: ApprovalListGitLabUsername, Value: "tanuki"},
+		},
+		{
+			name:       "gitlab group approval list",
+			identity:   gitLabEmployee,
+			ccla:       &models.Signature{SignatureID: "ccla-1", GitlabOrgApprovalList: []string{"https://gitlab.com/groups/example"}},
+			authorized: true,
+			reason:     ReasonECLA,
+			userID:     employee.UserID,
+			rule:       &ApprovalRule{List: ApprovalListGitLabGroup, Value: "https://gitlab.com/groups/example"},
+		},
+		{
+			name:       "gitlab user looked up by username",
+			identity:   Identity{Provider: utils.GitLabLower, Username: "tanuki"},
+			ccla:       &models.Signature{SignatureID: "ccla-1", GitlabUsernameApprovalList: []string{"tanuki"}},
+			authorized: true,
+			reason:     ReasonECLA,
+			userID:     employee.UserID,
+			rule:       &ApprovalRule{List: ApprovalListGitLabUsername, Value: "tanuki"},
+		},
+		{
+			name:       "bitbucket author looked up by email",
+			identity:   Identity{Provider: utils.BitbucketLower, ExternalID: "557058:dev", Username: "dev", Email: "dev@example.com", EmailVerified: true},
+			ccla:       &models.Signature{SignatureID: "ccla-1", DomainApprovalList: []string{"*.example.com"}},
+			authorized: true,
+			reason:     ReasonECLA,
+			userID:     employee.UserID,
+			rule:       &ApprovalRule{List: ApprovalListDomain, Value: "*.example.com"},
+		},
+		{
+			name:     "unverified commit email in an approved domain",
+			identity: Identity{Provider: utils.GitHubType, ExternalID: "6", Username: "removed", Email: "removed@example.com"},
+			ccla:     &models.Signature{SignatureID: "ccla-1", DomainApprovalList: []string{"example.com"}, EmailApprovalList: []string{"removed@example.com"}},
+			reason:   ReasonNotApproved,
+			userID:   removedEmployee.UserID,
+		},
+		{
+			name:       "verified email in an approved domain",
+			identity:   Identity{Provider: utils.GitHubType, ExternalID: "6", Username: "removed", Email: "removed@example.com", EmailVerified: true},
+			ccla:       &models.Signature{SignatureID: "ccla-1", DomainApprovalList: []string{"example.com"}},
+			authorized: true,
+			reason:     ReasonECLA,
+			userID:     removedEmployee.UserID,
+			rule:       &ApprovalRule{List: ApprovalListDomain, Value: "example.com"},
+		},
+		{
+			name:     "approved without the employee acknowledgement",
+			identity: Identity{Provider: utils.GitHubType, ExternalID: "5"},
+			ccla:     &models.Signature{SignatureID: "ccla-1", DomainApprovalList: []string{"example.com"}},
+			reason:   ReasonMissingAffiliation,
+			userID:   notAcknowledged.UserID,
+			rule:     &ApprovalRule{List: ApprovalListDomain, Value: "example.com"},
+		},
+		{
+			name:       "any of the users with the email is authorized",
+			identity:   Identity{Provider: utils.BitbucketLower, ExternalID: "557058:shared", Email: "shared@example.org", EmailVerified: true},
+			authorized: true,
+			reason:     ReasonICLA,
+			userID:     sharedEmailSigned.UserID,
+		},
+		{
+			name:     "unlinked bitbucket author with the email of a signer",
+			identity: Identity{Provider: utils.BitbucketLower, Username: "dev", Email: "dev@example.com"},
+			ccla:     &models.Signature{SignatureID: "ccla-1", DomainApprovalList: []string{"*.example.com"}},
+			reason:   ReasonMissingID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signatures.cclas[testCompanyID] = tc.ccla
+			verdict := evaluator.EvaluateIdentity(context.Background(), testCLAGroupID, tc.identity, allowlist)
+			assert.Equal(t, tc.authorized, verdict.Authorized)
+			assert.Equal(t, tc.reason, verdict.Reason)
+			assert.Equal(t, tc.userID, verdict.UserID)
+			assert.Equal(t, tc.rule, verdict.ApprovalRule)
+			assert.Equal(t, tc.identity, verdict.Identity)
+		})
+	}
+}
+
+func TestEvaluateIdentityExemptEntry(t *testing.T) {
+	entry := &bot_allowlist.DBBotAllowlistEntryModel{EntryID: "entry-1", MatchType: bot_allowlist.MatchTypeEmail, Value: "*@ci.example.org"}
+	users := &fakeUsers{}
+	evaluator := NewEvaluator(users, &fakeSignatures{}, nil, nil)
+
+	verdict := evaluator.EvaluateIdentity(context.Background(), testCLAGroupID,
+		Identity{Provider: utils.GitHubType, ExternalID: "7", Username: "builder", Email: "builder@ci.example.org", EmailVerified: true}, bot_allowlist.NewMatcher([]*bot_allowlist.DBBotAllowlistEntryModel{entry}))
+	assert.True(t, verdict.Exempt())
+	assert.Equal(t, entry, verdict.ExemptEntry)
+	assert.Equal(t, 0, users.calls, "exempt contributors are not looked up")
+
+	verdict = evaluator.EvaluateIdentity(context.Background(), testCLAGroupID,
+		Identity{Provider: utils.GitLabLower, Username: "builder", Email: "builder@ci.example.org"}, bot_allowlist.NewMatcher([]*bot_allowlist.DBBotAllowlistEntryModel{entry}))
+	assert.False(t, verdict.Exempt(), "unverified commit emails are not exempt")
+}
+
+func TestEvaluateIdentityLookupError(t *testing.T) {
+	lookupErr := errors.New("dynamodb unavailable")
+	users := &fakeUsers{users: []*models.User{{UserID: "user-1", GithubID: "1"}}}
+	evaluator := NewEvaluator(users, &fakeSignatures{err: lookupErr}, nil, nil)
+
+	verdict := evaluator.EvaluateIdentity(context.Background(), testCLAGroupID, Identity{Provider: utils.GitHubType, ExternalID: "1"}, nil)
+	assert.False(t, verdict.Authorized)
+	assert.Equal(t, ReasonError, verdict.Reason)
+	assert.Equal(t, lookupErr, verdict.Err)
+}
+
+func TestEvaluateDeduplicatesIdentities(t *testing.T) {
+	users := &fakeUsers{users: []*models.User{{UserID: "user-1", GithubID: "1"}}}
+	signatures := &fakeSignatures{iclas: map[string]*models.Signature{"user-1": {SignatureID: "icla-1"}}}
+	evaluator := NewEvaluator(users, signatures, nil, nil)
+
+	identities := []Identity{
+		{Provider: utils.GitHubType, ExternalID: "1", Email: "first@example.com"},
+		{Provider: utils.GitHubType, ExternalID: "2"},
+		{Provider: utils.GitHubType, ExternalID: "1", Email: "second@example.com"},
+	}
+	verdicts := evaluator.Evaluate(context.Background(), testCLAGroupID, identities, nil)
+	if assert.Len(t, verdicts, 3) {
+		assert.True(t, verdicts[0].Authorized)
+		assert.False(t, verdicts[1].Authorized)
+		assert.True(t, verdicts[2].Authorized)
+		assert.Equal(t, identities[2], verdicts[2].Identity, "the copied verdict keeps its own identity")
+	}
+	// the second identity isn't found by ID or email, the third one isn't looked up again
+	assert.Equal(t, 2, users.calls)
+}
+
+func TestMatchDomainPattern(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		email   string
+		match   bool
+	}{
+		{pattern: "example.com", email: "dev@example.com", match: true},
+		{pattern: "example.com", email: "dev@EXAMPLE.com", match: true},
+		{pattern: "example.com", email: "dev@eng.example.com", match: false},
+		{pattern: "*example.com", email: "dev@example.com", match: true},
+		{pattern: "*example.com", email: "dev@myexample.com", match: true},
+		{pattern: "*.example.com", email: "dev@eng.example.com", match: true},
+		{pattern: ".example.com", email: "dev@eng.example.com", match: true},
+		{pattern: "*.foo.com", email: "dev@example.com", match: false},
+		{pattern: "example.com", email: "dev@example.com.evil.org", match: false},
+	}
+	for _, tc := range testCases {
+		t.Run(strings.Join([]string{tc.pattern, tc.email}, " "), func(t *testing.T) {
+			assert.Equal(t, tc.match, MatchDomainPattern(tc.pattern, tc.email))
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	unsigned := &models.User{UserID: "user-1", Emails: []string{"dev@example.com"}}
+	employee := &models.User{UserID: "user-2", GithubUsername: "octocat", CompanyID: testCompanyID, Emails: []string{"dev@example.com"}}
+	users := &fakeUsers{users: []*models.User{unsigned, employee}}
+	signatures := &fakeSignatures{
+		cclas: map[string]*models.Signature{testCompanyID: {
+			SignatureID:                "ccla-1",
+			EmailApprovalList:          []string{"someone@example.com", "other@example.com"},
+			GithubUsernameApprovalList: []string{"octocat"},
+		}},
+		employees: map[string]*models.Signature{employee.UserID: {SignatureID: "ecla-1"}},
+	}
+	evaluator := NewEvaluator(users, signatures, nil, nil)
+
+	// the Bitbucket authors are looked up by email, the GitHub username approval list applies to the linked user
+	trace := evaluator.Explain(context.Background(), testCLAGroupID, Identity{Provider: utils.BitbucketLower, ExternalID: "557058:dev", Username: "dev", Email: "dev@example.com", EmailVerified: true}, nil)
+	assert.True(t, trace.Verdict.Authorized)
+	assert.Equal(t, ReasonECLA, trace.Verdict.Reason)
+
+	if assert.Len(t, trace.Lookups, 2) {
+		assert.Equal(t, &Lookup{Method: LookupLFEmail, Value: "dev@example.com"}, trace.Lookups[0])
+		assert.Equal(t, &Lookup{Method: LookupEmail, Value: "dev@example.com", UserIDs: []string{"user-1", "user-2"}}, trace.Lookups[1])
+	}
+
+	if assert.Len(t, trace.Users, 2) {
+		assert.Equal(t, ReasonNotSigned, trace.Users[0].Verdict.Reason)
+		assert.Empty(t, trace.Users[0].Criteria)
+
+		userTrace := trace.Users[1]
+		assert.Equal(t, "ccla-1", userTrace.CorporateSignatureID)
+		assert.Equal(t, "ecla-1", userTrace.EmployeeSignatureID)
+		assert.Equal(t, []*Criterion{
+			{List: ApprovalListEmail, Values: []string{"dev@example.com"}, Entries: 2},
+			{List: ApprovalListDomain, Values: []string{"dev@example.com"}},
+			{List: ApprovalListGitHubUsername, Values: []string{"octocat"}, Entries: 1, Matched: "octocat"},
+		}, userTrace.Criteria)
+		assert.Equal(t, trace.Verdict, userTrace.Verdict)
+	}
+}
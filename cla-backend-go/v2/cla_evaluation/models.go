@@ -0,0 +1,226 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_evaluation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bot_allowlist"
+)
+
+// Identity is a contributor of a change request - a commit author of a GitHub or Bitbucket pull request or a GitLab
+// merge request participant - normalized across the repository providers
+type Identity struct {
+	// Provider is the repository provider - utils.GitHubType, utils.GitLabLower or utils.BitbucketLower
+	Provider string
+	// ExternalID is the ID of the account on the provider, empty when the commit isn't linked to an account
+	ExternalID string
+	Username   string
+	Email      string
+	Name       string
+	// EmailVerified is set when the provider verified the email belongs to the account, only the verified emails are
+	// matched against the email entries of the bot allowlist
+	EmailVerified bool
+	// Bot is set when the provider reports the account as a bot, such as the GitHub App accounts
+	Bot bool
+}
+
+// Key returns the key identifying the contributor, the contributors with many commits are only evaluated once
+func (i Identity) Key() string {
+	if i.ExternalID != "" {
+		return fmt.Sprintf("%s:id:%s", i.Provider, i.ExternalID)
+	}
+	if i.Username != "" {
+		return fmt.Sprintf("%s:username:%s", i.Provider, strings.ToLower(i.Username))
+	}
+	return fmt.Sprintf("%s:email:%s", i.Provider, strings.ToLower(strings.TrimSpace(i.Email)))
+}
+
+// IsEmpty returns true if the identity can't be looked up - no account ID, username or email
+func (i Identity) IsEmpty() bool {
+	return i.ExternalID == "" && i.Username == "" && strings.TrimSpace(i.Email) == ""
+}
+
+// Reason explains the verdict of a contributor
+type Reason string
+
+// verdict reasons
+const (
+	// ReasonICLA the contributor signed an individual CLA
+	ReasonICLA Reason = "icla"
+	// ReasonECLA the contributor is on an approval list of a corporate CLA and acknowledged the employee CLA
+	ReasonECLA Reason = "ecla"
+	// ReasonExempt the contributor is exempt by the bot allowlist
+	ReasonExempt Reason = "exempt"
+	// ReasonMissingID the contributor isn't linked to an EasyCLA user
+	ReasonMissingID Reason = "missing_id"
+	// ReasonNotSigned the contributor hasn't signed an individual CLA and the company hasn't signed a corporate CLA
+	ReasonNotSigned Reason = "not_signed"
+	// ReasonNotApproved the company signed a corporate CLA but the contributor isn't on its approval lists
+	ReasonNotApproved Reason = "not_approved"
+	// ReasonMissingAffiliation the contributor is approved but hasn't acknowledged the employee CLA
+	ReasonMissingAffiliation Reason = "missing_affiliation"
+	// ReasonError the signatures of the contributor couldn't be checked
+	ReasonError Reason = "error"
+)
+
+// approval lists of the corporate signatures
+const (
+	ApprovalListEmail          = "email"
+	ApprovalListDomain         = "domain"
+	ApprovalListGitHubUsername = "github_username"
+	ApprovalListGitHubOrg      = "github_org"
+	ApprovalListGitLabUsername = "gitlab_username"
+	ApprovalListGitLabGroup    = "gitlab_group"
+)
+
+// ApprovalRule is the approval list entry of the corporate signature matching the contributor
+type ApprovalRule struct {
+	List  string
+	Value string
+}
+
+// String returns the rule as list:value
+func (r *ApprovalRule) String() string {
+	if r == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s", r.List, r.Value)
+}
+
+// Verdict is the result of the CLA check of a contributor
+type Verdict struct {
+	Identity   Identity
+	Authorized bool
+	Reason     Reason
+	// UserID is the EasyCLA user the verdict was reached for
+	UserID string
+	// CompanyID is the company the EasyCLA user is affiliated with
+	CompanyID string
+	// SignatureID is the individual, corporate or employee signature covering the contributor
+	SignatureID string
+	// ApprovalRule is set for the ReasonECLA, ReasonMissingAffiliation verdicts
+	ApprovalRule *ApprovalRule
+	// ExemptEntry is the allowlist entry of the ReasonExempt verdicts
+	ExemptEntry *bot_allowlist.DBBotAllowlistEntryModel
+	// Err is the lookup error of the ReasonError verdicts
+	Err error
+}
+
+// Exempt returns true if the contributor is exempt by the bot allowlist
+func (v *Verdict) Exempt() bool {
+	return v.Reason == ReasonExempt
+}
+
+// Affiliated returns true if the contributor is affiliated with a company
+func (v *Verdict) Affiliated() bool {
+	return v.CompanyID != ""
+}
+
+// ExemptReason returns the reason of the allowlist entry exempting the contributor
+func (v *Verdict) ExemptReason() string {
+	if v.ExemptEntry == nil {
+		return ""
+	}
+	return v.ExemptEntry.Reason()
+}
+
+// rank orders the verdicts of the EasyCLA users of a contributor, the highest rank is reported
+func (v *Verdict) rank() int {
+	switch v.Reason {
+	case ReasonICLA, ReasonECLA, ReasonExempt:
+		return 5
+	case ReasonMissingAffiliation:
+		return 4
+	case ReasonNotApproved:
+		return 3
+	case ReasonNotSigned:
+		return 2
+	case ReasonError:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// user lookups of the decision trace
+const (
+	LookupGitHubID       = "github_id"
+	LookupGitHubUsername = "github_username"
+	LookupGitLabID       = "gitlab_id"
+	LookupGitLabUsername = "gitlab_username"
+	LookupLFEmail        = "lf_email"
+	LookupEmail          = "email"
+)
+
+// Lookup is a lookup of the EasyCLA users of the contributor
+type Lookup struct {
+	Method  string
+	Value   string
+	UserIDs []string
+	Err     error
+}
+
+// Criterion is an approval list of the corporate signature checked for the contributor. Only the values of the
+// contributor and the matched entry are recorded - the approval lists name the other employees of the company.
+type Criterion struct {
+	List string
+	// Values are the emails, usernames or organization memberships of the contributor checked against the list
+	Values []string
+	// Entries is the number of entries of the list
+	Entries int
+	Matched string
+}
+
+// UserTrace is the evaluation of one of the EasyCLA users of the contributor
+type UserTrace struct {
+	User                  *models.User
+	IndividualSignatureID string
+	CorporateSignatureID  string
+	EmployeeSignatureID   string
+	Criteria              []*Criterion
+	Verdict               *Verdict
+}
+
+// check records the approval list checked for the user
+func (t *UserTrace) check(list string, values []string, entries int, matched string) {
+	if t == nil {
+		return
+	}
+	t.Criteria = append(t.Criteria, &Criterion{List: list, Values: values, Entries: entries, Matched: matched})
+}
+
+// Trace is the decision trace of the verdict of a contributor
+type Trace struct {
+	Identity Identity
+	Lookups  []*Lookup
+	Users    []*UserTrace
+	Verdict  *Verdict
+}
+
+// lookup records a lookup of the EasyCLA users of the contributor
+func (t *Trace) lookup(method, value string, users []*models.User, err error) {
+	if t == nil {
+		return
+	}
+	lookup := &Lookup{Method: method, Value: value, Err: err}
+	for _, user := range users {
+		if user != nil {
+			lookup.UserIDs = append(lookup.UserIDs, user.UserID)
+		}
+	}
+	t.Lookups = append(t.Lookups, lookup)
+}
+
+// user adds the evaluation of an EasyCLA user of the contributor
+func (t *Trace) user(user *models.User) *UserTrace {
+	if t == nil {
+		return nil
+	}
+	userTrace := &UserTrace{User: user}
+	t.Users = append(t.Users, userTrace)
+	return userTrace
+}
@@ -0,0 +1,170 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_group_settings
+
+import (
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/cla_group_settings"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service ServiceInterface, projectsClaGroupsRepo projects_cla_groups.Repository) { // nolint
+
+	api.ClaGroupSettingsGetClaGroupSettingsHandler = cla_group_settings.GetClaGroupSettingsHandlerFunc(
+		func(params cla_group_settings.GetClaGroupSettingsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.cla_group_settings.handlers.ClaGroupSettingsGetClaGroupSettingsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"claGroupID":     params.ClaGroupID,
+			}
+
+			if !common.IsUserAuthorizedForCLAGroup(ctx, authUser, params.ClaGroupID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to view the settings of CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return cla_group_settings.NewGetClaGroupSettingsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetSettings(ctx, params.ClaGroupID)
+			if err != nil {
+				msg := fmt.Sprintf("problem loading the settings of CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_group_settings.NewGetClaGroupSettingsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return cla_group_settings.NewGetClaGroupSettingsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.ClaGroupSettingsUpdateClaGroupSettingsHandler = cla_group_settings.UpdateClaGroupSettingsHandlerFunc(
+		func(params cla_group_settings.UpdateClaGroupSettingsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.cla_group_settings.handlers.ClaGroupSettingsUpdateClaGroupSettingsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"claGroupID":     params.ClaGroupID,
+			}
+
+			if !common.IsUserAuthorizedForCLAGroup(ctx, authUser, params.ClaGroupID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to update the settings of CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return cla_group_settings.NewUpdateClaGroupSettingsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.UpdateSettings(ctx, params.ClaGroupID, params.Body)
+			if err != nil {
+				msg := fmt.Sprintf("problem updating the settings of CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_group_settings.NewUpdateClaGroupSettingsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return cla_group_settings.NewUpdateClaGroupSettingsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.ClaGroupSettingsUpdateClaGroupCommentTemplateHandler = cla_group_settings.UpdateClaGroupCommentTemplateHandlerFunc(
+		func(params cla_group_settings.UpdateClaGroupCommentTemplateParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.cla_group_settings.handlers.ClaGroupSettingsUpdateClaGroupCommentTemplateHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"claGroupID":     params.ClaGroupID,
+			}
+
+			if !common.IsUserAuthorizedForCLAGroup(ctx, authUser, params.ClaGroupID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to update the comment template of CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return cla_group_settings.NewUpdateClaGroupCommentTemplateForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.UpdateCommentTemplate(ctx, params.ClaGroupID, params.Body)
+			if err != nil {
+				msg := fmt.Sprintf("problem updating the comment template of CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_group_settings.NewUpdateClaGroupCommentTemplateBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return cla_group_settings.NewUpdateClaGroupCommentTemplateOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.ClaGroupSettingsDeleteClaGroupCommentTemplateHandler = cla_group_settings.DeleteClaGroupCommentTemplateHandlerFunc(
+		func(params cla_group_settings.DeleteClaGroupCommentTemplateParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.cla_group_settings.handlers.ClaGroupSettingsDeleteClaGroupCommentTemplateHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"claGroupID":     params.ClaGroupID,
+			}
+
+			if !common.IsUserAuthorizedForCLAGroup(ctx, authUser, params.ClaGroupID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to remove the comment template of CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return cla_group_settings.NewDeleteClaGroupCommentTemplateForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.DeleteCommentTemplate(ctx, params.ClaGroupID)
+			if err != nil {
+				msg := fmt.Sprintf("problem removing the comment template of CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_group_settings.NewDeleteClaGroupCommentTemplateBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return cla_group_settings.NewDeleteClaGroupCommentTemplateOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.ClaGroupSettingsPreviewClaGroupCommentTemplateHandler = cla_group_settings.PreviewClaGroupCommentTemplateHandlerFunc(
+		func(params cla_group_settings.PreviewClaGroupCommentTemplateParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.cla_group_settings.handlers.ClaGroupSettingsPreviewClaGroupCommentTemplateHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"claGroupID":     params.ClaGroupID,
+			}
+
+			if !common.IsUserAuthorizedForCLAGroup(ctx, authUser, params.ClaGroupID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to preview the comment template of CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return cla_group_settings.NewPreviewClaGroupCommentTemplateForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			claGroupName := ""
+			projectCLAGroups, err := projectsClaGroupsRepo.GetProjectsIdsForClaGroup(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("unable to load the CLA Group name - rendering the preview without it")
+			} else if len(projectCLAGroups) > 0 {
+				claGroupName = projectCLAGroups[0].ClaGroupName
+			}
+
+			result, err := service.PreviewCommentTemplate(ctx, params.ClaGroupID, claGroupName, params.Body)
+			if err != nil {
+				msg := fmt.Sprintf("problem rendering the comment template of CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_group_settings.NewPreviewClaGroupCommentTemplateBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return cla_group_settings.NewPreviewClaGroupCommentTemplateOK().WithXRequestID(reqID).WithPayload(result)
+		})
+}
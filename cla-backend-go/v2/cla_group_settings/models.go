@@ -0,0 +1,115 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_group_settings
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/comment_templates"
+)
+
+// DBClaGroupSettingsModel data model for the CLA Group settings table
+type DBClaGroupSettingsModel struct {
+	ClaGroupID        string `dynamodbav:"cla_group_id" json:"cla_group_id"`
+	RequireAuthors    bool   `dynamodbav:"require_authors" json:"require_authors"`
+	RequireCommitters bool   `dynamodbav:"require_committers" json:"require_committers"`
+	RequireCoAuthors  bool   `dynamodbav:"require_co_authors" json:"require_co_authors"`
+	// the comment template is only used when CommentTemplateEnabled is set - empty templates fall back to the defaults
+	CommentTemplateEnabled bool   `dynamodbav:"comment_template_enabled" json:"comment_template_enabled"`
+	CommentTemplate        string `dynamodbav:"comment_template" json:"comment_template"`
+	BadgeTemplate          string `dynamodbav:"badge_template" json:"badge_template"`
+	CommentHelpURL         string `dynamodbav:"comment_help_url" json:"comment_help_url"`
+	CollapseSignedAuthors  bool   `dynamodbav:"collapse_signed_authors" json:"collapse_signed_authors"`
+	CollapseMissingAuthors bool   `dynamodbav:"collapse_missing_authors" json:"collapse_missing_authors"`
+	DateCreated            string `dynamodbav:"date_created" json:"date_created"`
+	DateModified           string `dynamodbav:"date_modified" json:"date_modified"`
+	Version                string `dynamodbav:"version" json:"version"`
+}
+
+// defaultSettings returns the settings used when the CLA Group has not been configured
+func defaultSettings(claGroupID string) *DBClaGroupSettingsModel {
+	policy := github.DefaultCommitIdentityPolicy()
+	return &DBClaGroupSettingsModel{
+		ClaGroupID:        claGroupID,
+		RequireAuthors:    policy.Authors,
+		RequireCommitters: policy.Committers,
+		RequireCoAuthors:  policy.CoAuthors,
+	}
+}
+
+// commitIdentityPolicy returns the commit identity policy of the settings
+func (m *DBClaGroupSettingsModel) commitIdentityPolicy() github.CommitIdentityPolicy {
+	return github.CommitIdentityPolicy{
+		Authors:    m.RequireAuthors,
+		Committers: m.RequireCommitters,
+		CoAuthors:  m.RequireCoAuthors,
+	}
+}
+
+// commentTemplate returns the comment template of the settings, nil if the CLA Group uses the default comment
+func (m *DBClaGroupSettingsModel) commentTemplate() *comment_templates.Template {
+	if !m.CommentTemplateEnabled {
+		return nil
+	}
+	return &comment_templates.Template{
+		Comment:         m.CommentTemplate,
+		Badge:           m.BadgeTemplate,
+		HelpURL:         m.CommentHelpURL,
+		CollapseSigned:  m.CollapseSignedAuthors,
+		CollapseMissing: m.CollapseMissingAuthors,
+	}
+}
+
+// setCommentTemplate updates the comment template of the settings, nil restores the default comment
+func (m *DBClaGroupSettingsModel) setCommentTemplate(tmpl *comment_templates.Template) {
+	m.CommentTemplateEnabled = tmpl != nil
+	if tmpl == nil {
+		tmpl = &comment_templates.Template{}
+	}
+	m.CommentTemplate = tmpl.Comment
+	m.BadgeTemplate = tmpl.Badge
+	m.CommentHelpURL = tmpl.HelpURL
+	m.CollapseSignedAuthors = tmpl.CollapseSigned
+	m.CollapseMissingAuthors = tmpl.CollapseMissing
+}
+
+// toModel converts the database model to the API model
+func (m *DBClaGroupSettingsModel) toModel() *models.ClaGroupSettings {
+	result := &models.ClaGroupSettings{
+		ClaGroupID: m.ClaGroupID,
+		CommitIdentityPolicy: &models.CommitIdentityPolicy{
+			RequireAuthors:    m.RequireAuthors,
+			RequireCommitters: m.RequireCommitters,
+			RequireCoAuthors:  m.RequireCoAuthors,
+		},
+		DateModified: m.DateModified,
+		Version:      m.Version,
+	}
+	if m.CommentTemplateEnabled {
+		result.CommentTemplate = toCommentTemplateModel(m.commentTemplate())
+	}
+	return result
+}
+
+// toCommentTemplateModel converts the comment template to the API model
+func toCommentTemplateModel(tmpl *comment_templates.Template) *models.CommentTemplate {
+	return &models.CommentTemplate{
+		Comment:         tmpl.Comment,
+		Badge:           tmpl.Badge,
+		HelpURL:         tmpl.HelpURL,
+		CollapseSigned:  tmpl.CollapseSigned,
+		CollapseMissing: tmpl.CollapseMissing,
+	}
+}
+
+// toCommentTemplate converts the API model to the comment template
+func toCommentTemplate(input *models.CommentTemplate) *comment_templates.Template {
+	return &comment_templates.Template{
+		Comment:         input.Comment,
+		Badge:           input.Badge,
+		HelpURL:         input.HelpURL,
+		CollapseSigned:  input.CollapseSigned,
+		CollapseMissing: input.CollapseMissing,
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_group_settings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// table columns
+const (
+	// ClaGroupIDColumn is the primary key of the CLA Group settings table
+	ClaGroupIDColumn = "cla_group_id"
+)
+
+// RepositoryInterface defines the CLA Group settings data access functions
+type RepositoryInterface interface {
+	GetSettings(ctx context.Context, claGroupID string) (*DBClaGroupSettingsModel, error)
+	PutSettings(ctx context.Context, settings *DBClaGroupSettingsModel) error
+}
+
+// Repository object/struct
+type Repository struct {
+	stage             string
+	dynamoDBClient    *dynamodb.DynamoDB
+	settingsTableName string
+}
+
+// NewRepository creates a new instance of the CLA Group settings repository
+func NewRepository(awsSession *session.Session, stage string) RepositoryInterface {
+	return &Repository{
+		stage:             stage,
+		dynamoDBClient:    dynamodb.New(awsSession),
+		settingsTableName: fmt.Sprintf("cla-%s-cla-group-settings", stage),
+	}
+}
+
+// GetSettings returns the settings of the CLA Group, nil if the CLA Group has not been configured
+func (repo *Repository) GetSettings(ctx context.Context, claGroupID string) (*DBClaGroupSettingsModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_group_settings.repository.GetSettings",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			ClaGroupIDColumn: {S: aws.String(claGroupID)},
+		},
+		TableName: aws.String(repo.settingsTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load CLA Group settings record")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		log.WithFields(f).Debug("unable to find CLA Group settings record - no results")
+		return nil, nil
+	}
+
+	var settings DBClaGroupSettingsModel
+	err = dynamodbattribute.UnmarshalMap(result.Item, &settings)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem decoding CLA Group settings record")
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// PutSettings creates or replaces the settings record of the CLA Group
+func (repo *Repository) PutSettings(ctx context.Context, settings *DBClaGroupSettingsModel) error {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_group_settings.repository.PutSettings",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     settings.ClaGroupID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(settings)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall CLA Group settings record")
+		return err
+	}
+
+	log.WithFields(f).Debug("saving CLA Group settings record...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.settingsTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save CLA Group settings record")
+		return err
+	}
+
+	return nil
+}
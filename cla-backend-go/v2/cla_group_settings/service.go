@@ -0,0 +1,300 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_group_settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/comment_templates"
+	"github.com/sirupsen/logrus"
+)
+
+// errors
+var (
+	// ErrEmptyCommitIdentityPolicy is returned when the policy does not require any commit identity to be covered
+	ErrEmptyCommitIdentityPolicy = errors.New("at least one of authors, committers or co-authors must be required")
+)
+
+// ServiceInterface defines the CLA Group settings service functions
+type ServiceInterface interface {
+	GetSettings(ctx context.Context, claGroupID string) (*models.ClaGroupSettings, error)
+	UpdateSettings(ctx context.Context, claGroupID string, input *models.ClaGroupSettingsInput) (*models.ClaGroupSettings, error)
+
+	UpdateCommentTemplate(ctx context.Context, claGroupID string, input *models.CommentTemplate) (*models.ClaGroupSettings, error)
+	DeleteCommentTemplate(ctx context.Context, claGroupID string) (*models.ClaGroupSettings, error)
+	PreviewCommentTemplate(ctx context.Context, claGroupID, claGroupName string, input *models.CommentTemplatePreviewInput) (*models.CommentTemplatePreview, error)
+
+	GetCommitIdentityPolicy(ctx context.Context, claGroupID string) (github.CommitIdentityPolicy, error)
+	GetCommentTemplate(ctx context.Context, claGroupID string) (*comment_templates.Template, error)
+}
+
+// Service data model
+type Service struct {
+	repo          RepositoryInterface
+	eventsService events.Service
+}
+
+// NewService creates a new CLA Group settings service
+func NewService(repo RepositoryInterface, eventsService events.Service) ServiceInterface {
+	return &Service{
+		repo:          repo,
+		eventsService: eventsService,
+	}
+}
+
+// GetSettings returns the settings of the CLA Group, or the defaults if the CLA Group has not been configured
+func (s *Service) GetSettings(ctx context.Context, claGroupID string) (*models.ClaGroupSettings, error) {
+	settings, err := s.loadSettings(ctx, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return settings.toModel(), nil
+}
+
+// UpdateSettings updates the settings of the CLA Group
+func (s *Service) UpdateSettings(ctx context.Context, claGroupID string, input *models.ClaGroupSettingsInput) (*models.ClaGroupSettings, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_group_settings.service.UpdateSettings",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	policy := input.CommitIdentityPolicy
+	if policy == nil || (!policy.RequireAuthors && !policy.RequireCommitters && !policy.RequireCoAuthors) {
+		log.WithFields(f).Warn("commit identity policy does not require any identity")
+		return nil, ErrEmptyCommitIdentityPolicy
+	}
+
+	settings, err := s.loadSettings(ctx, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	if settings.DateCreated == "" {
+		settings.DateCreated = currentTime
+	}
+	settings.DateModified = currentTime
+	settings.Version = "v1"
+	settings.RequireAuthors = policy.RequireAuthors
+	settings.RequireCommitters = policy.RequireCommitters
+	settings.RequireCoAuthors = policy.RequireCoAuthors
+
+	err = s.repo.PutSettings(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.ClaGroupSettingsUpdated,
+		CLAGroupID: claGroupID,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData: &events.ClaGroupSettingsUpdatedEventData{
+			RequireAuthors:    settings.RequireAuthors,
+			RequireCommitters: settings.RequireCommitters,
+			RequireCoAuthors:  settings.RequireCoAuthors,
+		},
+	})
+
+	return settings.toModel(), nil
+}
+
+// UpdateCommentTemplate updates the pull request and merge request comment template of the CLA Group
+func (s *Service) UpdateCommentTemplate(ctx context.Context, claGroupID string, input *models.CommentTemplate) (*models.ClaGroupSettings, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_group_settings.service.UpdateCommentTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	tmpl := toCommentTemplate(input)
+	err := comment_templates.Validate(tmpl)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid comment template")
+		return nil, err
+	}
+
+	settings, err := s.saveCommentTemplate(ctx, claGroupID, tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.ClaGroupCommentTemplateUpdated,
+		CLAGroupID: claGroupID,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData: &events.ClaGroupCommentTemplateUpdatedEventData{
+			CustomComment:   tmpl.Comment != "",
+			CustomBadge:     tmpl.Badge != "",
+			CollapseSigned:  tmpl.CollapseSigned,
+			CollapseMissing: tmpl.CollapseMissing,
+		},
+	})
+
+	return settings.toModel(), nil
+}
+
+// DeleteCommentTemplate removes the comment template of the CLA Group - the default comment is used again
+func (s *Service) DeleteCommentTemplate(ctx context.Context, claGroupID string) (*models.ClaGroupSettings, error) {
+	settings, err := s.saveCommentTemplate(ctx, claGroupID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.ClaGroupCommentTemplateDeleted,
+		CLAGroupID: claGroupID,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData:  &events.ClaGroupCommentTemplateDeletedEventData{},
+	})
+
+	return settings.toModel(), nil
+}
+
+// PreviewCommentTemplate renders the comment template with sample authors, using the stored template when the input has none
+func (s *Service) PreviewCommentTemplate(ctx context.Context, claGroupID, claGroupName string, input *models.CommentTemplatePreviewInput) (*models.CommentTemplatePreview, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_group_settings.service.PreviewCommentTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"provider":       input.Provider,
+		"allSigned":      input.AllSigned,
+	}
+
+	var tmpl *comment_templates.Template
+	if input.Template != nil {
+		tmpl = toCommentTemplate(input.Template)
+	} else {
+		settings, err := s.loadSettings(ctx, claGroupID)
+		if err != nil {
+			return nil, err
+		}
+		tmpl = settings.commentTemplate()
+	}
+	if tmpl == nil {
+		// preview the default comment layout
+		tmpl = &comment_templates.Template{}
+	}
+
+	err := comment_templates.Validate(tmpl)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid comment template")
+		return nil, err
+	}
+
+	comment, err := comment_templates.Render(tmpl, previewData(input.Provider, claGroupName, input.AllSigned))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to render the comment template")
+		return nil, err
+	}
+
+	return &models.CommentTemplatePreview{
+		Comment: comment,
+	}, nil
+}
+
+// GetCommitIdentityPolicy returns the commit identities which require CLA coverage on the pull requests of the CLA Group
+func (s *Service) GetCommitIdentityPolicy(ctx context.Context, claGroupID string) (github.CommitIdentityPolicy, error) {
+	settings, err := s.loadSettings(ctx, claGroupID)
+	if err != nil {
+		return github.DefaultCommitIdentityPolicy(), err
+	}
+	return settings.commitIdentityPolicy(), nil
+}
+
+// GetCommentTemplate returns the pull request and merge request comment template of the CLA Group, nil if the
+// CLA Group uses the default comment
+func (s *Service) GetCommentTemplate(ctx context.Context, claGroupID string) (*comment_templates.Template, error) {
+	settings, err := s.loadSettings(ctx, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return settings.commentTemplate(), nil
+}
+
+// saveCommentTemplate stores the comment template in the settings of the CLA Group, nil removes the template
+func (s *Service) saveCommentTemplate(ctx context.Context, claGroupID string, tmpl *comment_templates.Template) (*DBClaGroupSettingsModel, error) {
+	settings, err := s.loadSettings(ctx, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	if settings.DateCreated == "" {
+		settings.DateCreated = currentTime
+	}
+	settings.DateModified = currentTime
+	settings.Version = "v1"
+	settings.setCommentTemplate(tmpl)
+
+	err = s.repo.PutSettings(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// previewData returns the sample authors and links of the comment preview
+func previewData(provider, claGroupName string, allSigned bool) *comment_templates.Data {
+	if provider == "" {
+		provider = utils.GitHubType
+	}
+	landingPage := fmt.Sprintf("%s/#/?version=2", config.GetConfig().CLALandingPage)
+	signURL := fmt.Sprintf("%s/v4/repository-provider/%s/sign/preview", config.GetConfig().ClaAPIV4Base, provider)
+
+	data := &comment_templates.Data{
+		Provider:    provider,
+		ProjectName: claGroupName,
+		SignURL:     signURL,
+		HelpURL:     "https://help.github.com/en/github/committing-changes-to-your-project/why-are-my-commits-linked-to-the-wrong-user",
+		SupportURL:  "https://jira.linuxfoundation.org/servicedesk/customer/portal/4",
+		LandingPage: landingPage,
+		Signed: []*comment_templates.Author{
+			{Name: "Jane Doe", Login: "janedoe", Email: "jane.doe@example.org", Commits: []string{"3f2a9c1", "8b7e6d5"}},
+		},
+		Exempt: []*comment_templates.Author{
+			{Name: "dependabot[bot]", Login: "dependabot[bot]", Commits: []string{"c4d5e6f"}, Reason: "GitHub App bot"},
+		},
+	}
+	if provider == utils.GitLabLower {
+		data.HelpURL = "https://docs.gitlab.com/ee/user/profile/#add-emails-to-your-user-profile"
+		data.Exempt[0] = &comment_templates.Author{Name: "project_42_bot", Login: "project_42_bot", Reason: "GitLab project bot"}
+	}
+
+	if allSigned {
+		data.BadgeURL = fmt.Sprintf("%s/cla-signed.svg", config.GetConfig().CLALogoURL)
+		data.BadgeAlt = "CLA Signed"
+		data.Badge = comment_templates.BadgeHTML(landingPage, data.BadgeURL, data.BadgeAlt)
+		return data
+	}
+
+	data.Missing = []*comment_templates.Author{
+		{Name: "John Smith", Login: "johnsmith", Email: "john.smith@example.org", Commits: []string{"a1b2c3d"}, Reason: comment_templates.ReasonNotSigned},
+		{Name: "Sam Lee", Email: "sam.lee@example.com", Commits: []string{"e4f5a6b"}, Reason: comment_templates.ReasonConfirmAffiliation},
+	}
+	data.BadgeURL = fmt.Sprintf("%s/cla-not-signed.svg", config.GetConfig().CLALogoURL)
+	data.BadgeAlt = "CLA Not Signed"
+	data.Badge = comment_templates.BadgeHTML(signURL, data.BadgeURL, data.BadgeAlt)
+	return data
+}
+
+// loadSettings loads the settings record of the CLA Group, falling back to the defaults
+func (s *Service) loadSettings(ctx context.Context, claGroupID string) (*DBClaGroupSettingsModel, error) {
+	settings, err := s.repo.GetSettings(ctx, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return defaultSettings(claGroupID), nil
+	}
+	return settings, nil
+}
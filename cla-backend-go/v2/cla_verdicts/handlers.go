@@ -0,0 +1,115 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_verdicts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/cla_verdicts"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_evaluation"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service ServiceInterface, usersService users.Service, projectsClaGroupsRepo projects_cla_groups.Repository) {
+
+	api.ClaVerdictsExplainClaVerdictHandler = cla_verdicts.ExplainClaVerdictHandlerFunc(
+		func(params cla_verdicts.ExplainClaVerdictParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			identity := cla_evaluation.Identity{
+				Provider: utils.StringValue(params.Provider),
+				Username: strings.TrimSpace(utils.StringValue(params.Username)),
+				Email:    strings.TrimSpace(utils.StringValue(params.Email)),
+			}
+			f := logrus.Fields{
+				"functionName":   "v2.cla_verdicts.handlers.ClaVerdictsExplainClaVerdictHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"claGroupID":     params.ClaGroupID,
+				"provider":       identity.Provider,
+				"username":       identity.Username,
+				"email":          identity.Email,
+			}
+
+			if identity.Username == "" && identity.Email == "" {
+				msg := "the username or the email of the contributor is required"
+				log.WithFields(f).Warn(msg)
+				return cla_verdicts.NewExplainClaVerdictBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+			}
+
+			if !common.IsUserAuthorizedForCLAGroup(ctx, authUser, params.ClaGroupID, projectsClaGroupsRepo) && !isContributor(ctx, authUser, identity, usersService) {
+				msg := fmt.Sprintf("user %s does not have access to the CLA verdicts of CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return cla_verdicts.NewExplainClaVerdictForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.ExplainVerdict(ctx, params.ClaGroupID, identity, utils.StringValue(params.RepositoryID))
+			if err != nil {
+				msg := fmt.Sprintf("problem explaining the CLA verdict for CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_verdicts.NewExplainClaVerdictBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return cla_verdicts.NewExplainClaVerdictOK().WithXRequestID(reqID).WithPayload(result)
+		})
+}
+
+// isContributor returns true if the contributor is the user - the username and the email of the contributor must both
+// belong to the stored EasyCLA user of the LF login, the trace lists the other users with the email otherwise. The
+// email of the request headers is not trusted, only the emails of the user record are matched
+func isContributor(ctx context.Context, authUser *auth.User, identity cla_evaluation.Identity, usersService users.Service) bool {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_verdicts.handlers.isContributor",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"authUser":       authUser.UserName,
+	}
+
+	if authUser.UserName == "" {
+		return false
+	}
+
+	user, err := usersService.GetUserByLFUserName(authUser.UserName)
+	if err != nil || user == nil || user.UserID == "" || !strings.EqualFold(user.LfUsername, authUser.UserName) {
+		log.WithFields(f).WithError(err).Debug("unable to load the EasyCLA user of the LF login")
+		return false
+	}
+
+	if identity.Username != "" {
+		var username string
+		switch identity.Provider {
+		case utils.GitHubType:
+			username = user.GithubUsername
+		case utils.GitLabLower:
+			username = user.GitlabUsername
+		}
+		// Bitbucket accounts aren't linked to the EasyCLA users, the contributors look up their emails
+		if username == "" || !strings.EqualFold(identity.Username, username) {
+			return false
+		}
+	}
+
+	if identity.Email != "" {
+		emails := append([]string{string(user.LfEmail)}, user.Emails...)
+		for _, email := range emails {
+			if strings.EqualFold(identity.Email, strings.TrimSpace(email)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,97 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_verdicts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_evaluation"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeUsersService struct {
+	users.Service
+	users map[string]*models.User
+}
+
+func (s *fakeUsersService) GetUserByLFUserName(lfUserName string) (*models.User, error) {
+	return s.users[lfUserName], nil
+}
+
+func TestIsContributor(t *testing.T) {
+	ctx := context.Background()
+	usersService := &fakeUsersService{users: map[string]*models.User{
+		"jdoe": {UserID: "jdoe-id", LfUsername: "jdoe", LfEmail: "jdoe@linuxfoundation.org", Emails: []string{"jdoe@example.com"},
+			GithubUsername: "jdoe-gh", GitlabUsername: "jdoe-gl"},
+		// a stale record returned for the wrong LF login
+		"mallory": {UserID: "jdoe-id", LfUsername: "jdoe", LfEmail: "jdoe@linuxfoundation.org"},
+	}}
+
+	testCases := []struct {
+		name     string
+		authUser *auth.User
+		identity cla_evaluation.Identity
+		expected bool
+	}{
+		{
+			name:     "github username and stored email",
+			authUser: &auth.User{UserName: "jdoe", Email: "jdoe@example.com"},
+			identity: cla_evaluation.Identity{Provider: utils.GitHubType, Username: "JDoe-GH", Email: "jdoe@example.com"},
+			expected: true,
+		},
+		{
+			name:     "gitlab username and LF email",
+			authUser: &auth.User{UserName: "jdoe"},
+			identity: cla_evaluation.Identity{Provider: utils.GitLabLower, Username: "jdoe-gl", Email: "jdoe@linuxfoundation.org"},
+			expected: true,
+		},
+		{
+			name:     "bitbucket contributor with the stored email",
+			authUser: &auth.User{UserName: "jdoe"},
+			identity: cla_evaluation.Identity{Provider: utils.BitbucketLower, Email: "jdoe@example.com"},
+			expected: true,
+		},
+		{
+			name:     "username of another contributor",
+			authUser: &auth.User{UserName: "jdoe"},
+			identity: cla_evaluation.Identity{Provider: utils.GitHubType, Username: "octocat", Email: "jdoe@example.com"},
+			expected: false,
+		},
+		{
+			name:     "email of another contributor",
+			authUser: &auth.User{UserName: "jdoe"},
+			identity: cla_evaluation.Identity{Provider: utils.GitHubType, Username: "jdoe-gh", Email: "octocat@example.com"},
+			expected: false,
+		},
+		{
+			name:     "email header of the contributor is not trusted",
+			authUser: &auth.User{UserName: "jdoe", Email: "octocat@example.com"},
+			identity: cla_evaluation.Identity{Provider: utils.BitbucketLower, Email: "octocat@example.com"},
+			expected: false,
+		},
+		{
+			name:     "user record of another LF login",
+			authUser: &auth.User{UserName: "mallory", Email: "jdoe@example.com"},
+			identity: cla_evaluation.Identity{Provider: utils.BitbucketLower, Email: "jdoe@linuxfoundation.org"},
+			expected: false,
+		},
+		{
+			name:     "unknown LF login",
+			authUser: &auth.User{UserName: "octocat", Email: "octocat@example.com"},
+			identity: cla_evaluation.Identity{Provider: utils.GitHubType, Email: "octocat@example.com"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isContributor(ctx, tc.authUser, tc.identity, usersService))
+		})
+	}
+}
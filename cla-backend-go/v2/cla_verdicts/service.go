@@ -0,0 +1,152 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_verdicts
+
+import (
+	"context"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bot_allowlist"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_evaluation"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
+	"github.com/sirupsen/logrus"
+)
+
+// ServiceInterface explains the CLA verdicts of the contributors
+type ServiceInterface interface {
+	ExplainVerdict(ctx context.Context, claGroupID string, identity cla_evaluation.Identity, repositoryID string) (*models.ClaVerdictTrace, error)
+}
+
+// Service explains the CLA verdicts with the evaluator of the GitHub, GitLab and Bitbucket checks
+type Service struct {
+	evaluator           *cla_evaluation.Evaluator
+	companyRepo         company.IRepository
+	botAllowlistService bot_allowlist.ServiceInterface
+}
+
+// NewService creates a new CLA verdict service
+func NewService(usersService users.Service, signaturesRepo signatures.SignatureRepository, companyRepo company.IRepository, botAllowlistService bot_allowlist.ServiceInterface, gitlabOrgService gitlab_organizations.ServiceInterface) *Service {
+	return &Service{
+		evaluator:           cla_evaluation.NewEvaluator(usersService, signaturesRepo, github.OrganizationMembership{}, gitlab_organizations.NewGroupMembership(gitlabOrgService)),
+		companyRepo:         companyRepo,
+		botAllowlistService: botAllowlistService,
+	}
+}
+
+// ExplainVerdict evaluates the contributor for the CLA Group and returns the decision trace
+func (s *Service) ExplainVerdict(ctx context.Context, claGroupID string, identity cla_evaluation.Identity, repositoryID string) (*models.ClaVerdictTrace, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_verdicts.service.ExplainVerdict",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"provider":       identity.Provider,
+		"username":       identity.Username,
+		"email":          identity.Email,
+		"repositoryID":   repositoryID,
+	}
+
+	allowlist, err := s.botAllowlistService.GetMatcher(ctx, claGroupID, repositoryID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the bot allowlist")
+		return nil, err
+	}
+
+	trace := s.evaluator.Explain(ctx, claGroupID, identity, allowlist)
+	log.WithFields(f).Debugf("contributor verdict: %s", trace.Verdict.Reason)
+	return s.toModel(ctx, claGroupID, trace), nil
+}
+
+// toModel converts the decision trace to the API model, along with the names of the candidate companies
+func (s *Service) toModel(ctx context.Context, claGroupID string, trace *cla_evaluation.Trace) *models.ClaVerdictTrace {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_verdicts.service.toModel",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	verdict := trace.Verdict
+	result := &models.ClaVerdictTrace{
+		ClaGroupID:   claGroupID,
+		Provider:     trace.Identity.Provider,
+		Username:     trace.Identity.Username,
+		Email:        trace.Identity.Email,
+		Authorized:   verdict.Authorized,
+		Reason:       string(verdict.Reason),
+		UserID:       verdict.UserID,
+		CompanyID:    verdict.CompanyID,
+		SignatureID:  verdict.SignatureID,
+		ExemptReason: verdict.ExemptReason(),
+		Lookups:      []*models.ClaVerdictLookup{},
+		Users:        []*models.ClaVerdictUser{},
+	}
+	if verdict.ApprovalRule != nil {
+		result.ApprovalList = verdict.ApprovalRule.List
+		result.ApprovalValue = verdict.ApprovalRule.Value
+	}
+	if verdict.ExemptEntry != nil {
+		result.ExemptEntryID = verdict.ExemptEntry.EntryID
+	}
+	if verdict.Err != nil {
+		result.Error = verdict.Err.Error()
+	}
+
+	for _, lookup := range trace.Lookups {
+		lookupModel := &models.ClaVerdictLookup{
+			Method:  lookup.Method,
+			Value:   lookup.Value,
+			UserIds: lookup.UserIDs,
+		}
+		if lookup.Err != nil {
+			lookupModel.Error = lookup.Err.Error()
+		}
+		result.Lookups = append(result.Lookups, lookupModel)
+	}
+
+	companyNames := make(map[string]string)
+	for _, userTrace := range trace.Users {
+		userModel := &models.ClaVerdictUser{
+			UserID:                userTrace.User.UserID,
+			LfUsername:            userTrace.User.LfUsername,
+			GithubUsername:        userTrace.User.GithubUsername,
+			GitlabUsername:        userTrace.User.GitlabUsername,
+			CompanyID:             userTrace.User.CompanyID,
+			IndividualSignatureID: userTrace.IndividualSignatureID,
+			CorporateSignatureID:  userTrace.CorporateSignatureID,
+			EmployeeSignatureID:   userTrace.EmployeeSignatureID,
+			Criteria:              []*models.ClaVerdictCriterion{},
+			Authorized:            userTrace.Verdict.Authorized,
+			Reason:                string(userTrace.Verdict.Reason),
+		}
+
+		if companyID := userTrace.User.CompanyID; companyID != "" {
+			if _, ok := companyNames[companyID]; !ok {
+				companyModel, companyErr := s.companyRepo.GetCompany(ctx, companyID)
+				if companyErr != nil || companyModel == nil {
+					log.WithFields(f).WithError(companyErr).Warnf("unable to load the company: %s", companyID)
+				} else {
+					companyNames[companyID] = companyModel.CompanyName
+				}
+			}
+			userModel.CompanyName = companyNames[companyID]
+		}
+
+		for _, criterion := range userTrace.Criteria {
+			userModel.Criteria = append(userModel.Criteria, &models.ClaVerdictCriterion{
+				List:    criterion.List,
+				Values:  criterion.Values,
+				Entries: int64(criterion.Entries),
+				Matched: criterion.Matched,
+			})
+		}
+		result.Users = append(result.Users, userModel)
+	}
+
+	return result
+}
@@ -0,0 +1,219 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package comment_templates
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aymerick/raymond"
+)
+
+// MarkerPrefix identifies the pull request and merge request comments rendered from a custom template - the default
+// comments are recognized by their wording and badges, which a custom template may not contain
+const MarkerPrefix = "<!-- easycla-comment"
+
+// DefaultCommentTemplate is used when a CLA Group only customizes the badge
+const DefaultCommentTemplate = `{{{badge}}}<br/>
+
+{{{exemptAuthors}}}
+{{{signedAuthors}}}
+{{{missingAuthors}}}
+{{#if allSigned}}The committers listed above are authorized under a signed CLA.{{/if}}`
+
+// missing author reasons
+const (
+	// ReasonNotSigned indicates the author is not covered by a signed CLA
+	ReasonNotSigned = "not-signed"
+	// ReasonMissingID indicates the commit is not linked to a user account
+	ReasonMissingID = "missing-id"
+	// ReasonConfirmAffiliation indicates the author is authorized but has to confirm the company affiliation
+	ReasonConfirmAffiliation = "confirm-affiliation"
+)
+
+// Template is the comment and badge template of a CLA Group, rendered with the handlebars syntax
+type Template struct {
+	// Comment is the markdown template of the comment - DefaultCommentTemplate is used when empty
+	Comment string
+	// Badge is the template of the badge placeholder - the default EasyCLA badge is used when empty
+	Badge string
+	// HelpURL replaces the default help link
+	HelpURL string
+	// CollapseSigned renders the signed and exempt authors in a collapsed section
+	CollapseSigned bool
+	// CollapseMissing renders the missing authors in a collapsed section
+	CollapseMissing bool
+}
+
+// Author is a commit author or merge request participant listed in the comment
+type Author struct {
+	Name    string
+	Login   string
+	Email   string
+	Commits []string
+	// Reason is the exemption reason of exempt authors, or one of the Reason* values of missing authors
+	Reason string
+}
+
+// Data holds the values of the template placeholders
+type Data struct {
+	// Provider is the repository provider - github, gitlab or bitbucket
+	Provider    string
+	ProjectName string
+	SignURL     string
+	HelpURL     string
+	SupportURL  string
+	LandingPage string
+	// Badge is the default badge, used when the template does not customize the badge
+	Badge    string
+	BadgeURL string
+	BadgeAlt string
+	Signed   []*Author
+	Exempt   []*Author
+	Missing  []*Author
+}
+
+// Validate returns an error if the comment or badge template is not a valid handlebars template
+func Validate(tmpl *Template) error {
+	if _, err := raymond.Parse(tmpl.Comment); err != nil {
+		return fmt.Errorf("invalid comment template: %w", err)
+	}
+	if _, err := raymond.Parse(tmpl.Badge); err != nil {
+		return fmt.Errorf("invalid badge template: %w", err)
+	}
+	return nil
+}
+
+// Render renders the comment of the template - the comment ends with a marker used to find it on the next update
+func Render(tmpl *Template, data *Data) (string, error) {
+	helpURL := data.HelpURL
+	if tmpl.HelpURL != "" {
+		helpURL = tmpl.HelpURL
+	}
+	allSigned := len(data.Missing) == 0
+
+	ctx := map[string]interface{}{
+		"provider":    data.Provider,
+		"projectName": data.ProjectName,
+		"signURL":     data.SignURL,
+		"helpURL":     helpURL,
+		"supportURL":  data.SupportURL,
+		"landingPage": data.LandingPage,
+		"badgeURL":    data.BadgeURL,
+		"badgeAlt":    data.BadgeAlt,
+		"allSigned":   allSigned,
+		"signed":      authorsContext(data.Signed),
+		"exempt":      authorsContext(data.Exempt),
+		"missing":     authorsContext(data.Missing),
+		"signedAuthors": collapse(tmpl.CollapseSigned, fmt.Sprintf("Authorized (%d)", len(data.Signed)),
+			authorList(data.Signed, func(author *Author) string {
+				return fmt.Sprintf(":white_check_mark: %s%s", authorInfo(author), commits(author))
+			})),
+		"exemptAuthors": collapse(tmpl.CollapseSigned, fmt.Sprintf("Exempt (%d)", len(data.Exempt)),
+			authorList(data.Exempt, func(author *Author) string {
+				return fmt.Sprintf(":white_check_mark: %s%s - exempt: %s", authorInfo(author), commits(author), author.Reason)
+			})),
+		"missingAuthors": collapse(tmpl.CollapseMissing, fmt.Sprintf("Missing CLA authorization (%d)", len(data.Missing)),
+			authorList(data.Missing, func(author *Author) string {
+				return fmt.Sprintf(":x: %s%s - %s", authorInfo(author), commits(author), missingReason(author.Reason, data.SignURL, helpURL))
+			})),
+	}
+
+	badge := data.Badge
+	if tmpl.Badge != "" {
+		rendered, err := raymond.Render(tmpl.Badge, ctx)
+		if err != nil {
+			return "", fmt.Errorf("rendering badge template: %w", err)
+		}
+		badge = rendered
+	}
+	ctx["badge"] = badge
+
+	comment := tmpl.Comment
+	if comment == "" {
+		comment = DefaultCommentTemplate
+	}
+	result, err := raymond.Render(comment, ctx)
+	if err != nil {
+		return "", fmt.Errorf("rendering comment template: %w", err)
+	}
+
+	return fmt.Sprintf("%s\n\n%s", strings.TrimSpace(result), StatusMarker(allSigned)), nil
+}
+
+// BadgeHTML returns the markup of the default EasyCLA badge
+func BadgeHTML(link, imageURL, alt string) string {
+	return fmt.Sprintf(`<a href="%s"><img src="%s" alt="%s" align="left" height="28" width="328" ></a>`, link, imageURL, alt)
+}
+
+// StatusMarker returns the hidden marker appended to the rendered comments
+func StatusMarker(allSigned bool) string {
+	if allSigned {
+		return MarkerPrefix + ": passed -->"
+	}
+	return MarkerPrefix + ": failed -->"
+}
+
+// authorsContext converts the authors for the each blocks of the templates
+func authorsContext(authors []*Author) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(authors))
+	for _, author := range authors {
+		result = append(result, map[string]interface{}{
+			"name":    author.Name,
+			"login":   author.Login,
+			"email":   author.Email,
+			"commits": strings.Join(author.Commits, ", "),
+			"reason":  author.Reason,
+		})
+	}
+	return result
+}
+
+// authorList renders the authors as a markdown list
+func authorList(authors []*Author, line func(author *Author) string) string {
+	var sb strings.Builder
+	for _, author := range authors {
+		sb.WriteString(fmt.Sprintf("- %s\n", line(author)))
+	}
+	return sb.String()
+}
+
+// collapse wraps the list in a collapsed section when enabled
+func collapse(enabled bool, summary, list string) string {
+	if !enabled || list == "" {
+		return list
+	}
+	return fmt.Sprintf("<details><summary>%s</summary>\n\n%s\n</details>\n", summary, list)
+}
+
+func authorInfo(author *Author) string {
+	switch {
+	case author.Login != "":
+		return fmt.Sprintf("@%s", author.Login)
+	case author.Email != "":
+		return author.Email
+	case author.Name != "":
+		return author.Name
+	default:
+		return "unknown"
+	}
+}
+
+func commits(author *Author) string {
+	if len(author.Commits) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", strings.Join(author.Commits, ", "))
+}
+
+func missingReason(reason, signURL, helpURL string) string {
+	switch reason {
+	case ReasonMissingID:
+		return fmt.Sprintf("the commit is not linked to a user account, see the [help](%s) to resolve.", helpURL)
+	case ReasonConfirmAffiliation:
+		return fmt.Sprintf("authorized, but the company affiliation has to be [confirmed](%s).", signURL)
+	default:
+		return fmt.Sprintf("not authorized under a signed CLA, [please click here to be authorized](%s).", signURL)
+	}
+}
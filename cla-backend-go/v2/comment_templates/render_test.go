@@ -0,0 +1,107 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package comment_templates
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testData() *Data {
+	return &Data{
+		Provider:    "github",
+		ProjectName: "Example Project",
+		SignURL:     "https://sign.example.org",
+		HelpURL:     "https://help.example.org",
+		Badge:       `<img src="cla-not-signed.svg">`,
+		Signed: []*Author{
+			{Login: "octocat", Commits: []string{"abc123"}},
+		},
+		Exempt: []*Author{
+			{Login: "dependabot[bot]", Reason: "GitHub App bot"},
+		},
+		Missing: []*Author{
+			{Email: "jane@example.org", Commits: []string{"def456", "fed654"}, Reason: ReasonNotSigned},
+			{Name: "John", Reason: ReasonMissingID},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tmpl     *Template
+		data     *Data
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "default comment with custom badge",
+			tmpl: &Template{Badge: `![{{projectName}}]({{signURL}})`},
+			data: testData(),
+			contains: []string{
+				"![Example Project](https://sign.example.org)",
+				"- :white_check_mark: @octocat (abc123)",
+				"- :white_check_mark: @dependabot[bot] - exempt: GitHub App bot",
+				"- :x: jane@example.org (def456, fed654) - not authorized under a signed CLA",
+				"- :x: John - the commit is not linked to a user account, see the [help](https://help.example.org)",
+				StatusMarker(false),
+			},
+			excludes: []string{"cla-not-signed.svg", "<details>"},
+		},
+		{
+			name: "placeholders and each blocks",
+			tmpl: &Template{Comment: `{{{badge}}} {{projectName}}{{#each missing}} [{{email}}: {{commits}}]{{/each}}`},
+			data: testData(),
+			contains: []string{
+				`<img src="cla-not-signed.svg"> Example Project [jane@example.org: def456, fed654] [: ]`,
+			},
+		},
+		{
+			name:     "collapsed sections",
+			tmpl:     &Template{Comment: "{{{signedAuthors}}}{{{missingAuthors}}}", CollapseSigned: true, CollapseMissing: true},
+			data:     testData(),
+			contains: []string{"<details><summary>Authorized (1)</summary>", "<details><summary>Missing CLA authorization (2)</summary>"},
+		},
+		{
+			name:     "custom help link",
+			tmpl:     &Template{Comment: "{{helpURL}}", HelpURL: "https://docs.example.org"},
+			data:     testData(),
+			contains: []string{"https://docs.example.org"},
+			excludes: []string{"https://help.example.org"},
+		},
+		{
+			name: "all signed",
+			tmpl: &Template{Comment: "{{#if allSigned}}covered{{else}}not covered{{/if}}"},
+			data: &Data{Signed: []*Author{{Login: "octocat"}}},
+			contains: []string{
+				"covered",
+				StatusMarker(true),
+			},
+			excludes: []string{"not covered"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.tmpl, tc.data)
+			assert.Nil(t, err)
+			assert.True(t, strings.HasSuffix(result, StatusMarker(len(tc.data.Missing) == 0)))
+			for _, expected := range tc.contains {
+				assert.Contains(t, result, expected)
+			}
+			for _, unexpected := range tc.excludes {
+				assert.NotContains(t, result, unexpected)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(&Template{Comment: "{{#if allSigned}}ok{{/if}}", Badge: "{{{badge}}}"}))
+	assert.NotNil(t, Validate(&Template{Comment: "{{#if allSigned}}ok"}))
+	assert.NotNil(t, Validate(&Template{Badge: "{{badge"}))
+}
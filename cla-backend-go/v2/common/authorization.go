@@ -0,0 +1,44 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"context"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// IsUserAuthorizedForCLAGroup returns true if the user is an admin or a member of the foundation or one of the
+// projects of the CLA Group
+func IsUserAuthorizedForCLAGroup(ctx context.Context, authUser *auth.User, claGroupID string, projectsClaGroupsRepo projects_cla_groups.Repository) bool {
+	f := logrus.Fields{
+		"functionName":   "v2.common.authorization.IsUserAuthorizedForCLAGroup",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	if utils.IsUserAdmin(authUser) {
+		return true
+	}
+
+	projectCLAGroups, err := projectsClaGroupsRepo.GetProjectsIdsForClaGroup(ctx, claGroupID)
+	if err != nil || len(projectCLAGroups) == 0 {
+		log.WithFields(f).WithError(err).Warn("unable to load projects for CLA Group")
+		return false
+	}
+
+	if utils.IsUserAuthorizedForProjectTree(ctx, authUser, projectCLAGroups[0].FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+		return true
+	}
+
+	var projectSFIDs []string
+	for _, projectCLAGroup := range projectCLAGroups {
+		projectSFIDs = append(projectSFIDs, projectCLAGroup.ProjectSFID)
+	}
+	return utils.IsUserAuthorizedForAnyProjects(ctx, authUser, projectSFIDs, utils.ALLOW_ADMIN_SCOPE)
+}
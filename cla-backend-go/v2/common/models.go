@@ -19,6 +19,7 @@ type GitLabOrganization struct {
 	OrganizationURL         string `json:"organization_url,omitempty"`
 	OrganizationSFID        string `json:"organization_sfid,omitempty"`
 	ProjectSFID             string `json:"project_sfid"`
+	InstanceID              string `json:"instance_id,omitempty"`
 	Enabled                 bool   `json:"enabled"`
 	AutoEnabled             bool   `json:"auto_enabled"`
 	BranchProtectionEnabled bool   `json:"branch_protection_enabled"`
@@ -27,7 +28,31 @@ type GitLabOrganization struct {
 	AuthState               string `json:"auth_state"`
 	Note                    string `json:"note,omitempty"`
 	AuthExpirationTime      int    `json:"auth_expiry_time,omitempty"`
-	Version                 string `json:"version,omitempty"`
+	// WebhookSecret is the encrypted secret token of the project webhooks, the secret of the GitLab instance or the
+	// auth state is used when empty
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+	// PreviousWebhookSecret is the encrypted secret replaced by the last rotation, accepted until its expiry time
+	PreviousWebhookSecret       string `json:"previous_webhook_secret,omitempty"`
+	PreviousWebhookSecretExpiry int64  `json:"previous_webhook_secret_expiry,omitempty"`
+	WebhookSecretRotatedOn      string `json:"webhook_secret_rotated_on,omitempty"`
+	// Subgroups are the known subgroups of the group with their CLA Group and auto-enable overrides, refreshed by the
+	// GitLab repository check
+	Subgroups []*GitLabSubgroup `json:"subgroups,omitempty"`
+	Version   string            `json:"version,omitempty"`
+}
+
+// GitLabSubgroup is a subgroup of a GitLab organization - the projects of the subgroup use its CLA Group and
+// auto-enable overrides, inherited from the closest parent subgroup with an override, then from the organization
+type GitLabSubgroup struct {
+	GroupID       int64  `json:"group_id"`
+	ParentGroupID int64  `json:"parent_group_id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	FullPath      string `json:"full_path,omitempty"`
+	// ClaGroupID overrides the auto-enabled CLA Group of the organization when set
+	ClaGroupID string `json:"cla_group_id,omitempty"`
+	// AutoEnabled overrides the auto-enabled flag of the organization when set
+	AutoEnabled  *bool  `json:"auto_enabled,omitempty"`
+	DateModified string `json:"date_modified,omitempty"`
 }
 
 // ToModel converts to models.GitlabOrganization
@@ -47,6 +72,7 @@ func ToModel(in *GitLabOrganization) *models2.GitlabOrganization {
 		AutoEnabledClaGroupID:   in.AutoEnabledClaGroupID,
 		BranchProtectionEnabled: in.BranchProtectionEnabled,
 		ProjectSfid:             in.ProjectSFID,
+		InstanceID:              in.InstanceID,
 		OrganizationExternalID:  int64(in.ExternalGroupID),
 		AuthState:               in.AuthState,
 		AuthExpiryTime:          int64(in.AuthExpirationTime),
@@ -70,6 +96,7 @@ func ToCommonModel(in *models2.GitlabOrganization) *GitLabOrganization {
 		AutoEnabledClaGroupID:   in.AutoEnabledClaGroupID,
 		BranchProtectionEnabled: in.BranchProtectionEnabled,
 		ProjectSFID:             in.ProjectSfid,
+		InstanceID:              in.InstanceID,
 		ExternalGroupID:         int(in.OrganizationExternalID),
 		AuthState:               in.AuthState,
 		AuthExpirationTime:      int(in.AuthExpiryTime),
@@ -98,16 +125,62 @@ type GitLabAddOrganization struct {
 	OrganizationSFID        string `json:"organization_sfid,omitempty"`
 	ProjectSFID             string `json:"project_sfid"`
 	ParentProjectSFID       string `json:"parent_project_sfid"`
+	InstanceID              string `json:"instance_id,omitempty"`
 	Enabled                 bool   `json:"enabled"`
 	AutoEnabled             bool   `json:"auto_enabled"`
 	BranchProtectionEnabled bool   `json:"branch_protection_enabled"`
 	AutoEnabledClaGroupID   string `json:"auto_enabled_cla_group_id,omitempty"`
 	AuthInfo                string `json:"auth_info"`
 	AuthState               string `json:"auth_state"`
-	Version                 string `json:"version,omitempty"`
+	WebhookSecret           string `json:"webhook_secret,omitempty"`
+	// Subgroups are the subgroup overrides to enroll with the organization
+	Subgroups []*GitLabSubgroup `json:"subgroups,omitempty"`
+	Version   string            `json:"version,omitempty"`
 }
 
 // ExternalGroupIDAsInt returns the external group ID as an integer value
 func (m *GitLabAddOrganization) ExternalGroupIDAsInt() int {
 	return int(m.ExternalGroupID)
 }
+
+// BitbucketOrganization is data model for the Bitbucket organizations - the Bitbucket Cloud workspaces and the
+// Bitbucket Data Center projects. The access token, the webhook secret and the application secret are encrypted.
+type BitbucketOrganization struct {
+	OrganizationID        string `json:"organization_id"`
+	OrganizationKey       string `json:"organization_key"`
+	OrganizationName      string `json:"organization_name"`
+	OrganizationURL       string `json:"organization_url,omitempty"`
+	Flavor                string `json:"flavor"`
+	BaseURL               string `json:"base_url,omitempty"`
+	ProjectSFID           string `json:"project_sfid"`
+	AutoEnabled           bool   `json:"auto_enabled"`
+	AutoEnabledClaGroupID string `json:"auto_enabled_cla_group_id,omitempty"`
+	AccessToken           string `json:"access_token"`
+	WebhookSecret         string `json:"webhook_secret"`
+	AppClientID           string `json:"app_client_id,omitempty"`
+	AppClientSecret       string `json:"app_client_secret,omitempty"`
+	DateCreated           string `json:"date_created,omitempty"`
+	DateModified          string `json:"date_modified,omitempty"`
+	Note                  string `json:"note,omitempty"`
+	Version               string `json:"version,omitempty"`
+}
+
+// ToBitbucketModel converts to models.BitbucketOrganization, without the secrets
+func ToBitbucketModel(in *BitbucketOrganization) *models2.BitbucketOrganization {
+	return &models2.BitbucketOrganization{
+		OrganizationID:        in.OrganizationID,
+		OrganizationKey:       in.OrganizationKey,
+		OrganizationName:      in.OrganizationName,
+		OrganizationURL:       in.OrganizationURL,
+		Flavor:                in.Flavor,
+		BaseURL:               in.BaseURL,
+		ProjectSfid:           in.ProjectSFID,
+		AutoEnabled:           in.AutoEnabled,
+		AutoEnabledClaGroupID: in.AutoEnabledClaGroupID,
+		AppClientID:           in.AppClientID,
+		AccessTokenSet:        in.AccessToken != "",
+		DateCreated:           in.DateCreated,
+		DateModified:          in.DateModified,
+		Version:               in.Version,
+	}
+}
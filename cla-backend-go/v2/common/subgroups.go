@@ -0,0 +1,151 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// GitLab subgroup change kinds, detected when the subgroups of an organization are refreshed
+const (
+	GitLabSubgroupAdded   = "added"
+	GitLabSubgroupRenamed = "renamed"
+	GitLabSubgroupMoved   = "moved"
+	GitLabSubgroupRemoved = "removed"
+)
+
+// GitLabSubgroupSettings are the settings applying to the projects of a GitLab namespace
+type GitLabSubgroupSettings struct {
+	ClaGroupID  string
+	AutoEnabled bool
+	// ClaGroupSource and AutoEnabledSource are the IDs of the subgroups the settings are inherited from, 0 when they
+	// are the settings of the organization
+	ClaGroupSource    int64
+	AutoEnabledSource int64
+}
+
+// GitLabSubgroupChange is a change of a subgroup of an organization
+type GitLabSubgroupChange struct {
+	Kind string
+	// Subgroup is the subgroup after the change, the last known subgroup when removed
+	Subgroup              *GitLabSubgroup
+	PreviousFullPath      string
+	PreviousParentGroupID int64
+}
+
+// GetSubgroup returns the subgroup of the organization with the given GitLab group ID, nil if not known
+func (o *GitLabOrganization) GetSubgroup(groupID int64) *GitLabSubgroup {
+	for _, subgroup := range o.Subgroups {
+		if subgroup.GroupID == groupID {
+			return subgroup
+		}
+	}
+	return nil
+}
+
+// SubgroupSettings returns the settings applying to the projects of the namespace, a subgroup of the organization or
+// the organization group itself. Each setting is inherited from the closest subgroup overriding it, walking up the
+// parents, then from the organization.
+func (o *GitLabOrganization) SubgroupSettings(namespaceID int64) GitLabSubgroupSettings {
+	settings := GitLabSubgroupSettings{
+		ClaGroupID:  o.AutoEnabledClaGroupID,
+		AutoEnabled: o.AutoEnabled,
+	}
+
+	claGroupFound, autoEnabledFound := false, false
+	visited := make(map[int64]bool)
+	for groupID := namespaceID; groupID != 0 && !visited[groupID]; {
+		visited[groupID] = true
+		subgroup := o.GetSubgroup(groupID)
+		if subgroup == nil {
+			break
+		}
+		if !claGroupFound && subgroup.ClaGroupID != "" {
+			settings.ClaGroupID, settings.ClaGroupSource, claGroupFound = subgroup.ClaGroupID, subgroup.GroupID, true
+		}
+		if !autoEnabledFound && subgroup.AutoEnabled != nil {
+			settings.AutoEnabled, settings.AutoEnabledSource, autoEnabledFound = *subgroup.AutoEnabled, subgroup.GroupID, true
+		}
+		groupID = subgroup.ParentGroupID
+	}
+
+	return settings
+}
+
+// HasOverrides returns true if the subgroup overrides a setting of the organization
+func (s *GitLabSubgroup) HasOverrides() bool {
+	return s.ClaGroupID != "" || s.AutoEnabled != nil
+}
+
+// MergeSubgroups merges the subgroups currently under the organization group with the known subgroups, returning the
+// merged subgroups sorted by path and the changes. The overrides are kept by group ID, or by full path for the
+// overrides enrolled before the group ID was known. The subgroups moved out of the organization group, or deleted,
+// are removed with their overrides.
+func MergeSubgroups(known, current []*GitLabSubgroup) ([]*GitLabSubgroup, []*GitLabSubgroupChange) {
+	knownByID := make(map[int64]*GitLabSubgroup)
+	knownByPath := make(map[string]*GitLabSubgroup)
+	for _, subgroup := range known {
+		if subgroup.GroupID != 0 {
+			knownByID[subgroup.GroupID] = subgroup
+		} else if subgroup.FullPath != "" {
+			knownByPath[strings.ToLower(subgroup.FullPath)] = subgroup
+		}
+	}
+
+	var merged []*GitLabSubgroup
+	var changes []*GitLabSubgroupChange
+	matched := make(map[*GitLabSubgroup]bool)
+	for _, c := range current {
+		subgroup := &GitLabSubgroup{
+			GroupID:       c.GroupID,
+			ParentGroupID: c.ParentGroupID,
+			Name:          c.Name,
+			FullPath:      c.FullPath,
+		}
+
+		previous, ok := knownByID[c.GroupID]
+		if !ok {
+			previous, ok = knownByPath[strings.ToLower(c.FullPath)]
+		}
+		if !ok {
+			merged = append(merged, subgroup)
+			changes = append(changes, &GitLabSubgroupChange{Kind: GitLabSubgroupAdded, Subgroup: subgroup})
+			continue
+		}
+		matched[previous] = true
+		subgroup.ClaGroupID, subgroup.AutoEnabled, subgroup.DateModified = previous.ClaGroupID, previous.AutoEnabled, previous.DateModified
+		merged = append(merged, subgroup)
+
+		// the overrides enrolled with only the group ID or the full path are completed silently
+		if previous.GroupID == 0 || previous.FullPath == "" {
+			continue
+		}
+		change := &GitLabSubgroupChange{Subgroup: subgroup, PreviousFullPath: previous.FullPath, PreviousParentGroupID: previous.ParentGroupID}
+		if previous.ParentGroupID != subgroup.ParentGroupID {
+			change.Kind = GitLabSubgroupMoved
+		} else if path.Base(previous.FullPath) != path.Base(subgroup.FullPath) || (previous.Name != "" && previous.Name != subgroup.Name) {
+			change.Kind = GitLabSubgroupRenamed
+		} else {
+			// the path changed with the path of a parent, the change of the parent is reported
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	for _, subgroup := range known {
+		if !matched[subgroup] && subgroup.GroupID != 0 && subgroup.FullPath != "" {
+			changes = append(changes, &GitLabSubgroupChange{Kind: GitLabSubgroupRemoved, Subgroup: subgroup, PreviousFullPath: subgroup.FullPath, PreviousParentGroupID: subgroup.ParentGroupID})
+		} else if !matched[subgroup] {
+			// the overrides of the subgroups not found yet are kept, the subgroup may not be visible until authorized
+			merged = append(merged, subgroup)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return strings.ToLower(merged[i].FullPath) < strings.ToLower(merged[j].FullPath)
+	})
+	return merged, changes
+}
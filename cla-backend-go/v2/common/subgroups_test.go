@@ -0,0 +1,108 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+func TestSubgroupSettings(t *testing.T) {
+	org := &GitLabOrganization{
+		ExternalGroupID:       1,
+		AutoEnabled:           true,
+		AutoEnabledClaGroupID: "cla-group-org",
+		Subgroups: []*GitLabSubgroup{
+			{GroupID: 10, ParentGroupID: 1, FullPath: "org/a", ClaGroupID: "cla-group-a"},
+			{GroupID: 11, ParentGroupID: 10, FullPath: "org/a/b", AutoEnabled: boolPtr(false)},
+			{GroupID: 12, ParentGroupID: 11, FullPath: "org/a/b/c"},
+			{GroupID: 20, ParentGroupID: 1, FullPath: "org/d"},
+		},
+	}
+
+	// the organization group itself
+	assert.Equal(t, GitLabSubgroupSettings{ClaGroupID: "cla-group-org", AutoEnabled: true}, org.SubgroupSettings(1))
+	// a subgroup without overrides
+	assert.Equal(t, GitLabSubgroupSettings{ClaGroupID: "cla-group-org", AutoEnabled: true}, org.SubgroupSettings(20))
+	// each setting is inherited from the closest override
+	assert.Equal(t, GitLabSubgroupSettings{ClaGroupID: "cla-group-a", AutoEnabled: true, ClaGroupSource: 10}, org.SubgroupSettings(10))
+	assert.Equal(t, GitLabSubgroupSettings{ClaGroupID: "cla-group-a", AutoEnabled: false, ClaGroupSource: 10, AutoEnabledSource: 11}, org.SubgroupSettings(12))
+	// an unknown namespace uses the organization settings
+	assert.Equal(t, GitLabSubgroupSettings{ClaGroupID: "cla-group-org", AutoEnabled: true}, org.SubgroupSettings(99))
+}
+
+func TestSubgroupSettingsCycle(t *testing.T) {
+	org := &GitLabOrganization{
+		AutoEnabledClaGroupID: "cla-group-org",
+		Subgroups: []*GitLabSubgroup{
+			{GroupID: 10, ParentGroupID: 11},
+			{GroupID: 11, ParentGroupID: 10},
+		},
+	}
+	assert.Equal(t, "cla-group-org", org.SubgroupSettings(10).ClaGroupID)
+}
+
+func TestMergeSubgroups(t *testing.T) {
+	known := []*GitLabSubgroup{
+		{GroupID: 10, ParentGroupID: 1, Name: "a", FullPath: "org/a", ClaGroupID: "cla-group-a"},
+		{GroupID: 11, ParentGroupID: 10, Name: "b", FullPath: "org/a/b", AutoEnabled: boolPtr(false)},
+		{GroupID: 12, ParentGroupID: 1, Name: "c", FullPath: "org/c", ClaGroupID: "cla-group-c"},
+		{GroupID: 13, ParentGroupID: 1, Name: "d", FullPath: "org/d"},
+		// enrolled before the subgroups were known
+		{FullPath: "org/e", ClaGroupID: "cla-group-e"},
+		{GroupID: 15, AutoEnabled: boolPtr(true)},
+		{FullPath: "org/missing", ClaGroupID: "cla-group-missing"},
+	}
+	current := []*GitLabSubgroup{
+		// renamed, the path of the child changes too
+		{GroupID: 10, ParentGroupID: 1, Name: "a2", FullPath: "org/a2"},
+		{GroupID: 11, ParentGroupID: 10, Name: "b", FullPath: "org/a2/b"},
+		// moved under d
+		{GroupID: 12, ParentGroupID: 13, Name: "c", FullPath: "org/d/c"},
+		{GroupID: 13, ParentGroupID: 1, Name: "d", FullPath: "org/d"},
+		{GroupID: 14, ParentGroupID: 1, Name: "e", FullPath: "org/e"},
+		{GroupID: 15, ParentGroupID: 1, Name: "f", FullPath: "org/f"},
+		{GroupID: 16, ParentGroupID: 1, Name: "g", FullPath: "org/g"},
+	}
+	known = append(known, &GitLabSubgroup{GroupID: 17, ParentGroupID: 1, Name: "h", FullPath: "org/h", ClaGroupID: "cla-group-h"})
+
+	merged, changes := MergeSubgroups(known, current)
+
+	var paths []string
+	for _, subgroup := range merged {
+		paths = append(paths, subgroup.FullPath)
+	}
+	assert.Equal(t, []string{"org/a2", "org/a2/b", "org/d", "org/d/c", "org/e", "org/f", "org/g", "org/missing"}, paths)
+
+	org := &GitLabOrganization{Subgroups: merged}
+	assert.Equal(t, "cla-group-a", org.GetSubgroup(10).ClaGroupID)
+	assert.Equal(t, false, *org.GetSubgroup(11).AutoEnabled)
+	assert.Equal(t, "cla-group-c", org.GetSubgroup(12).ClaGroupID)
+	assert.Equal(t, "cla-group-e", org.GetSubgroup(14).ClaGroupID)
+	assert.Equal(t, true, *org.GetSubgroup(15).AutoEnabled)
+	assert.Nil(t, org.GetSubgroup(17))
+
+	kinds := make(map[string][]string)
+	for _, change := range changes {
+		kinds[change.Kind] = append(kinds[change.Kind], change.Subgroup.FullPath)
+	}
+	assert.Equal(t, map[string][]string{
+		GitLabSubgroupRenamed: {"org/a2"},
+		GitLabSubgroupMoved:   {"org/d/c"},
+		GitLabSubgroupAdded:   {"org/g"},
+		GitLabSubgroupRemoved: {"org/h"},
+	}, kinds)
+
+	for _, change := range changes {
+		if change.Kind == GitLabSubgroupMoved {
+			assert.Equal(t, "org/c", change.PreviousFullPath)
+			assert.Equal(t, int64(1), change.PreviousParentGroupID)
+		}
+	}
+}
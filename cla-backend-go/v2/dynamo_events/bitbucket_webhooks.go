@@ -0,0 +1,124 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// BitbucketRepoWebhookEventHandler adds the webhook of the enabled Bitbucket repositories and removes it from the
+// disabled ones
+func (s *service) BitbucketRepoWebhookEventHandler(event events.DynamoDBEventRecord) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "BitbucketRepoWebhookEventHandler",
+		"eventID":        event.EventID,
+		"eventName":      event.EventName,
+		"eventSource":    event.EventSource,
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	var newRepoModel repositories.RepositoryDBModel
+	log.WithFields(f).Debugf("processing record %s event...", event.EventName)
+	err := unmarshalStreamImage(event.Change.NewImage, &newRepoModel)
+	if err != nil {
+		log.WithFields(f).Warnf("problem unmarshalling the new repository model event, error: %+v", err)
+		return err
+	}
+
+	if newRepoModel.RepositoryType != utils.BitbucketLower {
+		return nil
+	}
+
+	if event.EventName == Modify {
+		var oldRepoModel repositories.RepositoryDBModel
+		err = unmarshalStreamImage(event.Change.OldImage, &oldRepoModel)
+		if err != nil {
+			log.WithFields(f).Warnf("problem unmarshalling the old repository model event, error: %+v", err)
+			return err
+		}
+		if newRepoModel.Enabled == oldRepoModel.Enabled {
+			log.WithFields(f).Debugf("only changes of Enabled field are processed")
+			return nil
+		}
+	}
+
+	return s.setBitbucketWebhook(ctx, f, &newRepoModel, newRepoModel.Enabled)
+}
+
+// BitbucketRepoRemovedWebhookEventHandler removes the webhook of the deleted Bitbucket repositories
+func (s *service) BitbucketRepoRemovedWebhookEventHandler(event events.DynamoDBEventRecord) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "BitbucketRepoRemovedWebhookEventHandler",
+		"eventID":        event.EventID,
+		"eventName":      event.EventName,
+		"eventSource":    event.EventSource,
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	var oldRepoModel repositories.RepositoryDBModel
+	log.WithFields(f).Debugf("processing record %s event...", event.EventName)
+	err := unmarshalStreamImage(event.Change.OldImage, &oldRepoModel)
+	if err != nil {
+		log.WithFields(f).Warnf("problem unmarshalling the old repository model event, error: %+v", err)
+		return err
+	}
+
+	if oldRepoModel.RepositoryType != utils.BitbucketLower || !oldRepoModel.Enabled {
+		return nil
+	}
+
+	return s.setBitbucketWebhook(ctx, f, &oldRepoModel, false)
+}
+
+func (s *service) setBitbucketWebhook(ctx context.Context, f logrus.Fields, repoModel *repositories.RepositoryDBModel, enabled bool) error {
+	f["repositoryName"] = repoModel.RepositoryName
+	f["enabled"] = enabled
+
+	org, err := s.bitbucketOrgRepo.GetBitbucketOrganizationByKey(ctx, repoModel.RepositoryOrganizationName)
+	if err != nil {
+		return fmt.Errorf("fetching bitbucket org : %s failed : %v", repoModel.RepositoryOrganizationName, err)
+	}
+	if org == nil {
+		// the webhooks of a deleted organization are removed along with the organization
+		log.WithFields(f).Debugf("bitbucket org : %s not found, nothing to do", repoModel.RepositoryOrganizationName)
+		return nil
+	}
+
+	client, err := s.bitbucketOrgService.GetBitbucketOrganizationClient(org)
+	if err != nil {
+		return fmt.Errorf("initializing Bitbucket client failed : %v", err)
+	}
+
+	slug := strings.TrimPrefix(repoModel.RepositoryName, org.OrganizationKey+"/")
+	webhookURL := s.bitbucketOrgService.WebhookURL(org)
+	if !enabled {
+		if err := client.RemoveWebhook(org.OrganizationName, slug, webhookURL); err != nil {
+			log.WithFields(f).WithError(err).Warn("removing bitbucket webhook failed")
+			return err
+		}
+		log.WithFields(f).Debug("bitbucket webhook removed successfully for repository")
+		return nil
+	}
+
+	secret, err := s.bitbucketOrgService.GetWebhookSecret(org)
+	if err != nil {
+		return fmt.Errorf("decrypting the webhook secret of bitbucket org : %s failed : %v", org.OrganizationKey, err)
+	}
+	if err := client.SetWebhook(org.OrganizationName, slug, webhookURL, secret); err != nil {
+		log.WithFields(f).WithError(err).Warn("adding bitbucket webhook failed")
+		return err
+	}
+	log.WithFields(f).Debug("bitbucket webhook added successfully for repository")
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"context"
+
+	"github.com/communitybridge/easycla/cla-backend-go/github/branch_protection"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// applyBranchProtectionPolicyForGithubOrg applies the branch protection policy of the GitHub organization to each of its
+// CLA enabled repositories
+func (s *service) applyBranchProtectionPolicyForGithubOrg(ctx context.Context, gitHubOrg github_organizations.GithubOrganization) error {
+	f := logrus.Fields{
+		"functionName":               "dynamo_events.github_branch_protection_policy.applyBranchProtectionPolicyForGithubOrg",
+		utils.XREQUESTID:             ctx.Value(utils.XREQUESTID),
+		"projectSFID":                gitHubOrg.ProjectSFID,
+		"organizationName":           gitHubOrg.OrganizationName,
+		"organizationInstallationID": gitHubOrg.OrganizationInstallationID,
+	}
+
+	policy := gitHubOrg.BranchProtectionPolicy.ToPolicy()
+	if policy.IsEmpty() {
+		log.WithFields(f).Debug("no branch protection policy - ignoring...")
+		return nil
+	}
+	f["defaultBranch"] = policy.DefaultBranch
+	f["branchPatterns"] = policy.BranchPatterns
+
+	log.WithFields(f).Debugf("loading repositories under the organization : %s", gitHubOrg.OrganizationName)
+	repos, err := s.repositoryService.GetRepositoriesByOrganizationName(ctx, gitHubOrg.OrganizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem locating repositories by organization name")
+		return err
+	}
+
+	branchProtectionRepo, err := branch_protection.NewBranchProtectionRepository(gitHubOrg.OrganizationInstallationID, branch_protection.EnableBlockingLimiter())
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("initializing branch protection repository failed")
+		return err
+	}
+
+	var eg errgroup.Group
+	// a pool of 5 concurrent workers
+	var workerTokens = make(chan struct{}, 5)
+	for _, repo := range repos {
+		if !repo.Enabled {
+			continue
+		}
+		// this is for goroutine local variables
+		repo := repo
+		// acquire a worker token to create a new goroutine
+		workerTokens <- struct{}{}
+		eg.Go(func() error {
+			defer func() {
+				<-workerTokens // release the workerToken
+			}()
+			log.WithFields(f).Debugf("applying the branch protection policy to the GitHub repository: %s...", repo.RepositoryName)
+			return branchProtectionRepo.ApplyPolicy(ctx, gitHubOrg.OrganizationName, repo.RepositoryName, policy)
+		})
+	}
+
+	log.WithFields(f).Debugf("waiting for %d repositories to complete...", len(repos))
+	if policyErr := eg.Wait(); policyErr != nil {
+		log.WithFields(f).WithError(policyErr).Warn("encountered branch protection policy error")
+		return policyErr
+	}
+
+	return nil
+}
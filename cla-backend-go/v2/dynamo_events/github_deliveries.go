@@ -0,0 +1,93 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_deliveries"
+	"github.com/sirupsen/logrus"
+)
+
+// PullRequestChecker re-evaluates the CLA check of a pull request
+type PullRequestChecker interface {
+	RecheckPullRequest(ctx context.Context, repositoryID, pullRequestID int64) error
+}
+
+// GitHubDeliveryQueuedEvent runs the CLA check of the pull requests of a queued GitHub webhook delivery, or publishes
+// the EasyCLA status of a merge group - the record is modified when a failed delivery is received again
+func (s *service) GitHubDeliveryQueuedEvent(event events.DynamoDBEventRecord) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "dynamo_events.github_deliveries.GitHubDeliveryQueuedEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventID":        event.EventID,
+		"eventName":      event.EventName,
+	}
+
+	var delivery github_deliveries.DBDeliveryModel
+	err := unmarshalStreamImage(event.Change.NewImage, &delivery)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem unmarshalling the github delivery model")
+		return err
+	}
+	if delivery.Status != github_deliveries.StatusQueued {
+		return nil
+	}
+	f["deliveryID"] = delivery.DeliveryID
+	f["eventType"] = delivery.EventType
+	f["repositoryID"] = delivery.RepositoryID
+	f["pullRequestIDs"] = delivery.PullRequestIDs
+
+	if s.pullRequestChecker == nil || s.githubDeliveriesRepo == nil {
+		return fmt.Errorf("pull request checks are not configured")
+	}
+
+	if err := s.githubDeliveriesRepo.UpdateDeliveryStatus(ctx, delivery.DeliveryID, github_deliveries.StatusProcessing, ""); err != nil {
+		return err
+	}
+
+	var errMessages []string
+	if delivery.EventType == github_deliveries.MergeGroupEventType {
+		// the merge groups reuse the results of their pull requests
+		if statusErr := s.updateMergeGroupStatus(ctx, &delivery); statusErr != nil {
+			log.WithFields(f).WithError(statusErr).Warn("unable to update the merge group status")
+			errMessages = append(errMessages, fmt.Sprintf("merge group %s: %v", delivery.HeadSHA, statusErr))
+		}
+	} else {
+		for _, pullRequestID := range delivery.PullRequestIDs {
+			log.WithFields(f).Debugf("re-checking pull request : %d", pullRequestID)
+			if checkErr := s.pullRequestChecker.RecheckPullRequest(ctx, delivery.RepositoryID, pullRequestID); checkErr != nil {
+				log.WithFields(f).WithError(checkErr).Warnf("unable to check pull request : %d", pullRequestID)
+				errMessages = append(errMessages, fmt.Sprintf("pull request %d: %v", pullRequestID, checkErr))
+			}
+		}
+	}
+
+	if len(errMessages) > 0 {
+		errMessage := strings.Join(errMessages, "; ")
+		if err := s.githubDeliveriesRepo.UpdateDeliveryStatus(ctx, delivery.DeliveryID, github_deliveries.StatusFailed, errMessage); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to update the github delivery status")
+		}
+		return fmt.Errorf("processing github delivery : %s failed : %s", delivery.DeliveryID, errMessage)
+	}
+
+	return s.githubDeliveriesRepo.UpdateDeliveryStatus(ctx, delivery.DeliveryID, github_deliveries.StatusCompleted, "")
+}
+
+// updateMergeGroupStatus publishes the EasyCLA status on the head commit of the merge group
+func (s *service) updateMergeGroupStatus(ctx context.Context, delivery *github_deliveries.DBDeliveryModel) error {
+	input, err := github.NewMergeGroupInput(delivery.InstallationID, delivery.RepositoryName, delivery.HeadSHA, delivery.HeadRef, config.GetConfig().CLALandingPage)
+	if err != nil {
+		return err
+	}
+	return github.UpdateMergeGroupStatus(ctx, input)
+}
@@ -5,6 +5,7 @@ package dynamo_events
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/communitybridge/easycla/cla-backend-go/github/branch_protection"
 
@@ -74,18 +75,33 @@ func (s *service) GitHubOrgUpdatedEvent(event events.DynamoDBEventRecord) error
 		return err
 	}
 
+	// If the branch protection policy was saved.... the other transitions are still processed
+	var policyErr error
+	if newGitHubOrg.BranchProtectionPolicy != nil && !reflect.DeepEqual(oldGitHubOrg.BranchProtectionPolicy, newGitHubOrg.BranchProtectionPolicy) {
+		log.WithFields(f).Debug("branchProtectionPolicy saved - processing...")
+		if policyErr = s.applyBranchProtectionPolicyForGithubOrg(ctx, newGitHubOrg); policyErr != nil {
+			log.WithFields(f).WithError(policyErr).Warn("problem applying the branch protection policy - continuing...")
+		}
+	}
+
 	// If the branch protection value was updated from false to true....
 	if !oldGitHubOrg.BranchProtectionEnabled && newGitHubOrg.BranchProtectionEnabled {
 		log.WithFields(f).Debug("transition of branchProtectionEnabled false => true - processing...")
-		return s.enableBranchProtectionForGithubOrg(ctx, newGitHubOrg)
+		if err = s.enableBranchProtectionForGithubOrg(ctx, newGitHubOrg); err != nil {
+			return err
+		}
+		return policyErr
 	}
 
 	if !oldGitHubOrg.AutoEnabled && newGitHubOrg.AutoEnabled {
 		log.WithFields(f).Debug("transition of autoEnabled false => true - processing...")
-		return s.autoEnableService.AutoEnabledForGithubOrg(f, newGitHubOrg, true)
+		if err = s.autoEnableService.AutoEnabledForGithubOrg(f, newGitHubOrg, true); err != nil {
+			return err
+		}
+		return policyErr
 	}
 	log.WithFields(f).Debug("no transition of branchProtectionEnabled false => true - ignoring...")
-	return nil
+	return policyErr
 }
 
 // GitHubOrgDeletedEvent github repository deleted event
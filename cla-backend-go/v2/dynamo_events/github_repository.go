@@ -124,6 +124,23 @@ func (s *service) EnableBranchProtectionServiceHandler(event events.DynamoDBEven
 				utils.GithubBranchProtectionPatternAll, true, []string{utils.GitHubBotName}, []string{})
 		}
 
+		policy, policyErr := s.githubOrgService.GetGitHubOrganizationBranchProtectionPolicy(context.Background(), parentOrgName)
+		if policyErr != nil {
+			log.WithFields(f).WithError(policyErr).Warnf("problem loading the branch protection policy of the github organization: %s", parentOrgName)
+			return policyErr
+		}
+		if policy != nil {
+			log.WithFields(f).Debug("branch protection policy is set for this organization")
+			branchProtectionRepository, err := branch_protection.NewBranchProtectionRepository(gitHubOrg.OrganizationInstallationID, branch_protection.EnableBlockingLimiter())
+			if err != nil {
+				log.WithFields(f).WithError(err).Warnf("initializing branch protection repository failed")
+				return err
+			}
+
+			log.WithFields(f).Debugf("applying the branch protection policy to the GitHub repository: %s...", newRepoModel.RepositoryName)
+			return branchProtectionRepository.ApplyPolicy(context.Background(), parentOrgName, newRepoModel.RepositoryName, policy.ToPolicy())
+		}
+
 		log.WithFields(f).Debug("github organization branch protection is not enabled - no action required")
 	}
 
@@ -4,6 +4,8 @@
 package dynamo_events
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/sirupsen/logrus"
+	goGitLab "github.com/xanzy/go-gitlab"
 )
 
 func (s *service) GitLabRepoAddedWebhookEventHandler(event events.DynamoDBEventRecord) error {
@@ -55,12 +58,7 @@ func (s *service) GitLabRepoAddedWebhookEventHandler(event events.DynamoDBEventR
 		return fmt.Errorf("fetching gitlab org : %s failed : %v", newRepoModel.RepositoryOrganizationName, err)
 	}
 
-	oauthResponse, err := s.gitLabOrgService.RefreshGitLabOrganizationAuth(ctx, gitlabOrg)
-	if err != nil {
-		return fmt.Errorf("refreshing gitlab org auth failed : %v", err)
-	}
-
-	gitLabClient, err := gitlab_api.NewGitlabOauthClient(*oauthResponse, s.gitLabApp)
+	gitLabClient, err := s.gitLabOrgService.GetGitLabOrganizationClient(ctx, gitlabOrg)
 	if err != nil {
 		return fmt.Errorf("initializing GitLab client failed : %v", err)
 	}
@@ -70,14 +68,18 @@ func (s *service) GitLabRepoAddedWebhookEventHandler(event events.DynamoDBEventR
 		return fmt.Errorf("parsing external repository id failed : %v", err)
 	}
 
+	webhookSecret, err := s.gitLabOrgService.GetWebhookSecret(ctx, gitlabOrg)
+	if err != nil {
+		return fmt.Errorf("loading webhook secret of gitlab org : %s failed : %v", gitlabOrg.OrganizationID, err)
+	}
+
 	conf := config.GetConfig()
-	if err := gitlab_api.SetWebHook(gitLabClient, conf.Gitlab.WebHookURI, repositoryExternalIDInt, gitlabOrg.AuthState); err != nil {
+	if err := gitlab_api.SetWebHook(gitLabClient, conf.Gitlab.WebHookURI, repositoryExternalIDInt, webhookSecret); err != nil {
 		log.WithFields(f).Errorf("adding gitlab webhook failed : %v", err)
 	}
 	log.WithFields(f).Debugf("gitlab webhhok added succesfully for repository")
 
-	log.WithFields(f).Debugf("enabling gitlab pipeline protection if not alreasy")
-	return gitlab_api.EnableMergePipelineProtection(ctx, gitLabClient, repositoryExternalIDInt)
+	return enableGitLabMergeRequestCheck(ctx, f, gitLabClient, repositoryExternalIDInt, webhookSecret)
 }
 
 func (s *service) GitlabRepoModifiedWebhookEventHandler(event events.DynamoDBEventRecord) error {
@@ -130,12 +132,7 @@ func (s *service) GitlabRepoModifiedWebhookEventHandler(event events.DynamoDBEve
 		return fmt.Errorf("fetching gitlab org : %s failed : %v", oldRepoModel.RepositoryOrganizationName, err)
 	}
 
-	oauthResponse, err := s.gitLabOrgService.RefreshGitLabOrganizationAuth(ctx, gitlabOrg)
-	if err != nil {
-		return fmt.Errorf("refreshing gitlab org auth failed : %v", err)
-	}
-
-	gitLabClient, err := gitlab_api.NewGitlabOauthClient(*oauthResponse, s.gitLabApp)
+	gitLabClient, err := s.gitLabOrgService.GetGitLabOrganizationClient(ctx, gitlabOrg)
 	if err != nil {
 		return fmt.Errorf("initializing GitLab client failed : %v", err)
 	}
@@ -148,17 +145,21 @@ func (s *service) GitlabRepoModifiedWebhookEventHandler(event events.DynamoDBEve
 	conf := config.GetConfig()
 
 	if newRepoModel.Enabled {
-		if err := gitlab_api.SetWebHook(gitLabClient, conf.Gitlab.WebHookURI, repositoryExternalIDInt, gitlabOrg.AuthState); err != nil {
+		webhookSecret, err := s.gitLabOrgService.GetWebhookSecret(ctx, gitlabOrg)
+		if err != nil {
+			return fmt.Errorf("loading webhook secret of gitlab org : %s failed : %v", gitlabOrg.OrganizationID, err)
+		}
+		if err := gitlab_api.SetWebHook(gitLabClient, conf.Gitlab.WebHookURI, repositoryExternalIDInt, webhookSecret); err != nil {
 			log.WithFields(f).Errorf("adding gitlab webhook failed : %v", err)
 		}
-		log.WithFields(f).Debugf("enabling gitlab pipeline protection if not alreasy")
-		if err := gitlab_api.EnableMergePipelineProtection(ctx, gitLabClient, repositoryExternalIDInt); err != nil {
+		if err := enableGitLabMergeRequestCheck(ctx, f, gitLabClient, repositoryExternalIDInt, webhookSecret); err != nil {
 			return err
 		}
 	} else {
 		if err := gitlab_api.RemoveWebHook(gitLabClient, conf.Gitlab.WebHookURI, repositoryExternalIDInt); err != nil {
 			log.WithFields(f).Errorf("removing gitlab webhook failed : %v", err)
 		}
+		disableGitLabMergeRequestCheck(f, gitLabClient, repositoryExternalIDInt)
 	}
 
 	log.WithFields(f).Debugf("gitlab webhhok processed succesfully for repository")
@@ -199,12 +200,7 @@ func (s *service) GitLabRepoRemovedWebhookEventHandler(event events.DynamoDBEven
 		return fmt.Errorf("fetching gitlab org : %s failed : %v", oldRepoModel.RepositoryOrganizationName, err)
 	}
 
-	oauthResponse, err := s.gitLabOrgService.RefreshGitLabOrganizationAuth(ctx, gitlabOrg)
-	if err != nil {
-		return fmt.Errorf("refreshing gitlab org auth failed : %v", err)
-	}
-
-	gitLabClient, err := gitlab_api.NewGitlabOauthClient(*oauthResponse, s.gitLabApp)
+	gitLabClient, err := s.gitLabOrgService.GetGitLabOrganizationClient(ctx, gitlabOrg)
 	if err != nil {
 		return fmt.Errorf("initializing GitLab client failed : %v", err)
 	}
@@ -218,11 +214,39 @@ func (s *service) GitLabRepoRemovedWebhookEventHandler(event events.DynamoDBEven
 	if err := gitlab_api.RemoveWebHook(gitLabClient, conf.Gitlab.WebHookURI, repositoryExternalIDInt); err != nil {
 		log.WithFields(f).Errorf("removing gitlab webhook failed : %v", err)
 	}
+	disableGitLabMergeRequestCheck(f, gitLabClient, repositoryExternalIDInt)
 
 	log.WithFields(f).Debugf("gitlab webhhok removed succesfully for repository")
 	return nil
 }
 
+// enableGitLabMergeRequestCheck registers the EasyCLA external status check on the project, signed with the webhook
+// secret of the GitLab organization, and falls back to the merge pipeline protection with the commit status when the
+// project doesn't support external status checks
+func enableGitLabMergeRequestCheck(ctx context.Context, f logrus.Fields, gitLabClient *goGitLab.Client, projectID int, webhookSecret string) error {
+	statusCheckURL := gitlab_api.ExternalStatusCheckURL(config.GetConfig().ClaAPIV4Base)
+	if _, err := gitlab_api.SetExternalStatusCheck(gitLabClient, projectID, statusCheckURL, webhookSecret); err != nil {
+		if errors.Is(err, gitlab_api.ErrExternalStatusChecksUnsupported) {
+			log.WithFields(f).Debug("external status checks are not supported for the project")
+		} else {
+			log.WithFields(f).WithError(err).Warn("adding gitlab external status check failed")
+		}
+		log.WithFields(f).Debugf("enabling gitlab pipeline protection if not alreasy")
+		return gitlab_api.EnableMergePipelineProtection(ctx, gitLabClient, projectID)
+	}
+
+	log.WithFields(f).Debugf("gitlab external status check added succesfully for repository")
+	return nil
+}
+
+// disableGitLabMergeRequestCheck removes the EasyCLA external status check from the project, if any
+func disableGitLabMergeRequestCheck(f logrus.Fields, gitLabClient *goGitLab.Client, projectID int) {
+	statusCheckURL := gitlab_api.ExternalStatusCheckURL(config.GetConfig().ClaAPIV4Base)
+	if err := gitlab_api.RemoveExternalStatusCheck(gitLabClient, projectID, statusCheckURL); err != nil && !errors.Is(err, gitlab_api.ErrExternalStatusChecksUnsupported) {
+		log.WithFields(f).WithError(err).Warn("removing gitlab external status check failed")
+	}
+}
+
 func (s *service) isGitlabRepo(logEntry *logrus.Entry, repoModel *repositories.RepositoryDBModel) bool {
 	if repoModel.RepositoryType != utils.GitLabLower {
 		logEntry.Debugf("only processing gitlab instances")
@@ -0,0 +1,54 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"github.com/aws/aws-lambda-go/events"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// SignatureMetricsHistoryEvent adds newly signed and approved signatures to the daily metrics history
+func (s *service) SignatureMetricsHistoryEvent(event events.DynamoDBEventRecord) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "dynamo_events.SignatureMetricsHistoryEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventName":      event.EventName,
+	}
+
+	if s.metricsHistoryRepo == nil {
+		return nil
+	}
+
+	var newSignature, oldSignature metrics.HistorySignature
+	err := unmarshalStreamImage(event.Change.NewImage, &newSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding post-update signature, error: %+v", err)
+		return err
+	}
+	if event.EventName == Modify {
+		err = unmarshalStreamImage(event.Change.OldImage, &oldSignature)
+		if err != nil {
+			log.WithFields(f).Warnf("problem decoding pre-update signature, error: %+v", err)
+			return err
+		}
+	}
+
+	f["signatureID"] = newSignature.SignatureID
+	f["claGroupID"] = newSignature.SignatureProjectID
+
+	// Only count the transition into signed and approved - the history repository ignores repeats anyway
+	if !newSignature.SignatureSigned || !newSignature.SignatureApproved {
+		return nil
+	}
+	if oldSignature.SignatureSigned && oldSignature.SignatureApproved {
+		return nil
+	}
+
+	log.WithFields(f).Debug("recording signature in the metrics history...")
+	return s.metricsHistoryRepo.RecordSignature(ctx, &newSignature)
+}
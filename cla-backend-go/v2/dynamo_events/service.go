@@ -14,9 +14,12 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/project/repository"
 	service2 "github.com/communitybridge/easycla/cla-backend-go/project/service"
 
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_deliveries"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
 	v2Repositories "github.com/communitybridge/easycla/cla-backend-go/v2/repositories"
 
 	gitlab_api "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bitbucket_organizations"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
@@ -65,6 +68,8 @@ type service struct {
 	eventsRepo               claevent.Repository
 	gitLabOrgRepo            gitlab_organizations.RepositoryInterface
 	gitLabOrgService         gitlab_organizations.ServiceInterface
+	bitbucketOrgRepo         bitbucket_organizations.RepositoryInterface
+	bitbucketOrgService      bitbucket_organizations.ServiceInterface
 	v2Repository             v2Repositories.RepositoryInterface
 	projectRepo              repository.ProjectRepository
 	projectService           service2.Service
@@ -75,6 +80,9 @@ type service struct {
 	claManagerRequestsRepo   cla_manager.IRepository
 	approvalListRequestsRepo approval_list.IRepository
 	gitLabApp                *gitlab_api.App
+	metricsHistoryRepo       metrics.HistoryRepository
+	githubDeliveriesRepo     github_deliveries.RepositoryInterface
+	pullRequestChecker       PullRequestChecker
 }
 
 // Service implements DynamoDB stream event handler service
@@ -99,7 +107,12 @@ func NewService(stage string,
 	claManagerRequestsRepo cla_manager.IRepository,
 	approvalListRequestsRepo approval_list.IRepository,
 	gitLabApp *gitlab_api.App,
-	gitlabOrgService gitlab_organizations.ServiceInterface) Service {
+	gitlabOrgService gitlab_organizations.ServiceInterface,
+	bitbucketOrgRepo bitbucket_organizations.RepositoryInterface,
+	bitbucketOrgService bitbucket_organizations.ServiceInterface,
+	metricsHistoryRepo metrics.HistoryRepository,
+	githubDeliveriesRepo github_deliveries.RepositoryInterface,
+	pullRequestChecker PullRequestChecker) Service {
 
 	signaturesTable := fmt.Sprintf("cla-%s-signatures", stage)
 	eventsTable := fmt.Sprintf("cla-%s-events", stage)
@@ -109,6 +122,7 @@ func NewService(stage string,
 	gitlabOrgTableName := fmt.Sprintf("cla-%s-gitlab-orgs", stage)
 	// gerritTableName := fmt.Sprintf("cla-%s-gerrit-instances", stage)
 	claGroupsTable := fmt.Sprintf("cla-%s-projects", stage)
+	githubDeliveriesTable := fmt.Sprintf("cla-%s-github-webhook-deliveries", stage)
 
 	s := &service{
 		functions:                make(map[string][]EventHandlerFunc),
@@ -129,6 +143,11 @@ func NewService(stage string,
 		approvalListRequestsRepo: approvalListRequestsRepo,
 		gitLabApp:                gitLabApp,
 		gitLabOrgService:         gitlabOrgService,
+		bitbucketOrgRepo:         bitbucketOrgRepo,
+		bitbucketOrgService:      bitbucketOrgService,
+		metricsHistoryRepo:       metricsHistoryRepo,
+		githubDeliveriesRepo:     githubDeliveriesRepo,
+		pullRequestChecker:       pullRequestChecker,
 	}
 
 	s.registerCallback(signaturesTable, Modify, s.SignatureSignedEvent)
@@ -138,6 +157,9 @@ func NewService(stage string,
 	s.registerCallback(signaturesTable, Insert, s.SignatureAddUsersDetails)
 	// Add or Remove any CLA Permissions
 	s.registerCallback(signaturesTable, Modify, s.UpdateCLAPermissions)
+	// Keep the daily metrics history up to date
+	s.registerCallback(signaturesTable, Insert, s.SignatureMetricsHistoryEvent)
+	s.registerCallback(signaturesTable, Modify, s.SignatureMetricsHistoryEvent)
 
 	s.registerCallback(eventsTable, Insert, s.EventAddedEvent)
 
@@ -164,6 +186,10 @@ func NewService(stage string,
 	s.registerCallback(repositoryTableName, Modify, s.GitlabRepoModifiedWebhookEventHandler)
 	s.registerCallback(repositoryTableName, Remove, s.GitLabRepoRemovedWebhookEventHandler)
 
+	s.registerCallback(repositoryTableName, Insert, s.BitbucketRepoWebhookEventHandler)
+	s.registerCallback(repositoryTableName, Modify, s.BitbucketRepoWebhookEventHandler)
+	s.registerCallback(repositoryTableName, Remove, s.BitbucketRepoRemovedWebhookEventHandler)
+
 	// gitlab org updates handled like branch protection and etc.
 	s.registerCallback(gitlabOrgTableName, Modify, s.GitLabOrgUpdatedEvent)
 
@@ -173,6 +199,10 @@ func NewService(stage string,
 
 	s.registerCallback(claGroupsTable, Modify, s.ProcessCLAGroupUpdateEvents)
 
+	// Run the pull request checks of the queued GitHub webhook deliveries
+	s.registerCallback(githubDeliveriesTable, Insert, s.GitHubDeliveryQueuedEvent)
+	s.registerCallback(githubDeliveriesTable, Modify, s.GitHubDeliveryQueuedEvent)
+
 	return s
 }
 
@@ -27,11 +27,12 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/events"
 	v1ProjectService "github.com/communitybridge/easycla/cla-backend-go/project/service"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/auditors"
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.EasyclaAPI, service v1Events.Service, v1CompanyRepo v1Company.IRepository, projectsClaGroupsRepo projects_cla_groups.Repository, projectService v1ProjectService.Service) { // nolint
+func Configure(api *operations.EasyclaAPI, service v1Events.Service, v1CompanyRepo v1Company.IRepository, projectsClaGroupsRepo projects_cla_groups.Repository, projectService v1ProjectService.Service, auditorService auditors.ServiceInterface) { // nolint
 	api.EventsGetRecentEventsHandler = events.GetRecentEventsHandlerFunc(
 		func(params events.GetRecentEventsParams, authUser *auth.User) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
@@ -89,7 +90,8 @@ func Configure(api *operations.EasyclaAPI, service v1Events.Service, v1CompanyRe
 			}
 
 			log.WithFields(f).Debug("checking permission...")
-			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) &&
+				!isFoundationAuditor(ctx, authUser, params.FoundationSFID, "foundation events CSV", auditorService) {
 				msg := fmt.Sprintf("user %s does not have access to Get Foundation Events for foundation %s.", authUser.UserName, params.FoundationSFID)
 				log.WithFields(f).Warn(msg)
 				return WriteResponse(http.StatusForbidden, runtime.JSONMime, runtime.JSONProducer(), utils.ErrorResponseForbidden(reqID, msg))
@@ -120,7 +122,8 @@ func Configure(api *operations.EasyclaAPI, service v1Events.Service, v1CompanyRe
 			}
 
 			log.WithFields(f).Debug("checking permission...")
-			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) &&
+				!isFoundationAuditor(ctx, authUser, params.FoundationSFID, "foundation events", auditorService) {
 				msg := fmt.Sprintf("user %s does not have access to Get Foundation Events for foundation %s.", authUser.UserName, params.FoundationSFID)
 				log.WithFields(f).Warn(msg)
 				return events.NewGetRecentEventsForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
@@ -166,7 +169,8 @@ func Configure(api *operations.EasyclaAPI, service v1Events.Service, v1CompanyRe
 			}
 
 			log.WithFields(f).Debug("checking permission...")
-			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) &&
+				!isProjectAuditor(ctx, authUser, params.ProjectSFID, "project events CSV", auditorService, projectsClaGroupsRepo) {
 				msg := fmt.Sprintf("user %s does not have access to Get Project Events for foundation %s.", authUser.UserName, params.ProjectSFID)
 				log.WithFields(f).Warn(msg)
 				return WriteResponse(http.StatusForbidden, runtime.JSONMime, runtime.JSONProducer(), &models.ErrorResponse{
@@ -218,7 +222,8 @@ func Configure(api *operations.EasyclaAPI, service v1Events.Service, v1CompanyRe
 			}
 
 			log.WithFields(f).Debug("checking permission...")
-			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) &&
+				!isProjectAuditor(ctx, authUser, params.ProjectSFID, "project events", auditorService, projectsClaGroupsRepo) {
 				msg := fmt.Sprintf("user %s does not have access to Get Project Events for foundation %s.", authUser.UserName, params.ProjectSFID)
 				log.WithFields(f).Warn(msg)
 				return events.NewGetRecentEventsForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
@@ -335,6 +340,37 @@ func Configure(api *operations.EasyclaAPI, service v1Events.Service, v1CompanyRe
 		})
 }
 
+// isFoundationAuditor is a helper function to determine if the user has read-only auditor access to the foundation,
+// each access granted through the auditor role is recorded as an event
+func isFoundationAuditor(ctx context.Context, authUser *auth.User, foundationSFID, resource string, auditorService auditors.ServiceInterface) bool {
+	if auditorService == nil || !auditorService.IsFoundationAuditor(ctx, authUser.UserName, foundationSFID) {
+		return false
+	}
+
+	auditorService.LogAuditorAccess(ctx, authUser.UserName, auditors.ScopeTypeFoundation, foundationSFID, resource)
+	return true
+}
+
+// isProjectAuditor is a helper function to determine if the user has read-only auditor access to the CLA Group
+// associated with the project
+func isProjectAuditor(ctx context.Context, authUser *auth.User, projectSFID, resource string, auditorService auditors.ServiceInterface, projectsClaGroupsRepo projects_cla_groups.Repository) bool {
+	if auditorService == nil {
+		return false
+	}
+
+	pm, err := projectsClaGroupsRepo.GetClaGroupIDForProject(ctx, projectSFID)
+	if err != nil || pm == nil {
+		return false
+	}
+
+	if !auditorService.IsCLAGroupAuditor(ctx, authUser.UserName, pm.ClaGroupID) {
+		return false
+	}
+
+	auditorService.LogAuditorAccess(ctx, authUser.UserName, auditors.ScopeTypeCLAGroup, pm.ClaGroupID, resource)
+	return true
+}
+
 // WriteResponse function writes http response.
 func WriteResponse(httpStatus int, contentType string, contentProducer runtime.Producer, data interface{}) middleware.Responder {
 	return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
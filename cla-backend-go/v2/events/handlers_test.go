@@ -0,0 +1,82 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/auditors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAuditorService struct {
+	auditors.ServiceInterface
+	foundations map[string]string
+	claGroups   map[string]string
+	accesses    []string
+}
+
+func (s *fakeAuditorService) IsFoundationAuditor(ctx context.Context, lfUsername, foundationSFID string) bool {
+	return s.foundations[foundationSFID] == lfUsername
+}
+
+func (s *fakeAuditorService) IsCLAGroupAuditor(ctx context.Context, lfUsername, claGroupID string) bool {
+	return s.claGroups[claGroupID] == lfUsername
+}
+
+func (s *fakeAuditorService) LogAuditorAccess(ctx context.Context, lfUsername, scopeType, scopeID, resource string) {
+	s.accesses = append(s.accesses, lfUsername+" "+scopeType+" "+scopeID+" "+resource)
+}
+
+type fakeProjectsClaGroupsRepo struct {
+	projects_cla_groups.Repository
+	claGroups map[string]string
+}
+
+func (r *fakeProjectsClaGroupsRepo) GetClaGroupIDForProject(ctx context.Context, projectSFID string) (*projects_cla_groups.ProjectClaGroup, error) {
+	claGroupID, ok := r.claGroups[projectSFID]
+	if !ok {
+		return nil, errors.New("project not found")
+	}
+	return &projects_cla_groups.ProjectClaGroup{ProjectSFID: projectSFID, ClaGroupID: claGroupID}, nil
+}
+
+func TestIsFoundationAuditor(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeAuditorService{foundations: map[string]string{"a09P000000DsNH2IAN": "auditor"}}
+	auditor := &auth.User{UserName: "auditor"}
+
+	assert.True(t, isFoundationAuditor(ctx, auditor, "a09P000000DsNH2IAN", "events", service))
+	assert.Equal(t, []string{"auditor foundation a09P000000DsNH2IAN events"}, service.accesses)
+
+	// the other users, the other foundations and the missing auditor service are denied - nothing is recorded
+	assert.False(t, isFoundationAuditor(ctx, &auth.User{UserName: "jdoe"}, "a09P000000DsNH2IAN", "events", service))
+	assert.False(t, isFoundationAuditor(ctx, auditor, "a09P000000DsCE5IAM", "events", service))
+	assert.False(t, isFoundationAuditor(ctx, auditor, "a09P000000DsNH2IAN", "events", nil))
+	assert.Len(t, service.accesses, 1)
+}
+
+func TestIsProjectAuditor(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeAuditorService{claGroups: map[string]string{"d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b": "auditor"}}
+	repo := &fakeProjectsClaGroupsRepo{claGroups: map[string]string{
+		"a09P000000DsNH2IAN": "d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b",
+		"a09P000000DsCE5IAM": "7f3b5e2a-1c4d-4e8f-9a6b-2d1c3e4f5a6b",
+	}}
+	auditor := &auth.User{UserName: "auditor"}
+
+	assert.True(t, isProjectAuditor(ctx, auditor, "a09P000000DsNH2IAN", "events", service, repo))
+	assert.Equal(t, []string{"auditor cla_group d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b events"}, service.accesses)
+
+	// the project of another CLA Group, the project without a CLA Group and the missing auditor service are denied
+	assert.False(t, isProjectAuditor(ctx, auditor, "a09P000000DsCE5IAM", "events", service, repo))
+	assert.False(t, isProjectAuditor(ctx, auditor, "a09P000000DsXYZIAM", "events", service, repo))
+	assert.False(t, isProjectAuditor(ctx, &auth.User{UserName: "jdoe"}, "a09P000000DsNH2IAN", "events", service, repo))
+	assert.False(t, isProjectAuditor(ctx, auditor, "a09P000000DsNH2IAN", "events", nil, repo))
+	assert.Len(t, service.accesses, 1)
+}
@@ -9,7 +9,9 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/communitybridge/easycla/cla-backend-go/config"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_deliveries"
 
 	"github.com/google/go-github/v37/github" // with go modules enabled (GO111MODULE=on or outside GOPATH)0:w
 
@@ -22,11 +24,18 @@ import (
 )
 
 // signatureCheckMiddleware is used to get access to raw http request so can do the
-// signature validation properly
+// signature validation properly - the events are rejected when no webhook secret is configured
 func signatureCheckMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		payload, err := github.ValidatePayload(r, nil)
+		webhookSecret := config.GetConfig().GitHub.AppWebhookSecret
+		if webhookSecret == "" {
+			log.Error("github app webhook secret is not configured - unable to validate the signature")
+			http.Error(w, "webhook secret not configured", http.StatusInternalServerError)
+			return
+		}
+		payload, err := github.ValidatePayload(r, []byte(webhookSecret))
 		if err != nil {
+			log.Warnf("github webhook signature validation failed : %v", err)
 			http.Error(w, "signature check failure", 401)
 			return
 		}
@@ -58,6 +67,25 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 				})
 			}
 
+			// the idempotency key of the pull request checks, redeliveries of the same event are skipped
+			githubDeliveryID := utils.StringValue(params.XGITHUBDELIVERY)
+			deliveryID := github_deliveries.DeliveryKey(githubDeliveryID, githubEvent, payload)
+
+			// merge_group is not supported by the go-github version we use
+			if githubEvent == github_deliveries.MergeGroupEventType {
+				mergeGroupEvent, parseErr := parseMergeGroupEvent(payload)
+				if parseErr != nil {
+					return github_activity.NewGithubActivityBadRequest().WithPayload(&models.ErrorResponse{
+						Code:    "400",
+						Message: fmt.Sprintf("parsing event failed : %v", parseErr),
+					})
+				}
+				if processErr := service.ProcessMergeGroupEvent(deliveryID, githubDeliveryID, mergeGroupEvent); processErr != nil {
+					log.Warnf("processing event : %s failed with : %v", githubEvent, processErr)
+				}
+				return github_activity.NewGithubActivityOK()
+			}
+
 			event, err := github.ParseWebHook(githubEvent, payload)
 			if err != nil {
 				return github_activity.NewGithubActivityBadRequest().WithPayload(&models.ErrorResponse{
@@ -72,6 +100,12 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 				processError = service.ProcessInstallationRepositoriesEvent(event)
 			case *github.RepositoryEvent:
 				processError = service.ProcessRepositoryEvent(event)
+			case *github.CheckRunEvent:
+				processError = service.ProcessCheckRunEvent(deliveryID, githubDeliveryID, event)
+			case *github.PullRequestEvent:
+				processError = service.ProcessPullRequestEvent(deliveryID, githubDeliveryID, event)
+			case *github.IssueCommentEvent:
+				processError = service.ProcessIssueCommentEvent(deliveryID, githubDeliveryID, event)
 			default:
 				log.Warnf("unsupported event sent : %s", githubEvent)
 			}
@@ -0,0 +1,38 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"encoding/json"
+
+	"github.com/google/go-github/v37/github"
+)
+
+// MergeGroupEvent is sent when a pull request is added to the merge queue - not available in the go-github version we use
+type MergeGroupEvent struct {
+	Action       *string              `json:"action,omitempty"`
+	MergeGroup   *MergeGroup          `json:"merge_group,omitempty"`
+	Repo         *github.Repository   `json:"repository,omitempty"`
+	Org          *github.Organization `json:"organization,omitempty"`
+	Installation *github.Installation `json:"installation,omitempty"`
+	Sender       *github.User         `json:"sender,omitempty"`
+}
+
+// MergeGroup is the temporary branch of the merge queue which combines the queued pull requests
+type MergeGroup struct {
+	HeadSHA    string             `json:"head_sha"`
+	HeadRef    string             `json:"head_ref"`
+	BaseSHA    string             `json:"base_sha"`
+	BaseRef    string             `json:"base_ref"`
+	HeadCommit *github.HeadCommit `json:"head_commit,omitempty"`
+}
+
+// parseMergeGroupEvent decodes the merge_group webhook payload
+func parseMergeGroupEvent(payload []byte) (*MergeGroupEvent, error) {
+	var event MergeGroupEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
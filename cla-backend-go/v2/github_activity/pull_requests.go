@@ -0,0 +1,168 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	claGitHub "github.com/communitybridge/easycla/cla-backend-go/github"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_deliveries"
+	"github.com/google/go-github/v37/github"
+	"github.com/sirupsen/logrus"
+)
+
+// ProcessPullRequestEvent queues the CLA check of opened, reopened and updated pull requests, and of the pull requests
+// whose base branch was changed - the branch protection and the repository requirements depend on the base branch
+func (s *eventHandlerService) ProcessPullRequestEvent(deliveryID, githubDeliveryID string, event *github.PullRequestEvent) error {
+	if event.Action == nil {
+		return fmt.Errorf("no action found in event payload")
+	}
+	switch *event.Action {
+	case "opened", "reopened", "synchronize":
+	case "edited":
+		if event.GetChanges().GetBase() == nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if event.Repo == nil || event.Repo.ID == nil || event.PullRequest == nil || event.PullRequest.Number == nil {
+		return fmt.Errorf("missing repository or pull request object in event payload")
+	}
+
+	delivery := github_deliveries.NewDelivery(deliveryID, githubDeliveryID, "pull_request", *event.Action)
+	delivery.InstallationID = event.GetInstallation().GetID()
+	delivery.RepositoryID = *event.Repo.ID
+	delivery.RepositoryName = event.Repo.GetFullName()
+	delivery.PullRequestIDs = []int64{int64(*event.PullRequest.Number)}
+	delivery.HeadSHA = event.PullRequest.GetHead().GetSHA()
+	delivery.HeadRef = event.PullRequest.GetHead().GetRef()
+	delivery.BaseRef = event.PullRequest.GetBase().GetRef()
+	delivery.Sender = event.GetSender().GetLogin()
+	return s.queueDelivery(utils.NewContext(), delivery)
+}
+
+// ProcessMergeGroupEvent queues the EasyCLA status of the merge group created for the pull request added to the merge queue
+func (s *eventHandlerService) ProcessMergeGroupEvent(deliveryID, githubDeliveryID string, event *MergeGroupEvent) error {
+	if event.Action == nil {
+		return fmt.Errorf("no action found in event payload")
+	}
+	if *event.Action != "checks_requested" {
+		return nil
+	}
+	if event.Repo == nil || event.Repo.ID == nil || event.MergeGroup == nil {
+		return fmt.Errorf("missing repository or merge group object in event payload")
+	}
+
+	pullRequestID, err := claGitHub.PullRequestIDFromMergeGroupRef(event.MergeGroup.HeadRef)
+	if err != nil {
+		return err
+	}
+
+	delivery := github_deliveries.NewDelivery(deliveryID, githubDeliveryID, github_deliveries.MergeGroupEventType, *event.Action)
+	delivery.InstallationID = event.Installation.GetID()
+	delivery.RepositoryID = *event.Repo.ID
+	delivery.RepositoryName = event.Repo.GetFullName()
+	delivery.PullRequestIDs = []int64{int64(pullRequestID)}
+	delivery.HeadSHA = event.MergeGroup.HeadSHA
+	delivery.HeadRef = event.MergeGroup.HeadRef
+	delivery.BaseRef = event.MergeGroup.BaseRef
+	delivery.BaseSHA = event.MergeGroup.BaseSHA
+	delivery.Sender = event.Sender.GetLogin()
+	return s.queueDelivery(utils.NewContext(), delivery)
+}
+
+// ProcessIssueCommentEvent queues the CLA check of a pull request when a comment contains the recheck command
+func (s *eventHandlerService) ProcessIssueCommentEvent(deliveryID, githubDeliveryID string, event *github.IssueCommentEvent) error {
+	if event.GetAction() != "created" || event.Issue == nil || !event.Issue.IsPullRequest() {
+		return nil
+	}
+	if event.GetSender().GetType() == "Bot" || !github_deliveries.IsRecheckCommand(event.GetComment().GetBody()) {
+		return nil
+	}
+	if event.Repo == nil || event.Repo.ID == nil || event.Issue.Number == nil {
+		return fmt.Errorf("missing repository or issue object in event payload")
+	}
+
+	delivery := github_deliveries.NewDelivery(deliveryID, githubDeliveryID, "issue_comment", event.GetAction())
+	delivery.InstallationID = event.GetInstallation().GetID()
+	delivery.RepositoryID = *event.Repo.ID
+	delivery.RepositoryName = event.Repo.GetFullName()
+	delivery.PullRequestIDs = []int64{int64(*event.Issue.Number)}
+	delivery.Sender = event.GetSender().GetLogin()
+	return s.queueDelivery(utils.NewContext(), delivery)
+}
+
+// queueDelivery records the delivery of an enabled repository - the DynamoDB stream handler of the deliveries
+// table runs the CLA check, so that the webhook is acknowledged before the GitHub timeout. Without a deliveries
+// table, such as in local mode, the check runs right away.
+func (s *eventHandlerService) queueDelivery(ctx context.Context, delivery *github_deliveries.DBDeliveryModel) error {
+	f := logrus.Fields{
+		"functionName":   "v2.github_activity.pull_requests.queueDelivery",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deliveryID":     delivery.DeliveryID,
+		"eventType":      delivery.EventType,
+		"action":         delivery.Action,
+		"repositoryID":   delivery.RepositoryID,
+		"pullRequestIDs": delivery.PullRequestIDs,
+	}
+
+	repoModel, err := s.gitV1Repository.GitHubGetRepositoryByExternalID(ctx, strconv.FormatInt(delivery.RepositoryID, 10))
+	if err != nil {
+		if _, ok := err.(*utils.GitHubRepositoryNotFound); ok {
+			log.WithFields(f).Debugf("event for non existing local repo : %s, nothing to do", delivery.RepositoryName)
+			return nil
+		}
+		return fmt.Errorf("fetching the repo : %s by external id : %d failed : %v", delivery.RepositoryName, delivery.RepositoryID, err)
+	}
+	if !repoModel.Enabled {
+		log.WithFields(f).Debugf("repo : %s is not enabled, nothing to do", delivery.RepositoryName)
+		return nil
+	}
+
+	if s.deliveriesRepo == nil {
+		if delivery.EventType == github_deliveries.MergeGroupEventType {
+			return s.updateMergeGroupStatus(ctx, delivery)
+		}
+		return s.recheckPullRequests(ctx, delivery)
+	}
+	if err := s.deliveriesRepo.AddDelivery(ctx, delivery); err != nil {
+		if errors.Is(err, github_deliveries.ErrDuplicateDelivery) {
+			log.WithFields(f).Info("delivery already received, skipping")
+			return nil
+		}
+		return err
+	}
+
+	log.WithFields(f).Debug("queued the pull request check")
+	return nil
+}
+
+func (s *eventHandlerService) recheckPullRequests(ctx context.Context, delivery *github_deliveries.DBDeliveryModel) error {
+	if s.pullRequestChecker == nil {
+		return fmt.Errorf("pull request checks are not configured")
+	}
+	for _, pullRequestID := range delivery.PullRequestIDs {
+		if err := s.pullRequestChecker.RecheckPullRequest(ctx, delivery.RepositoryID, pullRequestID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateMergeGroupStatus publishes the EasyCLA status on the head commit of the merge group - the merge groups reuse
+// the results of their pull requests
+func (s *eventHandlerService) updateMergeGroupStatus(ctx context.Context, delivery *github_deliveries.DBDeliveryModel) error {
+	input, err := claGitHub.NewMergeGroupInput(delivery.InstallationID, delivery.RepositoryName, delivery.HeadSHA, delivery.HeadRef, config.GetConfig().CLALandingPage)
+	if err != nil {
+		return err
+	}
+	return claGitHub.UpdateMergeGroupStatus(ctx, input)
+}
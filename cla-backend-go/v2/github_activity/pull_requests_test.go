@@ -0,0 +1,129 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_deliveries"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/assert"
+)
+
+type deliveriesRepo struct {
+	deliveries map[string]*github_deliveries.DBDeliveryModel
+}
+
+func (r *deliveriesRepo) AddDelivery(ctx context.Context, delivery *github_deliveries.DBDeliveryModel) error {
+	if _, ok := r.deliveries[delivery.DeliveryID]; ok {
+		return github_deliveries.ErrDuplicateDelivery
+	}
+	r.deliveries[delivery.DeliveryID] = delivery
+	return nil
+}
+
+func (r *deliveriesRepo) UpdateDeliveryStatus(ctx context.Context, deliveryID, status, errorMessage string) error {
+	r.deliveries[deliveryID].Status = status
+	return nil
+}
+
+func TestEventHandlerService_ProcessIssueCommentEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	githubRepo := mock.NewMockRepositoryInterface(ctrl)
+	githubRepo.EXPECT().
+		GitHubGetRepositoryByExternalID(gomock.Any(), "1").
+		Return(&models.GithubRepository{Enabled: true, RepositoryExternalID: 1}, nil).
+		Times(2)
+
+	repo := &deliveriesRepo{deliveries: make(map[string]*github_deliveries.DBDeliveryModel)}
+	activityService := newService(githubRepo, nil, nil, nil, nil, nil, repo, false)
+
+	commentEvent := func(body, senderType string, pullRequest bool) *github.IssueCommentEvent {
+		issue := &github.Issue{Number: github.Int(42)}
+		if pullRequest {
+			issue.PullRequestLinks = &github.PullRequestLinks{URL: github.String("https://api.github.com/repos/org1/repo1/pulls/42")}
+		}
+		return &github.IssueCommentEvent{
+			Action:  aws.String("created"),
+			Issue:   issue,
+			Comment: &github.IssueComment{Body: github.String(body)},
+			Repo:    &github.Repository{ID: aws.Int64(1), FullName: aws.String("org1/repo1")},
+			Sender:  &github.User{Login: aws.String("octocat"), Type: aws.String(senderType)},
+		}
+	}
+
+	// not a recheck command, an issue or a bot comment - nothing is queued
+	assert.NoError(t, activityService.ProcessIssueCommentEvent("d1", "d1", commentEvent("looks good", "User", true)))
+	assert.NoError(t, activityService.ProcessIssueCommentEvent("d2", "d2", commentEvent("/easycla recheck", "User", false)))
+	assert.NoError(t, activityService.ProcessIssueCommentEvent("d3", "d3", commentEvent("/easycla recheck", "Bot", true)))
+	assert.Empty(t, repo.deliveries)
+
+	// the redelivery is skipped
+	assert.NoError(t, activityService.ProcessIssueCommentEvent("d4", "d4", commentEvent("/easycla recheck", "User", true)))
+	assert.NoError(t, activityService.ProcessIssueCommentEvent("d4", "d4", commentEvent("/easycla recheck", "User", true)))
+	assert.Len(t, repo.deliveries, 1)
+
+	delivery := repo.deliveries["d4"]
+	assert.Equal(t, github_deliveries.StatusQueued, delivery.Status)
+	assert.Equal(t, "issue_comment", delivery.EventType)
+	assert.Equal(t, int64(1), delivery.RepositoryID)
+	assert.Equal(t, []int64{42}, delivery.PullRequestIDs)
+	assert.Equal(t, "octocat", delivery.Sender)
+}
+
+func TestEventHandlerService_ProcessPullRequestEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	githubRepo := mock.NewMockRepositoryInterface(ctrl)
+	githubRepo.EXPECT().
+		GitHubGetRepositoryByExternalID(gomock.Any(), "1").
+		Return(&models.GithubRepository{Enabled: true, RepositoryExternalID: 1}, nil).
+		Times(2)
+
+	repo := &deliveriesRepo{deliveries: make(map[string]*github_deliveries.DBDeliveryModel)}
+	activityService := newService(githubRepo, nil, nil, nil, nil, nil, repo, false)
+
+	pullRequestEvent := func(action string, changes *github.EditChange) *github.PullRequestEvent {
+		return &github.PullRequestEvent{
+			Action:  aws.String(action),
+			Changes: changes,
+			PullRequest: &github.PullRequest{
+				Number: github.Int(42),
+				Head:   &github.PullRequestBranch{SHA: aws.String("abc123"), Ref: aws.String("feature")},
+				Base:   &github.PullRequestBranch{Ref: aws.String("release")},
+			},
+			Repo:   &github.Repository{ID: aws.Int64(1), FullName: aws.String("org1/repo1")},
+			Sender: &github.User{Login: aws.String("octocat")},
+		}
+	}
+
+	// closed and title or body edits are not checked
+	assert.NoError(t, activityService.ProcessPullRequestEvent("d1", "d1", pullRequestEvent("closed", nil)))
+	assert.NoError(t, activityService.ProcessPullRequestEvent("d2", "d2", pullRequestEvent("edited", nil)))
+	assert.NoError(t, activityService.ProcessPullRequestEvent("d3", "d3", pullRequestEvent("edited", &github.EditChange{
+		Title: &github.EditTitle{From: aws.String("old title")},
+	})))
+	assert.Empty(t, repo.deliveries)
+
+	assert.NoError(t, activityService.ProcessPullRequestEvent("d4", "d4", pullRequestEvent("opened", nil)))
+	// the base branch change is checked against the new base branch
+	assert.NoError(t, activityService.ProcessPullRequestEvent("d5", "d5", pullRequestEvent("edited", &github.EditChange{
+		Base: &github.EditBase{Ref: &github.EditRef{From: aws.String("main")}},
+	})))
+	assert.Len(t, repo.deliveries, 2)
+
+	delivery := repo.deliveries["d5"]
+	assert.Equal(t, github_deliveries.StatusQueued, delivery.Status)
+	assert.Equal(t, "pull_request", delivery.EventType)
+	assert.Equal(t, "edited", delivery.Action)
+	assert.Equal(t, []int64{42}, delivery.PullRequestIDs)
+	assert.Equal(t, "abc123", delivery.HeadSHA)
+	assert.Equal(t, "release", delivery.BaseRef)
+}
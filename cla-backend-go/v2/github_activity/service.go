@@ -11,6 +11,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/communitybridge/easycla/cla-backend-go/emails"
+	claGitHub "github.com/communitybridge/easycla/cla-backend-go/github"
 	v1GithubOrg "github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 
 	"github.com/sirupsen/logrus"
@@ -20,6 +21,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
 
 	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_deliveries"
 
 	"github.com/communitybridge/easycla/cla-backend-go/events"
 
@@ -33,15 +35,26 @@ import (
 type Service interface {
 	ProcessInstallationRepositoriesEvent(event *github.InstallationRepositoriesEvent) error
 	ProcessRepositoryEvent(*github.RepositoryEvent) error
+	ProcessCheckRunEvent(deliveryID, githubDeliveryID string, event *github.CheckRunEvent) error
+	ProcessPullRequestEvent(deliveryID, githubDeliveryID string, event *github.PullRequestEvent) error
+	ProcessMergeGroupEvent(deliveryID, githubDeliveryID string, event *MergeGroupEvent) error
+	ProcessIssueCommentEvent(deliveryID, githubDeliveryID string, event *github.IssueCommentEvent) error
+}
+
+// PullRequestChecker re-evaluates the CLA check of a pull request
+type PullRequestChecker interface {
+	RecheckPullRequest(ctx context.Context, repositoryID, pullRequestID int64) error
 }
 
 type eventHandlerService struct {
-	gitV1Repository   repositories.RepositoryInterface
-	githubOrgRepo     v1GithubOrg.RepositoryInterface
-	eventService      events.Service
-	autoEnableService dynamo_events.AutoEnableService
-	emailService      emails.Service
-	sendEmail         bool
+	gitV1Repository    repositories.RepositoryInterface
+	githubOrgRepo      v1GithubOrg.RepositoryInterface
+	eventService       events.Service
+	autoEnableService  dynamo_events.AutoEnableService
+	emailService       emails.Service
+	pullRequestChecker PullRequestChecker
+	deliveriesRepo     github_deliveries.RepositoryInterface
+	sendEmail          bool
 }
 
 // NewService creates a new instance of the Event Handler Service
@@ -49,9 +62,11 @@ func NewService(gitV1Repository repositories.RepositoryInterface,
 	githubOrgRepo v1GithubOrg.RepositoryInterface,
 	eventService events.Service,
 	autoEnableService dynamo_events.AutoEnableService,
-	emailService emails.Service) Service {
+	emailService emails.Service,
+	pullRequestChecker PullRequestChecker,
+	deliveriesRepo github_deliveries.RepositoryInterface) Service {
 
-	return newService(gitV1Repository, githubOrgRepo, eventService, autoEnableService, emailService, true)
+	return newService(gitV1Repository, githubOrgRepo, eventService, autoEnableService, emailService, pullRequestChecker, deliveriesRepo, true)
 }
 
 func newService(gitV1Repository repositories.RepositoryInterface,
@@ -59,14 +74,18 @@ func newService(gitV1Repository repositories.RepositoryInterface,
 	eventService events.Service,
 	autoEnableService dynamo_events.AutoEnableService,
 	emailService emails.Service,
+	pullRequestChecker PullRequestChecker,
+	deliveriesRepo github_deliveries.RepositoryInterface,
 	sendEmail bool) Service {
 	return &eventHandlerService{
-		gitV1Repository:   gitV1Repository,
-		githubOrgRepo:     githubOrgRepo,
-		eventService:      eventService,
-		autoEnableService: autoEnableService,
-		emailService:      emailService,
-		sendEmail:         sendEmail,
+		gitV1Repository:    gitV1Repository,
+		githubOrgRepo:      githubOrgRepo,
+		eventService:       eventService,
+		autoEnableService:  autoEnableService,
+		emailService:       emailService,
+		pullRequestChecker: pullRequestChecker,
+		deliveriesRepo:     deliveriesRepo,
+		sendEmail:          sendEmail,
 	}
 }
 
@@ -536,3 +555,68 @@ func (s *eventHandlerService) ProcessInstallationRepositoriesEvent(event *github
 
 	return nil
 }
+
+// ProcessCheckRunEvent handles the EasyCLA check run "Re-run" and "Sign CLA" actions - the re-runs are queued
+func (s *eventHandlerService) ProcessCheckRunEvent(deliveryID, githubDeliveryID string, event *github.CheckRunEvent) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "v2.github_activity.service.ProcessCheckRunEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+	if event.Action == nil {
+		return fmt.Errorf("no action found in event payload")
+	}
+	if event.CheckRun == nil || event.CheckRun.GetName() != claGitHub.CheckRunName {
+		// not our check run
+		return nil
+	}
+	if event.Repo == nil || event.Repo.ID == nil {
+		return fmt.Errorf("missing repository object in event payload")
+	}
+
+	pullRequestID, err := claGitHub.PullRequestIDFromCheckRun(event.CheckRun)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to determine the pull request of the check run")
+		return err
+	}
+	f["repositoryID"] = *event.Repo.ID
+	f["pullRequestID"] = pullRequestID
+	f["action"] = *event.Action
+
+	switch *event.Action {
+	case "rerequested":
+		return s.queueCheckRun(ctx, deliveryID, githubDeliveryID, event, pullRequestID)
+	case "requested_action":
+		if event.RequestedAction == nil {
+			return fmt.Errorf("missing requested action in event payload")
+		}
+		switch event.RequestedAction.Identifier {
+		case claGitHub.CheckRunActionRerun:
+			return s.queueCheckRun(ctx, deliveryID, githubDeliveryID, event, pullRequestID)
+		case claGitHub.CheckRunActionSign:
+			if event.Installation == nil || event.Installation.ID == nil || event.Repo.Owner == nil || event.Sender == nil {
+				return fmt.Errorf("missing installation, owner or sender in event payload")
+			}
+			return claGitHub.CreateSignCLAComment(ctx, *event.Installation.ID, event.Repo.Owner.GetLogin(), event.Repo.GetName(),
+				pullRequestID, event.Sender.GetLogin(), event.CheckRun.GetDetailsURL())
+		default:
+			log.WithFields(f).Warnf("no handler for requested action : %s", event.RequestedAction.Identifier)
+		}
+	default:
+		log.WithFields(f).Debugf("no handler for action : %s", *event.Action)
+	}
+
+	return nil
+}
+
+// queueCheckRun queues the CLA check of the pull request of a re-run check run
+func (s *eventHandlerService) queueCheckRun(ctx context.Context, deliveryID, githubDeliveryID string, event *github.CheckRunEvent, pullRequestID int) error {
+	delivery := github_deliveries.NewDelivery(deliveryID, githubDeliveryID, "check_run", *event.Action)
+	delivery.InstallationID = event.GetInstallation().GetID()
+	delivery.RepositoryID = *event.Repo.ID
+	delivery.RepositoryName = event.Repo.GetFullName()
+	delivery.PullRequestIDs = []int64{int64(pullRequestID)}
+	delivery.HeadSHA = event.CheckRun.GetHeadSHA()
+	delivery.Sender = event.GetSender().GetLogin()
+	return s.queueDelivery(ctx, delivery)
+}
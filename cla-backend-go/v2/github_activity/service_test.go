@@ -55,7 +55,7 @@ func TestEventHandlerService_ProcessRepositoryEvent_HandleRepositoryRenamedActio
 			},
 		}).Return()
 
-	activityService := newService(githubRepo, githubOrganizationRepo, eventsService, nil, nil, false)
+	activityService := newService(githubRepo, githubOrganizationRepo, eventsService, nil, nil, nil, nil, false)
 	err := activityService.ProcessRepositoryEvent(&github.RepositoryEvent{
 		Action: aws.String("renamed"),
 		Repo: &github.Repository{
@@ -162,7 +162,7 @@ func TestEventHandlerService_ProcessRepositoryEvent_HandleRepositoryTransferredA
 					}).Return()
 			}
 
-			activityService := newService(githubRepo, githubOrganizationRepo, eventsService, nil, nil, false)
+			activityService := newService(githubRepo, githubOrganizationRepo, eventsService, nil, nil, nil, nil, false)
 			err := activityService.ProcessRepositoryEvent(&github.RepositoryEvent{
 				Action: aws.String("transferred"),
 				Repo: &github.Repository{
@@ -0,0 +1,93 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_deliveries
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+const (
+	// StatusQueued indicates the delivery is waiting to be processed by the DynamoDB stream handler
+	StatusQueued = "queued"
+	// StatusProcessing indicates the delivery is being processed
+	StatusProcessing = "processing"
+	// StatusCompleted indicates the delivery was processed successfully
+	StatusCompleted = "completed"
+	// StatusFailed indicates the delivery processing failed - a redelivery of the same event is processed again
+	StatusFailed = "failed"
+)
+
+// MergeGroupEventType is the X-GitHub-Event value of the merge queue events
+const MergeGroupEventType = "merge_group"
+
+// RecheckCommand is the pull request comment which re-runs the EasyCLA check
+const RecheckCommand = "/easycla recheck"
+
+// retentionDays is the number of days a delivery record is kept for the idempotency check
+const retentionDays = 7
+
+// DBDeliveryModel data model for the GitHub webhook deliveries table
+type DBDeliveryModel struct {
+	// DeliveryID is the idempotency key of the delivery, see DeliveryKey
+	DeliveryID       string  `dynamodbav:"delivery_id" json:"delivery_id"`
+	GitHubDeliveryID string  `dynamodbav:"github_delivery_id" json:"github_delivery_id"`
+	EventType        string  `dynamodbav:"event_type" json:"event_type"`
+	Action           string  `dynamodbav:"action" json:"action"`
+	InstallationID   int64   `dynamodbav:"installation_id" json:"installation_id"`
+	RepositoryID     int64   `dynamodbav:"repository_id" json:"repository_id"`
+	RepositoryName   string  `dynamodbav:"repository_name" json:"repository_name"`
+	PullRequestIDs   []int64 `dynamodbav:"pull_request_ids" json:"pull_request_ids"`
+	HeadSHA          string  `dynamodbav:"head_sha" json:"head_sha"`
+	HeadRef          string  `dynamodbav:"head_ref" json:"head_ref"`
+	BaseRef          string  `dynamodbav:"base_ref" json:"base_ref"`
+	BaseSHA          string  `dynamodbav:"base_sha" json:"base_sha"`
+	Sender           string  `dynamodbav:"sender" json:"sender"`
+	Status           string  `dynamodbav:"status" json:"status"`
+	ErrorMessage     string  `dynamodbav:"error_message" json:"error_message"`
+	DateCreated      string  `dynamodbav:"date_created" json:"date_created"`
+	DateModified     string  `dynamodbav:"date_modified" json:"date_modified"`
+	Expire           int64   `dynamodbav:"expire" json:"expire"`
+	Version          string  `dynamodbav:"version" json:"version"`
+}
+
+// NewDelivery returns a queued delivery record of the webhook event
+func NewDelivery(deliveryID, githubDeliveryID, eventType, action string) *DBDeliveryModel {
+	now, nowStr := utils.CurrentTime()
+	return &DBDeliveryModel{
+		DeliveryID:       deliveryID,
+		GitHubDeliveryID: githubDeliveryID,
+		EventType:        eventType,
+		Action:           action,
+		Status:           StatusQueued,
+		DateCreated:      nowStr,
+		DateModified:     nowStr,
+		Expire:           now.AddDate(0, 0, retentionDays).Unix(),
+		Version:          "v1",
+	}
+}
+
+// DeliveryKey returns the idempotency key of a webhook delivery - the X-GitHub-Delivery GUID, which GitHub keeps
+// for the redeliveries, or the hash of the event type and payload when the header is missing
+func DeliveryKey(githubDeliveryID, eventType string, payload []byte) string {
+	if githubDeliveryID != "" {
+		return githubDeliveryID
+	}
+	sum := sha256.Sum256(append([]byte(eventType+":"), payload...))
+	return fmt.Sprintf("%s:%s", eventType, hex.EncodeToString(sum[:]))
+}
+
+// IsRecheckCommand returns true if a line of the comment is the recheck command
+func IsRecheckCommand(comment string) bool {
+	for _, line := range strings.Split(comment, "\n") {
+		if strings.EqualFold(strings.TrimSpace(line), RecheckCommand) {
+			return true
+		}
+	}
+	return false
+}
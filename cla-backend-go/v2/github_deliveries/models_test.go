@@ -0,0 +1,42 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_deliveries
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliveryKey(t *testing.T) {
+	payload := []byte(`{"action":"opened"}`)
+
+	assert.Equal(t, "72d3162e-cc78-11e3-81ab-4c9367dc0958", DeliveryKey("72d3162e-cc78-11e3-81ab-4c9367dc0958", "pull_request", payload))
+
+	key := DeliveryKey("", "pull_request", payload)
+	assert.True(t, strings.HasPrefix(key, "pull_request:"))
+	assert.Equal(t, key, DeliveryKey("", "pull_request", payload))
+	assert.NotEqual(t, key, DeliveryKey("", "pull_request", []byte(`{"action":"reopened"}`)))
+	assert.NotEqual(t, key, DeliveryKey("", "merge_group", payload))
+}
+
+func TestIsRecheckCommand(t *testing.T) {
+	testCases := []struct {
+		comment string
+		recheck bool
+	}{
+		{comment: "/easycla recheck", recheck: true},
+		{comment: "  /EasyCLA Recheck  ", recheck: true},
+		{comment: "I signed the CLA\r\n/easycla recheck\r\nthanks", recheck: true},
+		{comment: "please /easycla recheck", recheck: false},
+		{comment: "/easycla rechecked", recheck: false},
+		{comment: "/easycla", recheck: false},
+		{comment: "", recheck: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.recheck, IsRecheckCommand(tc.comment), tc.comment)
+	}
+}
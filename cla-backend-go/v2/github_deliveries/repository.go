@@ -0,0 +1,134 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_deliveries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// table columns
+const (
+	// DeliveryIDColumn is the primary key of the deliveries table
+	DeliveryIDColumn = "delivery_id"
+	// StatusColumn is the processing status of the delivery
+	StatusColumn = "status"
+)
+
+// ErrDuplicateDelivery is returned when the delivery was already received
+var ErrDuplicateDelivery = errors.New("duplicate webhook delivery")
+
+// RepositoryInterface defines the GitHub webhook deliveries data access functions
+type RepositoryInterface interface {
+	AddDelivery(ctx context.Context, delivery *DBDeliveryModel) error
+	UpdateDeliveryStatus(ctx context.Context, deliveryID, status, errorMessage string) error
+}
+
+// Repository object/struct
+type Repository struct {
+	stage               string
+	dynamoDBClient      *dynamodb.DynamoDB
+	deliveriesTableName string
+}
+
+// NewRepository creates a new instance of the GitHub webhook deliveries repository
+func NewRepository(awsSession *session.Session, stage string) RepositoryInterface {
+	return &Repository{
+		stage:               stage,
+		dynamoDBClient:      dynamodb.New(awsSession),
+		deliveriesTableName: fmt.Sprintf("cla-%s-github-webhook-deliveries", stage),
+	}
+}
+
+// AddDelivery adds the delivery to the database, returns ErrDuplicateDelivery if the delivery was already received -
+// failed deliveries are replaced so that the redeliveries are processed again
+func (repo *Repository) AddDelivery(ctx context.Context, delivery *DBDeliveryModel) error {
+	f := logrus.Fields{
+		"functionName":   "v2.github_deliveries.repository.AddDelivery",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deliveryID":     delivery.DeliveryID,
+		"eventType":      delivery.EventType,
+		"action":         delivery.Action,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(delivery)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall github delivery record")
+		return err
+	}
+
+	condition := expression.AttributeNotExists(expression.Name(DeliveryIDColumn)).
+		Or(expression.Name(StatusColumn).Equal(expression.Value(StatusFailed)))
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem building condition expression")
+		return err
+	}
+
+	log.WithFields(f).Debug("adding github delivery record to the database...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                      av,
+		TableName:                 aws.String(repo.deliveriesTableName),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Debug("github delivery already received")
+			return ErrDuplicateDelivery
+		}
+		log.WithFields(f).WithError(err).Warn("unable to add github delivery record")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateDeliveryStatus updates the processing status of the delivery
+func (repo *Repository) UpdateDeliveryStatus(ctx context.Context, deliveryID, status, errorMessage string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.github_deliveries.repository.UpdateDeliveryStatus",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deliveryID":     deliveryID,
+		"status":         status,
+	}
+
+	_, now := utils.CurrentTime()
+	update := expression.Set(expression.Name(StatusColumn), expression.Value(status)).
+		Set(expression.Name("error_message"), expression.Value(errorMessage)).
+		Set(expression.Name("date_modified"), expression.Value(now))
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem building update expression")
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.deliveriesTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			DeliveryIDColumn: {S: aws.String(deliveryID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update github delivery status")
+		return err
+	}
+
+	return nil
+}
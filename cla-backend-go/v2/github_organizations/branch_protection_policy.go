@@ -0,0 +1,188 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_organizations
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github/branch_protection"
+	v1GithubOrg "github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// the number of repositories checked concurrently by the drift report
+const driftReportWorkers = 5
+
+func v2BranchProtectionPolicyModel(in *v1GithubOrg.BranchProtectionPolicy) *models.GithubOrganizationBranchProtectionPolicy {
+	if in == nil {
+		return &models.GithubOrganizationBranchProtectionPolicy{
+			BranchPatterns: []string{},
+		}
+	}
+	return &models.GithubOrganizationBranchProtectionPolicy{
+		DefaultBranch:  in.DefaultBranch,
+		BranchPatterns: append([]string{}, in.BranchPatterns...),
+		EnforceAdmin:   in.EnforceAdmin,
+		DateModified:   in.DateModified,
+	}
+}
+
+// GetGithubOrganizationBranchProtectionPolicy returns the branch protection policy of the GitHub organization
+func (s service) GetGithubOrganizationBranchProtectionPolicy(ctx context.Context, projectSFID, organizationName string) (*models.GithubOrganizationBranchProtectionPolicy, error) {
+	policy, err := s.repo.GetGitHubOrganizationBranchProtectionPolicy(ctx, organizationName)
+	if err != nil {
+		return nil, err
+	}
+	return v2BranchProtectionPolicyModel(policy), nil
+}
+
+// UpdateGithubOrganizationBranchProtectionPolicy saves the branch protection policy of the GitHub organization - the
+// policy is applied to the CLA enabled repositories of the organization by the dynamo events handler
+func (s service) UpdateGithubOrganizationBranchProtectionPolicy(ctx context.Context, projectSFID, organizationName string, input *models.GithubOrganizationBranchProtectionPolicy) (*models.GithubOrganizationBranchProtectionPolicy, error) {
+	f := logrus.Fields{
+		"functionName":     "v2.github_organizations.branch_protection_policy.UpdateGithubOrganizationBranchProtectionPolicy",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"projectSFID":      projectSFID,
+		"organizationName": organizationName,
+		"defaultBranch":    input.DefaultBranch,
+		"branchPatterns":   input.BranchPatterns,
+		"enforceAdmin":     input.EnforceAdmin,
+	}
+
+	var branchPatterns []string
+	seen := make(map[string]bool)
+	for _, pattern := range input.BranchPatterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || seen[pattern] {
+			continue
+		}
+		seen[pattern] = true
+		branchPatterns = append(branchPatterns, pattern)
+	}
+
+	var policy *v1GithubOrg.BranchProtectionPolicy
+	if input.DefaultBranch || len(branchPatterns) > 0 {
+		policy = &v1GithubOrg.BranchProtectionPolicy{
+			DefaultBranch:  input.DefaultBranch,
+			BranchPatterns: branchPatterns,
+			EnforceAdmin:   input.EnforceAdmin,
+		}
+	}
+
+	log.WithFields(f).Debug("updating the branch protection policy...")
+	if err := s.repo.UpdateGitHubOrganizationBranchProtectionPolicy(ctx, organizationName, policy); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem updating the branch protection policy")
+		return nil, err
+	}
+
+	return v2BranchProtectionPolicyModel(policy), nil
+}
+
+// GetGithubOrganizationBranchProtectionDrift checks the CLA enabled repositories of the GitHub organization against the
+// branch protection policy, and reports the protected branches which no longer require the EasyCLA check
+func (s service) GetGithubOrganizationBranchProtectionDrift(ctx context.Context, projectSFID, organizationName string) (*models.GithubOrganizationBranchProtectionDriftReport, error) {
+	f := logrus.Fields{
+		"functionName":     "v2.github_organizations.branch_protection_policy.GetGithubOrganizationBranchProtectionDrift",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"projectSFID":      projectSFID,
+		"organizationName": organizationName,
+	}
+
+	githubOrg, err := s.repo.GetGitHubOrganization(ctx, organizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the github organization")
+		return nil, err
+	}
+
+	storedPolicy, err := s.repo.GetGitHubOrganizationBranchProtectionPolicy(ctx, organizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the branch protection policy")
+		return nil, err
+	}
+
+	report := &models.GithubOrganizationBranchProtectionDriftReport{
+		OrganizationName: githubOrg.OrganizationName,
+		Policy:           v2BranchProtectionPolicyModel(storedPolicy),
+		Drift:            []*models.GithubBranchProtectionDrift{},
+		Errors:           []*models.GithubBranchProtectionDrift{},
+	}
+	policy := storedPolicy.ToPolicy()
+	if policy.IsEmpty() {
+		log.WithFields(f).Debug("no branch protection policy - nothing to check")
+		return report, nil
+	}
+
+	repos, err := s.gitV1Repository.GitHubGetRepositoriesByOrganizationName(ctx, organizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the repositories of the github organization")
+		return nil, err
+	}
+
+	branchProtectionRepo, err := branch_protection.NewBranchProtectionRepository(githubOrg.OrganizationInstallationID, branch_protection.EnableBlockingLimiter())
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("initializing branch protection repository failed")
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	workerTokens := make(chan struct{}, driftReportWorkers)
+	for _, repo := range repos {
+		if !repo.Enabled {
+			continue
+		}
+		report.RepositoriesChecked++
+		repoName := branch_protection.CleanGithubRepoName(repo.RepositoryName)
+
+		workerTokens <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-workerTokens
+				wg.Done()
+			}()
+
+			drift, checkErr := branchProtectionRepo.CheckPolicy(ctx, githubOrg.OrganizationName, repoName, policy)
+			mu.Lock()
+			defer mu.Unlock()
+			if checkErr != nil {
+				log.WithFields(f).WithError(checkErr).Warnf("unable to check the branch protection of the repository: %s", repoName)
+				report.Errors = append(report.Errors, &models.GithubBranchProtectionDrift{
+					RepositoryName: repoName,
+					Reason:         checkErr.Error(),
+				})
+				return
+			}
+			for _, d := range drift {
+				report.Drift = append(report.Drift, &models.GithubBranchProtectionDrift{
+					RepositoryName:      d.RepositoryName,
+					BranchPattern:       d.BranchPattern,
+					Reason:              d.Reason,
+					MissingStatusChecks: d.MissingStatusChecks,
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	sortDrift(report.Drift)
+	sortDrift(report.Errors)
+	log.WithFields(f).Debugf("checked %d repositories - found %d drifted branches", report.RepositoriesChecked, len(report.Drift))
+	return report, nil
+}
+
+func sortDrift(drift []*models.GithubBranchProtectionDrift) {
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].RepositoryName != drift[j].RepositoryName {
+			return strings.ToLower(drift[i].RepositoryName) < strings.ToLower(drift[j].RepositoryName)
+		}
+		return drift[i].BranchPattern < drift[j].BranchPattern
+	})
+}
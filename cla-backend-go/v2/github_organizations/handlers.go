@@ -5,6 +5,7 @@ package github_organizations
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/github_organizations"
 	"github.com/communitybridge/easycla/cla-backend-go/github"
+	v1GithubOrg "github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime/middleware"
 )
@@ -241,4 +243,189 @@ func Configure(api *operations.EasyclaAPI, service Service, eventService events.
 
 			return github_organizations.NewUpdateProjectGithubOrganizationConfigOK()
 		})
+
+	api.GithubOrganizationsGetProjectGithubOrganizationBranchProtectionPolicyHandler = github_organizations.GetProjectGithubOrganizationBranchProtectionPolicyHandlerFunc(
+		func(params github_organizations.GetProjectGithubOrganizationBranchProtectionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "github_organization.handlers.GithubOrganizationsGetProjectGithubOrganizationBranchProtectionPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Get Project GitHub Organization Branch Protection Policy with Project scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return github_organizations.NewGetProjectGithubOrganizationBranchProtectionPolicyForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetGithubOrganizationBranchProtectionPolicy(ctx, params.ProjectSFID, params.OrgName)
+			if err != nil {
+				if errors.Is(err, v1GithubOrg.ErrOrganizationDoesNotExist) {
+					msg := fmt.Sprintf("github organization not found: %s", params.OrgName)
+					log.WithFields(f).Debug(msg)
+					return github_organizations.NewGetProjectGithubOrganizationBranchProtectionPolicyNotFound().WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("problem loading the branch protection policy of the GitHub Organization: %s", params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return github_organizations.NewGetProjectGithubOrganizationBranchProtectionPolicyBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return github_organizations.NewGetProjectGithubOrganizationBranchProtectionPolicyOK().WithPayload(result)
+		})
+
+	api.GithubOrganizationsUpdateProjectGithubOrganizationBranchProtectionPolicyHandler = github_organizations.UpdateProjectGithubOrganizationBranchProtectionPolicyHandlerFunc(
+		func(params github_organizations.UpdateProjectGithubOrganizationBranchProtectionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "github_organization.handlers.GithubOrganizationsUpdateProjectGithubOrganizationBranchProtectionPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Update Project GitHub Organization Branch Protection Policy with Project scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return github_organizations.NewUpdateProjectGithubOrganizationBranchProtectionPolicyForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			if params.Body == nil {
+				msg := fmt.Sprintf("missing branch protection policy in request body for project SFID: %s for organization: %s", params.ProjectSFID, params.OrgName)
+				log.WithFields(f).Debug(msg)
+				return github_organizations.NewUpdateProjectGithubOrganizationBranchProtectionPolicyBadRequest().WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+			}
+
+			result, err := service.UpdateGithubOrganizationBranchProtectionPolicy(ctx, params.ProjectSFID, params.OrgName, params.Body)
+			if err != nil {
+				if errors.Is(err, v1GithubOrg.ErrOrganizationDoesNotExist) {
+					msg := fmt.Sprintf("github organization not found: %s", params.OrgName)
+					log.WithFields(f).Debug(msg)
+					return github_organizations.NewUpdateProjectGithubOrganizationBranchProtectionPolicyNotFound().WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("problem updating the branch protection policy for project SFID: %s for organization: %s", params.ProjectSFID, params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return github_organizations.NewUpdateProjectGithubOrganizationBranchProtectionPolicyBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			// Log the event
+			eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+				LfUsername:  authUser.UserName,
+				EventType:   events.GitHubOrganizationBranchProtectionPolicyUpdated,
+				ProjectSFID: params.ProjectSFID,
+				EventData: &events.GitHubOrganizationBranchProtectionPolicyUpdatedEventData{
+					GitHubOrganizationName: params.OrgName,
+					DefaultBranch:          result.DefaultBranch,
+					BranchPatterns:         result.BranchPatterns,
+					EnforceAdmin:           result.EnforceAdmin,
+				},
+			})
+
+			return github_organizations.NewUpdateProjectGithubOrganizationBranchProtectionPolicyOK().WithPayload(result)
+		})
+
+	api.GithubOrganizationsGetProjectGithubOrganizationBranchProtectionDriftHandler = github_organizations.GetProjectGithubOrganizationBranchProtectionDriftHandlerFunc(
+		func(params github_organizations.GetProjectGithubOrganizationBranchProtectionDriftParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "github_organization.handlers.GithubOrganizationsGetProjectGithubOrganizationBranchProtectionDriftHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Get Project GitHub Organization Branch Protection Drift with Project scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return github_organizations.NewGetProjectGithubOrganizationBranchProtectionDriftForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetGithubOrganizationBranchProtectionDrift(ctx, params.ProjectSFID, params.OrgName)
+			if err != nil {
+				if errors.Is(err, v1GithubOrg.ErrOrganizationDoesNotExist) {
+					msg := fmt.Sprintf("github organization not found: %s", params.OrgName)
+					log.WithFields(f).Debug(msg)
+					return github_organizations.NewGetProjectGithubOrganizationBranchProtectionDriftNotFound().WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("problem building the branch protection drift report of the GitHub Organization: %s", params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return github_organizations.NewGetProjectGithubOrganizationBranchProtectionDriftBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return github_organizations.NewGetProjectGithubOrganizationBranchProtectionDriftOK().WithPayload(result)
+		})
+
+	api.GithubOrganizationsUpdateProjectGithubOrganizationRulesetHandler = github_organizations.UpdateProjectGithubOrganizationRulesetHandlerFunc(
+		func(params github_organizations.UpdateProjectGithubOrganizationRulesetParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "github_organization.handlers.GithubOrganizationsUpdateProjectGithubOrganizationRulesetHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Update Project GitHub Organization Ruleset with Project scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return github_organizations.NewUpdateProjectGithubOrganizationRulesetForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			if params.Body == nil {
+				msg := fmt.Sprintf("missing ruleset in request body for project SFID: %s for organization: %s", params.ProjectSFID, params.OrgName)
+				log.WithFields(f).Debug(msg)
+				return github_organizations.NewUpdateProjectGithubOrganizationRulesetBadRequest().WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+			}
+
+			result, err := service.UpdateGithubOrganizationRuleset(ctx, params.ProjectSFID, params.OrgName, params.Body)
+			if err != nil {
+				if errors.Is(err, v1GithubOrg.ErrOrganizationDoesNotExist) {
+					msg := fmt.Sprintf("github organization not found: %s", params.OrgName)
+					log.WithFields(f).Debug(msg)
+					return github_organizations.NewUpdateProjectGithubOrganizationRulesetNotFound().WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("problem updating the EasyCLA ruleset for project SFID: %s for organization: %s", params.ProjectSFID, params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return github_organizations.NewUpdateProjectGithubOrganizationRulesetBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			// Log the event
+			eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+				LfUsername:  authUser.UserName,
+				EventType:   events.GitHubOrganizationRulesetUpdated,
+				ProjectSFID: params.ProjectSFID,
+				EventData: &events.GitHubOrganizationRulesetUpdatedEventData{
+					GitHubOrganizationName: params.OrgName,
+					RulesetID:              result.RulesetID,
+					BranchPatterns:         result.BranchPatterns,
+				},
+			})
+
+			return github_organizations.NewUpdateProjectGithubOrganizationRulesetOK().WithPayload(result)
+		})
 }
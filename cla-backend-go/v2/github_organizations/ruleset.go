@@ -0,0 +1,83 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_organizations
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github/branch_protection"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// UpdateGithubOrganizationRuleset creates or updates the EasyCLA organization ruleset, which requires the EasyCLA check
+// on the given branches of each repository of the GitHub organization
+func (s service) UpdateGithubOrganizationRuleset(ctx context.Context, projectSFID, organizationName string, input *models.GithubOrganizationRulesetInput) (*models.GithubOrganizationRuleset, error) {
+	f := logrus.Fields{
+		"functionName":     "v2.github_organizations.ruleset.UpdateGithubOrganizationRuleset",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"projectSFID":      projectSFID,
+		"organizationName": organizationName,
+		"branchPatterns":   input.BranchPatterns,
+	}
+
+	githubOrg, err := s.repo.GetGitHubOrganization(ctx, organizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the github organization")
+		return nil, err
+	}
+
+	var branchPatterns []string
+	for _, pattern := range input.BranchPatterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern != "" {
+			branchPatterns = append(branchPatterns, pattern)
+		}
+	}
+
+	branchProtectionRepo, err := branch_protection.NewBranchProtectionRepository(githubOrg.OrganizationInstallationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("initializing branch protection repository failed")
+		return nil, err
+	}
+
+	log.WithFields(f).Debug("updating the EasyCLA organization ruleset...")
+	ruleset, err := branchProtectionRepo.EnableOrganizationRulesetStatusChecks(ctx, githubOrg.OrganizationName, branchPatterns, []string{utils.GitHubBotName})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem updating the EasyCLA organization ruleset")
+		return nil, err
+	}
+
+	return v2RulesetModel(ruleset), nil
+}
+
+func v2RulesetModel(ruleset *branch_protection.Ruleset) *models.GithubOrganizationRuleset {
+	result := &models.GithubOrganizationRuleset{
+		RulesetID:      ruleset.ID,
+		Name:           ruleset.Name,
+		Enforcement:    ruleset.Enforcement,
+		BranchPatterns: []string{},
+		StatusChecks:   []string{},
+	}
+	if ruleset.Conditions != nil && ruleset.Conditions.RefName != nil {
+		result.BranchPatterns = append(result.BranchPatterns, ruleset.Conditions.RefName.Include...)
+	}
+	for _, rule := range ruleset.Rules {
+		if rule.Type != branch_protection.RulesetRuleRequiredStatusChecks {
+			continue
+		}
+		var parameters branch_protection.RequiredStatusChecksParameters
+		if err := json.Unmarshal(rule.Parameters, &parameters); err != nil {
+			continue
+		}
+		for _, check := range parameters.RequiredStatusChecks {
+			result.StatusChecks = append(result.StatusChecks, check.Context)
+		}
+	}
+	return result
+}
@@ -42,6 +42,10 @@ type Service interface {
 	AddGithubOrganization(ctx context.Context, projectSFID string, input *models.GithubCreateOrganization) (*models.GithubOrganization, error)
 	DeleteGithubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error
 	UpdateGithubOrganization(ctx context.Context, projectSFID string, organizationName string, autoEnabled bool, autoEnabledClaGroupID string, branchProtectionEnabled bool) error
+	GetGithubOrganizationBranchProtectionPolicy(ctx context.Context, projectSFID, organizationName string) (*models.GithubOrganizationBranchProtectionPolicy, error)
+	UpdateGithubOrganizationBranchProtectionPolicy(ctx context.Context, projectSFID, organizationName string, input *models.GithubOrganizationBranchProtectionPolicy) (*models.GithubOrganizationBranchProtectionPolicy, error)
+	GetGithubOrganizationBranchProtectionDrift(ctx context.Context, projectSFID, organizationName string) (*models.GithubOrganizationBranchProtectionDriftReport, error)
+	UpdateGithubOrganizationRuleset(ctx context.Context, projectSFID, organizationName string, input *models.GithubOrganizationRulesetInput) (*models.GithubOrganizationRuleset, error)
 }
 
 type service struct {
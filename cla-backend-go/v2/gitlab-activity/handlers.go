@@ -4,9 +4,11 @@
 package gitlab_activity
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strings"
 
@@ -73,15 +75,7 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 				utils.ErrorResponseBadRequest(reqID, msg))
 		}
 
-		encryptedOauthResponse, err := gitlabOrgService.RefreshGitLabOrganizationAuth(ctx, gitlabOrg)
-		if err != nil {
-			msg := fmt.Sprintf("refreshing gitlab org auth failed : %v", err)
-			log.WithFields(f).Errorf(msg)
-			return gitlab_activity.NewGitlabActivityBadRequest().WithPayload(
-				utils.ErrorResponseBadRequest(reqID, msg))
-		}
-
-		gitlabClient, err := gitlab_api.NewGitlabOauthClient(*encryptedOauthResponse, gitLabApp)
+		gitlabClient, err := gitlabOrgService.GetGitLabOrganizationClient(ctx, gitlabOrg)
 		if err != nil {
 			msg := fmt.Sprintf("initializing gitlab client : %v", err)
 			log.WithFields(f).Errorf(msg)
@@ -106,7 +100,7 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 				utils.ErrorResponseBadRequest(reqID, msg))
 		}
 
-		err = service.ProcessMergeActivity(ctx, gitlabOrg.AuthState, &ProcessMergeActivityInput{
+		err = service.ProcessMergeActivity(ctx, &ProcessMergeActivityInput{
 			ProjectName:      gitlabProject.Name,
 			ProjectPath:      gitlabProject.PathWithNamespace,
 			ProjectNamespace: gitlabProject.Namespace.Name,
@@ -140,11 +134,6 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 		log.WithFields(f).Debugf("handling gitlab activity callback")
 		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID)
 
-		// if params.XGitlabToken == "" {
-		// 	return gitlab_activity.NewGitlabActivityUnauthorized().WithPayload(
-		// 		utils.ErrorResponseUnauthorized(reqID, "missing webhook secret token"))
-		// }
-
 		// General note for this API endpoint:
 		// Even though we had an issue - we will  a 200 request indicating that we received the event, otherwise
 		// gitlab will disable the webhook after several failed requests (need to confirm this behavior)
@@ -178,6 +167,18 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 			return gitlab_activity.NewGitlabActivityOK()
 		}
 
+		if err := service.ValidateWebhookToken(ctx, params.XGitlabToken, mergeEvent); err != nil {
+			if errors.Is(err, secretTokenMismatch) {
+				msg := "webhook secret token mismatch"
+				log.WithFields(f).Warn(msg)
+				return gitlab_activity.NewGitlabActivityUnauthorized().WithPayload(
+					utils.ErrorResponseUnauthorized(reqID, msg))
+			}
+			log.WithFields(f).Debugf("validating gitlab webhook secret token failed : %v", err)
+			// Always return 200 response
+			return gitlab_activity.NewGitlabActivityOK()
+		}
+
 		if mergeEvent.ObjectKind == "merge_request" {
 
 			if mergeEvent.ObjectAttributes.State != "opened" && mergeEvent.ObjectAttributes.State != "update" && mergeEvent.ObjectAttributes.State != "reopen" {
@@ -187,7 +188,7 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 				return gitlab_activity.NewGitlabActivityOK()
 			}
 
-			err = service.ProcessMergeOpenedActivity(ctx, params.XGitlabToken, mergeEvent)
+			err = service.ProcessMergeOpenedActivity(ctx, mergeEvent)
 			if err != nil {
 				msg := fmt.Sprintf("processing gitlab merge event failed : %v", err)
 				log.WithFields(f).Debugf(msg)
@@ -197,7 +198,7 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 
 		} else if mergeEvent.ObjectKind == "note" && strings.Contains(mergeEvent.ObjectAttributes.Description, "/easycla") {
 			log.WithFields(f).Debugf("processing gitlab merge comment event")
-			err = service.ProcessMergeCommentActivity(ctx, params.XGitlabToken, mergeEvent)
+			err = service.ProcessMergeCommentActivity(ctx, mergeEvent)
 			if err != nil {
 				msg := fmt.Sprintf("processing gitlab merge comment event failed : %v", err)
 				log.WithFields(f).Debugf(msg)
@@ -209,6 +210,94 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 		return gitlab_activity.NewGitlabActivityOK()
 	})
 
+	api.GitlabActivityGitlabStatusCheckHandler = gitlab_activity.GitlabStatusCheckHandlerFunc(func(params gitlab_activity.GitlabStatusCheckParams) middleware.Responder {
+		requestID, _ := uuid.NewV4()
+		reqID := requestID.String()
+		f := logrus.Fields{
+			"functionName": "gitlab_activity.handlers.GitlabActivityGitlabStatusCheckHandler",
+			"requestID":    reqID,
+		}
+		log.WithFields(f).Debugf("handling gitlab external status check callback")
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID)
+
+		// The signature of the callback is checked by the statusCheckSignatureMiddleware. As for the activity callback,
+		// we always return a 200 response - the status check stays pending until we respond to it, the merge request
+		// event is processed again on the next change or /easycla comment
+
+		jsonData, err := params.GitlabActivityInput.MarshalJSON()
+		if err != nil {
+			log.WithFields(f).Debugf("unmarshall event data failed : %v", err)
+			return gitlab_activity.NewGitlabStatusCheckOK()
+		}
+
+		event, err := gitlabsdk.ParseWebhook(gitlabsdk.EventTypeMergeRequest, jsonData)
+		if err != nil {
+			log.WithFields(f).Debugf("parsing gitlab merge event type failed : %v", err)
+			return gitlab_activity.NewGitlabStatusCheckOK()
+		}
+
+		mergeEvent, ok := event.(*gitlabsdk.MergeEvent)
+		if !ok || mergeEvent.ObjectKind != "merge_request" {
+			log.WithFields(f).Debugf("status check callback is not a merge request event - ignoring")
+			return gitlab_activity.NewGitlabStatusCheckOK()
+		}
+
+		if mergeEvent.ObjectAttributes.State != "opened" {
+			log.WithFields(f).Debugf("merge request state : %s is not opened - ignoring", mergeEvent.ObjectAttributes.State)
+			return gitlab_activity.NewGitlabStatusCheckOK()
+		}
+
+		f["gitlabProjectID"] = mergeEvent.Project.ID
+		f["mergeID"] = mergeEvent.ObjectAttributes.IID
+		if err := service.ProcessMergeOpenedActivity(ctx, mergeEvent); err != nil {
+			log.WithFields(f).WithError(err).Warn("processing gitlab external status check callback failed")
+		}
+
+		return gitlab_activity.NewGitlabStatusCheckOK()
+	})
+
+	api.GitlabActivityGitlabProjectHookHandler = gitlab_activity.GitlabProjectHookHandlerFunc(func(params gitlab_activity.GitlabProjectHookParams) middleware.Responder {
+		requestID, _ := uuid.NewV4()
+		reqID := requestID.String()
+		f := logrus.Fields{
+			"functionName": "gitlab_activity.handlers.GitlabActivityGitlabProjectHookHandler",
+			"requestID":    reqID,
+		}
+		log.WithFields(f).Debugf("handling gitlab project hook callback")
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID)
+
+		// As for the activity callback, we always return a 200 response except for a secret token mismatch - the
+		// projects missed are picked up by the periodic GitLab repository check
+
+		jsonData, err := params.GitlabActivityInput.MarshalJSON()
+		if err != nil {
+			log.WithFields(f).Debugf("unmarshall event data failed : %v", err)
+			return gitlab_activity.NewGitlabProjectHookOK()
+		}
+
+		event, err := gitlab_api.ParseProjectHookEvent(jsonData)
+		if err != nil {
+			log.WithFields(f).Debugf("parsing gitlab project hook event failed : %v - ignoring", err)
+			return gitlab_activity.NewGitlabProjectHookOK()
+		}
+
+		instanceID := ""
+		if params.InstanceID != nil {
+			instanceID = *params.InstanceID
+		}
+		if err := service.ProcessProjectHookEvent(ctx, params.XGitlabToken, instanceID, event); err != nil {
+			if errors.Is(err, secretTokenMismatch) {
+				msg := "webhook secret token mismatch"
+				log.WithFields(f).Warn(msg)
+				return gitlab_activity.NewGitlabProjectHookUnauthorized().WithPayload(
+					utils.ErrorResponseUnauthorized(reqID, msg))
+			}
+			log.WithFields(f).WithError(err).Warn("processing gitlab project hook event failed")
+		}
+
+		return gitlab_activity.NewGitlabProjectHookOK()
+	})
+
 	api.GitlabActivityGitlabUserOauthCallbackHandler = gitlab_activity.GitlabUserOauthCallbackHandlerFunc(
 		func(guocp gitlab_activity.GitlabUserOauthCallbackParams) middleware.Responder {
 			reqID := utils.GetRequestID(guocp.XREQUESTID)
@@ -265,8 +354,18 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 						return
 					}
 
+					// the sign request stores the GitLab instance of the organization, gitlab.com when missing
+					instanceID, _ := session.Values["gitlab_instance_id"].(string)
+					instance, err := gitlabOrgService.GetGitLabInstance(ctx, instanceID)
+					if err != nil {
+						msg := fmt.Sprintf("unable to load the gitlab instance : %s", instanceID)
+						log.WithFields(f).WithError(err).Warn(msg)
+						http.Error(rw, msg, http.StatusInternalServerError)
+						return
+					}
+
 					log.WithFields(f).Debug("Fetching access token for user...")
-					token, err := gitlab_api.FetchOauthCredentials(guocp.Code)
+					token, err := gitlab_api.FetchOauthCredentials(instance, guocp.Code)
 					if err != nil {
 						msg := fmt.Sprint("unable to fetch access token for user")
 						log.WithFields(f).Warn(msg)
@@ -278,10 +377,10 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 					session.Save(guocp.HTTPRequest, rw)
 
 					// Get client
-					gitlabClient, err := gitlab_api.NewGitlabOauthClientFromAccessToken(token.AccessToken)
+					gitlabClient, err := gitlab_api.NewGitlabOauthClientFromAccessToken(token.AccessToken, instance)
 					if err != nil {
-						msg := fmt.Sprintf("unable to create gitlab client from token : %s ", token.AccessToken)
-						log.WithFields(f).Warn(msg)
+						msg := "unable to create gitlab client from the oauth token"
+						log.WithFields(f).WithError(err).Warn(msg)
 						http.Error(rw, msg, http.StatusInternalServerError)
 						return
 					}
@@ -292,4 +391,35 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 				})
 		})
 
+	api.AddMiddlewareFor("POST", "/gitlab/status-check", statusCheckSignatureMiddleware(service))
+}
+
+// statusCheckSignatureMiddleware is used to get access to the raw http request, the HMAC signature of the external
+// status check callbacks is computed on the raw payload. The unsigned callbacks are rejected.
+func statusCheckSignatureMiddleware(service Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			f := logrus.Fields{
+				"functionName": "gitlab_activity.handlers.statusCheckSignatureMiddleware",
+			}
+
+			payload, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("reading the status check callback payload failed")
+				http.Error(w, "invalid payload", http.StatusBadRequest)
+				return
+			}
+			defer r.Body.Close()
+
+			ctx := context.WithValue(r.Context(), utils.XREQUESTID, r.Header.Get(utils.XREQUESTID))
+			if err := service.ValidateStatusCheckSignature(ctx, payload, r.Header.Get(gitlab_api.ExternalStatusCheckSignatureHeader)); err != nil {
+				log.WithFields(f).WithError(err).Warn("gitlab status check signature validation failed")
+				http.Error(w, "signature check failure", http.StatusUnauthorized)
+				return
+			}
+
+			r.Body = ioutil.NopCloser(bytes.NewBuffer(payload))
+			next.ServeHTTP(w, r)
+		})
+	}
 }
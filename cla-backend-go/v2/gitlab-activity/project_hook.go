@@ -0,0 +1,142 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_activity
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"path"
+
+	gitlab_api "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
+	"github.com/sirupsen/logrus"
+	"github.com/xanzy/go-gitlab"
+)
+
+// ProjectHookHandler applies the project and subgroup events of the group hooks and system hooks to our database, the
+// same way as the periodic GitLab repository check
+type ProjectHookHandler interface {
+	ProjectEvent(ctx context.Context, gitLabOrg, previousGitLabOrg *common.GitLabOrganization, event *gitlab.ProjectSystemEvent) error
+	SubgroupEvent(ctx context.Context, gitLabOrg *common.GitLabOrganization, event *gitlab.GroupSystemEvent) error
+}
+
+// ProcessProjectHookEvent processes a project or subgroup event of a group hook, signed with the webhook secret of
+// the GitLab organization, or of a system hook of a self-managed GitLab instance, signed with the webhook secret of
+// the instance. The events of the projects outside the registered GitLab organizations are ignored.
+func (s *service) ProcessProjectHookEvent(ctx context.Context, secretToken, instanceID string, event interface{}) error {
+	f := logrus.Fields{
+		"functionName":   "gitlab_activity.project_hook.ProcessProjectHookEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"instanceID":     instanceID,
+	}
+
+	if s.projectHookHandler == nil {
+		log.WithFields(f).Debug("project hook events are not processed - ignoring")
+		return nil
+	}
+
+	var currentPath, previousPath string
+	switch e := event.(type) {
+	case *gitlab.ProjectSystemEvent:
+		currentPath, previousPath = e.PathWithNamespace, e.OldPathWithNamespace
+	case *gitlab.GroupSystemEvent:
+		currentPath, previousPath = e.PathWithNamespace, e.OldPathWithNamespace
+	default:
+		return fmt.Errorf("unexpected project hook event type : %T", event)
+	}
+	f["path"] = currentPath
+	f["previousPath"] = previousPath
+
+	gitLabOrg, err := s.findGitLabOrganization(ctx, instanceID, currentPath)
+	if err != nil {
+		return err
+	}
+	previousGitLabOrg, err := s.findGitLabOrganization(ctx, instanceID, previousPath)
+	if err != nil {
+		return err
+	}
+	if gitLabOrg == nil && previousGitLabOrg == nil {
+		log.WithFields(f).Debug("not under a registered gitlab group/organization - ignoring")
+		return nil
+	}
+
+	if err = s.validateProjectHookToken(ctx, secretToken, instanceID, gitLabOrg, previousGitLabOrg); err != nil {
+		return err
+	}
+
+	switch e := event.(type) {
+	case *gitlab.ProjectSystemEvent:
+		log.WithFields(f).Debugf("processing project event : %s for project : %d", e.EventName, e.ProjectID)
+		return s.projectHookHandler.ProjectEvent(ctx, gitLabOrg, previousGitLabOrg, e)
+	case *gitlab.GroupSystemEvent:
+		log.WithFields(f).Debugf("processing subgroup event : %s for group : %d", e.EventName, e.GroupID)
+		for _, org := range []*common.GitLabOrganization{gitLabOrg, previousGitLabOrg} {
+			if org == nil || (org == previousGitLabOrg && gitLabOrg != nil && gitLabOrg.OrganizationID == org.OrganizationID) {
+				continue
+			}
+			if subgroupErr := s.projectHookHandler.SubgroupEvent(ctx, org, e); subgroupErr != nil {
+				log.WithFields(f).WithError(subgroupErr).Warnf("problem refreshing the subgroups of gitlab group/organization : %s", org.OrganizationFullPath)
+			}
+		}
+	}
+	return nil
+}
+
+// findGitLabOrganization returns the registered GitLab organization of the GitLab instance the project or subgroup
+// path is under, nil if none
+func (s *service) findGitLabOrganization(ctx context.Context, instanceID, projectPath string) (*common.GitLabOrganization, error) {
+	if projectPath == "" {
+		return nil, nil
+	}
+	if gitlab_api.IsDefaultInstanceID(instanceID) {
+		instanceID = ""
+	}
+
+	for groupPath := path.Dir(projectPath); groupPath != "." && groupPath != "/"; groupPath = path.Dir(groupPath) {
+		gitLabOrg, err := s.gitlabOrgService.GetGitLabOrganizationByFullPath(ctx, groupPath)
+		if err != nil {
+			return nil, err
+		}
+		if gitLabOrg == nil || gitLabOrg.InstanceID != instanceID {
+			continue
+		}
+		return s.gitlabOrgService.GetGitLabOrganizationByID(ctx, gitLabOrg.OrganizationID)
+	}
+	return nil, nil
+}
+
+// validateProjectHookToken checks the secret token of a project hook event. The system hooks are signed with the
+// webhook secret of the self-managed GitLab instance, the group hooks with the webhook secret of the GitLab
+// organization the project is, or was, under. The token is enforced for all the organizations, the group hooks are
+// always registered with the webhook secret of the organization.
+func (s *service) validateProjectHookToken(ctx context.Context, secretToken, instanceID string, gitLabOrgs ...*common.GitLabOrganization) error {
+	if !gitlab_api.IsDefaultInstanceID(instanceID) {
+		instance, err := s.gitlabOrgService.GetGitLabInstance(ctx, instanceID)
+		if err != nil {
+			return err
+		}
+		if instance.WebhookSecret != "" && secretToken != "" && subtle.ConstantTimeCompare([]byte(instance.WebhookSecret), []byte(secretToken)) == 1 {
+			return nil
+		}
+	}
+
+	for _, gitLabOrg := range gitLabOrgs {
+		if gitLabOrg == nil {
+			continue
+		}
+		err := s.gitlabOrgService.ValidateGroupHookSecret(ctx, gitLabOrg.OrganizationID, secretToken)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gitlab_organizations.ErrWebhookSecretMismatch) {
+			return err
+		}
+	}
+	return secretTokenMismatch
+}
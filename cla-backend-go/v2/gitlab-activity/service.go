@@ -7,16 +7,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/communitybridge/easycla/cla-backend-go/config"
 
 	"github.com/communitybridge/easycla/cla-backend-go/company"
-	signatures1 "github.com/communitybridge/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
 
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
 	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	gitlab_api "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
@@ -24,9 +21,14 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bot_allowlist"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_evaluation"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_group_settings"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/comment_templates"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
 	gitV2Repositories "github.com/communitybridge/easycla/cla-backend-go/v2/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/repository_requirements"
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
@@ -38,6 +40,7 @@ var (
 	missingID                 = errors.New("user missing in easyCLA records")
 	missingCompanyAffiliation = errors.New("must confirm affiliation with their company")
 	missingCompanyApproval    = errors.New("missing in company approval lists")
+	missingSignature          = errors.New("missing individual or corporate signature")
 	secretTokenMismatch       = errors.New("secret token mismatch")
 )
 
@@ -57,11 +60,19 @@ type gatedGitlabUser struct {
 	err error
 }
 
+// exemptGitlabUser is a merge request participant exempted from the CLA check by the bot allowlist
+type exemptGitlabUser struct {
+	*gitlab.User
+	reason string
+}
+
 type Service interface {
-	ProcessMergeCommentActivity(ctx context.Context, secretToken string, commentEvent *gitlab.MergeEvent) error
-	ProcessMergeOpenedActivity(ctx context.Context, secretToken string, mergeEvent *gitlab.MergeEvent) error
-	ProcessMergeActivity(ctx context.Context, secretToken string, input *ProcessMergeActivityInput) error
-	IsUserApprovedForSignature(ctx context.Context, f logrus.Fields, corporateSignature *models.Signature, user *models.User, gitlabUser *gitlab.User) bool
+	ValidateWebhookToken(ctx context.Context, secretToken string, mergeEvent *gitlab.MergeEvent) error
+	ValidateStatusCheckSignature(ctx context.Context, payload []byte, signature string) error
+	ProcessMergeCommentActivity(ctx context.Context, commentEvent *gitlab.MergeEvent) error
+	ProcessMergeOpenedActivity(ctx context.Context, mergeEvent *gitlab.MergeEvent) error
+	ProcessMergeActivity(ctx context.Context, input *ProcessMergeActivityInput) error
+	ProcessProjectHookEvent(ctx context.Context, secretToken, instanceID string, event interface{}) error
 }
 
 type service struct {
@@ -73,11 +84,17 @@ type service struct {
 	projectsCLAGroupsRepository projects_cla_groups.Repository
 	companyRepository           company.IRepository
 	signatureRepository         signatures.SignatureRepository
+	botAllowlistService         bot_allowlist.ServiceInterface
+	requirementsService         repository_requirements.ServiceInterface
+	claGroupSettingsService     cla_group_settings.ServiceInterface
 	gitLabApp                   *gitlab_api.App
+	evaluator                   *cla_evaluation.Evaluator
+	projectHookHandler          ProjectHookHandler
 }
 
 func NewService(gitRepository repositories.RepositoryInterface, gitV2Repository gitV2Repositories.RepositoryInterface, usersRepository users.UserRepository, signaturesRepository signatures.SignatureRepository, projectsCLAGroupsRepository projects_cla_groups.Repository,
-	companyRepository company.IRepository, signatureRepository signatures.SignatureRepository, gitlabOrgService gitlab_organizations.ServiceInterface) Service {
+	companyRepository company.IRepository, signatureRepository signatures.SignatureRepository, gitlabOrgService gitlab_organizations.ServiceInterface, botAllowlistService bot_allowlist.ServiceInterface, requirementsService repository_requirements.ServiceInterface,
+	claGroupSettingsService cla_group_settings.ServiceInterface, projectHookHandler ProjectHookHandler) Service {
 	return &service{
 		gitRepository:               gitRepository,
 		gitV2Repository:             gitV2Repository,
@@ -88,10 +105,62 @@ func NewService(gitRepository repositories.RepositoryInterface, gitV2Repository
 		signatureRepository:         signatureRepository,
 		gitLabApp:                   gitlab_api.Init(config.GetConfig().Gitlab.AppClientID, config.GetConfig().Gitlab.AppClientSecret, config.GetConfig().Gitlab.AppPrivateKey),
 		gitlabOrgService:            gitlabOrgService,
+		botAllowlistService:         botAllowlistService,
+		requirementsService:         requirementsService,
+		claGroupSettingsService:     claGroupSettingsService,
+		evaluator:                   cla_evaluation.NewEvaluator(usersRepository, signaturesRepository, nil, gitlab_organizations.NewGroupMembership(gitlabOrgService)),
+		projectHookHandler:          projectHookHandler,
+	}
+}
+
+// ValidateWebhookToken checks the secret token of the webhook event against the webhook secrets of the GitLab
+// organization of the project
+func (s *service) ValidateWebhookToken(ctx context.Context, secretToken string, mergeEvent *gitlab.MergeEvent) error {
+	gitlabOrg, err := s.getGitlabOrganizationFromProjectPath(ctx, mergeEvent.Project.PathWithNamespace, mergeEvent.Project.Namespace)
+	if err != nil {
+		return fmt.Errorf("fetching internal gitlab org for following path : %s failed : %v", mergeEvent.Project.PathWithNamespace, err)
+	}
+
+	if err := s.gitlabOrgService.ValidateWebhookSecret(ctx, gitlabOrg.OrganizationID, secretToken); err != nil {
+		if errors.Is(err, gitlab_organizations.ErrWebhookSecretMismatch) {
+			return secretTokenMismatch
+		}
+		return err
+	}
+	return nil
+}
+
+// ValidateStatusCheckSignature checks the HMAC signature of the external status check callback against the webhook
+// secrets of the GitLab organization of the project, the unsigned callbacks are rejected
+func (s *service) ValidateStatusCheckSignature(ctx context.Context, payload []byte, signature string) error {
+	if signature == "" {
+		return secretTokenMismatch
+	}
+
+	event, err := gitlab.ParseWebhook(gitlab.EventTypeMergeRequest, payload)
+	if err != nil {
+		return fmt.Errorf("parsing gitlab merge event failed : %v", err)
+	}
+	mergeEvent, ok := event.(*gitlab.MergeEvent)
+	if !ok || mergeEvent.Project.PathWithNamespace == "" {
+		return fmt.Errorf("status check callback is not a merge request event : %T", event)
+	}
+
+	gitlabOrg, err := s.getGitlabOrganizationFromProjectPath(ctx, mergeEvent.Project.PathWithNamespace, mergeEvent.Project.Namespace)
+	if err != nil {
+		return fmt.Errorf("fetching internal gitlab org for following path : %s failed : %v", mergeEvent.Project.PathWithNamespace, err)
 	}
+
+	if err := s.gitlabOrgService.ValidateStatusCheckSignature(ctx, gitlabOrg.OrganizationID, payload, signature); err != nil {
+		if errors.Is(err, gitlab_organizations.ErrWebhookSecretMismatch) {
+			return secretTokenMismatch
+		}
+		return err
+	}
+	return nil
 }
 
-func (s *service) ProcessMergeOpenedActivity(ctx context.Context, secretToken string, mergeEvent *gitlab.MergeEvent) error {
+func (s *service) ProcessMergeOpenedActivity(ctx context.Context, mergeEvent *gitlab.MergeEvent) error {
 	projectName := mergeEvent.Project.Name
 	projectPath := mergeEvent.Project.PathWithNamespace
 	projectNamespace := mergeEvent.Project.Namespace
@@ -110,11 +179,11 @@ func (s *service) ProcessMergeOpenedActivity(ctx context.Context, secretToken st
 		LastCommitSha:    lastCommitSha,
 	}
 
-	return s.ProcessMergeActivity(ctx, secretToken, input)
+	return s.ProcessMergeActivity(ctx, input)
 
 }
 
-func (s *service) ProcessMergeCommentActivity(ctx context.Context, secretToken string, commentEvent *gitlab.MergeEvent) error {
+func (s *service) ProcessMergeCommentActivity(ctx context.Context, commentEvent *gitlab.MergeEvent) error {
 	f := logrus.Fields{
 		"functionName":      "ProcessMergeCommentActivity",
 		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
@@ -155,10 +224,10 @@ func (s *service) ProcessMergeCommentActivity(ctx context.Context, secretToken s
 		LastCommitSha:    commentEvent.ObjectAttributes.LastCommit.ID,
 	}
 
-	return s.ProcessMergeActivity(ctx, secretToken, input)
+	return s.ProcessMergeActivity(ctx, input)
 }
 
-func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string, input *ProcessMergeActivityInput) error {
+func (s *service) ProcessMergeActivity(ctx context.Context, input *ProcessMergeActivityInput) error {
 	projectName := input.ProjectName
 	projectPath := input.ProjectPath
 	projectNamespace := input.ProjectNamespace
@@ -184,21 +253,11 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 		return fmt.Errorf("fetching internal gitlab org for following path : %s failed : %v", repositoryPath, err)
 	}
 
-	// log.WithFields(f).Debugf("checking gitlab org : %s auth state agains the webhook secret token", gitlabOrg.OrganizationName)
-	// if gitlabOrg.AuthState != secretToken {
-	// 	return secretTokenMismatch
-	// }
-
 	log.WithFields(f).Debugf("internal gitlab org : %s:%s is associated with external path : %s", gitlabOrg.OrganizationID, gitlabOrg.OrganizationName, repositoryPath)
 
 	// fetch updated token info
 	log.WithFields(f).Debugf("refreshing gitlab org : %s:%s auth info", gitlabOrg.OrganizationID, gitlabOrg.OrganizationName)
-	oauthResponse, err := s.gitlabOrgService.RefreshGitLabOrganizationAuth(ctx, common.ToCommonModel(gitlabOrg))
-	if err != nil {
-		return fmt.Errorf("refreshing gitlab org auth info failed : %v", err)
-	}
-
-	gitlabClient, err := gitlab_api.NewGitlabOauthClient(*oauthResponse, s.gitLabApp)
+	gitlabClient, err := s.gitlabOrgService.GetGitLabOrganizationClient(ctx, common.ToCommonModel(gitlabOrg))
 	if err != nil {
 		return fmt.Errorf("initializing gitlab client : %v", err)
 	}
@@ -215,7 +274,7 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 	f["lastCommitSha"] = lastCommitSha
 	log.WithFields(f).Debugf("last commit sha for merge request: %d is %s", mergeID, lastCommitSha)
 
-	_, err = gitlab_api.FetchMrInfo(gitlabClient, projectID, mergeID)
+	mrInfo, err := gitlab_api.FetchMrInfo(gitlabClient, projectID, mergeID)
 	if err != nil {
 		return fmt.Errorf("fetching info for mr : %d and project : %d: %s, failed : %v", mergeID, projectID, projectName, err)
 	}
@@ -226,6 +285,19 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 		return fmt.Errorf("finding internal repository for gitlab org name failed : %v", err)
 	}
 
+	// the repository requirements are evaluated before the participants are loaded
+	if decision := s.evaluateRepositoryRequirements(ctx, gitlabClient, gitlabRepo.RepositoryID, mrInfo); decision != nil && !decision.Required {
+		log.WithFields(f).Debugf("CLA check not required for merge request: %d by the %s rule - %s", mergeID, decision.Rule, decision.Reason)
+		s.requirementsService.LogCheckSkipped(ctx, &repository_requirements.SkippedCheck{
+			CLAGroupID:      gitlabRepo.RepositoryClaGroupID,
+			Provider:        utils.GitLabLower,
+			RepositoryName:  repositoryPath,
+			ChangeRequestID: mergeID,
+			Decision:        decision,
+		})
+		return setMergeRequestStatus(f, gitlabClient, projectID, mergeID, lastCommitSha, true, fmt.Sprintf("EasyCLA check not required: %s", decision.Reason), "")
+	}
+
 	log.WithFields(f).Debugf("loading GitLab merge request participatants for merge request: %d", mergeID)
 	participants, err := gitlab_api.FetchMrParticipants(gitlabClient, projectID, mergeID)
 	if err != nil {
@@ -248,37 +320,57 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 	missingCLAMsg := "Missing CLA Authorization"
 	signedCLAMsg := "EasyCLA check passed. You are authorized to contribute."
 
+	// bots and service accounts on the allowlist of the repository, CLA Group or foundation are exempt
+	var allowlist *bot_allowlist.Matcher
+	if s.botAllowlistService != nil {
+		var allowlistErr error
+		allowlist, allowlistErr = s.botAllowlistService.GetMatcher(ctx, claGroupID, gitlabRepo.RepositoryID)
+		if allowlistErr != nil {
+			log.WithFields(f).WithError(allowlistErr).Warn("unable to load the bot allowlist - no participants are exempt")
+		}
+	}
+
+	identities := make([]cla_evaluation.Identity, len(participants))
+	for i, participant := range participants {
+		identities[i] = gitLabIdentity(participant)
+	}
+	verdicts := s.evaluator.Evaluate(ctx, claGroupID, identities, allowlist)
+
 	var missingUsers []*gatedGitlabUser
 	var signedUsers []*gitlab.User
-	for _, gitlabUser := range participants {
-		log.WithFields(f).Debugf("checking if GitLab user: %s (%d) with email: %s has signed", gitlabUser.Username, gitlabUser.ID, gitlabUser.Email)
-		userSigned, signedCheckErr := s.hasUserSigned(ctx, claGroupID, gitlabUser)
-		if signedCheckErr != nil {
-			log.WithFields(f).WithError(signedCheckErr).Warnf("problem checking if user : %s (%d) has signed - assuming not signed", gitlabUser.Username, gitlabUser.ID)
-			missingUsers = append(missingUsers, &gatedGitlabUser{
-				User: gitlabUser,
-				err:  err,
+	var exemptUsers []*exemptGitlabUser
+	for i, verdict := range verdicts {
+		gitlabUser := participants[i].User
+		switch {
+		case verdict.Exempt():
+			log.WithFields(f).Debugf("GitLab user: %s (%d) is exempt by the %s allowlist entry: %s", gitlabUser.Username, gitlabUser.ID, verdict.ExemptEntry.ScopeType, verdict.ExemptEntry.EntryID)
+			exemptUsers = append(exemptUsers, &exemptGitlabUser{User: gitlabUser, reason: verdict.ExemptReason()})
+			s.botAllowlistService.LogExemption(ctx, &bot_allowlist.Exemption{
+				CLAGroupID:      claGroupID,
+				Provider:        utils.GitLabLower,
+				RepositoryName:  repositoryPath,
+				ChangeRequestID: mergeID,
+				Username:        gitlabUser.Username,
+				Email:           gitlabUser.Email,
+				Entry:           verdict.ExemptEntry,
 			})
-			continue
-		}
-
-		if userSigned {
-			log.WithFields(f).Infof("gitlabUser: %s (%d) has signed", gitlabUser.Username, gitlabUser.ID)
+		case verdict.Authorized:
+			log.WithFields(f).Infof("gitlabUser: %s (%d) has signed - %s", gitlabUser.Username, gitlabUser.ID, verdict.Reason)
 			signedUsers = append(signedUsers, gitlabUser)
-		} else {
-			log.WithFields(f).Infof("gitlabUser: %s (%d) has NOT signed", gitlabUser.Username, gitlabUser.ID)
+		default:
+			log.WithFields(f).Infof("gitlabUser: %s (%d) has NOT signed - %s", gitlabUser.Username, gitlabUser.ID, verdict.Reason)
 			missingUsers = append(missingUsers, &gatedGitlabUser{
 				User: gitlabUser,
-				err:  err,
+				err:  verdictError(verdict),
 			})
 		}
 	}
 
 	signURL := GetFullSignURL(gitlabOrg.OrganizationID, strconv.Itoa(int(gitlabRepo.RepositoryExternalID)), strconv.Itoa(mergeID))
-	mrCommentContent := PrepareMrCommentContent(missingUsers, signedUsers, signURL)
+	mrCommentContent := s.getMrCommentContent(ctx, claGroupID, claGroup.ClaGroupName, missingUsers, signedUsers, exemptUsers, signURL)
 	if len(missingUsers) > 0 {
 		log.WithFields(f).Errorf("merge request faild with 1 or more users not passing authorization - failed users : %+v", missingUsers)
-		if statusErr := gitlab_api.SetCommitStatus(gitlabClient, projectID, lastCommitSha, gitlab.Failed, missingCLAMsg, signURL); statusErr != nil {
+		if statusErr := setMergeRequestStatus(f, gitlabClient, projectID, mergeID, lastCommitSha, false, missingCLAMsg, signURL); statusErr != nil {
 			log.WithFields(f).WithError(statusErr).Warnf("problem setting the commit status for merge request ID: %d, sha: %s", mergeID, lastCommitSha)
 			return fmt.Errorf("setting commit status failed : %v", statusErr)
 		}
@@ -291,7 +383,7 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 		return nil
 	}
 
-	commitStatusErr := gitlab_api.SetCommitStatus(gitlabClient, projectID, lastCommitSha, gitlab.Success, signedCLAMsg, "")
+	commitStatusErr := setMergeRequestStatus(f, gitlabClient, projectID, mergeID, lastCommitSha, true, signedCLAMsg, "")
 	if commitStatusErr != nil {
 		log.WithFields(f).WithError(commitStatusErr).Warnf("problem setting the commit status for merge request ID: %d, sha: %s", mergeID, lastCommitSha)
 		return fmt.Errorf("setting commit status failed : %v", commitStatusErr)
@@ -305,7 +397,83 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 	return nil
 }
 
-func PrepareMrCommentContent(missingUsers []*gatedGitlabUser, signedUsers []*gitlab.User, signURL string) string {
+// getMrCommentContent renders the merge request comment with the comment template of the CLA Group, falling back to
+// the default comment
+func (s *service) getMrCommentContent(ctx context.Context, claGroupID, claGroupName string, missingUsers []*gatedGitlabUser, signedUsers []*gitlab.User, exemptUsers []*exemptGitlabUser, signURL string) string {
+	f := logrus.Fields{
+		"functionName":   "getMrCommentContent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	if s.claGroupSettingsService != nil {
+		tmpl, err := s.claGroupSettingsService.GetCommentTemplate(ctx, claGroupID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the comment template - using the default comment")
+		} else if tmpl != nil {
+			comment, renderErr := RenderMrCommentTemplate(tmpl, claGroupName, missingUsers, signedUsers, exemptUsers, signURL)
+			if renderErr == nil {
+				return comment
+			}
+			log.WithFields(f).WithError(renderErr).Warn("unable to render the comment template - using the default comment")
+		}
+	}
+
+	return PrepareMrCommentContent(missingUsers, signedUsers, exemptUsers, signURL)
+}
+
+// RenderMrCommentTemplate renders the merge request comment with the comment template of the CLA Group
+func RenderMrCommentTemplate(tmpl *comment_templates.Template, claGroupName string, missingUsers []*gatedGitlabUser, signedUsers []*gitlab.User, exemptUsers []*exemptGitlabUser, signURL string) (string, error) {
+	landingPage := config.GetConfig().CLALandingPage + "/#/?version=2"
+	data := &comment_templates.Data{
+		Provider:    utils.GitLabLower,
+		ProjectName: claGroupName,
+		SignURL:     signURL,
+		HelpURL:     "https://docs.gitlab.com/ee/user/profile/#add-emails-to-your-user-profile",
+		SupportURL:  "https://jira.linuxfoundation.org/servicedesk/customer/portal/4",
+		LandingPage: landingPage,
+	}
+
+	badgeLink := landingPage
+	data.BadgeURL = fmt.Sprintf("%s/cla-signed.svg", config.GetConfig().CLALogoURL)
+	data.BadgeAlt = "CLA Signed"
+	if len(missingUsers) > 0 {
+		badgeLink = signURL
+		data.BadgeURL = fmt.Sprintf("%s/cla-not-signed.svg", config.GetConfig().CLALogoURL)
+		data.BadgeAlt = "CLA Not Signed"
+	}
+
+	for _, signed := range signedUsers {
+		data.Signed = append(data.Signed, templateAuthor(signed, ""))
+	}
+	for _, exempt := range exemptUsers {
+		data.Exempt = append(data.Exempt, templateAuthor(exempt.User, exempt.reason))
+	}
+	for _, missing := range missingUsers {
+		reason := comment_templates.ReasonNotSigned
+		if errors.Is(missing.err, missingCompanyAffiliation) {
+			reason = comment_templates.ReasonConfirmAffiliation
+			data.BadgeURL = fmt.Sprintf("%s/cla-confirmation-needed.svg", config.GetConfig().CLALogoURL)
+			data.BadgeAlt = "CLA Confirmation Needed"
+		}
+		data.Missing = append(data.Missing, templateAuthor(missing.User, reason))
+	}
+	data.Badge = comment_templates.BadgeHTML(badgeLink, data.BadgeURL, data.BadgeAlt)
+
+	return comment_templates.Render(tmpl, data)
+}
+
+func templateAuthor(gitlabUser *gitlab.User, reason string) *comment_templates.Author {
+	return &comment_templates.Author{
+		Name:   gitlabUser.Name,
+		Login:  gitlabUser.Username,
+		Email:  gitlabUser.Email,
+		Reason: reason,
+	}
+}
+
+// PrepareMrCommentContent builds the merge request comment listing the signed, exempt and missing participants
+func PrepareMrCommentContent(missingUsers []*gatedGitlabUser, signedUsers []*gitlab.User, exemptUsers []*exemptGitlabUser, signURL string) string {
 	landingPage := config.GetConfig().CLALandingPage
 	landingPage += "/#/?version=2"
 
@@ -331,12 +499,16 @@ func PrepareMrCommentContent(missingUsers []*gatedGitlabUser, signedUsers []*git
 	failed := ":x:"
 	success := ":white_check_mark:"
 
-	if len(signedUsers) > 0 {
+	if len(signedUsers) > 0 || len(exemptUsers) > 0 {
 		result = "<ul>"
 		for _, signed := range signedUsers {
 			authorInfo := getAuthorInfo(signed)
 			result += fmt.Sprintf("<li>%s %s</li>", success, authorInfo)
 		}
+		for _, exempt := range exemptUsers {
+			authorInfo := getAuthorInfo(exempt.User)
+			result += fmt.Sprintf("<li>%s %s - exempt: %s</li>", success, authorInfo, exempt.reason)
+		}
 		result += "</ul>"
 		body = coveredBadge
 	}
@@ -432,328 +604,83 @@ func (s *service) getGitlabOrganizationFromProjectPath(ctx context.Context, proj
 	return gitlabOrg, nil
 }
 
-func (s *service) getGitlabRepoByName(ctx context.Context, repoNameWithPath string) (*models.GithubRepository, error) {
-	gitlabRepo, err := s.gitV2Repository.GitLabGetRepositoryByName(ctx, repoNameWithPath)
-	if err != nil || gitlabRepo == nil {
-		return nil, fmt.Errorf("unable to locate GitLab repo for repoNameWithPath : %s, failed : %v", repoNameWithPath, err)
-	}
-
-	return gitlabRepo.ToGitHubModel(), nil
-}
-
-type UserSigned struct {
-	signed bool
-	err    error
-}
-
-func (s *service) hasUserSigned(ctx context.Context, claGroupID string, gitlabUser *gitlab.User) (bool, error) {
+// evaluateRepositoryRequirements evaluates the repository requirements against the merge request - a nil decision
+// requires the CLA check, the merge request changes are only loaded when the requirements need them
+func (s *service) evaluateRepositoryRequirements(ctx context.Context, gitlabClient *gitlab.Client, repositoryID string, mrInfo *gitlab.MergeRequest) *repository_requirements.Decision {
 	f := logrus.Fields{
-		"functionName":    "v2.gitlab-activity.service.hasUserSigned",
-		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
-		"gitlabUserID":    gitlabUser.ID,
-		"gitlabUserName":  gitlabUser.Username,
-		"gitlabUserEmail": gitlabUser.Email,
-	}
-
-	userModels, lookUpErr := s.findUserModelForGitlabUser(f, gitlabUser)
-	if lookUpErr != nil {
-		log.WithFields(f).WithError(lookUpErr).Warnf("unable to find user model for gitlab user: %v", gitlabUser)
-		return false, lookUpErr
+		"functionName":   "v2.gitlab_activity.service.evaluateRepositoryRequirements",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   repositoryID,
+		"mergeID":        mrInfo.IID,
 	}
 
-	if len(userModels) == 0 {
-		log.WithFields(f).Warnf("gitlab user: %s (%d) not found in easycla records", gitlabUser.Username, gitlabUser.ID)
-		return false, missingID
-	}
-
-	for _, userModel := range userModels {
-		signed, err := s.isSigned(ctx, userModel, claGroupID, gitlabUser)
-		if err != nil {
-			log.WithFields(f).Debugf("error checking if user is signed, error: %v", err)
-			continue
-		}
-		if signed {
-			log.WithFields(f).Debugf("found signed user for clagroupID: %s, userID: %s", claGroupID, userModel.UserID)
-			return true, nil
-		} else {
-			log.WithFields(f).Debugf("user is not signed for claGroupID: %s, userID: %s", claGroupID, userModel.UserID)
-		}
-	}
-
-	return false, nil
-}
-
-func (s *service) isSigned(ctx context.Context, userModel *models.User, claGroupID string, gitlabUser *gitlab.User) (bool, error) {
-	f := logrus.Fields{
-		"functionName":    "v2.gitlab-activity.service.isSigned",
-		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
-		"gitlabUserID":    gitlabUser.ID,
-		"gitlabUserName":  gitlabUser.Username,
-		"gitlabUserEmail": gitlabUser.Email,
-	}
-
-	// First check for an ICLA signature
-	icla, err := s.signaturesRepository.GetIndividualSignature(ctx, claGroupID, userModel.UserID, aws.Bool(true), aws.Bool(true))
-	if err != nil {
-		log.WithFields(f).Warnf("fetching ICLA for gitlab user : %d:%s failed : %v", gitlabUser.ID, gitlabUser.Username, err)
-		return false, err
-	}
-
-	if icla != nil {
-		log.WithFields(f).Infof("user has signed the following signature (ICLA): %s, passing", icla.SignatureID)
-		return true, nil
-	}
-
-	if userModel.CompanyID == "" {
-		log.WithFields(f).Debugf("user does not have association with any company, can't confirm employee acknoledgement")
-		return false, fmt.Errorf("user hasn't signed yet")
-	}
-
-	companyID := userModel.CompanyID
-	_, err = s.companyRepository.GetCompany(ctx, companyID)
-	if err != nil {
-		msg := fmt.Sprintf("can't load company record: %s for user: %s (%s), error: %v", companyID, userModel.Username, userModel.UserID, err)
-		log.WithFields(f).Errorf(msg)
-		return false, fmt.Errorf(msg)
-	}
-
-	corporateSignature, err := s.signatureRepository.GetCorporateSignature(ctx, claGroupID, companyID, aws.Bool(true), aws.Bool(true))
-	if err != nil {
-		msg := fmt.Sprintf("can't load company signature record for company: %s for user : %s (%s), error : %v", companyID, userModel.Username, userModel.UserID, err)
-		log.WithFields(f).Errorf(msg)
-		return false, fmt.Errorf(msg)
-	}
-
-	if corporateSignature == nil {
-		msg := fmt.Sprintf("no corporate signature (CCLA) record found for company : %s ", companyID)
-		log.WithFields(f).Errorf(msg)
-		return false, fmt.Errorf(msg)
-	}
-
-	log.WithFields(f).Debugf("loaded corporate signature id: %s for claGroupID: %s and companyID: %s", corporateSignature.SignatureID, claGroupID, companyID)
-
-	approvalCriteria := &signatures.ApprovalCriteria{}
-	if gitlabUser.Email != "" {
-		approvalCriteria.UserEmail = gitlabUser.Email
-	} else if gitlabUser.Username != "" {
-		approvalCriteria.GitlabUsername = gitlabUser.Username
-	} else {
-		msg := fmt.Sprintf("gitlabUser model doesn't have enough information to fetch the employee signatures for user : %s", userModel.UserID)
-		log.WithFields(f).Errorf(msg)
-		return false, fmt.Errorf(msg)
-	}
-
-	if !s.IsUserApprovedForSignature(ctx, f, corporateSignature, userModel, gitlabUser) {
-		log.WithFields(f).Debugf("user is not approved in signature : %s", corporateSignature.SignatureID)
-		return false, fmt.Errorf("user is not approved in signature : %s", corporateSignature.SignatureID)
+	if s.requirementsService == nil {
+		return nil
 	}
 
-	employeeSignatures, err := s.signaturesRepository.GetProjectCompanyEmployeeSignatures(ctx, signatures1.GetProjectCompanyEmployeeSignaturesParams{
-		CompanyID: companyID,
-		ProjectID: claGroupID,
-		PageSize:  utils.Int64(100),
-	}, approvalCriteria)
-
+	rules, err := s.requirementsService.GetRules(ctx, repositoryID)
 	if err != nil {
-		msg := fmt.Sprintf("can't load employee signature records : %s for user : %s association : %v", companyID, userModel.UserID, err)
-		log.WithFields(f).Errorf(msg)
-		return false, fmt.Errorf(msg)
-	}
-
-	if len(employeeSignatures.Signatures) == 0 {
-		msg := fmt.Sprintf("no employee signature records found for company : %s user : %s association", companyID, userModel.UserID)
-		log.WithFields(f).Errorf(msg)
-		return false, fmt.Errorf(msg)
+		log.WithFields(f).WithError(err).Warn("unable to load the repository requirements - enforcing the CLA check")
+		return nil
 	}
-
-	log.WithFields(f).Warnf("is in signature approval list : %s and has employee signature", corporateSignature.SignatureID)
-	return true, nil
-}
-
-// findUserModelForGitlabUser locates the user model in our users table for the given GitLab user (by GitLab ID, GitLab username, or email)
-func (s *service) findUserModelForGitlabUser(f logrus.Fields, gitlabUser *gitlab.User) ([]*models.User, error) {
-
-	if gitlabUser.ID != 0 {
-		log.WithFields(f).Debugf("Looking up GitLab user via ID: %d", gitlabUser.ID)
-		userModel, lookupErr := s.usersRepository.GetUserByGitlabID(gitlabUser.ID)
-		if lookupErr != nil {
-			log.WithFields(f).WithError(lookupErr).Warnf("problem locating GitLab user via GitLab ID : %d", gitlabUser.ID)
-		} else if userModel != nil {
-			log.WithFields(f).Debugf("located GitLab user via ID: %d", gitlabUser.ID)
-			return []*models.User{userModel}, nil
-		}
+	if rules == nil {
+		return nil
 	}
 
-	if gitlabUser.Username != "" {
-		log.WithFields(f).Debugf("Looking up GitLab user via username: %s", gitlabUser.Username)
-		userModel, lookupErr := s.usersRepository.GetUserByGitLabUsername(gitlabUser.Username)
-		if lookupErr != nil {
-			log.WithFields(f).WithError(lookupErr).Warnf("problem locating GitLab user via GitLab username : %s", gitlabUser.Username)
-		} else if userModel != nil {
-			log.WithFields(f).Debugf("located GitLab user via username: %s", gitlabUser.Username)
-			return []*models.User{userModel}, nil
-		}
+	change := &repository_requirements.ChangeRequest{
+		TargetBranch: mrInfo.TargetBranch,
 	}
-
-	if gitlabUser.Email != "" {
-		gitlabUsers := make([]*models.User, 0)
-		log.WithFields(f).Debugf("Looking up GitLab user via user email: %s", gitlabUser.Email)
-		// previously search was done by lf_email, now we are searching by email #3816
-		users, lookupErr := s.usersRepository.GetUsersByEmail(gitlabUser.Email)
-		if lookupErr != nil {
-			log.WithFields(f).WithError(lookupErr).Warnf("problem locating GitLab user via GitLab username : %s", gitlabUser.Username)
-		} else if len(users) > 0 {
-			log.WithFields(f).Debugf("located GitLab user via email: %s", gitlabUser.Email)
-			gitlabUsers = append(gitlabUsers, users...)
-			return gitlabUsers, nil
+	if rules.RequiresChanges() {
+		mrChanges, changesErr := gitlab_api.FetchMrChanges(gitlabClient, mrInfo.ProjectID, mrInfo.IID)
+		if changesErr != nil {
+			log.WithFields(f).WithError(changesErr).Warn("unable to load the merge request changes - evaluating the target branch only")
+		} else {
+			change.Files = mrChanges.Files
+			change.FilesComplete = mrChanges.FilesComplete
+			change.ChangedLines = mrChanges.ChangedLines
+			change.ChangedLinesKnown = mrChanges.ChangedLinesKnown
 		}
 	}
 
-	// Didn't find it
-	return nil, nil
+	return rules.Evaluate(change)
 }
 
-func (s *service) IsUserApprovedForSignature(ctx context.Context, f logrus.Fields, corporateSignature *models.Signature, user *models.User, gitlabUser *gitlab.User) bool {
-	log.WithFields(f).Debugf("checking if user : %s is approved for corporate signature : %s", user.UserID, corporateSignature.SignatureID)
-	userEmails := user.Emails
-	if string(user.LfEmail) != "" {
-		userEmails = append(userEmails, string(user.LfEmail))
-	}
-
-	emailApprovalList := corporateSignature.EmailApprovalList
-	domainApprovalList := corporateSignature.DomainApprovalList
-	log.WithFields(f).Debugf("checking if user : %s is approved for corporate signature : %s, email approval list : %+v", user.UserID, corporateSignature.SignatureID, emailApprovalList)
-
-	if len(userEmails) > 0 && len(emailApprovalList) > 0 {
-		for _, email := range userEmails {
-			for _, approvalEmail := range emailApprovalList {
-				if email == approvalEmail {
-					log.WithFields(f).Debugf("found user email : %s in email approval list ", email)
-					return true
-				}
-			}
-		}
-	} else {
-		log.WithFields(f).Warnf("no match for user in signature email approval list")
-	}
-
-	if len(domainApprovalList) > 0 && len(userEmails) > 0 {
-		log.WithFields(f).Debugf("checking if emails : %+v are approved for corporate signature : %s, domain approval list : %+v", userEmails, corporateSignature.SignatureID, domainApprovalList)
-		for _, userEmail := range userEmails {
-			for _, domainApprovalPattern := range domainApprovalList {
-				if strings.HasPrefix(domainApprovalPattern, "*.") {
-					domainApprovalPattern = strings.Replace(domainApprovalPattern, "*.", ".*", 1)
-				} else if strings.HasPrefix(domainApprovalPattern, "*") {
-					domainApprovalPattern = strings.Replace(domainApprovalPattern, "*", ".*", 1)
-				} else if strings.HasPrefix(domainApprovalPattern, ".") {
-					domainApprovalPattern = strings.Replace(domainApprovalPattern, ".", ".*", 1)
-				}
-				regexpApprovalPattern := "^.*@" + domainApprovalPattern + "$"
-				if ok, err := regexp.MatchString(regexpApprovalPattern, userEmail); ok && err == nil {
-					log.WithFields(f).Debugf("found user email : %s in email approval list : %s", userEmail, domainApprovalPattern)
-					return true
-				}
-			}
-		}
-	}
-
-	gitlabUserName := gitlabUser.Username
-	gitlabUsernameApprovalList := corporateSignature.GitlabUsernameApprovalList
-	if gitlabUserName != "" && len(gitlabUsernameApprovalList) > 0 {
-		log.WithFields(f).Debugf("checking gitlab username : %s for gitlab approval list : %+v", gitlabUserName, gitlabUsernameApprovalList)
-		for _, gitlabApproval := range gitlabUsernameApprovalList {
-			if gitlabApproval == gitlabUserName {
-				log.WithFields(f).Debugf("found gitlab username : %s in gitlab approval list ", gitlabUserName)
-				return true
-			}
-		}
-
-	} else {
-		log.WithFields(f).Warnf("no match found for gitlabUser : %s in gitlab approval list : %+v", gitlabUserName, gitlabUsernameApprovalList)
-	}
-
-	gitlabGroupApprovalList := corporateSignature.GitlabOrgApprovalList
-	if gitlabUserName != "" && len(gitlabGroupApprovalList) > 0 {
-		log.WithFields(f).Debugf("checking gitlab username : %s for gitlab org approval list : %+v ", gitlabUserName, gitlabGroupApprovalList)
-
-		for _, gitlabGroupApproval := range gitlabGroupApprovalList {
-			isApproved, err := s.checkGitLabGroupApproval(ctx, gitlabUserName, gitlabGroupApproval)
-			if err != nil {
-				log.WithFields(f).WithError(err).Warn("unable to get username")
-				break
-			}
-			if isApproved == true {
-				log.WithFields(f).Debugf(" found gitlab username : %s in gitlab org approval list : %+v", gitlabUserName, gitlabGroupApprovalList)
-				return true
-			}
-		}
+func (s *service) getGitlabRepoByName(ctx context.Context, repoNameWithPath string) (*models.GithubRepository, error) {
+	gitlabRepo, err := s.gitV2Repository.GitLabGetRepositoryByName(ctx, repoNameWithPath)
+	if err != nil || gitlabRepo == nil {
+		return nil, fmt.Errorf("unable to locate GitLab repo for repoNameWithPath : %s, failed : %v", repoNameWithPath, err)
 	}
 
-	log.WithFields(f).Errorf("unable to find user in any approval list")
-	return false
-
+	return gitlabRepo.ToGitHubModel(), nil
 }
 
-/**
- * Parses url with the given regular expression and returns the
- * group values defined in the expression.
- *
- */
-func getParams(regEx, url string) (paramsMap map[string]string) {
-
-	var compRegEx = regexp.MustCompile(regEx)
-	match := compRegEx.FindStringSubmatch(url)
-
-	paramsMap = make(map[string]string)
-	for i, name := range compRegEx.SubexpNames() {
-		if i > 0 && i <= len(match) {
-			paramsMap[name] = match[i]
-		}
+// gitLabIdentity returns the identity of the merge request participant for the CLA evaluation - the participants are
+// found by the email of their commits, which isn't verified
+func gitLabIdentity(participant *gitlab_api.MrParticipant) cla_evaluation.Identity {
+	identity := cla_evaluation.Identity{
+		Provider: utils.GitLabLower,
+		Username: participant.Username,
+		Email:    participant.Email,
+		Name:     participant.Name,
+		Bot:      participant.Bot,
 	}
-	return paramsMap
-}
-
-func (s *service) checkGitLabGroupApproval(ctx context.Context, userName, URL string) (bool, error) {
-	f := logrus.Fields{
-		"functionName": "checkGitLabGroupApproval",
-		"userName":     userName,
-		"group_url":    URL,
-	}
-
-	log.WithFields(f).Debugf("checking approval list gitlab org criteria : %s for user: %s ", URL, userName)
-	var searchURL = URL
-	params := getParams(`(?P<base>\bhttps://gitlab.com/\b)(?P<group>\bgroups\/\b)?(?P<name>\w+)`, URL)
-	if params[`group`] == "" {
-		params[`group`] = "groups/"
-		updated := fmt.Sprintf("%s%s%s", params[`base`], params[`group`], params[`name`])
-		log.WithFields(f).Debugf("updating url : %s to %s for easycla search purporses ", searchURL, updated)
-		searchURL = updated
-	}
-	gitlabOrg, _ := s.gitlabOrgService.GetGitLabOrganizationByURL(ctx, searchURL)
-	if gitlabOrg != nil {
-		oauthResponse, err := s.gitlabOrgService.RefreshGitLabOrganizationAuth(ctx, common.ToCommonModel(gitlabOrg))
-		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("problem refreshing gitlab auth for org: %s ", gitlabOrg.OrganizationName)
-			return false, err
-		}
-
-		gitlabClient, clientErr := gitlab_api.NewGitlabOauthClient(*oauthResponse, s.gitLabApp)
-		if clientErr != nil {
-			log.WithFields(f).WithError(clientErr).Warnf("problem getting gitLabClient for org: %s ", gitlabOrg.OrganizationName)
-			return false, clientErr
-		}
-		members, err := gitlab_api.ListGroupMembers(ctx, gitlabClient, int(gitlabOrg.OrganizationExternalID))
-		if err != nil {
-			log.WithFields(f).WithError(err).Warn("problem getting gitlab group members")
-			return false, err
-		}
-		for _, member := range members {
-			if userName == member.Username {
-				log.WithFields(f).Debugf("%s is a member of group: %s ", userName, URL)
-				return true, nil
-			}
-		}
+	if participant.ID != 0 {
+		identity.ExternalID = strconv.Itoa(participant.ID)
 	}
+	return identity
+}
 
-	return false, nil
+// verdictError returns the error reported in the merge request comment for the participant
+func verdictError(verdict *cla_evaluation.Verdict) error {
+	switch verdict.Reason {
+	case cla_evaluation.ReasonMissingID:
+		return missingID
+	case cla_evaluation.ReasonMissingAffiliation:
+		return missingCompanyAffiliation
+	case cla_evaluation.ReasonNotApproved:
+		return missingCompanyApproval
+	case cla_evaluation.ReasonError:
+		return verdict.Err
+	}
+	return missingSignature
 }
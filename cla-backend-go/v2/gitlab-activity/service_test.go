@@ -4,113 +4,16 @@
 package gitlab_activity
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"testing"
 
-	"github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
-	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/xanzy/go-gitlab"
 )
 
 const enabled = false //nolint
 
-func TestIsUserApprovedForSignature(t *testing.T) {
-	if enabled {
-		userModel := &models.User{
-			Emails: []string{
-				"one@example.com",
-				"two@bar.com",
-			},
-		}
-		gitlabUser := &gitlab.User{
-			Username: "one",
-		}
-
-		testCases := []struct {
-			name      string
-			signature *models.Signature
-			expected  bool
-		}{
-			{
-				name:      "nothing matched",
-				signature: &models.Signature{},
-			},
-			{
-				name: "email approval list non empty no match",
-				signature: &models.Signature{
-					EmailApprovalList: []string{"three@example.com"},
-				},
-			},
-			{
-				name: "email approval list match",
-				signature: &models.Signature{
-					EmailApprovalList: []string{"one@example.com"},
-				},
-				expected: true,
-			},
-			{
-				name: "domain approval list match no match",
-				signature: &models.Signature{
-					DomainApprovalList: []string{"*.foo.com"},
-				},
-				expected: false,
-			},
-			{
-				name: "domain approval list match domain star",
-				signature: &models.Signature{
-					DomainApprovalList: []string{"*.example.com"},
-				},
-				expected: true,
-			},
-			{
-				name: "domain approval list match domain star globbing",
-				signature: &models.Signature{
-					DomainApprovalList: []string{"*example.com"},
-				},
-				expected: true,
-			},
-			{
-				name: "domain approval list match domain star dot",
-				signature: &models.Signature{
-					DomainApprovalList: []string{".example.com"},
-				},
-				expected: true,
-			},
-			{
-				name: "gitlab username approval list no match",
-				signature: &models.Signature{
-					GitlabUsernameApprovalList: []string{"two"},
-				},
-				expected: false,
-			},
-			{
-				name: "gitlab username approval list match",
-				signature: &models.Signature{
-					GitlabUsernameApprovalList: []string{"one"},
-				},
-				expected: true,
-			},
-		}
-		activityService := NewService(nil, nil, nil, nil, nil, nil, nil, nil)
-
-		for _, tc := range testCases {
-			t.Run(tc.name, func(tt *testing.T) {
-				result := activityService.IsUserApprovedForSignature(context.Background(), logrus.Fields{}, tc.signature, userModel, gitlabUser)
-				if tc.expected {
-					assert.True(tt, result)
-
-				} else {
-					assert.False(tt, result)
-				}
-			})
-		}
-	}
-
-}
-
 func TestPrepareMrCommentContent(t *testing.T) {
 	if enabled {
 		signedContains := ":white_check_mark: %s"
@@ -171,7 +74,7 @@ func TestPrepareMrCommentContent(t *testing.T) {
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(tt *testing.T) {
-				result := PrepareMrCommentContent(tc.missing, tc.signed, "https://sign.com")
+				result := PrepareMrCommentContent(tc.missing, tc.signed, nil, "https://sign.com")
 				tt.Logf("the result is : %s", result)
 				parts := strings.Split(result, "<li>")
 				assert.Len(tt, parts, len(tc.expectedMsgs)+1)
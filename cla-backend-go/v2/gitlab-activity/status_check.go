@@ -0,0 +1,46 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_activity
+
+import (
+	"errors"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	gitlab_api "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+	"github.com/xanzy/go-gitlab"
+)
+
+// setMergeRequestStatus reports the CLA result on the EasyCLA external status check of the merge request, and falls
+// back to the commit status when the project has no EasyCLA external status check or doesn't support them
+func setMergeRequestStatus(f logrus.Fields, gitlabClient *gitlab.Client, projectID int, mergeID int, commitSha string, passed bool, message string, targetURL string) error {
+	statusCheckURL := gitlab_api.ExternalStatusCheckURL(config.GetConfig().ClaAPIV4Base)
+	statusCheck, err := gitlab_api.GetMergeRequestExternalStatusCheck(gitlabClient, projectID, mergeID, statusCheckURL)
+	if err != nil {
+		if errors.Is(err, gitlab_api.ErrExternalStatusChecksUnsupported) {
+			log.WithFields(f).Debug("external status checks are not supported for the project - setting the commit status")
+		} else {
+			log.WithFields(f).WithError(err).Warn("problem loading the external status checks of the merge request - setting the commit status")
+		}
+	}
+
+	if statusCheck != nil {
+		status := gitlab_api.ExternalStatusCheckFailed
+		if passed {
+			status = gitlab_api.ExternalStatusCheckPassed
+		}
+		statusErr := gitlab_api.SetExternalStatusCheckResponse(gitlabClient, projectID, mergeID, commitSha, statusCheck.ID, status)
+		if statusErr == nil {
+			return nil
+		}
+		log.WithFields(f).WithError(statusErr).Warnf("problem responding to the external status check : %d - setting the commit status", statusCheck.ID)
+	}
+
+	state := gitlab.Failed
+	if passed {
+		state = gitlab.Success
+	}
+	return gitlab_api.SetCommitStatus(gitlabClient, projectID, commitSha, state, message, targetURL)
+}
@@ -0,0 +1,172 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_instances
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/gitlab_instances"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service ServiceInterface) { // nolint
+
+	api.GitlabInstancesListGitlabInstancesHandler = gitlab_instances.ListGitlabInstancesHandlerFunc(
+		func(params gitlab_instances.ListGitlabInstancesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.gitlab_instances.handlers.GitlabInstancesListGitlabInstancesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to list the GitLab instances - only admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return gitlab_instances.NewListGitlabInstancesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetGitLabInstances(ctx)
+			if err != nil {
+				msg := "problem loading the GitLab instances"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitlab_instances.NewListGitlabInstancesBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return gitlab_instances.NewListGitlabInstancesOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.GitlabInstancesAddGitlabInstanceHandler = gitlab_instances.AddGitlabInstanceHandlerFunc(
+		func(params gitlab_instances.AddGitlabInstanceParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.gitlab_instances.handlers.GitlabInstancesAddGitlabInstanceHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"baseURL":        utils.StringValue(params.Body.BaseURL),
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to add GitLab instances - only admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return gitlab_instances.NewAddGitlabInstanceForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.AddGitLabInstance(ctx, params.Body)
+			if err != nil {
+				if err == ErrInstanceExists {
+					return gitlab_instances.NewAddGitlabInstanceConflict().WithXRequestID(reqID).WithPayload(utils.ErrorResponseConflictWithError(reqID, "GitLab instance already exists", err))
+				}
+				msg := "problem adding the GitLab instance"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitlab_instances.NewAddGitlabInstanceBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return gitlab_instances.NewAddGitlabInstanceOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.GitlabInstancesGetGitlabInstanceHandler = gitlab_instances.GetGitlabInstanceHandlerFunc(
+		func(params gitlab_instances.GetGitlabInstanceParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.gitlab_instances.handlers.GitlabInstancesGetGitlabInstanceHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"instanceID":     params.InstanceID,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to view the GitLab instances - only admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return gitlab_instances.NewGetGitlabInstanceForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetGitLabInstance(ctx, params.InstanceID)
+			if err != nil {
+				if err == ErrInstanceNotFound {
+					return gitlab_instances.NewGetGitlabInstanceNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, "GitLab instance not found", err))
+				}
+				msg := fmt.Sprintf("problem loading the GitLab instance %s", params.InstanceID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitlab_instances.NewGetGitlabInstanceBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return gitlab_instances.NewGetGitlabInstanceOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.GitlabInstancesUpdateGitlabInstanceHandler = gitlab_instances.UpdateGitlabInstanceHandlerFunc(
+		func(params gitlab_instances.UpdateGitlabInstanceParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.gitlab_instances.handlers.GitlabInstancesUpdateGitlabInstanceHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"instanceID":     params.InstanceID,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to update GitLab instances - only admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return gitlab_instances.NewUpdateGitlabInstanceForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.UpdateGitLabInstance(ctx, params.InstanceID, params.Body)
+			if err != nil {
+				if errors.Is(err, ErrInstanceNotFound) {
+					return gitlab_instances.NewUpdateGitlabInstanceNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, "GitLab instance not found", err))
+				}
+				msg := fmt.Sprintf("problem updating the GitLab instance %s", params.InstanceID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitlab_instances.NewUpdateGitlabInstanceBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return gitlab_instances.NewUpdateGitlabInstanceOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.GitlabInstancesDeleteGitlabInstanceHandler = gitlab_instances.DeleteGitlabInstanceHandlerFunc(
+		func(params gitlab_instances.DeleteGitlabInstanceParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.gitlab_instances.handlers.GitlabInstancesDeleteGitlabInstanceHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"instanceID":     params.InstanceID,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to delete GitLab instances - only admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return gitlab_instances.NewDeleteGitlabInstanceForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err := service.DeleteGitLabInstance(ctx, params.InstanceID)
+			if err != nil {
+				if err == ErrInstanceNotFound {
+					return gitlab_instances.NewDeleteGitlabInstanceNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, "GitLab instance not found", err))
+				}
+				msg := fmt.Sprintf("problem deleting the GitLab instance %s", params.InstanceID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitlab_instances.NewDeleteGitlabInstanceBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return gitlab_instances.NewDeleteGitlabInstanceNoContent().WithXRequestID(reqID)
+		})
+}
@@ -0,0 +1,39 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_instances
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// DBGitLabInstanceModel data model for the GitLab instances table, the secrets are encrypted with the keyring of the
+// GitLab application, or its private key when no keyring is configured
+type DBGitLabInstanceModel struct {
+	InstanceID      string `dynamodbav:"instance_id" json:"instance_id"`
+	BaseURL         string `dynamodbav:"base_url" json:"base_url"`
+	AppClientID     string `dynamodbav:"app_client_id" json:"app_client_id"`
+	AppClientSecret string `dynamodbav:"app_client_secret" json:"app_client_secret"`
+	RedirectURI     string `dynamodbav:"redirect_uri" json:"redirect_uri"`
+	WebhookSecret   string `dynamodbav:"webhook_secret" json:"webhook_secret"`
+	Description     string `dynamodbav:"description" json:"description"`
+	DateCreated     string `dynamodbav:"date_created" json:"date_created"`
+	DateModified    string `dynamodbav:"date_modified" json:"date_modified"`
+	Version         string `dynamodbav:"version" json:"version"`
+}
+
+// toModel converts the database model to the API model, without the secrets
+func (m *DBGitLabInstanceModel) toModel() *models.GitlabInstance {
+	return &models.GitlabInstance{
+		InstanceID:         m.InstanceID,
+		BaseURL:            m.BaseURL,
+		AppClientID:        m.AppClientID,
+		RedirectURI:        m.RedirectURI,
+		AppClientSecretSet: m.AppClientSecret != "",
+		WebhookSecretSet:   m.WebhookSecret != "",
+		Description:        m.Description,
+		DateCreated:        m.DateCreated,
+		DateModified:       m.DateModified,
+		Version:            m.Version,
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_instances
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// table columns
+const (
+	// InstanceIDColumn is the primary key of the GitLab instances table
+	InstanceIDColumn = "instance_id"
+	// AppClientSecretColumn is the encrypted client secret of the GitLab application of the instance
+	AppClientSecretColumn = "app_client_secret"
+	// WebhookSecretColumn is the encrypted secret token of the system hooks of the instance
+	WebhookSecretColumn = "webhook_secret"
+	// DateModifiedColumn is the date modified column
+	DateModifiedColumn = "date_modified"
+)
+
+// RepositoryInterface defines the GitLab instances data access functions
+type RepositoryInterface interface {
+	AddInstance(ctx context.Context, instance *DBGitLabInstanceModel) error
+	GetInstance(ctx context.Context, instanceID string) (*DBGitLabInstanceModel, error)
+	GetInstances(ctx context.Context) ([]*DBGitLabInstanceModel, error)
+	UpdateInstance(ctx context.Context, instance *DBGitLabInstanceModel) error
+	UpdateInstanceSecrets(ctx context.Context, instanceID string, current, updated InstanceSecrets) error
+	DeleteInstance(ctx context.Context, instanceID string) error
+}
+
+// Repository object/struct
+type Repository struct {
+	stage              string
+	dynamoDBClient     *dynamodb.DynamoDB
+	instancesTableName string
+}
+
+// NewRepository creates a new instance of the GitLab instances repository
+func NewRepository(awsSession *session.Session, stage string) RepositoryInterface {
+	return &Repository{
+		stage:              stage,
+		dynamoDBClient:     dynamodb.New(awsSession),
+		instancesTableName: fmt.Sprintf("cla-%s-gitlab-instances", stage),
+	}
+}
+
+// AddInstance adds the GitLab instance to the database, fails with ErrInstanceExists if the instance already exists
+func (repo *Repository) AddInstance(ctx context.Context, instance *DBGitLabInstanceModel) error {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_instances.repository.AddInstance",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"instanceID":     instance.InstanceID,
+		"baseURL":        instance.BaseURL,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(instance)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall GitLab instance record")
+		return err
+	}
+
+	log.WithFields(f).Debug("adding GitLab instance record to the database...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.instancesTableName),
+		ConditionExpression: aws.String("attribute_not_exists(instance_id)"),
+	})
+	if err != nil {
+		if aErr, ok := err.(awserr.Error); ok && aErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Warn("GitLab instance already exists")
+			return ErrInstanceExists
+		}
+		log.WithFields(f).WithError(err).Warn("unable to add GitLab instance record")
+		return err
+	}
+
+	return nil
+}
+
+// GetInstance returns the GitLab instance by ID, nil if not found
+func (repo *Repository) GetInstance(ctx context.Context, instanceID string) (*DBGitLabInstanceModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_instances.repository.GetInstance",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"instanceID":     instanceID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			InstanceIDColumn: {S: aws.String(instanceID)},
+		},
+		TableName: aws.String(repo.instancesTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load GitLab instance record")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		log.WithFields(f).Debug("unable to find GitLab instance record - no results")
+		return nil, nil
+	}
+
+	var instance DBGitLabInstanceModel
+	err = dynamodbattribute.UnmarshalMap(result.Item, &instance)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem decoding GitLab instance record")
+		return nil, err
+	}
+
+	return &instance, nil
+}
+
+// GetInstances returns all the GitLab instances
+func (repo *Repository) GetInstances(ctx context.Context) ([]*DBGitLabInstanceModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_instances.repository.GetInstances",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		TableName: aws.String(repo.instancesTableName),
+	}
+
+	var instances []*DBGitLabInstanceModel
+	for {
+		results, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.WithFields(f).WithError(scanErr).Warn("error scanning GitLab instances table")
+			return nil, scanErr
+		}
+
+		var page []*DBGitLabInstanceModel
+		err := dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding GitLab instance records")
+			return nil, err
+		}
+		instances = append(instances, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return instances, nil
+}
+
+// UpdateInstance replaces the GitLab instance record, fails with ErrInstanceNotFound if the instance doesn't exist
+func (repo *Repository) UpdateInstance(ctx context.Context, instance *DBGitLabInstanceModel) error {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_instances.repository.UpdateInstance",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"instanceID":     instance.InstanceID,
+		"baseURL":        instance.BaseURL,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(instance)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall GitLab instance record")
+		return err
+	}
+
+	log.WithFields(f).Debug("updating GitLab instance record...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.instancesTableName),
+		ConditionExpression: aws.String("attribute_exists(instance_id)"),
+	})
+	if err != nil {
+		if aErr, ok := err.(awserr.Error); ok && aErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrInstanceNotFound
+		}
+		log.WithFields(f).WithError(err).Warn("unable to update GitLab instance record")
+		return err
+	}
+
+	return nil
+}
+
+// InstanceSecrets are the encrypted secrets of a GitLab instance
+type InstanceSecrets struct {
+	AppClientSecret string
+	WebhookSecret   string
+}
+
+// UpdateInstanceSecrets replaces the encrypted secrets of the GitLab instance which changed. The update fails with a
+// conditional check error when a secret was updated since it was read.
+func (repo *Repository) UpdateInstanceSecrets(ctx context.Context, instanceID string, current, updated InstanceSecrets) error {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_instances.repository.UpdateInstanceSecrets",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"instanceID":     instanceID,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{
+		"#M": aws.String(DateModifiedColumn),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":m": {S: aws.String(currentTime)},
+	}
+	updateExpression := "SET #M = :m"
+	conditionExpression := "attribute_exists(instance_id)"
+
+	columns := []struct {
+		name, column, current, updated string
+	}{
+		{"C", AppClientSecretColumn, current.AppClientSecret, updated.AppClientSecret},
+		{"S", WebhookSecretColumn, current.WebhookSecret, updated.WebhookSecret},
+	}
+	for _, c := range columns {
+		if c.updated == "" || c.updated == c.current {
+			continue
+		}
+		expressionAttributeNames["#"+c.name] = aws.String(c.column)
+		expressionAttributeValues[":"+strings.ToLower(c.name)] = &dynamodb.AttributeValue{S: aws.String(c.updated)}
+		expressionAttributeValues[":o"+strings.ToLower(c.name)] = &dynamodb.AttributeValue{S: aws.String(c.current)}
+		updateExpression = fmt.Sprintf("%s, #%s = :%s", updateExpression, c.name, strings.ToLower(c.name))
+		conditionExpression = fmt.Sprintf("%s AND #%s = :o%s", conditionExpression, c.name, strings.ToLower(c.name))
+	}
+	if len(expressionAttributeNames) == 1 {
+		log.WithFields(f).Debug("no secret changed - nothing to update")
+		return nil
+	}
+
+	log.WithFields(f).Debug("updating GitLab instance secrets...")
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			InstanceIDColumn: {S: aws.String(instanceID)},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		ConditionExpression:       aws.String(conditionExpression),
+		UpdateExpression:          aws.String(updateExpression),
+		TableName:                 aws.String(repo.instancesTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update GitLab instance secrets")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteInstance removes the GitLab instance
+func (repo *Repository) DeleteInstance(ctx context.Context, instanceID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_instances.repository.DeleteInstance",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"instanceID":     instanceID,
+	}
+
+	log.WithFields(f).Debug("deleting GitLab instance record...")
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			InstanceIDColumn: {S: aws.String(instanceID)},
+		},
+		TableName: aws.String(repo.instancesTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to delete GitLab instance record")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_instances
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	gitlabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// errors
+var (
+	// ErrInstanceNotFound is returned when the GitLab instance does not exist
+	ErrInstanceNotFound = errors.New("gitlab instance not found")
+	// ErrInstanceExists is returned when adding a GitLab instance with the same host name as an existing one
+	ErrInstanceExists = errors.New("gitlab instance already exists")
+	// ErrInvalidBaseURL is returned when the base URL is not a https URL without a path
+	ErrInvalidBaseURL = errors.New("the base URL of the gitlab instance must be a https URL without a path")
+	// ErrDefaultInstance is returned when adding or changing gitlab.com, which is configured by the application
+	ErrDefaultInstance = errors.New("the gitlab.com instance is configured by the application and can't be changed")
+	// ErrClientSecretRequired is returned when adding a GitLab instance without the OAuth application secret
+	ErrClientSecretRequired = errors.New("the secret of the OAuth application is required")
+)
+
+// ServiceInterface defines the GitLab instances service functions
+type ServiceInterface interface {
+	AddGitLabInstance(ctx context.Context, input *models.GitlabInstanceInput) (*models.GitlabInstance, error)
+	GetGitLabInstances(ctx context.Context) (*models.GitlabInstanceList, error)
+	GetGitLabInstance(ctx context.Context, instanceID string) (*models.GitlabInstance, error)
+	UpdateGitLabInstance(ctx context.Context, instanceID string, input *models.GitlabInstanceInput) (*models.GitlabInstance, error)
+	DeleteGitLabInstance(ctx context.Context, instanceID string) error
+
+	GetInstance(ctx context.Context, instanceID string) (*gitlabApi.Instance, error)
+}
+
+// Service data model
+type Service struct {
+	repo          RepositoryInterface
+	gitLabApp     *gitlabApi.App
+	eventsService events.Service
+}
+
+// NewService creates a new GitLab instances service
+func NewService(repo RepositoryInterface, eventsService events.Service) ServiceInterface {
+	return &Service{
+		repo:          repo,
+		gitLabApp:     gitlabApi.Init(config.GetConfig().Gitlab.AppClientID, config.GetConfig().Gitlab.AppClientSecret, config.GetConfig().Gitlab.AppPrivateKey),
+		eventsService: eventsService,
+	}
+}
+
+// AddGitLabInstance registers the self-managed GitLab instance, the instance ID is the host name of the base URL
+func (s *Service) AddGitLabInstance(ctx context.Context, input *models.GitlabInstanceInput) (*models.GitlabInstance, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_instances.service.AddGitLabInstance",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"baseURL":        utils.StringValue(input.BaseURL),
+		"appClientID":    utils.StringValue(input.AppClientID),
+	}
+
+	instanceID, baseURL, err := ParseBaseURL(utils.StringValue(input.BaseURL))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid base URL")
+		return nil, err
+	}
+	if input.AppClientSecret == "" {
+		return nil, ErrClientSecretRequired
+	}
+
+	_, currentTime := utils.CurrentTime()
+	instance := &DBGitLabInstanceModel{
+		InstanceID:   instanceID,
+		BaseURL:      baseURL,
+		DateCreated:  currentTime,
+		DateModified: currentTime,
+		Version:      "v1",
+	}
+	if err := s.applyInput(instance, input); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem encrypting the GitLab instance secrets")
+		return nil, err
+	}
+
+	err = s.repo.AddInstance(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.GitLabInstanceAdded,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData: &events.GitLabInstanceAddedEventData{
+			InstanceID: instance.InstanceID,
+			BaseURL:    instance.BaseURL,
+		},
+	})
+
+	return instance.toModel(), nil
+}
+
+// GetGitLabInstances returns the registered GitLab instances
+func (s *Service) GetGitLabInstances(ctx context.Context) (*models.GitlabInstanceList, error) {
+	instances, err := s.repo.GetInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.GitlabInstanceList{
+		List: []*models.GitlabInstance{},
+	}
+	for _, instance := range instances {
+		result.List = append(result.List, instance.toModel())
+	}
+	return result, nil
+}
+
+// GetGitLabInstance returns the GitLab instance
+func (s *Service) GetGitLabInstance(ctx context.Context, instanceID string) (*models.GitlabInstance, error) {
+	instance, err := s.repo.GetInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, ErrInstanceNotFound
+	}
+	return instance.toModel(), nil
+}
+
+// UpdateGitLabInstance updates the GitLab instance, the secrets are kept when not provided
+func (s *Service) UpdateGitLabInstance(ctx context.Context, instanceID string, input *models.GitlabInstanceInput) (*models.GitlabInstance, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_instances.service.UpdateGitLabInstance",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"instanceID":     instanceID,
+		"baseURL":        utils.StringValue(input.BaseURL),
+	}
+
+	instance, err := s.repo.GetInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, ErrInstanceNotFound
+	}
+
+	inputInstanceID, baseURL, err := ParseBaseURL(utils.StringValue(input.BaseURL))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid base URL")
+		return nil, err
+	}
+	// the GitLab organizations refer to the instance by the host name
+	if inputInstanceID != instance.InstanceID {
+		return nil, fmt.Errorf("%w : the host name of the base URL can't be changed from %s", ErrInvalidBaseURL, instance.InstanceID)
+	}
+
+	instance.BaseURL = baseURL
+	if err := s.applyInput(instance, input); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem encrypting the GitLab instance secrets")
+		return nil, err
+	}
+	_, instance.DateModified = utils.CurrentTime()
+
+	err = s.repo.UpdateInstance(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.GitLabInstanceUpdated,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData: &events.GitLabInstanceUpdatedEventData{
+			InstanceID: instance.InstanceID,
+			BaseURL:    instance.BaseURL,
+		},
+	})
+
+	return instance.toModel(), nil
+}
+
+// DeleteGitLabInstance removes the GitLab instance
+func (s *Service) DeleteGitLabInstance(ctx context.Context, instanceID string) error {
+	instance, err := s.repo.GetInstance(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return ErrInstanceNotFound
+	}
+
+	err = s.repo.DeleteInstance(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.GitLabInstanceDeleted,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData: &events.GitLabInstanceDeletedEventData{
+			InstanceID: instance.InstanceID,
+			BaseURL:    instance.BaseURL,
+		},
+	})
+
+	return nil
+}
+
+// GetInstance returns the GitLab instance with the decrypted secrets, used to create the GitLab clients of the
+// GitLab organizations registered on the instance. An empty instance ID returns the gitlab.com instance.
+func (s *Service) GetInstance(ctx context.Context, instanceID string) (*gitlabApi.Instance, error) {
+	if gitlabApi.IsDefaultInstanceID(instanceID) {
+		return gitlabApi.DefaultInstance(), nil
+	}
+
+	instance, err := s.repo.GetInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, fmt.Errorf("%w : %s", ErrInstanceNotFound, instanceID)
+	}
+
+	appClientSecret, err := gitlabApi.DecryptSecret(instance.AppClientSecret, s.gitLabApp)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting the application secret of the gitlab instance : %s failed : %v", instanceID, err)
+	}
+	webhookSecret := ""
+	if instance.WebhookSecret != "" {
+		webhookSecret, err = gitlabApi.DecryptSecret(instance.WebhookSecret, s.gitLabApp)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting the webhook secret of the gitlab instance : %s failed : %v", instanceID, err)
+		}
+	}
+
+	return &gitlabApi.Instance{
+		InstanceID:      instance.InstanceID,
+		BaseURL:         instance.BaseURL,
+		AppClientID:     instance.AppClientID,
+		AppClientSecret: appClientSecret,
+		RedirectURI:     instance.RedirectURI,
+		WebhookSecret:   webhookSecret,
+	}, nil
+}
+
+// applyInput sets the values of the input on the instance, encrypting the provided secrets
+func (s *Service) applyInput(instance *DBGitLabInstanceModel, input *models.GitlabInstanceInput) error {
+	instance.AppClientID = utils.StringValue(input.AppClientID)
+	instance.Description = input.Description
+	instance.RedirectURI = input.RedirectURI
+	if instance.RedirectURI == "" {
+		instance.RedirectURI = config.GetConfig().Gitlab.RedirectURI
+	}
+
+	if input.AppClientSecret != "" {
+		encrypted, err := gitlabApi.EncryptSecret(input.AppClientSecret, s.gitLabApp)
+		if err != nil {
+			return err
+		}
+		instance.AppClientSecret = encrypted
+	}
+	if input.WebhookSecret != "" {
+		encrypted, err := gitlabApi.EncryptSecret(input.WebhookSecret, s.gitLabApp)
+		if err != nil {
+			return err
+		}
+		instance.WebhookSecret = encrypted
+	}
+	return nil
+}
+
+// ParseBaseURL validates the base URL of a self-managed GitLab instance, returns the instance ID - the lower case
+// host name - and the normalized base URL
+func ParseBaseURL(baseURL string) (string, string, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return "", "", fmt.Errorf("%w : %v", ErrInvalidBaseURL, err)
+	}
+	if u.Scheme != "https" || u.Host == "" || strings.Trim(u.Path, "/") != "" || u.RawQuery != "" || u.User != nil {
+		return "", "", ErrInvalidBaseURL
+	}
+
+	instanceID := strings.ToLower(u.Host)
+	if gitlabApi.IsDefaultInstanceID(instanceID) {
+		return "", "", ErrDefaultInstance
+	}
+	return instanceID, fmt.Sprintf("https://%s", instanceID), nil
+}
@@ -36,6 +36,18 @@ const (
 	GitLabOrganizationsDateModifiedColumn = "date_modified"
 	// GitLabOrganizationsExternalGitLabGroupIDColumn constant
 	GitLabOrganizationsExternalGitLabGroupIDColumn = "external_gitlab_group_id"
+	// GitLabOrganizationsInstanceIDColumn constant
+	GitLabOrganizationsInstanceIDColumn = "instance_id"
 	// GitLabOrganizationsAuthExpiryTimeColumn constant
 	GitLabOrganizationsAuthExpiryTimeColumn = "auth_expiry_time"
+	// GitLabOrganizationsWebhookSecretColumn constant
+	GitLabOrganizationsWebhookSecretColumn = "webhook_secret"
+	// GitLabOrganizationsPreviousWebhookSecretColumn constant
+	GitLabOrganizationsPreviousWebhookSecretColumn = "previous_webhook_secret"
+	// GitLabOrganizationsPreviousWebhookSecretExpiryColumn constant
+	GitLabOrganizationsPreviousWebhookSecretExpiryColumn = "previous_webhook_secret_expiry"
+	// GitLabOrganizationsWebhookSecretRotatedOnColumn constant
+	GitLabOrganizationsWebhookSecretRotatedOnColumn = "webhook_secret_rotated_on"
+	// GitLabOrganizationsSubgroupsColumn constant
+	GitLabOrganizationsSubgroupsColumn = "subgroups"
 )
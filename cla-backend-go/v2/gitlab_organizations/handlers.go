@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 
 	"github.com/LF-Engineering/lfx-kit/auth"
 	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/gitlab_organizations"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
@@ -209,6 +211,15 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 				ExternalGroupID:         params.Body.GroupID,
 				OrganizationURL:         orgURL,
 				OrganizationFullPath:    params.Body.OrganizationFullPath,
+				InstanceID:              params.Body.InstanceID,
+			}
+			for _, subgroup := range params.Body.Subgroups {
+				inputModel.Subgroups = append(inputModel.Subgroups, &common.GitLabSubgroup{
+					GroupID:     subgroup.GroupID,
+					FullPath:    subgroup.FullPath,
+					ClaGroupID:  subgroup.ClaGroupID,
+					AutoEnabled: subgroup.AutoEnabled,
+				})
 			}
 
 			result, err := service.AddGitLabOrganization(ctx, inputModel)
@@ -408,6 +419,154 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 		return gitlab_organizations.NewDeleteProjectGitlabGroupConfigNoContent()
 	})
 
+	api.GitlabOrganizationsRotateProjectGitlabGroupWebhookSecretHandler = gitlab_organizations.RotateProjectGitlabGroupWebhookSecretHandlerFunc(func(params gitlab_organizations.RotateProjectGitlabGroupWebhookSecretParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.gitlab_organizations.handlers.GitlabOrganizationsRotateProjectGitlabGroupWebhookSecretHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"projectSFID":    params.ProjectSFID,
+			"gitLabGroupID":  params.GitLabGroupID,
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+		}
+
+		// Load the project
+		psc := projectService.GetClient()
+		projectModel, err := psc.GetProject(params.ProjectSFID)
+		if err != nil || projectModel == nil {
+			return gitlab_organizations.NewRotateProjectGitlabGroupWebhookSecretNotFound().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate project with ID: %s", params.ProjectSFID)))
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to Rotate the GitLab Group/Organization Webhook Secret for Project '%s' with scope of %s",
+				authUser.UserName, projectModel.Name, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return gitlab_organizations.NewRotateProjectGitlabGroupWebhookSecretForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		gracePeriod := DefaultWebhookSecretGracePeriod
+		if params.Body != nil && params.Body.GracePeriodMinutes != nil {
+			gracePeriod = time.Duration(*params.Body.GracePeriodMinutes) * time.Minute
+		}
+
+		rotation, err := service.RotateWebhookSecret(ctx, params.ProjectSFID, params.GitLabGroupID, gracePeriod)
+		if err != nil {
+			if errors.Is(err, ErrGitLabOrganizationNotFound) {
+				msg := fmt.Sprintf("GitLab Group with ID: %d is not registered for the project %s", params.GitLabGroupID, projectModel.Name)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewRotateProjectGitlabGroupWebhookSecretNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			msg := fmt.Sprintf("problem rotating the webhook secret of the GitLab Group with ID: %d", params.GitLabGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return gitlab_organizations.NewRotateProjectGitlabGroupWebhookSecretBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		previousSecretExpiry := utils.TimeToString(rotation.PreviousSecretExpiry)
+		eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.GitLabOrganizationWebhookSecretRotated,
+			ProjectSFID: params.ProjectSFID,
+			ProjectName: projectModel.Name,
+			LfUsername:  authUser.UserName,
+			UserName:    authUser.UserName,
+			EventData: &events.GitLabOrganizationWebhookSecretRotatedEventData{
+				GitLabOrganizationName: rotation.Organization.OrganizationFullPath,
+				GitLabGroupID:          params.GitLabGroupID,
+				ProjectsUpdated:        len(rotation.ProjectsUpdated),
+				ProjectsFailed:         len(rotation.ProjectsFailed),
+				PreviousSecretExpiry:   previousSecretExpiry,
+			},
+		})
+
+		return gitlab_organizations.NewRotateProjectGitlabGroupWebhookSecretOK().WithXRequestID(reqID).WithPayload(&v2Models.GitlabWebhookSecretRotation{
+			OrganizationID:       rotation.Organization.OrganizationID,
+			GitlabGroupID:        params.GitLabGroupID,
+			PreviousSecretExpiry: previousSecretExpiry,
+			ProjectsUpdated:      rotation.ProjectsUpdated,
+			ProjectsFailed:       rotation.ProjectsFailed,
+		})
+	})
+
+	api.GitlabOrganizationsUpdateProjectGitlabSubgroupConfigHandler = gitlab_organizations.UpdateProjectGitlabSubgroupConfigHandlerFunc(func(params gitlab_organizations.UpdateProjectGitlabSubgroupConfigParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.gitlab_organizations.handlers.GitlabOrganizationsUpdateProjectGitlabSubgroupConfigHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"projectSFID":    params.ProjectSFID,
+			"gitLabGroupID":  params.GitLabGroupID,
+			"subgroupID":     params.SubgroupID,
+			"claGroupID":     params.Body.ClaGroupID,
+			"autoEnabled":    params.Body.AutoEnabled,
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+		}
+
+		// Load the project
+		psc := projectService.GetClient()
+		projectModel, err := psc.GetProject(params.ProjectSFID)
+		if err != nil || projectModel == nil {
+			return gitlab_organizations.NewUpdateProjectGitlabSubgroupConfigNotFound().WithPayload(
+				utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate project with ID: %s", params.ProjectSFID)))
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to Update Project GitLab Subgroups for Project '%s' with scope of %s",
+				authUser.UserName, projectModel.Name, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return gitlab_organizations.NewUpdateProjectGitlabSubgroupConfigForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		gitLabOrg, subgroup, err := service.UpdateSubgroupOverride(ctx, params.ProjectSFID, params.GitLabGroupID, params.SubgroupID, params.Body.ClaGroupID, params.Body.AutoEnabled)
+		if err != nil {
+			if errors.Is(err, ErrGitLabOrganizationNotFound) || errors.Is(err, ErrGitLabSubgroupNotFound) {
+				msg := fmt.Sprintf("GitLab subgroup with ID: %d of the GitLab Group with ID: %d is not registered for the project %s", params.SubgroupID, params.GitLabGroupID, projectModel.Name)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewUpdateProjectGitlabSubgroupConfigNotFound().WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			if errors.Is(err, projects_cla_groups.ErrCLAGroupDoesNotExist) {
+				msg := fmt.Sprintf("CLA Group with ID: %s was not found", params.Body.ClaGroupID)
+				return gitlab_organizations.NewUpdateProjectGitlabSubgroupConfigNotFound().WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			msg := fmt.Sprintf("problem updating the GitLab subgroup with ID: %d of the GitLab Group with ID: %d", params.SubgroupID, params.GitLabGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return gitlab_organizations.NewUpdateProjectGitlabSubgroupConfigBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		autoEnabled := ""
+		if subgroup.AutoEnabled != nil {
+			autoEnabled = strconv.FormatBool(*subgroup.AutoEnabled)
+		}
+		eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.GitLabSubgroupUpdated,
+			ProjectSFID: params.ProjectSFID,
+			ProjectName: projectModel.Name,
+			CLAGroupID:  subgroup.ClaGroupID,
+			LfUsername:  authUser.UserName,
+			UserName:    authUser.UserName,
+			EventData: &events.GitLabSubgroupUpdatedEventData{
+				GitLabOrganizationName: gitLabOrg.OrganizationFullPath,
+				GitLabGroupID:          params.GitLabGroupID,
+				SubgroupFullPath:       subgroup.FullPath,
+				SubgroupID:             params.SubgroupID,
+				ClaGroupID:             subgroup.ClaGroupID,
+				AutoEnabled:            autoEnabled,
+			},
+		})
+
+		results, err := service.GetGitLabOrganizationsByProjectSFID(ctx, params.ProjectSFID)
+		if err != nil {
+			msg := fmt.Sprintf("failed to locate Gitlab organization by project SFID: %s, error: %+v", params.ProjectSFID, err)
+			log.WithFields(f).Debug(msg)
+			return gitlab_organizations.NewUpdateProjectGitlabSubgroupConfigBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return gitlab_organizations.NewUpdateProjectGitlabSubgroupConfigOK().WithPayload(results)
+	})
+
 	api.GitlabActivityGitlabOauthCallbackHandler = gitlab_activity.GitlabOauthCallbackHandlerFunc(func(params gitlab_activity.GitlabOauthCallbackParams) middleware.Responder {
 		ctx := utils.NewContext()
 		f := logrus.Fields{
@@ -501,8 +660,18 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 						return
 					}
 
+					// the sign request stores the GitLab instance of the organization, gitlab.com when missing
+					instanceID, _ := session.Values["gitlab_instance_id"].(string)
+					instance, err := service.GetGitLabInstance(ctx, instanceID)
+					if err != nil {
+						msg := fmt.Sprintf("unable to load the gitlab instance : %s", instanceID)
+						log.WithFields(f).WithError(err).Warn(msg)
+						http.Error(rw, msg, http.StatusInternalServerError)
+						return
+					}
+
 					log.WithFields(f).Debug("Fetching access token for user...")
-					token, err := gitlabApi.FetchOauthCredentials(*params.Code)
+					token, err := gitlabApi.FetchOauthCredentials(instance, *params.Code)
 					if err != nil {
 						msg := fmt.Sprint("unable to fetch access token for user")
 						log.WithFields(f).Warn(msg)
@@ -514,10 +683,10 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 					session.Save(params.HTTPRequest, rw)
 
 					// Get client
-					gitlabClient, err := gitlabApi.NewGitlabOauthClientFromAccessToken(token.AccessToken)
+					gitlabClient, err := gitlabApi.NewGitlabOauthClientFromAccessToken(token.AccessToken, instance)
 					if err != nil {
-						msg := fmt.Sprintf("unable to create gitlab client from token : %s ", token.AccessToken)
-						log.WithFields(f).Warn(msg)
+						msg := "unable to create gitlab client from the oauth token"
+						log.WithFields(f).WithError(err).Warn(msg)
 						http.Error(rw, msg, http.StatusInternalServerError)
 						return
 					}
@@ -538,8 +707,15 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 			return NewServerError(reqID, "", errors.New(msg))
 		}
 
+		instance, err := service.GetGitLabInstance(ctx, gitLabOrg.InstanceID)
+		if err != nil {
+			msg := fmt.Sprintf("loading the gitlab instance : %s of the gitlab organization : %s failed : %v", gitLabOrg.InstanceID, gitlabOrganizationID, err)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return NewServerError(reqID, "", errors.New(msg))
+		}
+
 		// now fetch the oauth credentials and store to db
-		oauthResp, err := gitlabApi.FetchOauthCredentials(*params.Code)
+		oauthResp, err := gitlabApi.FetchOauthCredentials(instance, *params.Code)
 		if err != nil {
 			msg := fmt.Sprintf("fetching gitlab credentials failed : %s : %v", gitlabOrganizationID, err)
 			log.WithFields(f).WithError(err).Warn(msg)
@@ -565,7 +741,7 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 			return NewServerError(reqID, "", errors.New(msg))
 		}
 
-		return NewSuccessResponse(reqID, updatedGitLabOrgDBModel.ProjectSFID, updatedGitLabOrgDBModel.OrganizationName)
+		return NewSuccessResponse(reqID, updatedGitLabOrgDBModel.ProjectSFID, updatedGitLabOrgDBModel.OrganizationName, instance.ApplicationsURL())
 	})
 }
 
@@ -574,16 +750,18 @@ type SuccessResponse struct {
 	ReqID           string
 	ProjectSFID     string
 	GitLabGroupName string
+	// ConfigPage is the page of the GitLab instance listing the authorized applications
+	ConfigPage string
 }
 
 // NewSuccessResponse creates a new redirect handler
-func NewSuccessResponse(reqID, projectSFID, gitLabGroupName string) *SuccessResponse {
-	return &SuccessResponse{reqID, projectSFID, gitLabGroupName}
+func NewSuccessResponse(reqID, projectSFID, gitLabGroupName, configPage string) *SuccessResponse {
+	return &SuccessResponse{reqID, projectSFID, gitLabGroupName, configPage}
 }
 
 // WriteResponse to the client
 func (o *SuccessResponse) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
-	configPage := "https://gitlab.com/-/profile/applications"
+	configPage := o.ConfigPage
 
 	html := fmt.Sprintf(`<!DOCTYPE html>
     <html lang="en">
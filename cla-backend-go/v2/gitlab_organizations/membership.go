@@ -0,0 +1,85 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_organizations
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	gitlabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/sirupsen/logrus"
+)
+
+/**
+ * Parses url with the given regular expression and returns the
+ * group values defined in the expression.
+ *
+ */
+func getParams(regEx, url string) (paramsMap map[string]string) {
+
+	var compRegEx = regexp.MustCompile(regEx)
+	match := compRegEx.FindStringSubmatch(url)
+
+	paramsMap = make(map[string]string)
+	for i, name := range compRegEx.SubexpNames() {
+		if i > 0 && i <= len(match) {
+			paramsMap[name] = match[i]
+		}
+	}
+	return paramsMap
+}
+
+// GroupMembership checks the GitLab group approval lists of the corporate signatures, the groups are the GitLab
+// organizations onboarded to EasyCLA
+type GroupMembership struct {
+	gitlabOrgService ServiceInterface
+}
+
+// NewGroupMembership creates the GitLab group membership check of the approval lists
+func NewGroupMembership(gitlabOrgService ServiceInterface) GroupMembership {
+	return GroupMembership{gitlabOrgService: gitlabOrgService}
+}
+
+// IsMember returns true if the GitLab user is a member of the GitLab group URL
+func (m GroupMembership) IsMember(ctx context.Context, userName, URL string) (bool, error) {
+	f := logrus.Fields{
+		"functionName": "v2.gitlab_organizations.membership.IsMember",
+		"userName":     userName,
+		"group_url":    URL,
+	}
+
+	log.WithFields(f).Debugf("checking approval list gitlab org criteria : %s for user: %s ", URL, userName)
+	var searchURL = URL
+	params := getParams(`(?P<base>\bhttps?://[^/]+/)(?P<group>\bgroups\/\b)?(?P<name>\w+)`, URL)
+	if params[`group`] == "" {
+		params[`group`] = "groups/"
+		updated := fmt.Sprintf("%s%s%s", params[`base`], params[`group`], params[`name`])
+		log.WithFields(f).Debugf("updating url : %s to %s for easycla search purporses ", searchURL, updated)
+		searchURL = updated
+	}
+	gitlabOrg, _ := m.gitlabOrgService.GetGitLabOrganizationByURL(ctx, searchURL)
+	if gitlabOrg != nil {
+		gitlabClient, clientErr := m.gitlabOrgService.GetGitLabOrganizationClient(ctx, common.ToCommonModel(gitlabOrg))
+		if clientErr != nil {
+			log.WithFields(f).WithError(clientErr).Warnf("problem getting gitLabClient for org: %s ", gitlabOrg.OrganizationName)
+			return false, clientErr
+		}
+		members, err := gitlabApi.ListGroupMembers(ctx, gitlabClient, int(gitlabOrg.OrganizationExternalID))
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem getting gitlab group members")
+			return false, err
+		}
+		for _, member := range members {
+			if userName == member.Username {
+				log.WithFields(f).Debugf("%s is a member of group: %s ", userName, URL)
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,158 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_organizations
+
+import (
+	"context"
+	"errors"
+
+	bitbucketApi "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
+	gitlabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/bitbucket_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_instances"
+	"github.com/sirupsen/logrus"
+)
+
+// CredentialsReEncryption is the result of the re-encryption of the credentials of the GitLab organizations, the
+// GitLab instances and the Bitbucket organizations
+type CredentialsReEncryption struct {
+	// Scanned is the number of records scanned
+	Scanned int
+	// ReEncrypted are the IDs of the records with re-encrypted credentials, or with credentials to re-encrypt on a dry
+	// run - the GitLab instances and Bitbucket organizations IDs are prefixed by their type
+	ReEncrypted []string
+	// Failed are the IDs of the records with credentials which couldn't be re-encrypted
+	Failed []string
+}
+
+// ReEncryptCredentials re-encrypts the oauth credentials and webhook secrets of the GitLab organizations, the secrets
+// of the self-managed GitLab instances and the access tokens and secrets of the Bitbucket organizations with the
+// primary key of the keyring - the values encrypted with the private key of the application or a previous key of the
+// keyring. The GitLab and Bitbucket applications share the keyring. A previous key can be retired once no record fails.
+func ReEncryptCredentials(ctx context.Context, repo RepositoryInterface, instancesRepo gitlab_instances.RepositoryInterface, bitbucketRepo bitbucket_organizations.RepositoryInterface, gitLabApp *gitlabApi.App, bitbucketApp *bitbucketApi.App, dryRun bool) (*CredentialsReEncryption, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_organizations.reencrypt.ReEncryptCredentials",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"dryRun":         dryRun,
+	}
+
+	if gitLabApp.GetKeyring() == nil || bitbucketApp.GetKeyring() == nil {
+		return nil, errors.New("no keyring configured - unable to re-encrypt the credentials")
+	}
+	f["primaryKeyID"] = gitLabApp.GetKeyring().PrimaryKeyID()
+
+	records, err := repo.GetGitLabOrganizationRecords(ctx)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the gitlab organization records")
+		return nil, err
+	}
+	instances, err := instancesRepo.GetInstances(ctx)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the gitlab instance records")
+		return nil, err
+	}
+	bitbucketOrgs, err := bitbucketRepo.GetBitbucketOrganizations(ctx)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the bitbucket organization records")
+		return nil, err
+	}
+
+	result := &CredentialsReEncryption{Scanned: len(records) + len(instances) + len(bitbucketOrgs)}
+	gitLabReEncrypt := func(value string) (bool, string, error) {
+		if !gitlabApi.NeedsReEncryption(value, gitLabApp) {
+			return false, value, nil
+		}
+		reEncrypted, reEncryptErr := gitlabApi.ReEncrypt(value, gitLabApp)
+		return true, reEncrypted, reEncryptErr
+	}
+	bitbucketReEncrypt := func(value string) (bool, string, error) {
+		if !bitbucketApi.NeedsReEncryption(value, bitbucketApp) {
+			return false, value, nil
+		}
+		reEncrypted, reEncryptErr := bitbucketApi.ReEncrypt(value, bitbucketApp)
+		return true, reEncrypted, reEncryptErr
+	}
+
+	for _, record := range records {
+		current := GitLabOrganizationCredentials{
+			AuthInfo:              record.AuthInfo,
+			WebhookSecret:         record.WebhookSecret,
+			PreviousWebhookSecret: record.PreviousWebhookSecret,
+		}
+		updated := current
+		reEncryptErr := reEncryptValues(gitLabReEncrypt, &updated.AuthInfo, &updated.WebhookSecret, &updated.PreviousWebhookSecret)
+		result.apply(f, record.OrganizationID, reEncryptErr, updated == current, dryRun, func() error {
+			return repo.UpdateGitLabOrganizationCredentials(ctx, record.OrganizationID, current, updated)
+		})
+	}
+
+	for _, instance := range instances {
+		current := gitlab_instances.InstanceSecrets{
+			AppClientSecret: instance.AppClientSecret,
+			WebhookSecret:   instance.WebhookSecret,
+		}
+		updated := current
+		reEncryptErr := reEncryptValues(gitLabReEncrypt, &updated.AppClientSecret, &updated.WebhookSecret)
+		result.apply(f, "gitlab-instance:"+instance.InstanceID, reEncryptErr, updated == current, dryRun, func() error {
+			return instancesRepo.UpdateInstanceSecrets(ctx, instance.InstanceID, current, updated)
+		})
+	}
+
+	for _, org := range bitbucketOrgs {
+		current := bitbucket_organizations.OrganizationSecrets{
+			AccessToken:     org.AccessToken,
+			WebhookSecret:   org.WebhookSecret,
+			AppClientSecret: org.AppClientSecret,
+		}
+		updated := current
+		reEncryptErr := reEncryptValues(bitbucketReEncrypt, &updated.AccessToken, &updated.WebhookSecret, &updated.AppClientSecret)
+		result.apply(f, "bitbucket-organization:"+org.OrganizationID, reEncryptErr, updated == current, dryRun, func() error {
+			return bitbucketRepo.UpdateBitbucketOrganizationSecrets(ctx, org.OrganizationID, current, updated)
+		})
+	}
+
+	log.WithFields(f).Infof("scanned %d records - re-encrypted %d, failed %d", result.Scanned, len(result.ReEncrypted), len(result.Failed))
+	return result, nil
+}
+
+// reEncryptValues re-encrypts the non empty values in place, stops at the first failure
+func reEncryptValues(reEncrypt func(value string) (bool, string, error), values ...*string) error {
+	for _, value := range values {
+		if *value == "" {
+			continue
+		}
+		needed, reEncrypted, err := reEncrypt(*value)
+		if err != nil {
+			return err
+		}
+		if needed {
+			*value = reEncrypted
+		}
+	}
+	return nil
+}
+
+// apply records the re-encryption of a record and stores the re-encrypted credentials, unless on a dry run
+func (r *CredentialsReEncryption) apply(f logrus.Fields, id string, reEncryptErr error, unchanged, dryRun bool, update func() error) {
+	if reEncryptErr != nil {
+		log.WithFields(f).WithError(reEncryptErr).Warnf("problem re-encrypting the credentials of: %s", id)
+		r.Failed = append(r.Failed, id)
+		return
+	}
+	if unchanged {
+		return
+	}
+
+	if !dryRun {
+		if updateErr := update(); updateErr != nil {
+			// a conditional check failure means the credentials were replaced since the scan, the next run picks them up
+			log.WithFields(f).WithError(updateErr).Warnf("problem updating the credentials of: %s", id)
+			r.Failed = append(r.Failed, id)
+			return
+		}
+	}
+	r.ReEncrypted = append(r.ReEncrypted, id)
+}
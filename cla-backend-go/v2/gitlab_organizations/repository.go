@@ -57,6 +57,10 @@ type RepositoryInterface interface {
 	GetGitLabOrganizationByURL(ctx context.Context, url string) (*common.GitLabOrganization, error)
 	UpdateGitLabOrganizationAuth(ctx context.Context, organizationID string, gitLabGroupID int, authExpiryTime int64, authInfo, groupName, groupFullPath, organizationURL string) error
 	UpdateGitLabOrganization(ctx context.Context, input *common.GitLabAddOrganization, enabled bool) error
+	UpdateGitLabOrganizationWebhookSecret(ctx context.Context, organizationID, webhookSecret, previousWebhookSecret string, previousWebhookSecretExpiry int64) error
+	GetGitLabOrganizationRecords(ctx context.Context) ([]*common.GitLabOrganization, error)
+	UpdateGitLabOrganizationCredentials(ctx context.Context, organizationID string, current, updated GitLabOrganizationCredentials) error
+	UpdateGitLabOrganizationSubgroups(ctx context.Context, organizationID string, subgroups []*common.GitLabSubgroup) error
 	DeleteGitLabOrganizationByFullPath(ctx context.Context, projectSFID, gitlabOrgFullPath string) error
 }
 
@@ -167,11 +171,13 @@ func (repo *Repository) AddGitLabOrganization(ctx context.Context, input *common
 		ExternalGroupID:         input.ExternalGroupIDAsInt(),
 		OrganizationSFID:        input.ParentProjectSFID,
 		ProjectSFID:             input.ProjectSFID,
+		InstanceID:              input.InstanceID,
 		Enabled:                 enabled,
 		AutoEnabled:             input.AutoEnabled,
 		AutoEnabledClaGroupID:   input.AutoEnabledClaGroupID,
 		BranchProtectionEnabled: input.BranchProtectionEnabled,
 		AuthState:               authStateNonce.String(),
+		WebhookSecret:           input.WebhookSecret,
 		Version:                 "v1",
 	}
 
@@ -672,6 +678,190 @@ func (repo *Repository) UpdateGitLabOrganization(ctx context.Context, input *com
 	return nil
 }
 
+// UpdateGitLabOrganizationWebhookSecret updates the encrypted webhook secrets of the GitLab organization - the previous
+// secret is accepted until its expiry time, a unix timestamp
+func (repo *Repository) UpdateGitLabOrganizationWebhookSecret(ctx context.Context, organizationID, webhookSecret, previousWebhookSecret string, previousWebhookSecretExpiry int64) error {
+	f := logrus.Fields{
+		"functionName":                "gitlab_organizations.repository.UpdateGitLabOrganizationWebhookSecret",
+		utils.XREQUESTID:              ctx.Value(utils.XREQUESTID),
+		"organizationID":              organizationID,
+		"previousWebhookSecretExpiry": previousWebhookSecretExpiry,
+		"tableName":                   repo.gitlabOrgTableName,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	updateExpression := "SET #S = :s, #PS = :ps, #PE = :pe, #R = :r, #M = :m"
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			GitLabOrganizationsOrganizationIDColumn: {
+				S: aws.String(organizationID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#S":  aws.String(GitLabOrganizationsWebhookSecretColumn),
+			"#PS": aws.String(GitLabOrganizationsPreviousWebhookSecretColumn),
+			"#PE": aws.String(GitLabOrganizationsPreviousWebhookSecretExpiryColumn),
+			"#R":  aws.String(GitLabOrganizationsWebhookSecretRotatedOnColumn),
+			"#M":  aws.String(GitLabOrganizationsDateModifiedColumn),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":s": {
+				S: aws.String(webhookSecret),
+			},
+			":ps": {
+				S: aws.String(previousWebhookSecret),
+			},
+			":pe": {
+				N: aws.String(strconv.FormatInt(previousWebhookSecretExpiry, 10)),
+			},
+			":r": {
+				S: aws.String(currentTime),
+			},
+			":m": {
+				S: aws.String(currentTime),
+			},
+		},
+		ConditionExpression: aws.String("attribute_exists(organization_id)"),
+		UpdateExpression:    &updateExpression,
+		TableName:           aws.String(repo.gitlabOrgTableName),
+	}
+
+	log.WithFields(f).Debug("updating gitlab organization webhook secret...")
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		log.WithFields(f).WithError(updateErr).Warnf("unable to update Gitlab organization webhook secret, error: %+v", updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
+// UpdateGitLabOrganizationSubgroups replaces the subgroups of the GitLab organization
+func (repo *Repository) UpdateGitLabOrganizationSubgroups(ctx context.Context, organizationID string, subgroups []*common.GitLabSubgroup) error {
+	f := logrus.Fields{
+		"functionName":   "gitlab_organizations.repository.UpdateGitLabOrganizationSubgroups",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+		"subgroups":      len(subgroups),
+		"tableName":      repo.gitlabOrgTableName,
+	}
+
+	av, err := dynamodbattribute.MarshalList(subgroups)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall the gitlab subgroups")
+		return err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	updateExpression := "SET #SG = :sg, #M = :m"
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			GitLabOrganizationsOrganizationIDColumn: {
+				S: aws.String(organizationID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#SG": aws.String(GitLabOrganizationsSubgroupsColumn),
+			"#M":  aws.String(GitLabOrganizationsDateModifiedColumn),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":sg": {
+				L: av,
+			},
+			":m": {
+				S: aws.String(currentTime),
+			},
+		},
+		ConditionExpression: aws.String("attribute_exists(organization_id)"),
+		UpdateExpression:    &updateExpression,
+		TableName:           aws.String(repo.gitlabOrgTableName),
+	}
+
+	log.WithFields(f).Debug("updating gitlab organization subgroups...")
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		log.WithFields(f).WithError(updateErr).Warnf("unable to update Gitlab organization subgroups, error: %+v", updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
+// GitLabOrganizationCredentials are the encrypted credentials of a GitLab organization
+type GitLabOrganizationCredentials struct {
+	AuthInfo              string
+	WebhookSecret         string
+	PreviousWebhookSecret string
+}
+
+// UpdateGitLabOrganizationCredentials replaces the encrypted credentials of the GitLab organization which changed. The
+// update fails with a conditional check error when a credential was updated since it was read, such as by a new
+// authorization or a webhook secret rotation.
+func (repo *Repository) UpdateGitLabOrganizationCredentials(ctx context.Context, organizationID string, current, updated GitLabOrganizationCredentials) error {
+	f := logrus.Fields{
+		"functionName":   "gitlab_organizations.repository.UpdateGitLabOrganizationCredentials",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+		"tableName":      repo.gitlabOrgTableName,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{
+		"#M": aws.String(GitLabOrganizationsDateModifiedColumn),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":m": {
+			S: aws.String(currentTime),
+		},
+	}
+	updateExpression := "SET #M = :m"
+	conditionExpression := "attribute_exists(organization_id)"
+
+	columns := []struct {
+		name, column, current, updated string
+	}{
+		{"A", GitLabOrganizationsAuthInfoColumn, current.AuthInfo, updated.AuthInfo},
+		{"S", GitLabOrganizationsWebhookSecretColumn, current.WebhookSecret, updated.WebhookSecret},
+		{"PS", GitLabOrganizationsPreviousWebhookSecretColumn, current.PreviousWebhookSecret, updated.PreviousWebhookSecret},
+	}
+	for _, c := range columns {
+		if c.updated == "" || c.updated == c.current {
+			continue
+		}
+		expressionAttributeNames["#"+c.name] = aws.String(c.column)
+		expressionAttributeValues[":"+strings.ToLower(c.name)] = &dynamodb.AttributeValue{S: aws.String(c.updated)}
+		expressionAttributeValues[":o"+strings.ToLower(c.name)] = &dynamodb.AttributeValue{S: aws.String(c.current)}
+		updateExpression = fmt.Sprintf("%s, #%s = :%s", updateExpression, c.name, strings.ToLower(c.name))
+		conditionExpression = fmt.Sprintf("%s AND #%s = :o%s", conditionExpression, c.name, strings.ToLower(c.name))
+	}
+	if len(expressionAttributeNames) == 1 {
+		log.WithFields(f).Debug("no credential changed - nothing to update")
+		return nil
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			GitLabOrganizationsOrganizationIDColumn: {
+				S: aws.String(organizationID),
+			},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		ConditionExpression:       aws.String(conditionExpression),
+		UpdateExpression:          &updateExpression,
+		TableName:                 aws.String(repo.gitlabOrgTableName),
+	}
+
+	log.WithFields(f).Debug("updating gitlab organization credentials...")
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		log.WithFields(f).WithError(updateErr).Warnf("unable to update Gitlab organization credentials, error: %+v", updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
 // DeleteGitLabOrganizationByFullPath deletes the specified GitLab organization
 func (repo *Repository) DeleteGitLabOrganizationByFullPath(ctx context.Context, projectSFID, gitlabOrgFullPath string) error {
 	f := logrus.Fields{
@@ -844,8 +1034,22 @@ func updateResponse(fullResponse, response *v2Models.GitlabOrganizations) {
 }
 
 func (repo *Repository) getScanResults(ctx context.Context, filter *expression.ConditionBuilder) (*v2Models.GitlabOrganizations, error) {
+	records, err := repo.scanRecords(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v2Models.GitlabOrganizations{List: common.ToModels(records)}, nil
+}
+
+// GetGitLabOrganizationRecords returns all the GitLab organization records, with their encrypted credentials
+func (repo *Repository) GetGitLabOrganizationRecords(ctx context.Context) ([]*common.GitLabOrganization, error) {
+	return repo.scanRecords(ctx, nil)
+}
+
+func (repo *Repository) scanRecords(ctx context.Context, filter *expression.ConditionBuilder) ([]*common.GitLabOrganization, error) {
 	f := logrus.Fields{
-		"functionName":   "v2.gitlab_organizations.repository.GetGitLabOrganizations",
+		"functionName":   "v2.gitlab_organizations.repository.scanRecords",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
 	}
 
@@ -893,5 +1097,5 @@ func (repo *Repository) getScanResults(ctx context.Context, filter *expression.C
 		return nil, unmarshalErr
 	}
 
-	return &v2Models.GitlabOrganizations{List: common.ToModels(resultOutput)}, nil
+	return resultOutput, nil
 }
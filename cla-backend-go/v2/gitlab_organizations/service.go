@@ -21,6 +21,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/company"
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_instances"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/repositories"
 
 	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
@@ -63,6 +64,17 @@ type ServiceInterface interface {
 	DeleteGitLabOrganizationByFullPath(ctx context.Context, projectSFID string, gitlabOrgFullPath string) error
 	InitiateSignRequest(ctx context.Context, req *http.Request, gitlabClient *goGitLab.Client, repositoryID, mergeRequestID, originURL, contributorBaseURL string, eventService events.Service) (*string, error)
 	RefreshGitLabOrganizationAuth(ctx context.Context, gitLabOrg *common.GitLabOrganization) (*string, error)
+	GetGitLabOrganizationClient(ctx context.Context, gitLabOrg *common.GitLabOrganization) (*goGitLab.Client, error)
+	GetGitLabInstance(ctx context.Context, instanceID string) (*gitlabApi.Instance, error)
+	GetWebhookSecret(ctx context.Context, gitLabOrg *common.GitLabOrganization) (string, error)
+	ValidateWebhookSecret(ctx context.Context, gitLabOrganizationID, token string) error
+	ValidateGroupHookSecret(ctx context.Context, gitLabOrganizationID, token string) error
+	ValidateStatusCheckSignature(ctx context.Context, gitLabOrganizationID string, payload []byte, signature string) error
+	RotateWebhookSecret(ctx context.Context, projectSFID string, gitLabGroupID int64, gracePeriod time.Duration) (*WebhookSecretRotation, error)
+	SetGroupHook(ctx context.Context, gitLabOrg *common.GitLabOrganization, gitLabClient *goGitLab.Client) error
+	SetSubgroupOverrides(ctx context.Context, gitLabOrganizationID string, overrides []*common.GitLabSubgroup) error
+	SyncSubgroups(ctx context.Context, gitLabOrg *common.GitLabOrganization, gitLabClient *goGitLab.Client) ([]*common.GitLabSubgroupChange, error)
+	UpdateSubgroupOverride(ctx context.Context, projectSFID string, gitLabGroupID, subgroupID int64, claGroupID string, autoEnabled *bool) (*common.GitLabOrganization, *common.GitLabSubgroup, error)
 }
 
 // Service data modelffGetGitLabOrganizationByID
@@ -75,10 +87,13 @@ type Service struct {
 	userService        users.Service
 	signatureRepo      signatures.SignatureRepository
 	companyRepository  company.IRepository
+	instanceService    gitlab_instances.ServiceInterface
+	// now returns the current time - the webhook secret grace periods are checked against it
+	now func() time.Time
 }
 
 // NewService creates a new gitlab organization service
-func NewService(repo RepositoryInterface, v2GitRepoService repositories.ServiceInterface, claGroupRepository projects_cla_groups.Repository, storeRepo store.Repository, userService users.Service, signaturesRepo signatures.SignatureRepository, companyRepository company.IRepository) ServiceInterface {
+func NewService(repo RepositoryInterface, v2GitRepoService repositories.ServiceInterface, claGroupRepository projects_cla_groups.Repository, storeRepo store.Repository, userService users.Service, signaturesRepo signatures.SignatureRepository, companyRepository company.IRepository, instanceService gitlab_instances.ServiceInterface) ServiceInterface {
 	return &Service{
 		repo:               repo,
 		v2GitRepoService:   v2GitRepoService,
@@ -88,6 +103,8 @@ func NewService(repo RepositoryInterface, v2GitRepoService repositories.ServiceI
 		storeRepo:          storeRepo,
 		signatureRepo:      signaturesRepo,
 		companyRepository:  companyRepository,
+		instanceService:    instanceService,
+		now:                time.Now,
 	}
 }
 
@@ -102,6 +119,19 @@ func (s *Service) AddGitLabOrganization(ctx context.Context, input *common.GitLa
 		"branchProtectionEnabled": input.BranchProtectionEnabled,
 		"groupID":                 input.ExternalGroupID,
 		"groupFullPath":           input.OrganizationFullPath,
+		"instanceID":              input.InstanceID,
+	}
+
+	if gitlabApi.IsDefaultInstanceID(input.InstanceID) {
+		input.InstanceID = ""
+	} else if _, instanceErr := s.instanceService.GetGitLabInstance(ctx, input.InstanceID); instanceErr != nil {
+		log.WithFields(f).WithError(instanceErr).Warnf("problem loading GitLab instance: %s", input.InstanceID)
+		return nil, instanceErr
+	}
+
+	if err := s.validateSubgroupOverrides(ctx, input.OrganizationFullPath, input.Subgroups); err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid subgroup overrides")
+		return nil, err
 	}
 
 	var existingModel *v2Models.GitlabOrganization
@@ -123,6 +153,13 @@ func (s *Service) AddGitLabOrganization(ctx context.Context, input *common.GitLa
 
 	// If we have an existing record/entry
 	if existingModel != nil {
+		// The group paths and IDs are only unique within a GitLab instance
+		if existingModel.InstanceID != input.InstanceID {
+			msg := fmt.Sprintf("unable to add the GitLab Group/Organization - already registered on the GitLab instance: %s", instanceName(existingModel.InstanceID))
+			log.WithFields(f).Warn(msg)
+			return nil, errors.New(msg)
+		}
+
 		// Check to make sure another project doesn't own this GitLab Group - only care about conflicts if it is enabled
 		if existingModel.ProjectSfid != input.ProjectSFID && existingModel.Enabled {
 			psc := projectService.GetClient()
@@ -159,9 +196,26 @@ func (s *Service) AddGitLabOrganization(ctx context.Context, input *common.GitLa
 			log.WithFields(f).WithError(updateErr).Warnf("problem updating GitLab group/organization, error: %+v", updateErr)
 			return nil, getErr
 		}
+		if len(input.Subgroups) > 0 {
+			if subgroupsErr := s.SetSubgroupOverrides(ctx, existingModel.OrganizationID, input.Subgroups); subgroupsErr != nil {
+				log.WithFields(f).WithError(subgroupsErr).Warn("problem enrolling the subgroup overrides")
+				return nil, subgroupsErr
+			}
+		}
 		return s.GetGitLabOrganizationsByProjectSFID(ctx, input.ProjectSFID)
 	}
 
+	webhookSecret, err := gitlabApi.NewSecret()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem generating the webhook secret")
+		return nil, err
+	}
+	input.WebhookSecret, err = gitlabApi.EncryptSecret(webhookSecret, s.gitLabApp)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem encrypting the webhook secret")
+		return nil, err
+	}
+
 	log.WithFields(f).Debug("adding GitLab organization...")
 	resp, err := s.repo.AddGitLabOrganization(ctx, input, true)
 	if err != nil {
@@ -170,6 +224,13 @@ func (s *Service) AddGitLabOrganization(ctx context.Context, input *common.GitLa
 	}
 	log.WithFields(f).Debugf("created GitLab organization with ID: %s", resp.OrganizationID)
 
+	if len(input.Subgroups) > 0 {
+		if err = s.SetSubgroupOverrides(ctx, resp.OrganizationID, input.Subgroups); err != nil {
+			log.WithFields(f).WithError(err).Warn("problem enrolling the subgroup overrides")
+			return nil, err
+		}
+	}
+
 	return s.GetGitLabOrganizationsByProjectSFID(ctx, input.ProjectSFID)
 }
 
@@ -342,12 +403,7 @@ func (s *Service) GetGitLabGroupMembers(ctx context.Context, groupID string) (*v
 	}
 
 	if gitlabOrg != nil {
-		oauthResponse, err := s.RefreshGitLabOrganizationAuth(ctx, common.ToCommonModel(gitlabOrg))
-		if err != nil {
-			log.WithFields(f).WithError(err).Warn("unable to refresh gitlab auth")
-			return nil, err
-		}
-		glClient, clientErr := gitlabApi.NewGitlabOauthClient(*oauthResponse, s.gitLabApp)
+		glClient, clientErr := s.GetGitLabOrganizationClient(ctx, common.ToCommonModel(gitlabOrg))
 		if clientErr != nil {
 			log.WithFields(f).WithError(clientErr).Warn("problem getting gitLabClient")
 			return nil, clientErr
@@ -455,6 +511,12 @@ func (s *Service) RefreshGitLabOrganizationAuth(ctx context.Context, gitLabOrg *
 	var gitLabAuthResponse string
 	var err error
 
+	instance, err := s.GetGitLabInstance(ctx, gitLabOrg.InstanceID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem loading GitLab instance: %s", gitLabOrg.InstanceID)
+		return nil, err
+	}
+
 	// decrypt oauthResponse
 	decryptedOauthResponse, err := gitlabApi.DecryptAuthInfo(gitLabOrg.AuthInfo, s.gitLabApp)
 	if err != nil {
@@ -471,7 +533,7 @@ func (s *Service) RefreshGitLabOrganizationAuth(ctx context.Context, gitLabOrg *
 	// If the current time (minus a small buffer/window) is AFTER the expiration time, refresh the token
 	if gitLabOrg.AuthExpirationTime == 0 || time.Now().Add(timeBuffer).After(expireTime) {
 		log.WithFields(f).Debugf("refreshing gitlab auth token - now + buffer: %v - expiration: %v", time.Now().Add(timeBuffer), expireTime)
-		refreshOauthResponse, err := gitlabApi.RefreshOauthToken(decryptedOauthResponse.RefreshToken)
+		refreshOauthResponse, err := gitlabApi.RefreshOauthToken(instance, decryptedOauthResponse.RefreshToken)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warn("problem refreshing token")
 			return nil, err
@@ -503,6 +565,32 @@ func (s *Service) RefreshGitLabOrganizationAuth(ctx context.Context, gitLabOrg *
 	return &gitLabAuthResponse, nil
 }
 
+// GetGitLabOrganizationClient returns the GitLab client of the organization for its GitLab instance, refreshing the
+// auth token when expired
+func (s *Service) GetGitLabOrganizationClient(ctx context.Context, gitLabOrg *common.GitLabOrganization) (*goGitLab.Client, error) {
+	oauthResponse, err := s.RefreshGitLabOrganizationAuth(ctx, gitLabOrg)
+	if err != nil {
+		return nil, fmt.Errorf("refreshing gitlab org auth info failed : %v", err)
+	}
+
+	instance, err := s.GetGitLabInstance(ctx, gitLabOrg.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	gitLabClient, err := gitlabApi.NewGitlabOauthClient(*oauthResponse, s.gitLabApp, instance)
+	if err != nil {
+		return nil, fmt.Errorf("initializing gitlab client : %v", err)
+	}
+	return gitLabClient, nil
+}
+
+// GetGitLabInstance returns the GitLab instance of the GitLab organizations with the given instance ID, gitlab.com
+// when empty
+func (s *Service) GetGitLabInstance(ctx context.Context, instanceID string) (*gitlabApi.Instance, error) {
+	return s.instanceService.GetInstance(ctx, instanceID)
+}
+
 func (s *Service) toGitLabProjectOrganizationList(ctx context.Context, dbModels *v2Models.GitlabOrganizations) []*v2Models.GitlabProjectOrganization {
 	f := logrus.Fields{
 		"functionName":   "v2.gitlab_organizations.service.toGitLabProjectOrganizationList",
@@ -544,6 +632,11 @@ func (s *Service) toGitLabProjectOrganizationList(ctx context.Context, dbModels
 			}
 		}
 
+		instance, instanceErr := s.GetGitLabInstance(ctx, org.InstanceID)
+		if instanceErr != nil {
+			log.WithFields(f).WithError(instanceErr).Warnf("problem loading GitLab instance: %s for gitlab org: %s", org.InstanceID, org.OrganizationID)
+		}
+
 		rorg := &v2Models.GitlabProjectOrganization{
 			AutoEnabled:             org.AutoEnabled,
 			AutoEnableClaGroupID:    org.AutoEnabledClaGroupID,
@@ -554,14 +647,19 @@ func (s *Service) toGitLabProjectOrganizationList(ctx context.Context, dbModels
 			OrganizationURL:         org.OrganizationURL,
 			OrganizationFullPath:    org.OrganizationFullPath,
 			OrganizationExternalID:  org.OrganizationExternalID,
-			InstallationURL:         buildInstallationURL(org.OrganizationID, orgDetailed.AuthState),
+			InstallationURL:         buildInstallationURL(org.OrganizationID, orgDetailed.AuthState, instance),
+			InstanceID:              org.InstanceID,
 			BranchProtectionEnabled: org.BranchProtectionEnabled,
 			ConnectionStatus:        "",                                    // updated below
 			Repositories:            []*v2Models.GitlabProjectRepository{}, // updated below
+			Subgroups:               []*v2Models.GitlabProjectSubgroup{},   // updated below
 		}
 
 		if orgDetailed.AuthInfo == "" {
 			rorg.ConnectionStatus = utils.NoConnection
+		} else if instanceErr != nil {
+			rorg.ConnectionStatus = utils.ConnectionFailure
+			rorg.ConnectionStatusMessage = instanceErr.Error()
 		} else {
 			if repoErr != nil {
 				log.WithFields(f).Warnf("initializing gitlab client for gitlab org: %s failed : %v", org.OrganizationID, repoErr)
@@ -580,7 +678,7 @@ func (s *Service) toGitLabProjectOrganizationList(ctx context.Context, dbModels
 					rorg.ConnectionStatusMessage = "Connected"
 				}
 
-				glClient, clientErr := gitlabApi.NewGitlabOauthClient(*oauthResponse, s.gitLabApp)
+				glClient, clientErr := gitlabApi.NewGitlabOauthClient(*oauthResponse, s.gitLabApp, instance)
 				if clientErr != nil {
 					log.WithFields(f).Warnf("using gitlab client for gitlab group id: %d, internal group/org ID: %s failed: %v", org.OrganizationExternalID, org.OrganizationID, clientErr)
 					rorg.ConnectionStatus = utils.ConnectionFailure
@@ -601,6 +699,10 @@ func (s *Service) toGitLabProjectOrganizationList(ctx context.Context, dbModels
 			}
 		}
 
+		if len(orgDetailed.Subgroups) > 0 {
+			rorg.Subgroups = toGitLabProjectSubgroups(orgDetailed, rorg.Repositories)
+		}
+
 		orgMap[org.OrganizationName] = rorg
 		response = append(response, rorg)
 	}
@@ -659,8 +761,13 @@ func (s *Service) UpdateGitLabOrganizationAuth(ctx context.Context, gitLabOrgani
 		return fmt.Errorf("gitlab organization lookup error: %+v", err)
 	}
 
+	instance, err := s.GetGitLabInstance(ctx, gitLabOrgModel.InstanceID)
+	if err != nil {
+		return fmt.Errorf("gitlab instance lookup error: %+v", err)
+	}
+
 	// Get a reference to the GitLab client
-	gitLabClient, err := gitlabApi.NewGitlabOauthClientFromAccessToken(oauthResp.AccessToken)
+	gitLabClient, err := gitlabApi.NewGitlabOauthClientFromAccessToken(oauthResp.AccessToken, instance)
 	if err != nil {
 		return fmt.Errorf("initializing gitlab client : %v", err)
 	}
@@ -930,14 +1037,16 @@ func (s *Service) getOrCreateUser(ctx context.Context, gitlabClient *goGitLab.Cl
 	return claUser, nil
 }
 
-func buildInstallationURL(gitlabOrgID string, authStateNonce string) *strfmt.URI {
-	base := "https://gitlab.com/oauth/authorize"
-	c := config.GetConfig()
+func buildInstallationURL(gitlabOrgID string, authStateNonce string, instance *gitlabApi.Instance) *strfmt.URI {
+	if instance == nil {
+		instance = gitlabApi.DefaultInstance()
+	}
+	base := instance.OauthAuthorizeURL()
 	state := fmt.Sprintf("%s:%s", gitlabOrgID, authStateNonce)
 
 	params := url.Values{}
-	params.Add("client_id", c.Gitlab.AppClientID)
-	params.Add("redirect_uri", c.Gitlab.RedirectURI)
+	params.Add("client_id", instance.AppClientID)
+	params.Add("redirect_uri", instance.RedirectURI)
 	//params.Add("redirect_uri", "http://localhost:8080/v4/gitlab/oauth/callback")
 	params.Add("response_type", "code")
 	params.Add("state", state)
@@ -1058,3 +1167,11 @@ func (s *Service) updateRepositoryStatus(glClient *goGitLab.Client, gitLabProjec
 
 	return gitLabProjectRepos
 }
+
+// instanceName returns the name of the GitLab instance for the messages
+func instanceName(instanceID string) string {
+	if gitlabApi.IsDefaultInstanceID(instanceID) {
+		return gitlabApi.DefaultInstanceID
+	}
+	return instanceID
+}
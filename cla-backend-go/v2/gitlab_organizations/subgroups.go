@@ -0,0 +1,236 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	gitlabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/sirupsen/logrus"
+	goGitLab "github.com/xanzy/go-gitlab"
+)
+
+// ErrGitLabSubgroupNotFound is returned when the subgroup isn't under the GitLab group of the organization
+var ErrGitLabSubgroupNotFound = errors.New("gitlab subgroup not found")
+
+// validateSubgroupOverrides checks the subgroup overrides identify a subgroup under the organization group and use
+// existing CLA Groups
+func (s *Service) validateSubgroupOverrides(ctx context.Context, organizationFullPath string, overrides []*common.GitLabSubgroup) error {
+	for _, override := range overrides {
+		if override.GroupID == 0 && override.FullPath == "" {
+			return errors.New("the subgroup group ID or full path is required")
+		}
+		if override.FullPath != "" && organizationFullPath != "" &&
+			!strings.HasPrefix(strings.ToLower(override.FullPath), strings.ToLower(organizationFullPath)+"/") {
+			return fmt.Errorf("the subgroup %s is not under the GitLab group %s", override.FullPath, organizationFullPath)
+		}
+		if override.ClaGroupID != "" {
+			if _, err := s.claGroupRepository.GetCLAGroupNameByID(ctx, override.ClaGroupID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SetSubgroupOverrides enrolls the subgroup overrides with the GitLab organization, the subgroups are identified by
+// their group ID or full path. The overrides of the subgroups not known yet are completed by the next subgroup refresh.
+func (s *Service) SetSubgroupOverrides(ctx context.Context, gitLabOrganizationID string, overrides []*common.GitLabSubgroup) error {
+	f := logrus.Fields{
+		"functionName":         "v2.gitlab_organizations.subgroups.SetSubgroupOverrides",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"gitLabOrganizationID": gitLabOrganizationID,
+		"overrides":            len(overrides),
+	}
+
+	gitLabOrg, err := s.repo.GetGitLabOrganization(ctx, gitLabOrganizationID)
+	if err != nil {
+		return err
+	}
+	if err = s.validateSubgroupOverrides(ctx, gitLabOrg.OrganizationFullPath, overrides); err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid subgroup overrides")
+		return err
+	}
+
+	_, now := utils.CurrentTime()
+	for _, override := range overrides {
+		subgroup := findSubgroup(gitLabOrg.Subgroups, override.GroupID, override.FullPath)
+		if subgroup == nil {
+			subgroup = &common.GitLabSubgroup{GroupID: override.GroupID, FullPath: override.FullPath}
+			gitLabOrg.Subgroups = append(gitLabOrg.Subgroups, subgroup)
+		}
+		subgroup.ClaGroupID, subgroup.AutoEnabled, subgroup.DateModified = override.ClaGroupID, override.AutoEnabled, now
+	}
+
+	log.WithFields(f).Debug("storing the subgroup overrides...")
+	return s.repo.UpdateGitLabOrganizationSubgroups(ctx, gitLabOrg.OrganizationID, gitLabOrg.Subgroups)
+}
+
+// SyncSubgroups refreshes the subgroups of the GitLab organization from GitLab, keeping their overrides, and returns
+// the changes since the last refresh. The subgroups of the organization are updated in place.
+func (s *Service) SyncSubgroups(ctx context.Context, gitLabOrg *common.GitLabOrganization, gitLabClient *goGitLab.Client) ([]*common.GitLabSubgroupChange, error) {
+	f := logrus.Fields{
+		"functionName":         "v2.gitlab_organizations.subgroups.SyncSubgroups",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"gitLabOrganizationID": gitLabOrg.OrganizationID,
+		"gitLabGroupID":        gitLabOrg.ExternalGroupID,
+	}
+
+	groups, err := gitlabApi.GetDescendantGroupsByGroupID(ctx, gitLabClient, gitLabOrg.ExternalGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the subgroups of the gitlab group")
+		return nil, err
+	}
+
+	current := make([]*common.GitLabSubgroup, 0, len(groups))
+	for _, group := range groups {
+		current = append(current, &common.GitLabSubgroup{
+			GroupID:       int64(group.ID),
+			ParentGroupID: int64(group.ParentID),
+			Name:          group.Name,
+			FullPath:      group.FullPath,
+		})
+	}
+
+	merged, changes := common.MergeSubgroups(gitLabOrg.Subgroups, current)
+	if reflect.DeepEqual(merged, gitLabOrg.Subgroups) {
+		log.WithFields(f).Debugf("%d subgroups unchanged", len(merged))
+		return changes, nil
+	}
+
+	log.WithFields(f).Debugf("storing %d subgroups - %d changes", len(merged), len(changes))
+	if err = s.repo.UpdateGitLabOrganizationSubgroups(ctx, gitLabOrg.OrganizationID, merged); err != nil {
+		return nil, err
+	}
+	gitLabOrg.Subgroups = merged
+	return changes, nil
+}
+
+// UpdateSubgroupOverride replaces the overrides of the subgroup of the GitLab group of the project, the subgroups are
+// refreshed from GitLab when the subgroup isn't known yet. An empty CLA Group ID or a nil auto-enabled flag removes
+// the override, the setting is then inherited.
+func (s *Service) UpdateSubgroupOverride(ctx context.Context, projectSFID string, gitLabGroupID, subgroupID int64, claGroupID string, autoEnabled *bool) (*common.GitLabOrganization, *common.GitLabSubgroup, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_organizations.subgroups.UpdateSubgroupOverride",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"gitLabGroupID":  gitLabGroupID,
+		"subgroupID":     subgroupID,
+		"claGroupID":     claGroupID,
+	}
+
+	gitLabOrg, err := s.repo.GetGitLabOrganizationByExternalID(ctx, gitLabGroupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if gitLabOrg == nil || gitLabOrg.ProjectSFID != projectSFID {
+		return nil, nil, ErrGitLabOrganizationNotFound
+	}
+	f["gitLabOrganizationID"] = gitLabOrg.OrganizationID
+
+	if claGroupID != "" {
+		if _, err = s.claGroupRepository.GetCLAGroupNameByID(ctx, claGroupID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	subgroup := gitLabOrg.GetSubgroup(subgroupID)
+	if subgroup == nil || subgroup.FullPath == "" {
+		log.WithFields(f).Debug("subgroup not known yet, refreshing the subgroups of the gitlab group...")
+		gitLabClient, clientErr := s.GetGitLabOrganizationClient(ctx, gitLabOrg)
+		if clientErr != nil {
+			log.WithFields(f).WithError(clientErr).Warn("problem initializing the gitlab client")
+			return nil, nil, clientErr
+		}
+		if _, err = s.SyncSubgroups(ctx, gitLabOrg, gitLabClient); err != nil {
+			return nil, nil, err
+		}
+		subgroup = gitLabOrg.GetSubgroup(subgroupID)
+		if subgroup == nil {
+			return nil, nil, ErrGitLabSubgroupNotFound
+		}
+	}
+
+	_, subgroup.DateModified = utils.CurrentTime()
+	subgroup.ClaGroupID, subgroup.AutoEnabled = claGroupID, autoEnabled
+	if err = s.repo.UpdateGitLabOrganizationSubgroups(ctx, gitLabOrg.OrganizationID, gitLabOrg.Subgroups); err != nil {
+		return nil, nil, err
+	}
+
+	return gitLabOrg, subgroup, nil
+}
+
+// findSubgroup returns the subgroup with the group ID, or with the full path when the group ID isn't set
+func findSubgroup(subgroups []*common.GitLabSubgroup, groupID int64, fullPath string) *common.GitLabSubgroup {
+	for _, subgroup := range subgroups {
+		if groupID != 0 && subgroup.GroupID == groupID {
+			return subgroup
+		}
+		if groupID == 0 && fullPath != "" && strings.EqualFold(subgroup.FullPath, fullPath) {
+			return subgroup
+		}
+	}
+	return nil
+}
+
+// toGitLabProjectSubgroups converts the subgroups of the organization to the subgroup hierarchy, each subgroup with
+// the repositories directly under it and its effective settings
+func toGitLabProjectSubgroups(gitLabOrg *common.GitLabOrganization, repositories []*v2Models.GitlabProjectRepository) []*v2Models.GitlabProjectSubgroup {
+	repositoriesByPath := make(map[string][]*v2Models.GitlabProjectRepository)
+	for _, repository := range repositories {
+		parentPath := strings.ToLower(path.Dir(repository.RepositoryFullPath))
+		repositoriesByPath[parentPath] = append(repositoriesByPath[parentPath], repository)
+	}
+
+	nodes := make(map[int64]*v2Models.GitlabProjectSubgroup)
+	var response []*v2Models.GitlabProjectSubgroup
+	for _, subgroup := range gitLabOrg.Subgroups {
+		settings := gitLabOrg.SubgroupSettings(subgroup.GroupID)
+		node := &v2Models.GitlabProjectSubgroup{
+			GroupID:              subgroup.GroupID,
+			ParentGroupID:        subgroup.ParentGroupID,
+			Name:                 subgroup.Name,
+			FullPath:             subgroup.FullPath,
+			ClaGroupID:           subgroup.ClaGroupID,
+			AutoEnabled:          subgroup.AutoEnabled,
+			EffectiveClaGroupID:  settings.ClaGroupID,
+			EffectiveAutoEnabled: settings.AutoEnabled,
+			Subgroups:            []*v2Models.GitlabProjectSubgroup{},
+			Repositories:         repositoriesByPath[strings.ToLower(subgroup.FullPath)],
+		}
+		if subgroup.GroupID != 0 {
+			nodes[subgroup.GroupID] = node
+		}
+		response = append(response, node)
+	}
+
+	// the subgroups are attached to their parent, the subgroups directly under the organization group and the
+	// subgroups not refreshed yet are listed at the top
+	var roots []*v2Models.GitlabProjectSubgroup
+	for _, node := range response {
+		parent, ok := nodes[node.ParentGroupID]
+		if !ok || parent == node {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Subgroups = append(parent.Subgroups, node)
+	}
+
+	for _, node := range response {
+		sort.Slice(node.Repositories, func(i, j int) bool {
+			return strings.ToLower(node.Repositories[i].RepositoryName) < strings.ToLower(node.Repositories[j].RepositoryName)
+		})
+	}
+	return roots
+}
@@ -0,0 +1,304 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_organizations
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	gitlabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/sirupsen/logrus"
+	goGitLab "github.com/xanzy/go-gitlab"
+)
+
+// webhook secret rotation grace periods
+const (
+	// DefaultWebhookSecretGracePeriod is the time the previous webhook secret is still accepted after a rotation
+	DefaultWebhookSecretGracePeriod = 24 * time.Hour
+	// MaxWebhookSecretGracePeriod is the longest grace period of a rotation
+	MaxWebhookSecretGracePeriod = 7 * 24 * time.Hour
+)
+
+var (
+	// ErrWebhookSecretMismatch is returned when the secret token of a webhook event matches none of the secrets of
+	// the GitLab organization
+	ErrWebhookSecretMismatch = errors.New("webhook secret token mismatch")
+	// ErrGitLabOrganizationNotFound is returned when the GitLab group isn't registered for the project
+	ErrGitLabOrganizationNotFound = errors.New("gitlab group/organization not found")
+)
+
+// WebhookSecretRotation is the result of the rotation of the webhook secret of a GitLab organization
+type WebhookSecretRotation struct {
+	Organization *common.GitLabOrganization
+	// PreviousSecretExpiry is the end of the grace period, the previous secret is accepted until then
+	PreviousSecretExpiry time.Time
+	// ProjectsUpdated are the GitLab project IDs of the webhooks updated with the new secret
+	ProjectsUpdated []int64
+	// ProjectsFailed are the GitLab project IDs of the webhooks which couldn't be updated, their events are rejected
+	// after the grace period
+	ProjectsFailed []int64
+}
+
+// GetWebhookSecret returns the decrypted secret token of the project webhooks of the GitLab organization. The
+// organizations registered before the per-organization secrets use the secret of their GitLab instance, or their
+// auth state, until their first rotation.
+func (s *Service) GetWebhookSecret(ctx context.Context, gitLabOrg *common.GitLabOrganization) (string, error) {
+	if gitLabOrg.WebhookSecret != "" {
+		return gitlabApi.DecryptSecret(gitLabOrg.WebhookSecret, s.gitLabApp)
+	}
+
+	instance, err := s.GetGitLabInstance(ctx, gitLabOrg.InstanceID)
+	if err != nil {
+		return "", err
+	}
+	return instance.WebhookToken(gitLabOrg.AuthState), nil
+}
+
+// ValidateWebhookSecret checks the secret token of a webhook event of the GitLab organization against its current
+// secret, and against the previous secret during the grace period of the last rotation
+func (s *Service) ValidateWebhookSecret(ctx context.Context, gitLabOrganizationID, token string) error {
+	return s.validateWebhookSecret(ctx, gitLabOrganizationID, token, false)
+}
+
+// ValidateGroupHookSecret checks the secret token of a group hook event of the GitLab organization like
+// ValidateWebhookSecret, the token is enforced for the organizations whose secret was never rotated too - the group
+// hooks are always registered with the secret returned by GetWebhookSecret.
+func (s *Service) ValidateGroupHookSecret(ctx context.Context, gitLabOrganizationID, token string) error {
+	return s.validateWebhookSecret(ctx, gitLabOrganizationID, token, true)
+}
+
+// validateWebhookSecret checks the secret token of a webhook event of the GitLab organization, the token of the
+// organizations without a rotated secret is only enforced when requested
+func (s *Service) validateWebhookSecret(ctx context.Context, gitLabOrganizationID, token string, enforce bool) error {
+	f := logrus.Fields{
+		"functionName":         "v2.gitlab_organizations.webhook_secret.validateWebhookSecret",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"gitLabOrganizationID": gitLabOrganizationID,
+		"enforce":              enforce,
+	}
+
+	gitLabOrg, err := s.repo.GetGitLabOrganization(ctx, gitLabOrganizationID)
+	if err != nil {
+		return err
+	}
+
+	if gitLabOrg.WebhookSecret == "" {
+		expected, secretErr := s.GetWebhookSecret(ctx, gitLabOrg)
+		if secretErr == nil && secretMatches(expected, token) {
+			return nil
+		}
+		if enforce {
+			log.WithFields(f).WithError(secretErr).Warn("webhook secret token mismatch")
+			if secretErr != nil {
+				return secretErr
+			}
+			return ErrWebhookSecretMismatch
+		}
+		// the hooks of the organizations without a secret were registered with different tokens over time - the token
+		// is only enforced once the secret was rotated, which updates all the hooks
+		log.WithFields(f).WithError(secretErr).Warn("webhook secret token mismatch - not enforced until the webhook secret of the gitlab organization is rotated")
+		return nil
+	}
+
+	current, err := gitlabApi.DecryptSecret(gitLabOrg.WebhookSecret, s.gitLabApp)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem decrypting the webhook secret")
+		return err
+	}
+	if secretMatches(current, token) {
+		return nil
+	}
+
+	if gitLabOrg.PreviousWebhookSecret != "" && s.currentTime().Before(time.Unix(gitLabOrg.PreviousWebhookSecretExpiry, 0)) {
+		previous, err := gitlabApi.DecryptSecret(gitLabOrg.PreviousWebhookSecret, s.gitLabApp)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decrypting the previous webhook secret")
+			return err
+		}
+		if secretMatches(previous, token) {
+			log.WithFields(f).Debug("webhook event signed with the previous webhook secret during the rotation grace period")
+			return nil
+		}
+	}
+
+	return ErrWebhookSecretMismatch
+}
+
+// ValidateStatusCheckSignature checks the HMAC signature of an external status check callback of the GitLab
+// organization against its current webhook secret, and against the previous secret during the grace period of the
+// last rotation. The external status checks are registered with the webhook secret as shared secret, the signature
+// is always enforced.
+func (s *Service) ValidateStatusCheckSignature(ctx context.Context, gitLabOrganizationID string, payload []byte, signature string) error {
+	f := logrus.Fields{
+		"functionName":         "v2.gitlab_organizations.webhook_secret.ValidateStatusCheckSignature",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"gitLabOrganizationID": gitLabOrganizationID,
+	}
+
+	gitLabOrg, err := s.repo.GetGitLabOrganization(ctx, gitLabOrganizationID)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.GetWebhookSecret(ctx, gitLabOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the webhook secret")
+		return err
+	}
+	if gitlabApi.ValidExternalStatusCheckSignature(current, payload, signature) {
+		return nil
+	}
+
+	if gitLabOrg.PreviousWebhookSecret != "" && s.currentTime().Before(time.Unix(gitLabOrg.PreviousWebhookSecretExpiry, 0)) {
+		previous, err := gitlabApi.DecryptSecret(gitLabOrg.PreviousWebhookSecret, s.gitLabApp)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decrypting the previous webhook secret")
+			return err
+		}
+		if gitlabApi.ValidExternalStatusCheckSignature(previous, payload, signature) {
+			log.WithFields(f).Debug("status check callback signed with the previous webhook secret during the rotation grace period")
+			return nil
+		}
+	}
+
+	return ErrWebhookSecretMismatch
+}
+
+// RotateWebhookSecret generates a new webhook secret for the GitLab group of the project and updates the hooks of all
+// the enabled projects of the group. The previous secret is accepted for the grace period, so the events sent while
+// the hooks are updated aren't rejected.
+func (s *Service) RotateWebhookSecret(ctx context.Context, projectSFID string, gitLabGroupID int64, gracePeriod time.Duration) (*WebhookSecretRotation, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab_organizations.webhook_secret.RotateWebhookSecret",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"gitLabGroupID":  gitLabGroupID,
+		"gracePeriod":    gracePeriod.String(),
+	}
+
+	if gracePeriod < 0 || gracePeriod > MaxWebhookSecretGracePeriod {
+		return nil, fmt.Errorf("the grace period must be between 0 and %s", MaxWebhookSecretGracePeriod)
+	}
+
+	gitLabOrg, err := s.repo.GetGitLabOrganizationByExternalID(ctx, gitLabGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if gitLabOrg == nil || gitLabOrg.ProjectSFID != projectSFID {
+		return nil, ErrGitLabOrganizationNotFound
+	}
+	f["gitLabOrganizationID"] = gitLabOrg.OrganizationID
+
+	gitLabClient, err := s.GetGitLabOrganizationClient(ctx, gitLabOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem initializing the gitlab client")
+		return nil, err
+	}
+
+	// the secret the hooks are currently registered with becomes the previous secret, for the legacy organizations too
+	previous, err := s.GetWebhookSecret(ctx, gitLabOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the current webhook secret")
+		return nil, err
+	}
+	encryptedPrevious, err := gitlabApi.EncryptSecret(previous, s.gitLabApp)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := gitlabApi.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	encryptedSecret, err := gitlabApi.EncryptSecret(secret, s.gitLabApp)
+	if err != nil {
+		return nil, err
+	}
+
+	// the secret is stored before updating the hooks, the events signed with the new secret are accepted right away
+	previousSecretExpiry := s.currentTime().Add(gracePeriod)
+	err = s.repo.UpdateGitLabOrganizationWebhookSecret(ctx, gitLabOrg.OrganizationID, encryptedSecret, encryptedPrevious, previousSecretExpiry.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := &WebhookSecretRotation{
+		Organization:         gitLabOrg,
+		PreviousSecretExpiry: previousSecretExpiry,
+	}
+
+	repos, err := s.v2GitRepoService.GitLabGetRepositoriesByOrganizationName(ctx, gitLabOrg.OrganizationFullPath)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the gitlab repositories of the group")
+		return nil, err
+	}
+
+	hookURL := config.GetConfig().Gitlab.WebHookURI
+	for _, repo := range repos.List {
+		if !repo.Enabled {
+			continue
+		}
+		if hookErr := gitlabApi.SetWebHookToken(gitLabClient, hookURL, int(repo.RepositoryExternalID), secret); hookErr != nil {
+			log.WithFields(f).WithError(hookErr).Warnf("problem updating the webhook secret of the gitlab project: %d", repo.RepositoryExternalID)
+			rotation.ProjectsFailed = append(rotation.ProjectsFailed, repo.RepositoryExternalID)
+			continue
+		}
+		// the external status check callbacks are signed with the same secret
+		if _, statusCheckErr := gitlabApi.SetExternalStatusCheck(gitLabClient, int(repo.RepositoryExternalID), gitlabApi.ExternalStatusCheckURL(config.GetConfig().ClaAPIV4Base), secret); statusCheckErr != nil && !errors.Is(statusCheckErr, gitlabApi.ErrExternalStatusChecksUnsupported) {
+			log.WithFields(f).WithError(statusCheckErr).Warnf("problem updating the external status check secret of the gitlab project: %d", repo.RepositoryExternalID)
+			rotation.ProjectsFailed = append(rotation.ProjectsFailed, repo.RepositoryExternalID)
+			continue
+		}
+		rotation.ProjectsUpdated = append(rotation.ProjectsUpdated, repo.RepositoryExternalID)
+	}
+
+	// the group hook sending the project events uses the same secret
+	groupHookErr := gitlabApi.SetGroupWebHookToken(gitLabClient, gitlabApi.ProjectHookURL(config.GetConfig().ClaAPIV4Base), gitLabOrg.ExternalGroupID, secret)
+	if groupHookErr != nil && !errors.Is(groupHookErr, gitlabApi.ErrGroupHooksUnsupported) {
+		log.WithFields(f).WithError(groupHookErr).Warn("problem updating the webhook secret of the gitlab group hook")
+	}
+
+	log.WithFields(f).Debugf("rotated the webhook secret - updated %d gitlab projects, %d failed", len(rotation.ProjectsUpdated), len(rotation.ProjectsFailed))
+	return rotation, nil
+}
+
+// SetGroupHook registers the group hook sending the project and subgroup events of the GitLab organization group,
+// signed with the webhook secret of the organization. Returns gitlabApi.ErrGroupHooksUnsupported when the tier of the
+// group doesn't include the group hooks, the projects are then only discovered by the GitLab repository check.
+func (s *Service) SetGroupHook(ctx context.Context, gitLabOrg *common.GitLabOrganization, gitLabClient *goGitLab.Client) error {
+	f := logrus.Fields{
+		"functionName":         "v2.gitlab_organizations.webhook_secret.SetGroupHook",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"gitLabOrganizationID": gitLabOrg.OrganizationID,
+		"gitLabGroupID":        gitLabOrg.ExternalGroupID,
+	}
+
+	secret, err := s.GetWebhookSecret(ctx, gitLabOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading the webhook secret")
+		return err
+	}
+
+	return gitlabApi.SetGroupWebHook(gitLabClient, gitlabApi.ProjectHookURL(config.GetConfig().ClaAPIV4Base), gitLabOrg.ExternalGroupID, secret)
+}
+
+// currentTime returns the current time of the service clock
+func (s *Service) currentTime() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
+
+// secretMatches compares the secrets in constant time, an empty token never matches
+func secretMatches(secret, token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(token)) == 1
+}
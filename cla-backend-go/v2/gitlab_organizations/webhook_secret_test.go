@@ -0,0 +1,98 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_organizations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gitlabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_instances"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeWebhookSecretRepo struct {
+	RepositoryInterface
+	orgs map[string]*common.GitLabOrganization
+}
+
+func (r *fakeWebhookSecretRepo) GetGitLabOrganization(ctx context.Context, gitLabOrganizationID string) (*common.GitLabOrganization, error) {
+	return r.orgs[gitLabOrganizationID], nil
+}
+
+type fakeInstanceService struct {
+	gitlab_instances.ServiceInterface
+}
+
+func (s *fakeInstanceService) GetInstance(ctx context.Context, instanceID string) (*gitlabApi.Instance, error) {
+	// the gitlab.com instance has no secret, the hooks use the auth state of the organization
+	return nil, nil
+}
+
+func TestValidateWebhookSecret(t *testing.T) {
+	ctx := context.Background()
+	gitLabApp := gitlabApi.Init("124453345", "124453345", "0WqnDWHnZKo2cmQ8m93EtY9ZBpfzQW4UnnEuRmgtJKM=")
+	encrypt := func(secret string) string {
+		encrypted, err := gitlabApi.EncryptSecret(secret, gitLabApp)
+		assert.NoError(t, err)
+		return encrypted
+	}
+
+	rotatedOn := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	expiry := rotatedOn.Add(DefaultWebhookSecretGracePeriod)
+	repo := &fakeWebhookSecretRepo{orgs: map[string]*common.GitLabOrganization{
+		"rotated": {
+			OrganizationID:              "rotated",
+			AuthState:                   "auth-state",
+			WebhookSecret:               encrypt("new-secret"),
+			PreviousWebhookSecret:       encrypt("old-secret"),
+			PreviousWebhookSecretExpiry: expiry.Unix(),
+		},
+		"legacy": {
+			OrganizationID: "legacy",
+			AuthState:      "auth-state",
+		},
+	}}
+
+	testCases := []struct {
+		name        string
+		orgID       string
+		token       string
+		now         time.Time
+		groupHook   bool
+		expectedErr error
+	}{
+		{name: "new secret right after the rotation", orgID: "rotated", token: "new-secret", now: rotatedOn},
+		{name: "new secret after the grace window", orgID: "rotated", token: "new-secret", now: expiry.Add(30 * 24 * time.Hour)},
+		{name: "new secret of a group hook", orgID: "rotated", token: "new-secret", now: expiry.Add(time.Hour), groupHook: true},
+		{name: "old secret inside the grace window", orgID: "rotated", token: "old-secret", now: rotatedOn.Add(time.Hour)},
+		{name: "old secret at the end of the grace window", orgID: "rotated", token: "old-secret", now: expiry, expectedErr: ErrWebhookSecretMismatch},
+		{name: "old secret after the grace window", orgID: "rotated", token: "old-secret", now: expiry.Add(time.Second), expectedErr: ErrWebhookSecretMismatch},
+		{name: "auth state of a rotated organization", orgID: "rotated", token: "auth-state", now: rotatedOn, expectedErr: ErrWebhookSecretMismatch},
+		{name: "unknown secret", orgID: "rotated", token: "other-secret", now: rotatedOn, expectedErr: ErrWebhookSecretMismatch},
+		{name: "missing secret", orgID: "rotated", token: "", now: rotatedOn, expectedErr: ErrWebhookSecretMismatch},
+		{name: "never rotated organization with its secret", orgID: "legacy", token: "auth-state", now: rotatedOn},
+		{name: "never rotated organization skips the check", orgID: "legacy", token: "other-secret", now: rotatedOn},
+		{name: "never rotated organization without a secret skips the check", orgID: "legacy", token: "", now: rotatedOn},
+		{name: "group hook of a never rotated organization with its secret", orgID: "legacy", token: "auth-state", now: rotatedOn, groupHook: true},
+		{name: "group hook of a never rotated organization is enforced", orgID: "legacy", token: "other-secret", now: rotatedOn, groupHook: true, expectedErr: ErrWebhookSecretMismatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := tc.now
+			service := &Service{repo: repo, gitLabApp: gitLabApp, instanceService: &fakeInstanceService{}, now: func() time.Time { return now }}
+
+			var err error
+			if tc.groupHook {
+				err = service.ValidateGroupHookSecret(ctx, tc.orgID, tc.token)
+			} else {
+				err = service.ValidateWebhookSecret(ctx, tc.orgID, tc.token)
+			}
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
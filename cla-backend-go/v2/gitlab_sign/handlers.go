@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/communitybridge/easycla/cla-backend-go/config"
 	"github.com/communitybridge/easycla/cla-backend-go/events"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/gitlab_sign"
@@ -20,7 +19,6 @@ import (
 	"github.com/savaki/dynastore"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
-	oauth_gitlab "golang.org/x/oauth2/gitlab"
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 )
@@ -51,15 +49,24 @@ func Configure(api *operations.EasyclaAPI, service Service, eventService events.
 					http.Error(rw, err.Error(), http.StatusInternalServerError)
 					return
 				}
-				config := config.GetConfig()
-
 				log.WithFields(f).Debugf("Loading session : %+v", session)
 
+				instance, err := service.GetOrganizationInstance(ctx, srp.OrganizationID)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error loading the GitLab instance of the organization")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				// the token of the session is only valid on the GitLab instance it was issued by
+				sessionInstanceID, _ := session.Values["gitlab_instance_id"].(string)
+				sameInstance := sessionInstanceID == instance.InstanceID
+
 				log.WithFields(f).Debug("Initiating sign request..")
 
 				session.Values["gitlab_installation_id"] = srp.OrganizationID
 				session.Values["gitlab_repository_id"] = srp.GitlabRepositoryID
 				session.Values["gitlab_merge_request_id"] = srp.MergeRequestID
+				session.Values["gitlab_instance_id"] = instance.InstanceID
 
 				originURL, err := service.GetOriginURL(ctx, srp.OrganizationID, srp.GitlabRepositoryID, srp.MergeRequestID)
 				if err != nil {
@@ -71,15 +78,16 @@ func Configure(api *operations.EasyclaAPI, service Service, eventService events.
 				session.Values["gitlab_origin_url"] = *originURL
 
 				gitlabAuthToken, ok := session.Values["gitlab_oauth2_token"].(string)
-				if ok {
+				if ok && sameInstance {
 					session.Save(srp.HTTPRequest, rw)
-					log.WithFields(f).Debugf("using existing Gitlab Ouath2 Token: %s ", gitlabAuthToken)
-					gitlabClient, err := gitlabApi.NewGitlabOauthClientFromAccessToken(gitlabAuthToken)
+					log.WithFields(f).Debug("using existing Gitlab Ouath2 Token")
+					gitlabClient, err := gitlabApi.NewGitlabOauthClientFromAccessToken(gitlabAuthToken, instance)
 
 					if err != nil {
-						msg := fmt.Sprintf("problem creating gitlab client with token : %s ", gitlabAuthToken)
-						log.WithFields(f).Debug(msg)
+						msg := "problem creating gitlab client with the oauth token"
+						log.WithFields(f).WithError(err).Debug(msg)
 						http.Error(rw, msg, http.StatusInternalServerError)
+						return
 					}
 
 					log.WithFields(f).Debugf("Initiating Gitlab sign request for : %+v ", srp)
@@ -104,13 +112,16 @@ func Configure(api *operations.EasyclaAPI, service Service, eventService events.
 				session.Values["gitlab_oauth2_state"] = state
 				session.Save(srp.HTTPRequest, rw)
 				oauthConfig := &oauth2.Config{
-					ClientID: config.Gitlab.AppClientID,
+					ClientID: instance.AppClientID,
 					Scopes: []string{
 						"read_user",
 						"email",
 					},
-					Endpoint:    oauth_gitlab.Endpoint,
-					RedirectURL: config.Gitlab.RedirectURI,
+					Endpoint: oauth2.Endpoint{
+						AuthURL:  instance.OauthAuthorizeURL(),
+						TokenURL: instance.OauthTokenURL(),
+					},
+					RedirectURL: instance.RedirectURI,
 				}
 				session.Values["gitlab_oauth2_state"] = state
 				session.Save(srp.HTTPRequest, rw)
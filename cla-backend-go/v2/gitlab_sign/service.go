@@ -44,6 +44,7 @@ type service struct {
 type Service interface {
 	InitiateSignRequest(ctx context.Context, req *http.Request, gitlabClient *gitlab.Client, repositoryID, mergeRequestID, originURL, contributorBaseURL string, eventService events.Service) (*string, error)
 	GetOriginURL(ctx context.Context, organizationID, repositoryID, mergeRequestID string) (*string, error)
+	GetOrganizationInstance(ctx context.Context, organizationID string) (*gitlab_api.Instance, error)
 }
 
 func NewService(gitlabRepositoryService repositories.ServiceInterface, userService users.Service, storeRepo store.Repository, gitlabApp *gitlab_api.App, gitlabOrgService gitlab_organizations.ServiceInterface) Service {
@@ -74,13 +75,7 @@ func (s service) GetOriginURL(ctx context.Context, organizationID, repositoryID,
 		return nil, errors.New(msg)
 	}
 
-	oauthResponse, err := s.gitlabOrgService.RefreshGitLabOrganizationAuth(ctx, common.ToCommonModel(organization))
-	if err != nil {
-		log.WithFields(f).Debugf("unable to refresh gitlab organiztion auth by ID: %s, error: %+v ", organizationID, err)
-		return nil, err
-	}
-
-	gitlabClient, err := gitlab_api.NewGitlabOauthClient(*oauthResponse, s.gitlabApp)
+	gitlabClient, err := s.gitlabOrgService.GetGitLabOrganizationClient(ctx, common.ToCommonModel(organization))
 	if err != nil {
 		log.WithFields(f).Debugf("initializaing gitlab client for gitlab org: %s failed: %v", organizationID, err)
 		return nil, err
@@ -240,3 +235,15 @@ func (s service) getOrCreateUser(ctx context.Context, gitlabClient *gitlab.Clien
 
 	return claUser, nil
 }
+
+// GetOrganizationInstance returns the GitLab instance of the organization, the contributors sign in on the instance
+func (s service) GetOrganizationInstance(ctx context.Context, organizationID string) (*gitlab_api.Instance, error) {
+	organization, err := s.gitlabOrgService.GetGitLabOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return nil, fmt.Errorf("unable to locate gitlab organization by ID: %s", organizationID)
+	}
+	return s.gitlabOrgService.GetGitLabInstance(ctx, organization.InstanceID)
+}
@@ -29,6 +29,40 @@ func Configure(api *operations.EasyclaAPI, service v1Health.Service) {
 		}
 		return health.NewHealthCheckOK().WithXRequestID(reqID).WithPayload(&response)
 	})
+
+	api.HealthLivenessCheckHandler = health.LivenessCheckHandlerFunc(func(params health.LivenessCheckParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		result, err := service.LivenessCheck(params.HTTPRequest.Context())
+		if err != nil {
+			return health.NewLivenessCheckServiceUnavailable().WithXRequestID(reqID).WithPayload(&models.Health{Status: v1Health.StatusNotHealthy})
+		}
+		var response models.Health
+		err = copier.Copy(&response, result)
+		if err != nil {
+			return health.NewLivenessCheckServiceUnavailable().WithXRequestID(reqID).WithPayload(&models.Health{Status: v1Health.StatusNotHealthy})
+		}
+		if response.Status == v1Health.StatusNotHealthy {
+			return health.NewLivenessCheckServiceUnavailable().WithXRequestID(reqID).WithPayload(&response)
+		}
+		return health.NewLivenessCheckOK().WithXRequestID(reqID).WithPayload(&response)
+	})
+
+	api.HealthReadinessCheckHandler = health.ReadinessCheckHandlerFunc(func(params health.ReadinessCheckParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		result, err := service.ReadinessCheck(params.HTTPRequest.Context())
+		if err != nil {
+			return health.NewReadinessCheckServiceUnavailable().WithXRequestID(reqID).WithPayload(&models.Health{Status: v1Health.StatusNotHealthy})
+		}
+		var response models.Health
+		err = copier.Copy(&response, result)
+		if err != nil {
+			return health.NewReadinessCheckServiceUnavailable().WithXRequestID(reqID).WithPayload(&models.Health{Status: v1Health.StatusNotHealthy})
+		}
+		if response.Status == v1Health.StatusNotHealthy {
+			return health.NewReadinessCheckServiceUnavailable().WithXRequestID(reqID).WithPayload(&response)
+		}
+		return health.NewReadinessCheckOK().WithXRequestID(reqID).WithPayload(&response)
+	})
 }
 
 type codedResponse interface {
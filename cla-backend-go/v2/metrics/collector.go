@@ -0,0 +1,103 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// totalCountCacheDuration is how long the total count metrics are cached between scrapes, the metrics are only
+// recalculated once a day by the metrics lambda so there is no point in loading them on every scrape
+const totalCountCacheDuration = 5 * time.Minute
+
+// totalCountGauge describes a business gauge exposed from the total count metrics
+type totalCountGauge struct {
+	desc  *prometheus.Desc
+	value func(tcm *TotalCountMetrics) int64
+}
+
+// TotalCountCollector exposes the total count metrics as Prometheus gauges
+type TotalCountCollector struct {
+	repo     Repository
+	gauges   []totalCountGauge
+	lock     sync.Mutex
+	cached   *TotalCountMetrics
+	cachedAt time.Time
+}
+
+// NewTotalCountCollector creates a new Prometheus collector for the total count metrics
+func NewTotalCountCollector(repo Repository) *TotalCountCollector {
+	gauge := func(name, help string, value func(tcm *TotalCountMetrics) int64) totalCountGauge {
+		return totalCountGauge{
+			desc:  prometheus.NewDesc(prometheus.BuildFQName("easycla", "business", name), help, nil, nil),
+			value: value,
+		}
+	}
+
+	return &TotalCountCollector{
+		repo: repo,
+		gauges: []totalCountGauge{
+			gauge("corporate_contributors", "Number of corporate contributors.", func(tcm *TotalCountMetrics) int64 { return tcm.CorporateContributorsCount }),
+			gauge("individual_contributors", "Number of individual contributors.", func(tcm *TotalCountMetrics) int64 { return tcm.IndividualContributorsCount }),
+			gauge("contributors", "Number of contributors.", func(tcm *TotalCountMetrics) int64 { return tcm.ContributorsCount }),
+			gauge("cla_managers", "Number of CLA managers.", func(tcm *TotalCountMetrics) int64 { return tcm.ClaManagersCount }),
+			gauge("projects", "Number of projects.", func(tcm *TotalCountMetrics) int64 { return tcm.ProjectsCount }),
+			gauge("projects_live", "Number of live projects.", func(tcm *TotalCountMetrics) int64 { return tcm.ProjectsLiveCount }),
+			gauge("repositories", "Number of repositories.", func(tcm *TotalCountMetrics) int64 { return tcm.RepositoriesCount }),
+			gauge("github_repositories", "Number of GitHub repositories.", func(tcm *TotalCountMetrics) int64 { return tcm.GithubRepositoriesCount }),
+			gauge("github_repositories_enabled", "Number of enabled GitHub repositories.", func(tcm *TotalCountMetrics) int64 { return tcm.GithubRepositoriesEnabledCount }),
+			gauge("gerrit_repositories", "Number of Gerrit repositories.", func(tcm *TotalCountMetrics) int64 { return tcm.GerritRepositoriesCount }),
+			gauge("gerrit_repositories_enabled", "Number of enabled Gerrit repositories.", func(tcm *TotalCountMetrics) int64 { return tcm.GerritRepositoriesEnabledCount }),
+			gauge("companies", "Number of companies.", func(tcm *TotalCountMetrics) int64 { return tcm.CompaniesCount }),
+			gauge("companies_project_contribution", "Number of company project contributions.", func(tcm *TotalCountMetrics) int64 { return tcm.CompaniesProjectContributionCount }),
+			gauge("lf_members_clas", "Number of CLAs signed by LF members.", func(tcm *TotalCountMetrics) int64 { return tcm.LfMembersCLACount }),
+			gauge("non_lf_members_clas", "Number of CLAs signed by non LF members.", func(tcm *TotalCountMetrics) int64 { return tcm.NonLfMembersCLACount }),
+			gauge("clas_signed", "Number of CLAs signed.", func(tcm *TotalCountMetrics) int64 { return tcm.CLAsSignedCount }),
+		},
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *TotalCountCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, g := range c.gauges {
+		ch <- g.desc
+	}
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *TotalCountCollector) Collect(ch chan<- prometheus.Metric) {
+	tcm := c.load()
+	if tcm == nil {
+		return
+	}
+
+	for _, g := range c.gauges {
+		ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, float64(g.value(tcm)))
+	}
+}
+
+// load returns the cached total count metrics, reloading them when the cache expired
+func (c *TotalCountCollector) load() *TotalCountMetrics {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.cached != nil && time.Since(c.cachedAt) < totalCountCacheDuration {
+		return c.cached
+	}
+
+	tcm, err := c.repo.GetTotalCountMetrics()
+	if err != nil {
+		log.WithError(err).Warn("unable to load total count metrics for the Prometheus collector")
+		// Serve the stale value rather than dropping the gauges
+		return c.cached
+	}
+
+	c.cached = tcm
+	c.cachedAt = time.Now()
+	return c.cached
+}
@@ -15,12 +15,13 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/metrics"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Company.IRepository) {
+func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Company.IRepository, projectClaGroupsRepo projects_cla_groups.Repository) {
 	api.MetricsGetClaManagerDistributionHandler = metrics.GetClaManagerDistributionHandlerFunc(
 		func(params metrics.GetClaManagerDistributionParams, user *auth.User) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
@@ -126,6 +127,67 @@ func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Comp
 			}
 			return metrics.NewListCompanyProjectMetricsOK().WithXRequestID(reqID).WithPayload(result)
 		})
+
+	api.MetricsGetMetricsHistoryHandler = metrics.GetMetricsHistoryHandlerFunc(
+		func(params metrics.GetMetricsHistoryParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			scopeID := utils.StringValue(params.ScopeID)
+			f := logrus.Fields{
+				"functionName":   "MetricsGetMetricsHistoryHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"scopeType":      params.ScopeType,
+				"scopeID":        scopeID,
+				"from":           utils.StringValue(params.From),
+				"to":             utils.StringValue(params.To),
+			}
+
+			authorized, authErr := isAuthorizedForHistoryScope(ctx, authUser, params.ScopeType, scopeID, v1CompanyRepo, projectClaGroupsRepo)
+			if authErr != nil {
+				log.WithFields(f).WithError(authErr).Warn("unable to resolve the metrics history scope")
+				return metrics.NewGetMetricsHistoryBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, authErr))
+			}
+			if !authorized {
+				return metrics.NewGetMetricsHistoryForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Get Metrics History for %s scope %s",
+						authUser.UserName, params.ScopeType, scopeID),
+					XRequestID: reqID,
+				})
+			}
+
+			result, err := service.GetMetricsHistory(ctx, params.ScopeType, scopeID, utils.StringValue(params.From), utils.StringValue(params.To))
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("unable to load metrics history")
+				return metrics.NewGetMetricsHistoryBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+			return metrics.NewGetMetricsHistoryOK().WithXRequestID(reqID).WithPayload(result)
+		})
+}
+
+// isAuthorizedForHistoryScope checks the user has access to the project, company or CLA group of the history request
+func isAuthorizedForHistoryScope(ctx context.Context, authUser *auth.User, scopeType, scopeID string, v1CompanyRepo v1Company.IRepository, projectClaGroupsRepo projects_cla_groups.Repository) (bool, error) {
+	switch scopeType {
+	case HistoryScopeProject:
+		return utils.IsUserAuthorizedForProjectTree(ctx, authUser, scopeID, utils.ALLOW_ADMIN_SCOPE), nil
+	case HistoryScopeCompany:
+		company, err := v1CompanyRepo.GetCompany(ctx, scopeID)
+		if err != nil {
+			return false, err
+		}
+		return utils.IsUserAuthorizedForOrganization(ctx, authUser, company.CompanyExternalID, utils.ALLOW_ADMIN_SCOPE), nil
+	case HistoryScopeCLAGroup:
+		projectCLAGroups, err := projectClaGroupsRepo.GetProjectsIdsForClaGroup(ctx, scopeID)
+		if err != nil {
+			return false, err
+		}
+		if len(projectCLAGroups) == 0 {
+			return authUser.Admin, nil
+		}
+		return utils.IsUserAuthorizedForProjectTree(ctx, authUser, projectCLAGroups[0].FoundationSFID, utils.ALLOW_ADMIN_SCOPE), nil
+	}
+	return authUser.Admin, nil
 }
 
 type codedResponse interface {
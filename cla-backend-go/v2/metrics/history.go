@@ -0,0 +1,503 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// History scope types - a daily history record is kept for each scope a signature belongs to
+const (
+	HistoryScopeTotal    = "total"
+	HistoryScopeProject  = "project"
+	HistoryScopeCompany  = "company"
+	HistoryScopeCLAGroup = "cla-group"
+
+	// HistoryDateFormat is the format of the date sort key of the history table
+	HistoryDateFormat = "2006-01-02"
+
+	// HistoryMaxDays is the longest date range we return in a single history request
+	HistoryMaxDays = 366
+
+	// historyFirstSeenDate is the sort key of the markers recording the first signature of a contributor or a
+	// company in a scope
+	historyFirstSeenDate = "first-seen"
+)
+
+// errors
+var (
+	ErrInvalidHistoryScope = errors.New("invalid metrics history scope type")
+	ErrInvalidHistoryRange = errors.New("invalid metrics history date range")
+)
+
+// HistorySignature is the subset of a signature record needed to update the daily metrics history
+type HistorySignature struct {
+	SignatureID            string `json:"signature_id"`
+	SignatureProjectID     string `json:"signature_project_id"`
+	SignatureReferenceID   string `json:"signature_reference_id"`
+	SignatureReferenceType string `json:"signature_reference_type"`
+	SignatureType          string `json:"signature_type"`
+	SignatureUserCompanyID string `json:"signature_user_ccla_company_id"`
+	SignatureSigned        bool   `json:"signature_signed"`
+	SignatureApproved      bool   `json:"signature_approved"`
+	SignedOn               string `json:"signed_on"`
+	DateModified           string `json:"date_modified"`
+}
+
+// HistoryRecord is a daily metrics snapshot for a single scope
+type HistoryRecord struct {
+	ScopeKey              string `json:"scope_key"`
+	Date                  string `json:"date"`
+	ScopeType             string `json:"scope_type"`
+	ScopeID               string `json:"scope_id"`
+	SignaturesCount       int64  `json:"signatures_count"`
+	ICLACount             int64  `json:"icla_count"`
+	CCLACount             int64  `json:"ccla_count"`
+	EmployeeCount         int64  `json:"employee_count"`
+	NewContributorsCount  int64  `json:"new_contributors_count"`
+	CompaniesSigningCount int64  `json:"companies_signing_count"`
+	DateModified          string `json:"date_modified"`
+}
+
+// HistoryRepository stores and retrieves the daily metrics history
+type HistoryRepository interface {
+	RecordSignature(ctx context.Context, sig *HistorySignature) error
+	GetHistory(ctx context.Context, scopeType, scopeID string, from, to time.Time) ([]*HistoryRecord, error)
+	BackfillHistory(ctx context.Context) error
+}
+
+type historyRepo struct {
+	stage                 string
+	dynamoDBClient        dynamodbiface.DynamoDBAPI
+	historyTableName      string
+	projectsClaGroupsRepo projects_cla_groups.Repository
+}
+
+// NewHistoryRepository creates a new metrics history repository
+func NewHistoryRepository(awsSession *session.Session, stage string, pcgRepo projects_cla_groups.Repository) HistoryRepository {
+	return &historyRepo{
+		stage:                 stage,
+		dynamoDBClient:        dynamodb.New(awsSession),
+		historyTableName:      fmt.Sprintf("cla-%s-metrics-history", stage),
+		projectsClaGroupsRepo: pcgRepo,
+	}
+}
+
+// historyScopeKey returns the partition key of the history table for the specified scope
+func historyScopeKey(scopeType, scopeID string) string {
+	if scopeType == HistoryScopeTotal {
+		return HistoryScopeTotal
+	}
+	return fmt.Sprintf("%s#%s", scopeType, scopeID)
+}
+
+// IsValidHistoryScope returns true if the scope type is one we keep history for
+func IsValidHistoryScope(scopeType string) bool {
+	switch scopeType {
+	case HistoryScopeTotal, HistoryScopeProject, HistoryScopeCompany, HistoryScopeCLAGroup:
+		return true
+	}
+	return false
+}
+
+// historySignatureDate returns the day the signature counts towards - the signed on date if we have it,
+// otherwise the last modified date
+func historySignatureDate(sig *HistorySignature) string {
+	for _, value := range []string{sig.SignedOn, sig.DateModified} {
+		if value == "" {
+			continue
+		}
+		t, err := utils.ParseDateTime(value)
+		if err == nil {
+			return t.UTC().Format(HistoryDateFormat)
+		}
+	}
+	return time.Now().UTC().Format(HistoryDateFormat)
+}
+
+// historySignatureType returns the metrics signature type of the signature
+func historySignatureType(sig *HistorySignature) int {
+	return signatureType(&ItemSignature{
+		SignatureType:          sig.SignatureType,
+		SignatureReferenceType: sig.SignatureReferenceType,
+		SignatureUserCompanyID: sig.SignatureUserCompanyID,
+	})
+}
+
+// historyCounters returns the counters to increment for the specified signature
+func historyCounters(sig *HistorySignature) map[string]int64 {
+	switch historySignatureType(sig) {
+	case IclaSignature:
+		return map[string]int64{"signatures_count": 1, "icla_count": 1}
+	case EmployeeSignature:
+		return map[string]int64{"signatures_count": 1, "employee_count": 1}
+	case CclaSignature:
+		return map[string]int64{"signatures_count": 1, "ccla_count": 1}
+	}
+	return nil
+}
+
+// historyFirstSeen returns the counter to increment the first time the signer is seen in a scope and the ID of the
+// signer - the contributors are counted by user and the companies by company, whatever the number of signatures
+func historyFirstSeen(sig *HistorySignature) (string, string) {
+	switch historySignatureType(sig) {
+	case IclaSignature, EmployeeSignature:
+		if sig.SignatureReferenceID != "" {
+			return "new_contributors_count", "user#" + sig.SignatureReferenceID
+		}
+	case CclaSignature:
+		if companyID := historyCompanyID(sig); companyID != "" {
+			return "companies_signing_count", "company#" + companyID
+		}
+	}
+	return "", ""
+}
+
+// historyCompanyID returns the internal company ID associated with the signature, if any
+func historyCompanyID(sig *HistorySignature) string {
+	if sig.SignatureReferenceType == "company" {
+		return sig.SignatureReferenceID
+	}
+	return sig.SignatureUserCompanyID
+}
+
+// RecordSignature adds a signed and approved signature to the daily history of each scope it belongs to.
+// Each signature is only counted once per scope and day, so stream retries and backfills are safe. The contributors
+// and the companies are only counted as new on their first signature in the scope.
+func (repo *historyRepo) RecordSignature(ctx context.Context, sig *HistorySignature) error {
+	f := logrus.Fields{
+		"functionName":   "v2.metrics.history.RecordSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
+		"claGroupID":     sig.SignatureProjectID,
+	}
+
+	if !sig.SignatureSigned || !sig.SignatureApproved {
+		return nil
+	}
+	counters := historyCounters(sig)
+	if counters == nil {
+		log.WithFields(f).Debugf("skipping signature with type: %s and reference type: %s", sig.SignatureType, sig.SignatureReferenceType)
+		return nil
+	}
+
+	scopes := map[string]string{historyScopeKey(HistoryScopeTotal, ""): HistoryScopeTotal}
+	scopeIDs := map[string]string{historyScopeKey(HistoryScopeTotal, ""): ""}
+	addScope := func(scopeType, scopeID string) {
+		if scopeID == "" {
+			return
+		}
+		key := historyScopeKey(scopeType, scopeID)
+		scopes[key] = scopeType
+		scopeIDs[key] = scopeID
+	}
+	addScope(HistoryScopeCLAGroup, sig.SignatureProjectID)
+	addScope(HistoryScopeCompany, historyCompanyID(sig))
+
+	projects, err := repo.projectsClaGroupsRepo.GetProjectsIdsForClaGroup(ctx, sig.SignatureProjectID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load projects for CLA group - project history will not be updated")
+	}
+	for _, project := range projects {
+		addScope(HistoryScopeProject, project.ProjectSFID)
+	}
+
+	firstSeenCounter, signerID := historyFirstSeen(sig)
+	date := historySignatureDate(sig)
+	for key, scopeType := range scopes {
+		scopeCounters := counters
+		if signerID != "" {
+			firstSeen, claimErr := repo.claimFirstSeen(key, signerID, sig.SignatureID)
+			if claimErr != nil {
+				log.WithFields(f).WithError(claimErr).Warnf("unable to record the first signature of: %s for scope: %s", signerID, key)
+				return claimErr
+			}
+			if firstSeen {
+				scopeCounters = make(map[string]int64, len(counters)+1)
+				for name, value := range counters {
+					scopeCounters[name] = value
+				}
+				scopeCounters[firstSeenCounter] = 1
+			}
+		}
+
+		updateErr := repo.incrementHistory(key, date, scopeType, scopeIDs[key], sig.SignatureID, scopeCounters)
+		if updateErr != nil {
+			log.WithFields(f).WithError(updateErr).Warnf("unable to update metrics history for scope: %s on: %s", key, date)
+			return updateErr
+		}
+	}
+
+	return nil
+}
+
+// claimFirstSeen records the signature as the first one of the signer in the scope - returns true if the signature
+// holds the claim, retries of the same signature keep it while the other signatures of the signer are rejected
+func (repo *historyRepo) claimFirstSeen(scopeKey, signerID, signatureID string) (bool, error) {
+	_, now := utils.CurrentTime()
+	condition := expression.Or(
+		expression.AttributeNotExists(expression.Name("scope_key")),
+		expression.Name("signature_id").Equal(expression.Value(signatureID)),
+	)
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(repo.historyTableName),
+		Item: map[string]*dynamodb.AttributeValue{
+			"scope_key":     {S: aws.String(fmt.Sprintf("%s#seen#%s", scopeKey, signerID))},
+			"date":          {S: aws.String(historyFirstSeenDate)},
+			"signature_id":  {S: aws.String(signatureID)},
+			"date_modified": {S: aws.String(now)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ConditionExpression:       expr.Condition(),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			// an earlier signature of the signer holds the claim
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// incrementHistory adds the counters to the history record, guarded by the set of signature IDs already counted
+func (repo *historyRepo) incrementHistory(scopeKey, date, scopeType, scopeID, signatureID string, counters map[string]int64) error {
+	_, now := utils.CurrentTime()
+	update := expression.Set(expression.Name("scope_type"), expression.Value(scopeType)).
+		Set(expression.Name("scope_id"), expression.Value(scopeID)).
+		Set(expression.Name("date_modified"), expression.Value(now)).
+		Add(expression.Name("signature_ids"), expression.Value(&dynamodb.AttributeValue{SS: aws.StringSlice([]string{signatureID})}))
+	for name, value := range counters {
+		update = update.Add(expression.Name(name), expression.Value(value))
+	}
+	condition := expression.Not(expression.Contains(expression.Name("signature_ids"), signatureID))
+
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.historyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"scope_key": {S: aws.String(scopeKey)},
+			"date":      {S: aws.String(date)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			// already counted
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// GetHistory returns the daily history records of the scope between the from and to dates (inclusive)
+func (repo *historyRepo) GetHistory(ctx context.Context, scopeType, scopeID string, from, to time.Time) ([]*HistoryRecord, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.metrics.history.GetHistory",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scopeType":      scopeType,
+		"scopeID":        scopeID,
+	}
+
+	condition := expression.Key("scope_key").Equal(expression.Value(historyScopeKey(scopeType, scopeID))).
+		And(expression.Key("date").Between(expression.Value(from.Format(HistoryDateFormat)), expression.Value(to.Format(HistoryDateFormat))))
+	projection := expression.NamesList(
+		expression.Name("scope_key"),
+		expression.Name("date"),
+		expression.Name("scope_type"),
+		expression.Name("scope_id"),
+		expression.Name("signatures_count"),
+		expression.Name("icla_count"),
+		expression.Name("ccla_count"),
+		expression.Name("employee_count"),
+		expression.Name("new_contributors_count"),
+		expression.Name("companies_signing_count"),
+		expression.Name("date_modified"),
+	)
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithProjection(projection).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for metrics history query")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.historyTableName),
+	}
+
+	var records []*HistoryRecord
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("error querying metrics history")
+			return nil, queryErr
+		}
+
+		var page []*HistoryRecord
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("error unmarshalling metrics history")
+			return nil, err
+		}
+		records = append(records, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return records, nil
+}
+
+// BackfillHistory records every signed and approved signature - used to seed the history table, the stream
+// handler keeps it up to date afterwards
+func (repo *historyRepo) BackfillHistory(ctx context.Context) error {
+	f := logrus.Fields{
+		"functionName":   "v2.metrics.history.BackfillHistory",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	t := time.Now()
+	filter := expression.Name("signature_signed").Equal(expression.Value(true)).
+		And(expression.Name("signature_approved").Equal(expression.Value(true)))
+	projection := expression.NamesList(
+		expression.Name("signature_id"),
+		expression.Name("signature_project_id"),
+		expression.Name("signature_reference_id"),
+		expression.Name("signature_reference_type"),
+		expression.Name("signature_type"),
+		expression.Name("signature_user_ccla_company_id"),
+		expression.Name("signature_signed"),
+		expression.Name("signature_approved"),
+		expression.Name("signed_on"),
+		expression.Name("date_modified"),
+	)
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		return err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(fmt.Sprintf("cla-%s-signatures", repo.stage)),
+	}
+
+	var count int
+	for {
+		results, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.WithFields(f).WithError(scanErr).Warn("error scanning signatures")
+			return scanErr
+		}
+
+		var sigs []*HistorySignature
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &sigs)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("error unmarshalling signatures")
+			return err
+		}
+
+		for _, sig := range sigs {
+			if recordErr := repo.RecordSignature(ctx, sig); recordErr != nil {
+				return recordErr
+			}
+			count++
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	log.WithFields(f).Infof("backfilled metrics history with %d signatures, took: %s", count, time.Since(t).String())
+	return nil
+}
+
+// fillHistoryGaps returns one record per day between from and to (inclusive), using zero records for the days
+// without any activity
+func fillHistoryGaps(records []*HistoryRecord, scopeType, scopeID string, from, to time.Time) []*HistoryRecord {
+	byDate := make(map[string]*HistoryRecord, len(records))
+	for _, record := range records {
+		byDate[record.Date] = record
+	}
+
+	var out []*HistoryRecord
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(HistoryDateFormat)
+		if record, ok := byDate[date]; ok {
+			out = append(out, record)
+			continue
+		}
+		out = append(out, &HistoryRecord{
+			ScopeKey:  historyScopeKey(scopeType, scopeID),
+			Date:      date,
+			ScopeType: scopeType,
+			ScopeID:   scopeID,
+		})
+	}
+	return out
+}
+
+// parseHistoryRange parses the optional from/to dates, defaulting to the last 30 days
+func parseHistoryRange(fromStr, toStr string, now time.Time) (time.Time, time.Time, error) {
+	to := now.UTC().Truncate(24 * time.Hour)
+	if toStr != "" {
+		t, err := time.Parse(HistoryDateFormat, toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidHistoryRange
+		}
+		to = t
+	}
+
+	from := to.AddDate(0, 0, -29)
+	if fromStr != "" {
+		t, err := time.Parse(HistoryDateFormat, fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidHistoryRange
+		}
+		from = t
+	}
+
+	if from.After(to) || to.Sub(from) >= HistoryMaxDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrInvalidHistoryRange
+	}
+	return from, to, nil
+}
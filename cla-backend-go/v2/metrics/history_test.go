@@ -0,0 +1,219 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryCounters(t *testing.T) {
+	tests := []struct {
+		name string
+		sig  *HistorySignature
+		want map[string]int64
+	}{
+		{
+			name: "icla",
+			sig:  &HistorySignature{SignatureType: "cla", SignatureReferenceType: "user"},
+			want: map[string]int64{"signatures_count": 1, "icla_count": 1},
+		},
+		{
+			name: "employee",
+			sig:  &HistorySignature{SignatureType: "cla", SignatureReferenceType: "user", SignatureUserCompanyID: "company-1"},
+			want: map[string]int64{"signatures_count": 1, "employee_count": 1},
+		},
+		{
+			name: "ccla",
+			sig:  &HistorySignature{SignatureType: "ccla", SignatureReferenceType: "company"},
+			want: map[string]int64{"signatures_count": 1, "ccla_count": 1},
+		},
+		{
+			name: "invalid",
+			sig:  &HistorySignature{SignatureType: "ccla", SignatureReferenceType: "user"},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, historyCounters(tt.sig))
+		})
+	}
+}
+
+func TestHistoryFirstSeen(t *testing.T) {
+	counter, signerID := historyFirstSeen(&HistorySignature{SignatureType: "cla", SignatureReferenceType: "user", SignatureReferenceID: "user-1"})
+	assert.Equal(t, "new_contributors_count", counter)
+	assert.Equal(t, "user#user-1", signerID)
+
+	counter, signerID = historyFirstSeen(&HistorySignature{SignatureType: "cla", SignatureReferenceType: "user", SignatureReferenceID: "user-1", SignatureUserCompanyID: "company-1"})
+	assert.Equal(t, "new_contributors_count", counter)
+	assert.Equal(t, "user#user-1", signerID)
+
+	counter, signerID = historyFirstSeen(&HistorySignature{SignatureType: "ccla", SignatureReferenceType: "company", SignatureReferenceID: "company-1"})
+	assert.Equal(t, "companies_signing_count", counter)
+	assert.Equal(t, "company#company-1", signerID)
+
+	counter, signerID = historyFirstSeen(&HistorySignature{SignatureType: "cla", SignatureReferenceType: "user"})
+	assert.Empty(t, counter)
+	assert.Empty(t, signerID)
+}
+
+// fakeHistoryDynamoDB keeps the history table in memory, applying the first seen claims and the counter updates with
+// the same conditions as the DynamoDB table
+type fakeHistoryDynamoDB struct {
+	dynamodbiface.DynamoDBAPI
+	claims   map[string]string
+	counted  map[string]bool
+	counters map[string]map[string]int64
+}
+
+var fakeHistoryAddPattern = regexp.MustCompile(`(#\w+) (:\w+)`)
+
+func (db *fakeHistoryDynamoDB) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	key := aws.StringValue(input.Item["scope_key"].S)
+	signatureID := aws.StringValue(input.Item["signature_id"].S)
+	if claim, ok := db.claims[key]; ok && claim != signatureID {
+		return nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "conditional check failed", nil)
+	}
+	db.claims[key] = signatureID
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (db *fakeHistoryDynamoDB) UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+	key := aws.StringValue(input.Key["scope_key"].S) + "/" + aws.StringValue(input.Key["date"].S)
+	updates := map[string]*dynamodb.AttributeValue{}
+	for _, match := range fakeHistoryAddPattern.FindAllStringSubmatch(aws.StringValue(input.UpdateExpression), -1) {
+		updates[aws.StringValue(input.ExpressionAttributeNames[match[1]])] = input.ExpressionAttributeValues[match[2]]
+	}
+
+	signatureID := aws.StringValue(updates["signature_ids"].SS[0])
+	if db.counted[key+"/"+signatureID] {
+		return nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "conditional check failed", nil)
+	}
+	db.counted[key+"/"+signatureID] = true
+
+	if db.counters[key] == nil {
+		db.counters[key] = map[string]int64{}
+	}
+	for name, value := range updates {
+		if value.N != nil {
+			n, err := strconv.ParseInt(aws.StringValue(value.N), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			db.counters[key][name] += n
+		}
+	}
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+type fakeHistoryProjectClaGroupsRepo struct {
+	projects_cla_groups.Repository
+}
+
+func (r *fakeHistoryProjectClaGroupsRepo) GetProjectsIdsForClaGroup(ctx context.Context, claGroupID string) ([]*projects_cla_groups.ProjectClaGroup, error) {
+	return []*projects_cla_groups.ProjectClaGroup{{ProjectSFID: "project-" + claGroupID, ClaGroupID: claGroupID}}, nil
+}
+
+func TestRecordSignatureCountsRepeatSignersOnce(t *testing.T) {
+	ctx := context.Background()
+	db := &fakeHistoryDynamoDB{claims: map[string]string{}, counted: map[string]bool{}, counters: map[string]map[string]int64{}}
+	repo := &historyRepo{dynamoDBClient: db, historyTableName: "cla-test-metrics-history", projectsClaGroupsRepo: &fakeHistoryProjectClaGroupsRepo{}}
+
+	signatures := []*HistorySignature{
+		// user-1 signs the ICLA of two CLA groups on different days and re-signs the first one
+		{SignatureID: "icla-1", SignatureProjectID: "cla-group-1", SignatureReferenceID: "user-1", SignatureReferenceType: "user", SignatureType: "cla", SignedOn: "2021-01-01T10:00:00Z"},
+		{SignatureID: "icla-2", SignatureProjectID: "cla-group-2", SignatureReferenceID: "user-1", SignatureReferenceType: "user", SignatureType: "cla", SignedOn: "2021-01-02T10:00:00Z"},
+		{SignatureID: "icla-3", SignatureProjectID: "cla-group-1", SignatureReferenceID: "user-1", SignatureReferenceType: "user", SignatureType: "cla", SignedOn: "2021-01-03T10:00:00Z"},
+		// user-2 acknowledges as an employee of company-1, which signs the CCLA of both CLA groups
+		{SignatureID: "ecla-1", SignatureProjectID: "cla-group-1", SignatureReferenceID: "user-2", SignatureReferenceType: "user", SignatureType: "cla", SignatureUserCompanyID: "company-1", SignedOn: "2021-01-02T10:00:00Z"},
+		{SignatureID: "ccla-1", SignatureProjectID: "cla-group-1", SignatureReferenceID: "company-1", SignatureReferenceType: "company", SignatureType: "ccla", SignedOn: "2021-01-01T10:00:00Z"},
+		{SignatureID: "ccla-2", SignatureProjectID: "cla-group-2", SignatureReferenceID: "company-1", SignatureReferenceType: "company", SignatureType: "ccla", SignedOn: "2021-01-03T10:00:00Z"},
+	}
+	for _, sig := range signatures {
+		sig.SignatureSigned = true
+		sig.SignatureApproved = true
+		assert.Nil(t, repo.RecordSignature(ctx, sig))
+	}
+	// stream retries and backfills don't change the counts
+	for _, sig := range signatures {
+		assert.Nil(t, repo.RecordSignature(ctx, sig))
+	}
+
+	sum := func(scopeKey, counter string) int64 {
+		var total int64
+		for _, date := range []string{"2021-01-01", "2021-01-02", "2021-01-03"} {
+			total += db.counters[scopeKey+"/"+date][counter]
+		}
+		return total
+	}
+
+	assert.Equal(t, int64(6), sum("total", "signatures_count"))
+	assert.Equal(t, int64(2), sum("total", "new_contributors_count"))
+	assert.Equal(t, int64(1), sum("total", "companies_signing_count"))
+	assert.Equal(t, int64(1), db.counters["total/2021-01-01"]["new_contributors_count"])
+	assert.Equal(t, int64(0), db.counters["total/2021-01-03"]["new_contributors_count"])
+
+	assert.Equal(t, int64(2), sum("cla-group#cla-group-1", "new_contributors_count"))
+	assert.Equal(t, int64(1), sum("cla-group#cla-group-1", "companies_signing_count"))
+	assert.Equal(t, int64(1), sum("cla-group#cla-group-2", "new_contributors_count"))
+	assert.Equal(t, int64(1), sum("project#project-cla-group-2", "companies_signing_count"))
+	assert.Equal(t, int64(1), sum("company#company-1", "new_contributors_count"))
+	assert.Equal(t, int64(1), sum("company#company-1", "companies_signing_count"))
+}
+
+func TestHistoryScopeKey(t *testing.T) {
+	assert.Equal(t, "total", historyScopeKey(HistoryScopeTotal, "ignored"))
+	assert.Equal(t, "company#abc", historyScopeKey(HistoryScopeCompany, "abc"))
+	assert.Equal(t, "cla-group#xyz", historyScopeKey(HistoryScopeCLAGroup, "xyz"))
+}
+
+func TestParseHistoryRange(t *testing.T) {
+	now := time.Date(2021, 3, 15, 13, 45, 0, 0, time.UTC)
+
+	from, to, err := parseHistoryRange("", "", now)
+	assert.Nil(t, err)
+	assert.Equal(t, "2021-02-14", from.Format(HistoryDateFormat))
+	assert.Equal(t, "2021-03-15", to.Format(HistoryDateFormat))
+
+	from, to, err = parseHistoryRange("2021-01-01", "2021-01-31", now)
+	assert.Nil(t, err)
+	assert.Equal(t, "2021-01-01", from.Format(HistoryDateFormat))
+	assert.Equal(t, "2021-01-31", to.Format(HistoryDateFormat))
+
+	_, _, err = parseHistoryRange("2021-02-01", "2021-01-31", now)
+	assert.Equal(t, ErrInvalidHistoryRange, err)
+
+	_, _, err = parseHistoryRange("2019-01-01", "2021-01-31", now)
+	assert.Equal(t, ErrInvalidHistoryRange, err)
+
+	_, _, err = parseHistoryRange("01/01/2021", "", now)
+	assert.Equal(t, ErrInvalidHistoryRange, err)
+}
+
+func TestFillHistoryGaps(t *testing.T) {
+	from := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)
+	records := []*HistoryRecord{{Date: "2021-01-02", SignaturesCount: 4}}
+
+	out := fillHistoryGaps(records, HistoryScopeCompany, "abc", from, to)
+	assert.Len(t, out, 3)
+	assert.Equal(t, "2021-01-01", out[0].Date)
+	assert.Equal(t, int64(0), out[0].SignaturesCount)
+	assert.Equal(t, "company#abc", out[0].ScopeKey)
+	assert.Equal(t, int64(4), out[1].SignaturesCount)
+	assert.Equal(t, "2021-01-03", out[2].Date)
+}
@@ -273,6 +273,18 @@ func (tcm *TotalCountMetrics) toModel() *models.TotalCountMetrics {
 	}
 }
 
+func (hr *HistoryRecord) toModel() *models.MetricsHistoryDay {
+	return &models.MetricsHistoryDay{
+		Date:                  hr.Date,
+		SignaturesCount:       hr.SignaturesCount,
+		IclaCount:             hr.ICLACount,
+		CclaCount:             hr.CCLACount,
+		EmployeeCount:         hr.EmployeeCount,
+		NewContributorsCount:  hr.NewContributorsCount,
+		CompaniesSigningCount: hr.CompaniesSigningCount,
+	}
+}
+
 func companiesToModel(in []*CompanyMetric) []*models.CompanyMetric {
 	out := make([]*models.CompanyMetric, 0)
 	for _, cm := range in {
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
@@ -37,17 +38,20 @@ type Service interface {
 	GetTopProjects() (*models.TopProjects, error)
 	ListProjectMetrics(paramPageSize *int64, paramNextKey *string) (*models.ListProjectMetric, error)
 	ListCompanyProjectMetrics(ctx context.Context, companyID string, projectSFID string) (*models.CompanyProjectMetrics, error)
+	GetMetricsHistory(ctx context.Context, scopeType, scopeID, from, to string) (*models.MetricsHistory, error)
 }
 
 type service struct {
 	metricsRepo           Repository
+	historyRepo           HistoryRepository
 	projectsClaGroupsRepo projects_cla_groups.Repository
 }
 
 // NewService creates new instance of metrics service
-func NewService(metricsRepo Repository, pcgRepo projects_cla_groups.Repository) Service {
+func NewService(metricsRepo Repository, historyRepo HistoryRepository, pcgRepo projects_cla_groups.Repository) Service {
 	return &service{
 		metricsRepo:           metricsRepo,
+		historyRepo:           historyRepo,
 		projectsClaGroupsRepo: pcgRepo,
 	}
 }
@@ -326,3 +330,36 @@ func (s *service) ListCompanyProjectMetrics(ctx context.Context, companyID strin
 	})
 	return out, nil
 }
+
+func (s *service) GetMetricsHistory(ctx context.Context, scopeType, scopeID, from, to string) (*models.MetricsHistory, error) {
+	if !IsValidHistoryScope(scopeType) {
+		return nil, ErrInvalidHistoryScope
+	}
+	if scopeType != HistoryScopeTotal && scopeID == "" {
+		return nil, ErrInvalidHistoryScope
+	}
+	fromDate, toDate, err := parseHistoryRange(from, to, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.historyRepo.GetHistory(ctx, scopeType, scopeID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.MetricsHistory{
+		ScopeType: scopeType,
+		ScopeID:   scopeID,
+		From:      fromDate.Format(HistoryDateFormat),
+		To:        toDate.Format(HistoryDateFormat),
+		Series:    make([]*models.MetricsHistoryDay, 0),
+	}
+	for _, record := range fillHistoryGaps(records, scopeType, scopeID, fromDate, toDate) {
+		out.Series = append(out.Series, record.toModel())
+		out.SignaturesCount += record.SignaturesCount
+		out.NewContributorsCount += record.NewContributorsCount
+		out.CompaniesSigningCount += record.CompaniesSigningCount
+	}
+	return out, nil
+}
@@ -0,0 +1,229 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	bitbucketApi "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	repoModels "github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrBitbucketOrganizationNotFound is returned when the Bitbucket organization of the repositories doesn't exist
+var ErrBitbucketOrganizationNotFound = errors.New("bitbucket organization not found")
+
+// ErrBitbucketCLAGroupRequired is returned when enrolling Bitbucket repositories without a CLA Group
+var ErrBitbucketCLAGroupRequired = errors.New("cla group is required to enroll bitbucket repositories")
+
+// BitbucketOrgRepo redefine the interface here to avoid circular dependency issues
+type BitbucketOrgRepo interface {
+	GetBitbucketOrganization(ctx context.Context, organizationID string) (*common.BitbucketOrganization, error)
+}
+
+// BitbucketGetRepository returns the Bitbucket repository by the internal ID
+func (s *Service) BitbucketGetRepository(ctx context.Context, repositoryID string) (*v2Models.BitbucketRepository, error) {
+	dbModel, err := s.gitV2Repository.GitLabGetRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if dbModel.RepositoryType != utils.BitbucketLower {
+		return nil, &utils.GitLabRepositoryNotFound{
+			RepositoryName: repositoryID,
+		}
+	}
+
+	return dbModelToBitbucketRepository(dbModel), nil
+}
+
+// BitbucketGetRepositoryByExternalID returns the Bitbucket repository of the organization by the Bitbucket ID, nil if not found
+func (s *Service) BitbucketGetRepositoryByExternalID(ctx context.Context, organizationKey, repositoryExternalID string) (*v2Models.BitbucketRepository, error) {
+	dbModel, err := s.gitV2Repository.BitbucketGetRepositoryByExternalID(ctx, organizationKey, repositoryExternalID)
+	if err != nil || dbModel == nil {
+		return nil, err
+	}
+
+	return dbModelToBitbucketRepository(dbModel), nil
+}
+
+// BitbucketGetRepositoriesByProjectSFID returns the Bitbucket repositories of the project sorted by name
+func (s *Service) BitbucketGetRepositoriesByProjectSFID(ctx context.Context, projectSFID string) (*v2Models.BitbucketRepositoriesList, error) {
+	dbModels, err := s.gitV2Repository.BitbucketGetRepositoriesByProjectSFID(ctx, projectSFID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := dbModelsToBitbucketRepositories(dbModels)
+	// sort result by name
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].RepositoryName < responses[j].RepositoryName
+	})
+
+	return &v2Models.BitbucketRepositoriesList{
+		List: responses,
+	}, nil
+}
+
+// BitbucketEnrollRepositories enrolls (or unenrolls) the repositories of the Bitbucket organization by slug. The
+// repositories not yet known are loaded from Bitbucket and added when enrolling.
+func (s *Service) BitbucketEnrollRepositories(ctx context.Context, projectSFID, organizationID, claGroupID string, repositorySlugs []string, enrollValue bool) error {
+	f := logrus.Fields{
+		"functionName":    "v2.repositories.bitbucket_services.BitbucketEnrollRepositories",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"projectSFID":     projectSFID,
+		"organizationID":  organizationID,
+		"claGroupID":      claGroupID,
+		"repositorySlugs": repositorySlugs,
+		"enrollValue":     enrollValue,
+	}
+
+	if enrollValue && claGroupID == "" {
+		return ErrBitbucketCLAGroupRequired
+	}
+
+	org, err := s.bbOrgRepo.GetBitbucketOrganization(ctx, organizationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading bitbucket organization")
+		return err
+	}
+	if org == nil || org.ProjectSFID != projectSFID {
+		return ErrBitbucketOrganizationNotFound
+	}
+
+	existing, err := s.gitV2Repository.BitbucketGetRepositoriesByOrganizationName(ctx, org.OrganizationKey)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading bitbucket repositories")
+		return err
+	}
+	bySlug := make(map[string]*repoModels.RepositoryDBModel, len(existing))
+	for _, dbModel := range existing {
+		bySlug[bitbucketRepositorySlug(org, dbModel)] = dbModel
+	}
+
+	var client *bitbucketApi.Client
+	for _, slug := range repositorySlugs {
+		dbModel, ok := bySlug[slug]
+		if !ok {
+			if !enrollValue {
+				log.WithFields(f).Debugf("bitbucket repository %s is not known - nothing to unenroll", slug)
+				continue
+			}
+			if client == nil {
+				client, err = bitbucketApi.NewClientFromEncryptedToken(org.Flavor, org.BaseURL, org.AccessToken, s.bitbucketApp)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to create the bitbucket client")
+					return err
+				}
+			}
+			dbModel, err = s.bitbucketAddRepository(ctx, client, org, slug, claGroupID)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to add bitbucket repository %s", slug)
+				return err
+			}
+			bySlug[slug] = dbModel
+			continue
+		}
+
+		err = s.gitV2Repository.BitbucketSetRepositoryEnabled(ctx, dbModel.RepositoryID, claGroupID, enrollValue)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to update bitbucket repository %s", slug)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BitbucketDeleteRepositories deletes the repositories of the Bitbucket organization
+func (s *Service) BitbucketDeleteRepositories(ctx context.Context, organizationKey string) error {
+	dbModels, err := s.gitV2Repository.BitbucketGetRepositoriesByOrganizationName(ctx, organizationKey)
+	if err != nil {
+		return err
+	}
+
+	for _, dbModel := range dbModels {
+		if err := s.gitV2Repository.BitbucketDeleteRepository(ctx, dbModel.RepositoryID); err != nil {
+			return err
+		}
+
+		s.eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.RepositoryDeleted,
+			ProjectSFID: dbModel.ProjectSFID,
+			CLAGroupID:  dbModel.RepositoryCLAGroupID,
+			LfUsername:  utils.GetUserNameFromContext(ctx),
+			EventData: &events.RepositoryDeletedEventData{
+				RepositoryName: dbModel.RepositoryFullPath,
+			},
+		})
+	}
+
+	return nil
+}
+
+// bitbucketAddRepository loads the repository from Bitbucket and adds it enrolled in the CLA Group
+func (s *Service) bitbucketAddRepository(ctx context.Context, client *bitbucketApi.Client, org *common.BitbucketOrganization, slug, claGroupID string) (*repoModels.RepositoryDBModel, error) {
+	repo, err := client.GetRepository(bitbucketOrganizationOwner(org), slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.gitV2Repository.BitbucketAddRepository(ctx, &repoModels.RepositoryDBModel{
+		RepositoryExternalID:       repo.ExternalID,
+		RepositoryName:             fmt.Sprintf("%s/%s", org.OrganizationKey, repo.Slug),
+		RepositoryFullPath:         repo.FullName,
+		RepositoryOrganizationName: org.OrganizationKey,
+		RepositoryCLAGroupID:       claGroupID,
+		RepositoryURL:              repo.WebURL,
+		ProjectSFID:                org.ProjectSFID,
+		Enabled:                    true,
+	})
+}
+
+// bitbucketOrganizationOwner returns the workspace (Bitbucket Cloud) or the project key (Bitbucket Data Center) of the
+// organization, the organization name holds it
+func bitbucketOrganizationOwner(org *common.BitbucketOrganization) string {
+	return org.OrganizationName
+}
+
+// bitbucketRepositorySlug returns the slug of the repository from the name - the organization key followed by the slug
+func bitbucketRepositorySlug(org *common.BitbucketOrganization, dbModel *repoModels.RepositoryDBModel) string {
+	return strings.TrimPrefix(dbModel.RepositoryName, org.OrganizationKey+"/")
+}
+
+// dbModelToBitbucketRepository converts the database model to a v2 response model
+func dbModelToBitbucketRepository(dbModel *repoModels.RepositoryDBModel) *v2Models.BitbucketRepository {
+	return &v2Models.BitbucketRepository{
+		RepositoryID:               dbModel.RepositoryID,               // Internal database ID for this repository record
+		RepositoryProjectSfid:      dbModel.ProjectSFID,                // Project SFID
+		RepositoryClaGroupID:       dbModel.RepositoryCLAGroupID,       // CLA Group ID
+		RepositoryExternalID:       dbModel.RepositoryExternalID,       // Bitbucket UUID or numeric ID
+		RepositoryName:             dbModel.RepositoryName,             // Organization key followed by the slug
+		RepositoryOrganizationName: dbModel.RepositoryOrganizationName, // Organization key
+		RepositoryURL:              dbModel.RepositoryURL,              // full url
+		RepositoryType:             dbModel.RepositoryType,             // bitbucket
+		Enabled:                    dbModel.Enabled,                    // Enabled flag
+		DateCreated:                dbModel.DateCreated,                // date created
+		DateModified:               dbModel.DateModified,               // date updated
+		Note:                       dbModel.Note,                       // Optional note
+		Version:                    dbModel.Version,                    // record version
+	}
+}
+
+// dbModelsToBitbucketRepositories converts the slice of database models to a slice of v2 response model
+func dbModelsToBitbucketRepositories(dbModels []*repoModels.RepositoryDBModel) []*v2Models.BitbucketRepository {
+	responses := make([]*v2Models.BitbucketRepository, 0, len(dbModels))
+	for _, dbModel := range dbModels {
+		responses = append(responses, dbModelToBitbucketRepository(dbModel))
+	}
+	return responses
+}
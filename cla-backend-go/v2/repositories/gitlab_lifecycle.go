@@ -0,0 +1,416 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	gitLabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	repoModels "github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/sirupsen/logrus"
+	goGitLab "github.com/xanzy/go-gitlab"
+)
+
+// GitLabRenameRepository follows the rename of the GitLab project in the same namespace, the repository keeps its
+// CLA Group enrollment
+func (s *Service) GitLabRenameRepository(ctx context.Context, repositoryExternalID int64, newFullPath, newURL string) (*v2Models.GitlabRepository, error) {
+	f := logrus.Fields{
+		"functionName":         "v2.repositories.gitlab_lifecycle.GitLabRenameRepository",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"repositoryExternalID": repositoryExternalID,
+		"newFullPath":          newFullPath,
+	}
+
+	record, err := s.gitV2Repository.GitLabGetRepositoryByExternalID(ctx, repositoryExternalID)
+	if err != nil {
+		return nil, err
+	}
+	oldFullPath := record.RepositoryFullPath
+	if strings.EqualFold(oldFullPath, newFullPath) && (newURL == "" || record.RepositoryURL == newURL) {
+		log.WithFields(f).Debug("repository path unchanged, probably duplicate event was sent")
+		return dbModelToGitLabRepository(record)
+	}
+
+	log.WithFields(f).Infof("renaming GitLab repository from : %s to : %s", oldFullPath, newFullPath)
+	record.RepositoryName, record.RepositoryFullPath = newFullPath, newFullPath
+	if newURL != "" {
+		record.RepositoryURL = newURL
+	}
+	record.Note = appendRepositoryNote(record.Note, fmt.Sprintf("repository was renamed externally from %s", oldFullPath))
+	if err = s.gitV2Repository.GitLabUpdateRepository(ctx, record); err != nil {
+		return nil, err
+	}
+
+	s.eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:   events.RepositoryRenamed,
+		ProjectSFID: record.ProjectSFID,
+		CLAGroupID:  record.RepositoryCLAGroupID,
+		LfUsername:  utils.GetUserNameFromContext(ctx),
+		EventData: &events.RepositoryRenamedEventData{
+			NewRepositoryName: newFullPath,
+			OldRepositoryName: oldFullPath,
+			RepositoryType:    utils.GitLabRepositoryType,
+		},
+	})
+
+	if record.Enabled {
+		s.notifyGitLabRepositoryCLAManagers(ctx, f, record.RepositoryCLAGroupID, "EasyCLA: GitLab Repository Was Renamed", func() (string, error) {
+			return emails.RenderGitLabRepositoryRenamedTemplate(s.emailService, record.RepositoryCLAGroupID, emails.GitLabRepositoryRenamedTemplateParams{
+				GitLabRepositoryActionTemplateParams: gitLabRepositoryActionTemplateParams(newFullPath),
+				OldRepositoryName:                    oldFullPath,
+				NewRepositoryName:                    newFullPath,
+			})
+		})
+	}
+
+	return dbModelToGitLabRepository(record)
+}
+
+// GitLabTransferRepository follows the transfer of the GitLab project to another namespace. The repository moves to
+// the GitLab group/organization and the CLA Group of the new namespace, it is disabled when the new namespace has no
+// CLA Group and removed when it was transferred out of the registered GitLab groups/organizations. The transfer is
+// confirmed with the GitLab API first, the repository is left unchanged when the project doesn't live under the new
+// namespace.
+func (s *Service) GitLabTransferRepository(ctx context.Context, input *GitLabTransferRepoModel) (*v2Models.GitlabRepository, error) {
+	f := logrus.Fields{
+		"functionName":         "v2.repositories.gitlab_lifecycle.GitLabTransferRepository",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"repositoryExternalID": input.RepositoryExternalID,
+		"newFullPath":          input.NewFullPath,
+		"claGroupID":           input.ClaGroupID,
+	}
+
+	record, err := s.gitV2Repository.GitLabGetRepositoryByExternalID(ctx, input.RepositoryExternalID)
+	if err != nil {
+		return nil, err
+	}
+	oldFullPath, oldClaGroupID, wasEnabled := record.RepositoryFullPath, record.RepositoryCLAGroupID, record.Enabled
+	if strings.EqualFold(oldFullPath, input.NewFullPath) && (input.GitLabOrganization == nil || record.ProjectSFID == input.GitLabOrganization.ProjectSFID) {
+		log.WithFields(f).Debug("repository path unchanged, probably duplicate event was sent")
+		return dbModelToGitLabRepository(record)
+	}
+
+	newFullPath, newURL, transferred, err := s.confirmGitLabTransfer(ctx, record, input)
+	if err != nil {
+		return nil, err
+	}
+	if !transferred {
+		log.WithFields(f).Warnf("GitLab project : %s was not transferred to : %s - leaving the repository unchanged", oldFullPath, input.NewFullPath)
+		return dbModelToGitLabRepository(record)
+	}
+	f["newFullPath"] = newFullPath
+	oldNamespace, newNamespace := path.Dir(oldFullPath), path.Dir(newFullPath)
+	log.WithFields(f).Infof("running transfer for GitLab repository : %s from namespace : %s to namespace : %s", oldFullPath, oldNamespace, newNamespace)
+
+	transferredTemplateParams := emails.GitLabRepositoryTransferredTemplateParams{
+		GitLabRepositoryActionTemplateParams: gitLabRepositoryActionTemplateParams(newFullPath),
+		OldNamespace:                         oldNamespace,
+		NewNamespace:                         newNamespace,
+	}
+
+	if input.GitLabOrganization == nil {
+		log.WithFields(f).Infof("GitLab repository : %s transferred out of the registered GitLab groups, removing the repository", oldFullPath)
+		if err = s.gitV2Repository.GitLabDeleteRepositoryByExternalID(ctx, input.RepositoryExternalID); err != nil {
+			return nil, err
+		}
+		s.eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.RepositoryDeleted,
+			ProjectSFID: record.ProjectSFID,
+			CLAGroupID:  oldClaGroupID,
+			LfUsername:  utils.GetUserNameFromContext(ctx),
+			EventData: &events.RepositoryDeletedEventData{
+				RepositoryName:       oldFullPath,
+				RepositoryExternalID: input.RepositoryExternalID,
+				RepositoryType:       utils.GitLabRepositoryType,
+			},
+		})
+		if wasEnabled {
+			s.notifyGitLabRepositoryCLAManagers(ctx, f, oldClaGroupID, "EasyCLA: GitLab Repository Was Transferred", func() (string, error) {
+				return emails.RenderGitLabRepositoryTransferredTemplate(s.emailService, oldClaGroupID, transferredTemplateParams, false)
+			})
+		}
+		return nil, nil
+	}
+
+	record.RepositoryName, record.RepositoryFullPath = newFullPath, newFullPath
+	if newURL != "" {
+		record.RepositoryURL = newURL
+	}
+	record.RepositoryOrganizationName = input.GitLabOrganization.OrganizationName
+	record.ProjectSFID, record.RepositorySfdcID = input.GitLabOrganization.ProjectSFID, input.GitLabOrganization.ProjectSFID
+	note := fmt.Sprintf("repository was transferred from namespace : %s to : %s", oldNamespace, newNamespace)
+	if input.ClaGroupID != "" {
+		record.RepositoryCLAGroupID = input.ClaGroupID
+	} else if record.Enabled {
+		log.WithFields(f).Warnf("no CLA Group for the new namespace : %s, disabling the repository : %s", newNamespace, newFullPath)
+		record.Enabled = false
+		note = note + " - disabled, no CLA Group for the new namespace"
+	}
+	record.Note = appendRepositoryNote(record.Note, note)
+	if err = s.gitV2Repository.GitLabUpdateRepository(ctx, record); err != nil {
+		return nil, fmt.Errorf("repository : %s transfer failed for namespace : %s : %v", record.RepositoryID, newNamespace, err)
+	}
+
+	s.eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:   events.RepositoryTransferred,
+		ProjectSFID: record.ProjectSFID,
+		CLAGroupID:  record.RepositoryCLAGroupID,
+		LfUsername:  utils.GetUserNameFromContext(ctx),
+		EventData: &events.RepositoryTransferredEventData{
+			RepositoryName:   newFullPath,
+			OldGithubOrgName: oldNamespace,
+			NewGithubOrgName: newNamespace,
+			RepositoryType:   utils.GitLabRepositoryType,
+		},
+	})
+	if wasEnabled && !record.Enabled {
+		s.eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.RepositoryDisabled,
+			ProjectSFID: record.ProjectSFID,
+			CLAGroupID:  record.RepositoryCLAGroupID,
+			LfUsername:  utils.GetUserNameFromContext(ctx),
+			EventData: &events.RepositoryDisabledEventData{
+				RepositoryName:       newFullPath,
+				RepositoryExternalID: input.RepositoryExternalID,
+				RepositoryType:       utils.GitLabRepositoryType,
+			},
+		})
+	}
+
+	if wasEnabled {
+		s.notifyGitLabRepositoryCLAManagers(ctx, f, oldClaGroupID, "EasyCLA: GitLab Repository Was Transferred", func() (string, error) {
+			return emails.RenderGitLabRepositoryTransferredTemplate(s.emailService, oldClaGroupID, transferredTemplateParams, record.Enabled)
+		})
+	}
+
+	return dbModelToGitLabRepository(record)
+}
+
+// GitLabSetRepositoryArchived follows the archive and unarchive of the GitLab project, the repository keeps its CLA
+// Group enrollment - the archived projects are read-only
+func (s *Service) GitLabSetRepositoryArchived(ctx context.Context, repositoryExternalID int64, archived bool) error {
+	f := logrus.Fields{
+		"functionName":         "v2.repositories.gitlab_lifecycle.GitLabSetRepositoryArchived",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"repositoryExternalID": repositoryExternalID,
+		"archived":             archived,
+	}
+
+	record, err := s.gitV2Repository.GitLabGetRepositoryByExternalID(ctx, repositoryExternalID)
+	if err != nil {
+		return err
+	}
+	if record.IsArchived == archived {
+		log.WithFields(f).Debug("repository archived flag unchanged")
+		return nil
+	}
+
+	log.WithFields(f).Infof("setting archived flag of GitLab repository : %s to : %t", record.RepositoryFullPath, archived)
+	record.IsArchived = archived
+	if archived {
+		record.Note = appendRepositoryNote(record.Note, "repository was archived externally")
+	} else {
+		record.Note = appendRepositoryNote(record.Note, "repository was unarchived externally")
+	}
+	if err = s.gitV2Repository.GitLabUpdateRepository(ctx, record); err != nil {
+		return err
+	}
+
+	eventType := events.RepositoryArchived
+	if !archived {
+		eventType = events.RepositoryUnarchived
+	}
+	s.eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:   eventType,
+		ProjectSFID: record.ProjectSFID,
+		CLAGroupID:  record.RepositoryCLAGroupID,
+		LfUsername:  utils.GetUserNameFromContext(ctx),
+		EventData: &events.RepositoryArchivedEventData{
+			RepositoryName: record.RepositoryFullPath,
+			RepositoryType: utils.GitLabRepositoryType,
+			Archived:       archived,
+		},
+	})
+
+	if archived && record.Enabled {
+		s.notifyGitLabRepositoryCLAManagers(ctx, f, record.RepositoryCLAGroupID, "EasyCLA: GitLab Repository Was Archived", func() (string, error) {
+			return emails.RenderGitLabRepositoryArchivedTemplate(s.emailService, record.RepositoryCLAGroupID, emails.GitLabRepositoryArchivedTemplateParams{
+				GitLabRepositoryActionTemplateParams: gitLabRepositoryActionTemplateParams(record.RepositoryFullPath),
+			})
+		})
+	}
+
+	return nil
+}
+
+// GitLabRemoteDeleteRepository follows the delete of the GitLab project, the repository is removed. The delete is
+// confirmed with the GitLab API first, the repository is kept while the project still exists.
+func (s *Service) GitLabRemoteDeleteRepository(ctx context.Context, repositoryExternalID int64) error {
+	f := logrus.Fields{
+		"functionName":         "v2.repositories.gitlab_lifecycle.GitLabRemoteDeleteRepository",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"repositoryExternalID": repositoryExternalID,
+	}
+
+	record, err := s.gitV2Repository.GitLabGetRepositoryByExternalID(ctx, repositoryExternalID)
+	if err != nil {
+		if _, ok := err.(*utils.GitLabRepositoryNotFound); ok {
+			log.WithFields(f).Debug("event for non existing local repo, nothing to do")
+			return nil
+		}
+		return err
+	}
+
+	project, err := s.getRemoteGitLabProject(ctx, record, nil)
+	if err != nil {
+		return err
+	}
+	if project != nil {
+		log.WithFields(f).Warnf("GitLab project : %s still exists - not removing the repository", project.PathWithNamespace)
+		return nil
+	}
+
+	log.WithFields(f).Infof("removing deleted GitLab repository : %s", record.RepositoryFullPath)
+	if err = s.GitLabDeleteRepositoryByExternalID(ctx, repositoryExternalID); err != nil {
+		return err
+	}
+
+	if record.Enabled {
+		s.notifyGitLabRepositoryCLAManagers(ctx, f, record.RepositoryCLAGroupID, "EasyCLA: GitLab Repository Was Removed", func() (string, error) {
+			return emails.RenderGitLabRepositoryDeletedTemplate(s.emailService, record.RepositoryCLAGroupID, emails.GitLabRepositoryDeletedTemplateParams{
+				GitLabRepositoryActionTemplateParams: gitLabRepositoryActionTemplateParams(record.RepositoryFullPath),
+			})
+		})
+	}
+
+	return nil
+}
+
+// confirmGitLabTransfer loads the transferred GitLab project with the GitLab API and returns its actual path and URL,
+// and false when it doesn't live under the new namespace. A project transferred under a registered GitLab
+// group/organization must be found under it, a project transferred out of the registered groups must be gone from the
+// group/organization of the repository.
+func (s *Service) confirmGitLabTransfer(ctx context.Context, record *repoModels.RepositoryDBModel, input *GitLabTransferRepoModel) (string, string, bool, error) {
+	if input.GitLabOrganization != nil {
+		project, err := s.getRemoteGitLabProject(ctx, record, input.GitLabOrganization)
+		if err != nil || project == nil || !gitLabApi.IsProjectUnderGroup(project.PathWithNamespace, input.GitLabOrganization.OrganizationFullPath) {
+			return "", "", false, err
+		}
+		return project.PathWithNamespace, project.WebURL, true, nil
+	}
+
+	gitLabOrg, err := s.getGitLabRepositoryOrganization(ctx, record)
+	if err != nil {
+		return "", "", false, err
+	}
+	project, err := s.getRemoteGitLabProject(ctx, record, gitLabOrg)
+	if err != nil {
+		return "", "", false, err
+	}
+	if project == nil {
+		// not visible to the group/organization anymore
+		return input.NewFullPath, input.NewURL, true, nil
+	}
+	if gitLabApi.IsProjectUnderGroup(project.PathWithNamespace, gitLabOrg.OrganizationFullPath) {
+		return "", "", false, nil
+	}
+	return project.PathWithNamespace, project.WebURL, true, nil
+}
+
+// getRemoteGitLabProject loads the GitLab project of the repository with the client of the GitLab
+// group/organization, the group/organization of the repository when nil. Returns nil when the project doesn't exist,
+// or isn't visible to the group/organization anymore.
+func (s *Service) getRemoteGitLabProject(ctx context.Context, record *repoModels.RepositoryDBModel, gitLabOrg *common.GitLabOrganization) (*goGitLab.Project, error) {
+	if gitLabOrg == nil {
+		var err error
+		gitLabOrg, err = s.getGitLabRepositoryOrganization(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	repositoryExternalID, err := strconv.Atoi(record.RepositoryExternalID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid external ID : %s of repository : %s : %v", record.RepositoryExternalID, record.RepositoryID, err)
+	}
+
+	gitLabClient, err := s.newGitLabClient(ctx, gitLabOrg)
+	if err != nil {
+		return nil, fmt.Errorf("initializing GitLab client : %v", err)
+	}
+
+	project, err := gitLabApi.GetProjectByID(ctx, gitLabClient, repositoryExternalID)
+	if err != nil {
+		if errors.Is(err, gitLabApi.ErrProjectNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return project, nil
+}
+
+// getGitLabRepositoryOrganization returns the GitLab group/organization of the repository
+func (s *Service) getGitLabRepositoryOrganization(ctx context.Context, record *repoModels.RepositoryDBModel) (*common.GitLabOrganization, error) {
+	gitLabOrg, err := s.glOrgRepo.GetGitLabOrganizationByName(ctx, record.RepositoryOrganizationName)
+	if err != nil {
+		return nil, err
+	}
+	if gitLabOrg == nil {
+		return nil, fmt.Errorf("GitLab group/organization : %s of repository : %s not found", record.RepositoryOrganizationName, record.RepositoryID)
+	}
+	return gitLabOrg, nil
+}
+
+// notifyGitLabRepositoryCLAManagers sends the GitLab repository lifecycle email to the CLA Managers of the CLA Group,
+// the problems are only logged
+func (s *Service) notifyGitLabRepositoryCLAManagers(ctx context.Context, f logrus.Fields, claGroupID, subject string, render func() (string, error)) {
+	if s.emailService == nil || claGroupID == "" {
+		return
+	}
+
+	body, err := render()
+	if err != nil {
+		log.WithFields(f).Warnf("rendering email template failed : %v", err)
+		return
+	}
+
+	if err := s.emailService.NotifyClaManagersForClaGroupID(ctx, claGroupID, subject, body); err != nil {
+		log.WithFields(f).Warnf("notifying cla managers via email failed : %v", err)
+	}
+}
+
+// gitLabRepositoryActionTemplateParams returns the common email params of the GitLab repository lifecycle emails
+func gitLabRepositoryActionTemplateParams(repositoryName string) emails.GitLabRepositoryActionTemplateParams {
+	return emails.GitLabRepositoryActionTemplateParams{
+		CommonEmailParams: emails.CommonEmailParams{
+			RecipientName: "CLA Manager",
+		},
+		RepositoryName: repositoryName,
+	}
+}
+
+// appendRepositoryNote appends the dated note to the existing note of the repository record
+func appendRepositoryNote(existingNote, note string) string {
+	_, now := utils.CurrentTime()
+	note = fmt.Sprintf("%s on %s.", note, now)
+	existingNote = strings.TrimSpace(existingNote)
+	if existingNote == "" {
+		return note
+	}
+	if !strings.HasSuffix(existingNote, ".") {
+		existingNote = existingNote + "."
+	}
+	return existingNote + " " + note
+}
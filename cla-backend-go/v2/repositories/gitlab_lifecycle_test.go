@@ -0,0 +1,334 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	gitLabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
+	repoModels "github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_instances"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRepositoryExternalID = int64(4242)
+	testClaGroupID           = "d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b"
+	testOtherClaGroupID      = "7f3b5e2a-1c4d-4e8f-9a6b-2d1c3e4f5a6b"
+)
+
+type fakeGitLabRepository struct {
+	RepositoryInterface
+	records map[int64]*repoModels.RepositoryDBModel
+	updates int
+}
+
+func (r *fakeGitLabRepository) GitLabGetRepositoryByExternalID(ctx context.Context, repositoryExternalID int64) (*repoModels.RepositoryDBModel, error) {
+	record, ok := r.records[repositoryExternalID]
+	if !ok {
+		return nil, &utils.GitLabRepositoryNotFound{RepositoryExternalID: repositoryExternalID}
+	}
+	// the service changes the loaded record, only the updates are stored
+	loaded := *record
+	return &loaded, nil
+}
+
+func (r *fakeGitLabRepository) GitLabUpdateRepository(ctx context.Context, input *repoModels.RepositoryDBModel) error {
+	externalID, err := strconv.ParseInt(input.RepositoryExternalID, 10, 64)
+	if err != nil {
+		return err
+	}
+	stored := *input
+	r.records[externalID] = &stored
+	r.updates++
+	return nil
+}
+
+func (r *fakeGitLabRepository) GitLabDeleteRepositoryByExternalID(ctx context.Context, gitLabExternalID int64) error {
+	delete(r.records, gitLabExternalID)
+	return nil
+}
+
+type fakeGitLabOrgRepo struct {
+	GitLabOrgRepo
+	orgs []*common.GitLabOrganization
+}
+
+func (r *fakeGitLabOrgRepo) GetGitLabOrganizationByName(ctx context.Context, gitLabOrganizationName string) (*common.GitLabOrganization, error) {
+	for _, org := range r.orgs {
+		if org.OrganizationName == gitLabOrganizationName {
+			return org, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeGitLabInstanceService struct {
+	gitlab_instances.ServiceInterface
+	instance *gitLabApi.Instance
+}
+
+func (s *fakeGitLabInstanceService) GetInstance(ctx context.Context, instanceID string) (*gitLabApi.Instance, error) {
+	return s.instance, nil
+}
+
+type fakeEventService struct {
+	events.Service
+	eventTypes []string
+}
+
+func (s *fakeEventService) LogEventWithContext(ctx context.Context, args *events.LogEventArgs) {
+	s.eventTypes = append(s.eventTypes, args.EventType)
+}
+
+type fakeEmailService struct {
+	emails.Service
+	notified []string
+}
+
+func (s *fakeEmailService) GetCLAGroupTemplateParamsFromCLAGroup(claGroupID string) (emails.CLAGroupTemplateParams, error) {
+	return emails.CLAGroupTemplateParams{CLAGroupName: "JohnsProject", Version: utils.V2}, nil
+}
+
+func (s *fakeEmailService) NotifyClaManagersForClaGroupID(ctx context.Context, claGroupID, subject, body string) error {
+	s.notified = append(s.notified, claGroupID+" "+subject)
+	return nil
+}
+
+type lifecycleTest struct {
+	service      *Service
+	repo         *fakeGitLabRepository
+	eventService *fakeEventService
+	emailService *fakeEmailService
+	// projects are the GitLab projects served by the GitLab API, by project ID
+	projects map[int64]string
+}
+
+// newLifecycleTest creates the service with the repository of the GitLab project 4242 under the johnsGroup GitLab
+// group, enrolled in the CLA Group, and a GitLab API serving the projects of the test
+func newLifecycleTest(t *testing.T, enabled bool) *lifecycleTest {
+	gitLabApp := gitLabApi.Init("124453345", "124453345", "0WqnDWHnZKo2cmQ8m93EtY9ZBpfzQW4UnnEuRmgtJKM=")
+	authInfo, err := gitLabApi.EncryptAuthInfo(&gitLabApi.OauthSuccessResponse{AccessToken: "access-token", TokenType: "Bearer"}, gitLabApp)
+	assert.NoError(t, err)
+
+	test := &lifecycleTest{
+		repo: &fakeGitLabRepository{records: map[int64]*repoModels.RepositoryDBModel{
+			testRepositoryExternalID: {
+				RepositoryID:               "repository-1",
+				RepositoryExternalID:       strconv.FormatInt(testRepositoryExternalID, 10),
+				RepositoryName:             "johnsGroup/johnsRepository",
+				RepositoryFullPath:         "johnsGroup/johnsRepository",
+				RepositoryURL:              "https://gitlab.example.org/johnsGroup/johnsRepository",
+				RepositoryOrganizationName: "johnsGroup",
+				RepositoryCLAGroupID:       testClaGroupID,
+				ProjectSFID:                "a092M00001IV4RGQA1",
+				Enabled:                    enabled,
+			},
+		}},
+		eventService: &fakeEventService{},
+		emailService: &fakeEmailService{},
+		projects:     map[int64]string{},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		projectID, parseErr := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/api/v4/projects/"), 10, 64)
+		fullPath, ok := test.projects[projectID]
+		if parseErr != nil || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":                  projectID,
+			"path_with_namespace": fullPath,
+			"web_url":             "https://gitlab.example.org/" + fullPath,
+		}))
+	}))
+	t.Cleanup(server.Close)
+
+	test.service = &Service{
+		gitV2Repository: test.repo,
+		glOrgRepo: &fakeGitLabOrgRepo{orgs: []*common.GitLabOrganization{
+			{OrganizationName: "johnsGroup", OrganizationFullPath: "johnsGroup", ProjectSFID: "a092M00001IV4RGQA1", AuthInfo: authInfo},
+		}},
+		glInstanceService: &fakeGitLabInstanceService{instance: &gitLabApi.Instance{InstanceID: "gitlab.example.org", BaseURL: server.URL}},
+		gitLabApp:         gitLabApp,
+		eventService:      test.eventService,
+		emailService:      test.emailService,
+	}
+	return test
+}
+
+func (test *lifecycleTest) record() *repoModels.RepositoryDBModel {
+	return test.repo.records[testRepositoryExternalID]
+}
+
+func TestGitLabSetRepositoryArchived(t *testing.T) {
+	ctx := context.Background()
+	test := newLifecycleTest(t, true)
+
+	assert.NoError(t, test.service.GitLabSetRepositoryArchived(ctx, testRepositoryExternalID, true))
+	assert.True(t, test.record().IsArchived)
+	assert.True(t, test.record().Enabled)
+	assert.Equal(t, testClaGroupID, test.record().RepositoryCLAGroupID)
+	assert.Contains(t, test.record().Note, "repository was archived externally")
+	assert.Equal(t, []string{events.RepositoryArchived}, test.eventService.eventTypes)
+	assert.Equal(t, []string{testClaGroupID + " EasyCLA: GitLab Repository Was Archived"}, test.emailService.notified)
+
+	// a duplicate event changes nothing
+	assert.NoError(t, test.service.GitLabSetRepositoryArchived(ctx, testRepositoryExternalID, true))
+	assert.Equal(t, 1, test.repo.updates)
+	assert.Len(t, test.eventService.eventTypes, 1)
+}
+
+func TestGitLabSetRepositoryUnarchived(t *testing.T) {
+	ctx := context.Background()
+	test := newLifecycleTest(t, true)
+	test.record().IsArchived = true
+
+	// the unarchived project is writable again, it keeps its CLA Group enrollment - no email is sent
+	assert.NoError(t, test.service.GitLabSetRepositoryArchived(ctx, testRepositoryExternalID, false))
+	assert.False(t, test.record().IsArchived)
+	assert.True(t, test.record().Enabled)
+	assert.Equal(t, testClaGroupID, test.record().RepositoryCLAGroupID)
+	assert.Contains(t, test.record().Note, "repository was unarchived externally")
+	assert.Equal(t, []string{events.RepositoryUnarchived}, test.eventService.eventTypes)
+	assert.Empty(t, test.emailService.notified)
+}
+
+func TestGitLabTransferRepository(t *testing.T) {
+	ctx := context.Background()
+	otherGroup := &common.GitLabOrganization{OrganizationName: "otherGroup", OrganizationFullPath: "otherGroup", ProjectSFID: "a092M00001IV4RHQA1"}
+
+	t.Run("to a registered group with a CLA Group", func(t *testing.T) {
+		test := newLifecycleTest(t, true)
+		test.projects[testRepositoryExternalID] = "otherGroup/johnsRepository"
+
+		result, err := test.service.GitLabTransferRepository(ctx, &GitLabTransferRepoModel{
+			RepositoryExternalID: testRepositoryExternalID,
+			NewFullPath:          "otherGroup/johnsRepository",
+			GitLabOrganization:   otherGroup,
+			ClaGroupID:           testOtherClaGroupID,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, "otherGroup/johnsRepository", test.record().RepositoryFullPath)
+		assert.Equal(t, "https://gitlab.example.org/otherGroup/johnsRepository", test.record().RepositoryURL)
+		assert.Equal(t, "otherGroup", test.record().RepositoryOrganizationName)
+		assert.Equal(t, "a092M00001IV4RHQA1", test.record().ProjectSFID)
+		assert.Equal(t, testOtherClaGroupID, test.record().RepositoryCLAGroupID)
+		assert.True(t, test.record().Enabled)
+		assert.Equal(t, []string{events.RepositoryTransferred}, test.eventService.eventTypes)
+		assert.Equal(t, []string{testClaGroupID + " EasyCLA: GitLab Repository Was Transferred"}, test.emailService.notified)
+	})
+
+	t.Run("to a registered group without a CLA Group", func(t *testing.T) {
+		test := newLifecycleTest(t, true)
+		test.projects[testRepositoryExternalID] = "otherGroup/johnsRepository"
+
+		_, err := test.service.GitLabTransferRepository(ctx, &GitLabTransferRepoModel{
+			RepositoryExternalID: testRepositoryExternalID,
+			NewFullPath:          "otherGroup/johnsRepository",
+			GitLabOrganization:   otherGroup,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "otherGroup/johnsRepository", test.record().RepositoryFullPath)
+		assert.False(t, test.record().Enabled)
+		assert.Contains(t, test.record().Note, "disabled, no CLA Group for the new namespace")
+		assert.Equal(t, []string{events.RepositoryTransferred, events.RepositoryDisabled}, test.eventService.eventTypes)
+		assert.Len(t, test.emailService.notified, 1)
+	})
+
+	t.Run("out of the registered groups", func(t *testing.T) {
+		test := newLifecycleTest(t, true)
+
+		result, err := test.service.GitLabTransferRepository(ctx, &GitLabTransferRepoModel{
+			RepositoryExternalID: testRepositoryExternalID,
+			NewFullPath:          "someoneElse/johnsRepository",
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+		assert.Nil(t, test.record())
+		assert.Equal(t, []string{events.RepositoryDeleted}, test.eventService.eventTypes)
+		assert.Len(t, test.emailService.notified, 1)
+	})
+
+	t.Run("not confirmed by the GitLab API", func(t *testing.T) {
+		test := newLifecycleTest(t, true)
+		// the project still lives under its group
+		test.projects[testRepositoryExternalID] = "johnsGroup/johnsRepository"
+
+		_, err := test.service.GitLabTransferRepository(ctx, &GitLabTransferRepoModel{
+			RepositoryExternalID: testRepositoryExternalID,
+			NewFullPath:          "someoneElse/johnsRepository",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "johnsGroup/johnsRepository", test.record().RepositoryFullPath)
+		assert.Equal(t, 0, test.repo.updates)
+		assert.Empty(t, test.eventService.eventTypes)
+		assert.Empty(t, test.emailService.notified)
+	})
+}
+
+func TestGitLabTransferRepositoryReEnrolled(t *testing.T) {
+	ctx := context.Background()
+	// a repository disabled by an earlier transfer moves to a namespace with a CLA Group
+	test := newLifecycleTest(t, false)
+	test.record().RepositoryCLAGroupID = ""
+	test.projects[testRepositoryExternalID] = "otherGroup/johnsRepository"
+
+	_, err := test.service.GitLabTransferRepository(ctx, &GitLabTransferRepoModel{
+		RepositoryExternalID: testRepositoryExternalID,
+		NewFullPath:          "otherGroup/johnsRepository",
+		GitLabOrganization:   &common.GitLabOrganization{OrganizationName: "otherGroup", OrganizationFullPath: "otherGroup", ProjectSFID: "a092M00001IV4RHQA1"},
+		ClaGroupID:           testOtherClaGroupID,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testOtherClaGroupID, test.record().RepositoryCLAGroupID)
+	// the repository takes the CLA Group of the new namespace, enabling it is left to the CLA Managers
+	assert.False(t, test.record().Enabled)
+	assert.Equal(t, []string{events.RepositoryTransferred}, test.eventService.eventTypes)
+	assert.Empty(t, test.emailService.notified)
+}
+
+func TestGitLabRemoteDeleteRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deleted project", func(t *testing.T) {
+		test := newLifecycleTest(t, true)
+
+		assert.NoError(t, test.service.GitLabRemoteDeleteRepository(ctx, testRepositoryExternalID))
+		assert.Nil(t, test.record())
+		assert.Equal(t, []string{events.RepositoryDeleted}, test.eventService.eventTypes)
+		assert.Equal(t, []string{testClaGroupID + " EasyCLA: GitLab Repository Was Removed"}, test.emailService.notified)
+	})
+
+	t.Run("project still exists", func(t *testing.T) {
+		test := newLifecycleTest(t, true)
+		test.projects[testRepositoryExternalID] = "johnsGroup/johnsRepository"
+
+		assert.NoError(t, test.service.GitLabRemoteDeleteRepository(ctx, testRepositoryExternalID))
+		assert.NotNil(t, test.record())
+		assert.Empty(t, test.eventService.eventTypes)
+		assert.Empty(t, test.emailService.notified)
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		test := newLifecycleTest(t, true)
+
+		assert.NoError(t, test.service.GitLabRemoteDeleteRepository(ctx, 4343))
+		assert.NotNil(t, test.record())
+		assert.Empty(t, test.eventService.eventTypes)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/sirupsen/logrus"
+	goGitLab "github.com/xanzy/go-gitlab"
 
 	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	repoModels "github.com/communitybridge/easycla/cla-backend-go/repositories"
@@ -70,7 +71,7 @@ func (s *Service) GitLabAddRepositoriesWithEnabledFlag(ctx context.Context, proj
 	log.WithFields(f).Debugf("successfully loaded GitLab group/organization")
 
 	// Get the client
-	gitLabClient, err := gitLabApi.NewGitlabOauthClient(gitLabOrgModel.AuthInfo, s.gitLabApp)
+	gitLabClient, err := s.newGitLabClient(ctx, gitLabOrgModel)
 	if err != nil {
 		return nil, fmt.Errorf("initializing GitLab client : %v", err)
 	}
@@ -177,7 +178,7 @@ func (s *Service) GitLabAddRepositoriesByApp(ctx context.Context, gitLabOrgModel
 	}
 
 	// Get the client
-	gitLabClient, err := gitLabApi.NewGitlabOauthClient(gitLabOrgModel.AuthInfo, s.gitLabApp)
+	gitLabClient, err := s.newGitLabClient(ctx, gitLabOrgModel)
 	if err != nil {
 		return nil, fmt.Errorf("initializing gitlab client : %v", err)
 	}
@@ -420,7 +421,7 @@ func (s *Service) GitLabDeleteRepositoryByExternalID(ctx context.Context, gitLab
 
 	// Convert the external ID value
 	repositoryExternalID, parseIntErr := strconv.ParseInt(record.RepositoryExternalID, 10, 64)
-	if err == nil {
+	if parseIntErr != nil {
 		return parseIntErr
 	}
 
@@ -433,6 +434,7 @@ func (s *Service) GitLabDeleteRepositoryByExternalID(ctx context.Context, gitLab
 		EventData: &events.RepositoryDeletedEventData{
 			RepositoryName:       record.RepositoryFullPath, // give the full path/name
 			RepositoryExternalID: repositoryExternalID,
+			RepositoryType:       utils.GitLabRepositoryType,
 		},
 	})
 	return err
@@ -457,6 +459,7 @@ func dbModelToGitLabRepository(dbModel *repoModels.RepositoryDBModel) (*v2Models
 		RepositoryURL:              dbModel.RepositoryURL,              // full url
 		RepositoryType:             dbModel.RepositoryType,             // gitlab
 		Enabled:                    dbModel.Enabled,                    // Enabled flag
+		Archived:                   dbModel.IsArchived,                 // Archived flag
 		DateCreated:                dbModel.DateCreated,                // date created
 		DateModified:               dbModel.DateModified,               // date updated
 		Note:                       dbModel.Note,                       // Optional note
@@ -479,3 +482,12 @@ func dbModelsToGitLabRepositories(dbModels []*repoModels.RepositoryDBModel) ([]*
 	}
 	return responses, nil
 }
+
+// newGitLabClient creates the GitLab client of the organization on the GitLab instance of the organization
+func (s *Service) newGitLabClient(ctx context.Context, gitLabOrgModel *v2GitLabOrg.GitLabOrganization) (*goGitLab.Client, error) {
+	instance, err := s.glInstanceService.GetInstance(ctx, gitLabOrgModel.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	return gitLabApi.NewGitlabOauthClient(gitLabOrgModel.AuthInfo, s.gitLabApp, instance)
+}
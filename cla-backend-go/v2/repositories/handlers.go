@@ -10,6 +10,7 @@ import (
 
 	project_service "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
 
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/bitbucket_repositories"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/gitlab_repositories"
 
 	"github.com/communitybridge/easycla/cla-backend-go/github/branch_protection"
@@ -561,4 +562,141 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 
 			return gitlab_repositories.NewEnrollGitLabRepositoryOK().WithPayload(repoList)
 		})
+
+	api.BitbucketRepositoriesGetProjectBitbucketRepositoriesHandler = bitbucket_repositories.GetProjectBitbucketRepositoriesHandlerFunc(
+		func(params bitbucket_repositories.GetProjectBitbucketRepositoriesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.repositories.handlers.BitbucketRepositoriesGetProjectBitbucketRepositoriesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+			}
+
+			// Load the project
+			psc := project_service.GetClient()
+			projectModel, err := psc.GetProject(params.ProjectSFID)
+			if err != nil || projectModel == nil {
+				return bitbucket_repositories.NewGetProjectBitbucketRepositoriesNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate project with ID: %s", params.ProjectSFID)))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Get Bitbucket Repositories for Project %s with scope of %s",
+					authUser.UserName, projectModel.Name, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return bitbucket_repositories.NewGetProjectBitbucketRepositoriesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.BitbucketGetRepositoriesByProjectSFID(ctx, params.ProjectSFID)
+			if err != nil {
+				msg := fmt.Sprintf("problem looking up Bitbucket repositories for projectSFID: %s", params.ProjectSFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bitbucket_repositories.NewGetProjectBitbucketRepositoriesBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return bitbucket_repositories.NewGetProjectBitbucketRepositoriesOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.BitbucketRepositoriesEnrollBitbucketRepositoriesHandler = bitbucket_repositories.EnrollBitbucketRepositoriesHandlerFunc(
+		func(params bitbucket_repositories.EnrollBitbucketRepositoriesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			input := params.BitbucketRepositoriesEnroll
+			organizationID := utils.StringValue(input.OrganizationID)
+			f := logrus.Fields{
+				"functionName":   "v2.repositories.handlers.BitbucketRepositoriesEnrollBitbucketRepositoriesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"organizationID": organizationID,
+				"claGroupID":     input.ClaGroupID,
+			}
+
+			// Load the project
+			psc := project_service.GetClient()
+			projectModel, err := psc.GetProject(params.ProjectSFID)
+			if err != nil || projectModel == nil {
+				return bitbucket_repositories.NewEnrollBitbucketRepositoriesNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate project with ID: %s", params.ProjectSFID)))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Enroll Bitbucket Repositories for Project '%s' with scope of %s",
+					authUser.UserName, projectModel.Name, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return bitbucket_repositories.NewEnrollBitbucketRepositoriesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			if len(input.Enroll) == 0 && len(input.Unenroll) == 0 {
+				msg := "missing Bitbucket repository slugs for enroll or unenroll - should have at least one value in either list"
+				return bitbucket_repositories.NewEnrollBitbucketRepositoriesBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+			}
+
+			if duplicates := utils.FindStringDuplicates(input.Enroll, input.Unenroll); len(duplicates) > 0 {
+				msg := fmt.Sprintf("found duplicate entries in both enroll and unenroll: %+v", duplicates)
+				return bitbucket_repositories.NewEnrollBitbucketRepositoriesBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+			}
+
+			enrollments := []struct {
+				slugs       []string
+				enrollValue bool
+			}{
+				{slugs: input.Enroll, enrollValue: true},
+				{slugs: input.Unenroll, enrollValue: false},
+			}
+			for _, enrollment := range enrollments {
+				if len(enrollment.slugs) == 0 {
+					continue
+				}
+
+				log.WithFields(f).Debugf("setting the enrolled flag of the Bitbucket repositories %+v to %t", enrollment.slugs, enrollment.enrollValue)
+				enrollErr := service.BitbucketEnrollRepositories(ctx, params.ProjectSFID, organizationID, input.ClaGroupID, enrollment.slugs, enrollment.enrollValue)
+				if enrollErr != nil {
+					if errors.Is(enrollErr, ErrBitbucketOrganizationNotFound) {
+						msg := fmt.Sprintf("bitbucket organization %s not found for projectSFID: %s", organizationID, params.ProjectSFID)
+						return bitbucket_repositories.NewEnrollBitbucketRepositoriesNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+					}
+					msg := fmt.Sprintf("problem enrolling Bitbucket repositories for projectSFID: %s", params.ProjectSFID)
+					log.WithFields(f).WithError(enrollErr).Warn(msg)
+					return bitbucket_repositories.NewEnrollBitbucketRepositoriesBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, enrollErr))
+				}
+
+				for _, slug := range enrollment.slugs {
+					var eventData events.EventData = &events.RepositoryDisabledEventData{
+						RepositoryName: slug,
+						RepositoryType: utils.BitbucketRepositoryType,
+					}
+					eventType := events.RepositoryDisabled
+					if enrollment.enrollValue {
+						eventType = events.RepositoryAdded
+						eventData = &events.RepositoryAddedEventData{
+							RepositoryName: slug,
+							RepositoryType: utils.BitbucketRepositoryType,
+						}
+					}
+					eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+						EventType:   eventType,
+						ProjectSFID: params.ProjectSFID,
+						CLAGroupID:  input.ClaGroupID,
+						LfUsername:  authUser.UserName,
+						EventData:   eventData,
+					})
+				}
+			}
+
+			repoList, getErr := service.BitbucketGetRepositoriesByProjectSFID(ctx, params.ProjectSFID)
+			if getErr != nil {
+				msg := fmt.Sprintf("problem fetching Bitbucket repositories for projectSFID: %s", params.ProjectSFID)
+				log.WithFields(f).WithError(getErr).Warn(msg)
+				return bitbucket_repositories.NewEnrollBitbucketRepositoriesBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, getErr))
+			}
+
+			return bitbucket_repositories.NewEnrollBitbucketRepositoriesOK().WithXRequestID(reqID).WithPayload(repoList)
+		})
 }
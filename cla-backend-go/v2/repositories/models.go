@@ -3,6 +3,8 @@
 
 package repositories
 
+import "github.com/communitybridge/easycla/cla-backend-go/v2/common"
+
 // GitLabAddRepoModel data model for GitLab add repository
 type GitLabAddRepoModel struct {
 	ClaGroupID    string
@@ -11,3 +13,15 @@ type GitLabAddRepoModel struct {
 	GroupFullPath string
 	ProjectIDList []int64
 }
+
+// GitLabTransferRepoModel data model for a GitLab project transferred to another namespace
+type GitLabTransferRepoModel struct {
+	RepositoryExternalID int64
+	NewFullPath          string
+	NewURL               string
+	// GitLabOrganization is the registered GitLab group/organization the project was transferred under, nil when the
+	// project was transferred out of the registered groups
+	GitLabOrganization *common.GitLabOrganization
+	// ClaGroupID is the CLA Group applying to the new namespace, empty when the new namespace has none
+	ClaGroupID string
+}
@@ -39,6 +39,14 @@ type RepositoryInterface interface {
 	GitLabEnableCLAGroupRepositories(ctx context.Context, claGroupID string, enrollValue bool) error
 	GitLabDeleteRepositories(ctx context.Context, gitLabGroupPath string) error
 	GitLabDeleteRepositoryByExternalID(ctx context.Context, gitLabExternalID int64) error
+	GitLabUpdateRepository(ctx context.Context, input *repoModels.RepositoryDBModel) error
+
+	BitbucketGetRepositoriesByOrganizationName(ctx context.Context, organizationKey string) ([]*repoModels.RepositoryDBModel, error)
+	BitbucketGetRepositoriesByProjectSFID(ctx context.Context, projectSFID string) ([]*repoModels.RepositoryDBModel, error)
+	BitbucketGetRepositoryByExternalID(ctx context.Context, organizationKey, repositoryExternalID string) (*repoModels.RepositoryDBModel, error)
+	BitbucketAddRepository(ctx context.Context, input *repoModels.RepositoryDBModel) (*repoModels.RepositoryDBModel, error)
+	BitbucketSetRepositoryEnabled(ctx context.Context, repositoryID, claGroupID string, enabled bool) error
+	BitbucketDeleteRepository(ctx context.Context, repositoryID string) error
 }
 
 // Repository object/struct
@@ -497,6 +505,197 @@ func (r *Repository) GitLabDeleteRepositoryByExternalID(ctx context.Context, git
 	return deleteErr
 }
 
+// GitLabUpdateRepository stores the path, organization, CLA Group and state of the GitLab repository record, used to
+// follow the GitLab project rename, transfer and archive
+func (r *Repository) GitLabUpdateRepository(ctx context.Context, input *repoModels.RepositoryDBModel) error {
+	f := logrus.Fields{
+		"functionName":         "v2.repositories.repository.GitLabUpdateRepository",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"repositoryID":         input.RepositoryID,
+		"repositoryExternalID": input.RepositoryExternalID,
+		"repositoryFullPath":   input.RepositoryFullPath,
+	}
+
+	_, now := utils.CurrentTime()
+	update := expression.Set(expression.Name(repoModels.RepositoryNameColumn), expression.Value(input.RepositoryName)).
+		Set(expression.Name(repoModels.RepositoryFullPathColumn), expression.Value(input.RepositoryFullPath)).
+		Set(expression.Name(repoModels.RepositoryURLColumn), expression.Value(input.RepositoryURL)).
+		Set(expression.Name(repoModels.RepositoryOrganizationNameColumn), expression.Value(input.RepositoryOrganizationName)).
+		Set(expression.Name(repoModels.RepositoryProjectIDColumn), expression.Value(input.ProjectSFID)).
+		Set(expression.Name(repoModels.RepositorySfdcIDColumn), expression.Value(input.RepositorySfdcID)).
+		Set(expression.Name(repoModels.RepositoryCLAGroupIDColumn), expression.Value(input.RepositoryCLAGroupID)).
+		Set(expression.Name(repoModels.RepositoryEnabledColumn), expression.Value(input.Enabled)).
+		Set(expression.Name(repoModels.RepositoryArchivedColumn), expression.Value(input.IsArchived)).
+		Set(expression.Name(repoModels.RepositoryNoteColumn), expression.Value(input.Note)).
+		Set(expression.Name(repoModels.RepositoryDateModifiedColumn), expression.Value(now))
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem creating builder")
+		return err
+	}
+
+	_, err = r.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			repoModels.RepositoryIDColumn: {S: aws.String(input.RepositoryID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		TableName:                 aws.String(r.repositoryTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem updating the gitlab repository")
+		return err
+	}
+
+	input.DateModified = now
+	return nil
+}
+
+// BitbucketGetRepositoriesByOrganizationName returns the Bitbucket repositories of the Bitbucket organization, an
+// empty list if none
+func (r *Repository) BitbucketGetRepositoriesByOrganizationName(ctx context.Context, organizationKey string) ([]*repoModels.RepositoryDBModel, error) {
+	condition := expression.Key(repoModels.RepositoryOrganizationNameColumn).Equal(expression.Value(organizationKey))
+	filter := expression.Name(repoModels.RepositoryTypeColumn).Equal(expression.Value(utils.BitbucketLower))
+	return r.getBitbucketRepositories(ctx, condition, filter, repoModels.RepositoryOrganizationNameIndex)
+}
+
+// BitbucketGetRepositoriesByProjectSFID returns the Bitbucket repositories of the project, an empty list if none
+func (r *Repository) BitbucketGetRepositoriesByProjectSFID(ctx context.Context, projectSFID string) ([]*repoModels.RepositoryDBModel, error) {
+	condition := expression.Key(repoModels.RepositoryProjectIDColumn).Equal(expression.Value(projectSFID))
+	filter := expression.Name(repoModels.RepositoryTypeColumn).Equal(expression.Value(utils.BitbucketLower))
+	return r.getBitbucketRepositories(ctx, condition, filter, repoModels.RepositoryProjectSFIDIndex)
+}
+
+// BitbucketGetRepositoryByExternalID returns the Bitbucket repository of the organization by the Bitbucket ID, nil if
+// not found. The numeric IDs of Bitbucket Data Center are only unique within the instance.
+func (r *Repository) BitbucketGetRepositoryByExternalID(ctx context.Context, organizationKey, repositoryExternalID string) (*repoModels.RepositoryDBModel, error) {
+	condition := expression.Key(repoModels.RepositoryExternalIDColumn).Equal(expression.Value(repositoryExternalID))
+	filter := expression.Name(repoModels.RepositoryTypeColumn).Equal(expression.Value(utils.BitbucketLower)).
+		And(expression.Name(repoModels.RepositoryOrganizationNameColumn).Equal(expression.Value(organizationKey)))
+	records, err := r.getBitbucketRepositories(ctx, condition, filter, repoModels.RepositoryExternalIDIndex)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return records[0], nil
+}
+
+// BitbucketAddRepository creates a new entry in the repositories table for the Bitbucket repository
+func (r *Repository) BitbucketAddRepository(ctx context.Context, input *repoModels.RepositoryDBModel) (*repoModels.RepositoryDBModel, error) {
+	f := logrus.Fields{
+		"functionName":               "v2.repositories.repositories.BitbucketAddRepository",
+		utils.XREQUESTID:             ctx.Value(utils.XREQUESTID),
+		"projectSFID":                input.ProjectSFID,
+		"repositoryExternalID":       input.RepositoryExternalID,
+		"repositoryName":             input.RepositoryName,
+		"repositoryOrganizationName": input.RepositoryOrganizationName,
+		"repositoryCLAGroupID":       input.RepositoryCLAGroupID,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	repoID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	input.RepositoryID = repoID.String()
+	input.RepositoryType = utils.BitbucketLower
+	input.DateCreated = currentTime
+	input.DateModified = currentTime
+	input.Note = fmt.Sprintf("created on %s", currentTime)
+	input.Version = "v1"
+
+	av, err := dynamodbattribute.MarshalMap(input)
+	if err != nil {
+		log.WithFields(f).Warnf("problem marshalling the input, error: %+v", err)
+		return nil, err
+	}
+
+	_, err = r.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(r.repositoryTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to add bitbucket repository")
+		return nil, err
+	}
+
+	return input, nil
+}
+
+// BitbucketSetRepositoryEnabled sets the enabled flag and the CLA group of the Bitbucket repository, the CLA group is
+// kept when empty
+func (r *Repository) BitbucketSetRepositoryEnabled(ctx context.Context, repositoryID, claGroupID string, enabled bool) error {
+	f := logrus.Fields{
+		"functionName":   "v2.repositories.repository.BitbucketSetRepositoryEnabled",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   repositoryID,
+		"claGroupID":     claGroupID,
+		"enabled":        enabled,
+	}
+
+	_, now := utils.CurrentTime()
+	update := expression.Set(expression.Name(repoModels.RepositoryEnabledColumn), expression.Value(enabled)).
+		Set(expression.Name(repoModels.RepositoryDateModifiedColumn), expression.Value(now))
+	if claGroupID != "" {
+		update = update.Set(expression.Name(repoModels.RepositoryCLAGroupIDColumn), expression.Value(claGroupID))
+	}
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem creating builder")
+		return err
+	}
+
+	_, err = r.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			repoModels.RepositoryIDColumn: {S: aws.String(repositoryID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		TableName:                 aws.String(r.repositoryTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem updating the bitbucket repository")
+	}
+
+	return err
+}
+
+// BitbucketDeleteRepository deletes the Bitbucket repository
+func (r *Repository) BitbucketDeleteRepository(ctx context.Context, repositoryID string) error {
+	_, err := r.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			repoModels.RepositoryIDColumn: {S: aws.String(repositoryID)},
+		},
+		TableName: aws.String(r.repositoryTableName),
+	})
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"functionName":   "v2.repositories.repository.BitbucketDeleteRepository",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"repositoryID":   repositoryID,
+		}).WithError(err).Warn("unable to delete bitbucket repository")
+	}
+	return err
+}
+
+// getBitbucketRepositories returns the repositories matching the condition and filter, an empty list if none
+func (r *Repository) getBitbucketRepositories(ctx context.Context, condition expression.KeyConditionBuilder, filter expression.ConditionBuilder, indexName string) ([]*repoModels.RepositoryDBModel, error) {
+	records, err := r.getRepositoriesWithConditionFilter(ctx, condition, filter, indexName)
+	if err != nil {
+		// the query helpers report the empty results with the GitLab not found error
+		if _, ok := err.(*utils.GitLabRepositoryNotFound); ok {
+			return []*repoModels.RepositoryDBModel{}, nil
+		}
+		return nil, err
+	}
+	return records, nil
+}
+
 // getRepositoryWithConditionFilter fetches the repository entry based on the specified condition and filter criteria using the provided index
 func (r *Repository) getRepositoryWithConditionFilter(ctx context.Context, condition expression.KeyConditionBuilder, filter expression.ConditionBuilder, indexName string) (*repoModels.RepositoryDBModel, error) {
 	f := logrus.Fields{
@@ -9,11 +9,14 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
 	"github.com/communitybridge/easycla/cla-backend-go/events"
 	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 
 	"github.com/communitybridge/easycla/cla-backend-go/v2/common"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_instances"
 
+	bitbucketApi "github.com/communitybridge/easycla/cla-backend-go/bitbucket_api"
 	"github.com/communitybridge/easycla/cla-backend-go/config"
 	gitLabApi "github.com/communitybridge/easycla/cla-backend-go/gitlab_api"
 
@@ -69,6 +72,18 @@ type ServiceInterface interface {
 	GitLabEnrollCLAGroupRepositories(ctx context.Context, claGroupID string, enrollValue bool) error
 	GitLabDeleteRepositories(ctx context.Context, gitLabGroupPath string) error
 	GitLabDeleteRepositoryByExternalID(ctx context.Context, gitLabExternalID int64) error
+	GitLabRenameRepository(ctx context.Context, repositoryExternalID int64, newFullPath, newURL string) (*v2Models.GitlabRepository, error)
+	GitLabTransferRepository(ctx context.Context, input *GitLabTransferRepoModel) (*v2Models.GitlabRepository, error)
+	GitLabSetRepositoryArchived(ctx context.Context, repositoryExternalID int64, archived bool) error
+	GitLabRemoteDeleteRepository(ctx context.Context, repositoryExternalID int64) error
+
+	// Bitbucket
+
+	BitbucketGetRepository(ctx context.Context, repositoryID string) (*v2Models.BitbucketRepository, error)
+	BitbucketGetRepositoryByExternalID(ctx context.Context, organizationKey, repositoryExternalID string) (*v2Models.BitbucketRepository, error)
+	BitbucketGetRepositoriesByProjectSFID(ctx context.Context, projectSFID string) (*v2Models.BitbucketRepositoriesList, error)
+	BitbucketEnrollRepositories(ctx context.Context, projectSFID, organizationID, claGroupID string, repositorySlugs []string, enrollValue bool) error
+	BitbucketDeleteRepositories(ctx context.Context, organizationKey string) error
 }
 
 // GitLabOrgRepo redefine the interface here to avoid circular dependency issues
@@ -91,8 +106,12 @@ type Service struct {
 	projectsClaGroupsRepo projects_cla_groups.Repository
 	ghOrgRepo             github_organizations.RepositoryInterface
 	glOrgRepo             GitLabOrgRepo
+	glInstanceService     gitlab_instances.ServiceInterface
+	bbOrgRepo             BitbucketOrgRepo
 	gitLabApp             *gitLabApi.App
+	bitbucketApp          *bitbucketApi.App
 	eventService          events.Service
+	emailService          emails.Service
 }
 
 var (
@@ -102,15 +121,19 @@ var (
 )
 
 // NewService creates a new githubOrganizations service
-func NewService(gitV1Repository *v1Repositories.Repository, gitV2Repository RepositoryInterface, pcgRepo projects_cla_groups.Repository, ghOrgRepo github_organizations.RepositoryInterface, glOrgRepo GitLabOrgRepo, eventService events.Service) ServiceInterface {
+func NewService(gitV1Repository *v1Repositories.Repository, gitV2Repository RepositoryInterface, pcgRepo projects_cla_groups.Repository, ghOrgRepo github_organizations.RepositoryInterface, glOrgRepo GitLabOrgRepo, glInstanceService gitlab_instances.ServiceInterface, bbOrgRepo BitbucketOrgRepo, eventService events.Service, emailService emails.Service) ServiceInterface {
 	return &Service{
 		gitV1Repository:       gitV1Repository,
 		gitV2Repository:       gitV2Repository,
 		projectsClaGroupsRepo: pcgRepo,
 		ghOrgRepo:             ghOrgRepo,
 		glOrgRepo:             glOrgRepo,
+		glInstanceService:     glInstanceService,
+		bbOrgRepo:             bbOrgRepo,
 		eventService:          eventService,
+		emailService:          emailService,
 		gitLabApp:             gitLabApi.Init(config.GetConfig().Gitlab.AppClientID, config.GetConfig().Gitlab.AppClientSecret, config.GetConfig().Gitlab.AppPrivateKey),
+		bitbucketApp:          bitbucketApi.Init(config.GetConfig().Bitbucket.AppClientID, config.GetConfig().Bitbucket.AppClientSecret, config.GetConfig().Bitbucket.AppPrivateKey),
 	}
 }
 
@@ -437,9 +460,17 @@ func (s *Service) GitHubGetProtectedBranch(ctx context.Context, projectSFID, rep
 		BranchName: &branchName,
 	}
 
+	// the rulesets apply on top of the branch protection rules, a failure to load them is not fatal - the app may not
+	// have the permission to read them
+	rulesetStatus, rulesetErr := branchProtectionRepository.GetBranchRulesetStatus(ctx, owner, githubRepoName, branchName)
+	if rulesetErr != nil {
+		log.WithFields(f).WithError(rulesetErr).Warnf("unable to load the rulesets for owner : %s, repository : %s and branch : %s - ignoring", owner, githubRepoName, branchName)
+	}
+
 	branchProtection, err := branchProtectionRepository.GetProtectedBranch(ctx, owner, githubRepoName, branchName)
 	if err != nil {
 		if errors.Is(err, branch_protection.ErrBranchNotProtected) {
+			applyBranchRulesetStatus(result, rulesetStatus)
 			return result, nil
 		}
 		log.WithFields(f).WithError(err).Warnf("getting the github protected branch for owner : %s, gitV1Repository : %s and branch : %s failed : %v", owner, githubRepoName, branchName, err)
@@ -454,10 +485,31 @@ func (s *Service) GitHubGetProtectedBranch(ctx context.Context, projectSFID, rep
 	requiredChecks := requiredBranchProtectionChecks
 	requiredChecksResult := s.getRequiredProtectedBranchCheckStatus(branchProtection, requiredChecks)
 	result.StatusChecks = requiredChecksResult
+	applyBranchRulesetStatus(result, rulesetStatus)
 
 	return result, nil
 }
 
+// applyBranchRulesetStatus reports the rulesets of the branch and the required checks they enforce
+func applyBranchRulesetStatus(result *v2Models.GithubRepositoryBranchProtection, rulesetStatus *branch_protection.BranchRulesetStatus) {
+	if rulesetStatus == nil || !rulesetStatus.RulesetsDetected {
+		return
+	}
+	result.RulesetsDetected = true
+
+	if len(result.StatusChecks) == 0 {
+		for _, rc := range requiredBranchProtectionChecks {
+			result.StatusChecks = append(result.StatusChecks, &v2Models.GithubRepositoryBranchProtectionStatusChecks{
+				Name:    swag.String(rc),
+				Enabled: swag.Bool(false),
+			})
+		}
+	}
+	for _, check := range result.StatusChecks {
+		check.RequiredByRuleset = rulesetStatus.IsStatusCheckRequired(swag.StringValue(check.Name))
+	}
+}
+
 // GitHubUpdateProtectedBranch service function
 func (s *Service) GitHubUpdateProtectedBranch(ctx context.Context, projectSFID, repositoryID string, input *v2Models.GithubRepositoryBranchProtectionInput) (*v2Models.GithubRepositoryBranchProtection, error) {
 	f := logrus.Fields{
@@ -0,0 +1,152 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package repository_requirements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/repository_requirements"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service ServiceInterface, projectsClaGroupsRepo projects_cla_groups.Repository) { // nolint
+
+	api.RepositoryRequirementsGetRepositoryRequirementsHandler = repository_requirements.GetRepositoryRequirementsHandlerFunc(
+		func(params repository_requirements.GetRepositoryRequirementsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.repository_requirements.handlers.RepositoryRequirementsGetRepositoryRequirementsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"repositoryID":   params.RepositoryID,
+			}
+
+			if !isUserAuthorizedForRepository(ctx, authUser, params.RepositoryID, service, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to view the requirements of the repository %s", authUser.UserName, params.RepositoryID)
+				log.WithFields(f).Warn(msg)
+				return repository_requirements.NewGetRepositoryRequirementsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetRequirements(ctx, params.RepositoryID)
+			if err != nil {
+				if err == ErrRequirementsNotFound {
+					return repository_requirements.NewGetRepositoryRequirementsNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, "repository requirements not found", err))
+				}
+				msg := fmt.Sprintf("problem loading the requirements of the repository %s", params.RepositoryID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return repository_requirements.NewGetRepositoryRequirementsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return repository_requirements.NewGetRepositoryRequirementsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.RepositoryRequirementsUpdateRepositoryRequirementsHandler = repository_requirements.UpdateRepositoryRequirementsHandlerFunc(
+		func(params repository_requirements.UpdateRepositoryRequirementsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.repository_requirements.handlers.RepositoryRequirementsUpdateRepositoryRequirementsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"repositoryID":   params.RepositoryID,
+			}
+
+			if !isUserAuthorizedForRepository(ctx, authUser, params.RepositoryID, service, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to update the requirements of the repository %s", authUser.UserName, params.RepositoryID)
+				log.WithFields(f).Warn(msg)
+				return repository_requirements.NewUpdateRepositoryRequirementsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.UpdateRequirements(ctx, params.RepositoryID, params.Body)
+			if err != nil {
+				msg := fmt.Sprintf("problem updating the requirements of the repository %s", params.RepositoryID)
+				if errors.Is(err, ErrInvalidPattern) || err == ErrInvalidMinChangeSize {
+					msg = fmt.Sprintf("invalid requirements for the repository %s", params.RepositoryID)
+				}
+				log.WithFields(f).WithError(err).Warn(msg)
+				return repository_requirements.NewUpdateRepositoryRequirementsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return repository_requirements.NewUpdateRepositoryRequirementsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.RepositoryRequirementsDeleteRepositoryRequirementsHandler = repository_requirements.DeleteRepositoryRequirementsHandlerFunc(
+		func(params repository_requirements.DeleteRepositoryRequirementsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+			f := logrus.Fields{
+				"functionName":   "v2.repository_requirements.handlers.RepositoryRequirementsDeleteRepositoryRequirementsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"repositoryID":   params.RepositoryID,
+			}
+
+			if !isUserAuthorizedForRepository(ctx, authUser, params.RepositoryID, service, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to update the requirements of the repository %s", authUser.UserName, params.RepositoryID)
+				log.WithFields(f).Warn(msg)
+				return repository_requirements.NewDeleteRepositoryRequirementsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err := service.DeleteRequirements(ctx, params.RepositoryID)
+			if err != nil {
+				if err == ErrRequirementsNotFound {
+					return repository_requirements.NewDeleteRepositoryRequirementsNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, "repository requirements not found", err))
+				}
+				msg := fmt.Sprintf("problem removing the requirements of the repository %s", params.RepositoryID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return repository_requirements.NewDeleteRepositoryRequirementsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return repository_requirements.NewDeleteRepositoryRequirementsNoContent().WithXRequestID(reqID)
+		})
+}
+
+// isUserAuthorizedForRepository returns true if the user may manage the requirements of the repository - authorized
+// through the CLA Group of the repository
+func isUserAuthorizedForRepository(ctx context.Context, authUser *auth.User, repositoryID string, service ServiceInterface, projectsClaGroupsRepo projects_cla_groups.Repository) bool {
+	f := logrus.Fields{
+		"functionName":   "v2.repository_requirements.handlers.isUserAuthorizedForRepository",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   repositoryID,
+	}
+
+	if utils.IsUserAdmin(authUser) {
+		return true
+	}
+
+	claGroupID, err := service.GetRepositoryCLAGroupID(ctx, repositoryID)
+	if err != nil || claGroupID == "" {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group of the repository")
+		return false
+	}
+
+	projectCLAGroups, err := projectsClaGroupsRepo.GetProjectsIdsForClaGroup(ctx, claGroupID)
+	if err != nil || len(projectCLAGroups) == 0 {
+		log.WithFields(f).WithError(err).Warn("unable to load projects for CLA Group")
+		return false
+	}
+
+	if utils.IsUserAuthorizedForProjectTree(ctx, authUser, projectCLAGroups[0].FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+		return true
+	}
+
+	var projectSFIDs []string
+	for _, projectCLAGroup := range projectCLAGroups {
+		projectSFIDs = append(projectSFIDs, projectCLAGroup.ProjectSFID)
+	}
+	return utils.IsUserAuthorizedForAnyProjects(ctx, authUser, projectSFIDs, utils.ALLOW_ADMIN_SCOPE)
+}
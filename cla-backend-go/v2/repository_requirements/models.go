@@ -0,0 +1,57 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package repository_requirements
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// DBRepositoryRequirementsModel data model for the repository requirements table
+type DBRepositoryRequirementsModel struct {
+	RepositoryID   string   `dynamodbav:"repository_id" json:"repository_id"`
+	RepositoryType string   `dynamodbav:"repository_type" json:"repository_type"`
+	ClaGroupID     string   `dynamodbav:"cla_group_id" json:"cla_group_id"`
+	PathExemptions []string `dynamodbav:"path_exemptions" json:"path_exemptions"`
+	TargetBranches []string `dynamodbav:"target_branches" json:"target_branches"`
+	MinChangeSize  int64    `dynamodbav:"min_change_size" json:"min_change_size"`
+	Note           string   `dynamodbav:"note" json:"note"`
+	UpdatedBy      string   `dynamodbav:"updated_by" json:"updated_by"`
+	DateCreated    string   `dynamodbav:"date_created" json:"date_created"`
+	DateModified   string   `dynamodbav:"date_modified" json:"date_modified"`
+	Version        string   `dynamodbav:"version" json:"version"`
+}
+
+// toModel converts the database model to the API model
+func (m *DBRepositoryRequirementsModel) toModel() *models.RepositoryRequirements {
+	return &models.RepositoryRequirements{
+		RepositoryID:   m.RepositoryID,
+		RepositoryType: m.RepositoryType,
+		ClaGroupID:     m.ClaGroupID,
+		PathExemptions: nonNilStrings(m.PathExemptions),
+		TargetBranches: nonNilStrings(m.TargetBranches),
+		MinChangeSize:  m.MinChangeSize,
+		Note:           m.Note,
+		UpdatedBy:      m.UpdatedBy,
+		DateCreated:    m.DateCreated,
+		DateModified:   m.DateModified,
+		Version:        m.Version,
+	}
+}
+
+// rules returns the evaluation rules of the record
+func (m *DBRepositoryRequirementsModel) rules() *Rules {
+	return &Rules{
+		PathExemptions: m.PathExemptions,
+		TargetBranches: m.TargetBranches,
+		MinChangeSize:  m.MinChangeSize,
+	}
+}
+
+// nonNilStrings returns an empty list instead of nil, so the API always renders the list
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
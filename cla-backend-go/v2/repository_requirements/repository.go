@@ -0,0 +1,130 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package repository_requirements
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// table columns
+const (
+	// RepositoryIDColumn is the primary key of the repository requirements table, the internal repository ID
+	RepositoryIDColumn = "repository_id"
+)
+
+// RepositoryInterface defines the repository requirements data access functions
+type RepositoryInterface interface {
+	PutRequirements(ctx context.Context, requirements *DBRepositoryRequirementsModel) error
+	GetRequirements(ctx context.Context, repositoryID string) (*DBRepositoryRequirementsModel, error)
+	DeleteRequirements(ctx context.Context, repositoryID string) error
+}
+
+// Repository object/struct
+type Repository struct {
+	stage                 string
+	dynamoDBClient        *dynamodb.DynamoDB
+	requirementsTableName string
+}
+
+// NewRepository creates a new instance of the repository requirements repository
+func NewRepository(awsSession *session.Session, stage string) RepositoryInterface {
+	return &Repository{
+		stage:                 stage,
+		dynamoDBClient:        dynamodb.New(awsSession),
+		requirementsTableName: fmt.Sprintf("cla-%s-repository-requirements", stage),
+	}
+}
+
+// PutRequirements adds or replaces the requirements of the repository
+func (repo *Repository) PutRequirements(ctx context.Context, requirements *DBRepositoryRequirementsModel) error {
+	f := logrus.Fields{
+		"functionName":   "v2.repository_requirements.repository.PutRequirements",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   requirements.RepositoryID,
+		"repositoryType": requirements.RepositoryType,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(requirements)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall repository requirements record")
+		return err
+	}
+
+	log.WithFields(f).Debug("saving repository requirements record to the database...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.requirementsTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save repository requirements record")
+		return err
+	}
+
+	return nil
+}
+
+// GetRequirements returns the requirements of the repository, nil if not found
+func (repo *Repository) GetRequirements(ctx context.Context, repositoryID string) (*DBRepositoryRequirementsModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.repository_requirements.repository.GetRequirements",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   repositoryID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			RepositoryIDColumn: {S: aws.String(repositoryID)},
+		},
+		TableName: aws.String(repo.requirementsTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load repository requirements record")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		log.WithFields(f).Debug("unable to find repository requirements record - no results")
+		return nil, nil
+	}
+
+	var requirements DBRepositoryRequirementsModel
+	err = dynamodbattribute.UnmarshalMap(result.Item, &requirements)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem decoding repository requirements record")
+		return nil, err
+	}
+
+	return &requirements, nil
+}
+
+// DeleteRequirements removes the requirements of the repository
+func (repo *Repository) DeleteRequirements(ctx context.Context, repositoryID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.repository_requirements.repository.DeleteRequirements",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   repositoryID,
+	}
+
+	log.WithFields(f).Debug("deleting repository requirements record...")
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			RepositoryIDColumn: {S: aws.String(repositoryID)},
+		},
+		TableName: aws.String(repo.requirementsTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to delete repository requirements record")
+		return err
+	}
+
+	return nil
+}
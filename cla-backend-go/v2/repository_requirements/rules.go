@@ -0,0 +1,205 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package repository_requirements
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	// RuleTargetBranch indicates the change request targets a branch outside of the enforced branches
+	RuleTargetBranch = "target_branch"
+	// RuleMinChangeSize indicates the change request is smaller than the minimum change size
+	RuleMinChangeSize = "min_change_size"
+	// RulePathExemption indicates all the files of the change request match the path exemptions
+	RulePathExemption = "path_exemption"
+)
+
+// ErrInvalidPattern is returned when a path exemption or target branch pattern is malformed
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// Rules are the CLA requirement overrides of a repository, evaluated before the author checks of the change requests
+type Rules struct {
+	// PathExemptions are the glob patterns of the files not requiring the CLA check, e.g. docs/**
+	PathExemptions []string
+	// TargetBranches are the glob patterns of the enforced target branches, e.g. main or release/* - all the
+	// branches are enforced when empty
+	TargetBranches []string
+	// MinChangeSize is the number of added and deleted lines below which the CLA check is not required
+	MinChangeSize int64
+}
+
+// ChangeRequest describes the pull request or merge request evaluated against the rules - the zero values never skip
+// the CLA check
+type ChangeRequest struct {
+	TargetBranch string
+	// Files are the paths of the changed files, the renamed files include both the old and the new path
+	Files []string
+	// FilesComplete is false when the provider truncated the list of the changed files
+	FilesComplete bool
+	// ChangedLines is the number of added and deleted lines
+	ChangedLines int64
+	// ChangedLinesKnown is false when the provider did not report the size of the change
+	ChangedLinesKnown bool
+}
+
+// Decision is the outcome of the rules evaluation
+type Decision struct {
+	// Required is true when the CLA check of the authors applies to the change request
+	Required bool
+	// Rule is the rule skipping the CLA check, empty when required
+	Rule string
+	// Reason is a short description of the skipped CLA check, shown on the change request
+	Reason string
+}
+
+// RequiresChanges returns true if evaluating the rules needs the files or the size of the change request
+func (r *Rules) RequiresChanges() bool {
+	return r != nil && (len(r.PathExemptions) > 0 || r.MinChangeSize > 0)
+}
+
+// RequiresFiles returns true if evaluating the rules needs the files of the change request
+func (r *Rules) RequiresFiles() bool {
+	return r != nil && len(r.PathExemptions) > 0
+}
+
+// Evaluate returns whether the CLA check applies to the change request - the target branch filter is evaluated first,
+// then the minimum change size and the path exemptions
+func (r *Rules) Evaluate(change *ChangeRequest) *Decision {
+	if r == nil || change == nil {
+		return &Decision{Required: true}
+	}
+
+	if len(r.TargetBranches) > 0 && change.TargetBranch != "" && !matchesAnyBranch(r.TargetBranches, change.TargetBranch) {
+		return &Decision{
+			Rule:   RuleTargetBranch,
+			Reason: fmt.Sprintf("the target branch %s is not enforced", change.TargetBranch),
+		}
+	}
+
+	if r.MinChangeSize > 0 && change.ChangedLinesKnown && change.ChangedLines < r.MinChangeSize {
+		return &Decision{
+			Rule:   RuleMinChangeSize,
+			Reason: fmt.Sprintf("the change of %d lines is below the minimum of %d lines", change.ChangedLines, r.MinChangeSize),
+		}
+	}
+
+	if len(r.PathExemptions) > 0 && change.FilesComplete && len(change.Files) > 0 && allPathsExempt(r.PathExemptions, change.Files) {
+		return &Decision{
+			Rule:   RulePathExemption,
+			Reason: "all the changed files match the path exemptions",
+		}
+	}
+
+	return &Decision{Required: true}
+}
+
+// ValidatePattern returns ErrInvalidPattern if the path exemption or target branch pattern is empty or malformed
+func ValidatePattern(pattern string) error {
+	pattern = normalizePattern(pattern)
+	if pattern == "" {
+		return fmt.Errorf("%w: the pattern is empty", ErrInvalidPattern)
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == "" {
+			return fmt.Errorf("%w: %s has an empty path segment", ErrInvalidPattern, pattern)
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidPattern, pattern)
+		}
+	}
+	return nil
+}
+
+// MatchPath returns true if the file path matches the path exemption pattern - a ** segment matches any number of
+// directories, a trailing / matches everything under the directory and a pattern without a / matches the file name in
+// any directory, as in the .gitignore files
+func MatchPath(pattern, filePath string) bool {
+	pattern = normalizePattern(pattern)
+	if pattern == "" {
+		return false
+	}
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(strings.TrimPrefix(filePath, "/"), "/"))
+}
+
+// MatchBranch returns true if the branch matches the target branch pattern, e.g. release/* matches release/1.0
+func MatchBranch(pattern, branch string) bool {
+	pattern = normalizePattern(pattern)
+	if pattern == "" {
+		return false
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(branch, "/"))
+}
+
+// normalizePattern trims the spaces and the leading /, a trailing / is converted to /**
+func normalizePattern(pattern string) string {
+	pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern = pattern + "**"
+	}
+	return pattern
+}
+
+// matchSegments matches the path segments against the pattern segments, a ** pattern segment matching zero or more
+// path segments
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				// a trailing ** matches the content of the directory, not the directory itself
+				return len(name) > 0
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		matched, err := path.Match(pattern[0], name[0])
+		if err != nil || !matched {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// matchesAnyBranch returns true if the branch matches one of the target branch patterns
+func matchesAnyBranch(patterns []string, branch string) bool {
+	for _, pattern := range patterns {
+		if MatchBranch(pattern, branch) {
+			return true
+		}
+	}
+	return false
+}
+
+// allPathsExempt returns true if every file matches one of the path exemption patterns
+func allPathsExempt(patterns, files []string) bool {
+	for _, file := range files {
+		exempt := false
+		for _, pattern := range patterns {
+			if MatchPath(pattern, file) {
+				exempt = true
+				break
+			}
+		}
+		if !exempt {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,155 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package repository_requirements
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPath(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"docs/**", "docs/README.md", true},
+		{"docs/**", "docs/guide/install.md", true},
+		{"docs/**", "docs", false},
+		{"docs/**", "src/docs/README.md", false},
+		{"docs/", "docs/guide/install.md", true},
+		{"/docs/**", "docs/README.md", true},
+		{"docs/*.md", "docs/README.md", true},
+		{"docs/*.md", "docs/guide/install.md", false},
+		{"**/*.md", "README.md", true},
+		{"**/*.md", "src/pkg/README.md", true},
+		{"*.md", "src/pkg/README.md", true},
+		{"*.md", "src/pkg/main.go", false},
+		{"LICENSE", "LICENSE", true},
+		{"src/**/testdata/**", "src/a/b/testdata/file.json", true},
+		{"src/**/testdata/**", "src/testdata/file.json", true},
+		{"src/**/testdata/**", "src/a/file.json", false},
+		{"", "README.md", false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.match, MatchPath(tc.pattern, tc.path), "pattern %q path %q", tc.pattern, tc.path)
+	}
+}
+
+func TestMatchBranch(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		branch  string
+		match   bool
+	}{
+		{"main", "main", true},
+		{"main", "maintenance", false},
+		{"release/*", "release/1.0", true},
+		{"release/*", "release/1.0/hotfix", false},
+		{"release/**", "release/1.0/hotfix", true},
+		{"release/*", "release", false},
+		{"*", "feature/x", false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.match, MatchBranch(tc.pattern, tc.branch), "pattern %q branch %q", tc.pattern, tc.branch)
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	assert.NoError(t, ValidatePattern("docs/**"))
+	assert.NoError(t, ValidatePattern("release/*"))
+	assert.NoError(t, ValidatePattern("docs/"))
+	assert.True(t, errors.Is(ValidatePattern(""), ErrInvalidPattern))
+	assert.True(t, errors.Is(ValidatePattern("docs//*.md"), ErrInvalidPattern))
+	assert.True(t, errors.Is(ValidatePattern("docs/[a-"), ErrInvalidPattern))
+}
+
+func TestRulesEvaluate(t *testing.T) {
+	rules := &Rules{
+		PathExemptions: []string{"docs/**", "*.md"},
+		TargetBranches: []string{"main", "release/*"},
+		MinChangeSize:  5,
+	}
+
+	testCases := []struct {
+		name     string
+		rules    *Rules
+		change   *ChangeRequest
+		required bool
+		rule     string
+	}{
+		{
+			name:     "no rules",
+			rules:    nil,
+			change:   &ChangeRequest{TargetBranch: "feature/x"},
+			required: true,
+		},
+		{
+			name:     "branch not enforced",
+			rules:    rules,
+			change:   &ChangeRequest{TargetBranch: "feature/x", Files: []string{"main.go"}, FilesComplete: true, ChangedLines: 100, ChangedLinesKnown: true},
+			required: false,
+			rule:     RuleTargetBranch,
+		},
+		{
+			name:     "unknown branch is enforced",
+			rules:    rules,
+			change:   &ChangeRequest{Files: []string{"main.go"}, FilesComplete: true, ChangedLines: 100, ChangedLinesKnown: true},
+			required: true,
+		},
+		{
+			name:     "small change",
+			rules:    rules,
+			change:   &ChangeRequest{TargetBranch: "main", Files: []string{"main.go"}, FilesComplete: true, ChangedLines: 4, ChangedLinesKnown: true},
+			required: false,
+			rule:     RuleMinChangeSize,
+		},
+		{
+			name:     "unknown size is enforced",
+			rules:    rules,
+			change:   &ChangeRequest{TargetBranch: "main", Files: []string{"main.go"}, FilesComplete: true},
+			required: true,
+		},
+		{
+			name:     "docs only",
+			rules:    rules,
+			change:   &ChangeRequest{TargetBranch: "release/1.0", Files: []string{"docs/index.md", "pkg/README.md"}, FilesComplete: true, ChangedLines: 100, ChangedLinesKnown: true},
+			required: false,
+			rule:     RulePathExemption,
+		},
+		{
+			name:     "docs and code",
+			rules:    rules,
+			change:   &ChangeRequest{TargetBranch: "main", Files: []string{"docs/index.md", "main.go"}, FilesComplete: true, ChangedLines: 100, ChangedLinesKnown: true},
+			required: true,
+		},
+		{
+			name:     "truncated files are enforced",
+			rules:    rules,
+			change:   &ChangeRequest{TargetBranch: "main", Files: []string{"docs/index.md"}, ChangedLines: 100, ChangedLinesKnown: true},
+			required: true,
+		},
+		{
+			name:     "renamed out of docs is enforced",
+			rules:    rules,
+			change:   &ChangeRequest{TargetBranch: "main", Files: []string{"src/index.go", "docs/index.go"}, FilesComplete: true, ChangedLines: 100, ChangedLinesKnown: true},
+			required: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decision := tc.rules.Evaluate(tc.change)
+			assert.Equal(t, tc.required, decision.Required)
+			assert.Equal(t, tc.rule, decision.Rule)
+			if !tc.required {
+				assert.NotEmpty(t, decision.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package repository_requirements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/v1/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// errors
+var (
+	// ErrRequirementsNotFound is returned when the repository has no requirements
+	ErrRequirementsNotFound = errors.New("repository requirements not found")
+	// ErrInvalidMinChangeSize is returned when the minimum change size is negative
+	ErrInvalidMinChangeSize = errors.New("the minimum change size must not be negative")
+)
+
+// SkippedCheck describes a change request not requiring the CLA check according to the repository requirements
+type SkippedCheck struct {
+	CLAGroupID      string
+	Provider        string
+	RepositoryName  string
+	ChangeRequestID int
+	Decision        *Decision
+}
+
+// ServiceInterface defines the repository requirements service functions
+type ServiceInterface interface {
+	GetRequirements(ctx context.Context, repositoryID string) (*models.RepositoryRequirements, error)
+	UpdateRequirements(ctx context.Context, repositoryID string, input *models.RepositoryRequirementsInput) (*models.RepositoryRequirements, error)
+	DeleteRequirements(ctx context.Context, repositoryID string) error
+	GetRepositoryCLAGroupID(ctx context.Context, repositoryID string) (string, error)
+
+	GetRules(ctx context.Context, repositoryID string) (*Rules, error)
+	LogCheckSkipped(ctx context.Context, skipped *SkippedCheck)
+}
+
+// Service data model
+type Service struct {
+	repo                RepositoryInterface
+	repositoriesService repositories.Service
+	eventsService       events.Service
+}
+
+// NewService creates a new repository requirements service
+func NewService(repo RepositoryInterface, repositoriesService repositories.Service, eventsService events.Service) ServiceInterface {
+	return &Service{
+		repo:                repo,
+		repositoriesService: repositoriesService,
+		eventsService:       eventsService,
+	}
+}
+
+// GetRequirements returns the requirements of the repository
+func (s *Service) GetRequirements(ctx context.Context, repositoryID string) (*models.RepositoryRequirements, error) {
+	requirements, err := s.repo.GetRequirements(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if requirements == nil {
+		return nil, ErrRequirementsNotFound
+	}
+	return requirements.toModel(), nil
+}
+
+// UpdateRequirements replaces the requirements of the repository
+func (s *Service) UpdateRequirements(ctx context.Context, repositoryID string, input *models.RepositoryRequirementsInput) (*models.RepositoryRequirements, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.repository_requirements.service.UpdateRequirements",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   repositoryID,
+		"pathExemptions": strings.Join(input.PathExemptions, ","),
+		"targetBranches": strings.Join(input.TargetBranches, ","),
+		"minChangeSize":  input.MinChangeSize,
+	}
+
+	if input.MinChangeSize < 0 {
+		log.WithFields(f).Warn("invalid minimum change size")
+		return nil, ErrInvalidMinChangeSize
+	}
+	pathExemptions, err := normalizePatterns(input.PathExemptions)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid path exemption")
+		return nil, err
+	}
+	targetBranches, err := normalizePatterns(input.TargetBranches)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid target branch")
+		return nil, err
+	}
+
+	repository, err := s.repositoriesService.GetRepository(ctx, repositoryID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the repository")
+		return nil, err
+	}
+
+	existing, err := s.repo.GetRequirements(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	requirements := &DBRepositoryRequirementsModel{
+		RepositoryID:   repositoryID,
+		RepositoryType: repository.RepositoryType,
+		ClaGroupID:     repository.RepositoryClaGroupID,
+		PathExemptions: pathExemptions,
+		TargetBranches: targetBranches,
+		MinChangeSize:  input.MinChangeSize,
+		Note:           input.Note,
+		UpdatedBy:      utils.GetUserNameFromContext(ctx),
+		DateCreated:    currentTime,
+		DateModified:   currentTime,
+		Version:        "v1",
+	}
+	if existing != nil {
+		requirements.DateCreated = existing.DateCreated
+	}
+
+	err = s.repo.PutRequirements(ctx, requirements)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, s.eventArgs(ctx, events.RepositoryRequirementsUpdated, repository, &events.RepositoryRequirementsUpdatedEventData{
+		RepositoryName: repository.RepositoryName,
+		PathExemptions: pathExemptions,
+		TargetBranches: targetBranches,
+		MinChangeSize:  input.MinChangeSize,
+	}))
+
+	return requirements.toModel(), nil
+}
+
+// DeleteRequirements removes the requirements of the repository - the CLA check applies to all the change requests again
+func (s *Service) DeleteRequirements(ctx context.Context, repositoryID string) error {
+	requirements, err := s.repo.GetRequirements(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if requirements == nil {
+		return ErrRequirementsNotFound
+	}
+
+	err = s.repo.DeleteRequirements(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+
+	repository, err := s.repositoriesService.GetRepository(ctx, repositoryID)
+	if err != nil {
+		log.WithError(err).Warnf("unable to load the repository: %s", repositoryID)
+		repository = &v1Models.GithubRepository{RepositoryID: repositoryID, RepositoryClaGroupID: requirements.ClaGroupID}
+	}
+	s.eventsService.LogEventWithContext(ctx, s.eventArgs(ctx, events.RepositoryRequirementsDeleted, repository, &events.RepositoryRequirementsDeletedEventData{
+		RepositoryName: repository.RepositoryName,
+	}))
+
+	return nil
+}
+
+// GetRepositoryCLAGroupID returns the CLA Group of the repository - used to authorize the requirements updates
+func (s *Service) GetRepositoryCLAGroupID(ctx context.Context, repositoryID string) (string, error) {
+	repository, err := s.repositoriesService.GetRepository(ctx, repositoryID)
+	if err != nil {
+		return "", err
+	}
+	return repository.RepositoryClaGroupID, nil
+}
+
+// GetRules returns the rules of the repository, nil when the repository has no requirements
+func (s *Service) GetRules(ctx context.Context, repositoryID string) (*Rules, error) {
+	requirements, err := s.repo.GetRequirements(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if requirements == nil {
+		return nil, nil
+	}
+	return requirements.rules(), nil
+}
+
+// LogCheckSkipped logs the event for a change request not requiring the CLA check
+func (s *Service) LogCheckSkipped(ctx context.Context, skipped *SkippedCheck) {
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.RepositoryRequirementsCheckSkipped,
+		CLAGroupID: skipped.CLAGroupID,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData: &events.RepositoryRequirementsCheckSkippedEventData{
+			Provider:        skipped.Provider,
+			RepositoryName:  skipped.RepositoryName,
+			ChangeRequestID: skipped.ChangeRequestID,
+			Rule:            skipped.Decision.Rule,
+			Reason:          skipped.Decision.Reason,
+		},
+	})
+}
+
+// eventArgs is a helper function to build the event arguments for the repository
+func (s *Service) eventArgs(ctx context.Context, eventType string, repository *v1Models.GithubRepository, eventData events.EventData) *events.LogEventArgs {
+	return &events.LogEventArgs{
+		EventType:   eventType,
+		ProjectSFID: repository.RepositoryProjectSfid,
+		CLAGroupID:  repository.RepositoryClaGroupID,
+		LfUsername:  utils.GetUserNameFromContext(ctx),
+		EventData:   eventData,
+	}
+}
+
+// normalizePatterns trims and validates the patterns, dropping the duplicates
+func normalizePatterns(patterns []string) ([]string, error) {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if err := ValidatePattern(pattern); err != nil {
+			return nil, fmt.Errorf("%s: %w", pattern, err)
+		}
+		if seen[pattern] {
+			continue
+		}
+		seen[pattern] = true
+		result = append(result, pattern)
+	}
+	return result, nil
+}
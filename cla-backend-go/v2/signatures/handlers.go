@@ -18,6 +18,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/auditors"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/organization-service/client/organizations" // nolint - lint error for import not used, but it really is
 
 	"github.com/go-openapi/runtime"
@@ -42,7 +43,7 @@ import (
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.EasyclaAPI, claGroupService service.Service, projectRepo repository.ProjectRepository, companyService company.IService, v1SignatureService signatureService.SignatureService, sessionStore *dynastore.Store, eventsService events.Service, v2SignatureService ServiceInterface, projectClaGroupsRepo projects_cla_groups.Repository) { //nolint
+func Configure(api *operations.EasyclaAPI, claGroupService service.Service, projectRepo repository.ProjectRepository, companyService company.IService, v1SignatureService signatureService.SignatureService, sessionStore *dynastore.Store, eventsService events.Service, v2SignatureService ServiceInterface, projectClaGroupsRepo projects_cla_groups.Repository, auditorService auditors.ServiceInterface) { //nolint
 
 	const problemLoadingCLAGroupByID = "problem loading cla group by ID"
 	const iclaNotSupportedForCLAGroup = "individual contribution is not supported for this project"
@@ -73,7 +74,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		}
 
 		log.WithFields(f).Debug("checking access control permissions for user...")
-		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, signature.ProjectID, projectClaGroupsRepo, projectRepo) {
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, signature.ProjectID, projectClaGroupsRepo, projectRepo) &&
+			!isAuditorForCLAGroup(ctx, authUser, signature.ProjectID, "signature "+params.SignatureID, auditorService) {
 			msg := fmt.Sprintf("user %s is not authorized to view project ICLA signatures", authUser.UserName)
 			log.Warn(msg)
 			return signatures.NewGetProjectCompanyEmployeeSignaturesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
@@ -407,7 +409,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		}
 
 		log.WithFields(f).Debug("checking access control permissions for user...")
-		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) &&
+			!isAuditorForCLAGroup(ctx, authUser, params.ClaGroupID, "project signatures", auditorService) {
 			msg := fmt.Sprintf("user '%s' is not authorized to view project ICLA signatures any scope of project", authUser.UserName)
 			log.Warn(msg)
 			return signatures.NewGetProjectSignaturesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
@@ -475,7 +478,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 			})
 		}
 
-		if !isUserHaveAccessToCLAProjectOrganization(ctx, authUser, params.ProjectSFID, companyModel.CompanyExternalID, projectClaGroupsRepo) {
+		if !isUserHaveAccessToCLAProjectOrganization(ctx, authUser, params.ProjectSFID, companyModel.CompanyExternalID, projectClaGroupsRepo) &&
+			!isAuditorForProject(ctx, authUser, params.ProjectSFID, "project company signatures", projectClaGroupsRepo, auditorService) {
 			msg := fmt.Sprintf("user %s is not authorized to view project company signatures any scope of project: %s, organization %s",
 				authUser.UserName, params.ProjectSFID, params.CompanyID)
 			log.WithFields(f).Warn(msg)
@@ -773,7 +777,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		f["foundationSFID"] = projectCLAGroupEntries[0].FoundationSFID
 
 		log.WithFields(f).Debug("checking access control permissions for user...")
-		if !isUserHaveAccessToCLAProjectOrganization(ctx, authUser, projectCLAGroupEntries[0].FoundationSFID, companyModel.CompanyExternalID, projectClaGroupsRepo) {
+		if !isUserHaveAccessToCLAProjectOrganization(ctx, authUser, projectCLAGroupEntries[0].FoundationSFID, companyModel.CompanyExternalID, projectClaGroupsRepo) &&
+			!isAuditorForCLAGroup(ctx, authUser, params.ClaGroupID, "ECLA signatures CSV", auditorService) {
 			msg := fmt.Sprintf(" user %s is not authorized to view project employee signatures any scope of project", authUser.UserName)
 			log.Warn(msg)
 			return signatures.NewDownloadProjectSignatureEmployeeAsCSVForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
@@ -849,7 +854,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		}
 
 		log.WithFields(f).Debug("checking access control permissions for user...")
-		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) &&
+			!isAuditorForCLAGroup(ctx, authUser, params.ClaGroupID, "ICLA signatures", auditorService) {
 			msg := fmt.Sprintf("user %s is not authorized to view project ICLA signatures any scope of project", authUser.UserName)
 			log.Warn(msg)
 			return signatures.NewGetProjectCompanyEmployeeSignaturesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
@@ -938,7 +944,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		f["foundationSFID"] = projectCLAGroupEntries[0].FoundationSFID
 
 		log.WithFields(f).Debug("checking access control permissions for user...")
-		if !isUserHaveAccessToCLAProjectOrganization(ctx, authUser, projectCLAGroupEntries[0].FoundationSFID, companyModel.CompanyExternalID, projectClaGroupsRepo) {
+		if !isUserHaveAccessToCLAProjectOrganization(ctx, authUser, projectCLAGroupEntries[0].FoundationSFID, companyModel.CompanyExternalID, projectClaGroupsRepo) &&
+			!isAuditorForCLAGroup(ctx, authUser, params.ClaGroupID, "corporate contributors", auditorService) {
 			msg := fmt.Sprintf("user '%s' is not authorized to view project CCLA signatures project scope or project|organization scope for company ID: %s",
 				authUser.UserName, companyModel.CompanyID)
 			log.Warn(msg)
@@ -990,6 +997,10 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 			return signatures.NewGetSignatureSignedDocumentBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
 		}
 
+		if !haveAccess && isAuditorForCLAGroup(ctx, authUser, signatureModel.ProjectID, "signed document "+signatureModel.SignatureID, auditorService) {
+			haveAccess = true
+		}
+
 		if !haveAccess {
 			return signatures.NewGetSignatureSignedDocumentForbidden().WithXRequestID(reqID).WithPayload(
 				utils.ErrorResponseForbidden(reqID, fmt.Sprintf("user %s does not have access to the specified signature", authUser.UserName)))
@@ -1037,7 +1048,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		}
 
 		log.WithFields(f).Debug("checking access control permissions for user...")
-		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) &&
+			!isAuditorForCLAGroup(ctx, authUser, params.ClaGroupID, "ICLA signature PDFs", auditorService) {
 			msg := fmt.Sprintf("user %s is not authorized to view project ICLA signatures any scope of project", authUser.UserName)
 			log.Warn(msg)
 			return signatures.NewDownloadProjectSignatureICLAsForbidden().WithXRequestID(reqID).WithPayload(
@@ -1103,7 +1115,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		f["foundationSFID"] = claGroupModel.FoundationSFID
 
 		log.WithFields(f).Debug("checking access control permissions for user...")
-		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) &&
+			!isAuditorForCLAGroup(ctx, authUser, params.ClaGroupID, "ICLA signatures CSV", auditorService) {
 			msg := fmt.Sprintf("user '%s' is not authorized to view project ICLA signatures any scope of project", authUser.UserName)
 			log.Warn(msg)
 			return signatures.NewDownloadProjectSignatureICLAAsCSVForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
@@ -1160,7 +1173,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		f["foundationSFID"] = claGroupModel.FoundationSFID
 
 		log.WithFields(f).Debug("checking access control permissions for user...")
-		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) &&
+			!isAuditorForCLAGroup(ctx, authUser, params.ClaGroupID, "CCLA signature PDFs", auditorService) {
 			msg := fmt.Sprintf("user %s is not authorized to view project ICLA signatures any scope of project", authUser.UserName)
 			log.Warn(msg)
 			return signatures.NewDownloadProjectSignatureCCLAsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
@@ -1225,7 +1239,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		f["foundationSFID"] = claGroupModel.FoundationSFID
 
 		log.WithFields(f).Debug("checking access control permissions for user...")
-		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) &&
+			!isAuditorForCLAGroup(ctx, authUser, params.ClaGroupID, "CCLA signatures CSV", auditorService) {
 			msg := fmt.Sprintf("user '%s' is not authorized to view project CCLA signatures any scope of project", authUser.UserName)
 			log.Warn(msg)
 			return signatures.NewDownloadProjectSignatureCCLAAsCSVForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
@@ -1586,6 +1601,37 @@ func isUserHaveAccessToCLAGroupProjects(ctx context.Context, authUser *auth.User
 	return false
 }
 
+// isAuditorForCLAGroup is a helper function to determine if the user has read-only auditor access to the CLA Group,
+// each access granted through the auditor role is recorded as an event
+func isAuditorForCLAGroup(ctx context.Context, authUser *auth.User, claGroupID, resource string, auditorService auditors.ServiceInterface) bool {
+	if auditorService == nil || !auditorService.IsCLAGroupAuditor(ctx, authUser.UserName, claGroupID) {
+		return false
+	}
+
+	auditorService.LogAuditorAccess(ctx, authUser.UserName, auditors.ScopeTypeCLAGroup, claGroupID, resource)
+	return true
+}
+
+// isAuditorForProject is a helper function to determine if the user has read-only auditor access to the CLA Group
+// the project belongs to
+func isAuditorForProject(ctx context.Context, authUser *auth.User, projectSFID, resource string, projectClaGroupsRepo projects_cla_groups.Repository, auditorService auditors.ServiceInterface) bool {
+	if auditorService == nil {
+		return false
+	}
+
+	projectCLAGroupModel, err := projectClaGroupsRepo.GetClaGroupIDForProject(ctx, projectSFID)
+	if err != nil || projectCLAGroupModel == nil {
+		log.WithFields(logrus.Fields{
+			"functionName":   "v2.signatures.handlers.isAuditorForProject",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"projectSFID":    projectSFID,
+		}).WithError(err).Debug("unable to load the project -> cla group mapping - not an auditor")
+		return false
+	}
+
+	return isAuditorForCLAGroup(ctx, authUser, projectCLAGroupModel.ClaGroupID, resource, auditorService)
+}
+
 // isUserHaveAccessToCLAProjectOrganization is a helper function to determine if the user has access to the specified project and organization
 func isUserHaveAccessToCLAProjectOrganization(ctx context.Context, authUser *auth.User, projectSFID, organizationSFID string, projectClaGroupsRepo projects_cla_groups.Repository) bool {
 	f := logrus.Fields{
@@ -0,0 +1,140 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/auditors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAuditorService struct {
+	auditors.ServiceInterface
+	claGroups map[string]string
+	accesses  []string
+}
+
+func (s *fakeAuditorService) IsCLAGroupAuditor(ctx context.Context, lfUsername, claGroupID string) bool {
+	return s.claGroups[claGroupID] == lfUsername
+}
+
+func (s *fakeAuditorService) LogAuditorAccess(ctx context.Context, lfUsername, scopeType, scopeID, resource string) {
+	s.accesses = append(s.accesses, lfUsername+" "+scopeType+" "+scopeID+" "+resource)
+}
+
+type fakeProjectClaGroupsRepo struct {
+	projects_cla_groups.Repository
+	mappings []*projects_cla_groups.ProjectClaGroup
+}
+
+func (r *fakeProjectClaGroupsRepo) GetClaGroupIDForProject(ctx context.Context, projectSFID string) (*projects_cla_groups.ProjectClaGroup, error) {
+	for _, mapping := range r.mappings {
+		if mapping.ProjectSFID == projectSFID {
+			return mapping, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeProjectClaGroupsRepo) GetProjectsIdsForClaGroup(ctx context.Context, claGroupID string) ([]*projects_cla_groups.ProjectClaGroup, error) {
+	var mappings []*projects_cla_groups.ProjectClaGroup
+	for _, mapping := range r.mappings {
+		if mapping.ClaGroupID == claGroupID {
+			mappings = append(mappings, mapping)
+		}
+	}
+	return mappings, nil
+}
+
+func TestIsAuditorForCLAGroup(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeAuditorService{claGroups: map[string]string{"d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b": "auditor"}}
+	auditor := &auth.User{UserName: "auditor"}
+
+	assert.True(t, isAuditorForCLAGroup(ctx, auditor, "d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b", "signatures", service))
+	assert.Equal(t, []string{"auditor cla_group d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b signatures"}, service.accesses)
+
+	// the other users, the other CLA Groups and the missing auditor service are denied - nothing is recorded
+	assert.False(t, isAuditorForCLAGroup(ctx, &auth.User{UserName: "jdoe"}, "d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b", "signatures", service))
+	assert.False(t, isAuditorForCLAGroup(ctx, auditor, "7f3b5e2a-1c4d-4e8f-9a6b-2d1c3e4f5a6b", "signatures", service))
+	assert.False(t, isAuditorForCLAGroup(ctx, auditor, "d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b", "signatures", nil))
+	assert.Len(t, service.accesses, 1)
+}
+
+func TestIsAuditorForProject(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeAuditorService{claGroups: map[string]string{"d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b": "auditor"}}
+	repo := &fakeProjectClaGroupsRepo{mappings: []*projects_cla_groups.ProjectClaGroup{
+		{ProjectSFID: "a092M00001IV4RGQA1", FoundationSFID: "a092M00001IV4Q9QAL", ClaGroupID: "d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b"},
+	}}
+	auditor := &auth.User{UserName: "auditor"}
+
+	assert.True(t, isAuditorForProject(ctx, auditor, "a092M00001IV4RGQA1", "project company signatures", repo, service))
+	assert.Equal(t, []string{"auditor cla_group d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b project company signatures"}, service.accesses)
+
+	// the projects without a CLA Group and the missing auditor service are denied
+	assert.False(t, isAuditorForProject(ctx, auditor, "a092M00001IV4RHQA1", "project company signatures", repo, service))
+	assert.False(t, isAuditorForProject(ctx, auditor, "a092M00001IV4RGQA1", "project company signatures", repo, nil))
+	assert.Len(t, service.accesses, 1)
+}
+
+// TestCompanySignatureHandlersAuditorAccess walks the access checks of the company scoped signature handlers - the
+// auditors have no project or organization scope and are let in by the auditor fallback only
+func TestCompanySignatureHandlersAuditorAccess(t *testing.T) {
+	ctx := context.Background()
+	const claGroupID = "d5412c4f-3d8d-4c1b-8d6e-6d3c4f1a8a2b"
+	const foundationSFID = "a092M00001IV4Q9QAL"
+	const projectSFID = "a092M00001IV4RGQA1"
+	const companySFID = "0014100000Te0yqAAB"
+	repo := &fakeProjectClaGroupsRepo{mappings: []*projects_cla_groups.ProjectClaGroup{
+		{ProjectSFID: projectSFID, FoundationSFID: foundationSFID, ClaGroupID: claGroupID},
+	}}
+
+	handlers := []struct {
+		name     string
+		resource string
+		access   func(authUser *auth.User, service auditors.ServiceInterface) bool
+	}{
+		{
+			name:     "GetProjectCompanySignatures",
+			resource: "project company signatures",
+			access: func(authUser *auth.User, service auditors.ServiceInterface) bool {
+				return isUserHaveAccessToCLAProjectOrganization(ctx, authUser, projectSFID, companySFID, repo) ||
+					isAuditorForProject(ctx, authUser, projectSFID, "project company signatures", repo, service)
+			},
+		},
+		{
+			name:     "DownloadProjectSignatureEmployeeAsCSV",
+			resource: "ECLA signatures CSV",
+			access: func(authUser *auth.User, service auditors.ServiceInterface) bool {
+				return isUserHaveAccessToCLAProjectOrganization(ctx, authUser, foundationSFID, companySFID, repo) ||
+					isAuditorForCLAGroup(ctx, authUser, claGroupID, "ECLA signatures CSV", service)
+			},
+		},
+		{
+			name:     "ListClaGroupCorporateContributors",
+			resource: "corporate contributors",
+			access: func(authUser *auth.User, service auditors.ServiceInterface) bool {
+				return isUserHaveAccessToCLAProjectOrganization(ctx, authUser, foundationSFID, companySFID, repo) ||
+					isAuditorForCLAGroup(ctx, authUser, claGroupID, "corporate contributors", service)
+			},
+		},
+	}
+
+	for _, handler := range handlers {
+		t.Run(handler.name, func(t *testing.T) {
+			service := &fakeAuditorService{claGroups: map[string]string{claGroupID: "auditor"}}
+
+			assert.True(t, handler.access(&auth.User{UserName: "auditor"}, service))
+			assert.Equal(t, []string{"auditor cla_group " + claGroupID + " " + handler.resource}, service.accesses)
+
+			assert.False(t, handler.access(&auth.User{UserName: "jdoe"}, service))
+			assert.Len(t, service.accesses, 1)
+		})
+	}
+}
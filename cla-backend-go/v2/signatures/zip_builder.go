@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/communitybridge/easycla/cla-backend-go/telemetry"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
 	"github.com/juju/zip"
@@ -88,8 +90,12 @@ func (z *Zipper) BuildECLACSVZip(claGroupID string) error {
 	return z.buildCSVZip(utils.ClaTypeECLA, claGroupID)
 }
 
-func (z *Zipper) buildPDFZip(claType string, claGroupID string) error {
+func (z *Zipper) buildPDFZip(claType string, claGroupID string) (err error) {
 	f := logrus.Fields{"cla_group_id": claGroupID, "cla_type": claType}
+	start := time.Now()
+	defer func() {
+		telemetry.ObserveZipBuild(claType, utils.FileTypePDF, start, err)
+	}()
 	// get zip file from s3
 	buff, err := z.getZipFileFromS3(claType, claGroupID)
 	if err != nil {
@@ -165,8 +171,12 @@ func (z *Zipper) buildPDFZip(claType string, claGroupID string) error {
 	}
 	return nil
 }
-func (z *Zipper) buildCSVZip(claType string, claGroupID string) error {
+func (z *Zipper) buildCSVZip(claType string, claGroupID string) (err error) {
 	f := logrus.Fields{"cla_group_id": claGroupID, "cla_type": claType}
+	start := time.Now()
+	defer func() {
+		telemetry.ObserveZipBuild(claType, utils.FileTypeCSV, start, err)
+	}()
 	// TODO: DAD - requires query to the signatures table to get the list of signatures, then encode as CSV, then build a zip file, and upload to S3
 	log.WithFields(f).Infof("building %s csv zip for cla-group: %s is currently not supported", claType, claGroupID)
 	return nil